@@ -0,0 +1,8 @@
+package dto
+
+// CachePurgeResponse represents a response cache purge response.
+type CachePurgeResponse struct {
+	Domain string `json:"domain"`
+	Path   string `json:"path,omitempty"`
+	Purged int    `json:"purged"`
+}
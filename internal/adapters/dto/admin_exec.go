@@ -0,0 +1,27 @@
+package dto
+
+// ExecFrame is one JSON message of the websocket opened by
+// GET /admin/exec/<domain>. The client sends stdin, resize, signal and eof
+// frames; the server sends stdout and stderr frames and ends the session
+// with an exit or error frame.
+type ExecFrame struct {
+	Type     string `json:"type"`
+	Data     []byte `json:"data,omitempty"`
+	Rows     uint   `json:"rows,omitempty"`
+	Cols     uint   `json:"cols,omitempty"`
+	Signal   string `json:"signal,omitempty"` // e.g. "INT", "QUIT", "TSTP"
+	ExitCode *int   `json:"exit_code,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Exec frame types.
+const (
+	ExecFrameStdin  = "stdin"
+	ExecFrameResize = "resize"
+	ExecFrameSignal = "signal"
+	ExecFrameEOF    = "eof"
+	ExecFrameStdout = "stdout"
+	ExecFrameStderr = "stderr"
+	ExecFrameExit   = "exit"
+	ExecFrameError  = "error"
+)
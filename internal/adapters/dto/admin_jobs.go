@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// Job describes a configured scheduled job in admin API responses.
+type Job struct {
+	Route    string     `json:"route"`
+	Name     string     `json:"name"`
+	Schedule string     `json:"schedule"`
+	Timezone string     `json:"timezone"`
+	Command  []string   `json:"command"`
+	Timeout  string     `json:"timeout"`
+	Overlap  string     `json:"overlap"`
+	NextRun  *time.Time `json:"next_run,omitempty"`
+	Active   int        `json:"active"`
+	LastRun  *JobRun    `json:"last_run,omitempty"`
+}
+
+// JobRun is one run of a scheduled job.
+type JobRun struct {
+	ID         string     `json:"id"`
+	Route      string     `json:"route"`
+	Job        string     `json:"job"`
+	Trigger    string     `json:"trigger"`
+	Status     string     `json:"status"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ExitCode   int        `json:"exit_code"`
+	Output     string     `json:"output,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// Finished reports whether the run is over.
+func (r JobRun) Finished() bool {
+	return domain.JobRunStatus(r.Status).Finished()
+}
+
+// JobsResponse is returned by GET /admin/jobs.
+type JobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+// JobRunsResponse is returned by GET /admin/jobs/<domain>/<name>/runs.
+type JobRunsResponse struct {
+	Runs []JobRun `json:"runs"`
+}
+
+// JobRunResponse is returned by POST /admin/jobs/<domain>/<name>/run and
+// GET /admin/jobs/<domain>/<name>/runs/<id>.
+type JobRunResponse struct {
+	Run JobRun `json:"run"`
+}
+
+// JobFromDomain converts a job status to its transport DTO.
+func JobFromDomain(s domain.JobStatus) Job {
+	job := Job{
+		Route:    s.Job.Route,
+		Name:     s.Job.Name,
+		Schedule: s.Job.Schedule.Expression,
+		Timezone: "UTC",
+		Command:  s.Job.Command,
+		Timeout:  s.Job.EffectiveTimeout().String(),
+		Overlap:  string(s.Job.EffectiveOverlap()),
+		Active:   s.Active,
+	}
+	if s.Job.Schedule.Location != nil {
+		job.Timezone = s.Job.Schedule.Location.String()
+	}
+	if !s.NextRun.IsZero() {
+		next := s.NextRun
+		job.NextRun = &next
+	}
+	if s.LastRun != nil {
+		last := JobRunFromDomain(*s.LastRun)
+		job.LastRun = &last
+	}
+	return job
+}
+
+// JobRunFromDomain converts a job run to its transport DTO.
+func JobRunFromDomain(r domain.JobRun) JobRun {
+	run := JobRun{
+		ID:       r.ID,
+		Route:    r.Route,
+		Job:      r.Job,
+		Trigger:  string(r.Trigger),
+		Status:   string(r.Status),
+		ExitCode: r.ExitCode,
+		Output:   r.Output,
+		Error:    r.Error,
+	}
+	if !r.StartedAt.IsZero() {
+		t := r.StartedAt
+		run.StartedAt = &t
+	}
+	if !r.FinishedAt.IsZero() {
+		t := r.FinishedAt
+		run.FinishedAt = &t
+	}
+	return run
+}
+
+// JobRunsFromDomain converts job runs to their transport DTOs.
+func JobRunsFromDomain(runs []domain.JobRun) []JobRun {
+	out := make([]JobRun, 0, len(runs))
+	for _, r := range runs {
+		out = append(out, JobRunFromDomain(r))
+	}
+	return out
+}
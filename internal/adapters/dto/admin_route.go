@@ -3,9 +3,10 @@ package dto
 
 // Route represents route configuration in API responses.
 type Route struct {
-	Domain string `json:"domain"`
-	Image  string `json:"image"`
-	HTTPS  bool   `json:"https"`
+	Domain    string              `json:"domain"`
+	Image     string              `json:"image"`
+	HTTPS     bool                `json:"https"`
+	Processes map[string][]string `json:"processes,omitempty"`
 }
 
 // RouteInfo represents route details in API responses.
@@ -27,6 +28,14 @@ type Attachment struct {
 	Network     string `json:"network"`
 }
 
+// Process represents a route process in API responses.
+type Process struct {
+	Name        string   `json:"name"`
+	Command     []string `json:"command,omitempty"`
+	ContainerID string   `json:"container_id,omitempty"`
+	Status      string   `json:"status,omitempty"`
+}
+
 // RoutesResponse represents a list of routes.
 type RoutesResponse struct {
 	Routes []Route `json:"routes"`
@@ -42,6 +51,11 @@ type AttachmentsResponse struct {
 	Attachments []Attachment `json:"attachments"`
 }
 
+// ProcessesResponse represents the processes of a route.
+type ProcessesResponse struct {
+	Processes []Process `json:"processes"`
+}
+
 // RoutesByImageResponse represents routes found for a given image.
 type RoutesByImageResponse struct {
 	Image  string  `json:"image"`
@@ -0,0 +1,80 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// ResourceSample is one resource usage sample of a container. Rates are in
+// bytes per second over the interval since the previous sample; totals are
+// cumulative since the container started.
+type ResourceSample struct {
+	Time           time.Time `json:"time"`
+	CPUPercent     float64   `json:"cpu_percent"`
+	MemoryUsage    uint64    `json:"memory_usage"`
+	MemoryLimit    uint64    `json:"memory_limit"`
+	NetworkRxRate  float64   `json:"network_rx_rate"`
+	NetworkTxRate  float64   `json:"network_tx_rate"`
+	BlockReadRate  float64   `json:"block_read_rate"`
+	BlockWriteRate float64   `json:"block_write_rate"`
+	NetworkRx      uint64    `json:"network_rx"`
+	NetworkTx      uint64    `json:"network_tx"`
+	BlockRead      uint64    `json:"block_read"`
+	BlockWrite     uint64    `json:"block_write"`
+	PIDs           uint64    `json:"pids"`
+}
+
+// ContainerStats is the recent resource usage of one container.
+type ContainerStats struct {
+	Kind          string           `json:"kind"`
+	Name          string           `json:"name"`
+	Owner         string           `json:"owner,omitempty"`
+	ContainerID   string           `json:"container_id"`
+	ContainerName string           `json:"container_name"`
+	Latest        ResourceSample   `json:"latest"`
+	History       []ResourceSample `json:"history,omitempty"`
+}
+
+// StatsResponse is returned by GET /admin/stats and GET /admin/stats/<name>.
+type StatsResponse struct {
+	Containers []ContainerStats `json:"containers"`
+}
+
+// ContainerStatsFromDomain converts sampled container stats to their
+// transport DTO, dropping the history unless withHistory is set.
+func ContainerStatsFromDomain(st domain.ContainerStats, withHistory bool) ContainerStats {
+	stats := ContainerStats{
+		Kind:          string(st.Kind),
+		Name:          st.Name,
+		Owner:         st.Owner,
+		ContainerID:   st.ContainerID,
+		ContainerName: st.ContainerName,
+		Latest:        resourceSampleFromDomain(st.Latest),
+	}
+	if withHistory {
+		stats.History = make([]ResourceSample, 0, len(st.History))
+		for _, sample := range st.History {
+			stats.History = append(stats.History, resourceSampleFromDomain(sample))
+		}
+	}
+	return stats
+}
+
+func resourceSampleFromDomain(s domain.ResourceSample) ResourceSample {
+	return ResourceSample{
+		Time:           s.Time.UTC(),
+		CPUPercent:     s.CPUPercent,
+		MemoryUsage:    s.MemoryUsage,
+		MemoryLimit:    s.MemoryLimit,
+		NetworkRxRate:  s.NetworkRxRate,
+		NetworkTxRate:  s.NetworkTxRate,
+		BlockReadRate:  s.BlockReadRate,
+		BlockWriteRate: s.BlockWriteRate,
+		NetworkRx:      s.NetworkRx,
+		NetworkTx:      s.NetworkTx,
+		BlockRead:      s.BlockRead,
+		BlockWrite:     s.BlockWrite,
+		PIDs:           s.PIDs,
+	}
+}
@@ -0,0 +1,23 @@
+package dto
+
+// RunTaskRequest is the body of POST /admin/run/<domain>.
+type RunTaskRequest struct {
+	Command []string `json:"command"`
+	TTY     bool     `json:"tty,omitempty"`
+}
+
+// TaskOutputFrame is one line of the newline-delimited JSON stream returned
+// by POST /admin/run/<domain>. Output frames carry Stream and Data; the last
+// frame carries either ExitCode or Error.
+type TaskOutputFrame struct {
+	Stream   string `json:"stream,omitempty"` // "stdout" or "stderr"
+	Data     []byte `json:"data,omitempty"`
+	ExitCode *int   `json:"exit_code,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Task output stream names.
+const (
+	TaskStreamStdout = "stdout"
+	TaskStreamStderr = "stderr"
+)
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// UpstreamStatusResponse represents the circuit breaker state of a route's upstream.
+type UpstreamStatusResponse struct {
+	Domain              string     `json:"domain"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	Threshold           int        `json:"threshold"`
+	Cooldown            string     `json:"cooldown,omitempty"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	LastError           string     `json:"last_error,omitempty"`
+	LastErrorAt         *time.Time `json:"last_error_at,omitempty"`
+}
+
+// UpstreamStatusFromDomain converts a domain upstream status into its response form.
+func UpstreamStatusFromDomain(status domain.UpstreamStatus) UpstreamStatusResponse {
+	resp := UpstreamStatusResponse{
+		Domain:              status.Domain,
+		State:               status.State,
+		ConsecutiveFailures: status.ConsecutiveFailures,
+		Threshold:           status.Threshold,
+		LastError:           status.LastError,
+	}
+	if status.Threshold > 0 {
+		resp.Cooldown = status.Cooldown.String()
+	}
+	if !status.OpenedAt.IsZero() {
+		openedAt := status.OpenedAt.UTC()
+		resp.OpenedAt = &openedAt
+	}
+	if !status.LastErrorAt.IsZero() {
+		lastErrorAt := status.LastErrorAt.UTC()
+		resp.LastErrorAt = &lastErrorAt
+	}
+	return resp
+}
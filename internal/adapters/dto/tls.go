@@ -20,13 +20,17 @@ type TLSStatusResponse struct {
 
 // TLSCertificateEntry represents a managed certificate entry in the TLS status.
 type TLSCertificateEntry struct {
-	ID             string    `json:"id"`
-	Names          []string  `json:"names"`
-	Challenge      string    `json:"challenge"`
-	Status         string    `json:"status"`
-	NotAfter       time.Time `json:"not_after"`
-	LastError      string    `json:"last_error,omitempty"`
-	RenewalPending bool      `json:"renewal_pending"`
+	ID              string    `json:"id"`
+	Names           []string  `json:"names"`
+	Challenge       string    `json:"challenge"`
+	Status          string    `json:"status"`
+	NotAfter        time.Time `json:"not_after"`
+	LastError       string    `json:"last_error,omitempty"`
+	RenewalPending  bool      `json:"renewal_pending"`
+	RenewalFailures int       `json:"renewal_failures,omitempty"`
+	Alert           string    `json:"alert,omitempty"`
+	OCSPStatus      string    `json:"ocsp_status,omitempty"`
+	OCSPNextUpdate  time.Time `json:"ocsp_next_update,omitzero"`
 }
 
 // TLSRouteCoverage represents a route's TLS coverage status.
@@ -43,13 +47,17 @@ func TLSStatusFromDomain(s domain.PublicTLSStatus) TLSStatusResponse {
 	certs := make([]TLSCertificateEntry, 0, len(s.Certificates))
 	for _, c := range s.Certificates {
 		certs = append(certs, TLSCertificateEntry{
-			ID:             c.ID,
-			Names:          c.Names,
-			Challenge:      string(c.Challenge),
-			Status:         string(c.Status),
-			NotAfter:       c.NotAfter,
-			LastError:      c.LastError,
-			RenewalPending: c.RenewalPending,
+			ID:              c.ID,
+			Names:           c.Names,
+			Challenge:       string(c.Challenge),
+			Status:          string(c.Status),
+			NotAfter:        c.NotAfter,
+			LastError:       c.LastError,
+			RenewalPending:  c.RenewalPending,
+			RenewalFailures: c.RenewalFailures,
+			Alert:           string(c.Alert),
+			OCSPStatus:      string(c.OCSPStatus),
+			OCSPNextUpdate:  c.OCSPNextUpdate,
 		})
 	}
 
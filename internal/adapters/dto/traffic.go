@@ -24,45 +24,78 @@ type TrafficEntryPointStatus struct {
 	BytesIn              int64                     `json:"bytes_in"`
 	BytesOut             int64                     `json:"bytes_out"`
 	SmartTCP             SmartTCPCounters          `json:"smart_tcp"`
+	ProxyProtocol        ProxyProtocolCounters     `json:"proxy_protocol"`
+	QUIC                 QUICCounters              `json:"quic"`
+	Limits               LimitCounters             `json:"limits"`
 }
 
 type TrafficRouterStatus struct {
-	Name       string                `json:"name"`
-	EntryPoint string                `json:"entrypoint"`
-	Protocol   domain.RouterProtocol `json:"protocol"`
-	Rule       TrafficRule           `json:"rule"`
-	Service    string                `json:"service"`
-	Active     bool                  `json:"active"`
+	Name          string                `json:"name"`
+	EntryPoint    string                `json:"entrypoint"`
+	Protocol      domain.RouterProtocol `json:"protocol"`
+	Rule          TrafficRule           `json:"rule"`
+	Service       string                `json:"service"`
+	ProxyProtocol int                   `json:"proxy_protocol,omitempty"`
+	Active        bool                  `json:"active"`
+	Matches       int64                 `json:"matches"`
 }
 
 type TrafficServiceStatus struct {
-	Name     string                 `json:"name"`
-	Active   bool                   `json:"active"`
-	Backends []TrafficBackendStatus `json:"backends"`
+	Name     string                      `json:"name"`
+	Active   bool                        `json:"active"`
+	Strategy domain.LoadBalancerStrategy `json:"strategy,omitempty"`
+	Backends []TrafficBackendStatus      `json:"backends"`
 }
 
 type TrafficRule struct {
-	Host string `json:"host,omitempty"`
-	SNI  string `json:"sni,omitempty"`
+	Host     string `json:"host,omitempty"`
+	SNI      string `json:"sni,omitempty"`
+	SNIRegex string `json:"sni_regex,omitempty"`
 }
 
 type TrafficBackendStatus struct {
-	Name     string                 `json:"name"`
-	Host     string                 `json:"host"`
-	Port     int                    `json:"port"`
-	Protocol domain.NetworkProtocol `json:"protocol"`
-	Active   bool                   `json:"active"`
+	Name              string                 `json:"name"`
+	Host              string                 `json:"host"`
+	Port              int                    `json:"port"`
+	Protocol          domain.NetworkProtocol `json:"protocol"`
+	Active            bool                   `json:"active"`
+	Health            domain.BackendHealth   `json:"health,omitempty"`
+	ActiveConnections int64                  `json:"active_connections"`
+	TotalFailures     int64                  `json:"total_failures"`
+	LastError         string                 `json:"last_error,omitempty"`
 }
 
 type TrafficCounters struct {
-	ActiveTCPConnections int64            `json:"active_tcp_connections"`
-	ActiveUDPSessions    int64            `json:"active_udp_sessions"`
-	TotalAccepted        int64            `json:"total_accepted"`
-	TotalRefused         int64            `json:"total_refused"`
-	TotalErrors          int64            `json:"total_errors"`
-	BytesIn              int64            `json:"bytes_in"`
-	BytesOut             int64            `json:"bytes_out"`
-	SmartTCP             SmartTCPCounters `json:"smart_tcp"`
+	ActiveTCPConnections int64                 `json:"active_tcp_connections"`
+	ActiveUDPSessions    int64                 `json:"active_udp_sessions"`
+	TotalAccepted        int64                 `json:"total_accepted"`
+	TotalRefused         int64                 `json:"total_refused"`
+	TotalErrors          int64                 `json:"total_errors"`
+	BytesIn              int64                 `json:"bytes_in"`
+	BytesOut             int64                 `json:"bytes_out"`
+	SmartTCP             SmartTCPCounters      `json:"smart_tcp"`
+	ProxyProtocol        ProxyProtocolCounters `json:"proxy_protocol"`
+	QUIC                 QUICCounters          `json:"quic"`
+	Limits               LimitCounters         `json:"limits"`
+}
+
+type ProxyProtocolCounters struct {
+	Accepted int64 `json:"accepted"`
+	Rejected int64 `json:"rejected"`
+}
+
+type LimitCounters struct {
+	RouterRefused    int64 `json:"router_refused"`
+	SourceRefused    int64 `json:"source_refused"`
+	RateRefused      int64 `json:"rate_refused"`
+	BandwidthDropped int64 `json:"bandwidth_dropped"`
+}
+
+type QUICCounters struct {
+	SessionsAccepted int64 `json:"sessions_accepted"`
+	SessionsRefused  int64 `json:"sessions_refused"`
+	ActiveSessions   int64 `json:"active_sessions"`
+	Requests         int64 `json:"requests"`
 }
 
 type SmartTCPCounters struct {
@@ -99,6 +132,8 @@ func trafficEntryPointsFromDomain(values []domain.EntryPointStatus) []TrafficEnt
 			ActiveTCPConnections: value.ActiveTCPConnections, ActiveUDPSessions: value.ActiveUDPSessions,
 			TotalAccepted: value.TotalAccepted, TotalRefused: value.TotalRefused, TotalErrors: value.TotalErrors,
 			BytesIn: value.BytesIn, BytesOut: value.BytesOut, SmartTCP: smartTCPCountersFromDomain(value.SmartTCP),
+			ProxyProtocol: proxyProtocolCountersFromDomain(value.ProxyProtocol), QUIC: quicCountersFromDomain(value.QUIC),
+			Limits: limitCountersFromDomain(value.Limits),
 		})
 	}
 	return out
@@ -109,7 +144,8 @@ func trafficRoutersFromDomain(values []domain.TrafficRouterStatus) []TrafficRout
 	for _, value := range values {
 		out = append(out, TrafficRouterStatus{
 			Name: value.Name, EntryPoint: value.EntryPoint, Protocol: value.Protocol,
-			Rule: TrafficRule{Host: value.Rule.Host, SNI: value.Rule.SNI}, Service: value.Service, Active: value.Active,
+			Rule: TrafficRule{Host: value.Rule.Host, SNI: value.Rule.SNI, SNIRegex: value.Rule.SNIRegex}, Service: value.Service,
+			ProxyProtocol: value.ProxyProtocol, Active: value.Active, Matches: value.Matches,
 		})
 	}
 	return out
@@ -118,7 +154,7 @@ func trafficRoutersFromDomain(values []domain.TrafficRouterStatus) []TrafficRout
 func trafficServicesFromDomain(values []domain.TrafficServiceStatus) []TrafficServiceStatus {
 	out := make([]TrafficServiceStatus, 0, len(values))
 	for _, value := range values {
-		out = append(out, TrafficServiceStatus{Name: value.Name, Active: value.Active, Backends: trafficBackendsFromDomain(value.Backends)})
+		out = append(out, TrafficServiceStatus{Name: value.Name, Active: value.Active, Strategy: value.Strategy, Backends: trafficBackendsFromDomain(value.Backends)})
 	}
 	return out
 }
@@ -128,6 +164,7 @@ func trafficBackendsFromDomain(values []domain.TrafficBackendStatus) []TrafficBa
 	for _, value := range values {
 		out = append(out, TrafficBackendStatus{
 			Name: value.Name, Host: value.Host, Port: value.Port, Protocol: value.Protocol, Active: value.Active,
+			Health: value.Health, ActiveConnections: value.ActiveConnections, TotalFailures: value.TotalFailures, LastError: value.LastError,
 		})
 	}
 	return out
@@ -143,6 +180,31 @@ func trafficCountersFromDomain(value domain.TrafficCounters) TrafficCounters {
 		BytesIn:              value.BytesIn,
 		BytesOut:             value.BytesOut,
 		SmartTCP:             smartTCPCountersFromDomain(value.SmartTCP),
+		ProxyProtocol:        proxyProtocolCountersFromDomain(value.ProxyProtocol),
+		QUIC:                 quicCountersFromDomain(value.QUIC),
+		Limits:               limitCountersFromDomain(value.Limits),
+	}
+}
+
+func limitCountersFromDomain(value domain.LimitCounters) LimitCounters {
+	return LimitCounters{
+		RouterRefused:    value.RouterRefused,
+		SourceRefused:    value.SourceRefused,
+		RateRefused:      value.RateRefused,
+		BandwidthDropped: value.BandwidthDropped,
+	}
+}
+
+func proxyProtocolCountersFromDomain(value domain.ProxyProtocolCounters) ProxyProtocolCounters {
+	return ProxyProtocolCounters{Accepted: value.Accepted, Rejected: value.Rejected}
+}
+
+func quicCountersFromDomain(value domain.QUICCounters) QUICCounters {
+	return QUICCounters{
+		SessionsAccepted: value.SessionsAccepted,
+		SessionsRefused:  value.SessionsRefused,
+		ActiveSessions:   value.ActiveSessions,
+		Requests:         value.Requests,
 	}
 }
 
@@ -84,7 +84,7 @@ Registry scopes (for Docker push/pull):
 Admin scopes (for remote CLI access):
   Format: admin:<resource>:<actions>
 
-  Resources: routes, secrets, config, status, logs, volumes, * (all)
+  Resources: routes, secrets, config, status, logs, volumes, exec, * (all)
   Actions:   read, write, * (all)
 
   Examples:
@@ -94,6 +94,7 @@ Admin scopes (for remote CLI access):
     admin:logs:read        Read-only log access
     admin:volumes:read     Read-only volume access
     admin:volumes:write    Volume management access
+    admin:exec:write       Interactive exec into containers
 
 Repository scoping:
   --repo myapp           Scope to specific repository
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -25,6 +26,10 @@ func newCACmd() *cobra.Command {
 	cmd.AddCommand(newCAExportCmd())
 	cmd.AddCommand(newCAInstallCmd())
 	cmd.AddCommand(newCAInfoCmd())
+	cmd.AddCommand(newCAIssueCmd())
+	cmd.AddCommand(newCAIssueClientCmd())
+	cmd.AddCommand(newCARevokeClientCmd())
+	cmd.AddCommand(newCACRLCmd())
 
 	return cmd
 }
@@ -95,6 +100,107 @@ func newCAInfoCmd() *cobra.Command {
 	return cmd
 }
 
+func newCAIssueCmd() *cobra.Command {
+	var (
+		sans    []string
+		ttl     time.Duration
+		outDir  string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "issue <name>",
+		Short: "Issue a server certificate for a service",
+		Long:  "Issue a server certificate signed by Gordon's root CA for a service that terminates TLS itself, such as a database. Writes <name>.crt, <name>.key and ca.crt to the output directory. Without --san, the name is the only SAN.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir, err := resolveCADataDir()
+			if err != nil {
+				return err
+			}
+			return runCAIssue(cmd.Context(), cmd.OutOrStdout(), dataDir, args[0], sans, ttl, outDir, jsonOut)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&sans, "san", nil, "DNS name or IP address to include (repeatable)")
+	cmd.Flags().DurationVar(&ttl, "ttl", 30*24*time.Hour, "Certificate lifetime")
+	cmd.Flags().StringVar(&outDir, "out-dir", ".", "Directory to write the certificate, key and CA certificate to")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newCAIssueClientCmd() *cobra.Command {
+	var (
+		ttl     time.Duration
+		outDir  string
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "issue-client <name>",
+		Short: "Issue a client certificate for routes with client_auth",
+		Long:  "Issue a client certificate signed by Gordon's root CA. Writes <name>.crt and <name>.key to the output directory.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir, err := resolveCADataDir()
+			if err != nil {
+				return err
+			}
+			return runCAIssueClient(cmd.Context(), cmd.OutOrStdout(), dataDir, args[0], ttl, outDir, jsonOut)
+		},
+	}
+
+	cmd.Flags().DurationVar(&ttl, "ttl", 30*24*time.Hour, "Certificate lifetime")
+	cmd.Flags().StringVar(&outDir, "out-dir", ".", "Directory to write the certificate and key to")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newCARevokeClientCmd() *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "revoke-client <serial>",
+		Short: "Revoke a client certificate",
+		Long:  "Add a client certificate serial (hex, as printed by issue-client) to the revocation list. A running server picks up the change on the next handshake.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dataDir, err := resolveCADataDir()
+			if err != nil {
+				return err
+			}
+			return runCARevokeClient(cmd.Context(), cmd.OutOrStdout(), dataDir, args[0], jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func newCACRLCmd() *cobra.Command {
+	var outPath string
+
+	cmd := &cobra.Command{
+		Use:   "crl",
+		Short: "Export the client certificate revocation list",
+		Long:  "Export the client certificate revocation list in PEM format, signed by Gordon's root CA.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dataDir, err := resolveCADataDir()
+			if err != nil {
+				return err
+			}
+			return runCACRL(cmd.Context(), cmd.OutOrStdout(), dataDir, outPath)
+		},
+	}
+
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the revocation list to file instead of stdout")
+
+	return cmd
+}
+
 func resolveCADataDir() (string, error) {
 	local, err := GetLocalServices(configPath)
 	if err != nil {
@@ -200,3 +306,140 @@ func runCAInfo(_ context.Context, out io.Writer, dataDir string, jsonOut bool) e
 	}
 	return cliWriteLine(out, cliRenderMeta("Intermediate:", fmt.Sprintf("expires in %s (auto-renews)", remaining)))
 }
+
+func runCAIssue(_ context.Context, out io.Writer, dataDir, name string, sans []string, ttl time.Duration, outDir string, jsonOut bool) error {
+	ca, err := loadCAFromDataDir(dataDir)
+	if err != nil {
+		return err
+	}
+
+	issued, err := ca.IssueServiceCertificate(name, sans, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to issue service certificate: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0750); err != nil {
+		return fmt.Errorf("create directory %s: %w", outDir, err)
+	}
+	certPath := filepath.Join(outDir, name+".crt")
+	keyPath := filepath.Join(outDir, name+".key")
+	caPath := filepath.Join(outDir, "ca.crt")
+	if err := os.WriteFile(keyPath, issued.KeyPEM, 0600); err != nil {
+		return fmt.Errorf("write key to %s: %w", keyPath, err)
+	}
+	if err := os.WriteFile(certPath, issued.CertPEM, 0644); err != nil {
+		return fmt.Errorf("write certificate to %s: %w", certPath, err)
+	}
+	if err := os.WriteFile(caPath, issued.CAPEM, 0644); err != nil {
+		return fmt.Errorf("write CA certificate to %s: %w", caPath, err)
+	}
+
+	if jsonOut {
+		return writeJSON(out, map[string]any{
+			"name":        issued.Name,
+			"sans":        issued.SANs,
+			"serial":      issued.Serial,
+			"expires":     issued.NotAfter.Format(time.RFC3339),
+			"certificate": certPath,
+			"key":         keyPath,
+			"ca":          caPath,
+		})
+	}
+
+	if err := cliWriteLine(out, cliRenderSuccess(fmt.Sprintf("Service certificate written to %s and %s", certPath, keyPath))); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, cliRenderMeta("SANs:", strings.Join(issued.SANs, ", "))); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, cliRenderMeta("CA:", caPath)); err != nil {
+		return err
+	}
+	return cliWriteLine(out, cliRenderMeta("Expires:", issued.NotAfter.Format(time.RFC3339)))
+}
+
+func runCAIssueClient(_ context.Context, out io.Writer, dataDir, name string, ttl time.Duration, outDir string, jsonOut bool) error {
+	ca, err := loadCAFromDataDir(dataDir)
+	if err != nil {
+		return err
+	}
+
+	issued, err := ca.IssueClientCertificate(name, ttl)
+	if err != nil {
+		return fmt.Errorf("failed to issue client certificate: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0750); err != nil {
+		return fmt.Errorf("create directory %s: %w", outDir, err)
+	}
+	certPath := filepath.Join(outDir, name+".crt")
+	keyPath := filepath.Join(outDir, name+".key")
+	if err := os.WriteFile(keyPath, issued.KeyPEM, 0600); err != nil {
+		return fmt.Errorf("write key to %s: %w", keyPath, err)
+	}
+	if err := os.WriteFile(certPath, issued.CertPEM, 0644); err != nil {
+		return fmt.Errorf("write certificate to %s: %w", certPath, err)
+	}
+
+	if jsonOut {
+		return writeJSON(out, map[string]string{
+			"name":        issued.Name,
+			"serial":      issued.Serial,
+			"fingerprint": issued.Fingerprint,
+			"expires":     issued.NotAfter.Format(time.RFC3339),
+			"certificate": certPath,
+			"key":         keyPath,
+		})
+	}
+
+	if err := cliWriteLine(out, cliRenderSuccess(fmt.Sprintf("Client certificate written to %s and %s", certPath, keyPath))); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, cliRenderMeta("Serial:", issued.Serial)); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, cliRenderMeta("Fingerprint:", "SHA256:"+issued.Fingerprint)); err != nil {
+		return err
+	}
+	return cliWriteLine(out, cliRenderMeta("Expires:", issued.NotAfter.Format(time.RFC3339)))
+}
+
+func runCARevokeClient(_ context.Context, out io.Writer, dataDir, serial string, jsonOut bool) error {
+	ca, err := loadCAFromDataDir(dataDir)
+	if err != nil {
+		return err
+	}
+
+	if err := ca.RevokeClientCertificate(serial); err != nil {
+		return fmt.Errorf("failed to revoke client certificate: %w", err)
+	}
+	if jsonOut {
+		return writeJSON(out, map[string]string{"status": "revoked", "serial": serial})
+	}
+	return cliWriteLine(out, cliRenderSuccess(fmt.Sprintf("Client certificate %s revoked", serial)))
+}
+
+func runCACRL(_ context.Context, out io.Writer, dataDir, outPath string) error {
+	ca, err := loadCAFromDataDir(dataDir)
+	if err != nil {
+		return err
+	}
+
+	crlPEM, err := ca.ClientRevocationList()
+	if err != nil {
+		return fmt.Errorf("failed to load revocation list: %w", err)
+	}
+
+	if outPath != "" {
+		if err := os.MkdirAll(filepath.Dir(outPath), 0750); err != nil {
+			return fmt.Errorf("create directory for %s: %w", outPath, err)
+		}
+		if err := os.WriteFile(outPath, crlPEM, 0644); err != nil {
+			return fmt.Errorf("write revocation list to %s: %w", outPath, err)
+		}
+		return cliWriteLine(out, cliRenderSuccess(fmt.Sprintf("Revocation list written to %s", outPath)))
+	}
+
+	_, err = out.Write(crlPEM)
+	return err
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCAIssueAndRevokeClient(t *testing.T) {
+	dataDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "certs")
+
+	var out bytes.Buffer
+	require.NoError(t, runCAIssueClient(context.Background(), &out, dataDir, "laptop", 48*time.Hour, outDir, true))
+
+	var issued map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &issued))
+	assert.Equal(t, "laptop", issued["name"])
+	assert.NotEmpty(t, issued["serial"])
+	assert.NotEmpty(t, issued["fingerprint"])
+
+	keyInfo, err := os.Stat(filepath.Join(outDir, "laptop.key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+	_, err = tls.LoadX509KeyPair(filepath.Join(outDir, "laptop.crt"), filepath.Join(outDir, "laptop.key"))
+	require.NoError(t, err)
+
+	out.Reset()
+	require.NoError(t, runCARevokeClient(context.Background(), &out, dataDir, issued["serial"], false))
+	assert.Contains(t, out.String(), issued["serial"])
+
+	out.Reset()
+	require.NoError(t, runCACRL(context.Background(), &out, dataDir, ""))
+	block, _ := pem.Decode(out.Bytes())
+	require.NotNil(t, block)
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	require.NoError(t, err)
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, issued["serial"], crl.RevokedCertificateEntries[0].SerialNumber.Text(16))
+}
+
+func TestCAIssueClientRejectsPathNames(t *testing.T) {
+	err := runCAIssueClient(context.Background(), &bytes.Buffer{}, t.TempDir(), "../laptop", time.Hour, t.TempDir(), false)
+	assert.Error(t, err)
+}
+
+func TestCAIssueServiceCertificate(t *testing.T) {
+	dataDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "certs")
+
+	var out bytes.Buffer
+	require.NoError(t, runCAIssue(context.Background(), &out, dataDir, "postgres", []string{"db.internal", "10.0.0.5"}, 72*time.Hour, outDir, true))
+
+	var issued struct {
+		Name string   `json:"name"`
+		SANs []string `json:"sans"`
+		CA   string   `json:"ca"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &issued))
+	assert.Equal(t, "postgres", issued.Name)
+	assert.Equal(t, []string{"db.internal", "10.0.0.5"}, issued.SANs)
+	assert.Equal(t, filepath.Join(outDir, "ca.crt"), issued.CA)
+
+	keyInfo, err := os.Stat(filepath.Join(outDir, "postgres.key"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), keyInfo.Mode().Perm())
+	pair, err := tls.LoadX509KeyPair(filepath.Join(outDir, "postgres.crt"), filepath.Join(outDir, "postgres.key"))
+	require.NoError(t, err)
+
+	caPEM, err := os.ReadFile(issued.CA)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(caPEM))
+	leaf, err := x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+	_, err = leaf.Verify(x509.VerifyOptions{Roots: roots, DNSName: "db.internal"})
+	require.NoError(t, err)
+}
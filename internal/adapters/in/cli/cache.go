@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/adapters/in/cli/ui/styles"
+)
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the proxy response cache",
+	}
+	cmd.AddCommand(newCachePurgeCmd())
+	return cmd
+}
+
+func newCachePurgeCmd() *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "purge <domain> [path]",
+		Short: "Purge cached responses for a route",
+		Long: `Removes cached proxy responses for a route domain. Without a path, every
+cached response of the domain is purged; with a path, only the responses
+for that path are purged, whatever their query string.
+
+Deploys purge the route's cache automatically.
+
+Examples:
+  gordon cache purge app.example.com --remote https://gordon.mydomain.com --token $TOKEN
+  gordon cache purge app.example.com /assets/app.js --remote ...`,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+			cacheDomain := args[0]
+			var path string
+			if len(args) == 2 {
+				path = args[1]
+			}
+
+			handle, err := resolveControlPlaneForRouteDomain(ctx, cacheDomain)
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			return runCachePurge(ctx, handle.plane, cmd.OutOrStdout(), cacheDomain, path, jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output JSON")
+
+	return cmd
+}
+
+func runCachePurge(ctx context.Context, cp ControlPlane, out io.Writer, cacheDomain, path string, jsonOut bool) error {
+	if path != "" && !strings.HasPrefix(path, "/") {
+		return fmt.Errorf("path must start with /: %q", path)
+	}
+
+	result, err := cp.PurgeCache(ctx, cacheDomain, path)
+	if err != nil {
+		return fmt.Errorf("failed to purge cache: %w", err)
+	}
+	if jsonOut {
+		return writeJSON(out, result)
+	}
+
+	target := result.Domain
+	if result.Path != "" {
+		target += result.Path
+	}
+	_, err = fmt.Fprintln(out, styles.RenderSuccess(fmt.Sprintf("Purged %d cached responses for %s", result.Purged, target)))
+	return err
+}
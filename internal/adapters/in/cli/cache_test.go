@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestCachePurgeCommandExists(t *testing.T) {
+	cmd := NewRootCmd()
+	purge, _, err := cmd.Find([]string{"cache", "purge"})
+	require.NoError(t, err)
+	require.NotNil(t, purge)
+	assert.Equal(t, "purge", purge.Name())
+	require.Error(t, purge.Args(purge, nil))
+	require.NoError(t, purge.Args(purge, []string{"app.example.com", "/a"}))
+}
+
+func TestRunCachePurge(t *testing.T) {
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().PurgeCache(mock.Anything, "app.example.com", "/assets/app.js").
+		Return(&dto.CachePurgeResponse{Domain: "app.example.com", Path: "/assets/app.js", Purged: 2}, nil)
+
+	var buf bytes.Buffer
+	require.NoError(t, runCachePurge(context.Background(), cp, &buf, "app.example.com", "/assets/app.js", false))
+	assert.Contains(t, buf.String(), "Purged 2 cached responses for app.example.com/assets/app.js")
+}
+
+func TestRunCachePurge_RejectsRelativePath(t *testing.T) {
+	cp := climocks.NewMockControlPlane(t)
+	err := runCachePurge(context.Background(), cp, &bytes.Buffer{}, "app.example.com", "assets/app.js", false)
+	require.Error(t, err)
+}
+
+func TestLocalControlPlanePurgeCacheUnavailable(t *testing.T) {
+	cp := &localControlPlane{}
+	_, err := cp.PurgeCache(context.Background(), "app.example.com", "")
+	require.ErrorIs(t, err, domain.ErrResponseCacheUnavailable)
+}
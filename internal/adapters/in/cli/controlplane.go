@@ -2,9 +2,11 @@ package cli
 
 import (
 	"context"
+	"io"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
 	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
+	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
 )
 
@@ -42,13 +44,18 @@ type ControlPlane interface {
 	GetStatus(ctx context.Context) (*remote.Status, error)
 	GetTLSStatus(ctx context.Context) (*dto.TLSStatusResponse, error)
 	GetTrafficStatus(ctx context.Context) (*dto.TrafficStatusResponse, error)
+	GetUpstreamStatus(ctx context.Context, routeDomain string) (*dto.UpstreamStatusResponse, error)
+	ListProcesses(ctx context.Context, routeDomain string) ([]dto.Process, error)
 	Reload(ctx context.Context) error
 	ListNetworks(ctx context.Context) ([]*domain.NetworkInfo, error)
 	GetConfig(ctx context.Context) (*remote.Config, error)
 	DeployIntent(ctx context.Context, imageName string) error
 	Deploy(ctx context.Context, deployDomain string) (*remote.DeployResult, error)
 	Restart(ctx context.Context, restartDomain string, withAttachments bool) (*remote.RestartResult, error)
+	RunTask(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout, stderr io.Writer) (int, error)
+	Exec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error)
 	ListTags(ctx context.Context, repository string) ([]string, error)
+	PurgeCache(ctx context.Context, cacheDomain, path string) (*dto.CachePurgeResponse, error)
 
 	ListBackups(ctx context.Context, backupDomain string) ([]dto.BackupJob, error)
 	BackupStatus(ctx context.Context) ([]dto.BackupJob, error)
@@ -58,6 +65,13 @@ type ControlPlane interface {
 	VolumeBackupStatus(ctx context.Context) ([]dto.VolumeBackupJob, error)
 	RunVolumeBackups(ctx context.Context, backupDomain, volumeName string) (*dto.VolumeBackupRunResponse, error)
 
+	ListJobs(ctx context.Context, routeDomain string) ([]dto.Job, error)
+	RunJob(ctx context.Context, routeDomain, name string) (*dto.JobRun, error)
+	ListJobRuns(ctx context.Context, routeDomain, name string) ([]dto.JobRun, error)
+	GetJobRun(ctx context.Context, routeDomain, name, runID string) (*dto.JobRun, error)
+
+	ListStats(ctx context.Context, name string) ([]dto.ContainerStats, error)
+
 	GetProcessLogs(ctx context.Context, lines int) ([]string, error)
 	GetContainerLogs(ctx context.Context, logDomain string, lines int) ([]string, error)
 	StreamProcessLogs(ctx context.Context, lines int) (<-chan string, error)
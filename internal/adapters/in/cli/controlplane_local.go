@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sort"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
 	"github.com/bnema/gordon/internal/app"
 	"github.com/bnema/gordon/internal/boundaries/in"
+	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
 	"github.com/bnema/gordon/internal/usecase/config"
 )
@@ -27,6 +29,7 @@ type localControlPlane struct {
 	logSvc          in.LogService
 	volumeSvc       in.VolumeService
 	publicTLSSvc    in.PublicTLSService
+	jobSvc          in.JobService
 }
 
 func NewLocalControlPlane(kernel *app.Kernel) ControlPlane {
@@ -54,6 +57,7 @@ func NewLocalControlPlane(kernel *app.Kernel) ControlPlane {
 		logSvc:          kernel.Logs(),
 		volumeSvc:       kernel.Volumes(),
 		publicTLSSvc:    kernel.PublicTLS(),
+		jobSvc:          kernel.Jobs(),
 	}
 }
 
@@ -449,6 +453,30 @@ func (l *localControlPlane) GetTrafficStatus(_ context.Context) (*dto.TrafficSta
 	return nil, fmt.Errorf("local traffic status is unavailable from the in-process CLI control plane; query the running Gordon daemon with --remote or set GORDON_REMOTE to its admin URL: %w", domain.ErrTrafficStatusUnavailable)
 }
 
+func (l *localControlPlane) PurgeCache(_ context.Context, _, _ string) (*dto.CachePurgeResponse, error) {
+	return nil, fmt.Errorf("the response cache lives in the running Gordon daemon; purge it with --remote or set GORDON_REMOTE to its admin URL: %w", domain.ErrResponseCacheUnavailable)
+}
+
+func (l *localControlPlane) GetUpstreamStatus(_ context.Context, _ string) (*dto.UpstreamStatusResponse, error) {
+	return nil, fmt.Errorf("upstream circuit breakers live in the running Gordon daemon; inspect them with --remote or set GORDON_REMOTE to its admin URL: %w", domain.ErrUpstreamStatusUnavailable)
+}
+
+func (l *localControlPlane) ListProcesses(ctx context.Context, routeDomain string) ([]dto.Process, error) {
+	if l.configSvc == nil || l.containerSvc == nil {
+		return nil, fmt.Errorf("local services unavailable")
+	}
+	route, err := l.configSvc.GetRoute(ctx, routeDomain)
+	if err != nil {
+		return nil, err
+	}
+	processes := l.containerSvc.ListProcesses(ctx, *route)
+	result := make([]dto.Process, 0, len(processes))
+	for _, p := range processes {
+		result = append(result, dto.Process{Name: p.Name, Command: p.Command, ContainerID: p.ContainerID, Status: p.Status})
+	}
+	return result, nil
+}
+
 func (l *localControlPlane) GetStatus(ctx context.Context) (*remote.Status, error) {
 	if l.configSvc == nil {
 		return nil, fmt.Errorf("local config service unavailable")
@@ -546,6 +574,26 @@ func (l *localControlPlane) Deploy(ctx context.Context, deployDomain string) (*r
 	return &remote.DeployResult{Status: "queued", Domain: domainName}, nil
 }
 
+func (l *localControlPlane) RunTask(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+	taskSvc, ok := l.containerSvc.(in.TaskService)
+	if !ok || l.configSvc == nil {
+		return 0, fmt.Errorf("local task runner unavailable")
+	}
+	route, err := l.configSvc.GetRoute(ctx, routeDomain)
+	if err != nil {
+		return 0, err
+	}
+	return taskSvc.RunTask(ctx, *route, task, stdout, stderr)
+}
+
+func (l *localControlPlane) Exec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error) {
+	execSvc, ok := l.containerSvc.(in.ExecService)
+	if !ok {
+		return nil, fmt.Errorf("local exec unavailable")
+	}
+	return execSvc.StartExec(ctx, routeDomain, attachment, req)
+}
+
 func (l *localControlPlane) Restart(ctx context.Context, restartDomain string, withAttachments bool) (*remote.RestartResult, error) {
 	if l.containerSvc == nil {
 		if withAttachments {
@@ -676,6 +724,95 @@ func localVolumeBackupServiceUnavailable() error {
 	return fmt.Errorf("local volume backup service unavailable: %w", domain.ErrVolumeBackupUnavailable)
 }
 
+func (l *localControlPlane) ListJobs(ctx context.Context, routeDomain string) ([]dto.Job, error) {
+	if l.jobSvc == nil {
+		return nil, fmt.Errorf("local job service unavailable")
+	}
+	statuses, err := l.jobSvc.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]dto.Job, 0, len(statuses))
+	for _, status := range statuses {
+		if routeDomain != "" && status.Job.Route != routeDomain {
+			continue
+		}
+		jobs = append(jobs, dto.JobFromDomain(status))
+	}
+	return jobs, nil
+}
+
+func (l *localControlPlane) RunJob(ctx context.Context, routeDomain, name string) (*dto.JobRun, error) {
+	if l.jobSvc == nil {
+		return nil, fmt.Errorf("local job service unavailable")
+	}
+	run, err := l.jobSvc.RunJob(ctx, routeDomain, name)
+	if err != nil {
+		return nil, err
+	}
+	result := dto.JobRunFromDomain(run)
+	return &result, nil
+}
+
+func (l *localControlPlane) ListJobRuns(ctx context.Context, routeDomain, name string) ([]dto.JobRun, error) {
+	if l.jobSvc == nil {
+		return nil, fmt.Errorf("local job service unavailable")
+	}
+	runs, err := l.jobSvc.ListJobRuns(ctx, routeDomain, name)
+	if err != nil {
+		return nil, err
+	}
+	return dto.JobRunsFromDomain(runs), nil
+}
+
+func (l *localControlPlane) GetJobRun(ctx context.Context, routeDomain, name, runID string) (*dto.JobRun, error) {
+	runs, err := l.ListJobRuns(ctx, routeDomain, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if run.ID == runID {
+			return &run, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", domain.ErrJobRunNotFound, runID)
+}
+
+// localStatsSampler is the container service's resource sampler, which
+// the CLI process only starts once stats are asked for.
+type localStatsSampler interface {
+	in.StatsService
+	StartStatsSampler(ctx context.Context)
+}
+
+func (l *localControlPlane) ListStats(ctx context.Context, name string) ([]dto.ContainerStats, error) {
+	sampler, ok := l.containerSvc.(localStatsSampler)
+	if !ok {
+		return nil, fmt.Errorf("local stats unavailable")
+	}
+	// The sampler outlives this call and is stopped when the kernel closes;
+	// rows appear once it has taken two samples of a container.
+	sampler.StartStatsSampler(context.WithoutCancel(ctx))
+
+	var (
+		stats []domain.ContainerStats
+		err   error
+	)
+	if name == "" {
+		stats, err = sampler.ListStats(ctx)
+	} else {
+		stats, err = sampler.GetStats(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dto.ContainerStats, 0, len(stats))
+	for _, st := range stats {
+		result = append(result, dto.ContainerStatsFromDomain(st, name != ""))
+	}
+	return result, nil
+}
+
 func (l *localControlPlane) GetProcessLogs(ctx context.Context, lines int) ([]string, error) {
 	if l.logSvc == nil {
 		return nil, fmt.Errorf("local log service unavailable")
@@ -3,9 +3,11 @@ package cli
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
 	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
+	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
 )
 
@@ -154,6 +156,30 @@ func (r *remoteControlPlane) Restart(ctx context.Context, restartDomain string,
 	return r.client.Restart(ctx, restartDomain, withAttachments)
 }
 
+func (r *remoteControlPlane) PurgeCache(ctx context.Context, cacheDomain, path string) (*dto.CachePurgeResponse, error) {
+	return r.client.PurgeCache(ctx, cacheDomain, path)
+}
+
+func (r *remoteControlPlane) GetUpstreamStatus(ctx context.Context, routeDomain string) (*dto.UpstreamStatusResponse, error) {
+	return r.client.GetUpstreamStatus(ctx, routeDomain)
+}
+
+func (r *remoteControlPlane) ListProcesses(ctx context.Context, routeDomain string) ([]dto.Process, error) {
+	return r.client.ListProcesses(ctx, routeDomain)
+}
+
+func (r *remoteControlPlane) RunTask(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+	return r.client.RunTask(ctx, routeDomain, dto.RunTaskRequest{Command: task.Command, TTY: task.TTY}, stdout, stderr)
+}
+
+func (r *remoteControlPlane) Exec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error) {
+	session, err := r.client.Exec(ctx, routeDomain, attachment, req)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
 func (r *remoteControlPlane) ListTags(ctx context.Context, repository string) ([]string, error) {
 	return r.client.ListTags(ctx, repository)
 }
@@ -198,6 +224,26 @@ func (r *remoteControlPlane) RunVolumeBackups(ctx context.Context, backupDomain,
 	return result, nil
 }
 
+func (r *remoteControlPlane) ListJobs(ctx context.Context, routeDomain string) ([]dto.Job, error) {
+	return r.client.ListJobs(ctx, routeDomain)
+}
+
+func (r *remoteControlPlane) RunJob(ctx context.Context, routeDomain, name string) (*dto.JobRun, error) {
+	return r.client.RunJob(ctx, routeDomain, name)
+}
+
+func (r *remoteControlPlane) ListJobRuns(ctx context.Context, routeDomain, name string) ([]dto.JobRun, error) {
+	return r.client.ListJobRuns(ctx, routeDomain, name)
+}
+
+func (r *remoteControlPlane) GetJobRun(ctx context.Context, routeDomain, name, runID string) (*dto.JobRun, error) {
+	return r.client.GetJobRun(ctx, routeDomain, name, runID)
+}
+
+func (r *remoteControlPlane) ListStats(ctx context.Context, name string) ([]dto.ContainerStats, error) {
+	return r.client.ListStats(ctx, name)
+}
+
 func (r *remoteControlPlane) GetProcessLogs(ctx context.Context, lines int) ([]string, error) {
 	return r.client.GetProcessLogs(ctx, lines)
 }
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+type execStarter interface {
+	Exec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error)
+}
+
+// execSignaler is implemented by exec sessions that deliver signals
+// themselves, such as remote sessions.
+type execSignaler interface {
+	Signal(sig string) error
+}
+
+// newExecCmd creates the exec command.
+func newExecCmd() *cobra.Command {
+	var attachment string
+	var noTTY bool
+	cmd := &cobra.Command{
+		Use:   "exec <domain> [--attachment <name>] -- <command> [args...]",
+		Short: "Run a command interactively in a route's container",
+		Long: `Runs a command inside the route's running container, or inside one of its
+attachments with --attachment, and connects your terminal to it.
+
+When stdin is a terminal the command gets a pseudo-terminal, your terminal
+switches to raw mode, and window resizes follow along. Use --no-tty to pipe
+data in and out instead; stdout and stderr then stay separate.
+
+Remote sessions need a token with the admin:exec:write scope and are audit
+logged on the server. gordon exits with the command's exit code.
+
+Examples:
+  gordon exec myapp.example.com -- sh
+  gordon exec myapp.example.com --attachment postgres -- psql -U app
+  gordon exec --remote prod --no-tty myapp.example.com -- cat /app/config.json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MinimumNArgs(2)(cmd, args); err != nil {
+				return err
+			}
+			if dash := cmd.ArgsLenAtDash(); dash > 1 {
+				return fmt.Errorf("expected a single domain before --, got %d arguments", dash)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle, err := resolveControlPlaneForRouteDomain(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			stdin := cmd.InOrStdin()
+			req := domain.ExecRequest{Command: args[1:], TTY: !noTTY && isTerminalReader(stdin)}
+			err = runExec(cmd.Context(), handle.plane, args[0], attachment, req, stdin, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				// The command already reported its failure.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+	cmd.Flags().StringVarP(&attachment, "attachment", "a", "", "Run in the named attachment (e.g. postgres) instead of the route's container")
+	cmd.Flags().BoolVar(&noTTY, "no-tty", false, "Do not allocate a pseudo-terminal even when stdin is a terminal")
+	return cmd
+}
+
+func runExec(ctx context.Context, starter execStarter, routeDomain, attachment string, req domain.ExecRequest, stdin io.Reader, stdout, stderr io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var termFd uintptr
+	if req.TTY {
+		termFd = stdin.(*os.File).Fd()
+		if cols, rows, err := term.GetSize(termFd); err == nil {
+			req.Rows, req.Cols = uint(rows), uint(cols)
+		}
+	}
+
+	session, err := starter.Exec(ctx, routeDomain, attachment, req)
+	if err != nil {
+		return fmt.Errorf("failed to start exec session: %w", err)
+	}
+	defer session.Close()
+
+	if req.TTY {
+		state, err := term.MakeRaw(termFd)
+		if err != nil {
+			return fmt.Errorf("failed to put terminal in raw mode: %w", err)
+		}
+		defer func() { _ = term.Restore(termFd, state) }()
+	}
+
+	sigs := make(chan os.Signal, 4)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGWINCH)
+	defer signal.Stop(sigs)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-sigs:
+				handleExecSignal(ctx, cancel, session, req.TTY, termFd, sig)
+			}
+		}
+	}()
+
+	go func() {
+		if _, err := io.Copy(session, stdin); err == nil {
+			_ = session.CloseStdin()
+		}
+	}()
+
+	exitCode, err := session.Wait(ctx, stdout, stderr)
+	if err != nil {
+		return fmt.Errorf("exec session failed: %w", err)
+	}
+	if exitCode != 0 {
+		return &ExitError{Code: exitCode}
+	}
+	return nil
+}
+
+// handleExecSignal relays a signal received by gordon to the exec session.
+// Without a TTY there is nothing to deliver interrupts to, so they end the
+// session instead.
+func handleExecSignal(ctx context.Context, cancel context.CancelFunc, session out.ExecSession, tty bool, termFd uintptr, sig os.Signal) {
+	switch sig {
+	case syscall.SIGWINCH:
+		if !tty {
+			return
+		}
+		if cols, rows, err := term.GetSize(termFd); err == nil {
+			_ = session.Resize(ctx, uint(rows), uint(cols))
+		}
+	case syscall.SIGINT, syscall.SIGQUIT:
+		if !tty {
+			cancel()
+			return
+		}
+		name := "INT"
+		if sig == syscall.SIGQUIT {
+			name = "QUIT"
+		}
+		_ = signalExec(session, name)
+	default:
+		cancel()
+	}
+}
+
+// signalExec delivers sig to a TTY exec session, through the session itself
+// when it can, or as the terminal control character otherwise.
+func signalExec(session out.ExecSession, sig string) error {
+	if signaler, ok := session.(execSignaler); ok {
+		return signaler.Signal(sig)
+	}
+	input, ok := domain.TTYSignalInput(sig)
+	if !ok {
+		return domain.ErrExecSignalUnsupported
+	}
+	_, err := session.Write(input)
+	return err
+}
+
+func isTerminalReader(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && isatty.IsTerminal(f.Fd())
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestExecCommandParsesAttachmentAfterDomain(t *testing.T) {
+	cmd := NewRootCmd()
+	execCmd, args, err := cmd.Find([]string{"exec", "app.example.com", "--attachment", "postgres", "--", "psql", "-U", "app"})
+	require.NoError(t, err)
+	require.NoError(t, execCmd.ParseFlags(args))
+	assert.Equal(t, []string{"app.example.com", "psql", "-U", "app"}, execCmd.Flags().Args())
+	attachment, err := execCmd.Flags().GetString("attachment")
+	require.NoError(t, err)
+	assert.Equal(t, "postgres", attachment)
+	require.NoError(t, execCmd.Args(execCmd, execCmd.Flags().Args()))
+}
+
+func TestRunExecCopiesStdinAndPropagatesExitCode(t *testing.T) {
+	req := domain.ExecRequest{Command: []string{"sh"}}
+	stdin := make(chan string, 1)
+	session := outmocks.NewMockExecSession(t)
+	session.EXPECT().Write([]byte("exit 7\n")).RunAndReturn(func(p []byte) (int, error) {
+		stdin <- string(p)
+		return len(p), nil
+	})
+	session.EXPECT().CloseStdin().Return(nil).Maybe()
+	session.EXPECT().Wait(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, stdout, _ io.Writer) (int, error) {
+			_, _ = io.WriteString(stdout, <-stdin)
+			return 7, nil
+		})
+	session.EXPECT().Close().Return(nil)
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().Exec(mock.Anything, "app.example.com", "postgres", req).Return(session, nil)
+
+	var stdout bytes.Buffer
+	err := runExec(context.Background(), cp, "app.example.com", "postgres", req, strings.NewReader("exit 7\n"), &stdout, io.Discard)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 7, exitErr.Code)
+	assert.Equal(t, "exit 7\n", stdout.String())
+}
+
+func TestSignalExecFallsBackToControlCharacters(t *testing.T) {
+	session := outmocks.NewMockExecSession(t)
+	session.EXPECT().Write([]byte{0x1c}).Return(1, nil)
+
+	require.NoError(t, signalExec(session, "QUIT"))
+	assert.ErrorIs(t, signalExec(session, "USR1"), domain.ErrExecSignalUnsupported)
+}
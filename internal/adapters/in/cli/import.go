@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
+	"github.com/bnema/gordon/internal/adapters/in/cli/ui/components"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/internal/usecase/compose"
+)
+
+// composeImportClient is the part of the control plane gordon import
+// compose reads and writes.
+type composeImportClient interface {
+	GetRoute(ctx context.Context, routeDomain string) (*domain.Route, error)
+	AddRoute(ctx context.Context, route domain.Route) error
+	UpdateRoute(ctx context.Context, route domain.Route) error
+	GetAttachmentsConfig(ctx context.Context, domainOrGroup string) ([]string, error)
+	AddAttachment(ctx context.Context, domainOrGroup, image string) error
+	ListSecretsWithAttachments(ctx context.Context, secretDomain string) (*remote.SecretsListResult, error)
+	SetSecrets(ctx context.Context, secretDomain string, secrets map[string]string) error
+	SetAttachmentSecrets(ctx context.Context, domain, service string, secrets map[string]string) error
+}
+
+type composeImportFlags struct {
+	compose.Options
+	DryRun    bool
+	NoConfirm bool
+	Json      bool
+}
+
+const (
+	importActionAdd       = "add"
+	importActionUpdate    = "update"
+	importActionUnchanged = "unchanged"
+)
+
+// composeImportChange is one line of the import diff. Secret values are
+// never part of it.
+type composeImportChange struct {
+	Action string `json:"action"`
+	Kind   string `json:"kind"` // route, attachment, secret or attachment_secret
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type composeImportResult struct {
+	Domain   string                `json:"domain"`
+	Changes  []composeImportChange `json:"changes"`
+	Warnings []string              `json:"warnings,omitempty"`
+	Config   string                `json:"config,omitempty"`
+	Applied  bool                  `json:"applied"`
+}
+
+func newImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import apps from other tools",
+	}
+	cmd.AddCommand(newImportComposeCmd())
+	return cmd
+}
+
+func newImportComposeCmd() *cobra.Command {
+	var flags composeImportFlags
+	cmd := &cobra.Command{
+		Use:   "compose <file>",
+		Short: "Import a docker compose app as a route with attachments",
+		Long: `Import a docker compose file as a Gordon route.
+
+The web service becomes the route for --domain, image services become its
+attachments, and env and env_file values are stored as secrets. Services
+given with --service are printed as [[services]] configuration to add to
+gordon.toml. The changes are shown before they are applied.
+
+With --json nothing is applied unless --no-confirm is also given.`,
+		Example: `  gordon import compose docker-compose.yml --domain app.example.com
+  gordon import compose compose.yaml --domain app.example.com --image app:latest --dry-run
+  gordon import compose compose.yaml --domain app.example.com --service mailpit`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plan, err := compose.Load(args[0], flags.Options, os.LookupEnv)
+			if err != nil {
+				return err
+			}
+			handle, err := resolveControlPlane(configPath)
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+			return runImportCompose(cmd.Context(), handle.plane, plan, flags, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&flags.Domain, "domain", "", "Route domain for the web service")
+	cmd.Flags().StringVar(&flags.Image, "image", "", "Route image (defaults to the web service image)")
+	cmd.Flags().StringVar(&flags.Web, "web", "", "Compose service served on --domain (detected when unset)")
+	cmd.Flags().StringArrayVar(&flags.Services, "service", nil, "Compose service to import as a standalone service (repeatable)")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Show the changes without applying them")
+	cmd.Flags().BoolVar(&flags.NoConfirm, "no-confirm", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&flags.Json, "json", false, "Output as JSON; implies --dry-run unless --no-confirm is given")
+	_ = cmd.MarkFlagRequired("domain")
+	return cmd
+}
+
+func runImportCompose(ctx context.Context, client composeImportClient, plan *compose.Plan, flags composeImportFlags, out io.Writer) error {
+	existing, err := client.GetRoute(ctx, plan.Domain)
+	if err != nil && !isRemoteNotFoundError(err) {
+		return fmt.Errorf("failed to get route: %w", err)
+	}
+	if err != nil {
+		existing = nil
+	}
+
+	result, err := diffComposeImport(ctx, client, plan, existing)
+	if err != nil {
+		return err
+	}
+
+	if !flags.Json {
+		if err := renderComposeImport(out, result); err != nil {
+			return err
+		}
+	}
+	// JSON output has no prompt, so it only applies with --no-confirm.
+	if flags.DryRun || (flags.Json && !flags.NoConfirm) {
+		if flags.Json {
+			return writeJSON(out, result)
+		}
+		return nil
+	}
+
+	if !flags.NoConfirm {
+		if !isInteractiveTerminal() {
+			return fmt.Errorf("refusing to import without confirmation in a non-interactive session; pass --no-confirm")
+		}
+		confirmed, err := components.RunConfirm(fmt.Sprintf("Apply these changes to %s?", plan.Domain))
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return cliWriteLine(out, "Cancelled.")
+		}
+	}
+
+	if err := applyComposeImport(ctx, client, plan, existing); err != nil {
+		return err
+	}
+	result.Applied = true
+
+	if flags.Json {
+		return writeJSON(out, result)
+	}
+	if err := cliWriteLine(out, cliRenderSuccess(fmt.Sprintf("Imported %s", plan.Domain))); err != nil {
+		return err
+	}
+	return cliWriteLine(out, fmt.Sprintf("Next: gordon push %s --build", plan.Image))
+}
+
+// diffComposeImport compares the plan with what the control plane already
+// has for the domain.
+func diffComposeImport(ctx context.Context, client composeImportClient, plan *compose.Plan, existing *domain.Route) (*composeImportResult, error) {
+	result := &composeImportResult{Domain: plan.Domain, Warnings: plan.Warnings, Changes: []composeImportChange{}}
+	add := func(action, kind, target, detail string) {
+		result.Changes = append(result.Changes, composeImportChange{Action: action, Kind: kind, Target: target, Detail: detail})
+	}
+
+	switch {
+	case existing == nil:
+		add(importActionAdd, "route", plan.Domain, plan.Image)
+	case existing.Image != plan.Image:
+		add(importActionUpdate, "route", plan.Domain, existing.Image+" -> "+plan.Image)
+	default:
+		add(importActionUnchanged, "route", plan.Domain, plan.Image)
+	}
+
+	attached, err := client.GetAttachmentsConfig(ctx, plan.Domain)
+	if err != nil && !errors.Is(err, domain.ErrAttachmentNotFound) && !isRemoteNotFoundError(err) {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	for _, attachment := range plan.Attachments {
+		action := importActionAdd
+		if slices.Contains(attached, attachment.Image) {
+			action = importActionUnchanged
+		}
+		add(action, "attachment", attachment.Name, attachment.Image)
+	}
+
+	secretKeys := map[string][]string{}
+	if existing != nil {
+		secrets, err := client.ListSecretsWithAttachments(ctx, plan.Domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %w", err)
+		}
+		secretKeys[""] = secrets.Keys
+		for _, attachment := range secrets.Attachments {
+			secretKeys[attachment.Service] = attachment.Keys
+		}
+	}
+	secretAction := func(service, key string) string {
+		if slices.Contains(secretKeys[service], key) {
+			return importActionUpdate
+		}
+		return importActionAdd
+	}
+	for _, key := range slices.Sorted(maps.Keys(plan.Env)) {
+		add(secretAction("", key), "secret", key, "")
+	}
+	for _, attachment := range plan.Attachments {
+		for _, key := range slices.Sorted(maps.Keys(attachment.Env)) {
+			add(secretAction(attachment.Name, key), "attachment_secret", attachment.Name+"/"+key, "")
+		}
+	}
+
+	config, err := plan.ManualConfig()
+	if err != nil {
+		return nil, err
+	}
+	result.Config = config
+	return result, nil
+}
+
+func renderComposeImport(out io.Writer, result *composeImportResult) error {
+	if err := cliWriteLine(out, cliRenderTitle("Import "+result.Domain)); err != nil {
+		return err
+	}
+	symbols := map[string]string{importActionAdd: "+", importActionUpdate: "~", importActionUnchanged: "="}
+	for _, change := range result.Changes {
+		line := fmt.Sprintf("  %s %-17s %s", symbols[change.Action], change.Kind, change.Target)
+		if change.Detail != "" {
+			line += "  " + cliRenderMuted(change.Detail)
+		}
+		if change.Action == importActionUpdate && strings.HasSuffix(change.Kind, "secret") {
+			line += "  " + cliRenderMuted("(overwrite)")
+		}
+		if err := cliWriteLine(out, line); err != nil {
+			return err
+		}
+	}
+	for _, warning := range result.Warnings {
+		if err := cliWriteLine(out, cliRenderWarning(warning)); err != nil {
+			return err
+		}
+	}
+	if result.Config == "" {
+		return nil
+	}
+	if err := cliWriteLine(out, ""); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, cliRenderInfo("Add to gordon.toml (not applied by import):")); err != nil {
+		return err
+	}
+	return cliWriteLine(out, strings.TrimRight(result.Config, "\n"))
+}
+
+// applyComposeImport writes the secrets and attachments before the route,
+// so that a new route, or a route switched to the new image, never deploys
+// without them. When a step fails, the error names what was already
+// written; running the import again finishes it.
+func applyComposeImport(ctx context.Context, client composeImportClient, plan *compose.Plan, existing *domain.Route) error {
+	var written []string
+	partial := func(err error) error {
+		if len(written) == 0 {
+			return err
+		}
+		return fmt.Errorf("%w (already written: %s; run the import again to finish)", err, strings.Join(written, ", "))
+	}
+
+	if len(plan.Env) > 0 {
+		if err := client.SetSecrets(ctx, plan.Domain, plan.Env); err != nil {
+			return fmt.Errorf("failed to set secrets: %w", err)
+		}
+		written = append(written, "route secrets")
+	}
+	for _, attachment := range plan.Attachments {
+		if len(attachment.Env) == 0 {
+			continue
+		}
+		if err := client.SetAttachmentSecrets(ctx, plan.Domain, attachment.Name, attachment.Env); err != nil {
+			return partial(fmt.Errorf("failed to set secrets for %s: %w", attachment.Name, err))
+		}
+		written = append(written, "secrets for "+attachment.Name)
+	}
+
+	attached, err := client.GetAttachmentsConfig(ctx, plan.Domain)
+	if err != nil && !errors.Is(err, domain.ErrAttachmentNotFound) && !isRemoteNotFoundError(err) {
+		return partial(fmt.Errorf("failed to get attachments: %w", err))
+	}
+	for _, attachment := range plan.Attachments {
+		if slices.Contains(attached, attachment.Image) {
+			continue
+		}
+		if err := client.AddAttachment(ctx, plan.Domain, attachment.Image); err != nil && !errors.Is(err, domain.ErrAttachmentExists) {
+			return partial(fmt.Errorf("failed to add attachment %s: %w", attachment.Image, err))
+		}
+		written = append(written, "attachment "+attachment.Image)
+	}
+
+	switch {
+	case existing == nil:
+		if err := client.AddRoute(ctx, domain.Route{Domain: plan.Domain, Image: plan.Image}); err != nil {
+			return partial(fmt.Errorf("failed to add route: %w", err))
+		}
+	case existing.Image != plan.Image:
+		route := *existing
+		route.Image = plan.Image
+		if err := client.UpdateRoute(ctx, route); err != nil {
+			return partial(fmt.Errorf("failed to update route: %w", err))
+		}
+	}
+	return nil
+}
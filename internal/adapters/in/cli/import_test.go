@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/internal/usecase/compose"
+)
+
+func testComposeImportPlan() *compose.Plan {
+	return &compose.Plan{
+		Domain: "shop.example.com",
+		Image:  "shop:v2",
+		Env:    map[string]string{"RAILS_ENV": "production", "SECRET_KEY_BASE": "abc"},
+		Attachments: []compose.Attachment{
+			{Service: "db", Name: "postgres", Image: "postgres:16", Env: map[string]string{"POSTGRES_PASSWORD": "s3cret"}},
+			{Service: "cache", Name: "redis", Image: "redis:7"},
+		},
+		Readiness: map[string]compose.Readiness{
+			"postgres": {Type: domain.AttachmentReadinessCommand, Command: []string{"pg_isready"}},
+		},
+		Warnings: []string{`service "worker" runs the app image and was not imported`},
+	}
+}
+
+func TestRunImportCompose_DryRunShowsDiffWithoutSecretValues(t *testing.T) {
+	ctx := context.Background()
+	client := climocks.NewMockcomposeImportClient(t)
+	client.EXPECT().GetRoute(ctx, "shop.example.com").Return(&domain.Route{Domain: "shop.example.com", Image: "shop:v1"}, nil).Once()
+	client.EXPECT().GetAttachmentsConfig(ctx, "shop.example.com").Return([]string{"redis:7"}, nil).Once()
+	client.EXPECT().ListSecretsWithAttachments(ctx, "shop.example.com").Return(&remote.SecretsListResult{
+		Keys:        []string{"RAILS_ENV"},
+		Attachments: []remote.AttachmentSecrets{{Service: "postgres", Keys: []string{"POSTGRES_PASSWORD"}}},
+	}, nil).Once()
+
+	var out bytes.Buffer
+	err := runImportCompose(ctx, client, testComposeImportPlan(), composeImportFlags{DryRun: true}, &out)
+	require.NoError(t, err)
+
+	output := out.String()
+	assert.Contains(t, output, "~ route")
+	assert.Contains(t, output, "shop:v1 -> shop:v2")
+	assert.Contains(t, output, "+ attachment        postgres")
+	assert.Contains(t, output, "= attachment        redis")
+	assert.Contains(t, output, "~ secret            RAILS_ENV")
+	assert.Contains(t, output, "+ secret            SECRET_KEY_BASE")
+	assert.Contains(t, output, "~ attachment_secret postgres/POSTGRES_PASSWORD")
+	assert.Contains(t, output, "[attachment_readiness.postgres]")
+	assert.Contains(t, output, `runs the app image`)
+	assert.NotContains(t, output, "s3cret")
+	assert.NotContains(t, output, "production")
+}
+
+func TestRunImportCompose_AppliesNewRoute(t *testing.T) {
+	ctx := context.Background()
+	plan := testComposeImportPlan()
+	client := climocks.NewMockcomposeImportClient(t)
+	client.EXPECT().GetRoute(ctx, "shop.example.com").Return(nil, domain.ErrRouteNotFound).Once()
+	client.EXPECT().GetAttachmentsConfig(ctx, "shop.example.com").Return(nil, domain.ErrAttachmentNotFound).Twice()
+	routeCall := client.EXPECT().AddRoute(ctx, domain.Route{Domain: "shop.example.com", Image: "shop:v2"}).Return(nil).Once()
+	pgCall := client.EXPECT().AddAttachment(ctx, "shop.example.com", "postgres:16").Return(nil).Once()
+	redisCall := client.EXPECT().AddAttachment(ctx, "shop.example.com", "redis:7").Return(nil).Once()
+	secretsCall := client.EXPECT().SetSecrets(ctx, "shop.example.com", plan.Env).Return(nil).Once()
+	attachmentSecretsCall := client.EXPECT().SetAttachmentSecrets(ctx, "shop.example.com", "postgres", map[string]string{"POSTGRES_PASSWORD": "s3cret"}).Return(nil).Once()
+	mock.InOrder(secretsCall, attachmentSecretsCall, pgCall, redisCall, routeCall)
+
+	var out bytes.Buffer
+	err := runImportCompose(ctx, client, plan, composeImportFlags{NoConfirm: true, Json: true}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"applied": true`)
+	assert.Contains(t, out.String(), `"action": "add"`)
+}
+
+func TestRunImportCompose_PartialApplyNamesWrittenSteps(t *testing.T) {
+	ctx := context.Background()
+	plan := testComposeImportPlan()
+	client := climocks.NewMockcomposeImportClient(t)
+	client.EXPECT().GetRoute(ctx, "shop.example.com").Return(nil, domain.ErrRouteNotFound).Once()
+	client.EXPECT().GetAttachmentsConfig(ctx, "shop.example.com").Return(nil, domain.ErrAttachmentNotFound).Twice()
+	client.EXPECT().SetSecrets(ctx, "shop.example.com", plan.Env).Return(nil).Once()
+	client.EXPECT().SetAttachmentSecrets(ctx, "shop.example.com", "postgres", mock.Anything).Return(nil).Once()
+	client.EXPECT().AddAttachment(ctx, "shop.example.com", "postgres:16").Return(nil).Once()
+	client.EXPECT().AddAttachment(ctx, "shop.example.com", "redis:7").Return(errors.New("boom")).Once()
+
+	var out bytes.Buffer
+	err := runImportCompose(ctx, client, plan, composeImportFlags{NoConfirm: true, Json: true}, &out)
+	require.ErrorContains(t, err, "failed to add attachment redis:7: boom")
+	assert.ErrorContains(t, err, "already written: route secrets, secrets for postgres, attachment postgres:16")
+	client.AssertNotCalled(t, "AddRoute", mock.Anything, mock.Anything)
+}
+
+func TestRunImportCompose_JSONWithoutNoConfirmDoesNotApply(t *testing.T) {
+	ctx := context.Background()
+	client := climocks.NewMockcomposeImportClient(t)
+	client.EXPECT().GetRoute(ctx, "shop.example.com").Return(nil, domain.ErrRouteNotFound).Once()
+	client.EXPECT().GetAttachmentsConfig(ctx, "shop.example.com").Return(nil, domain.ErrAttachmentNotFound).Once()
+
+	var out bytes.Buffer
+	err := runImportCompose(ctx, client, testComposeImportPlan(), composeImportFlags{Json: true}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"applied": false`)
+	assert.Contains(t, out.String(), `"action": "add"`)
+	assert.NotContains(t, out.String(), "s3cret")
+}
+
+func TestRunImportCompose_UpdatesExistingRouteImage(t *testing.T) {
+	ctx := context.Background()
+	plan := &compose.Plan{Domain: "shop.example.com", Image: "shop:v2"}
+	existing := &domain.Route{Domain: "shop.example.com", Image: "shop:v1", HTTPS: true}
+	client := climocks.NewMockcomposeImportClient(t)
+	client.EXPECT().GetRoute(ctx, "shop.example.com").Return(existing, nil).Once()
+	client.EXPECT().GetAttachmentsConfig(ctx, "shop.example.com").Return(nil, nil).Twice()
+	client.EXPECT().ListSecretsWithAttachments(ctx, "shop.example.com").Return(&remote.SecretsListResult{}, nil).Once()
+	client.EXPECT().UpdateRoute(ctx, domain.Route{Domain: "shop.example.com", Image: "shop:v2", HTTPS: true}).Return(nil).Once()
+
+	var out bytes.Buffer
+	err := runImportCompose(ctx, client, plan, composeImportFlags{NoConfirm: true}, &out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Imported shop.example.com")
+}
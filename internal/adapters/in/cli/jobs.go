@@ -0,0 +1,292 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+)
+
+// jobPollInterval is how often gordon jobs run checks whether a run is over.
+var jobPollInterval = time.Second
+
+type jobRunner interface {
+	RunJob(ctx context.Context, routeDomain, name string) (*dto.JobRun, error)
+	GetJobRun(ctx context.Context, routeDomain, name, runID string) (*dto.JobRun, error)
+}
+
+// newJobsCmd creates the jobs command group.
+func newJobsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "jobs",
+		Aliases: []string{"job"},
+		Short:   "Manage scheduled jobs",
+		Long: `Manage the scheduled jobs declared with [[jobs]] in the config.
+
+Each run starts an ephemeral container from the route's image with its
+environment, secrets, volumes and network, like gordon run.`,
+	}
+
+	cmd.AddCommand(newJobsListCmd())
+	cmd.AddCommand(newJobsRunCmd())
+	cmd.AddCommand(newJobsLogsCmd())
+
+	return cmd
+}
+
+func newJobsListCmd() *cobra.Command {
+	var jsonOut bool
+
+	cmd := &cobra.Command{
+		Use:   "list [domain]",
+		Short: "List scheduled jobs",
+		Long:  cliRenderMuted("List scheduled jobs for all routes or a specific route."),
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			domainName := ""
+			if len(args) == 1 {
+				domainName = args[0]
+			}
+			handle, err := backupResolveControlPlane(cmd.Context(), configPath, domainName)
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			jobs, err := handle.plane.ListJobs(cmd.Context(), domainName)
+			if err != nil {
+				return fmt.Errorf("failed to list jobs: %w", err)
+			}
+			return printJobs(cmd.OutOrStdout(), jobs, jsonOut)
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+
+	return cmd
+}
+
+func printJobs(out io.Writer, jobs []dto.Job, jsonOut bool) error {
+	if jsonOut {
+		if jobs == nil {
+			jobs = []dto.Job{}
+		}
+		return writeJSON(out, jobs)
+	}
+	if len(jobs) == 0 {
+		return cliWriteLine(out, cliRenderMuted("No jobs configured"))
+	}
+
+	if err := cliWriteLine(out, cliRenderTitle("Jobs")); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "ROUTE\tJOB\tSCHEDULE\tTIMEZONE\tNEXT_RUN\tLAST_RUN\tACTIVE"); err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		lastRun := ""
+		if job.LastRun != nil {
+			lastRun = fmt.Sprintf("%s %s", job.LastRun.Status, formatBackupTime(job.LastRun.StartedAt))
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", job.Route, job.Name, job.Schedule, job.Timezone, formatBackupTime(job.NextRun), lastRun, job.Active); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+func newJobsRunCmd() *cobra.Command {
+	var detach bool
+
+	cmd := &cobra.Command{
+		Use:   "run <domain> <job>",
+		Short: "Run a scheduled job now",
+		Long: `Runs a job now, outside of its schedule, and waits for it to finish.
+
+The job's output is printed once the run is over and gordon exits with the
+job's exit code. The job's overlap policy applies to manual runs too, so a
+run can be skipped while another one is in progress.
+
+Use --detach to return as soon as the run has started. Detached runs need
+a remote Gordon instance: a local run stops when the CLI exits.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle, err := resolveControlPlaneForRouteDomain(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			if detach {
+				if !handle.isRemote {
+					return fmt.Errorf("--detach requires a remote Gordon instance")
+				}
+				run, err := handle.plane.RunJob(cmd.Context(), args[0], args[1])
+				if err != nil {
+					return fmt.Errorf("failed to run job: %w", err)
+				}
+				return cliWriteLine(cmd.OutOrStdout(), cliRenderSuccess(fmt.Sprintf("Started run %s of %s/%s", run.ID, args[0], args[1])))
+			}
+
+			err = runJob(cmd.Context(), handle.plane, args[0], args[1], cmd.OutOrStdout())
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				// The job's output already explains the failure.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Return once the run has started")
+
+	return cmd
+}
+
+// runJob starts a run of a job, waits for it to finish and prints its output.
+func runJob(ctx context.Context, runner jobRunner, routeDomain, name string, out io.Writer) error {
+	run, err := runner.RunJob(ctx, routeDomain, name)
+	if err != nil {
+		return fmt.Errorf("failed to run job: %w", err)
+	}
+
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for !run.Finished() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if run, err = runner.GetJobRun(ctx, routeDomain, name, run.ID); err != nil {
+			return fmt.Errorf("failed to get job run: %w", err)
+		}
+	}
+
+	if _, err := io.WriteString(out, run.Output); err != nil {
+		return err
+	}
+	return jobRunError(run)
+}
+
+// jobRunError turns a finished run that did not succeed into an error.
+func jobRunError(run *dto.JobRun) error {
+	switch run.Status {
+	case "succeeded":
+		return nil
+	case "failed":
+		if run.ExitCode != 0 {
+			return &ExitError{Code: run.ExitCode}
+		}
+	}
+	if run.Error != "" {
+		return fmt.Errorf("job run %s: %s", run.Status, run.Error)
+	}
+	return fmt.Errorf("job run %s", run.Status)
+}
+
+func newJobsLogsCmd() *cobra.Command {
+	var (
+		runID   string
+		history bool
+		jsonOut bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "logs <domain> <job>",
+		Short: "Show the output of job runs",
+		Long: `Shows the output of the latest run of a job, or of the run given with --run.
+
+Use --history to list the recorded runs instead.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle, err := resolveControlPlaneForRouteDomain(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			out := cmd.OutOrStdout()
+			if runID != "" {
+				run, err := handle.plane.GetJobRun(cmd.Context(), args[0], args[1], runID)
+				if err != nil {
+					return fmt.Errorf("failed to get job run: %w", err)
+				}
+				return printJobRunLogs(out, run, jsonOut)
+			}
+
+			runs, err := handle.plane.ListJobRuns(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return fmt.Errorf("failed to list job runs: %w", err)
+			}
+			if history {
+				return printJobRuns(out, runs, jsonOut)
+			}
+			if len(runs) == 0 {
+				return cliWriteLine(out, cliRenderMuted("No runs recorded"))
+			}
+			return printJobRunLogs(out, &runs[0], jsonOut)
+		},
+	}
+
+	cmd.Flags().StringVar(&runID, "run", "", "Show the output of this run")
+	cmd.Flags().BoolVar(&history, "history", false, "List the recorded runs")
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.MarkFlagsMutuallyExclusive("run", "history")
+
+	return cmd
+}
+
+func printJobRunLogs(out io.Writer, run *dto.JobRun, jsonOut bool) error {
+	if jsonOut {
+		return writeJSON(out, run)
+	}
+	header := fmt.Sprintf("Run %s: %s", run.ID, run.Status)
+	if run.Error != "" {
+		header += " (" + run.Error + ")"
+	}
+	if err := cliWriteLine(out, cliRenderMuted(header)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(out, run.Output)
+	return err
+}
+
+func printJobRuns(out io.Writer, runs []dto.JobRun, jsonOut bool) error {
+	if jsonOut {
+		if runs == nil {
+			runs = []dto.JobRun{}
+		}
+		return writeJSON(out, runs)
+	}
+	if len(runs) == 0 {
+		return cliWriteLine(out, cliRenderMuted("No runs recorded"))
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "RUN_ID\tTRIGGER\tSTATUS\tSTARTED_AT\tDURATION\tEXIT_CODE"); err != nil {
+		return err
+	}
+	for _, run := range runs {
+		duration := ""
+		if run.StartedAt != nil && run.FinishedAt != nil {
+			duration = run.FinishedAt.Sub(*run.StartedAt).Round(time.Second).String()
+		}
+		exitCode := ""
+		if run.Status == "succeeded" || run.ExitCode != 0 {
+			exitCode = fmt.Sprintf("%d", run.ExitCode)
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", run.ID, run.Trigger, run.Status, formatBackupTime(run.StartedAt), duration, exitCode); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+)
+
+func TestRunJobWaitsForRunAndPropagatesExitCode(t *testing.T) {
+	prev := jobPollInterval
+	jobPollInterval = time.Millisecond
+	t.Cleanup(func() { jobPollInterval = prev })
+
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().RunJob(mock.Anything, "app.example.com", "cleanup").
+		Return(&dto.JobRun{ID: "run-1", Status: "running"}, nil)
+	cp.EXPECT().GetJobRun(mock.Anything, "app.example.com", "cleanup", "run-1").
+		Return(&dto.JobRun{ID: "run-1", Status: "running"}, nil).Once()
+	cp.EXPECT().GetJobRun(mock.Anything, "app.example.com", "cleanup", "run-1").
+		Return(&dto.JobRun{ID: "run-1", Status: "failed", ExitCode: 4, Output: "boom\n"}, nil).Once()
+
+	var out bytes.Buffer
+	err := runJob(context.Background(), cp, "app.example.com", "cleanup", &out)
+
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 4, exitErr.Code)
+	assert.Equal(t, "boom\n", out.String())
+}
+
+func TestRunJobReportsSkippedRun(t *testing.T) {
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().RunJob(mock.Anything, "app.example.com", "cleanup").
+		Return(&dto.JobRun{ID: "run-2", Status: "skipped", Error: "previous run still in progress"}, nil)
+
+	err := runJob(context.Background(), cp, "app.example.com", "cleanup", &bytes.Buffer{})
+
+	require.EqualError(t, err, "job run skipped: previous run still in progress")
+}
+
+func TestPrintJobs(t *testing.T) {
+	next := time.Date(2026, 2, 7, 12, 45, 0, 0, time.UTC)
+	var out bytes.Buffer
+	err := printJobs(&out, []dto.Job{{
+		Route:    "app.example.com",
+		Name:     "cleanup",
+		Schedule: "*/15 * * * *",
+		Timezone: "Europe/Paris",
+		NextRun:  &next,
+		LastRun:  &dto.JobRun{Status: "succeeded"},
+	}}, false)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "*/15 * * * *")
+	assert.Contains(t, out.String(), "Europe/Paris")
+	assert.Contains(t, out.String(), "2026-02-07T12:45:00Z")
+	assert.Contains(t, out.String(), "succeeded")
+}
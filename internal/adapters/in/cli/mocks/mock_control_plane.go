@@ -6,9 +6,11 @@ package mocks
 
 import (
 	"context"
+	"io"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
 	"github.com/bnema/gordon/internal/adapters/in/cli/remote"
+	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -746,6 +748,86 @@ func (_c *MockControlPlane_DetectDatabases_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
+// Exec provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) Exec(ctx context.Context, routeDomain string, attachment string, req domain.ExecRequest) (out.ExecSession, error) {
+	ret := _mock.Called(ctx, routeDomain, attachment, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 out.ExecSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.ExecRequest) (out.ExecSession, error)); ok {
+		return returnFunc(ctx, routeDomain, attachment, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, domain.ExecRequest) out.ExecSession); ok {
+		r0 = returnFunc(ctx, routeDomain, attachment, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(out.ExecSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, domain.ExecRequest) error); ok {
+		r1 = returnFunc(ctx, routeDomain, attachment, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type MockControlPlane_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+//   - attachment string
+//   - req domain.ExecRequest
+func (_e *MockControlPlane_Expecter) Exec(ctx any, routeDomain any, attachment any, req any) *MockControlPlane_Exec_Call {
+	return &MockControlPlane_Exec_Call{Call: _e.mock.On("Exec", ctx, routeDomain, attachment, req)}
+}
+
+func (_c *MockControlPlane_Exec_Call) Run(run func(ctx context.Context, routeDomain string, attachment string, req domain.ExecRequest)) *MockControlPlane_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 domain.ExecRequest
+		if args[3] != nil {
+			arg3 = args[3].(domain.ExecRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_Exec_Call) Return(execSession out.ExecSession, err error) *MockControlPlane_Exec_Call {
+	_c.Call.Return(execSession, err)
+	return _c
+}
+
+func (_c *MockControlPlane_Exec_Call) RunAndReturn(run func(ctx context.Context, routeDomain string, attachment string, req domain.ExecRequest) (out.ExecSession, error)) *MockControlPlane_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindAttachmentTargetsByImage provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) FindAttachmentTargetsByImage(ctx context.Context, imageName string) ([]string, error) {
 	ret := _mock.Called(ctx, imageName)
@@ -1272,6 +1354,86 @@ func (_c *MockControlPlane_GetHealth_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// GetJobRun provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) GetJobRun(ctx context.Context, routeDomain string, name string, runID string) (*dto.JobRun, error) {
+	ret := _mock.Called(ctx, routeDomain, name, runID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobRun")
+	}
+
+	var r0 *dto.JobRun
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*dto.JobRun, error)); ok {
+		return returnFunc(ctx, routeDomain, name, runID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *dto.JobRun); ok {
+		r0 = returnFunc(ctx, routeDomain, name, runID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.JobRun)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain, name, runID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_GetJobRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobRun'
+type MockControlPlane_GetJobRun_Call struct {
+	*mock.Call
+}
+
+// GetJobRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+//   - name string
+//   - runID string
+func (_e *MockControlPlane_Expecter) GetJobRun(ctx any, routeDomain any, name any, runID any) *MockControlPlane_GetJobRun_Call {
+	return &MockControlPlane_GetJobRun_Call{Call: _e.mock.On("GetJobRun", ctx, routeDomain, name, runID)}
+}
+
+func (_c *MockControlPlane_GetJobRun_Call) Run(run func(ctx context.Context, routeDomain string, name string, runID string)) *MockControlPlane_GetJobRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_GetJobRun_Call) Return(jobRun *dto.JobRun, err error) *MockControlPlane_GetJobRun_Call {
+	_c.Call.Return(jobRun, err)
+	return _c
+}
+
+func (_c *MockControlPlane_GetJobRun_Call) RunAndReturn(run func(ctx context.Context, routeDomain string, name string, runID string) (*dto.JobRun, error)) *MockControlPlane_GetJobRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProcessLogs provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) GetProcessLogs(ctx context.Context, lines int) ([]string, error) {
 	ret := _mock.Called(ctx, lines)
@@ -1594,6 +1756,74 @@ func (_c *MockControlPlane_GetTrafficStatus_Call) RunAndReturn(run func(ctx cont
 	return _c
 }
 
+// GetUpstreamStatus provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) GetUpstreamStatus(ctx context.Context, routeDomain string) (*dto.UpstreamStatusResponse, error) {
+	ret := _mock.Called(ctx, routeDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpstreamStatus")
+	}
+
+	var r0 *dto.UpstreamStatusResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*dto.UpstreamStatusResponse, error)); ok {
+		return returnFunc(ctx, routeDomain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *dto.UpstreamStatusResponse); ok {
+		r0 = returnFunc(ctx, routeDomain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.UpstreamStatusResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_GetUpstreamStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUpstreamStatus'
+type MockControlPlane_GetUpstreamStatus_Call struct {
+	*mock.Call
+}
+
+// GetUpstreamStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+func (_e *MockControlPlane_Expecter) GetUpstreamStatus(ctx any, routeDomain any) *MockControlPlane_GetUpstreamStatus_Call {
+	return &MockControlPlane_GetUpstreamStatus_Call{Call: _e.mock.On("GetUpstreamStatus", ctx, routeDomain)}
+}
+
+func (_c *MockControlPlane_GetUpstreamStatus_Call) Run(run func(ctx context.Context, routeDomain string)) *MockControlPlane_GetUpstreamStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_GetUpstreamStatus_Call) Return(upstreamStatusResponse *dto.UpstreamStatusResponse, err error) *MockControlPlane_GetUpstreamStatus_Call {
+	_c.Call.Return(upstreamStatusResponse, err)
+	return _c
+}
+
+func (_c *MockControlPlane_GetUpstreamStatus_Call) RunAndReturn(run func(ctx context.Context, routeDomain string) (*dto.UpstreamStatusResponse, error)) *MockControlPlane_GetUpstreamStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListBackups provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) ListBackups(ctx context.Context, backupDomain string) ([]dto.BackupJob, error) {
 	ret := _mock.Called(ctx, backupDomain)
@@ -1662,148 +1892,166 @@ func (_c *MockControlPlane_ListBackups_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
-// ListNetworks provides a mock function for the type MockControlPlane
-func (_mock *MockControlPlane) ListNetworks(ctx context.Context) ([]*domain.NetworkInfo, error) {
-	ret := _mock.Called(ctx)
+// ListJobRuns provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListJobRuns(ctx context.Context, routeDomain string, name string) ([]dto.JobRun, error) {
+	ret := _mock.Called(ctx, routeDomain, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNetworks")
+		panic("no return value specified for ListJobRuns")
 	}
 
-	var r0 []*domain.NetworkInfo
+	var r0 []dto.JobRun
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*domain.NetworkInfo, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]dto.JobRun, error)); ok {
+		return returnFunc(ctx, routeDomain, name)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*domain.NetworkInfo); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []dto.JobRun); ok {
+		r0 = returnFunc(ctx, routeDomain, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.NetworkInfo)
+			r0 = ret.Get(0).([]dto.JobRun)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain, name)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockControlPlane_ListNetworks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNetworks'
-type MockControlPlane_ListNetworks_Call struct {
+// MockControlPlane_ListJobRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobRuns'
+type MockControlPlane_ListJobRuns_Call struct {
 	*mock.Call
 }
 
-// ListNetworks is a helper method to define mock.On call
+// ListJobRuns is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockControlPlane_Expecter) ListNetworks(ctx any) *MockControlPlane_ListNetworks_Call {
-	return &MockControlPlane_ListNetworks_Call{Call: _e.mock.On("ListNetworks", ctx)}
+//   - routeDomain string
+//   - name string
+func (_e *MockControlPlane_Expecter) ListJobRuns(ctx any, routeDomain any, name any) *MockControlPlane_ListJobRuns_Call {
+	return &MockControlPlane_ListJobRuns_Call{Call: _e.mock.On("ListJobRuns", ctx, routeDomain, name)}
 }
 
-func (_c *MockControlPlane_ListNetworks_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListNetworks_Call {
+func (_c *MockControlPlane_ListJobRuns_Call) Run(run func(ctx context.Context, routeDomain string, name string)) *MockControlPlane_ListJobRuns_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockControlPlane_ListNetworks_Call) Return(networkInfos []*domain.NetworkInfo, err error) *MockControlPlane_ListNetworks_Call {
-	_c.Call.Return(networkInfos, err)
+func (_c *MockControlPlane_ListJobRuns_Call) Return(jobRuns []dto.JobRun, err error) *MockControlPlane_ListJobRuns_Call {
+	_c.Call.Return(jobRuns, err)
 	return _c
 }
 
-func (_c *MockControlPlane_ListNetworks_Call) RunAndReturn(run func(ctx context.Context) ([]*domain.NetworkInfo, error)) *MockControlPlane_ListNetworks_Call {
+func (_c *MockControlPlane_ListJobRuns_Call) RunAndReturn(run func(ctx context.Context, routeDomain string, name string) ([]dto.JobRun, error)) *MockControlPlane_ListJobRuns_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListOrphanedAttachments provides a mock function for the type MockControlPlane
-func (_mock *MockControlPlane) ListOrphanedAttachments(ctx context.Context) ([]domain.CleanupAttachment, error) {
-	ret := _mock.Called(ctx)
+// ListJobs provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListJobs(ctx context.Context, routeDomain string) ([]dto.Job, error) {
+	ret := _mock.Called(ctx, routeDomain)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListOrphanedAttachments")
+		panic("no return value specified for ListJobs")
 	}
 
-	var r0 []domain.CleanupAttachment
+	var r0 []dto.Job
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.CleanupAttachment, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]dto.Job, error)); ok {
+		return returnFunc(ctx, routeDomain)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.CleanupAttachment); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []dto.Job); ok {
+		r0 = returnFunc(ctx, routeDomain)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]domain.CleanupAttachment)
+			r0 = ret.Get(0).([]dto.Job)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockControlPlane_ListOrphanedAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrphanedAttachments'
-type MockControlPlane_ListOrphanedAttachments_Call struct {
+// MockControlPlane_ListJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobs'
+type MockControlPlane_ListJobs_Call struct {
 	*mock.Call
 }
 
-// ListOrphanedAttachments is a helper method to define mock.On call
+// ListJobs is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockControlPlane_Expecter) ListOrphanedAttachments(ctx any) *MockControlPlane_ListOrphanedAttachments_Call {
-	return &MockControlPlane_ListOrphanedAttachments_Call{Call: _e.mock.On("ListOrphanedAttachments", ctx)}
+//   - routeDomain string
+func (_e *MockControlPlane_Expecter) ListJobs(ctx any, routeDomain any) *MockControlPlane_ListJobs_Call {
+	return &MockControlPlane_ListJobs_Call{Call: _e.mock.On("ListJobs", ctx, routeDomain)}
 }
 
-func (_c *MockControlPlane_ListOrphanedAttachments_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListOrphanedAttachments_Call {
+func (_c *MockControlPlane_ListJobs_Call) Run(run func(ctx context.Context, routeDomain string)) *MockControlPlane_ListJobs_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockControlPlane_ListOrphanedAttachments_Call) Return(cleanupAttachments []domain.CleanupAttachment, err error) *MockControlPlane_ListOrphanedAttachments_Call {
-	_c.Call.Return(cleanupAttachments, err)
+func (_c *MockControlPlane_ListJobs_Call) Return(jobs []dto.Job, err error) *MockControlPlane_ListJobs_Call {
+	_c.Call.Return(jobs, err)
 	return _c
 }
 
-func (_c *MockControlPlane_ListOrphanedAttachments_Call) RunAndReturn(run func(ctx context.Context) ([]domain.CleanupAttachment, error)) *MockControlPlane_ListOrphanedAttachments_Call {
+func (_c *MockControlPlane_ListJobs_Call) RunAndReturn(run func(ctx context.Context, routeDomain string) ([]dto.Job, error)) *MockControlPlane_ListJobs_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListRoutesWithDetails provides a mock function for the type MockControlPlane
-func (_mock *MockControlPlane) ListRoutesWithDetails(ctx context.Context) ([]remote.RouteInfo, error) {
+// ListNetworks provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListNetworks(ctx context.Context) ([]*domain.NetworkInfo, error) {
 	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListRoutesWithDetails")
+		panic("no return value specified for ListNetworks")
 	}
 
-	var r0 []remote.RouteInfo
+	var r0 []*domain.NetworkInfo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]remote.RouteInfo, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*domain.NetworkInfo, error)); ok {
 		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []remote.RouteInfo); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*domain.NetworkInfo); ok {
 		r0 = returnFunc(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]remote.RouteInfo)
+			r0 = ret.Get(0).([]*domain.NetworkInfo)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -1814,18 +2062,18 @@ func (_mock *MockControlPlane) ListRoutesWithDetails(ctx context.Context) ([]rem
 	return r0, r1
 }
 
-// MockControlPlane_ListRoutesWithDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoutesWithDetails'
-type MockControlPlane_ListRoutesWithDetails_Call struct {
+// MockControlPlane_ListNetworks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNetworks'
+type MockControlPlane_ListNetworks_Call struct {
 	*mock.Call
 }
 
-// ListRoutesWithDetails is a helper method to define mock.On call
+// ListNetworks is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockControlPlane_Expecter) ListRoutesWithDetails(ctx any) *MockControlPlane_ListRoutesWithDetails_Call {
-	return &MockControlPlane_ListRoutesWithDetails_Call{Call: _e.mock.On("ListRoutesWithDetails", ctx)}
+func (_e *MockControlPlane_Expecter) ListNetworks(ctx any) *MockControlPlane_ListNetworks_Call {
+	return &MockControlPlane_ListNetworks_Call{Call: _e.mock.On("ListNetworks", ctx)}
 }
 
-func (_c *MockControlPlane_ListRoutesWithDetails_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListRoutesWithDetails_Call {
+func (_c *MockControlPlane_ListNetworks_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListNetworks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1838,7 +2086,199 @@ func (_c *MockControlPlane_ListRoutesWithDetails_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockControlPlane_ListRoutesWithDetails_Call) Return(vs []remote.RouteInfo, err error) *MockControlPlane_ListRoutesWithDetails_Call {
+func (_c *MockControlPlane_ListNetworks_Call) Return(networkInfos []*domain.NetworkInfo, err error) *MockControlPlane_ListNetworks_Call {
+	_c.Call.Return(networkInfos, err)
+	return _c
+}
+
+func (_c *MockControlPlane_ListNetworks_Call) RunAndReturn(run func(ctx context.Context) ([]*domain.NetworkInfo, error)) *MockControlPlane_ListNetworks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOrphanedAttachments provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListOrphanedAttachments(ctx context.Context) ([]domain.CleanupAttachment, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrphanedAttachments")
+	}
+
+	var r0 []domain.CleanupAttachment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.CleanupAttachment, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.CleanupAttachment); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.CleanupAttachment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_ListOrphanedAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOrphanedAttachments'
+type MockControlPlane_ListOrphanedAttachments_Call struct {
+	*mock.Call
+}
+
+// ListOrphanedAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockControlPlane_Expecter) ListOrphanedAttachments(ctx any) *MockControlPlane_ListOrphanedAttachments_Call {
+	return &MockControlPlane_ListOrphanedAttachments_Call{Call: _e.mock.On("ListOrphanedAttachments", ctx)}
+}
+
+func (_c *MockControlPlane_ListOrphanedAttachments_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListOrphanedAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_ListOrphanedAttachments_Call) Return(cleanupAttachments []domain.CleanupAttachment, err error) *MockControlPlane_ListOrphanedAttachments_Call {
+	_c.Call.Return(cleanupAttachments, err)
+	return _c
+}
+
+func (_c *MockControlPlane_ListOrphanedAttachments_Call) RunAndReturn(run func(ctx context.Context) ([]domain.CleanupAttachment, error)) *MockControlPlane_ListOrphanedAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProcesses provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListProcesses(ctx context.Context, routeDomain string) ([]dto.Process, error) {
+	ret := _mock.Called(ctx, routeDomain)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProcesses")
+	}
+
+	var r0 []dto.Process
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]dto.Process, error)); ok {
+		return returnFunc(ctx, routeDomain)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []dto.Process); ok {
+		r0 = returnFunc(ctx, routeDomain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.Process)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_ListProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProcesses'
+type MockControlPlane_ListProcesses_Call struct {
+	*mock.Call
+}
+
+// ListProcesses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+func (_e *MockControlPlane_Expecter) ListProcesses(ctx any, routeDomain any) *MockControlPlane_ListProcesses_Call {
+	return &MockControlPlane_ListProcesses_Call{Call: _e.mock.On("ListProcesses", ctx, routeDomain)}
+}
+
+func (_c *MockControlPlane_ListProcesses_Call) Run(run func(ctx context.Context, routeDomain string)) *MockControlPlane_ListProcesses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_ListProcesses_Call) Return(processs []dto.Process, err error) *MockControlPlane_ListProcesses_Call {
+	_c.Call.Return(processs, err)
+	return _c
+}
+
+func (_c *MockControlPlane_ListProcesses_Call) RunAndReturn(run func(ctx context.Context, routeDomain string) ([]dto.Process, error)) *MockControlPlane_ListProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListRoutesWithDetails provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListRoutesWithDetails(ctx context.Context) ([]remote.RouteInfo, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoutesWithDetails")
+	}
+
+	var r0 []remote.RouteInfo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]remote.RouteInfo, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []remote.RouteInfo); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]remote.RouteInfo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_ListRoutesWithDetails_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListRoutesWithDetails'
+type MockControlPlane_ListRoutesWithDetails_Call struct {
+	*mock.Call
+}
+
+// ListRoutesWithDetails is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockControlPlane_Expecter) ListRoutesWithDetails(ctx any) *MockControlPlane_ListRoutesWithDetails_Call {
+	return &MockControlPlane_ListRoutesWithDetails_Call{Call: _e.mock.On("ListRoutesWithDetails", ctx)}
+}
+
+func (_c *MockControlPlane_ListRoutesWithDetails_Call) Run(run func(ctx context.Context)) *MockControlPlane_ListRoutesWithDetails_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_ListRoutesWithDetails_Call) Return(vs []remote.RouteInfo, err error) *MockControlPlane_ListRoutesWithDetails_Call {
 	_c.Call.Return(vs, err)
 	return _c
 }
@@ -1916,6 +2356,74 @@ func (_c *MockControlPlane_ListSecretsWithAttachments_Call) RunAndReturn(run fun
 	return _c
 }
 
+// ListStats provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) ListStats(ctx context.Context, name string) ([]dto.ContainerStats, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStats")
+	}
+
+	var r0 []dto.ContainerStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]dto.ContainerStats, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []dto.ContainerStats); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ContainerStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_ListStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStats'
+type MockControlPlane_ListStats_Call struct {
+	*mock.Call
+}
+
+// ListStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockControlPlane_Expecter) ListStats(ctx any, name any) *MockControlPlane_ListStats_Call {
+	return &MockControlPlane_ListStats_Call{Call: _e.mock.On("ListStats", ctx, name)}
+}
+
+func (_c *MockControlPlane_ListStats_Call) Run(run func(ctx context.Context, name string)) *MockControlPlane_ListStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_ListStats_Call) Return(containerStatss []dto.ContainerStats, err error) *MockControlPlane_ListStats_Call {
+	_c.Call.Return(containerStatss, err)
+	return _c
+}
+
+func (_c *MockControlPlane_ListStats_Call) RunAndReturn(run func(ctx context.Context, name string) ([]dto.ContainerStats, error)) *MockControlPlane_ListStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTags provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) ListTags(ctx context.Context, repository string) ([]string, error) {
 	ret := _mock.Called(ctx, repository)
@@ -2182,6 +2690,80 @@ func (_c *MockControlPlane_PruneVolumes_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// PurgeCache provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) PurgeCache(ctx context.Context, cacheDomain string, path string) (*dto.CachePurgeResponse, error) {
+	ret := _mock.Called(ctx, cacheDomain, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeCache")
+	}
+
+	var r0 *dto.CachePurgeResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*dto.CachePurgeResponse, error)); ok {
+		return returnFunc(ctx, cacheDomain, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *dto.CachePurgeResponse); ok {
+		r0 = returnFunc(ctx, cacheDomain, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.CachePurgeResponse)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, cacheDomain, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_PurgeCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeCache'
+type MockControlPlane_PurgeCache_Call struct {
+	*mock.Call
+}
+
+// PurgeCache is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cacheDomain string
+//   - path string
+func (_e *MockControlPlane_Expecter) PurgeCache(ctx any, cacheDomain any, path any) *MockControlPlane_PurgeCache_Call {
+	return &MockControlPlane_PurgeCache_Call{Call: _e.mock.On("PurgeCache", ctx, cacheDomain, path)}
+}
+
+func (_c *MockControlPlane_PurgeCache_Call) Run(run func(ctx context.Context, cacheDomain string, path string)) *MockControlPlane_PurgeCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_PurgeCache_Call) Return(cachePurgeResponse *dto.CachePurgeResponse, err error) *MockControlPlane_PurgeCache_Call {
+	_c.Call.Return(cachePurgeResponse, err)
+	return _c
+}
+
+func (_c *MockControlPlane_PurgeCache_Call) RunAndReturn(run func(ctx context.Context, cacheDomain string, path string) (*dto.CachePurgeResponse, error)) *MockControlPlane_PurgeCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Reload provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) Reload(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -2558,6 +3140,164 @@ func (_c *MockControlPlane_RunBackup_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// RunJob provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) RunJob(ctx context.Context, routeDomain string, name string) (*dto.JobRun, error) {
+	ret := _mock.Called(ctx, routeDomain, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunJob")
+	}
+
+	var r0 *dto.JobRun
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*dto.JobRun, error)); ok {
+		return returnFunc(ctx, routeDomain, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *dto.JobRun); ok {
+		r0 = returnFunc(ctx, routeDomain, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dto.JobRun)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, routeDomain, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_RunJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunJob'
+type MockControlPlane_RunJob_Call struct {
+	*mock.Call
+}
+
+// RunJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+//   - name string
+func (_e *MockControlPlane_Expecter) RunJob(ctx any, routeDomain any, name any) *MockControlPlane_RunJob_Call {
+	return &MockControlPlane_RunJob_Call{Call: _e.mock.On("RunJob", ctx, routeDomain, name)}
+}
+
+func (_c *MockControlPlane_RunJob_Call) Run(run func(ctx context.Context, routeDomain string, name string)) *MockControlPlane_RunJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_RunJob_Call) Return(jobRun *dto.JobRun, err error) *MockControlPlane_RunJob_Call {
+	_c.Call.Return(jobRun, err)
+	return _c
+}
+
+func (_c *MockControlPlane_RunJob_Call) RunAndReturn(run func(ctx context.Context, routeDomain string, name string) (*dto.JobRun, error)) *MockControlPlane_RunJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RunTask provides a mock function for the type MockControlPlane
+func (_mock *MockControlPlane) RunTask(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout io.Writer, stderr io.Writer) (int, error) {
+	ret := _mock.Called(ctx, routeDomain, task, stdout, stderr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RunTask")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TaskRequest, io.Writer, io.Writer) (int, error)); ok {
+		return returnFunc(ctx, routeDomain, task, stdout, stderr)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TaskRequest, io.Writer, io.Writer) int); ok {
+		r0 = returnFunc(ctx, routeDomain, task, stdout, stderr)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TaskRequest, io.Writer, io.Writer) error); ok {
+		r1 = returnFunc(ctx, routeDomain, task, stdout, stderr)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockControlPlane_RunTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RunTask'
+type MockControlPlane_RunTask_Call struct {
+	*mock.Call
+}
+
+// RunTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - routeDomain string
+//   - task domain.TaskRequest
+//   - stdout io.Writer
+//   - stderr io.Writer
+func (_e *MockControlPlane_Expecter) RunTask(ctx any, routeDomain any, task any, stdout any, stderr any) *MockControlPlane_RunTask_Call {
+	return &MockControlPlane_RunTask_Call{Call: _e.mock.On("RunTask", ctx, routeDomain, task, stdout, stderr)}
+}
+
+func (_c *MockControlPlane_RunTask_Call) Run(run func(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout io.Writer, stderr io.Writer)) *MockControlPlane_RunTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.TaskRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.TaskRequest)
+		}
+		var arg3 io.Writer
+		if args[3] != nil {
+			arg3 = args[3].(io.Writer)
+		}
+		var arg4 io.Writer
+		if args[4] != nil {
+			arg4 = args[4].(io.Writer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockControlPlane_RunTask_Call) Return(n int, err error) *MockControlPlane_RunTask_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockControlPlane_RunTask_Call) RunAndReturn(run func(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout io.Writer, stderr io.Writer) (int, error)) *MockControlPlane_RunTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RunVolumeBackups provides a mock function for the type MockControlPlane
 func (_mock *MockControlPlane) RunVolumeBackups(ctx context.Context, backupDomain string, volumeName string) (*dto.VolumeBackupRunResponse, error) {
 	ret := _mock.Called(ctx, backupDomain, volumeName)
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/net/websocket"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
 	"github.com/bnema/gordon/internal/domain"
@@ -495,6 +496,29 @@ func (c *Client) ListAttachments(ctx context.Context, routeDomain string) ([]Att
 	return result.Attachments, nil
 }
 
+// ListProcesses returns the processes of a route and the containers running them.
+func (c *Client) ListProcesses(ctx context.Context, routeDomain string) ([]dto.Process, error) {
+	if routeDomain == "" {
+		return nil, fmt.Errorf("domain is required")
+	}
+	path := "/routes/" + url.PathEscape(routeDomain) + "/processes"
+	resp, err := c.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.ProcessesResponse
+	if err := parseResponse(resp, &result); err != nil {
+		var httpErr *HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, domain.ErrRouteNotFound
+		}
+		return nil, err
+	}
+
+	return result.Processes, nil
+}
+
 // GetRoute returns a specific route by domain.
 func (c *Client) GetRoute(ctx context.Context, routeDomain string) (*domain.Route, error) {
 	resp, err := c.request(ctx, http.MethodGet, "/routes/"+url.PathEscape(routeDomain), nil)
@@ -514,6 +538,21 @@ func (c *Client) GetRoute(ctx context.Context, routeDomain string) (*domain.Rout
 	return &route, nil
 }
 
+// GetUpstreamStatus returns the circuit breaker state of a route's upstream.
+func (c *Client) GetUpstreamStatus(ctx context.Context, routeDomain string) (*dto.UpstreamStatusResponse, error) {
+	resp, err := c.request(ctx, http.MethodGet, "/routes/"+url.PathEscape(routeDomain)+"/upstream", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var status dto.UpstreamStatusResponse
+	if err := parseResponse(resp, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
 // GetRouteCleanupPreview returns retained cleanup state for a route that may no longer be configured.
 func (c *Client) GetRouteCleanupPreview(ctx context.Context, routeDomain string) (*domain.CleanupReport, error) {
 	resp, err := c.request(ctx, http.MethodGet, "/routes/"+url.PathEscape(routeDomain)+"/cleanup", nil)
@@ -1056,6 +1095,320 @@ func (c *Client) Restart(ctx context.Context, restartDomain string, withAttachme
 	return &result, nil
 }
 
+// Task API
+
+// RunTask runs a one-off command with the image of a route's container,
+// copies its output to stdout and stderr, and returns the command's exit code.
+func (c *Client) RunTask(ctx context.Context, runDomain string, req dto.RunTaskRequest, stdout, stderr io.Writer) (int, error) {
+	if runDomain == "" {
+		return 0, fmt.Errorf("domain cannot be empty")
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/admin/run/"+url.PathEscape(runDomain), bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/x-ndjson")
+	bearer, err := c.bearerToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if bearer != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+bearer)
+	}
+
+	// Like log streams, tasks must not be cut off by the client timeout.
+	streamClient := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := streamClient.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return 0, parseErrorResponse(resp, errBody)
+	}
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var frame dto.TaskOutputFrame
+		if err := decoder.Decode(&frame); err != nil {
+			if errors.Is(err, io.EOF) {
+				return 0, fmt.Errorf("task output ended without an exit code")
+			}
+			return 0, fmt.Errorf("failed to read task output: %w", err)
+		}
+		switch {
+		case frame.Error != "":
+			return 0, errors.New(frame.Error)
+		case frame.ExitCode != nil:
+			return *frame.ExitCode, nil
+		case frame.Stream == dto.TaskStreamStderr:
+			_, err = stderr.Write(frame.Data)
+		default:
+			_, err = stdout.Write(frame.Data)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Jobs API
+
+// ListJobs returns the scheduled jobs, only those of jobDomain when it is
+// not empty.
+func (c *Client) ListJobs(ctx context.Context, jobDomain string) ([]dto.Job, error) {
+	path := "/jobs"
+	if jobDomain != "" {
+		path += "?domain=" + url.QueryEscape(jobDomain)
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.JobsResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Jobs, nil
+}
+
+// RunJob starts a run of a scheduled job and returns it as first recorded.
+func (c *Client) RunJob(ctx context.Context, jobDomain, name string) (*dto.JobRun, error) {
+	if jobDomain == "" || name == "" {
+		return nil, fmt.Errorf("domain and job name cannot be empty")
+	}
+
+	resp, err := c.request(ctx, http.MethodPost, jobPath(jobDomain, name)+"/run", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.JobRunResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Run, nil
+}
+
+// ListJobRuns returns the recorded runs of a scheduled job, newest first.
+func (c *Client) ListJobRuns(ctx context.Context, jobDomain, name string) ([]dto.JobRun, error) {
+	if jobDomain == "" || name == "" {
+		return nil, fmt.Errorf("domain and job name cannot be empty")
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, jobPath(jobDomain, name)+"/runs", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.JobRunsResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Runs, nil
+}
+
+// GetJobRun returns one recorded run of a scheduled job.
+func (c *Client) GetJobRun(ctx context.Context, jobDomain, name, runID string) (*dto.JobRun, error) {
+	if jobDomain == "" || name == "" || runID == "" {
+		return nil, fmt.Errorf("domain, job name and run ID cannot be empty")
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, jobPath(jobDomain, name)+"/runs/"+url.PathEscape(runID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.JobRunResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result.Run, nil
+}
+
+func jobPath(jobDomain, name string) string {
+	return "/jobs/" + url.PathEscape(jobDomain) + "/" + url.PathEscape(name)
+}
+
+// Stats API
+
+// ListStats returns the recent resource usage of every running route,
+// attachment and service container. When name is a route domain or service
+// name, only its containers are returned, with their sample history.
+func (c *Client) ListStats(ctx context.Context, name string) ([]dto.ContainerStats, error) {
+	path := "/stats"
+	if name != "" {
+		path += "/" + url.PathEscape(name)
+	}
+
+	resp, err := c.request(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.StatsResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Containers, nil
+}
+
+// Exec API
+
+// Exec opens an interactive session running req.Command in a route's
+// container, or in the named attachment when attachment is not empty.
+// The caller must close the returned session.
+func (c *Client) Exec(ctx context.Context, execDomain, attachment string, req domain.ExecRequest) (*ExecSession, error) {
+	if execDomain == "" {
+		return nil, fmt.Errorf("domain cannot be empty")
+	}
+
+	query := url.Values{"cmd": req.Command}
+	if attachment != "" {
+		query.Set("attachment", attachment)
+	}
+	if req.TTY {
+		query.Set("tty", "true")
+		if req.Rows > 0 && req.Cols > 0 {
+			query.Set("rows", fmt.Sprint(req.Rows))
+			query.Set("cols", fmt.Sprint(req.Cols))
+		}
+	}
+
+	wsURL := "ws" + strings.TrimPrefix(c.baseURL, "http") + "/admin/exec/" + url.PathEscape(execDomain) + "?" + query.Encode()
+	config, err := websocket.NewConfig(wsURL, c.baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	bearer, err := c.bearerToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if bearer != "" {
+		config.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+		config.TlsConfig = transport.TLSClientConfig.Clone()
+	}
+
+	ws, err := config.DialContext(ctx)
+	if err != nil {
+		if errors.Is(err, websocket.ErrBadStatus) {
+			return nil, fmt.Errorf("server refused the exec session; check that the token has admin:exec:write and that %s is running", execDomain)
+		}
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return &ExecSession{ws: ws}, nil
+}
+
+// ExecSession is an interactive session opened with Client.Exec. Writes are
+// sent to the command's stdin.
+type ExecSession struct {
+	ws *websocket.Conn
+}
+
+// Write sends p to the command's stdin.
+func (s *ExecSession) Write(p []byte) (int, error) {
+	if err := websocket.JSON.Send(s.ws, dto.ExecFrame{Type: dto.ExecFrameStdin, Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// CloseStdin tells the command its input has ended.
+func (s *ExecSession) CloseStdin() error {
+	return websocket.JSON.Send(s.ws, dto.ExecFrame{Type: dto.ExecFrameEOF})
+}
+
+// Resize sets the terminal size of a TTY session.
+func (s *ExecSession) Resize(_ context.Context, rows, cols uint) error {
+	return websocket.JSON.Send(s.ws, dto.ExecFrame{Type: dto.ExecFrameResize, Rows: rows, Cols: cols})
+}
+
+// Signal asks the server to deliver sig (e.g. "INT") to a TTY session.
+func (s *ExecSession) Signal(sig string) error {
+	return websocket.JSON.Send(s.ws, dto.ExecFrame{Type: dto.ExecFrameSignal, Signal: sig})
+}
+
+// Wait copies the command's output to stdout and stderr until it exits and
+// returns its exit code.
+func (s *ExecSession) Wait(ctx context.Context, stdout, stderr io.Writer) (int, error) {
+	stop := context.AfterFunc(ctx, func() { _ = s.ws.Close() })
+	defer stop()
+
+	for {
+		var frame dto.ExecFrame
+		if err := websocket.JSON.Receive(s.ws, &frame); err != nil {
+			if ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return 0, fmt.Errorf("exec session ended without an exit code")
+			}
+			return 0, fmt.Errorf("failed to read exec output: %w", err)
+		}
+		var err error
+		switch frame.Type {
+		case dto.ExecFrameError:
+			return 0, errors.New(frame.Error)
+		case dto.ExecFrameExit:
+			if frame.ExitCode == nil {
+				return 0, fmt.Errorf("exec session ended without an exit code")
+			}
+			return *frame.ExitCode, nil
+		case dto.ExecFrameStderr:
+			_, err = stderr.Write(frame.Data)
+		case dto.ExecFrameStdout:
+			_, err = stdout.Write(frame.Data)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Close ends the session.
+func (s *ExecSession) Close() error {
+	return s.ws.Close()
+}
+
+// PurgeCache removes the cached proxy responses of a domain, or only those
+// for path when it is not empty.
+func (c *Client) PurgeCache(ctx context.Context, cacheDomain, path string) (*dto.CachePurgeResponse, error) {
+	if cacheDomain == "" {
+		return nil, fmt.Errorf("domain cannot be empty")
+	}
+	reqPath := "/cache/purge/" + url.PathEscape(cacheDomain)
+	if path != "" {
+		reqPath += "?" + url.Values{"path": {path}}.Encode()
+	}
+	resp, err := c.request(ctx, http.MethodPost, reqPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result dto.CachePurgeResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // Tags API
 
 // ListTags returns available tags for a repository.
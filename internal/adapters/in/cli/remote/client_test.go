@@ -16,6 +16,7 @@ import (
 	"github.com/bnema/gordon/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
 )
@@ -134,6 +135,196 @@ func TestClientGetTrafficStatus(t *testing.T) {
 	assert.Equal(t, int64(1), status.Counters.ActiveTCPConnections)
 }
 
+func TestClientPurgeCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/admin/cache/purge/app.example.com", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/assets/app.js", r.URL.Query().Get("path"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"domain":"app.example.com","path":"/assets/app.js","purged":2}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	result, err := client.PurgeCache(context.Background(), "app.example.com", "/assets/app.js")
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 2, result.Purged)
+}
+
+func TestClientGetUpstreamStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/admin/routes/app.example.com/upstream", r.URL.Path)
+		require.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"domain":"app.example.com","state":"open","consecutive_failures":5,"threshold":5,"cooldown":"30s"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	status, err := client.GetUpstreamStatus(context.Background(), "app.example.com")
+	require.NoError(t, err)
+	require.NotNil(t, status)
+	assert.Equal(t, "open", status.State)
+	assert.Equal(t, 5, status.ConsecutiveFailures)
+}
+
+func TestClientListProcesses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/admin/routes/app.example.com/processes", r.URL.Path)
+		require.Equal(t, http.MethodGet, r.Method)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"processes":[{"name":"worker","command":["bundle","exec","sidekiq"],"container_id":"worker-1","status":"running"}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	processes, err := client.ListProcesses(context.Background(), "app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, []dto.Process{
+		{Name: "worker", Command: []string{"bundle", "exec", "sidekiq"}, ContainerID: "worker-1", Status: "running"},
+	}, processes)
+}
+
+func TestClientRunTask(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/admin/run/app.example.com", r.URL.Path)
+		require.Equal(t, http.MethodPost, r.Method)
+		var req dto.RunTaskRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Equal(t, []string{"./manage", "migrate"}, req.Command)
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(`{"stream":"stdout","data":"b2sK"}` + "\n" + `{"stream":"stderr","data":"b29wcwo="}` + "\n" + `{"exit_code":4}` + "\n"))
+	}))
+	defer srv.Close()
+
+	var stdout, stderr strings.Builder
+	client := NewClient(srv.URL)
+	exitCode, err := client.RunTask(context.Background(), "app.example.com", dto.RunTaskRequest{Command: []string{"./manage", "migrate"}}, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, 4, exitCode)
+	assert.Equal(t, "ok\n", stdout.String())
+	assert.Equal(t, "oops\n", stderr.String())
+}
+
+func TestClientRunTask_StreamWithoutExitCodeFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"stream":"stdout","data":"b2sK"}` + "\n"))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	_, err := client.RunTask(context.Background(), "app.example.com", dto.RunTaskRequest{Command: []string{"true"}}, io.Discard, io.Discard)
+	assert.ErrorContains(t, err, "without an exit code")
+}
+
+func TestClientJobs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/jobs":
+			assert.Equal(t, "app.example.com", r.URL.Query().Get("domain"))
+			_, _ = w.Write([]byte(`{"jobs":[{"route":"app.example.com","name":"cleanup","schedule":"*/15 * * * *"}]}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/admin/jobs/app.example.com/cleanup/run":
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte(`{"run":{"id":"run-1","status":"running"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/admin/jobs/app.example.com/cleanup/runs/run-1":
+			_, _ = w.Write([]byte(`{"run":{"id":"run-1","status":"succeeded","output":"done\n"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	ctx := context.Background()
+
+	jobs, err := client.ListJobs(ctx, "app.example.com")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "cleanup", jobs[0].Name)
+
+	run, err := client.RunJob(ctx, "app.example.com", "cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, "run-1", run.ID)
+	assert.False(t, run.Finished())
+
+	run, err = client.GetJobRun(ctx, "app.example.com", "cleanup", "run-1")
+	require.NoError(t, err)
+	assert.True(t, run.Finished())
+	assert.Equal(t, "done\n", run.Output)
+}
+
+func TestClientListStats(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/admin/stats":
+			_, _ = w.Write([]byte(`{"containers":[{"kind":"route","name":"app.example.com","latest":{"cpu_percent":12.5}}]}`))
+		case "/admin/stats/app.example.com":
+			_, _ = w.Write([]byte(`{"containers":[{"kind":"route","name":"app.example.com","history":[{"cpu_percent":10},{"cpu_percent":12.5}]}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	ctx := context.Background()
+
+	stats, err := client.ListStats(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.InDelta(t, 12.5, stats[0].Latest.CPUPercent, 0.001)
+
+	stats, err = client.ListStats(ctx, "app.example.com")
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Len(t, stats[0].History, 2)
+}
+
+func TestClientExec(t *testing.T) {
+	srv := httptest.NewServer(websocket.Server{
+		Handler: func(ws *websocket.Conn) {
+			r := ws.Request()
+			assert.Equal(t, "/admin/exec/app.example.com", r.URL.Path)
+			assert.Equal(t, []string{"psql", "-U", "app"}, r.URL.Query()["cmd"])
+			assert.Equal(t, "postgres", r.URL.Query().Get("attachment"))
+			assert.Equal(t, "true", r.URL.Query().Get("tty"))
+			assert.Equal(t, "24", r.URL.Query().Get("rows"))
+
+			var input, signal dto.ExecFrame
+			require.NoError(t, websocket.JSON.Receive(ws, &input))
+			assert.Equal(t, dto.ExecFrame{Type: dto.ExecFrameStdin, Data: []byte("\\q\n")}, input)
+			require.NoError(t, websocket.JSON.Receive(ws, &signal))
+			assert.Equal(t, dto.ExecFrame{Type: dto.ExecFrameSignal, Signal: "INT"}, signal)
+
+			exitCode := 1
+			_ = websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameStdout, Data: []byte("bye\n")})
+			_ = websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameExit, ExitCode: &exitCode})
+		},
+	})
+	defer srv.Close()
+
+	client := NewClient(srv.URL)
+	session, err := client.Exec(context.Background(), "app.example.com", "postgres",
+		domain.ExecRequest{Command: []string{"psql", "-U", "app"}, TTY: true, Rows: 24, Cols: 80})
+	require.NoError(t, err)
+	defer session.Close()
+
+	_, err = session.Write([]byte("\\q\n"))
+	require.NoError(t, err)
+	require.NoError(t, session.Signal("INT"))
+
+	var stdout strings.Builder
+	exitCode, err := session.Wait(context.Background(), &stdout, io.Discard)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exitCode)
+	assert.Equal(t, "bye\n", stdout.String())
+}
+
 func TestClientGetTLSStatus(t *testing.T) {
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		require.Equal(t, "/admin/tls/status", r.URL.Path)
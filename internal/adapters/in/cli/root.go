@@ -109,6 +109,18 @@ Commands are organized by where they run:
 	restartCmd.GroupID = groupManage
 	rootCmd.AddCommand(restartCmd)
 
+	runCmd := newRunCmd()
+	runCmd.GroupID = groupManage
+	rootCmd.AddCommand(runCmd)
+
+	execCmd := newExecCmd()
+	execCmd.GroupID = groupManage
+	rootCmd.AddCommand(execCmd)
+
+	jobsCmd := newJobsCmd()
+	jobsCmd.GroupID = groupManage
+	rootCmd.AddCommand(jobsCmd)
+
 	pushCmd := newPushCmd()
 	pushCmd.GroupID = groupManage
 	rootCmd.AddCommand(pushCmd)
@@ -129,6 +141,10 @@ Commands are organized by where they run:
 	statusCmd.GroupID = groupManage
 	rootCmd.AddCommand(statusCmd)
 
+	topCmd := newTopCmd()
+	topCmd.GroupID = groupManage
+	rootCmd.AddCommand(topCmd)
+
 	backupCmd := newBackupCmd()
 	backupCmd.GroupID = groupManage
 	rootCmd.AddCommand(backupCmd)
@@ -141,6 +157,10 @@ Commands are organized by where they run:
 	bootstrapCmd.GroupID = groupManage
 	rootCmd.AddCommand(bootstrapCmd)
 
+	importCmd := newImportCmd()
+	importCmd.GroupID = groupManage
+	rootCmd.AddCommand(importCmd)
+
 	configCmd := newConfigCmd()
 	configCmd.GroupID = groupManage
 	rootCmd.AddCommand(configCmd)
@@ -169,6 +189,10 @@ Commands are organized by where they run:
 	trafficCmd.GroupID = groupManage
 	rootCmd.AddCommand(trafficCmd)
 
+	cacheCmd := newCacheCmd()
+	cacheCmd.GroupID = groupManage
+	rootCmd.AddCommand(cacheCmd)
+
 	// Client-only commands (no server needed)
 	remotesCmd := newRemotesCmd()
 	remotesCmd.GroupID = groupClient
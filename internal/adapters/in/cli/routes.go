@@ -132,6 +132,7 @@ type routeDiagnosis struct {
 	Route               *domain.Route                  `json:"route,omitempty"`
 	Runtime             *remote.RouteInfo              `json:"runtime,omitempty"`
 	Health              *remote.RouteHealth            `json:"health,omitempty"`
+	Upstream            *dto.UpstreamStatusResponse    `json:"upstream,omitempty"`
 	Volumes             []dto.Volume                   `json:"volumes,omitempty"`
 	OrphanedAttachments []domain.CleanupAttachment     `json:"orphaned_attachments,omitempty"`
 	OrphanedEntities    []domain.CleanupOrphanedEntity `json:"orphaned_entities,omitempty"`
@@ -1044,6 +1045,8 @@ func runRoutesShow(ctx context.Context, cp ControlPlane, out io.Writer, routeDom
 		}
 	}
 
+	processes := routeShowProcesses(ctx, cp, route)
+
 	if jsonOut {
 		payload := map[string]any{
 			"domain":           route.Domain,
@@ -1054,10 +1057,65 @@ func runRoutesShow(ctx context.Context, cp ControlPlane, out io.Writer, routeDom
 		if healthErr != "" {
 			payload["health_error"] = healthErr
 		}
+		if len(processes) > 0 {
+			payload["processes"] = processes
+		}
 		return writeJSON(out, payload)
 	}
 
-	return writeRouteShowText(out, route.Domain, route.Image, containerStatus, httpStatus, healthErr)
+	if err := writeRouteShowText(out, route.Domain, route.Image, containerStatus, httpStatus, healthErr); err != nil {
+		return err
+	}
+	return writeRouteShowProcesses(out, processes)
+}
+
+// routeShowProcesses returns the processes of route with their containers.
+// When they cannot be listed, the declared processes are returned without
+// a status.
+func routeShowProcesses(ctx context.Context, cp ControlPlane, route *domain.Route) []dto.Process {
+	if len(route.Processes) == 0 {
+		return nil
+	}
+	processes, err := cp.ListProcesses(ctx, route.Domain)
+	if err == nil {
+		return processes
+	}
+	names := make([]string, 0, len(route.Processes))
+	for name := range route.Processes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	processes = make([]dto.Process, 0, len(names))
+	for _, name := range names {
+		processes = append(processes, dto.Process{Name: name, Command: route.Processes[name]})
+	}
+	return processes
+}
+
+func writeRouteShowProcesses(out io.Writer, processes []dto.Process) error {
+	if len(processes) == 0 {
+		return nil
+	}
+	if err := cliWriteLine(out, ""); err != nil {
+		return err
+	}
+	if err := cliWriteLine(out, "Processes:"); err != nil {
+		return err
+	}
+	for _, process := range processes {
+		status := process.Status
+		switch {
+		case process.Command == nil:
+			status += " (no longer declared)"
+		case status == "":
+			status = "not running"
+		}
+		line := fmt.Sprintf("%-12s %-12s %s", process.Name, status, strings.Join(process.Command, " "))
+		if err := cliWriteLine(out, cliRenderListItem(strings.TrimRight(line, " "))); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func writeRouteShowText(out io.Writer, domainName, image, containerStatus string, httpStatus int, healthErr string) error {
@@ -1127,6 +1185,7 @@ func buildRouteDiagnosis(ctx context.Context, cp ControlPlane, domainName string
 	loadRouteDiagnosisCleanupPreview(ctx, cp, domainName, diag)
 	loadRouteDiagnosisRuntime(ctx, cp, domainName, diag)
 	loadRouteDiagnosisHealth(ctx, cp, domainName, diag)
+	loadRouteDiagnosisUpstream(ctx, cp, domainName, diag)
 	loadRouteDiagnosisOrphanedAttachments(ctx, cp, domainName, diag)
 	loadRouteDiagnosisVolumes(ctx, cp, domainName, diag)
 	finalizeRouteDiagnosis(diag)
@@ -1199,6 +1258,21 @@ func loadRouteDiagnosisHealth(ctx context.Context, cp ControlPlane, domainName s
 	}
 }
 
+func loadRouteDiagnosisUpstream(ctx context.Context, cp ControlPlane, domainName string, diag *routeDiagnosis) {
+	if !diag.Configured {
+		return
+	}
+	status, err := cp.GetUpstreamStatus(ctx, domainName)
+	if err != nil {
+		if errors.Is(err, domain.ErrUpstreamStatusUnavailable) || isRemoteNotFoundError(err) {
+			return
+		}
+		diag.Warnings = append(diag.Warnings, "failed to load upstream circuit breaker: "+err.Error())
+		return
+	}
+	diag.Upstream = status
+}
+
 func loadRouteDiagnosisVolumes(ctx context.Context, cp ControlPlane, domainName string, diag *routeDiagnosis) {
 	if len(diag.Volumes) > 0 {
 		return
@@ -1248,6 +1322,9 @@ func finalizeRouteDiagnosis(diag *routeDiagnosis) {
 	if !diag.Configured && (diag.Runtime != nil || hasRouteCleanupEntity(diag.OrphanedEntities, "route_container")) {
 		diag.Warnings = append(diag.Warnings, "route is not configured but runtime state still exists")
 	}
+	if diag.Upstream != nil && diag.Upstream.State == domain.CircuitOpen {
+		diag.Warnings = append(diag.Warnings, fmt.Sprintf("upstream circuit is open after %d consecutive errors; requests fail fast with 503 for %s before a trial request", diag.Upstream.ConsecutiveFailures, diag.Upstream.Cooldown))
+	}
 	if len(diag.Volumes) > 0 {
 		diag.Hints = append(diag.Hints, "persistent volumes are preserved by default")
 	}
@@ -1368,6 +1445,11 @@ func writeRouteDiagnosisSummary(out io.Writer, diag *routeDiagnosis) error {
 	} else if err := cliWriteLine(out, cliRenderWarning("Route is not configured")); err != nil {
 		return err
 	}
+	if diag.Upstream != nil && diag.Upstream.State != domain.CircuitDisabled {
+		if err := cliWriteLine(out, cliRenderMeta("Circuit breaker:", formatUpstreamBreaker(diag.Upstream))); err != nil {
+			return err
+		}
+	}
 	if diag.Runtime == nil {
 		return nil
 	}
@@ -1395,6 +1477,16 @@ func writeRouteDiagnosisSummary(out io.Writer, diag *routeDiagnosis) error {
 	return nil
 }
 
+// formatUpstreamBreaker renders a circuit breaker state such as
+// "open (5/5 consecutive errors, last: connection refused)".
+func formatUpstreamBreaker(status *dto.UpstreamStatusResponse) string {
+	text := fmt.Sprintf("%s (%d/%d consecutive errors", status.State, status.ConsecutiveFailures, status.Threshold)
+	if status.LastError != "" && status.ConsecutiveFailures > 0 {
+		text += ", last: " + status.LastError
+	}
+	return text + ")"
+}
+
 func writeRouteDiagnosisLeftovers(out io.Writer, diag *routeDiagnosis) error {
 	for _, entity := range diag.OrphanedEntities {
 		label := entity.Name
@@ -1827,7 +1919,7 @@ func newStatusCmd() *cobra.Command {
 				fmt.Println(styles.Theme.Bold.Render("Container Status:"))
 				for domain, containerStatus := range status.ContainerStatus {
 					badge := components.ContainerStatusBadge(containerStatus)
-					fmt.Printf("  %s: %s\n", domain, badge)
+					fmt.Printf("  %s: %s %s\n", domain, badge, styles.Theme.Muted.Render(containerStatus))
 				}
 			}
 
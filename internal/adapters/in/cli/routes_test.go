@@ -327,6 +327,54 @@ func TestRunRoutesShow_TextIncludesHealthWarning(t *testing.T) {
 	assert.Contains(t, text, "probe failed")
 }
 
+func TestRunRoutesShow_ListsProcesses(t *testing.T) {
+	route := &domain.Route{
+		Domain: "app.example.com",
+		Image:  "app:latest",
+		Processes: map[string][]string{
+			"worker": {"bundle", "exec", "sidekiq"},
+			"clock":  {"bundle", "exec", "clockwork"},
+		},
+	}
+	cpMock := climocks.NewMockControlPlane(t)
+	cpMock.EXPECT().GetRoute(context.Background(), "app.example.com").Return(route, nil).Twice()
+	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Twice()
+	cpMock.EXPECT().ListProcesses(context.Background(), "app.example.com").Return([]dto.Process{
+		{Name: "clock", Command: []string{"bundle", "exec", "clockwork"}},
+		{Name: "worker", Command: []string{"bundle", "exec", "sidekiq"}, ContainerID: "worker-1", Status: "running"},
+		{Name: "mailer", ContainerID: "mailer-1", Status: "running"},
+	}, nil).Twice()
+
+	var out bytes.Buffer
+	require.NoError(t, runRoutesShow(context.Background(), cpMock, &out, "app.example.com", false))
+	text := stripANSI(out.String())
+	assert.Contains(t, text, "Processes:")
+	assert.Regexp(t, `clock\s+not running\s+bundle exec clockwork`, text)
+	assert.Regexp(t, `worker\s+running\s+bundle exec sidekiq`, text)
+	assert.Contains(t, text, "running (no longer declared)")
+
+	out.Reset()
+	require.NoError(t, runRoutesShow(context.Background(), cpMock, &out, "app.example.com", true))
+	var got struct {
+		Processes []dto.Process `json:"processes"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got.Processes, 3)
+	assert.Equal(t, "worker-1", got.Processes[1].ContainerID)
+}
+
+func TestRunRoutesShow_ListsDeclaredProcessesWhenListingFails(t *testing.T) {
+	route := &domain.Route{Domain: "app.example.com", Image: "app:latest", Processes: map[string][]string{"worker": {"rake", "jobs:work"}}}
+	cpMock := climocks.NewMockControlPlane(t)
+	cpMock.EXPECT().GetRoute(context.Background(), "app.example.com").Return(route, nil).Once()
+	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().ListProcesses(context.Background(), "app.example.com").Return(nil, errors.New("unavailable")).Once()
+
+	var out bytes.Buffer
+	require.NoError(t, runRoutesShow(context.Background(), cpMock, &out, "app.example.com", false))
+	assert.Regexp(t, `worker\s+not running\s+rake jobs:work`, stripANSI(out.String()))
+}
+
 func TestRunRoutesShow_JSONIncludesRouteProbeFailureAndUnknownContainerStatus(t *testing.T) {
 	cpMock := climocks.NewMockControlPlane(t)
 	cpMock.EXPECT().GetRoute(context.Background(), "app.example.com").Return(&domain.Route{Domain: "app.example.com", Image: "app:latest"}, nil).Once()
@@ -934,6 +982,7 @@ func TestBuildRouteDiagnosis_VolumesAreScopedToExactRouteContainers(t *testing.T
 		ContainerStatus: "running",
 	}}, nil).Once()
 	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetUpstreamStatus(context.Background(), "example.test").Return(nil, domain.ErrUpstreamStatusUnavailable).Once()
 	cpMock.EXPECT().ListVolumes(context.Background()).Return([]dto.Volume{
 		{Name: "gordon-example-test-data-docs", Containers: []string{"gordon-example.test"}},
 		{Name: "gordon-api-example-test-cache", Containers: []string{"gordon-api.example.test"}},
@@ -997,6 +1046,7 @@ func TestBuildRouteDiagnosis_IncludesAttachmentVolumesWithDoubleUnderscoreOwnerE
 		}},
 	}}, nil).Once()
 	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetUpstreamStatus(context.Background(), "app.example.test").Return(nil, domain.ErrUpstreamStatusUnavailable).Once()
 	cpMock.EXPECT().ListVolumes(context.Background()).Return([]dto.Volume{
 		{Name: "gordon-gordon-app__example__test-postgres-var-lib-postgresql", Containers: []string{"gordon-app__example__test-postgres"}},
 	}, nil).Once()
@@ -1119,6 +1169,7 @@ func TestBuildRouteDiagnosis_UsesRouteScopedReviewHintForOrphanedAttachments(t *
 		ContainerStatus: "running",
 	}}, nil).Once()
 	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetUpstreamStatus(context.Background(), "app.example.test").Return(nil, domain.ErrUpstreamStatusUnavailable).Once()
 	cpMock.EXPECT().ListVolumes(context.Background()).Return(nil, nil).Once()
 	cpMock.EXPECT().ListOrphanedAttachments(context.Background()).Return([]domain.CleanupAttachment{{
 		Name:        "old-postgres",
@@ -1132,6 +1183,31 @@ func TestBuildRouteDiagnosis_UsesRouteScopedReviewHintForOrphanedAttachments(t *
 	assert.Contains(t, diag.Hints, "run 'gordon routes purge app.example.test --attachments' to review orphaned attachment cleanup for this route")
 }
 
+func TestBuildRouteDiagnosis_ReportsOpenUpstreamCircuit(t *testing.T) {
+	cpMock := climocks.NewMockControlPlane(t)
+	cpMock.EXPECT().GetRoute(context.Background(), "app.example.test").Return(&domain.Route{Domain: "app.example.test", Image: "app:latest"}, nil).Once()
+	cpMock.EXPECT().ListRoutesWithDetails(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetUpstreamStatus(context.Background(), "app.example.test").Return(&dto.UpstreamStatusResponse{
+		Domain:              "app.example.test",
+		State:               domain.CircuitOpen,
+		ConsecutiveFailures: 5,
+		Threshold:           5,
+		Cooldown:            "30s",
+		LastError:           "dial tcp 10.0.0.5:8080: connect: connection refused",
+	}, nil).Once()
+	cpMock.EXPECT().ListVolumes(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().ListOrphanedAttachments(context.Background()).Return(nil, nil).Once()
+
+	diag, err := buildRouteDiagnosis(context.Background(), cpMock, "app.example.test")
+	require.NoError(t, err)
+	assert.Contains(t, diag.Warnings, "upstream circuit is open after 5 consecutive errors; requests fail fast with 503 for 30s before a trial request")
+
+	var out bytes.Buffer
+	require.NoError(t, writeRouteDiagnosisText(&out, diag))
+	assert.Contains(t, stripANSI(out.String()), "Circuit breaker: open (5/5 consecutive errors, last: dial tcp 10.0.0.5:8080: connect: connection refused)")
+}
+
 func TestWriteRouteDiagnosisText_UsesNeutralVolumeLabelForConfiguredRoutes(t *testing.T) {
 	var out bytes.Buffer
 	diag := &routeDiagnosis{
@@ -1194,6 +1270,7 @@ func TestRunRoutePurge_AttachmentsForceIsScopedToRoute(t *testing.T) {
 	cpMock.EXPECT().GetRoute(context.Background(), "app.example.com").Return(&domain.Route{Domain: "app.example.com", Image: "app:latest"}, nil).Once()
 	cpMock.EXPECT().ListRoutesWithDetails(context.Background()).Return(nil, nil).Once()
 	cpMock.EXPECT().GetHealth(context.Background()).Return(nil, nil).Once()
+	cpMock.EXPECT().GetUpstreamStatus(context.Background(), "app.example.com").Return(nil, domain.ErrUpstreamStatusUnavailable).Once()
 	cpMock.EXPECT().ListVolumes(context.Background()).Return(nil, nil).Once()
 	cpMock.EXPECT().ListOrphanedAttachments(context.Background()).Return(orphanedAttachments, nil).Once()
 	cpMock.EXPECT().CleanupOrphanedAttachments(context.Background(), "app.example.com", true).Return(&domain.CleanupReport{
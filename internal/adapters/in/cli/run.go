@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+type taskRunner interface {
+	RunTask(ctx context.Context, routeDomain string, task domain.TaskRequest, stdout, stderr io.Writer) (int, error)
+}
+
+// ExitError carries the non-zero exit code of a command run with gordon run,
+// so the CLI can exit with the same code.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("command exited with code %d", e.Code)
+}
+
+// newRunCmd creates the run command.
+func newRunCmd() *cobra.Command {
+	var tty bool
+	cmd := &cobra.Command{
+		Use:   "run <domain> -- <command> [args...]",
+		Short: "Run a one-off command with a route's image",
+		Long: `Runs a command in an ephemeral container next to the route's deployed
+container. The container uses the exact image the route runs, with its
+environment, secrets, volumes and network, and is removed once the
+command exits.
+
+Output is streamed as the command runs and gordon exits with the command's
+exit code. Input is not forwarded, so interactive programs must read from
+somewhere other than stdin. Use --tty for programs that only produce
+output on a terminal; stdout and stderr are then merged.
+
+Examples:
+  gordon run myapp.example.com -- ./manage migrate
+  gordon run myapp.example.com -- sh -c 'echo $DATABASE_URL | cut -d@ -f2'
+  gordon run --remote prod --tty myapp.example.com -- top -b -n 1`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			handle, err := resolveControlPlaneForRouteDomain(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			err = runTask(cmd.Context(), handle.plane, args[0], domain.TaskRequest{Command: args[1:], TTY: tty}, cmd.OutOrStdout(), cmd.ErrOrStderr())
+			var exitErr *ExitError
+			if errors.As(err, &exitErr) {
+				// The command already reported its failure on stderr.
+				cmd.SilenceErrors = true
+				cmd.SilenceUsage = true
+			}
+			return err
+		},
+	}
+	// Everything after the domain belongs to the command, including flags.
+	cmd.Flags().SetInterspersed(false)
+	cmd.Flags().BoolVarP(&tty, "tty", "t", false, "Allocate a pseudo-terminal for the command")
+	return cmd
+}
+
+func runTask(ctx context.Context, runner taskRunner, routeDomain string, task domain.TaskRequest, stdout, stderr io.Writer) error {
+	exitCode, err := runner.RunTask(ctx, routeDomain, task, stdout, stderr)
+	if err != nil {
+		return fmt.Errorf("failed to run command: %w", err)
+	}
+	if exitCode != 0 {
+		return &ExitError{Code: exitCode}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestRunCommandPassesCommandFlagsThrough(t *testing.T) {
+	cmd := NewRootCmd()
+	run, args, err := cmd.Find([]string{"run", "--tty", "app.example.com", "ls", "-la"})
+	require.NoError(t, err)
+	require.NoError(t, run.ParseFlags(args))
+	assert.Equal(t, []string{"app.example.com", "ls", "-la"}, run.Flags().Args())
+	tty, err := run.Flags().GetBool("tty")
+	require.NoError(t, err)
+	assert.True(t, tty)
+	require.Error(t, run.Args(run, []string{"app.example.com"}))
+}
+
+func TestRunTaskPropagatesExitCode(t *testing.T) {
+	task := domain.TaskRequest{Command: []string{"./manage", "migrate"}}
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().RunTask(mock.Anything, "app.example.com", task, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, _ domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+			_, _ = io.WriteString(stdout, "migrating\n")
+			_, _ = io.WriteString(stderr, "relation exists\n")
+			return 3, nil
+		})
+
+	var stdout, stderr bytes.Buffer
+	err := runTask(context.Background(), cp, "app.example.com", task, &stdout, &stderr)
+	var exitErr *ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 3, exitErr.Code)
+	assert.Equal(t, "migrating\n", stdout.String())
+	assert.Equal(t, "relation exists\n", stderr.String())
+}
+
+func TestRunTaskSucceedsOnZeroExit(t *testing.T) {
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().RunTask(mock.Anything, "app.example.com", mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	require.NoError(t, runTask(context.Background(), cp, "app.example.com", domain.TaskRequest{Command: []string{"true"}}, io.Discard, io.Discard))
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
 )
 
 // tlsResolveControlPlane allows tests to override control-plane resolution.
@@ -132,6 +133,25 @@ func renderTLSCertificatesSection(out io.Writer, s *dto.TLSStatusResponse) error
 				return err
 			}
 		}
+		if cert.OCSPStatus != "" {
+			ocsp := cert.OCSPStatus
+			if !cert.OCSPNextUpdate.IsZero() {
+				ocsp += " (next update " + cert.OCSPNextUpdate.Format(time.DateTime) + ")"
+			}
+			if err := cliWriteLine(out, cliRenderMeta("  OCSP:", ocsp)); err != nil {
+				return err
+			}
+		}
+		if cert.RenewalFailures > 0 {
+			if err := cliWriteLine(out, cliRenderMeta("  Renewal Failures:", fmt.Sprintf("%d", cert.RenewalFailures))); err != nil {
+				return err
+			}
+		}
+		if cert.Alert != "" {
+			if err := cliWriteLine(out, cliRenderMeta("  Alert:", tlsAlertText(cert.Alert))); err != nil {
+				return err
+			}
+		}
 		if cert.LastError != "" {
 			if err := cliWriteLine(out, cliRenderMeta("  Last Error:", cert.LastError)); err != nil {
 				return err
@@ -142,6 +162,20 @@ func renderTLSCertificatesSection(out io.Writer, s *dto.TLSStatusResponse) error
 	return nil
 }
 
+// tlsAlertText describes a certificate alert reason for humans.
+func tlsAlertText(alert string) string {
+	switch domain.TLSCertificateAlert(alert) {
+	case domain.TLSCertificateAlertExpiring:
+		return "expires soon"
+	case domain.TLSCertificateAlertRenewalFailing:
+		return "renewal keeps failing"
+	case domain.TLSCertificateAlertRevoked:
+		return "revoked by the CA"
+	default:
+		return alert
+	}
+}
+
 func renderTLSRouteCoverageSection(out io.Writer, s *dto.TLSStatusResponse) error {
 	if len(s.Routes) == 0 {
 		return nil
@@ -126,3 +126,33 @@ func TestTLSStatus_JSONOutput(t *testing.T) {
 	assert.Equal(t, "valid", result.Certificates[0].Status)
 	assert.Equal(t, notAfter.Format(time.RFC3339), result.Certificates[0].NotAfter.Format(time.RFC3339))
 }
+
+func TestTLSStatus_HumanOutputAlert(t *testing.T) {
+	nextUpdate := time.Date(2026, 5, 2, 12, 0, 0, 0, time.UTC)
+
+	cpMock := climocks.NewMockControlPlane(t)
+	cpMock.EXPECT().GetTLSStatus(context.Background()).Return(&dto.TLSStatusResponse{
+		ACMEEnabled: true,
+		Certificates: []dto.TLSCertificateEntry{
+			{
+				ID:              "cert-abc123",
+				Names:           []string{"example.com"},
+				Status:          "error",
+				NotAfter:        time.Date(2026, 5, 10, 12, 0, 0, 0, time.UTC),
+				LastError:       "renew: rate limited",
+				RenewalFailures: 4,
+				Alert:           "renewal_failing",
+				OCSPStatus:      "good",
+				OCSPNextUpdate:  nextUpdate,
+			},
+		},
+	}, nil).Once()
+
+	var buf bytes.Buffer
+	require.NoError(t, runTLSStatusCmd(context.Background(), cpMock, &buf, false))
+
+	output := buf.String()
+	assert.Contains(t, output, "good (next update "+nextUpdate.Format(time.DateTime)+")")
+	assert.Contains(t, output, "Renewal Failures:")
+	assert.Contains(t, output, "renewal keeps failing")
+}
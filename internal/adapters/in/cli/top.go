@@ -0,0 +1,254 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/adapters/in/cli/ui/components"
+	"github.com/bnema/gordon/internal/adapters/in/cli/ui/styles"
+	"github.com/bnema/gordon/pkg/bytesize"
+)
+
+// topRefreshInterval is how often gordon top asks for new samples. The
+// server samples on its own interval, so faster refreshes only repeat rows.
+var topRefreshInterval = 2 * time.Second
+
+// topLocalWarmup bounds how long a local snapshot waits for samples.
+var topLocalWarmup = 30 * time.Second
+
+var topTableColumns = []components.TableColumn{
+	{Title: "NAME", Width: 32},
+	{Title: "KIND", Width: 10},
+	{Title: "CPU", Width: 8},
+	{Title: "MEMORY", Width: 22},
+	{Title: "NET RX/TX", Width: 22},
+	{Title: "BLOCK R/W", Width: 22},
+	{Title: "PIDS", Width: 6},
+}
+
+func newTopCmd() *cobra.Command {
+	var (
+		jsonOut bool
+		once    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "top [domain|service]",
+		Short: "Show live resource usage of containers",
+		Long: `Show the CPU, memory, network and block I/O usage of route, attachment
+and service containers, refreshed live until you press q.
+
+With a route domain, only the route's container and its attachments are
+shown; with a service name, only that service. Output that is not a
+terminal, --once and --json print a single snapshot instead.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := ""
+			if len(args) == 1 {
+				name = args[0]
+			}
+			handle, err := backupResolveControlPlane(cmd.Context(), configPath, name)
+			if err != nil {
+				return err
+			}
+			defer handle.close()
+
+			out := cmd.OutOrStdout()
+			if jsonOut || once || !isInteractiveTerminal() {
+				stats, err := topSnapshot(cmd.Context(), handle, name)
+				if err != nil {
+					return fmt.Errorf("failed to get resource usage: %w", err)
+				}
+				if jsonOut {
+					return writeJSON(out, stats)
+				}
+				return printTop(out, stats)
+			}
+
+			model := newTopModel(cmd.Context(), handle.plane, name)
+			_, err = tea.NewProgram(model, tea.WithContext(cmd.Context()), tea.WithAltScreen()).Run()
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&jsonOut, "json", false, "Output as JSON")
+	cmd.Flags().BoolVar(&once, "once", false, "Print a single snapshot and exit")
+
+	return cmd
+}
+
+// topSnapshot returns the current samples. Without a remote, the CLI has
+// just started its own sampler, so it waits for the first samples to come in.
+func topSnapshot(ctx context.Context, handle *controlPlaneHandle, name string) ([]dto.ContainerStats, error) {
+	deadline := time.Now().Add(topLocalWarmup)
+	for {
+		stats, err := handle.plane.ListStats(ctx, name)
+		if err != nil || len(stats) > 0 || handle.isRemote || time.Now().After(deadline) {
+			return stats, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(topRefreshInterval):
+		}
+	}
+}
+
+func printTop(out io.Writer, stats []dto.ContainerStats) error {
+	if len(stats) == 0 {
+		return cliWriteLine(out, cliRenderEmptyState("No resource samples yet. Containers appear after two samples."))
+	}
+	return cliWriteLine(out, renderTopTable(stats))
+}
+
+func renderTopTable(stats []dto.ContainerStats) string {
+	table := components.NewTable(
+		components.WithColumns(topTableColumns),
+		components.WithRows(topRows(stats)),
+		components.WithHeaderStyle(lipgloss.NewStyle().Bold(true)),
+		components.WithCellStyle(lipgloss.NewStyle()),
+	)
+	return table.Render()
+}
+
+// topRows formats one row per container. Processes and attachments follow
+// their route and are indented under it.
+func topRows(stats []dto.ContainerStats) [][]string {
+	rows := make([][]string, 0, len(stats))
+	for _, st := range stats {
+		name := st.Name
+		if st.Kind == "process" || st.Kind == "attachment" {
+			name = "  " + name
+		}
+		s := st.Latest
+		memory := bytesize.Format(clampBytes(s.MemoryUsage))
+		if s.MemoryLimit > 0 {
+			memory += " / " + bytesize.Format(clampBytes(s.MemoryLimit))
+		}
+		rows = append(rows, []string{
+			name,
+			st.Kind,
+			fmt.Sprintf("%.1f%%", s.CPUPercent),
+			memory,
+			formatRate(s.NetworkRxRate) + " / " + formatRate(s.NetworkTxRate),
+			formatRate(s.BlockReadRate) + " / " + formatRate(s.BlockWriteRate),
+			fmt.Sprint(s.PIDs),
+		})
+	}
+	return rows
+}
+
+func formatRate(bytesPerSecond float64) string {
+	return bytesize.Format(int64(bytesPerSecond)) + "/s"
+}
+
+func clampBytes(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
+
+type topStatsMsg struct {
+	stats []dto.ContainerStats
+	err   error
+}
+
+type topTickMsg time.Time
+
+// topModel polls the control plane and renders the latest samples.
+type topModel struct {
+	ctx     context.Context
+	plane   ControlPlane
+	name    string
+	spinner components.SpinnerModel
+	stats   []dto.ContainerStats
+	err     error
+	updated time.Time
+}
+
+func newTopModel(ctx context.Context, plane ControlPlane, name string) topModel {
+	return topModel{
+		ctx:   ctx,
+		plane: plane,
+		name:  name,
+		spinner: components.NewSpinner(
+			components.WithMessage("Collecting samples..."),
+			components.WithSpinnerType(components.SpinnerMiniDot),
+		),
+	}
+}
+
+func (m topModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Init(), m.fetch())
+}
+
+func (m topModel) fetch() tea.Cmd {
+	return func() tea.Msg {
+		stats, err := m.plane.ListStats(m.ctx, m.name)
+		return topStatsMsg{stats: stats, err: err}
+	}
+}
+
+func (m topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+		return m, nil
+	case topStatsMsg:
+		// Keep the last rows on a failed refresh; the error is shown above them.
+		m.err = msg.err
+		if msg.err == nil {
+			m.stats = msg.stats
+			m.updated = time.Now()
+		}
+		return m, tea.Tick(topRefreshInterval, func(t time.Time) tea.Msg { return topTickMsg(t) })
+	case topTickMsg:
+		return m, m.fetch()
+	default:
+		updated, cmd := m.spinner.Update(msg)
+		if spinnerModel, ok := updated.(components.SpinnerModel); ok {
+			m.spinner = spinnerModel
+		}
+		return m, cmd
+	}
+}
+
+func (m topModel) View() string {
+	var b strings.Builder
+
+	scope := "all containers"
+	if m.name != "" {
+		scope = m.name
+	}
+	b.WriteString(cliRenderTitle("gordon top") + " " + cliRenderMuted(scope))
+	if !m.updated.IsZero() {
+		b.WriteString(cliRenderMuted(" · updated " + m.updated.Format(time.TimeOnly)))
+	}
+	b.WriteString("\n\n")
+
+	if m.err != nil {
+		b.WriteString(styles.RenderError(m.err.Error()) + "\n\n")
+	}
+	switch {
+	case len(m.stats) > 0:
+		b.WriteString(renderTopTable(m.stats) + "\n")
+	case m.err == nil:
+		b.WriteString(m.spinner.View() + "\n")
+	}
+
+	b.WriteString("\n" + cliRenderMuted("q to quit"))
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	climocks "github.com/bnema/gordon/internal/adapters/in/cli/mocks"
+)
+
+func testTopStats() []dto.ContainerStats {
+	return []dto.ContainerStats{
+		{Kind: "route", Name: "app.example.com", Latest: dto.ResourceSample{
+			CPUPercent: 12.34, MemoryUsage: 64 << 20, MemoryLimit: 512 << 20,
+			NetworkRxRate: 2048, NetworkTxRate: 512, PIDs: 9,
+		}},
+		{Kind: "attachment", Name: "gordon-app-postgres", Owner: "app.example.com"},
+	}
+}
+
+func TestTopRows(t *testing.T) {
+	rows := topRows(testTopStats())
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, "app.example.com", rows[0][0])
+	assert.Equal(t, "12.3%", rows[0][2])
+	assert.Contains(t, rows[0][3], " / ")
+	assert.Contains(t, rows[0][4], "/s / ")
+	assert.Equal(t, "9", rows[0][6])
+	assert.Equal(t, "  gordon-app-postgres", rows[1][0], "attachments are indented under their route")
+	assert.NotContains(t, rows[1][3], " / ", "no limit shown when unknown")
+}
+
+func TestTopModelKeepsRowsOnFailedRefresh(t *testing.T) {
+	m := newTopModel(context.Background(), nil, "")
+
+	updated, cmd := m.Update(topStatsMsg{stats: testTopStats()})
+	m = updated.(topModel)
+	require.NotNil(t, cmd, "schedules the next refresh")
+	assert.Contains(t, m.View(), "app.example.com")
+
+	updated, _ = m.Update(topStatsMsg{err: errors.New("connection refused")})
+	m = updated.(topModel)
+	view := m.View()
+	assert.Contains(t, view, "connection refused")
+	assert.Contains(t, view, "app.example.com")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestTopSnapshotWaitsForLocalSamples(t *testing.T) {
+	prev := topRefreshInterval
+	topRefreshInterval = time.Millisecond
+	t.Cleanup(func() { topRefreshInterval = prev })
+
+	cp := climocks.NewMockControlPlane(t)
+	cp.EXPECT().ListStats(mock.Anything, "app.example.com").Return([]dto.ContainerStats{}, nil).Once()
+	cp.EXPECT().ListStats(mock.Anything, "app.example.com").Return(testTopStats(), nil).Once()
+
+	stats, err := topSnapshot(context.Background(), &controlPlaneHandle{plane: cp}, "app.example.com")
+
+	require.NoError(t, err)
+	assert.Len(t, stats, 2)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
 )
 
 var trafficResolveControlPlane = resolveControlPlane
@@ -100,7 +101,22 @@ func renderTrafficEntryPoint(out io.Writer, entry dto.TrafficEntryPointStatus) e
 		return err
 	}
 	if hasSmartTCPCounters(entry.SmartTCP) {
-		return renderSmartTCPCounters(out, "    smart_tcp", entry.SmartTCP)
+		if err := renderSmartTCPCounters(out, "    smart_tcp", entry.SmartTCP); err != nil {
+			return err
+		}
+	}
+	if hasProxyProtocolCounters(entry.ProxyProtocol) {
+		if err := renderProxyProtocolCounters(out, "    proxy_protocol", entry.ProxyProtocol); err != nil {
+			return err
+		}
+	}
+	if hasQUICCounters(entry.QUIC) {
+		if err := renderQUICCounters(out, "    quic", entry.QUIC); err != nil {
+			return err
+		}
+	}
+	if hasLimitCounters(entry.Limits) {
+		return renderLimitCounters(out, "    limits", entry.Limits)
 	}
 	return nil
 }
@@ -115,11 +131,52 @@ func renderTrafficTotals(out io.Writer, counters dto.TrafficCounters) error {
 		return err
 	}
 	if hasSmartTCPCounters(counters.SmartTCP) {
-		return renderSmartTCPCounters(out, "Smart TCP totals", counters.SmartTCP)
+		if err := renderSmartTCPCounters(out, "Smart TCP totals", counters.SmartTCP); err != nil {
+			return err
+		}
+	}
+	if hasProxyProtocolCounters(counters.ProxyProtocol) {
+		if err := renderProxyProtocolCounters(out, "PROXY protocol totals", counters.ProxyProtocol); err != nil {
+			return err
+		}
+	}
+	if hasQUICCounters(counters.QUIC) {
+		if err := renderQUICCounters(out, "QUIC totals", counters.QUIC); err != nil {
+			return err
+		}
+	}
+	if hasLimitCounters(counters.Limits) {
+		return renderLimitCounters(out, "Limit totals", counters.Limits)
 	}
 	return nil
 }
 
+func hasLimitCounters(c dto.LimitCounters) bool {
+	return c.RouterRefused != 0 || c.SourceRefused != 0 || c.RateRefused != 0 || c.BandwidthDropped != 0
+}
+
+func renderLimitCounters(out io.Writer, label string, c dto.LimitCounters) error {
+	return cliWritef(out, "%s: router_refused=%d source_refused=%d rate_refused=%d bandwidth_dropped=%d\n",
+		label, c.RouterRefused, c.SourceRefused, c.RateRefused, c.BandwidthDropped)
+}
+
+func hasQUICCounters(c dto.QUICCounters) bool {
+	return c.SessionsAccepted != 0 || c.SessionsRefused != 0 || c.ActiveSessions != 0 || c.Requests != 0
+}
+
+func renderQUICCounters(out io.Writer, label string, c dto.QUICCounters) error {
+	return cliWritef(out, "%s: sessions_accepted=%d sessions_refused=%d active_sessions=%d requests=%d\n",
+		label, c.SessionsAccepted, c.SessionsRefused, c.ActiveSessions, c.Requests)
+}
+
+func hasProxyProtocolCounters(c dto.ProxyProtocolCounters) bool {
+	return c.Accepted != 0 || c.Rejected != 0
+}
+
+func renderProxyProtocolCounters(out io.Writer, label string, c dto.ProxyProtocolCounters) error {
+	return cliWritef(out, "%s: accepted=%d rejected=%d\n", label, c.Accepted, c.Rejected)
+}
+
 func hasSmartTCPCounters(c dto.SmartTCPCounters) bool {
 	return c.HTTPAccepted != 0 || c.H2CAccepted != 0 || c.HTTPSFallbackAccepted != 0 || c.TLSPassthroughAccepted != 0 ||
 		c.RawFallbackAccepted != 0 || c.EntrypointCIDRRefused != 0 || c.RawFallbackCIDRRefused != 0 || c.PROXYRefused != 0 ||
@@ -148,8 +205,19 @@ func renderTrafficRouters(out io.Writer, routers []dto.TrafficRouterStatus) erro
 		if rule == "" {
 			rule = router.Rule.SNI
 		}
-		if err := cliWritef(out, "  %s  %s  entrypoint=%s rule=%s service=%s active=%t\n",
-			router.Name, router.Protocol, router.EntryPoint, rule, router.Service, router.Active); err != nil {
+		if router.Rule.SNIRegex != "" {
+			rule = "~" + router.Rule.SNIRegex
+		}
+		proxyProtocol := ""
+		if router.ProxyProtocol != 0 {
+			proxyProtocol = fmt.Sprintf(" proxy_protocol=v%d", router.ProxyProtocol)
+		}
+		matches := ""
+		if router.Protocol == domain.RouterProtocolTLSPassthrough {
+			matches = fmt.Sprintf(" matches=%d", router.Matches)
+		}
+		if err := cliWritef(out, "  %s  %s  entrypoint=%s rule=%s service=%s%s active=%t%s\n",
+			router.Name, router.Protocol, router.EntryPoint, rule, router.Service, proxyProtocol, router.Active, matches); err != nil {
 			return err
 		}
 	}
@@ -167,16 +235,32 @@ func renderTrafficServices(out io.Writer, services []dto.TrafficServiceStatus) e
 		return cliWriteLine(out, cliRenderMuted("  none"))
 	}
 	for _, service := range services {
-		if err := cliWritef(out, "  %s  active=%t\n", service.Name, service.Active); err != nil {
+		strategy := ""
+		if service.Strategy != "" {
+			strategy = " strategy=" + string(service.Strategy)
+		}
+		if err := cliWritef(out, "  %s %s active=%t\n", service.Name, strategy, service.Active); err != nil {
 			return err
 		}
 		for _, backend := range service.Backends {
-			addr := net.JoinHostPort(backend.Host, fmt.Sprint(backend.Port))
-			if err := cliWritef(out, "    %s  %s://%s active=%t\n",
-				backend.Name, backend.Protocol, addr, backend.Active); err != nil {
+			if err := renderTrafficBackend(out, backend); err != nil {
 				return err
 			}
 		}
 	}
 	return nil
 }
+
+func renderTrafficBackend(out io.Writer, backend dto.TrafficBackendStatus) error {
+	addr := net.JoinHostPort(backend.Host, fmt.Sprint(backend.Port))
+	health := ""
+	if backend.Health != "" {
+		health = fmt.Sprintf(" health=%s connections=%d failures=%d", backend.Health, backend.ActiveConnections, backend.TotalFailures)
+	}
+	lastError := ""
+	if backend.LastError != "" {
+		lastError = fmt.Sprintf(" last_error=%q", backend.LastError)
+	}
+	return cliWritef(out, "    %s  %s://%s active=%t%s%s\n",
+		backend.Name, backend.Protocol, addr, backend.Active, health, lastError)
+}
@@ -41,11 +41,12 @@ func TestTrafficStatusHumanOutput(t *testing.T) {
 		EntryPoints: []dto.TrafficEntryPointStatus{{
 			Name: "postgres", Address: "127.0.0.1:5432", Protocol: domain.EntryPointProtocolTCP,
 			Active: true, ActiveTCPConnections: 1, TotalAccepted: 2, BytesIn: 10, BytesOut: 20,
-			SmartTCP: dto.SmartTCPCounters{HTTPAccepted: 1, PROXYRefused: 1},
+			SmartTCP:      dto.SmartTCPCounters{HTTPAccepted: 1, PROXYRefused: 1},
+			ProxyProtocol: dto.ProxyProtocolCounters{Accepted: 3, Rejected: 1},
 		}},
 		Routers: []dto.TrafficRouterStatus{{
 			Name: "pg-router", EntryPoint: "postgres", Protocol: domain.RouterProtocolTCP,
-			Service: "network_service:postgres:db", Active: true,
+			Service: "network_service:postgres:db", ProxyProtocol: domain.ProxyProtocolV2, Active: true,
 		}},
 		Services: []dto.TrafficServiceStatus{{
 			Name: "network_service:postgres:db", Active: true,
@@ -66,6 +67,73 @@ func TestTrafficStatusHumanOutput(t *testing.T) {
 	assert.Contains(t, output, "smart_tcp")
 	assert.Contains(t, output, "http_accepted=1")
 	assert.Contains(t, output, "proxy_refused=1")
+	assert.Contains(t, output, "proxy_protocol: accepted=3 rejected=1")
+	assert.Contains(t, output, "proxy_protocol=v2")
+}
+
+func TestTrafficStatusHumanOutputQUIC(t *testing.T) {
+	status := &dto.TrafficStatusResponse{
+		LastReloadStatus: "ok",
+		EntryPoints: []dto.TrafficEntryPointStatus{{
+			Name: "edge-h3", Address: ":443", Protocol: domain.EntryPointProtocolHTTP3, Active: true, TotalAccepted: 4,
+			QUIC: dto.QUICCounters{SessionsAccepted: 4, SessionsRefused: 1, ActiveSessions: 2, Requests: 9},
+		}},
+		Counters: dto.TrafficCounters{TotalAccepted: 4, QUIC: dto.QUICCounters{SessionsAccepted: 4, SessionsRefused: 1, ActiveSessions: 2, Requests: 9}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, renderTrafficStatus(&buf, status, false))
+	output := buf.String()
+	assert.Contains(t, output, "edge-h3  http3  :443")
+	assert.Contains(t, output, "    quic: sessions_accepted=4 sessions_refused=1 active_sessions=2 requests=9")
+	assert.Contains(t, output, "QUIC totals: sessions_accepted=4")
+}
+
+func TestTrafficStatusHumanOutputSNIMatches(t *testing.T) {
+	status := &dto.TrafficStatusResponse{
+		LastReloadStatus: "ok",
+		Routers: []dto.TrafficRouterStatus{
+			{Name: "tenants", EntryPoint: "edge", Protocol: domain.RouterProtocolTLSPassthrough, Rule: dto.TrafficRule{SNIRegex: `tenant-[0-9]+\.example\.com`}, Service: "pool:tenants", Active: true, Matches: 12},
+			{Name: "pg-router", EntryPoint: "postgres", Protocol: domain.RouterProtocolTCP, Service: "network_service:postgres:db", Active: true},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, renderTrafficStatus(&buf, status, false))
+	output := buf.String()
+	assert.Contains(t, output, `rule=~tenant-[0-9]+\.example\.com service=pool:tenants active=true matches=12`)
+	assert.NotContains(t, output, "network_service:postgres:db active=true matches=")
+}
+
+func TestTrafficStatusHumanOutputLimits(t *testing.T) {
+	limits := dto.LimitCounters{SourceRefused: 4, RateRefused: 2, BandwidthDropped: 7}
+	status := &dto.TrafficStatusResponse{
+		LastReloadStatus: "ok",
+		EntryPoints:      []dto.TrafficEntryPointStatus{{Name: "game", Address: ":28015", Protocol: domain.EntryPointProtocolUDP, Active: true, TotalRefused: 6, Limits: limits}},
+		Counters:         dto.TrafficCounters{TotalRefused: 6, Limits: limits},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, renderTrafficStatus(&buf, status, false))
+	output := buf.String()
+	assert.Contains(t, output, "    limits: router_refused=0 source_refused=4 rate_refused=2 bandwidth_dropped=7")
+	assert.Contains(t, output, "Limit totals: router_refused=0 source_refused=4")
+}
+
+func TestTrafficStatusHumanOutputBackendHealth(t *testing.T) {
+	status := &dto.TrafficStatusResponse{
+		LastReloadStatus: "ok",
+		Services: []dto.TrafficServiceStatus{{
+			Name: "pool:rust", Active: true, Strategy: domain.LoadBalancerSourceIPHash,
+			Backends: []dto.TrafficBackendStatus{
+				{Name: "rust-a:game", Host: "10.0.0.2", Port: 28015, Protocol: domain.NetworkProtocolUDP, Active: true, Health: domain.BackendHealthHealthy, ActiveConnections: 3},
+				{Name: "rust-b:game", Host: "10.0.0.3", Port: 28015, Protocol: domain.NetworkProtocolUDP, Health: domain.BackendHealthEjected, TotalFailures: 3, LastError: "connection refused"},
+			},
+		}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, renderTrafficStatus(&buf, status, false))
+	output := buf.String()
+	assert.Contains(t, output, "pool:rust  strategy=source_ip_hash active=true")
+	assert.Contains(t, output, "rust-a:game  udp://10.0.0.2:28015 active=true health=healthy connections=3 failures=0")
+	assert.Contains(t, output, `rust-b:game  udp://10.0.0.3:28015 active=false health=ejected connections=0 failures=3 last_error="connection refused"`)
 }
 
 func TestTrafficStatusJSONOutput(t *testing.T) {
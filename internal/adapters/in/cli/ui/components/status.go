@@ -22,6 +22,7 @@ const (
 	StatusPaused
 	StatusRestarting
 	StatusExited
+	StatusSleeping
 	StatusUnknown
 )
 
@@ -77,6 +78,10 @@ var DefaultStatusConfigs = map[Status]StatusConfig{
 		Icon:  styles.IconExited,
 		Style: styles.Theme.Error,
 	},
+	StatusSleeping: {
+		Icon:  styles.IconSleeping,
+		Style: styles.Theme.Info,
+	},
 	StatusUnknown: {
 		Icon:  styles.IconUnknown,
 		Style: styles.Theme.Muted,
@@ -137,6 +142,8 @@ func ParseStatus(s string) Status {
 		return StatusRestarting
 	case "exited", "dead":
 		return StatusExited
+	case "sleeping":
+		return StatusSleeping
 	case "unknown":
 		return StatusUnknown
 	default:
@@ -18,6 +18,7 @@ const (
 	IconExited     = "\uf00d" // nf-fa-times
 	IconPaused     = "\uf04c" // nf-fa-pause
 	IconRestarting = "\uf021" // nf-fa-refresh
+	IconSleeping   = "\uf186" // nf-fa-moon_o
 	IconUnknown    = "\uf071" // nf-fa-exclamation_triangle
 
 	// Objects
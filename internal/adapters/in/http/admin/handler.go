@@ -55,12 +55,19 @@ type Handler struct {
 	reloadTrigger   reloadTrigger
 	publicTLSSvc    in.PublicTLSService
 	trafficSvc      in.TrafficStatusService
+	cacheSvc        in.ResponseCacheService
+	upstreamSvc     in.UpstreamStatusService
+	taskSvc         in.TaskService
+	execSvc         in.ExecService
+	jobSvc          in.JobService
+	statsSvc        in.StatsService
 	log             zerowrap.Logger
 }
 
 // Type aliases for API responses using shared DTO types.
 type routeInfoResponse = dto.RouteInfo
 type attachmentResponse = dto.Attachment
+type processResponse = dto.Process
 type routeResponse = dto.Route
 
 // toAttachmentResponse converts a domain.Attachment to a dto.Attachment.
@@ -91,11 +98,22 @@ func toRouteInfoResponse(r domain.RouteInfo) dto.RouteInfo {
 }
 
 // toRouteResponse converts a domain.Route to a dto.Route.
+// toProcessResponse converts a domain.Process to a dto.Process.
+func toProcessResponse(p domain.Process) dto.Process {
+	return dto.Process{
+		Name:        p.Name,
+		Command:     p.Command,
+		ContainerID: p.ContainerID,
+		Status:      p.Status,
+	}
+}
+
 func toRouteResponse(r domain.Route) dto.Route {
 	return dto.Route{
-		Domain: r.Domain,
-		Image:  r.Image,
-		HTTPS:  r.HTTPS,
+		Domain:    r.Domain,
+		Image:     r.Image,
+		HTTPS:     r.HTTPS,
+		Processes: r.Processes,
 	}
 }
 
@@ -204,6 +222,12 @@ type HandlerDeps struct {
 	ReloadTrigger   reloadTrigger
 	PublicTLSSvc    in.PublicTLSService
 	TrafficSvc      in.TrafficStatusService
+	CacheSvc        in.ResponseCacheService
+	UpstreamSvc     in.UpstreamStatusService
+	TaskSvc         in.TaskService
+	ExecSvc         in.ExecService
+	JobSvc          in.JobService
+	StatsSvc        in.StatsService
 }
 
 // NewHandler creates a new admin HTTP handler.
@@ -224,6 +248,12 @@ func NewHandler(deps HandlerDeps) *Handler {
 		reloadTrigger:   deps.ReloadTrigger,
 		publicTLSSvc:    deps.PublicTLSSvc,
 		trafficSvc:      deps.TrafficSvc,
+		cacheSvc:        deps.CacheSvc,
+		upstreamSvc:     deps.UpstreamSvc,
+		taskSvc:         deps.TaskSvc,
+		execSvc:         deps.ExecSvc,
+		jobSvc:          deps.JobSvc,
+		statsSvc:        deps.StatsSvc,
 		log:             deps.Log,
 	}
 }
@@ -297,12 +327,17 @@ func (h *Handler) matchRoute(path string) (routeHandler, bool) {
 		{"/deploy-intent", h.handleDeployIntent},
 		{"/deploy", h.handleDeploy},
 		{"/restart", h.handleRestart},
+		{"/run", h.handleRun},
+		{"/exec", h.handleExec},
+		{"/jobs", h.handleJobs},
+		{"/stats", h.handleStats},
 		{"/tags", h.handleTags},
 		{"/images", h.handleImages},
 		{"/logs", h.handleLogs},
 		{"/autoroute/allowed-domains", h.handleAutoRouteAllowedDomains},
 		{"/previews", h.handlePreviewList},
 		{"/preview", h.handlePreviewAction},
+		{"/cache/purge", h.handleCachePurge},
 	}
 	for _, route := range prefixRoutes {
 		if path == route.prefix || strings.HasPrefix(path, route.prefix+"/") {
@@ -674,6 +709,15 @@ func (h *Handler) handleRoutesGet(w http.ResponseWriter, r *http.Request, routeD
 		return
 	}
 
+	if parentDomain, ok := strings.CutSuffix(routeDomain, "/upstream"); ok {
+		if parentDomain == "" {
+			h.sendError(w, http.StatusBadRequest, "domain required in path")
+			return
+		}
+		h.handleRouteUpstreamStatus(w, r, parentDomain)
+		return
+	}
+
 	if parentDomain, ok := strings.CutSuffix(routeDomain, "/attachments"); ok {
 		if parentDomain == "" {
 			h.sendError(w, http.StatusBadRequest, "domain required in path")
@@ -688,6 +732,21 @@ func (h *Handler) handleRoutesGet(w http.ResponseWriter, r *http.Request, routeD
 		return
 	}
 
+	if parentDomain, ok := strings.CutSuffix(routeDomain, "/processes"); ok {
+		route, err := h.configSvc.GetRoute(ctx, parentDomain)
+		if err != nil {
+			h.sendError(w, http.StatusNotFound, "route not found")
+			return
+		}
+		processes := h.containerSvc.ListProcesses(ctx, *route)
+		response := make([]processResponse, 0, len(processes))
+		for _, process := range processes {
+			response = append(response, toProcessResponse(process))
+		}
+		h.sendJSON(w, http.StatusOK, dto.ProcessesResponse{Processes: response})
+		return
+	}
+
 	route, err := h.configSvc.GetRoute(ctx, routeDomain)
 	if err != nil {
 		h.sendError(w, http.StatusNotFound, "route not found")
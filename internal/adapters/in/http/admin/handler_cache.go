@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleCachePurge handles POST /admin/cache/purge/<domain>?path=/some/path.
+func (h *Handler) handleCachePurge(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+	log := zerowrap.FromCtx(ctx)
+
+	if !HasAccess(ctx, domain.AdminResourceConfig, domain.AdminActionWrite) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for config:write")
+		return
+	}
+
+	purgeDomain := strings.TrimPrefix(path, "/cache/purge/")
+	if purgeDomain == "" || purgeDomain == "/cache/purge" {
+		h.sendError(w, http.StatusBadRequest, "domain required in path")
+		return
+	}
+	if err := validation.ValidateDomainParam(purgeDomain); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid domain")
+		return
+	}
+
+	purgePath := r.URL.Query().Get("path")
+	if purgePath != "" && !strings.HasPrefix(purgePath, "/") {
+		h.sendError(w, http.StatusBadRequest, "path must start with /")
+		return
+	}
+
+	if h.cacheSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "response cache not available")
+		return
+	}
+
+	purged, err := h.cacheSvc.PurgeResponseCache(ctx, purgeDomain, purgePath)
+	if err != nil {
+		log.Error().Err(err).Str("domain", purgeDomain).Msg("failed to purge response cache")
+		switch {
+		case errors.Is(err, domain.ErrResponseCacheUnavailable):
+			h.sendError(w, http.StatusServiceUnavailable, "response cache not available")
+		case errors.Is(err, domain.ErrRouteDomainInvalid):
+			h.sendError(w, http.StatusBadRequest, "invalid domain")
+		default:
+			h.sendError(w, http.StatusInternalServerError, "failed to purge response cache")
+		}
+		return
+	}
+
+	log.Info().Str("domain", purgeDomain).Str("path", purgePath).Int("purged", purged).Msg("response cache purged via admin API")
+	h.sendJSON(w, http.StatusOK, dto.CachePurgeResponse{
+		Domain: purgeDomain,
+		Path:   purgePath,
+		Purged: purged,
+	})
+}
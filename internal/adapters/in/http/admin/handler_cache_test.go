@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestHandler_CachePurge(t *testing.T) {
+	cacheSvc := inmocks.NewMockResponseCacheService(t)
+	cacheSvc.EXPECT().PurgeResponseCache(mock.Anything, "app.example.com", "/assets/app.js").Return(3, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.CacheSvc = cacheSvc })
+	server := newScopedTestServer(t, handler, "admin:config:write")
+
+	resp, err := http.Post(server.URL+"/admin/cache/purge/app.example.com?path=/assets/app.js", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body dto.CachePurgeResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, dto.CachePurgeResponse{Domain: "app.example.com", Path: "/assets/app.js", Purged: 3}, body)
+}
+
+func TestHandler_CachePurgeRejectsInvalidRequests(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		scopes     []string
+		wantStatus int
+	}{
+		{"wrong method", http.MethodGet, "/admin/cache/purge/app.example.com", []string{"admin:config:write"}, http.StatusMethodNotAllowed},
+		{"read scope only", http.MethodPost, "/admin/cache/purge/app.example.com", []string{"admin:config:read"}, http.StatusForbidden},
+		{"missing domain", http.MethodPost, "/admin/cache/purge", []string{"admin:config:write"}, http.StatusBadRequest},
+		{"relative path", http.MethodPost, "/admin/cache/purge/app.example.com?path=assets", []string{"admin:config:write"}, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cacheSvc := inmocks.NewMockResponseCacheService(t)
+			handler := newTestHandler(t, func(d *HandlerDeps) { d.CacheSvc = cacheSvc })
+			server := newScopedTestServer(t, handler, tt.scopes...)
+
+			req, err := http.NewRequest(tt.method, server.URL+tt.url, nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestHandler_CachePurgeUnavailable(t *testing.T) {
+	cacheSvc := inmocks.NewMockResponseCacheService(t)
+	cacheSvc.EXPECT().PurgeResponseCache(mock.Anything, "app.example.com", "").Return(0, domain.ErrResponseCacheUnavailable)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.CacheSvc = cacheSvc })
+	server := newScopedTestServer(t, handler, "admin:config:write")
+
+	resp, err := http.Post(server.URL+"/admin/cache/purge/app.example.com", "", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
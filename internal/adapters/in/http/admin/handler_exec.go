@@ -0,0 +1,232 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"golang.org/x/net/websocket"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleExec handles GET /admin/exec/<domain>. It starts the command given
+// by the repeated cmd query parameter in the route's container, or in the
+// attachment named by the attachment parameter, then upgrades to a websocket
+// that carries dto.ExecFrame messages in both directions. Every session is
+// audit logged with the token subject.
+func (h *Handler) handleExec(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+	log := zerowrap.FromCtx(ctx).WithFields(map[string]any{
+		"audit":       true,
+		"subject":     GetSubject(ctx),
+		"remote_addr": r.RemoteAddr,
+	})
+
+	execDomain := strings.TrimPrefix(path, "/exec/")
+	if !HasAccess(ctx, domain.AdminResourceExec, domain.AdminActionWrite) {
+		log.Warn().Str("domain", execDomain).Msg("exec session denied: missing admin:exec:write")
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for exec:write")
+		return
+	}
+
+	if execDomain == "" || execDomain == "/exec" {
+		h.sendError(w, http.StatusBadRequest, "domain required in path")
+		return
+	}
+	if err := validation.ValidateDomainParam(execDomain); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid domain")
+		return
+	}
+
+	if h.execSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "exec not available")
+		return
+	}
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		h.sendError(w, http.StatusBadRequest, "websocket upgrade required")
+		return
+	}
+
+	query := r.URL.Query()
+	attachment := query.Get("attachment")
+	req, err := parseExecRequest(query)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if _, err := h.configSvc.GetRoute(ctx, execDomain); err != nil {
+		if errors.Is(err, domain.ErrRouteNotFound) {
+			h.sendError(w, http.StatusNotFound, "route not found")
+			return
+		}
+		log.Error().Err(err).Str("domain", execDomain).Msg("failed to load route")
+		h.sendError(w, http.StatusInternalServerError, "failed to start exec session")
+		return
+	}
+
+	// The session must not be tied to the request context: it outlives
+	// ServeHTTP's view of the request once the connection is hijacked.
+	sessionCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	session, err := h.execSvc.StartExec(sessionCtx, execDomain, attachment, req)
+	if err != nil {
+		log.Warn().Err(err).Str("domain", execDomain).Str("attachment", attachment).Msg("exec session failed to start")
+		switch {
+		case errors.Is(err, domain.ErrExecCommandRequired):
+			h.sendError(w, http.StatusBadRequest, "command required")
+		case errors.Is(err, domain.ErrAttachmentNotFound):
+			h.sendError(w, http.StatusNotFound, "attachment not running")
+		case errors.Is(err, domain.ErrContainerNotFound):
+			h.sendError(w, http.StatusConflict, "route has no deployed container")
+		default:
+			h.sendError(w, http.StatusInternalServerError, "failed to start exec session")
+		}
+		return
+	}
+	defer session.Close()
+
+	started := time.Now()
+	log.Info().
+		Str("domain", execDomain).
+		Str("attachment", attachment).
+		Strs("command", req.Command).
+		Bool("tty", req.TTY).
+		Msg("exec session started")
+
+	server := websocket.Server{
+		// Admin requests authenticate with a bearer token rather than
+		// cookies, so the browser Origin check adds nothing here.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			exitCode, err := bridgeExecSession(sessionCtx, cancel, ws, session, req.TTY, log)
+			event := log.Info().
+				Str("domain", execDomain).
+				Str("attachment", attachment).
+				Dur("duration", time.Since(started))
+			if err != nil {
+				event.Err(err).Msg("exec session ended")
+				return
+			}
+			event.Int("exit_code", exitCode).Msg("exec session ended")
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// parseExecRequest reads the command and terminal options of an exec session
+// from its query parameters.
+func parseExecRequest(query url.Values) (domain.ExecRequest, error) {
+	req := domain.ExecRequest{Command: query["cmd"]}
+	if len(req.Command) == 0 || req.Command[0] == "" {
+		return req, errors.New("command required")
+	}
+
+	if raw := query.Get("tty"); raw != "" {
+		tty, err := strconv.ParseBool(raw)
+		if err != nil {
+			return req, errors.New("invalid tty")
+		}
+		req.TTY = tty
+	}
+	for key, dst := range map[string]*uint{"rows": &req.Rows, "cols": &req.Cols} {
+		raw := query.Get(key)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(raw, 10, 16)
+		if err != nil {
+			return req, errors.New("invalid " + key)
+		}
+		*dst = uint(n)
+	}
+	return req, nil
+}
+
+// bridgeExecSession copies websocket frames to the exec session and its
+// output back until the command exits or the client goes away.
+func bridgeExecSession(ctx context.Context, cancel context.CancelFunc, ws *websocket.Conn, session out.ExecSession, tty bool, log zerowrap.Logger) (int, error) {
+	// Interactive sessions idle for long stretches; the server's read and
+	// write timeouts must not cut them off.
+	_ = ws.SetDeadline(time.Time{})
+	ws.MaxPayloadBytes = maxAdminRequestSize
+
+	go func() {
+		// The client leaving ends the session.
+		defer cancel()
+		for {
+			var frame dto.ExecFrame
+			if err := websocket.JSON.Receive(ws, &frame); err != nil {
+				return
+			}
+			if err := applyExecFrame(ctx, session, frame, tty); err != nil {
+				log.Debug().Err(err).Str("frame", frame.Type).Msg("failed to apply exec frame")
+			}
+		}
+	}()
+
+	exitCode, err := session.Wait(ctx,
+		execStreamWriter{ws: ws, stream: dto.ExecFrameStdout},
+		execStreamWriter{ws: ws, stream: dto.ExecFrameStderr})
+	if err != nil {
+		if ctx.Err() == nil {
+			_ = websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameError, Error: "exec session failed"})
+		}
+		return 0, err
+	}
+	_ = websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameExit, ExitCode: &exitCode})
+	return exitCode, nil
+}
+
+// applyExecFrame forwards one client frame to the exec session.
+func applyExecFrame(ctx context.Context, session out.ExecSession, frame dto.ExecFrame, tty bool) error {
+	switch frame.Type {
+	case dto.ExecFrameStdin:
+		_, err := session.Write(frame.Data)
+		return err
+	case dto.ExecFrameResize:
+		if frame.Rows == 0 || frame.Cols == 0 {
+			return nil
+		}
+		return session.Resize(ctx, frame.Rows, frame.Cols)
+	case dto.ExecFrameSignal:
+		input, ok := domain.TTYSignalInput(frame.Signal)
+		if !tty || !ok {
+			return domain.ErrExecSignalUnsupported
+		}
+		_, err := session.Write(input)
+		return err
+	case dto.ExecFrameEOF:
+		return session.CloseStdin()
+	default:
+		return errors.New("unknown exec frame type " + strconv.Quote(frame.Type))
+	}
+}
+
+// execStreamWriter turns writes to one exec output stream into frames.
+type execStreamWriter struct {
+	ws     *websocket.Conn
+	stream string
+}
+
+func (s execStreamWriter) Write(p []byte) (int, error) {
+	if err := websocket.JSON.Send(s.ws, dto.ExecFrame{Type: s.stream, Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,107 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestHandler_ExecBridgesWebsocketFrames(t *testing.T) {
+	route := &domain.Route{Domain: "app.example.com", Image: "app:latest"}
+	configSvc := inmocks.NewMockConfigService(t)
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").Return(route, nil)
+
+	stdin := make(chan []byte, 4)
+	session := outmocks.NewMockExecSession(t)
+	session.EXPECT().Resize(mock.Anything, uint(50), uint(160)).Return(nil)
+	session.EXPECT().Write(mock.Anything).RunAndReturn(func(p []byte) (int, error) {
+		stdin <- append([]byte(nil), p...)
+		return len(p), nil
+	})
+	session.EXPECT().Wait(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, stdout, _ io.Writer) (int, error) {
+			_, _ = stdout.Write(<-stdin)
+			_, _ = stdout.Write(<-stdin)
+			return 130, nil
+		})
+	session.EXPECT().Close().Return(nil)
+
+	execSvc := inmocks.NewMockExecService(t)
+	execSvc.EXPECT().StartExec(mock.Anything, "app.example.com", "", domain.ExecRequest{Command: []string{"sh"}, TTY: true, Rows: 40, Cols: 120}).
+		Return(session, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+		d.ExecSvc = execSvc
+	})
+	server := newScopedTestServer(t, handler, "admin:exec:write")
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/admin/exec/app.example.com?cmd=sh&tty=true&rows=40&cols=120"
+	ws, err := websocket.Dial(wsURL, "", server.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	require.NoError(t, websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameResize, Rows: 50, Cols: 160}))
+	require.NoError(t, websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameStdin, Data: []byte("ls\n")}))
+	require.NoError(t, websocket.JSON.Send(ws, dto.ExecFrame{Type: dto.ExecFrameSignal, Signal: "INT"}))
+
+	var frames []dto.ExecFrame
+	for {
+		var frame dto.ExecFrame
+		require.NoError(t, websocket.JSON.Receive(ws, &frame))
+		frames = append(frames, frame)
+		if frame.Type == dto.ExecFrameExit || frame.Type == dto.ExecFrameError {
+			break
+		}
+	}
+	require.Len(t, frames, 3)
+	assert.Equal(t, dto.ExecFrame{Type: dto.ExecFrameStdout, Data: []byte("ls\n")}, frames[0])
+	assert.Equal(t, dto.ExecFrame{Type: dto.ExecFrameStdout, Data: []byte{0x03}}, frames[1])
+	require.NotNil(t, frames[2].ExitCode)
+	assert.Equal(t, 130, *frames[2].ExitCode)
+}
+
+func TestHandler_ExecRequiresExecScope(t *testing.T) {
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.ExecSvc = inmocks.NewMockExecService(t) })
+	server := newScopedTestServer(t, handler, "admin:config:write", "admin:secrets:read")
+
+	resp, err := http.Get(server.URL + "/admin/exec/app.example.com?cmd=sh")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestHandler_ExecRejectsPlainRequestsAndMissingTargets(t *testing.T) {
+	route := &domain.Route{Domain: "app.example.com", Image: "app:latest"}
+	configSvc := inmocks.NewMockConfigService(t)
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").Return(route, nil)
+	execSvc := inmocks.NewMockExecService(t)
+	execSvc.EXPECT().StartExec(mock.Anything, "app.example.com", "postgres", mock.Anything).Return(nil, domain.ErrAttachmentNotFound)
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+		d.ExecSvc = execSvc
+	})
+	server := newScopedTestServer(t, handler, "admin:exec:write")
+
+	resp, err := http.Get(server.URL + "/admin/exec/app.example.com?cmd=sh")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/admin/exec/app.example.com?cmd=psql&attachment=postgres"
+	_, err = websocket.Dial(wsURL, "", server.URL)
+	var dialErr *websocket.DialError
+	require.ErrorAs(t, err, &dialErr)
+	assert.ErrorIs(t, dialErr.Err, websocket.ErrBadStatus)
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleJobs routes the scheduled job endpoints:
+//
+//	GET  /admin/jobs[?domain=<domain>]
+//	POST /admin/jobs/<domain>/<name>/run
+//	GET  /admin/jobs/<domain>/<name>/runs
+//	GET  /admin/jobs/<domain>/<name>/runs/<id>
+func (h *Handler) handleJobs(w http.ResponseWriter, r *http.Request, path string) {
+	if h.jobSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "jobs not available")
+		return
+	}
+
+	rest := strings.Trim(strings.TrimPrefix(path, "/jobs"), "/")
+	if rest == "" {
+		h.handleJobsList(w, r)
+		return
+	}
+
+	parts := strings.Split(rest, "/")
+	if len(parts) < 3 {
+		h.sendError(w, http.StatusNotFound, "not found")
+		return
+	}
+	jobDomain, name := parts[0], parts[1]
+	if err := validation.ValidateDomainParam(jobDomain); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid domain")
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[2] == "run":
+		h.handleJobRun(w, r, jobDomain, name)
+	case len(parts) == 3 && parts[2] == "runs":
+		h.handleJobRuns(w, r, jobDomain, name, "")
+	case len(parts) == 4 && parts[2] == "runs":
+		h.handleJobRuns(w, r, jobDomain, name, parts[3])
+	default:
+		h.sendError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (h *Handler) handleJobsList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+	if !HasAccess(ctx, domain.AdminResourceConfig, domain.AdminActionRead) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for config:read")
+		return
+	}
+
+	statuses, err := h.jobSvc.ListJobs(ctx)
+	if err != nil {
+		log := zerowrap.FromCtx(ctx)
+		log.Error().Err(err).Msg("failed to list jobs")
+		h.sendError(w, http.StatusInternalServerError, "failed to list jobs")
+		return
+	}
+
+	filter := r.URL.Query().Get("domain")
+	jobs := make([]dto.Job, 0, len(statuses))
+	for _, status := range statuses {
+		if filter != "" && status.Job.Route != filter {
+			continue
+		}
+		jobs = append(jobs, dto.JobFromDomain(status))
+	}
+	h.sendJSON(w, http.StatusOK, dto.JobsResponse{Jobs: jobs})
+}
+
+func (h *Handler) handleJobRun(w http.ResponseWriter, r *http.Request, jobDomain, name string) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+
+	// A job sees the route's secrets, so it needs the same scopes as a task.
+	if !HasAccess(ctx, domain.AdminResourceConfig, domain.AdminActionWrite) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for config:write")
+		return
+	}
+	if !HasAccess(ctx, domain.AdminResourceSecrets, domain.AdminActionRead) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for secrets:read")
+		return
+	}
+
+	run, err := h.jobSvc.RunJob(ctx, jobDomain, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			h.sendError(w, http.StatusNotFound, "job not found")
+			return
+		}
+		log := zerowrap.FromCtx(ctx)
+		log.Error().Err(err).Str("domain", jobDomain).Str("job", name).Msg("failed to run job")
+		h.sendError(w, http.StatusInternalServerError, "failed to run job")
+		return
+	}
+	h.sendJSON(w, http.StatusAccepted, dto.JobRunResponse{Run: dto.JobRunFromDomain(run)})
+}
+
+func (h *Handler) handleJobRuns(w http.ResponseWriter, r *http.Request, jobDomain, name, runID string) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+	if !HasAccess(ctx, domain.AdminResourceLogs, domain.AdminActionRead) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for logs:read")
+		return
+	}
+
+	runs, err := h.jobSvc.ListJobRuns(ctx, jobDomain, name)
+	if err != nil {
+		if errors.Is(err, domain.ErrJobNotFound) {
+			h.sendError(w, http.StatusNotFound, "job not found")
+			return
+		}
+		log := zerowrap.FromCtx(ctx)
+		log.Error().Err(err).Str("domain", jobDomain).Str("job", name).Msg("failed to list job runs")
+		h.sendError(w, http.StatusInternalServerError, "failed to list job runs")
+		return
+	}
+
+	if runID == "" {
+		h.sendJSON(w, http.StatusOK, dto.JobRunsResponse{Runs: dto.JobRunsFromDomain(runs)})
+		return
+	}
+	for _, run := range runs {
+		if run.ID == runID {
+			h.sendJSON(w, http.StatusOK, dto.JobRunResponse{Run: dto.JobRunFromDomain(run)})
+			return
+		}
+	}
+	h.sendError(w, http.StatusNotFound, "job run not found")
+}
@@ -0,0 +1,105 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestHandler_JobsListFiltersByDomain(t *testing.T) {
+	next := time.Date(2026, 2, 7, 12, 45, 0, 0, time.UTC)
+	jobSvc := inmocks.NewMockJobService(t)
+	jobSvc.EXPECT().ListJobs(mock.Anything).Return([]domain.JobStatus{
+		{Job: domain.ScheduledJob{Route: "api.example.com", Name: "report", Command: []string{"./report"}}},
+		{
+			Job: domain.ScheduledJob{
+				Route:    "app.example.com",
+				Name:     "cleanup",
+				Schedule: domain.CronSchedule{Expression: "*/15 * * * *", Location: time.UTC},
+				Command:  []string{"bin/cleanup"},
+			},
+			NextRun: next,
+			LastRun: &domain.JobRun{ID: "run-1", Status: domain.JobRunSucceeded},
+		},
+	}, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.JobSvc = jobSvc })
+	server := newScopedTestServer(t, handler, "admin:config:read")
+
+	resp, err := http.Get(server.URL + "/admin/jobs?domain=app.example.com")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body dto.JobsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Jobs, 1)
+	job := body.Jobs[0]
+	assert.Equal(t, "cleanup", job.Name)
+	assert.Equal(t, "*/15 * * * *", job.Schedule)
+	assert.Equal(t, "forbid", job.Overlap)
+	require.NotNil(t, job.NextRun)
+	assert.True(t, next.Equal(*job.NextRun))
+	require.NotNil(t, job.LastRun)
+	assert.Equal(t, "succeeded", job.LastRun.Status)
+}
+
+func TestHandler_JobRunRequiresTaskScopes(t *testing.T) {
+	jobSvc := inmocks.NewMockJobService(t)
+	jobSvc.EXPECT().RunJob(mock.Anything, "app.example.com", "cleanup").
+		Return(domain.JobRun{ID: "run-2", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunRunning}, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.JobSvc = jobSvc })
+
+	denied := newScopedTestServer(t, handler, "admin:config:write")
+	resp, err := http.Post(denied.URL+"/admin/jobs/app.example.com/cleanup/run", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	allowed := newScopedTestServer(t, handler, "admin:config:write", "admin:secrets:read")
+	resp, err = http.Post(allowed.URL+"/admin/jobs/app.example.com/cleanup/run", "application/json", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	var body dto.JobRunResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "run-2", body.Run.ID)
+	assert.Equal(t, "running", body.Run.Status)
+}
+
+func TestHandler_JobRunsLookup(t *testing.T) {
+	jobSvc := inmocks.NewMockJobService(t)
+	jobSvc.EXPECT().ListJobRuns(mock.Anything, "app.example.com", "cleanup").
+		Return([]domain.JobRun{{ID: "run-1", Status: domain.JobRunFailed, ExitCode: 1, Output: "boom\n"}}, nil)
+	jobSvc.EXPECT().ListJobRuns(mock.Anything, "app.example.com", "missing").
+		Return(nil, domain.ErrJobNotFound)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.JobSvc = jobSvc })
+	server := newScopedTestServer(t, handler, "admin:logs:read")
+
+	resp, err := http.Get(server.URL + "/admin/jobs/app.example.com/cleanup/runs/run-1")
+	require.NoError(t, err)
+	var body dto.JobRunResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "boom\n", body.Run.Output)
+
+	resp, err = http.Get(server.URL + "/admin/jobs/app.example.com/cleanup/runs/run-9")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/admin/jobs/app.example.com/missing/runs")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
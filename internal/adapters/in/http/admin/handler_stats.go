@@ -0,0 +1,67 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleStats routes the resource usage endpoints:
+//
+//	GET /admin/stats[?history=true]
+//	GET /admin/stats/<domain or service>
+func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodGet {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	ctx := r.Context()
+	if !HasAccess(ctx, domain.AdminResourceStatus, domain.AdminActionRead) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for status:read")
+		return
+	}
+	if h.statsSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "stats not available")
+		return
+	}
+
+	name := strings.Trim(strings.TrimPrefix(path, "/stats"), "/")
+	withHistory := name != ""
+	var (
+		stats []domain.ContainerStats
+		err   error
+	)
+	if name == "" {
+		withHistory, _ = strconv.ParseBool(r.URL.Query().Get("history"))
+		stats, err = h.statsSvc.ListStats(ctx)
+	} else {
+		if err := validation.ValidateDomainParam(name); err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid domain")
+			return
+		}
+		stats, err = h.statsSvc.GetStats(ctx, name)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrStatsDisabled) {
+			h.sendError(w, http.StatusServiceUnavailable, "resource stats sampling is disabled")
+			return
+		}
+		log := zerowrap.FromCtx(ctx)
+		log.Error().Err(err).Str("name", name).Msg("failed to get container stats")
+		h.sendError(w, http.StatusInternalServerError, "failed to get container stats")
+		return
+	}
+
+	resp := dto.StatsResponse{Containers: make([]dto.ContainerStats, 0, len(stats))}
+	for _, st := range stats {
+		resp.Containers = append(resp.Containers, dto.ContainerStatsFromDomain(st, withHistory))
+	}
+	h.sendJSON(w, http.StatusOK, resp)
+}
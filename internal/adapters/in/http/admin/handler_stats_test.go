@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func testContainerStats() domain.ContainerStats {
+	first := domain.ResourceSample{Time: time.Date(2026, 2, 7, 12, 0, 0, 0, time.UTC), CPUPercent: 10, MemoryUsage: 64 << 20}
+	latest := domain.ResourceSample{Time: first.Time.Add(10 * time.Second), CPUPercent: 25.5, MemoryUsage: 80 << 20, NetworkRxRate: 1024}
+	return domain.ContainerStats{
+		Kind:          domain.ContainerStatsKindRoute,
+		Name:          "app.example.com",
+		Owner:         "app.example.com",
+		ContainerID:   "ctr-1",
+		ContainerName: "gordon-app.example.com",
+		Latest:        latest,
+		History:       []domain.ResourceSample{first, latest},
+	}
+}
+
+func TestHandler_StatsListOmitsHistoryByDefault(t *testing.T) {
+	statsSvc := inmocks.NewMockStatsService(t)
+	statsSvc.EXPECT().ListStats(mock.Anything).Return([]domain.ContainerStats{testContainerStats()}, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.StatsSvc = statsSvc })
+	server := newScopedTestServer(t, handler, "admin:status:read")
+
+	resp, err := http.Get(server.URL + "/admin/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body dto.StatsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Containers, 1)
+	assert.Equal(t, "route", body.Containers[0].Kind)
+	assert.InDelta(t, 25.5, body.Containers[0].Latest.CPUPercent, 0.001)
+	assert.Empty(t, body.Containers[0].History)
+}
+
+func TestHandler_StatsForDomainIncludesHistory(t *testing.T) {
+	statsSvc := inmocks.NewMockStatsService(t)
+	statsSvc.EXPECT().GetStats(mock.Anything, "app.example.com").Return([]domain.ContainerStats{testContainerStats()}, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.StatsSvc = statsSvc })
+	server := newScopedTestServer(t, handler, "admin:status:read")
+
+	resp, err := http.Get(server.URL + "/admin/stats/app.example.com")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body dto.StatsResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Containers, 1)
+	require.Len(t, body.Containers[0].History, 2)
+	assert.Equal(t, uint64(80<<20), body.Containers[0].History[1].MemoryUsage)
+}
+
+func TestHandler_StatsRequiresStatusRead(t *testing.T) {
+	statsSvc := inmocks.NewMockStatsService(t)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.StatsSvc = statsSvc })
+	server := newScopedTestServer(t, handler, "admin:config:read")
+
+	resp, err := http.Get(server.URL + "/admin/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestHandler_StatsDisabled(t *testing.T) {
+	statsSvc := inmocks.NewMockStatsService(t)
+	statsSvc.EXPECT().ListStats(mock.Anything).Return(nil, domain.ErrStatsDisabled)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.StatsSvc = statsSvc })
+	server := newScopedTestServer(t, handler, "admin:status:read")
+
+	resp, err := http.Get(server.URL + "/admin/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
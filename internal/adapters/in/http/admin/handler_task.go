@@ -0,0 +1,152 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleRun handles POST /admin/run/<domain>. It runs a one-off command with
+// the route's image and streams its output as newline-delimited JSON frames,
+// ending with a frame that carries the exit code.
+func (h *Handler) handleRun(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodPost {
+		h.sendError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	ctx := r.Context()
+	log := zerowrap.FromCtx(ctx)
+
+	// A task sees the route's secrets, so it needs both scopes.
+	if !HasAccess(ctx, domain.AdminResourceConfig, domain.AdminActionWrite) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for config:write")
+		return
+	}
+	if !HasAccess(ctx, domain.AdminResourceSecrets, domain.AdminActionRead) {
+		h.sendError(w, http.StatusForbidden, "insufficient permissions for secrets:read")
+		return
+	}
+
+	runDomain := strings.TrimPrefix(path, "/run/")
+	if runDomain == "" || runDomain == "/run" {
+		h.sendError(w, http.StatusBadRequest, "domain required in path")
+		return
+	}
+	if err := validation.ValidateDomainParam(runDomain); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid domain")
+		return
+	}
+
+	if h.taskSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "task runner not available")
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.sendError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxAdminRequestSize)
+	var req dto.RunTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid JSON")
+		return
+	}
+	if len(req.Command) == 0 || req.Command[0] == "" {
+		h.sendError(w, http.StatusBadRequest, "command required")
+		return
+	}
+
+	route, err := h.configSvc.GetRoute(ctx, runDomain)
+	if err != nil {
+		if errors.Is(err, domain.ErrRouteNotFound) {
+			h.sendError(w, http.StatusNotFound, "route not found")
+			return
+		}
+		log.Error().Err(err).Str("domain", runDomain).Msg("failed to load route")
+		h.sendError(w, http.StatusInternalServerError, "failed to run task")
+		return
+	}
+
+	// Tasks such as migrations may outlive the server write timeout.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	frames := &taskFrameWriter{w: w, flusher: flusher, enc: json.NewEncoder(w)}
+	task := domain.TaskRequest{Command: req.Command, TTY: req.TTY}
+	exitCode, err := h.taskSvc.RunTask(ctx, *route, task,
+		taskStreamWriter{frames: frames, stream: dto.TaskStreamStdout},
+		taskStreamWriter{frames: frames, stream: dto.TaskStreamStderr})
+	if err != nil {
+		log.Error().Err(err).Str("domain", runDomain).Msg("failed to run task")
+		if !frames.hasStarted() {
+			switch {
+			case errors.Is(err, domain.ErrContainerNotFound):
+				h.sendError(w, http.StatusConflict, "route has no deployed container")
+			case errors.Is(err, domain.ErrTaskCommandRequired):
+				h.sendError(w, http.StatusBadRequest, "command required")
+			default:
+				h.sendError(w, http.StatusInternalServerError, "failed to run task")
+			}
+			return
+		}
+		_ = frames.write(dto.TaskOutputFrame{Error: "failed to run task"})
+		return
+	}
+	_ = frames.write(dto.TaskOutputFrame{ExitCode: &exitCode})
+}
+
+// taskFrameWriter writes task output frames, sending the response header
+// with the first frame so errors before any output keep their status code.
+type taskFrameWriter struct {
+	mu      sync.Mutex
+	w       http.ResponseWriter
+	flusher http.Flusher
+	enc     *json.Encoder
+	started bool
+}
+
+func (f *taskFrameWriter) write(frame dto.TaskOutputFrame) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.started {
+		f.w.Header().Set("Content-Type", "application/x-ndjson")
+		f.w.Header().Set("Cache-Control", "no-cache")
+		f.w.WriteHeader(http.StatusOK)
+		f.started = true
+	}
+	if err := f.enc.Encode(frame); err != nil {
+		return err
+	}
+	f.flusher.Flush()
+	return nil
+}
+
+func (f *taskFrameWriter) hasStarted() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.started
+}
+
+// taskStreamWriter turns writes to one task stream into output frames.
+type taskStreamWriter struct {
+	frames *taskFrameWriter
+	stream string
+}
+
+func (s taskStreamWriter) Write(p []byte) (int, error) {
+	if err := s.frames.write(dto.TaskOutputFrame{Stream: s.stream, Data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
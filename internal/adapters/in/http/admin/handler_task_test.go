@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestHandler_RunStreamsFramesAndExitCode(t *testing.T) {
+	route := &domain.Route{Domain: "app.example.com", Image: "app:latest"}
+	configSvc := inmocks.NewMockConfigService(t)
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").Return(route, nil)
+	taskSvc := inmocks.NewMockTaskService(t)
+	taskSvc.EXPECT().RunTask(mock.Anything, *route, domain.TaskRequest{Command: []string{"./manage", "migrate"}}, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Route, _ domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+			_, _ = io.WriteString(stdout, "applied 3 migrations\n")
+			_, _ = io.WriteString(stderr, "warning: slow query\n")
+			return 2, nil
+		})
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+		d.TaskSvc = taskSvc
+	})
+	server := newScopedTestServer(t, handler, "admin:config:write", "admin:secrets:read")
+
+	resp, err := http.Post(server.URL+"/admin/run/app.example.com", "application/json", strings.NewReader(`{"command":["./manage","migrate"]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-ndjson", resp.Header.Get("Content-Type"))
+
+	var frames []dto.TaskOutputFrame
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var frame dto.TaskOutputFrame
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &frame))
+		frames = append(frames, frame)
+	}
+	require.Len(t, frames, 3)
+	assert.Equal(t, dto.TaskOutputFrame{Stream: dto.TaskStreamStdout, Data: []byte("applied 3 migrations\n")}, frames[0])
+	assert.Equal(t, dto.TaskOutputFrame{Stream: dto.TaskStreamStderr, Data: []byte("warning: slow query\n")}, frames[1])
+	require.NotNil(t, frames[2].ExitCode)
+	assert.Equal(t, 2, *frames[2].ExitCode)
+}
+
+func TestHandler_RunErrorsBeforeOutputKeepStatus(t *testing.T) {
+	route := &domain.Route{Domain: "app.example.com", Image: "app:latest"}
+	configSvc := inmocks.NewMockConfigService(t)
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").Return(route, nil)
+	taskSvc := inmocks.NewMockTaskService(t)
+	taskSvc.EXPECT().RunTask(mock.Anything, *route, mock.Anything, mock.Anything, mock.Anything).Return(0, domain.ErrContainerNotFound)
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+		d.TaskSvc = taskSvc
+	})
+	server := newScopedTestServer(t, handler, "admin:config:write", "admin:secrets:read")
+
+	resp, err := http.Post(server.URL+"/admin/run/app.example.com", "application/json", strings.NewReader(`{"command":["true"]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestHandler_RunRequiresSecretsRead(t *testing.T) {
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.TaskSvc = inmocks.NewMockTaskService(t) })
+	server := newScopedTestServer(t, handler, "admin:config:write")
+
+	resp, err := http.Post(server.URL+"/admin/run/app.example.com", "application/json", strings.NewReader(`{"command":["env"]}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
@@ -1606,6 +1606,57 @@ func TestHandler_RoutesGet_NotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
 
+func TestHandler_RouteProcessesGet(t *testing.T) {
+	configSvc := inmocks.NewMockConfigService(t)
+	containerSvc := inmocks.NewMockContainerService(t)
+
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+		d.ContainerSvc = containerSvc
+	})
+
+	route := &domain.Route{
+		Domain:    "app.example.com",
+		Image:     "app:latest",
+		Processes: map[string][]string{"worker": {"bundle", "exec", "sidekiq"}},
+	}
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").Return(route, nil)
+	containerSvc.EXPECT().ListProcesses(mock.Anything, *route).Return([]domain.Process{
+		{Name: "worker", Command: []string{"bundle", "exec", "sidekiq"}, ContainerID: "worker-1", Status: "running", ConfigHash: "abc"},
+	})
+
+	req := httptest.NewRequest("GET", "/admin/routes/app.example.com/processes", nil)
+	req = req.WithContext(ctxWithScopes("admin:routes:read"))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp dto.ProcessesResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, []dto.Process{
+		{Name: "worker", Command: []string{"bundle", "exec", "sidekiq"}, ContainerID: "worker-1", Status: "running"},
+	}, resp.Processes)
+}
+
+func TestHandler_RouteProcessesGet_NotFound(t *testing.T) {
+	configSvc := inmocks.NewMockConfigService(t)
+
+	handler := newTestHandler(t, func(d *HandlerDeps) {
+		d.ConfigSvc = configSvc
+	})
+
+	configSvc.EXPECT().GetRoute(mock.Anything, "missing.example.com").Return(nil, domain.ErrRouteNotFound)
+
+	req := httptest.NewRequest("GET", "/admin/routes/missing.example.com/processes", nil)
+	req = req.WithContext(ctxWithScopes("admin:routes:read"))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
 func TestHandler_RouteCleanupPreview_RequiresRoutesConfigAndVolumesReadScopes(t *testing.T) {
 	configSvc := inmocks.NewMockConfigService(t)
 	authSvc := inmocks.NewMockAuthService(t)
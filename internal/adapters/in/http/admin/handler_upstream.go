@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// handleRouteUpstreamStatus handles GET /admin/routes/<domain>/upstream.
+// Callers have already checked routes:read.
+func (h *Handler) handleRouteUpstreamStatus(w http.ResponseWriter, r *http.Request, routeDomain string) {
+	ctx := r.Context()
+	log := zerowrap.FromCtx(ctx)
+
+	if err := validation.ValidateDomainParam(routeDomain); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid domain")
+		return
+	}
+
+	if h.upstreamSvc == nil {
+		h.sendError(w, http.StatusServiceUnavailable, "upstream status not available")
+		return
+	}
+
+	status, err := h.upstreamSvc.UpstreamStatus(ctx, routeDomain)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrRouteNotFound):
+			h.sendError(w, http.StatusNotFound, "route not found")
+		case errors.Is(err, domain.ErrRouteDomainInvalid):
+			h.sendError(w, http.StatusBadRequest, "invalid domain")
+		default:
+			log.Error().Err(err).Str("domain", routeDomain).Msg("failed to get upstream status")
+			h.sendError(w, http.StatusInternalServerError, "failed to get upstream status")
+		}
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, dto.UpstreamStatusFromDomain(status))
+}
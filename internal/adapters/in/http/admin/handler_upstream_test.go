@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/dto"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestHandler_RouteUpstreamStatus(t *testing.T) {
+	openedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	upstreamSvc := inmocks.NewMockUpstreamStatusService(t)
+	upstreamSvc.EXPECT().UpstreamStatus(mock.Anything, "app.example.com").Return(domain.UpstreamStatus{
+		Domain:              "app.example.com",
+		State:               domain.CircuitOpen,
+		ConsecutiveFailures: 5,
+		Threshold:           5,
+		Cooldown:            30 * time.Second,
+		OpenedAt:            openedAt,
+		LastError:           "dial tcp 10.0.0.5:8080: connect: connection refused",
+		LastErrorAt:         openedAt,
+	}, nil)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.UpstreamSvc = upstreamSvc })
+	server := newScopedTestServer(t, handler, "admin:routes:read")
+
+	resp, err := http.Get(server.URL + "/admin/routes/app.example.com/upstream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body dto.UpstreamStatusResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, domain.CircuitOpen, body.State)
+	assert.Equal(t, 5, body.ConsecutiveFailures)
+	assert.Equal(t, "30s", body.Cooldown)
+	require.NotNil(t, body.OpenedAt)
+	assert.True(t, openedAt.Equal(*body.OpenedAt))
+}
+
+func TestHandler_RouteUpstreamStatusNotFound(t *testing.T) {
+	upstreamSvc := inmocks.NewMockUpstreamStatusService(t)
+	upstreamSvc.EXPECT().UpstreamStatus(mock.Anything, "missing.example.com").Return(domain.UpstreamStatus{}, domain.ErrRouteNotFound)
+	handler := newTestHandler(t, func(d *HandlerDeps) { d.UpstreamSvc = upstreamSvc })
+	server := newScopedTestServer(t, handler, "admin:routes:read")
+
+	resp, err := http.Get(server.URL + "/admin/routes/missing.example.com/upstream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -2,6 +2,7 @@
 package middleware
 
 import (
+	"bufio"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
@@ -63,6 +64,12 @@ func (rw *ResponseWriter) Flush() {
 	}
 }
 
+// Hijack implements http.Hijacker so websocket endpoints keep working
+// behind the request logger.
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
 // Unwrap returns the underlying ResponseWriter.
 func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
 	return rw.ResponseWriter
@@ -84,3 +84,21 @@ func TestPanicRecovery_RethrowsReverseProxyAbort(t *testing.T) {
 	})
 	assert.NotEqual(t, http.StatusInternalServerError, rw.Code)
 }
+
+func TestResponseWriter_HijackReachesUnderlyingConnection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		conn, buf, err := NewResponseWriter(w).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		_, _ = buf.WriteString("HTTP/1.1 204 No Content\r\nConnection: close\r\n\r\n")
+		_ = buf.Flush()
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
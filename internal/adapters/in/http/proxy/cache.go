@@ -0,0 +1,517 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// revalidateTimeout bounds a background refresh of a stale cache entry.
+const revalidateTimeout = time.Minute
+
+// cacheableStatus lists the status codes that are heuristically cacheable
+// (RFC 9110 section 15.1). Only these are stored, and only with explicit
+// freshness information.
+var cacheableStatus = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMultipleChoices:      true,
+	http.StatusMovedPermanently:     true,
+	http.StatusPermanentRedirect:    true,
+	http.StatusNotFound:             true,
+	http.StatusMethodNotAllowed:     true,
+	http.StatusGone:                 true,
+	http.StatusRequestURITooLong:    true,
+	http.StatusNotImplemented:       true,
+}
+
+// notModifiedHeaders are the stored headers repeated in a 304 response.
+var notModifiedHeaders = []string{"Cache-Control", "Content-Location", "Date", "ETag", "Expires", "Vary"}
+
+// responseCache implements the HTTP caching semantics of the proxy on top of
+// a ResponseCacheStore: Cache-Control, Expires and Vary handling,
+// stale-while-revalidate, and coalescing of concurrent misses.
+type responseCache struct {
+	store out.ResponseCacheStore
+	now   func() time.Time
+
+	mu    sync.Mutex
+	fills map[string]*cacheFill // primary key → in-progress upstream fetch
+}
+
+// cacheFill tracks an upstream fetch that other requests for the same URL
+// wait on instead of hitting the container themselves.
+type cacheFill struct {
+	done chan struct{}
+}
+
+func newResponseCache(store out.ResponseCacheStore) *responseCache {
+	return &responseCache{
+		store: store,
+		now:   time.Now,
+		fills: make(map[string]*cacheFill),
+	}
+}
+
+// serveWithCache serves a request for a cache-enabled route.
+func (h *Handler) serveWithCache(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, maxResponseSize int64) {
+	c := h.cache
+	domainName, ok := domain.CanonicalRouteDomain(normalizeRequestHost(r.Host))
+	if !ok {
+		h.proxyToTarget(w, r, target, maxResponseSize, nil)
+		return
+	}
+
+	if !isSafeMethod(r.Method) {
+		h.proxyToTarget(w, r, target, maxResponseSize, c.purgeOnSuccess(r, domainName))
+		return
+	}
+	if !cacheableRequest(r) {
+		h.proxyToTarget(w, r, target, maxResponseSize, nil)
+		return
+	}
+
+	key := domainName + r.URL.RequestURI()
+	lookup := !requestBypassesCache(r)
+	if lookup {
+		if entry, ok := c.lookup(r, key); ok {
+			if entry.Fresh(c.now()) {
+				h.serveCachedEntry(w, r, target, entry, "HIT")
+				return
+			}
+			h.serveCachedEntry(w, r, target, entry, "STALE")
+			h.revalidate(r, target, maxResponseSize, domainName, key)
+			return
+		}
+	}
+
+	// HEAD responses carry no body, so they are never stored.
+	if r.Method == http.MethodHead {
+		h.proxyToTarget(w, r, target, maxResponseSize, nil)
+		return
+	}
+
+	if lookup {
+		fill, leader := c.join(key)
+		if leader {
+			defer c.release(key, fill)
+		} else {
+			select {
+			case <-fill.done:
+			case <-r.Context().Done():
+				clientClosedRequest(w)
+				return
+			}
+			if entry, ok := c.lookup(r, key); ok && entry.Fresh(c.now()) {
+				h.serveCachedEntry(w, r, target, entry, "HIT")
+				return
+			}
+		}
+	}
+
+	h.proxyToTarget(w, r, target, maxResponseSize, c.capture(r, target.Cache, domainName, key))
+}
+
+// serveCachedEntry serves a cached response. It counts as traffic for the
+// route container, so a route served from cache does not reach its
+// idle_timeout.
+func (h *Handler) serveCachedEntry(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, entry *domain.CachedResponse, status string) {
+	releaseInFlight := h.proxySvc.TrackInFlight(target.ContainerID)
+	defer releaseInFlight()
+	h.cache.serveEntry(w, r, target, entry, status)
+}
+
+// revalidate refreshes a stale entry in the background. At most one refresh
+// per URL runs at a time.
+func (h *Handler) revalidate(r *http.Request, target *domain.ProxyTarget, maxResponseSize int64, domainName, key string) {
+	c := h.cache
+	fill, leader := c.join(key)
+	if !leader {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), revalidateTimeout)
+	req := r.Clone(ctx)
+	req.Method = http.MethodGet
+	req.Body = http.NoBody
+	req.ContentLength = 0
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+
+	go func() {
+		defer cancel()
+		defer c.release(key, fill)
+		h.proxyToTarget(newDiscardResponseWriter(), req, target, maxResponseSize, c.capture(req, target.Cache, domainName, key))
+	}()
+}
+
+// join registers an upstream fetch for key. It returns the existing fill and
+// false when another request is already fetching it.
+func (c *responseCache) join(key string) (*cacheFill, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if fill, ok := c.fills[key]; ok {
+		return fill, false
+	}
+	fill := &cacheFill{done: make(chan struct{})}
+	c.fills[key] = fill
+	return fill, true
+}
+
+func (c *responseCache) release(key string, fill *cacheFill) {
+	c.mu.Lock()
+	if c.fills[key] == fill {
+		delete(c.fills, key)
+	}
+	c.mu.Unlock()
+	close(fill.done)
+}
+
+// lookup returns the stored response matching the request, following the
+// primary entry to the variant selected by its Vary headers.
+func (c *responseCache) lookup(r *http.Request, key string) (*domain.CachedResponse, bool) {
+	entry, ok := c.store.Get(r.Context(), key)
+	if !ok || len(entry.Vary) == 0 {
+		return entry, ok
+	}
+	return c.store.Get(r.Context(), variantKey(key, entry.Vary, r.Header))
+}
+
+// serveEntry writes a stored response, answering conditional requests with
+// 304 and compressing the body like a proxied response.
+func (c *responseCache) serveEntry(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, entry *domain.CachedResponse, status string) {
+	age := int64(c.now().Sub(entry.StoredAt) / time.Second)
+	if age < 0 {
+		age = 0
+	}
+
+	if entry.StatusCode == http.StatusOK && etagMatches(r.Header.Get("If-None-Match"), http.Header(entry.Header).Get("ETag")) {
+		for _, name := range notModifiedHeaders {
+			if values := http.Header(entry.Header).Values(name); len(values) > 0 {
+				w.Header()[name] = slices.Clone(values)
+			}
+		}
+		w.Header().Set("Age", strconv.FormatInt(age, 10))
+		w.Header().Set("X-Cache", status)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	resp := &http.Response{
+		StatusCode:    entry.StatusCode,
+		Header:        http.Header(entry.Header).Clone(),
+		Body:          io.NopCloser(bytes.NewReader(entry.Body)),
+		ContentLength: int64(len(entry.Body)),
+	}
+	defer resp.Body.Close()
+	resp.Header.Set("Content-Length", strconv.Itoa(len(entry.Body)))
+	compressResponse(target.Compression, r, resp)
+
+	header := w.Header()
+	for name, values := range resp.Header {
+		header[name] = values
+	}
+	header.Set("Age", strconv.FormatInt(age, 10))
+	header.Set("X-Cache", status)
+	w.WriteHeader(resp.StatusCode)
+	if r.Method != http.MethodHead {
+		_, _ = io.Copy(w, resp.Body)
+	}
+}
+
+// capture returns a response hook that stores a storable upstream response
+// once its body has been read to the end.
+func (c *responseCache) capture(r *http.Request, policy *domain.ResponseCachePolicy, domainName, key string) func(*http.Response) {
+	maxSize := policy.MaxObjectSize
+	if maxSize <= 0 {
+		maxSize = domain.DefaultResponseCacheMaxObjectSize
+	}
+
+	return func(resp *http.Response) {
+		header := resp.Header.Clone()
+		resp.Header.Set("X-Cache", "MISS")
+
+		now := c.now()
+		storedAt, freshUntil, staleUntil, ok := cacheLifetime(resp.StatusCode, header, now)
+		if !ok || resp.ContentLength > maxSize {
+			return
+		}
+		vary := varyHeaders(header)
+		if slices.Contains(vary, "*") {
+			return
+		}
+
+		entry := &domain.CachedResponse{
+			Key:        key,
+			Domain:     domainName,
+			Path:       r.URL.Path,
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			StoredAt:   storedAt,
+			FreshUntil: freshUntil,
+			StaleUntil: staleUntil,
+		}
+		if len(vary) > 0 {
+			entry.Key = variantKey(key, vary, r.Header)
+		}
+
+		store := func(body []byte) {
+			ctx := context.WithoutCancel(r.Context())
+			log := zerowrap.FromCtx(ctx)
+			entry.Body = body
+			if len(vary) > 0 {
+				primary := *entry
+				primary.Key, primary.Header, primary.Body, primary.Vary = key, nil, nil, vary
+				if err := c.store.Put(ctx, &primary); err != nil {
+					log.Warn().Err(err).Str("key", key).Msg("failed to store cached response")
+					return
+				}
+			}
+			if err := c.store.Put(ctx, entry); err != nil {
+				log.Warn().Err(err).Str("key", entry.Key).Msg("failed to store cached response")
+			}
+		}
+
+		if resp.Body == nil || resp.Body == http.NoBody {
+			store(nil)
+			return
+		}
+		resp.Body = &captureBody{ReadCloser: resp.Body, maxSize: maxSize, store: store}
+	}
+}
+
+// purgeOnSuccess returns a response hook that drops the cached responses for
+// the request path once an unsafe method succeeded on it.
+func (c *responseCache) purgeOnSuccess(r *http.Request, domainName string) func(*http.Response) {
+	return func(resp *http.Response) {
+		if resp.StatusCode >= http.StatusBadRequest {
+			return
+		}
+		ctx := r.Context()
+		if _, err := c.store.Purge(ctx, domainName, r.URL.Path); err != nil {
+			log := zerowrap.FromCtx(ctx)
+			log.Warn().Err(err).Str("path", r.URL.Path).Msg("failed to purge cached responses")
+		}
+	}
+}
+
+// captureBody buffers a response body as it is streamed to the client and
+// hands it to store on a clean EOF. Bodies larger than maxSize are passed
+// through without being stored.
+type captureBody struct {
+	io.ReadCloser
+	buf      bytes.Buffer
+	maxSize  int64
+	overflow bool
+	done     bool
+	store    func([]byte)
+}
+
+func (b *captureBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if !b.overflow && !b.done {
+		if int64(b.buf.Len()+n) > b.maxSize {
+			b.overflow = true
+			b.buf = bytes.Buffer{}
+		} else {
+			b.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !b.overflow && !b.done {
+		b.done = true
+		b.store(bytes.Clone(b.buf.Bytes()))
+	}
+	return n, err
+}
+
+// cacheableRequest reports whether a response to r may be served from or
+// stored in the shared cache.
+func cacheableRequest(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if r.Header.Get("Authorization") != "" || r.Header.Get("Range") != "" || r.Header.Get("Upgrade") != "" {
+		return false
+	}
+	_, noStore := parseCacheControl(r.Header.Values("Cache-Control"))["no-store"]
+	return !noStore
+}
+
+// requestBypassesCache reports whether the client asked for a response
+// validated by the origin. Such requests skip the lookup but still refresh
+// the cache.
+func requestBypassesCache(r *http.Request) bool {
+	directives := parseCacheControl(r.Header.Values("Cache-Control"))
+	if _, ok := directives["no-cache"]; ok {
+		return true
+	}
+	if maxAge, ok := directiveSeconds(directives, "max-age"); ok && maxAge == 0 {
+		return true
+	}
+	return len(directives) == 0 && strings.EqualFold(r.Header.Get("Pragma"), "no-cache")
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+// cacheLifetime computes when a response was generated and until when it is
+// fresh and may be served stale. ok is false when the response must not be
+// stored: only responses with explicit freshness information are cached.
+func cacheLifetime(status int, header http.Header, now time.Time) (storedAt, freshUntil, staleUntil time.Time, ok bool) {
+	if !cacheableStatus[status] || len(header.Values("Set-Cookie")) > 0 {
+		return storedAt, freshUntil, staleUntil, false
+	}
+	directives := parseCacheControl(header.Values("Cache-Control"))
+	for _, name := range []string{"no-store", "no-cache", "private"} {
+		if _, found := directives[name]; found {
+			return storedAt, freshUntil, staleUntil, false
+		}
+	}
+
+	var age time.Duration
+	if seconds, err := strconv.ParseInt(header.Get("Age"), 10, 64); err == nil && seconds > 0 {
+		age = time.Duration(seconds) * time.Second
+	}
+	storedAt = now.Add(-age)
+
+	lifetime, found := directiveSeconds(directives, "s-maxage")
+	if !found {
+		lifetime, found = directiveSeconds(directives, "max-age")
+	}
+	if !found {
+		expires, err := http.ParseTime(header.Get("Expires"))
+		if err != nil {
+			return storedAt, freshUntil, staleUntil, false
+		}
+		date, err := http.ParseTime(header.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		lifetime = expires.Sub(date)
+	}
+
+	freshUntil = storedAt.Add(lifetime)
+	if !now.Before(freshUntil) {
+		return storedAt, freshUntil, staleUntil, false
+	}
+	staleUntil = freshUntil
+	_, mustRevalidate := directives["must-revalidate"]
+	_, proxyRevalidate := directives["proxy-revalidate"]
+	if swr, found := directiveSeconds(directives, "stale-while-revalidate"); found && !mustRevalidate && !proxyRevalidate {
+		staleUntil = freshUntil.Add(swr)
+	}
+	return storedAt, freshUntil, staleUntil, true
+}
+
+// parseCacheControl parses Cache-Control header values into lower-cased
+// directive names mapped to their unquoted arguments.
+func parseCacheControl(values []string) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(part), "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+		}
+	}
+	return directives
+}
+
+func directiveSeconds(directives map[string]string, name string) (time.Duration, bool) {
+	arg, ok := directives[name]
+	if !ok {
+		return 0, false
+	}
+	seconds, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
+
+// varyHeaders returns the sorted, canonical request header names listed in
+// the Vary response header.
+func varyHeaders(header http.Header) []string {
+	var names []string
+	for _, value := range header.Values("Vary") {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if name != "*" {
+				name = http.CanonicalHeaderKey(name)
+			}
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// variantKey extends a primary key with the request values of the Vary
+// headers.
+func variantKey(key string, vary []string, header http.Header) string {
+	var b strings.Builder
+	b.WriteString(key)
+	for _, name := range vary {
+		b.WriteString("\x00")
+		b.WriteString(name)
+		b.WriteString("=")
+		b.WriteString(strings.Join(header.Values(name), ","))
+	}
+	return b.String()
+}
+
+// etagMatches implements the weak comparison of If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// discardResponseWriter is the ResponseWriter of background revalidations.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func newDiscardResponseWriter() *discardResponseWriter {
+	return &discardResponseWriter{header: make(http.Header)}
+}
+
+func (d *discardResponseWriter) Header() http.Header         { return d.header }
+func (d *discardResponseWriter) Write(p []byte) (int, error) { return len(p), nil }
+func (d *discardResponseWriter) WriteHeader(int)             {}
@@ -0,0 +1,368 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/in"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// fakeCacheStore is an in-memory ResponseCacheStore without size limits.
+type fakeCacheStore struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries map[string]*domain.CachedResponse
+}
+
+func newFakeCacheStore(now func() time.Time) *fakeCacheStore {
+	return &fakeCacheStore{now: now, entries: make(map[string]*domain.CachedResponse)}
+}
+
+func (s *fakeCacheStore) Get(_ context.Context, key string) (*domain.CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok || entry.Expired(s.now()) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (s *fakeCacheStore) Put(_ context.Context, entry *domain.CachedResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.Key] = entry
+	return nil
+}
+
+func (s *fakeCacheStore) Purge(_ context.Context, domainName, path string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	purged := 0
+	for key, entry := range s.entries {
+		if entry.Domain == domainName && (path == "" || entry.Path == path) {
+			delete(s.entries, key)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// testClock is a manually advanced clock shared by the cache and its store.
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newCachingHandler(t *testing.T, backend *httptest.Server, compression *domain.CompressionPolicy) (*Handler, *fakeCacheStore, *testClock) {
+	t.Helper()
+	clock := &testClock{now: time.Now()}
+	store := newFakeCacheStore(clock.Now)
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{MaxResponseSize: 1 << 20})
+	proxySvc.EXPECT().IsRegistryDomain("web.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "web.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        backend.Listener.Addr().(*net.TCPAddr).Port,
+		ContainerID: "web-1",
+		Scheme:      "http",
+		Compression: compression,
+		Cache:       &domain.ResponseCachePolicy{Enabled: true, MaxObjectSize: 1 << 10},
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("web-1").Return(func() {}).Maybe()
+
+	handler := NewHandler(proxySvc, nil, zerowrap.Default())
+	handler.SetResponseCache(store)
+	handler.cache.now = clock.Now
+	return handler, store, clock
+}
+
+func doCachedRequest(handler http.Handler, method, path string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "http://web.example.com"+path, nil)
+	req.Host = "web.example.com"
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestResponseCache_ServesFreshHits(t *testing.T) {
+	var hits atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = io.WriteString(w, "hello "+r.URL.RawQuery)
+	}))
+	defer backend.Close()
+	handler, _, clock := newCachingHandler(t, backend, nil)
+
+	first := doCachedRequest(handler, http.MethodGet, "/page?a=1", nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "MISS", first.Header().Get("X-Cache"))
+
+	clock.Advance(10 * time.Second)
+	second := doCachedRequest(handler, http.MethodGet, "/page?a=1", nil)
+	assert.Equal(t, "HIT", second.Header().Get("X-Cache"))
+	assert.Equal(t, "10", second.Header().Get("Age"))
+	assert.Equal(t, "hello a=1", second.Body.String())
+
+	notModified := doCachedRequest(handler, http.MethodGet, "/page?a=1", http.Header{"If-None-Match": {`"v1"`}})
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+
+	other := doCachedRequest(handler, http.MethodGet, "/page?a=2", nil)
+	assert.Equal(t, "hello a=2", other.Body.String())
+	assert.Equal(t, int32(2), hits.Load())
+
+	clock.Advance(time.Minute)
+	doCachedRequest(handler, http.MethodGet, "/page?a=1", nil)
+	assert.Equal(t, int32(3), hits.Load(), "expired entries are fetched again")
+}
+
+func TestResponseCache_HitsCountAsActivity(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=60")
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer backend.Close()
+	handler, _, _ := newCachingHandler(t, backend, nil)
+	proxySvc := handler.proxySvc.(*inmocks.MockProxyService)
+
+	doCachedRequest(handler, http.MethodGet, "/page", nil)
+	proxySvc.AssertNumberOfCalls(t, "TrackInFlight", 1)
+
+	// Hits keep the route awake so it does not reach its idle_timeout.
+	hit := doCachedRequest(handler, http.MethodGet, "/page", nil)
+	require.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	proxySvc.AssertNumberOfCalls(t, "TrackInFlight", 2)
+}
+
+func TestResponseCache_DoesNotStoreUncacheableResponses(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		req    http.Header
+	}{
+		{"no freshness", http.Header{}, nil},
+		{"no-store", http.Header{"Cache-Control": {"no-store, max-age=60"}}, nil},
+		{"private", http.Header{"Cache-Control": {"private, max-age=60"}}, nil},
+		{"set-cookie", http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"session=1"}}, nil},
+		{"vary star", http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"*"}}, nil},
+		{"authorized request", http.Header{"Cache-Control": {"max-age=60"}}, http.Header{"Authorization": {"Bearer x"}}},
+		{"too large", http.Header{"Cache-Control": {"max-age=60"}, "X-Large": {"1"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hits atomic.Int32
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				for name, values := range tt.header {
+					w.Header()[name] = values
+				}
+				body := "hello"
+				if tt.header.Get("X-Large") != "" {
+					body = string(make([]byte, 2<<10))
+				}
+				_, _ = io.WriteString(w, body)
+			}))
+			defer backend.Close()
+			handler, _, _ := newCachingHandler(t, backend, nil)
+
+			doCachedRequest(handler, http.MethodGet, "/", tt.req)
+			rec := doCachedRequest(handler, http.MethodGet, "/", tt.req)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, int32(2), hits.Load())
+		})
+	}
+}
+
+func TestResponseCache_Vary(t *testing.T) {
+	var hits atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Vary", "Accept-Language")
+		_, _ = io.WriteString(w, "lang="+r.Header.Get("Accept-Language"))
+	}))
+	defer backend.Close()
+	handler, _, _ := newCachingHandler(t, backend, nil)
+
+	fr := http.Header{"Accept-Language": {"fr"}}
+	en := http.Header{"Accept-Language": {"en"}}
+	doCachedRequest(handler, http.MethodGet, "/", fr)
+	doCachedRequest(handler, http.MethodGet, "/", en)
+
+	rec := doCachedRequest(handler, http.MethodGet, "/", fr)
+	assert.Equal(t, "HIT", rec.Header().Get("X-Cache"))
+	assert.Equal(t, "lang=fr", rec.Body.String())
+	rec = doCachedRequest(handler, http.MethodGet, "/", en)
+	assert.Equal(t, "lang=en", rec.Body.String())
+	assert.Equal(t, int32(2), hits.Load())
+}
+
+func TestResponseCache_StaleWhileRevalidate(t *testing.T) {
+	var version atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		v := version.Add(1)
+		w.Header().Set("Cache-Control", "max-age=10, stale-while-revalidate=60")
+		_, _ = io.WriteString(w, "v"+string(rune('0'+v)))
+	}))
+	defer backend.Close()
+	handler, store, clock := newCachingHandler(t, backend, nil)
+
+	doCachedRequest(handler, http.MethodGet, "/", nil)
+	clock.Advance(20 * time.Second)
+
+	stale := doCachedRequest(handler, http.MethodGet, "/", nil)
+	assert.Equal(t, "STALE", stale.Header().Get("X-Cache"))
+	assert.Equal(t, "v1", stale.Body.String())
+
+	require.Eventually(t, func() bool {
+		entry, ok := store.Get(context.Background(), "web.example.com/")
+		return ok && string(entry.Body) == "v2"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	fresh := doCachedRequest(handler, http.MethodGet, "/", nil)
+	assert.Equal(t, "HIT", fresh.Header().Get("X-Cache"))
+	assert.Equal(t, "v2", fresh.Body.String())
+}
+
+func TestResponseCache_CoalescesConcurrentMisses(t *testing.T) {
+	var hits atomic.Int32
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		<-release
+		w.Header().Set("Cache-Control", "max-age=60")
+		_, _ = io.WriteString(w, "hello")
+	}))
+	defer backend.Close()
+	handler, _, _ := newCachingHandler(t, backend, nil)
+
+	const clients = 5
+	var wg sync.WaitGroup
+	bodies := make([]string, clients)
+	for i := range clients {
+		wg.Go(func() {
+			bodies[i] = doCachedRequest(handler, http.MethodGet, "/", nil).Body.String()
+		})
+	}
+
+	require.Eventually(t, func() bool { return hits.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond) // let the other clients queue behind the fill
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), hits.Load())
+	for _, body := range bodies {
+		assert.Equal(t, "hello", body)
+	}
+}
+
+func TestResponseCache_UnsafeMethodPurgesPath(t *testing.T) {
+	var hits atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			hits.Add(1)
+			w.Header().Set("Cache-Control", "max-age=60")
+		}
+		_, _ = io.WriteString(w, "ok")
+	}))
+	defer backend.Close()
+	handler, _, _ := newCachingHandler(t, backend, nil)
+
+	doCachedRequest(handler, http.MethodGet, "/items", nil)
+	doCachedRequest(handler, http.MethodPost, "/items", nil)
+	rec := doCachedRequest(handler, http.MethodGet, "/items", nil)
+
+	assert.Equal(t, "MISS", rec.Header().Get("X-Cache"))
+	assert.Equal(t, int32(2), hits.Load())
+}
+
+func TestResponseCache_CompressesHits(t *testing.T) {
+	body := string(make([]byte, 800))
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer backend.Close()
+	handler, _, _ := newCachingHandler(t, backend, &domain.CompressionPolicy{
+		Enabled:      true,
+		Algorithms:   []string{domain.CompressionGzip},
+		ContentTypes: domain.DefaultCompressionContentTypes(),
+	})
+
+	gzipped := http.Header{"Accept-Encoding": {"gzip"}}
+	doCachedRequest(handler, http.MethodGet, "/", gzipped)
+
+	hit := doCachedRequest(handler, http.MethodGet, "/", gzipped)
+	assert.Equal(t, "HIT", hit.Header().Get("X-Cache"))
+	assert.Equal(t, "gzip", hit.Header().Get("Content-Encoding"))
+
+	plain := doCachedRequest(handler, http.MethodGet, "/", nil)
+	assert.Equal(t, "HIT", plain.Header().Get("X-Cache"))
+	assert.Empty(t, plain.Header().Get("Content-Encoding"))
+	assert.Equal(t, body, plain.Body.String())
+}
+
+func TestCacheLifetime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	storedAt, freshUntil, staleUntil, ok := cacheLifetime(http.StatusOK, http.Header{
+		"Cache-Control": {"max-age=60, s-maxage=120, stale-while-revalidate=30"},
+		"Age":           {"20"},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(-20*time.Second), storedAt)
+	assert.Equal(t, now.Add(100*time.Second), freshUntil)
+	assert.Equal(t, now.Add(130*time.Second), staleUntil)
+
+	_, freshUntil, staleUntil, ok = cacheLifetime(http.StatusOK, http.Header{
+		"Cache-Control": {"max-age=60, stale-while-revalidate=30, must-revalidate"},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, freshUntil, staleUntil)
+
+	_, freshUntil, _, ok = cacheLifetime(http.StatusOK, http.Header{
+		"Date":    {now.Format(http.TimeFormat)},
+		"Expires": {now.Add(time.Hour).Format(http.TimeFormat)},
+	}, now)
+	require.True(t, ok)
+	assert.Equal(t, now.Add(time.Hour), freshUntil)
+
+	_, _, _, ok = cacheLifetime(http.StatusPartialContent, http.Header{"Cache-Control": {"max-age=60"}}, now)
+	assert.False(t, ok)
+	_, _, _, ok = cacheLifetime(http.StatusOK, http.Header{"Cache-Control": {"max-age=0"}}, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,99 @@
+package proxy
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"strings"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// Headers carrying the verified client certificate to the upstream. Any
+// incoming header with the prefix is dropped so clients cannot forge them.
+const (
+	clientCertHeaderPrefix      = "X-Client-Cert-"
+	clientCertSubjectHeader     = "X-Client-Cert-Subject"
+	clientCertSANHeader         = "X-Client-Cert-San"
+	clientCertFingerprintHeader = "X-Client-Cert-Fingerprint"
+	clientCertSerialHeader      = "X-Client-Cert-Serial"
+)
+
+// clientAuthStatus enforces the client certificate mode of a target. It
+// returns 0 when the request may proceed, otherwise the status to reply with.
+func clientAuthStatus(r *http.Request, host string, mode domain.ClientAuthMode) int {
+	if mode == "" {
+		return 0
+	}
+	// Client certificates are requested per SNI. A connection opened for
+	// another name (HTTP/2 coalescing) never asked for one, so send the
+	// client to a fresh connection for this host.
+	if r.TLS != nil && normalizeRequestHost(r.TLS.ServerName) != host {
+		return http.StatusMisdirectedRequest
+	}
+	if mode == domain.ClientAuthRequire && verifiedClientCertificate(r) == nil {
+		return http.StatusForbidden
+	}
+	return 0
+}
+
+// verifiedClientCertificate returns the leaf of the verified client chain,
+// or nil when the client did not present a trusted certificate.
+func verifiedClientCertificate(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// setClientCertHeaders replaces the client certificate headers of an
+// outgoing request with the details of the verified client certificate.
+func setClientCertHeaders(out http.Header, in *http.Request) {
+	for name := range out {
+		if strings.HasPrefix(http.CanonicalHeaderKey(name), clientCertHeaderPrefix) {
+			delete(out, name)
+		}
+	}
+
+	cert := verifiedClientCertificate(in)
+	if cert == nil {
+		return
+	}
+	fingerprint := sha256.Sum256(cert.Raw)
+	out.Set(clientCertSubjectHeader, headerSafe(cert.Subject.String()))
+	if sans := certificateSANs(cert); len(sans) > 0 {
+		out.Set(clientCertSANHeader, headerSafe(strings.Join(sans, ", ")))
+	}
+	out.Set(clientCertFingerprintHeader, hex.EncodeToString(fingerprint[:]))
+	out.Set(clientCertSerialHeader, cert.SerialNumber.Text(16))
+}
+
+// certificateSANs lists the subject alternative names of a certificate in
+// openssl notation, e.g. "DNS:laptop.example.com".
+func certificateSANs(cert *x509.Certificate) []string {
+	var sans []string
+	for _, name := range cert.DNSNames {
+		sans = append(sans, "DNS:"+name)
+	}
+	for _, email := range cert.EmailAddresses {
+		sans = append(sans, "email:"+email)
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, "IP:"+ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, "URI:"+uri.String())
+	}
+	return sans
+}
+
+// headerSafe drops control characters that are not allowed in header values.
+func headerSafe(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,158 @@
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/in"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func testClientCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(0xbeef),
+		Subject:        pkix.Name{CommonName: "alice@example.com", Organization: []string{"Gordon"}},
+		EmailAddresses: []string{"alice@example.com"},
+		DNSNames:       []string{"alice-laptop.example.com"},
+		NotBefore:      time.Now().Add(-time.Minute),
+		NotAfter:       time.Now().Add(time.Hour),
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestClientAuthStatus(t *testing.T) {
+	cert := testClientCertificate(t)
+	verified := &tls.ConnectionState{ServerName: "admin.example.com", VerifiedChains: [][]*x509.Certificate{{cert}}}
+	anonymous := &tls.ConnectionState{ServerName: "admin.example.com"}
+	coalesced := &tls.ConnectionState{ServerName: "app.example.com", VerifiedChains: [][]*x509.Certificate{{cert}}}
+
+	tests := []struct {
+		name string
+		mode domain.ClientAuthMode
+		tls  *tls.ConnectionState
+		want int
+	}{
+		{name: "off", mode: "", tls: nil, want: 0},
+		{name: "require with certificate", mode: domain.ClientAuthRequire, tls: verified, want: 0},
+		{name: "require without certificate", mode: domain.ClientAuthRequire, tls: anonymous, want: http.StatusForbidden},
+		{name: "require over plain http", mode: domain.ClientAuthRequire, tls: nil, want: http.StatusForbidden},
+		{name: "optional without certificate", mode: domain.ClientAuthOptional, tls: anonymous, want: 0},
+		{name: "connection for another sni", mode: domain.ClientAuthRequire, tls: coalesced, want: http.StatusMisdirectedRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://admin.example.com/", nil)
+			req.TLS = tt.tls
+			assert.Equal(t, tt.want, clientAuthStatus(req, "admin.example.com", tt.mode))
+		})
+	}
+}
+
+func TestHandler_ForwardsVerifiedClientCertificate(t *testing.T) {
+	received := make(chan http.Header, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("admin.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "admin.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        backend.Listener.Addr().(*net.TCPAddr).Port,
+		ContainerID: "c-1",
+		Scheme:      "http",
+		ClientAuth:  domain.ClientAuthRequire,
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("c-1").Return(func() {})
+
+	cert := testClientCertificate(t)
+	req := httptest.NewRequest(http.MethodGet, "https://admin.example.com/", nil)
+	req.TLS = &tls.ConnectionState{ServerName: "admin.example.com", VerifiedChains: [][]*x509.Certificate{{cert}}}
+	req.Header.Set("X-Client-Cert-Subject", "CN=forged")
+	req.Header.Set("X-Client-Cert-Extra", "forged")
+	w := httptest.NewRecorder()
+	NewHandler(proxySvc, nil, testLogger()).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	headers := <-received
+	fingerprint := sha256.Sum256(cert.Raw)
+	assert.Equal(t, "CN=alice@example.com,O=Gordon", headers.Get("X-Client-Cert-Subject"))
+	assert.Equal(t, "DNS:alice-laptop.example.com, email:alice@example.com", headers.Get("X-Client-Cert-SAN"))
+	assert.Equal(t, hex.EncodeToString(fingerprint[:]), headers.Get("X-Client-Cert-Fingerprint"))
+	assert.Equal(t, "beef", headers.Get("X-Client-Cert-Serial"))
+	assert.Empty(t, headers.Get("X-Client-Cert-Extra"))
+}
+
+func TestHandler_StripsClientCertHeadersWithoutCertificate(t *testing.T) {
+	received := make(chan http.Header, 1)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("app.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "app.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        backend.Listener.Addr().(*net.TCPAddr).Port,
+		ContainerID: "c-1",
+		Scheme:      "http",
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("c-1").Return(func() {})
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil)
+	req.Header.Set("X-Client-Cert-Subject", "CN=forged")
+	w := httptest.NewRecorder()
+	NewHandler(proxySvc, nil, testLogger()).ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	assert.Empty(t, (<-received).Get("X-Client-Cert-Subject"))
+}
+
+func TestHandler_RejectsRequireRouteWithoutCertificate(t *testing.T) {
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("admin.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "admin.example.com").Return(&domain.ProxyTarget{
+		Host:       "127.0.0.1",
+		Port:       1,
+		Scheme:     "http",
+		ClientAuth: domain.ClientAuthRequire,
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "http://admin.example.com/", nil)
+	w := httptest.NewRecorder()
+	NewHandler(proxySvc, nil, testLogger()).ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,187 @@
+package proxy
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// compressResponse replaces the upstream response body with a compressed
+// stream when the client accepts one of the policy algorithms and the
+// response is eligible. It must run after the response size limiter so the
+// limit keeps applying to the bytes read from the container.
+func compressResponse(policy *domain.CompressionPolicy, in *http.Request, resp *http.Response) {
+	if policy == nil || !policy.Enabled || !compressible(policy, in, resp) {
+		return
+	}
+
+	encoding := negotiateEncoding(in.Header.Get("Accept-Encoding"), policy.Algorithms)
+	if encoding == "" {
+		return
+	}
+
+	resp.Body = newCompressedBody(resp.Body, encoding)
+	resp.ContentLength = -1
+	resp.Header.Del("Content-Length")
+	resp.Header.Set("Content-Encoding", encoding)
+	resp.Header.Add("Vary", "Accept-Encoding")
+	// A strong ETag identifies the exact bytes; the encoded body differs.
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		resp.Header.Set("ETag", "W/"+etag)
+	}
+}
+
+// compressible reports whether a response may be compressed by the proxy.
+func compressible(policy *domain.CompressionPolicy, in *http.Request, resp *http.Response) bool {
+	if in.Method == http.MethodHead || in.Header.Get("Range") != "" {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	if resp.StatusCode < http.StatusOK || resp.Body == nil || resp.Body == http.NoBody {
+		return false
+	}
+	if resp.Header.Get("Content-Encoding") != "" || resp.Header.Get("Content-Range") != "" {
+		return false
+	}
+	if strings.Contains(strings.ToLower(resp.Header.Get("Cache-Control")), "no-transform") {
+		return false
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if strings.HasPrefix(strings.ToLower(contentType), "text/event-stream") {
+		return false
+	}
+	if !policy.MatchesContentType(contentType) {
+		return false
+	}
+	return resp.ContentLength < 0 || resp.ContentLength >= policy.MinSize
+}
+
+// negotiateEncoding picks the first server-preferred algorithm the client
+// accepts with a non-zero quality value.
+func negotiateEncoding(acceptEncoding string, preferred []string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+
+	accepted := make(map[string]bool)
+	wildcard, wildcardSet := false, false
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		token, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		token = strings.ToLower(strings.TrimSpace(token))
+		ok := qualityNonZero(params)
+		if token == "*" {
+			wildcard, wildcardSet = ok, true
+			continue
+		}
+		accepted[token] = ok
+	}
+
+	for _, algorithm := range preferred {
+		if ok, listed := accepted[algorithm]; listed {
+			if ok {
+				return algorithm
+			}
+			continue
+		}
+		if wildcardSet && wildcard {
+			return algorithm
+		}
+	}
+	return ""
+}
+
+func qualityNonZero(params string) bool {
+	for _, param := range strings.Split(params, ";") {
+		key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.ToLower(strings.TrimSpace(key)) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		return err == nil && q > 0
+	}
+	return true
+}
+
+// compressedBody streams the compressed form of an upstream body through a
+// pipe. Closing it stops the encoder goroutine and closes the upstream body.
+// The encoder is flushed after every upstream read, so a chunked or
+// streaming response reaches the client as it is produced rather than when
+// the upstream body ends.
+type compressedBody struct {
+	pr       *io.PipeReader
+	upstream io.ReadCloser
+}
+
+func newCompressedBody(upstream io.ReadCloser, encoding string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		encoder := newEncoder(pw, encoding)
+		err := copyFlushing(encoder, upstream)
+		if closeErr := encoder.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+	return &compressedBody{pr: pr, upstream: upstream}
+}
+
+// copyFlushing copies upstream into encoder and flushes the encoder after
+// each read.
+func copyFlushing(encoder encodeWriter, upstream io.Reader) error {
+	buf := make([]byte, 32<<10)
+	for {
+		n, err := upstream.Read(buf)
+		if n > 0 {
+			if _, werr := encoder.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if ferr := encoder.Flush(); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *compressedBody) Read(p []byte) (int, error) {
+	return c.pr.Read(p)
+}
+
+func (c *compressedBody) Close() error {
+	c.pr.Close()
+	return c.upstream.Close()
+}
+
+// encodeWriter is a compressing writer whose buffered output can be
+// flushed to the underlying writer.
+type encodeWriter interface {
+	io.WriteCloser
+	Flush() error
+}
+
+func newEncoder(w io.Writer, encoding string) encodeWriter {
+	switch encoding {
+	case domain.CompressionZstd:
+		// NewWriter only fails on invalid options, and these are fixed.
+		encoder, _ := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return encoder
+	case domain.CompressionBrotli:
+		return brotli.NewWriterLevel(w, 4)
+	}
+	return gzip.NewWriter(w)
+}
@@ -0,0 +1,206 @@
+package proxy
+
+import (
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/bnema/zerowrap"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/in"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	preferred := domain.DefaultCompressionAlgorithms()
+
+	assert.Equal(t, "zstd", negotiateEncoding("gzip, br, zstd", preferred))
+	assert.Equal(t, "br", negotiateEncoding("gzip, br", preferred))
+	assert.Equal(t, "gzip", negotiateEncoding("gzip;q=0.5, br;q=0", preferred))
+	assert.Equal(t, "zstd", negotiateEncoding("*", preferred))
+	assert.Equal(t, "gzip", negotiateEncoding("zstd;q=0, br;q=0, *", preferred))
+	assert.Empty(t, negotiateEncoding("identity", preferred))
+	assert.Empty(t, negotiateEncoding("", preferred))
+}
+
+func TestCompressible(t *testing.T) {
+	policy := &domain.CompressionPolicy{
+		Enabled:      true,
+		MinSize:      1024,
+		ContentTypes: domain.DefaultCompressionContentTypes(),
+	}
+	newResp := func(contentType string, length int64) *http.Response {
+		return &http.Response{
+			StatusCode:    http.StatusOK,
+			Header:        http.Header{"Content-Type": []string{contentType}},
+			Body:          io.NopCloser(strings.NewReader("")),
+			ContentLength: length,
+		}
+	}
+	get := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	assert.True(t, compressible(policy, get, newResp("text/html", 4096)))
+	assert.True(t, compressible(policy, get, newResp("application/json", -1)))
+	assert.False(t, compressible(policy, get, newResp("text/html", 100)), "below min size")
+	assert.False(t, compressible(policy, get, newResp("image/png", 4096)), "not allowlisted")
+	assert.False(t, compressible(policy, get, newResp("text/event-stream", -1)), "SSE")
+
+	encoded := newResp("text/html", 4096)
+	encoded.Header.Set("Content-Encoding", "gzip")
+	assert.False(t, compressible(policy, get, encoded), "already encoded")
+
+	ranged := httptest.NewRequest(http.MethodGet, "/", nil)
+	ranged.Header.Set("Range", "bytes=0-10")
+	assert.False(t, compressible(policy, ranged, newResp("text/html", 4096)), "range request")
+}
+
+func TestForwardToTarget_CompressesResponse(t *testing.T) {
+	body := strings.Repeat("hello gordon ", 500)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = io.WriteString(w, body)
+	}))
+	defer backend.Close()
+
+	backendPort := backend.Listener.Addr().(*net.TCPAddr).Port
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{MaxResponseSize: 1 << 20})
+	proxySvc.EXPECT().IsRegistryDomain("web.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "web.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        backendPort,
+		ContainerID: "web-1",
+		Scheme:      "http",
+		Compression: &domain.CompressionPolicy{
+			Enabled:      true,
+			Algorithms:   []string{domain.CompressionGzip},
+			MinSize:      domain.DefaultCompressionMinSize,
+			ContentTypes: domain.DefaultCompressionContentTypes(),
+		},
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("web-1").Return(func() {})
+
+	handler := NewHandler(proxySvc, nil, zerowrap.Default())
+
+	req := httptest.NewRequest(http.MethodGet, "http://web.example.com/", nil)
+	req.Host = "web.example.com"
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+	assert.Contains(t, rec.Header().Values("Vary"), "Accept-Encoding")
+	assert.Less(t, rec.Body.Len(), len(body))
+
+	zr, err := gzip.NewReader(rec.Body)
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, body, string(decoded))
+}
+
+// readWithin reads from r until want has arrived, failing the test if it
+// does not arrive within a few seconds.
+func readWithin(t *testing.T, r io.Reader, want string) {
+	t.Helper()
+	got := make(chan string, 1)
+	go func() {
+		buf := make([]byte, len(want))
+		n, _ := io.ReadFull(r, buf)
+		got <- string(buf[:n])
+	}()
+	select {
+	case data := <-got:
+		assert.Equal(t, want, data)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%q did not reach the client before the upstream body ended", want)
+	}
+}
+
+func TestCompressedBody_FlushesEachUpstreamChunk(t *testing.T) {
+	decoders := map[string]func(io.Reader) (io.Reader, error){
+		domain.CompressionGzip: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		domain.CompressionBrotli: func(r io.Reader) (io.Reader, error) {
+			return brotli.NewReader(r), nil
+		},
+		domain.CompressionZstd: func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+	for encoding, decode := range decoders {
+		t.Run(encoding, func(t *testing.T) {
+			upstreamR, upstreamW := io.Pipe()
+			body := newCompressedBody(upstreamR, encoding)
+			defer body.Close()
+
+			_, err := io.WriteString(upstreamW, "{\"event\":1}\n")
+			require.NoError(t, err)
+
+			decoded, err := decode(body)
+			require.NoError(t, err)
+			readWithin(t, decoded, "{\"event\":1}\n")
+
+			_, err = io.WriteString(upstreamW, "{\"event\":2}\n")
+			require.NoError(t, err)
+			readWithin(t, decoded, "{\"event\":2}\n")
+			require.NoError(t, upstreamW.Close())
+		})
+	}
+}
+
+func TestForwardToTarget_StreamsCompressedChunks(t *testing.T) {
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "first chunk\n")
+		w.(http.Flusher).Flush()
+		<-release
+		_, _ = io.WriteString(w, "second chunk\n")
+	}))
+	defer backend.Close()
+	defer close(release)
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{MaxResponseSize: 1 << 20})
+	proxySvc.EXPECT().IsRegistryDomain("web.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "web.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        backend.Listener.Addr().(*net.TCPAddr).Port,
+		ContainerID: "web-1",
+		Scheme:      "http",
+		Compression: &domain.CompressionPolicy{
+			Enabled:      true,
+			Algorithms:   []string{domain.CompressionGzip},
+			ContentTypes: domain.DefaultCompressionContentTypes(),
+		},
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("web-1").Return(func() {})
+
+	front := httptest.NewServer(NewHandler(proxySvc, nil, zerowrap.Default()))
+	defer front.Close()
+
+	req, err := http.NewRequest(http.MethodGet, front.URL+"/", nil)
+	require.NoError(t, err)
+	req.Host = "web.example.com"
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := front.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+
+	zr, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	readWithin(t, zr, "first chunk\n")
+}
@@ -20,39 +20,40 @@ import (
 	"github.com/bnema/gordon/internal/domain"
 )
 
-// newAppTransport creates the shared HTTP/1.1 transport for proxying to application containers.
-func newAppTransport() *http.Transport {
+// newAppTransport creates an HTTP/1.1 transport for proxying to application
+// containers, using the connection timeouts of policy.
+func newAppTransport(policy domain.UpstreamPolicy) *http.Transport {
 	return &http.Transport{
 		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
+			Timeout:   policy.DialTimeout,
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
 		TLSHandshakeTimeout:   10 * time.Second,
-		ResponseHeaderTimeout: 30 * time.Second,
+		ResponseHeaderTimeout: policy.HeaderTimeout,
 		MaxIdleConns:          100,
 		MaxIdleConnsPerHost:   10,
-		IdleConnTimeout:       90 * time.Second,
+		IdleConnTimeout:       policy.IdleTimeout,
 	}
 }
 
-// newH2CTransport creates a dedicated cleartext HTTP/2 transport for containers
+// newH2CTransport creates a cleartext HTTP/2 transport for containers
 // that opt in via the gordon.proxy.protocol=h2c label.
 // Uses Go 1.24+ native UnencryptedHTTP2 protocol support.
 // HTTP/1 is disabled so http:// URLs use HTTP/2 prior-knowledge.
-func newH2CTransport() *http.Transport {
+func newH2CTransport(policy domain.UpstreamPolicy) *http.Transport {
 	var protos http.Protocols
 	protos.SetUnencryptedHTTP2(true)
 	// Do NOT set HTTP1 — this forces HTTP/2 for http:// URLs.
 	return &http.Transport{
 		Protocols: &protos,
 		DialContext: (&net.Dialer{
-			Timeout:   10 * time.Second,
+			Timeout:   policy.DialTimeout,
 			KeepAlive: 30 * time.Second,
 		}).DialContext,
-		ResponseHeaderTimeout: 30 * time.Second,
+		ResponseHeaderTimeout: policy.HeaderTimeout,
 		MaxIdleConns:          100,
 		MaxIdleConnsPerHost:   10,
-		IdleConnTimeout:       90 * time.Second,
+		IdleConnTimeout:       policy.IdleTimeout,
 	}
 }
 
@@ -72,16 +73,21 @@ func newRegistryTransport() *http.Transport {
 	}
 }
 
-// transportForTarget selects the appropriate HTTP transport based on the target's protocol.
-func (h *Handler) transportForTarget(target *domain.ProxyTarget) http.RoundTripper {
-	if target.Protocol == "h2c" {
-		return h.h2cTransport
+// forwardToTarget proxies a request to the resolved target, going through
+// the response cache when the route has caching enabled.
+func (h *Handler) forwardToTarget(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, maxResponseSize int64) {
+	// Responses of client_auth routes may depend on the client identity, so
+	// they are never shared through the cache.
+	if h.cache != nil && target.Cache != nil && target.Cache.Enabled && target.ClientAuth == "" {
+		h.serveWithCache(w, r, target, maxResponseSize)
+		return
 	}
-	return h.appTransport
+	h.proxyToTarget(w, r, target, maxResponseSize, nil)
 }
 
-// forwardToTarget proxies a request to the resolved target.
-func (h *Handler) forwardToTarget(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, maxResponseSize int64) {
+// proxyToTarget proxies a request to the target. capture, when not nil, sees
+// each upstream response after the size limit and before compression.
+func (h *Handler) proxyToTarget(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget, maxResponseSize int64, capture func(*http.Response)) {
 	log := zerowrap.FromCtx(r.Context())
 
 	targetURL, err := url.Parse(fmt.Sprintf("%s://%s", target.Scheme, net.JoinHostPort(target.Host, strconv.Itoa(target.Port))))
@@ -91,11 +97,28 @@ func (h *Handler) forwardToTarget(w http.ResponseWriter, r *http.Request, target
 		return
 	}
 
+	releaseUpstream, ok := h.acquireUpstream(w, r, target)
+	if !ok {
+		return
+	}
+	var upstreamErr error
+	defer func() { releaseUpstream(upstreamErr) }()
+
 	releaseInFlight := h.proxySvc.TrackInFlight(target.ContainerID)
 	defer releaseInFlight()
 
 	transport := h.transportForTarget(target)
 
+	errorHandler := newProxyErrorHandler(
+		log.WithField("target", targetURL.String()),
+		proxyErrorHandlerConfig{
+			requestTooLargeLog:  "proxy error: request body too large",
+			clientDisconnectLog: "proxy request canceled by client",
+			upstreamErrorLog:    "proxy error: connection failed",
+			upstreamErrorBody:   "Service Unavailable",
+		},
+	)
+
 	proxy := newReverseProxy(reverseProxyOptions{
 		targetURL:     targetURL,
 		hostHeader:    targetHostHeader(target, targetURL),
@@ -103,16 +126,17 @@ func (h *Handler) forwardToTarget(w http.ResponseWriter, r *http.Request, target
 		transport:     transport,
 		incomingReq:   r,
 		trustedNets:   h.trustedNets,
-		errorHandler: newProxyErrorHandler(
-			log.WithField("target", targetURL.String()),
-			proxyErrorHandlerConfig{
-				requestTooLargeLog:  "proxy error: request body too large",
-				clientDisconnectLog: "proxy request canceled by client",
-				upstreamErrorLog:    "proxy error: connection failed",
-				upstreamErrorBody:   "Service Unavailable",
-			},
-		),
-		modifyResponse: modifyResponse(maxResponseSize),
+		errorHandler: func(w http.ResponseWriter, req *http.Request, proxyErr error) {
+			switch {
+			case isUpstreamError(proxyErr):
+				upstreamErr = proxyErr
+			case isClientDisconnectError(proxyErr):
+				// Neither a success nor an upstream failure for the breaker.
+				upstreamErr = context.Canceled
+			}
+			errorHandler(w, req, proxyErr)
+		},
+		modifyResponse: appModifyResponse(maxResponseSize, target, r, capture),
 	})
 
 	if target.Protocol == "h2c" {
@@ -248,6 +272,7 @@ func newReverseProxy(opts reverseProxyOptions) *httputil.ReverseProxy {
 			if opts.forwardedHost != "" {
 				pr.Out.Header.Set("X-Forwarded-Host", opts.forwardedHost)
 			}
+			setClientCertHeaders(pr.Out.Header, pr.In)
 			// Preserve X-Forwarded-Proto from trusted upstream proxies.
 			// SetXForwarded() unconditionally sets it based on the incoming scheme
 			// (HTTP between proxies), but when a trusted proxy already sent the
@@ -292,6 +317,23 @@ func modifyResponse(maxResponseSize int64) func(*http.Response) error {
 	}
 }
 
+// appModifyResponse extends modifyResponse with the per-route response
+// policies of an application target. The response cache captures the
+// uncompressed body so it can serve every client encoding from one entry.
+func appModifyResponse(maxResponseSize int64, target *domain.ProxyTarget, in *http.Request, capture func(*http.Response)) func(*http.Response) error {
+	limit := modifyResponse(maxResponseSize)
+	return func(resp *http.Response) error {
+		if err := limit(resp); err != nil {
+			return err
+		}
+		if capture != nil {
+			capture(resp)
+		}
+		compressResponse(target.Compression, in, resp)
+		return nil
+	}
+}
+
 // limitedReadCloser wraps an io.ReadCloser with a byte limit.
 type limitedReadCloser struct {
 	io.ReadCloser
@@ -2,16 +2,20 @@
 package proxy
 
 import (
+	"errors"
 	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 
 	"github.com/bnema/zerowrap"
 
 	"github.com/bnema/gordon/internal/adapters/in/http/middleware"
 	"github.com/bnema/gordon/internal/boundaries/in"
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
 )
 
 // Handler implements http.Handler for the reverse proxy.
@@ -26,6 +30,12 @@ type Handler struct {
 	h2cTransport      http.RoundTripper
 	registryTransport http.RoundTripper
 	activeConns       atomic.Int64
+	cache             *responseCache // nil when the response cache is disabled
+
+	// upstreamTransports holds the transports of routes with custom
+	// upstream settings, shared between routes with the same settings.
+	upstreamTransports map[upstreamTransportKey]http.RoundTripper
+	transportMu        sync.Mutex
 }
 
 // NewHandler creates a new proxy HTTP handler.
@@ -34,12 +44,22 @@ func NewHandler(proxySvc in.ProxyService, trustedNets []*net.IPNet, log zerowrap
 		proxySvc:          proxySvc,
 		log:               log,
 		trustedNets:       trustedNets,
-		appTransport:      newAppTransport(),
-		h2cTransport:      newH2CTransport(),
+		appTransport:      newAppTransport(domain.DefaultUpstreamPolicy()),
+		h2cTransport:      newH2CTransport(domain.DefaultUpstreamPolicy()),
 		registryTransport: newRegistryTransport(),
 	}
 }
 
+// SetResponseCache enables edge caching for routes whose target carries a
+// cache policy. It must be called before the handler starts serving.
+func (h *Handler) SetResponseCache(store out.ResponseCacheStore) {
+	if store == nil {
+		h.cache = nil
+		return
+	}
+	h.cache = newResponseCache(store)
+}
+
 // ServeHTTP handles incoming HTTP requests and proxies them to the appropriate backend.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	cfg := h.proxySvc.ProxyConfig()
@@ -87,13 +107,28 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Get target for this domain
 	log.Debug().Str("resolving_target_for", host).Msg("looking up proxy target")
-	target, err := h.proxySvc.GetTarget(ctx, host)
+	targetCtx := ctx
+	if acceptsWakePage(r) {
+		targetCtx = domain.WithWakePage(ctx)
+	}
+	target, err := h.proxySvc.GetTarget(targetCtx, host)
+	if errors.Is(err, domain.ErrRouteWaking) {
+		log.Debug().Msg("route is waking up, serving waking page")
+		serveWakingPage(w, r)
+		return
+	}
 	if err != nil {
 		log.Warn().Err(err).Msg("no route found for domain")
 		proxyError(w, "404 page not found", http.StatusNotFound)
 		return
 	}
 
+	if status := clientAuthStatus(r, host, target.ClientAuth); status != 0 {
+		log.Debug().Int("status", status).Msg("client certificate check failed")
+		proxyError(w, http.StatusText(status), status)
+		return
+	}
+
 	log.Debug().
 		Str("host", target.Host).
 		Int("port", target.Port).
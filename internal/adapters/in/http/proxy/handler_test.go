@@ -131,6 +131,50 @@ func TestHandler_Returns404WhenNoTarget(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestHandler_ServesWakingPageForBrowserNavigation(t *testing.T) {
+	proxySvc := inmocks.NewMockProxyService(t)
+
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("sleepy.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.MatchedBy(domain.IsWakePage), "sleepy.example.com").Return(nil, domain.ErrRouteWaking)
+
+	handler := NewHandler(proxySvc, nil, testLogger())
+
+	req := httptest.NewRequest(http.MethodGet, "http://sleepy.example.com/", nil)
+	req.Host = "sleepy.example.com"
+	req.Header.Set("Sec-Fetch-Mode", "navigate")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "waking up")
+}
+
+func TestAcceptsWakePage(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "navigation", method: http.MethodGet, headers: map[string]string{"Sec-Fetch-Mode": "navigate"}, want: true},
+		{name: "fetch", method: http.MethodGet, headers: map[string]string{"Sec-Fetch-Mode": "cors", "Accept": "text/html"}, want: false},
+		{name: "html accept", method: http.MethodGet, headers: map[string]string{"Accept": "text/html,application/xhtml+xml"}, want: true},
+		{name: "json api", method: http.MethodGet, headers: map[string]string{"Accept": "application/json"}, want: false},
+		{name: "post", method: http.MethodPost, headers: map[string]string{"Sec-Fetch-Mode": "navigate"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://app.example.com/", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, acceptsWakePage(req))
+		})
+	}
+}
+
 func TestHandler_ProxiesToTarget(t *testing.T) {
 	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -0,0 +1,164 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// upstreamRetryBackoff is the delay before the first retry of an idempotent
+// request; each further retry waits one more step.
+const upstreamRetryBackoff = 250 * time.Millisecond
+
+// upstreamTransportKey identifies the transport settings of an upstream policy.
+type upstreamTransportKey struct {
+	h2c           bool
+	dialTimeout   time.Duration
+	headerTimeout time.Duration
+	idleTimeout   time.Duration
+	retries       int
+}
+
+// transportForTarget selects the HTTP transport for a target based on its
+// protocol and upstream policy.
+func (h *Handler) transportForTarget(target *domain.ProxyTarget) http.RoundTripper {
+	h2c := target.Protocol == "h2c"
+	shared := h.appTransport
+	if h2c {
+		shared = h.h2cTransport
+	}
+	if target.Upstream == nil {
+		return shared
+	}
+
+	policy := *target.Upstream
+	key := upstreamTransportKey{
+		h2c:           h2c,
+		dialTimeout:   policy.DialTimeout,
+		headerTimeout: policy.HeaderTimeout,
+		idleTimeout:   policy.IdleTimeout,
+		retries:       policy.Retries,
+	}
+
+	h.transportMu.Lock()
+	defer h.transportMu.Unlock()
+
+	if transport, ok := h.upstreamTransports[key]; ok {
+		return transport
+	}
+
+	transport := shared
+	defaults := domain.DefaultUpstreamPolicy()
+	if policy.DialTimeout != defaults.DialTimeout ||
+		policy.HeaderTimeout != defaults.HeaderTimeout ||
+		policy.IdleTimeout != defaults.IdleTimeout {
+		if h2c {
+			transport = newH2CTransport(policy)
+		} else {
+			transport = newAppTransport(policy)
+		}
+	}
+	if policy.Retries > 0 {
+		transport = &retryTransport{base: transport, retries: policy.Retries, backoff: upstreamRetryBackoff}
+	}
+
+	if h.upstreamTransports == nil {
+		h.upstreamTransports = make(map[upstreamTransportKey]http.RoundTripper)
+	}
+	h.upstreamTransports[key] = transport
+	return transport
+}
+
+// acquireUpstream checks the circuit breaker of the request's route. It
+// writes a 503 and returns false when the circuit is open; otherwise the
+// returned function must be called with the upstream error once the request
+// completes.
+func (h *Handler) acquireUpstream(w http.ResponseWriter, r *http.Request, target *domain.ProxyTarget) (func(error), bool) {
+	if target.Upstream == nil || target.Upstream.BreakerThreshold <= 0 {
+		return func(error) {}, true
+	}
+
+	release, err := h.proxySvc.AcquireUpstream(normalizeRequestHost(r.Host), *target.Upstream)
+	if err != nil {
+		log := zerowrap.FromCtx(r.Context())
+		log.Debug().Err(err).Msg("upstream circuit open, failing fast")
+		if cooldown := target.Upstream.BreakerCooldown; cooldown > 0 {
+			w.Header().Set("Retry-After", strconv.Itoa(int((cooldown+time.Second-1)/time.Second)))
+		}
+		proxyError(w, "Service Unavailable", http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return release, true
+}
+
+// isUpstreamError reports whether a proxy error is the upstream's fault
+// rather than the client's.
+func isUpstreamError(err error) bool {
+	return err != nil && !isRequestBodyTooLargeError(err) && !isClientDisconnectError(err)
+}
+
+// retryTransport retries idempotent requests without a body when the
+// upstream connection fails before a response is received, e.g. while a
+// container restarts mid-deploy.
+type retryTransport struct {
+	base    http.RoundTripper
+	retries int
+	backoff time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetryableRequest(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err == nil || attempt > t.retries || !isConnectionError(err) || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		log := zerowrap.FromCtx(req.Context())
+		log.Debug().Err(err).Int("attempt", attempt).Msg("upstream connection failed, retrying")
+
+		timer := time.NewTimer(t.backoff * time.Duration(attempt))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableRequest reports whether a request can be sent again safely.
+func isRetryableRequest(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isConnectionError reports whether err means the upstream connection could
+// not be established or was dropped, as opposed to a slow response.
+func isConnectionError(err error) bool {
+	if opErr, ok := errors.AsType[*net.OpError](err); ok && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,211 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/in"
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestRetryTransport_RetriesIdempotentRequestsOnConnectionErrors(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	calls := 0
+	transport := &retryTransport{
+		base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			if calls < 3 {
+				return nil, dialErr
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+		}),
+		retries: 2,
+	}
+
+	resp, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	_, err = transport.RoundTrip(httptest.NewRequest(http.MethodPost, "http://app.example.com/", nil))
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.Equal(t, 1, calls)
+
+	calls = 0
+	_, err = transport.RoundTrip(httptest.NewRequest(http.MethodPut, "http://app.example.com/", strings.NewReader("body")))
+	assert.ErrorIs(t, err, syscall.ECONNREFUSED)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryTransport_GivesUpAfterRetries(t *testing.T) {
+	calls := 0
+	transport := &retryTransport{
+		base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return nil, io.ErrUnexpectedEOF
+		}),
+		retries: 2,
+	}
+
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil))
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryTransport_DoesNotRetryTimeouts(t *testing.T) {
+	calls := 0
+	transport := &retryTransport{
+		base: roundTripFunc(func(*http.Request) (*http.Response, error) {
+			calls++
+			return nil, errors.New("net/http: timeout awaiting response headers")
+		}),
+		retries: 2,
+	}
+
+	_, err := transport.RoundTrip(httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil))
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransportForTarget_UpstreamPolicy(t *testing.T) {
+	handler := NewHandler(inmocks.NewMockProxyService(t), nil, zerowrap.Default())
+
+	breakerOnly := domain.DefaultUpstreamPolicy()
+	breakerOnly.BreakerThreshold = 5
+	assert.Equal(t, handler.appTransport, handler.transportForTarget(&domain.ProxyTarget{Upstream: &breakerOnly}))
+
+	slow := domain.DefaultUpstreamPolicy()
+	slow.HeaderTimeout = 10 * time.Minute
+	transport := handler.transportForTarget(&domain.ProxyTarget{Upstream: &slow})
+	httpTransport, ok := transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 10*time.Minute, httpTransport.ResponseHeaderTimeout)
+	assert.Same(t, httpTransport, handler.transportForTarget(&domain.ProxyTarget{Upstream: &slow}))
+
+	retrying := domain.DefaultUpstreamPolicy()
+	retrying.Retries = 2
+	retry, ok := handler.transportForTarget(&domain.ProxyTarget{Upstream: &retrying}).(*retryTransport)
+	require.True(t, ok)
+	assert.Equal(t, 2, retry.retries)
+	assert.Equal(t, handler.appTransport, retry.base)
+}
+
+func TestForwardToTarget_CircuitOpen_Returns503(t *testing.T) {
+	policy := domain.DefaultUpstreamPolicy()
+	policy.BreakerThreshold = 3
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("app.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "app.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        8080,
+		ContainerID: "c-app",
+		Scheme:      "http",
+		Upstream:    &policy,
+	}, nil)
+	proxySvc.EXPECT().AcquireUpstream("app.example.com", policy).Return(nil, domain.ErrUpstreamCircuitOpen)
+
+	handler := NewHandler(proxySvc, nil, zerowrap.Default())
+	handler.appTransport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		t.Fatal("request must not reach the upstream while the circuit is open")
+		return nil, nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil)
+	req.Host = "app.example.com"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+}
+
+func TestForwardToTarget_ReportsUpstreamOutcome(t *testing.T) {
+	policy := domain.DefaultUpstreamPolicy()
+	policy.BreakerThreshold = 3
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("app.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "app.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        8080,
+		ContainerID: "c-app",
+		Scheme:      "http",
+		Upstream:    &policy,
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("c-app").Return(func() {})
+
+	var reported []error
+	proxySvc.EXPECT().AcquireUpstream("app.example.com", policy).Return(func(err error) {
+		reported = append(reported, err)
+	}, nil)
+
+	upstreamErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	handler := NewHandler(proxySvc, nil, zerowrap.Default())
+	handler.appTransport = roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, upstreamErr
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil)
+	req.Host = "app.example.com"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], syscall.ECONNREFUSED)
+}
+
+func TestForwardToTarget_ReportsClientCancelAsNoVerdict(t *testing.T) {
+	policy := domain.DefaultUpstreamPolicy()
+	policy.BreakerThreshold = 3
+
+	proxySvc := inmocks.NewMockProxyService(t)
+	proxySvc.EXPECT().ProxyConfig().Return(in.ProxyServiceConfig{})
+	proxySvc.EXPECT().IsRegistryDomain("app.example.com").Return(false)
+	proxySvc.EXPECT().GetTarget(mock.Anything, "app.example.com").Return(&domain.ProxyTarget{
+		Host:        "127.0.0.1",
+		Port:        8080,
+		ContainerID: "c-app",
+		Scheme:      "http",
+		Upstream:    &policy,
+	}, nil)
+	proxySvc.EXPECT().TrackInFlight("c-app").Return(func() {})
+
+	var reported []error
+	proxySvc.EXPECT().AcquireUpstream("app.example.com", policy).Return(func(err error) {
+		reported = append(reported, err)
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	handler := NewHandler(proxySvc, nil, zerowrap.Default())
+	handler.appTransport = roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		cancel()
+		return nil, req.Context().Err()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://app.example.com/", nil).WithContext(ctx)
+	req.Host = "app.example.com"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], context.Canceled)
+}
@@ -0,0 +1,51 @@
+package proxy
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// wakeRetryAfterSeconds is how long browsers wait before reloading the
+// "waking up" page while a sleeping route container starts.
+// Keep in sync with the meta refresh in wakingPage.
+const wakeRetryAfterSeconds = 3
+
+const wakingPage = `<!doctype html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="3">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Waking up…</title>
+<style>body{font-family:system-ui,sans-serif;display:flex;align-items:center;justify-content:center;height:100vh;margin:0;color:#444}</style>
+</head>
+<body><p>This site is waking up. The page will reload in a few seconds.</p></body>
+</html>
+`
+
+// acceptsWakePage reports whether the request is a browser page navigation
+// that can be answered with an auto-refreshing "waking up" page. API calls and
+// non-idempotent requests are held until the container is ready instead.
+func acceptsWakePage(r *http.Request) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if mode := r.Header.Get("Sec-Fetch-Mode"); mode != "" {
+		return mode == "navigate"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// serveWakingPage answers with a 503 page that reloads itself once the
+// sleeping route container has had time to start.
+func serveWakingPage(w http.ResponseWriter, r *http.Request) {
+	setProxyGeneratedResponseHeaders(w)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; frame-ancestors 'none'")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Retry-After", strconv.Itoa(wakeRetryAfterSeconds))
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if r.Method != http.MethodHead {
+		_, _ = w.Write([]byte(wakingPage))
+	}
+}
@@ -0,0 +1,332 @@
+package traffic
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// serviceBalancers maps traffic service names to their balancers.
+type serviceBalancers map[string]*serviceBalancer
+
+// serviceBalancer selects backends for one L4 service and owns its active
+// health checks. Backend state survives reloads that keep the backend.
+type serviceBalancer struct {
+	service  domain.TrafficService
+	backends []*backendState
+	next     atomic.Uint64
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// backendState tracks load and health for one backend.
+type backendState struct {
+	backend       domain.TrafficBackend
+	active        atomic.Int64
+	totalFailures atomic.Int64
+
+	mu                  sync.Mutex
+	health              domain.BackendHealth
+	checkSuccesses      int
+	checkFailures       int
+	consecutiveFailures int
+	ejectedUntil        time.Time
+	lastError           string
+}
+
+func newBackendState(backend domain.TrafficBackend) *backendState {
+	return &backendState{backend: backend, health: domain.BackendHealthUnknown}
+}
+
+// buildBalancers creates balancers for every service with backends, reusing
+// unchanged balancers and the state of retained backends from current.
+func buildBalancers(graph *domain.TrafficGraph, current serviceBalancers) (serviceBalancers, []*serviceBalancer) {
+	next := make(serviceBalancers, len(graph.Services))
+	created := make([]*serviceBalancer, 0)
+	for _, service := range graph.Services {
+		if len(service.Backends) == 0 {
+			continue
+		}
+		previous := current[service.Name]
+		if previous != nil && trafficServiceEqual(previous.service, service) {
+			next[service.Name] = previous
+			continue
+		}
+		balancer := &serviceBalancer{service: service, backends: make([]*backendState, 0, len(service.Backends))}
+		for _, backend := range service.Backends {
+			state := previous.state(backend)
+			if state == nil {
+				state = newBackendState(backend)
+			}
+			balancer.backends = append(balancer.backends, state)
+		}
+		next[service.Name] = balancer
+		created = append(created, balancer)
+	}
+	return next, created
+}
+
+func trafficServiceEqual(left domain.TrafficService, right domain.TrafficService) bool {
+	return left.Name == right.Name && left.LoadBalancer == right.LoadBalancer && slices.EqualFunc(left.Backends, right.Backends, trafficBackendEqual)
+}
+
+func (b *serviceBalancer) state(backend domain.TrafficBackend) *backendState {
+	if b == nil {
+		return nil
+	}
+	for _, state := range b.backends {
+		if trafficBackendEqual(state.backend, backend) {
+			return state
+		}
+	}
+	return nil
+}
+
+func (b *serviceBalancer) protocol() domain.NetworkProtocol {
+	return b.service.Backends[0].Protocol
+}
+
+func (b *serviceBalancer) contains(backend domain.TrafficBackend) bool {
+	return b.state(backend) != nil
+}
+
+// candidates returns backends in the order they should be tried for client.
+// Unavailable backends are skipped unless none is available, in which case
+// every backend is tried rather than refusing the connection outright.
+func (b *serviceBalancer) candidates(client net.Addr) []*backendState {
+	now := time.Now()
+	ordered := make([]*backendState, 0, len(b.backends))
+	for _, state := range b.backends {
+		if state.available(now) {
+			ordered = append(ordered, state)
+		}
+	}
+	if len(ordered) == 0 {
+		ordered = append(ordered, b.backends...)
+	}
+	switch b.service.LoadBalancer.EffectiveStrategy() {
+	case domain.LoadBalancerSourceIPHash:
+		rotate(ordered, sourceHash(client))
+	case domain.LoadBalancerLeastConnections:
+		rotate(ordered, b.next.Add(1)-1)
+		sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].active.Load() < ordered[j].active.Load() })
+	default:
+		rotate(ordered, b.next.Add(1)-1)
+	}
+	return ordered
+}
+
+func rotate(states []*backendState, offset uint64) {
+	if len(states) < 2 {
+		return
+	}
+	shift := int(offset % uint64(len(states)))
+	slices.Reverse(states[:shift])
+	slices.Reverse(states[shift:])
+	slices.Reverse(states)
+}
+
+func sourceHash(addr net.Addr) uint64 {
+	host := addr.String()
+	if value, _, err := net.SplitHostPort(host); err == nil {
+		host = value
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(host))
+	return hash.Sum64()
+}
+
+// dialTCP dials the first reachable candidate, recording passive failures for
+// the ones that refuse. The returned state's active count is incremented and
+// must be released once the connection closes.
+func (b *serviceBalancer) dialTCP(ctx context.Context, client net.Addr, timeout time.Duration) (net.Conn, *backendState, error) {
+	var lastErr error
+	for _, state := range b.candidates(client) {
+		dialCtx, cancel := context.WithTimeout(ctx, timeout)
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", state.address(state.backend.Port))
+		cancel()
+		if err != nil {
+			lastErr = err
+			b.recordFailure(ctx, state, err)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		state.recordSuccess()
+		state.active.Add(1)
+		return conn, state, nil
+	}
+	return nil, nil, fmt.Errorf("dial %s: %w", b.service.Name, lastErr)
+}
+
+func (b *serviceBalancer) recordFailure(ctx context.Context, state *backendState, err error) {
+	if !state.recordFailure(err, b.service.LoadBalancer) {
+		return
+	}
+	trafficWarn(ctx).Str("service", b.service.Name).Str("backend", state.backend.Name).Err(err).Dur("eject_duration", b.service.LoadBalancer.EjectDuration).Msg("ejected traffic backend after consecutive failures")
+}
+
+func (s *backendState) address(port int) string {
+	return net.JoinHostPort(s.backend.Host, strconv.Itoa(port))
+}
+
+func (s *backendState) available(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health != domain.BackendHealthUnhealthy && !now.Before(s.ejectedUntil)
+}
+
+// recordFailure counts a failure seen on real traffic and reports whether it
+// ejected the backend.
+func (s *backendState) recordFailure(err error, lb domain.TrafficLoadBalancer) bool {
+	s.totalFailures.Add(1)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastError = err.Error()
+	s.consecutiveFailures++
+	if lb.MaxFailures == 0 || s.consecutiveFailures < lb.MaxFailures {
+		return false
+	}
+	s.consecutiveFailures = 0
+	s.ejectedUntil = time.Now().Add(lb.EjectDuration)
+	return true
+}
+
+func (s *backendState) recordSuccess() {
+	s.mu.Lock()
+	s.consecutiveFailures = 0
+	s.mu.Unlock()
+}
+
+// recordCheck applies an active check result and returns the health before
+// and after it.
+func (s *backendState) recordCheck(err error, check domain.TrafficHealthCheck) (domain.BackendHealth, domain.BackendHealth) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous := s.health
+	if err == nil {
+		s.checkFailures = 0
+		s.checkSuccesses++
+		if s.health == domain.BackendHealthUnknown || s.checkSuccesses >= check.HealthyThreshold {
+			s.health = domain.BackendHealthHealthy
+		}
+		return previous, s.health
+	}
+	s.totalFailures.Add(1)
+	s.lastError = err.Error()
+	s.checkSuccesses = 0
+	s.checkFailures++
+	if s.checkFailures >= check.UnhealthyThreshold {
+		s.health = domain.BackendHealthUnhealthy
+	}
+	return previous, s.health
+}
+
+func (s *backendState) status(now time.Time) domain.TrafficBackendStatus {
+	s.mu.Lock()
+	health := s.health
+	if now.Before(s.ejectedUntil) {
+		health = domain.BackendHealthEjected
+	}
+	lastError := s.lastError
+	s.mu.Unlock()
+	return domain.TrafficBackendStatus{
+		Name:              s.backend.Name,
+		Host:              s.backend.Host,
+		Port:              s.backend.Port,
+		Protocol:          s.backend.Protocol,
+		Active:            health != domain.BackendHealthUnhealthy && health != domain.BackendHealthEjected,
+		Health:            health,
+		ActiveConnections: s.active.Load(),
+		TotalFailures:     s.totalFailures.Load(),
+		LastError:         lastError,
+	}
+}
+
+// startHealthChecks runs active TCP connect checks until stopHealthChecks.
+func (b *serviceBalancer) startHealthChecks(ctx context.Context) {
+	check := b.service.LoadBalancer.HealthCheck
+	if check.Interval <= 0 {
+		return
+	}
+	ctx, b.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	b.done = make(chan struct{})
+	go func() {
+		defer close(b.done)
+		ticker := time.NewTicker(check.Interval)
+		defer ticker.Stop()
+		for {
+			b.checkBackends(ctx, check)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (b *serviceBalancer) stopHealthChecks() {
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	<-b.done
+}
+
+func (b *serviceBalancer) checkBackends(ctx context.Context, check domain.TrafficHealthCheck) {
+	var wg sync.WaitGroup
+	for _, state := range b.backends {
+		wg.Go(func() {
+			port := state.backend.Port
+			if check.Port != 0 {
+				port = check.Port
+			}
+			err := probeTCP(ctx, state.address(port), check.Timeout)
+			if ctx.Err() != nil {
+				return
+			}
+			previous, current := state.recordCheck(err, check)
+			if previous == current {
+				return
+			}
+			if current == domain.BackendHealthUnhealthy {
+				trafficWarn(ctx).Str("service", b.service.Name).Str("backend", state.backend.Name).Err(err).Msg("traffic backend failed health checks")
+				return
+			}
+			trafficInfo(ctx).Str("service", b.service.Name).Str("backend", state.backend.Name).Str("health", string(current)).Msg("traffic backend health changed")
+		})
+	}
+	wg.Wait()
+}
+
+func probeTCP(ctx context.Context, address string, timeout time.Duration) error {
+	dialCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	conn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+func (b *serviceBalancer) serviceStatus(now time.Time) domain.TrafficServiceStatus {
+	status := domain.TrafficServiceStatus{Name: b.service.Name, Strategy: b.service.LoadBalancer.EffectiveStrategy(), Backends: make([]domain.TrafficBackendStatus, 0, len(b.backends))}
+	for _, state := range b.backends {
+		backend := state.status(now)
+		status.Active = status.Active || backend.Active
+		status.Backends = append(status.Backends, backend)
+	}
+	return status
+}
@@ -0,0 +1,221 @@
+package traffic
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestPoolRoundRobinSpreadsConnections(t *testing.T) {
+	a := startTCPNameServer(t, "a")
+	b := startTCPNameServer(t, "b")
+	graph := tcpPoolGraph(t, freeTCPAddress(t), domain.TrafficLoadBalancer{}, a, b)
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	got := []string{}
+	for range 4 {
+		conn, name := dialPoolBackend(t, graph.EntryPoints[0].Address)
+		_ = conn.Close()
+		got = append(got, name)
+	}
+	assert.Equal(t, []string{"a", "b", "a", "b"}, got)
+}
+
+func TestPoolLeastConnectionsPrefersIdleBackend(t *testing.T) {
+	a := startTCPNameServer(t, "a")
+	b := startTCPNameServer(t, "b")
+	graph := tcpPoolGraph(t, freeTCPAddress(t), domain.TrafficLoadBalancer{Strategy: domain.LoadBalancerLeastConnections}, a, b)
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	held, first := dialPoolBackend(t, graph.EntryPoints[0].Address)
+	defer held.Close()
+	require.Equal(t, "a", first)
+	second, name := dialPoolBackend(t, graph.EntryPoints[0].Address)
+	require.Equal(t, "b", name)
+	_ = second.Close()
+	require.Eventually(t, func() bool { return backendStatus(t, manager, "b").ActiveConnections == 0 }, time.Second, 10*time.Millisecond)
+
+	third, name := dialPoolBackend(t, graph.EntryPoints[0].Address)
+	defer third.Close()
+	assert.Equal(t, "b", name, "round-robin would pick a; least connections keeps a's load in mind")
+	assert.Equal(t, int64(1), backendStatus(t, manager, "a").ActiveConnections)
+}
+
+func TestPoolSourceIPHashIsSticky(t *testing.T) {
+	a := startTCPNameServer(t, "a")
+	b := startTCPNameServer(t, "b")
+	graph := tcpPoolGraph(t, freeTCPAddress(t), domain.TrafficLoadBalancer{Strategy: domain.LoadBalancerSourceIPHash}, a, b)
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	_, want := dialPoolBackend(t, graph.EntryPoints[0].Address)
+	for range 4 {
+		conn, name := dialPoolBackend(t, graph.EntryPoints[0].Address)
+		_ = conn.Close()
+		assert.Equal(t, want, name)
+	}
+}
+
+func TestPoolPassiveEjectionFailsOver(t *testing.T) {
+	dead := freeTCPAddress(t)
+	b := startTCPNameServer(t, "b")
+	lb := domain.TrafficLoadBalancer{MaxFailures: 1, EjectDuration: time.Minute}
+	graph := tcpPoolGraph(t, freeTCPAddress(t), lb, dead, b)
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	for range 3 {
+		conn, name := dialPoolBackend(t, graph.EntryPoints[0].Address)
+		_ = conn.Close()
+		assert.Equal(t, "b", name)
+	}
+	status := backendStatus(t, manager, "a")
+	assert.Equal(t, domain.BackendHealthEjected, status.Health)
+	assert.False(t, status.Active)
+	assert.Equal(t, int64(1), status.TotalFailures, "ejected backends are skipped")
+	assert.NotEmpty(t, status.LastError)
+	assert.Zero(t, manager.Status().Counters.TotalErrors)
+}
+
+func TestPoolActiveHealthChecksTrackBackend(t *testing.T) {
+	address := freeTCPAddress(t)
+	b := startTCPNameServer(t, "b")
+	lb := domain.TrafficLoadBalancer{HealthCheck: domain.TrafficHealthCheck{Interval: 20 * time.Millisecond, Timeout: 100 * time.Millisecond, HealthyThreshold: 1, UnhealthyThreshold: 1}}
+	graph := tcpPoolGraph(t, freeTCPAddress(t), lb, address, b)
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	require.Eventually(t, func() bool { return backendStatus(t, manager, "a").Health == domain.BackendHealthUnhealthy }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, domain.BackendHealthHealthy, backendStatus(t, manager, "b").Health)
+	assert.False(t, manager.Status().Services[0].Backends[0].Active)
+	assert.True(t, manager.Status().Services[0].Active)
+
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+	defer listener.Close()
+	require.Eventually(t, func() bool { return backendStatus(t, manager, "a").Health == domain.BackendHealthHealthy }, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	assert.Equal(t, domain.BackendHealthHealthy, backendStatus(t, manager, "a").Health, "reload keeps backend health")
+}
+
+func TestPoolUDPSessionsKeepBackendAffinity(t *testing.T) {
+	a := startUDPEchoServerWithPrefix(t, "a:")
+	b := startUDPEchoServerWithPrefix(t, "b:")
+	graph := udpGraph(t, freeUDPAddress(t), a.address)
+	backendB, err := backendFromAddress("b", b.address)
+	require.NoError(t, err)
+	backendB.Protocol = domain.NetworkProtocolUDP
+	graph.Routers[0].Service = "pool:echo"
+	graph.Services[0].Name = "pool:echo"
+	graph.Services[0].Backends[0].Name = "a"
+	graph.Services[0].Backends = append(graph.Services[0].Backends, backendB)
+	require.NoError(t, graph.Validate())
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	first := dialUDP(t, graph.EntryPoints[0].Address)
+	defer first.Close()
+	second := dialUDP(t, graph.EntryPoints[0].Address)
+	defer second.Close()
+	for range 3 {
+		assertUDPRoundTripWant(t, first, "ping", "a:ping")
+		assertUDPRoundTripWant(t, second, "ping", "b:ping")
+	}
+	assert.Equal(t, int64(1), backendStatus(t, manager, "a").ActiveConnections)
+	assert.Equal(t, int64(1), backendStatus(t, manager, "b").ActiveConnections)
+}
+
+func TestBalancerFailsOpenWhenNoBackendIsAvailable(t *testing.T) {
+	service := domain.TrafficService{Name: "pool:echo", Backends: []domain.TrafficBackend{
+		{Name: "a", Host: "127.0.0.1", Port: 1, Protocol: domain.NetworkProtocolTCP},
+		{Name: "b", Host: "127.0.0.1", Port: 2, Protocol: domain.NetworkProtocolTCP},
+	}}
+	balancers, _ := buildBalancers(&domain.TrafficGraph{Services: []domain.TrafficService{service}}, nil)
+	balancer := balancers["pool:echo"]
+	for _, state := range balancer.backends {
+		state.recordCheck(assert.AnError, domain.TrafficHealthCheck{UnhealthyThreshold: 1})
+	}
+	assert.Len(t, balancer.candidates(&net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)}), 2)
+}
+
+func tcpPoolGraph(t *testing.T, listenAddress string, lb domain.TrafficLoadBalancer, backendAddresses ...string) domain.TrafficGraph {
+	t.Helper()
+	graph := tcpGraph(t, listenAddress, backendAddresses[0])
+	graph.Routers[0].Service = "pool:echo"
+	service := domain.TrafficService{Name: "pool:echo", LoadBalancer: lb}
+	for i, address := range backendAddresses {
+		backend, err := backendFromAddress(string(rune('a'+i)), address)
+		require.NoError(t, err)
+		service.Backends = append(service.Backends, backend)
+	}
+	graph.Services = []domain.TrafficService{service}
+	require.NoError(t, graph.Validate())
+	return graph
+}
+
+// startTCPNameServer accepts connections, writes name and holds them open
+// until the client closes.
+func startTCPNameServer(t *testing.T, name string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte(name + "\n"))
+				_, _ = io.Copy(io.Discard, conn)
+			}()
+		}
+	}()
+	t.Cleanup(func() {
+		_ = listener.Close()
+		<-done
+	})
+	return listener.Addr().String()
+}
+
+func dialPoolBackend(t *testing.T, address string) (net.Conn, string) {
+	t.Helper()
+	conn := dialTCP(t, address)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	return conn, line[:len(line)-1]
+}
+
+func backendStatus(t *testing.T, manager *Manager, name string) domain.TrafficBackendStatus {
+	t.Helper()
+	for _, service := range manager.Status().Services {
+		for _, backend := range service.Backends {
+			if backend.Name == name {
+				return backend
+			}
+		}
+	}
+	require.FailNow(t, "backend not found", name)
+	return domain.TrafficBackendStatus{}
+}
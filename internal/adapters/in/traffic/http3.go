@@ -0,0 +1,395 @@
+package traffic
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	// http3AltSvcMaxAge is how long clients may remember the Alt-Svc
+	// advertisement of an http3 entrypoint.
+	http3AltSvcMaxAge  = 24 * time.Hour
+	http3IdleTimeout   = 30 * time.Second
+	http3RefusedReason = "refused"
+)
+
+// http3RefusedCode closes QUIC sessions refused before any request was served.
+var http3RefusedCode = quic.ApplicationErrorCode(http3.ErrCodeRequestRejected)
+
+// HTTP3ServerConfig describes the HTTP/3 server attached to an http3 entrypoint.
+type HTTP3ServerConfig struct {
+	Handler   http.Handler
+	TLSConfig *tls.Config
+}
+
+type http3Servers map[string]HTTP3ServerConfig
+
+// SetHTTP3Server installs or removes the HTTP/3 server for an http3
+// entrypoint. The TLS config is the one used by the TCP TLS fallback; the
+// h3 ALPN protocol is applied to it here.
+func (m *Manager) SetHTTP3Server(entryPoint string, handler http.Handler, tlsConfig *tls.Config) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current := m.loadHTTP3Servers()
+	next := make(http3Servers, len(current)+1)
+	maps.Copy(next, current)
+	if handler == nil || tlsConfig == nil {
+		delete(next, entryPoint)
+	} else {
+		next[entryPoint] = HTTP3ServerConfig{Handler: handler, TLSConfig: http3.ConfigureTLSConfig(tlsConfig)}
+	}
+	m.http3Servers.Store(next)
+}
+
+func (m *Manager) http3Server(entryPoint string) (HTTP3ServerConfig, bool) {
+	config, ok := m.loadHTTP3Servers()[entryPoint]
+	return config, ok
+}
+
+func (m *Manager) loadHTTP3Servers() http3Servers {
+	value := m.http3Servers.Load()
+	if value == nil {
+		return http3Servers{}
+	}
+	return value.(http3Servers)
+}
+
+// storeHTTP3Ports records the UDP ports bound by http3 entrypoints for Alt-Svc.
+// Must be called with m.mu held.
+func (m *Manager) storeHTTP3Ports(listeners map[string]*http3EntryPointRuntime) {
+	ports := make(map[string]int, len(listeners))
+	for name, runtime := range listeners {
+		if port := addrPort(runtime.packetConn.LocalAddr()); port > 0 {
+			ports[name] = port
+		}
+	}
+	m.http3Ports.Store(ports)
+}
+
+// http3AltSvc returns the Alt-Svc value advertising HTTP/3 to clients of a
+// TCP entrypoint bound on tcpPort: the http3 entrypoint on the same port, or
+// else the only http3 entrypoint. It is empty when no http3 entrypoint has a
+// server or the choice is ambiguous.
+func (m *Manager) http3AltSvc(tcpPort int) string {
+	ports, _ := m.http3Ports.Load().(map[string]int)
+	servers := m.loadHTTP3Servers()
+	port := 0
+	candidates := 0
+	for name, candidate := range ports {
+		if _, ok := servers[name]; !ok {
+			continue
+		}
+		if candidate == tcpPort {
+			port = candidate
+			candidates = 1
+			break
+		}
+		port = candidate
+		candidates++
+	}
+	if candidates != 1 {
+		return ""
+	}
+	return fmt.Sprintf(`h3=":%d"; ma=%d`, port, int(http3AltSvcMaxAge.Seconds()))
+}
+
+// altSvcHandler advertises HTTP/3 on responses of the TLS servers attached
+// to a TCP entrypoint.
+func (r *entryPointRuntime) altSvcHandler(next http.Handler) http.Handler {
+	port := addrPort(r.listener.Addr())
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if value := r.manager.http3AltSvc(port); value != "" {
+			w.Header().Set("Alt-Svc", value)
+		}
+		next.ServeHTTP(w, req)
+	})
+}
+
+func addrPort(addr net.Addr) int {
+	if addr == nil {
+		return 0
+	}
+	_, portText, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return 0
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return 0
+	}
+	return port
+}
+
+type http3EntryPointRuntime struct {
+	manager    *Manager
+	entryPoint domain.EntryPoint
+	packetConn net.PacketConn
+	transport  *quic.Transport
+	counters   trafficCounters
+	trusted    []*net.IPNet
+
+	started atomic.Bool
+	closed  atomic.Bool
+
+	ctx            context.Context
+	cancel         context.CancelFunc
+	acceptDone     chan struct{}
+	acceptDoneOnce sync.Once
+	activeWG       sync.WaitGroup
+
+	listener *quic.Listener
+	server   *http3.Server
+
+	mu       sync.Mutex
+	sessions map[*quic.Conn]struct{}
+}
+
+func newHTTP3EntryPointRuntime(parentCtx context.Context, manager *Manager, entryPoint domain.EntryPoint, packetConn net.PacketConn, trusted []*net.IPNet) *http3EntryPointRuntime {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(parentCtx))
+	runtime := &http3EntryPointRuntime{
+		manager:    manager,
+		entryPoint: entryPoint,
+		packetConn: packetConn,
+		transport:  &quic.Transport{Conn: packetConn},
+		trusted:    trusted,
+		ctx:        ctx,
+		cancel:     cancel,
+		acceptDone: make(chan struct{}),
+		sessions:   map[*quic.Conn]struct{}{},
+	}
+	runtime.server = &http3.Server{Handler: http.HandlerFunc(runtime.serveHTTP), IdleTimeout: http3IdleTimeout}
+	return runtime
+}
+
+func (r *http3EntryPointRuntime) start() {
+	if !r.started.CompareAndSwap(false, true) {
+		return
+	}
+	entryPoint := r.entryPointSnapshot()
+	// The handshake looks up the entrypoint's server on every connection, so
+	// SetHTTP3Server takes effect without rebinding the socket.
+	listener, err := r.transport.Listen(&tls.Config{
+		MinVersion:         tls.VersionTLS13,
+		NextProtos:         []string{http3.NextProtoH3},
+		GetConfigForClient: r.tlsConfigForClient,
+	}, &quic.Config{MaxIdleTimeout: http3IdleTimeout})
+	if err != nil {
+		trafficWarn(r.ctx).Err(err).Str("entrypoint", entryPoint.Name).Msg("failed to start http3 traffic entrypoint")
+		r.closeAcceptDone()
+		return
+	}
+	r.mu.Lock()
+	r.listener = listener
+	r.mu.Unlock()
+	trafficInfo(r.ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Str("protocol", string(entryPoint.Protocol)).Msg("started http3 traffic entrypoint")
+	go r.acceptLoop(listener)
+}
+
+func (r *http3EntryPointRuntime) tlsConfigForClient(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	config, ok := r.manager.http3Server(r.entryPointSnapshot().Name)
+	if !ok {
+		return nil, domain.ErrHTTP3ServerUnavailable
+	}
+	// Certificate selection and per-route client authentication come from
+	// the fallback config's own GetConfigForClient, already adapted for h3.
+	if config.TLSConfig.GetConfigForClient != nil {
+		perClient, err := config.TLSConfig.GetConfigForClient(hello)
+		if err != nil || perClient != nil {
+			return perClient, err
+		}
+	}
+	return config.TLSConfig, nil
+}
+
+func (r *http3EntryPointRuntime) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	r.counters.quic.requests.Add(1)
+	config, ok := r.manager.http3Server(r.entryPointSnapshot().Name)
+	if !ok {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	config.Handler.ServeHTTP(w, req)
+}
+
+func (r *http3EntryPointRuntime) acceptLoop(listener *quic.Listener) {
+	defer r.closeAcceptDone()
+	for {
+		conn, err := listener.Accept(r.ctx)
+		if err != nil {
+			if r.isClosed() || errors.Is(err, quic.ErrServerClosed) || errors.Is(err, context.Canceled) {
+				return
+			}
+			r.counters.totalErrors.Add(1)
+			continue
+		}
+		if !trustedRemoteAddr(r.trustedSnapshot(), conn.RemoteAddr()) {
+			r.refuse(conn)
+			continue
+		}
+		options := effectiveUDPOptions(snapshotUDPOptions(r.manager.snapshot.Load()))
+		if !r.track(conn, options.MaxSessions) {
+			r.refuse(conn)
+			continue
+		}
+		go r.serveSession(conn)
+	}
+}
+
+func (r *http3EntryPointRuntime) serveSession(conn *quic.Conn) {
+	defer r.untrack(conn)
+	if err := r.server.ServeQUICConn(conn); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		trafficDebug(r.ctx).Err(err).Str("entrypoint", r.entryPointSnapshot().Name).Msg("http3 session ended")
+	}
+	_ = conn.CloseWithError(quic.ApplicationErrorCode(http3.ErrCodeNoError), "")
+}
+
+func (r *http3EntryPointRuntime) refuse(conn *quic.Conn) {
+	r.counters.totalRefused.Add(1)
+	r.counters.quic.sessionsRefused.Add(1)
+	_ = conn.CloseWithError(http3RefusedCode, http3RefusedReason)
+}
+
+func (r *http3EntryPointRuntime) track(conn *quic.Conn, maxSessions int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.isClosed() || len(r.sessions) >= maxSessions {
+		return false
+	}
+	r.sessions[conn] = struct{}{}
+	r.activeWG.Add(1)
+	r.counters.totalAccepted.Add(1)
+	r.counters.quic.sessionsAccepted.Add(1)
+	r.counters.quic.activeSessions.Add(1)
+	return true
+}
+
+func (r *http3EntryPointRuntime) untrack(conn *quic.Conn) {
+	r.mu.Lock()
+	if _, ok := r.sessions[conn]; !ok {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.sessions, conn)
+	r.mu.Unlock()
+	r.counters.quic.activeSessions.Add(-1)
+	r.activeWG.Done()
+}
+
+func (r *http3EntryPointRuntime) stop(ctx context.Context, drainTimeout time.Duration) {
+	if r.closed.CompareAndSwap(false, true) {
+		entryPoint := r.entryPointSnapshot()
+		trafficInfo(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Msg("stopping http3 traffic entrypoint")
+		r.cancel()
+		r.mu.Lock()
+		listener := r.listener
+		r.mu.Unlock()
+		if listener != nil {
+			_ = listener.Close()
+		}
+		if !r.started.Load() {
+			r.closeAcceptDone()
+		}
+	}
+	select {
+	case <-r.acceptDone:
+	case <-ctx.Done():
+		return
+	}
+	if drainTimeout <= 0 {
+		drainTimeout = defaultUDPOptions().DrainTimeout
+	}
+	// Shutdown sends GOAWAY and waits for in-flight requests; sessions that
+	// outlive the drain timeout are closed here because the server does not
+	// own connections passed to ServeQUICConn.
+	shutdownCtx, cancel := context.WithTimeout(ctx, drainTimeout)
+	defer cancel()
+	if err := r.server.Shutdown(shutdownCtx); err != nil {
+		trafficDebug(ctx).Str("entrypoint", r.entryPointSnapshot().Name).Dur("drain_timeout", drainTimeout).Msg("forcing http3 traffic entrypoint drain")
+		r.closeSessions()
+	}
+	select {
+	case <-r.sessionsDone():
+	case <-ctx.Done():
+	}
+	_ = r.transport.Close()
+	_ = r.packetConn.Close()
+	trafficInfo(ctx).Str("entrypoint", r.entryPointSnapshot().Name).Msg("stopped http3 traffic entrypoint")
+}
+
+func (r *http3EntryPointRuntime) sessionsDone() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		r.activeWG.Wait()
+		close(done)
+	}()
+	return done
+}
+
+func (r *http3EntryPointRuntime) closeSessions() {
+	r.closeSessionsMatching(func(*quic.Conn) bool { return true })
+}
+
+func (r *http3EntryPointRuntime) closeSessionsMatching(match func(*quic.Conn) bool) {
+	r.mu.Lock()
+	stale := make([]*quic.Conn, 0, len(r.sessions))
+	for conn := range r.sessions {
+		if match(conn) {
+			stale = append(stale, conn)
+		}
+	}
+	r.mu.Unlock()
+	for _, conn := range stale {
+		_ = conn.CloseWithError(http3RefusedCode, http3RefusedReason)
+	}
+}
+
+func (r *http3EntryPointRuntime) closeAcceptDone() {
+	r.acceptDoneOnce.Do(func() { close(r.acceptDone) })
+}
+
+func (r *http3EntryPointRuntime) matches(entryPoint domain.EntryPoint) bool {
+	current := r.entryPointSnapshot()
+	return current.Name == entryPoint.Name && current.Address == entryPoint.Address && current.Protocol == entryPoint.Protocol && trustedCIDRsEqual(current.TrustedCIDRs, entryPoint.TrustedCIDRs)
+}
+
+func (r *http3EntryPointRuntime) sameAddress(entryPoint domain.EntryPoint) bool {
+	return r.entryPointSnapshot().Address == entryPoint.Address
+}
+
+func (r *http3EntryPointRuntime) updateEntryPoint(entryPoint domain.EntryPoint, trusted []*net.IPNet) {
+	r.mu.Lock()
+	r.entryPoint = entryPoint
+	r.trusted = trusted
+	r.mu.Unlock()
+	r.closeSessionsMatching(func(conn *quic.Conn) bool {
+		return !trustedRemoteAddr(trusted, conn.RemoteAddr())
+	})
+}
+
+func (r *http3EntryPointRuntime) entryPointSnapshot() domain.EntryPoint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.entryPoint
+}
+
+func (r *http3EntryPointRuntime) trustedSnapshot() []*net.IPNet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.trusted
+}
+
+func (r *http3EntryPointRuntime) isClosed() bool { return r.closed.Load() }
@@ -0,0 +1,139 @@
+package traffic
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func http3Graph(t *testing.T, listenAddress string, trustedCIDRs ...string) domain.TrafficGraph {
+	t.Helper()
+	graph := domain.TrafficGraph{
+		Options:     domain.TrafficOptions{UDP: domain.UDPOptions{DrainTimeout: time.Second}},
+		EntryPoints: []domain.EntryPoint{{Name: "edge-h3", Address: listenAddress, Protocol: domain.EntryPointProtocolHTTP3, TrustedCIDRs: trustedCIDRs}},
+	}
+	require.NoError(t, graph.Validate())
+	return graph
+}
+
+func http3Get(t *testing.T, address string, serverName string) (*http.Response, error) {
+	t.Helper()
+	transport := &http3.Transport{TLSClientConfig: &tls.Config{ServerName: serverName, InsecureSkipVerify: true}}
+	t.Cleanup(func() { _ = transport.Close() })
+	client := &http.Client{Transport: transport, Timeout: 2 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, "https://"+address+"/", nil)
+	require.NoError(t, err)
+	req.Host = serverName
+	return client.Do(req)
+}
+
+func TestHTTP3ServesFallbackHandler(t *testing.T) {
+	graph := http3Graph(t, freeUDPAddress(t))
+	manager := NewManager()
+	manager.SetHTTP3Server("edge-h3", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, "%s %s", r.Proto, r.Host)
+	}), testTLSConfig(t, "app.example.com"))
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	resp, err := http3Get(t, graph.EntryPoints[0].Address, "app.example.com")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, "HTTP/3.0 app.example.com", string(body))
+
+	status := manager.Status()
+	require.Len(t, status.EntryPoints, 1)
+	assert.True(t, status.EntryPoints[0].Active)
+	assert.Equal(t, int64(1), status.EntryPoints[0].QUIC.SessionsAccepted)
+	assert.Equal(t, int64(1), status.EntryPoints[0].QUIC.Requests)
+	assert.Equal(t, int64(1), status.Counters.QUIC.SessionsAccepted)
+}
+
+func TestHTTP3RefusesUntrustedPeers(t *testing.T) {
+	graph := http3Graph(t, freeUDPAddress(t), "10.0.0.0/8")
+	manager := NewManager()
+	manager.SetHTTP3Server("edge-h3", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("unexpected"))
+	}), testTLSConfig(t, "app.example.com"))
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	resp, err := http3Get(t, graph.EntryPoints[0].Address, "app.example.com")
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	require.Error(t, err)
+	assert.Eventually(t, func() bool {
+		return manager.Status().EntryPoints[0].QUIC.SessionsRefused >= 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(t, manager.Status().EntryPoints[0].QUIC.SessionsAccepted)
+}
+
+func TestHTTP3WithoutServerFailsHandshake(t *testing.T) {
+	graph := http3Graph(t, freeUDPAddress(t))
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	resp, err := http3Get(t, graph.EntryPoints[0].Address, "app.example.com")
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	require.Error(t, err)
+}
+
+func TestTLSFallbackAdvertisesHTTP3(t *testing.T) {
+	h3Address := freeUDPAddress(t)
+	graph := tlsGraph(t, freeTCPAddress(t), nil)
+	graph.EntryPoints = append(graph.EntryPoints, domain.EntryPoint{Name: "edge-h3", Address: h3Address, Protocol: domain.EntryPointProtocolHTTP3})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+	manager := NewManager()
+	manager.SetTLSHTTPServer("websecure", handler, testTLSConfig(t, "app.example.com"))
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	assert.Empty(t, httpsAltSvc(t, graph.EntryPoints[0].Address), "no Alt-Svc before an http3 server is installed")
+
+	manager.SetHTTP3Server("edge-h3", handler, testTLSConfig(t, "app.example.com"))
+	want := fmt.Sprintf(`h3=":%d"; ma=86400`, addrPort(manager.http3Listeners["edge-h3"].packetConn.LocalAddr()))
+	assert.Equal(t, want, httpsAltSvc(t, graph.EntryPoints[0].Address))
+}
+
+func TestHTTP3AltSvcPrefersSamePort(t *testing.T) {
+	manager := NewManager()
+	manager.http3Ports.Store(map[string]int{"a": 443, "b": 8443})
+	manager.SetHTTP3Server("a", http.NotFoundHandler(), &tls.Config{})
+	manager.SetHTTP3Server("b", http.NotFoundHandler(), &tls.Config{})
+
+	assert.Equal(t, `h3=":8443"; ma=86400`, manager.http3AltSvc(8443))
+	assert.Empty(t, manager.http3AltSvc(9443), "ambiguous without a same-port http3 entrypoint")
+
+	manager.SetHTTP3Server("a", nil, nil)
+	assert.Equal(t, `h3=":8443"; ma=86400`, manager.http3AltSvc(9443))
+}
+
+func httpsAltSvc(t *testing.T, address string) string {
+	t.Helper()
+	transport := &http.Transport{TLSClientConfig: &tls.Config{ServerName: "app.example.com", InsecureSkipVerify: true}}
+	defer transport.CloseIdleConnections()
+	client := &http.Client{Transport: transport, Timeout: time.Second}
+	resp, err := client.Get("https://" + address + "/")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Header.Get("Alt-Svc")
+}
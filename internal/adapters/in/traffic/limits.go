@@ -0,0 +1,192 @@
+package traffic
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// maxIdleLimitSources bounds how many idle source entries a router keeps for
+// rate limiting before pruning the ones whose bucket has refilled.
+const maxIdleLimitSources = 4096
+
+// routerLimiters maps router names to the limiters of routers with limits.
+type routerLimiters map[string]*routerLimiter
+
+// routerLimiter enforces one router's connection limits. It survives reloads
+// so live connections keep counting against updated limits.
+type routerLimiter struct {
+	mu      sync.Mutex
+	limits  domain.TrafficLimits
+	active  int
+	sources map[netip.Addr]*sourceUsage
+}
+
+type sourceUsage struct {
+	active int
+	rate   *rate.Limiter
+}
+
+type limitRefusal int
+
+const (
+	limitAllowed limitRefusal = iota
+	limitRouterConnections
+	limitSourceConnections
+	limitSourceRate
+)
+
+// buildRouterLimiters returns limiters for routers with limits, reusing the
+// limiter of a router with the same name from current.
+func buildRouterLimiters(graph *domain.TrafficGraph, current routerLimiters) routerLimiters {
+	next := routerLimiters{}
+	for _, router := range graph.Routers {
+		if router.Limits == (domain.TrafficLimits{}) {
+			continue
+		}
+		limiter := current[router.Name]
+		if limiter == nil {
+			limiter = &routerLimiter{sources: map[netip.Addr]*sourceUsage{}}
+		}
+		limiter.update(router.Limits)
+		next[router.Name] = limiter
+	}
+	return next
+}
+
+func (l *routerLimiter) update(limits domain.TrafficLimits) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limits.ConnectionRate != limits.ConnectionRate || l.limits.EffectiveConnectionBurst() != limits.EffectiveConnectionBurst() {
+		for ip, source := range l.sources {
+			source.rate = nil
+			if source.active == 0 {
+				delete(l.sources, ip)
+			}
+		}
+	}
+	l.limits = limits
+}
+
+// acquire reserves a connection or session slot for client. A nil limiter
+// always allows; an allowed acquire must be paired with release.
+func (l *routerLimiter) acquire(client net.Addr) limitRefusal {
+	if l == nil {
+		return limitAllowed
+	}
+	ip := sourceAddr(client)
+	now := time.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limits.MaxConnections > 0 && l.active >= l.limits.MaxConnections {
+		return limitRouterConnections
+	}
+	source := l.sources[ip]
+	if source == nil {
+		l.pruneLocked(now)
+		source = &sourceUsage{}
+		l.sources[ip] = source
+	}
+	if l.limits.MaxConnectionsPerIP > 0 && source.active >= l.limits.MaxConnectionsPerIP {
+		return limitSourceConnections
+	}
+	if l.limits.ConnectionRate > 0 {
+		if source.rate == nil {
+			source.rate = rate.NewLimiter(rate.Limit(l.limits.ConnectionRate), l.limits.EffectiveConnectionBurst())
+		}
+		if !source.rate.AllowN(now, 1) {
+			return limitSourceRate
+		}
+	}
+	l.active++
+	source.active++
+	return limitAllowed
+}
+
+func (l *routerLimiter) release(client net.Addr) {
+	if l == nil {
+		return
+	}
+	ip := sourceAddr(client)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	if source := l.sources[ip]; source != nil {
+		source.active--
+		if source.active == 0 && source.rate == nil {
+			delete(l.sources, ip)
+		}
+	}
+}
+
+// pruneLocked drops idle sources whose rate bucket has refilled, so the map
+// only grows with sources that are connected or recently limited.
+func (l *routerLimiter) pruneLocked(now time.Time) {
+	if len(l.sources) < maxIdleLimitSources {
+		return
+	}
+	for ip, source := range l.sources {
+		if source.active == 0 && (source.rate == nil || source.rate.TokensAt(now) >= float64(source.rate.Burst())) {
+			delete(l.sources, ip)
+		}
+	}
+}
+
+func sourceAddr(addr net.Addr) netip.Addr {
+	if addr == nil {
+		return netip.Addr{}
+	}
+	addrPort, err := netip.ParseAddrPort(addr.String())
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addrPort.Addr().Unmap()
+}
+
+func (c *trafficCounters) refuseLimit(refusal limitRefusal) {
+	c.totalRefused.Add(1)
+	switch refusal {
+	case limitRouterConnections:
+		c.limits.routerRefused.Add(1)
+	case limitSourceConnections:
+		c.limits.sourceRefused.Add(1)
+	case limitSourceRate:
+		c.limits.rateRefused.Add(1)
+	}
+}
+
+// newBandwidthLimiter returns a token bucket for bytesPerSecond, or nil when
+// bandwidth is unlimited. The burst is at least minBurst so a single UDP
+// datagram always fits.
+func newBandwidthLimiter(bytesPerSecond int64, minBurst int) *rate.Limiter {
+	if bytesPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(bytesPerSecond), max(int(bytesPerSecond), minBurst))
+}
+
+// throttledReader delays reads so the bytes returned stay within limiter.
+type throttledReader struct {
+	ctx     context.Context
+	reader  io.Reader
+	limiter *rate.Limiter
+}
+
+func (r throttledReader) Read(p []byte) (int, error) {
+	if len(p) > r.limiter.Burst() {
+		p = p[:r.limiter.Burst()]
+	}
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		// WaitN only fails once ctx is done, when the connection is closing.
+		_ = r.limiter.WaitN(r.ctx, n)
+	}
+	return n, err
+}
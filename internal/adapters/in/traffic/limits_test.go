@@ -0,0 +1,139 @@
+package traffic
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestRouterLimiterEnforcesLimits(t *testing.T) {
+	clientA := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	clientA2 := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1001}
+	clientB := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 1000}
+
+	t.Run("router and source connections", func(t *testing.T) {
+		limiter := limiterFor(domain.TrafficLimits{MaxConnections: 2, MaxConnectionsPerIP: 1})
+		require.Equal(t, limitAllowed, limiter.acquire(clientA))
+		assert.Equal(t, limitSourceConnections, limiter.acquire(clientA2))
+		require.Equal(t, limitAllowed, limiter.acquire(clientB))
+		assert.Equal(t, limitRouterConnections, limiter.acquire(&net.TCPAddr{IP: net.IPv4(10, 0, 0, 3)}))
+		limiter.release(clientA)
+		assert.Equal(t, limitAllowed, limiter.acquire(clientA2))
+	})
+
+	t.Run("source rate", func(t *testing.T) {
+		limiter := limiterFor(domain.TrafficLimits{ConnectionRate: 1, ConnectionBurst: 2})
+		for range 2 {
+			require.Equal(t, limitAllowed, limiter.acquire(clientA))
+			limiter.release(clientA)
+		}
+		assert.Equal(t, limitSourceRate, limiter.acquire(clientA2))
+		assert.Equal(t, limitAllowed, limiter.acquire(clientB))
+	})
+
+	t.Run("nil limiter allows", func(t *testing.T) {
+		var limiter *routerLimiter
+		assert.Equal(t, limitAllowed, limiter.acquire(clientA))
+		limiter.release(clientA)
+	})
+}
+
+func TestBuildRouterLimitersKeepsActiveCountsAcrossReload(t *testing.T) {
+	client := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1000}
+	graph := &domain.TrafficGraph{Routers: []domain.TrafficRouter{{Name: "db", Limits: domain.TrafficLimits{MaxConnections: 2}}, {Name: "open"}}}
+	limiters := buildRouterLimiters(graph, nil)
+	require.NotContains(t, limiters, "open")
+	require.Equal(t, limitAllowed, limiters["db"].acquire(client))
+
+	graph.Routers[0].Limits.MaxConnections = 1
+	reloaded := buildRouterLimiters(graph, limiters)
+	require.Same(t, limiters["db"], reloaded["db"])
+	assert.Equal(t, limitRouterConnections, reloaded["db"].acquire(client))
+}
+
+func TestThrottledReaderCapsBandwidth(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), 3000)
+	reader := throttledReader{ctx: t.Context(), reader: bytes.NewReader(payload), limiter: newBandwidthLimiter(2000, 0)}
+	started := time.Now()
+	got, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+	assert.GreaterOrEqual(t, time.Since(started), 400*time.Millisecond, "the burst covers 2000 bytes and the rest waits for refill")
+}
+
+func TestTCPRouterLimitsRefusePerSourceOverflow(t *testing.T) {
+	backend := startTCPEchoServer(t, 0)
+	graph := tcpGraph(t, freeTCPAddress(t), backend.address)
+	graph.Routers[0].Limits = domain.TrafficLimits{MaxConnectionsPerIP: 1}
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	first := dialTCP(t, graph.EntryPoints[0].Address)
+	defer first.Close()
+	assertRoundTrip(t, first, "first")
+
+	assertTCPRejected(t, manager, graph.EntryPoints[0].Address, 1)
+	assert.Equal(t, domain.LimitCounters{SourceRefused: 1}, manager.Status().Counters.Limits)
+
+	require.NoError(t, first.Close())
+	require.Eventually(t, func() bool { return manager.Status().Counters.ActiveTCPConnections == 0 }, time.Second, 10*time.Millisecond)
+	third := dialTCP(t, graph.EntryPoints[0].Address)
+	defer third.Close()
+	assertRoundTrip(t, third, "third")
+}
+
+func TestUDPRouterLimitsRefusePerSourceOverflow(t *testing.T) {
+	backend := startUDPEchoServer(t)
+	graph := udpGraph(t, freeUDPAddress(t), backend.address)
+	graph.Routers[0].Limits = domain.TrafficLimits{MaxConnectionsPerIP: 1}
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	first := dialUDP(t, graph.EntryPoints[0].Address)
+	defer first.Close()
+	assertUDPRoundTrip(t, first, "first")
+
+	second := dialUDP(t, graph.EntryPoints[0].Address)
+	defer second.Close()
+	_, err := second.Write([]byte("second"))
+	require.NoError(t, err)
+	_ = second.SetReadDeadline(time.Now().Add(80 * time.Millisecond))
+	_, err = second.Read(make([]byte, 32))
+	require.Error(t, err)
+	assert.Eventually(t, func() bool { return manager.Status().Counters.Limits.SourceRefused == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int64(1), manager.Status().Counters.TotalRefused)
+}
+
+func TestUDPRouterBandwidthDropsExcessDatagrams(t *testing.T) {
+	backend := startUDPEchoServer(t)
+	graph := udpGraph(t, freeUDPAddress(t), backend.address)
+	graph.Routers[0].Limits = domain.TrafficLimits{BandwidthBytesPerSecond: 1}
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	conn := dialUDP(t, graph.EntryPoints[0].Address)
+	defer conn.Close()
+	payload := bytes.Repeat([]byte("x"), udpBufferSize/2)
+	_, err := conn.Write(payload)
+	require.NoError(t, err)
+	_, err = conn.Write(payload)
+	require.NoError(t, err)
+	_, err = conn.Write(payload)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return manager.Status().Counters.Limits.BandwidthDropped >= 1 }, time.Second, 10*time.Millisecond)
+}
+
+func limiterFor(limits domain.TrafficLimits) *routerLimiter {
+	return buildRouterLimiters(&domain.TrafficGraph{Routers: []domain.TrafficRouter{{Name: "r", Limits: limits}}}, nil)["r"]
+}
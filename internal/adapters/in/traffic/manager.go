@@ -47,16 +47,24 @@ func trafficWarn(ctx context.Context) *zerolog.Event {
 
 // Manager owns runtime entrypoints for the traffic plane.
 type Manager struct {
-	mu           sync.Mutex
-	snapshot     atomic.Pointer[domain.TrafficGraph]
-	listeners    map[string]*entryPointRuntime
-	udpListeners map[string]*udpEntryPointRuntime
+	mu             sync.Mutex
+	snapshot       atomic.Pointer[domain.TrafficGraph]
+	listeners      map[string]*entryPointRuntime
+	udpListeners   map[string]*udpEntryPointRuntime
+	http3Listeners map[string]*http3EntryPointRuntime
 
 	lastReloadStatus string
 	lastReloadError  string
 	tlsHTTPServers   atomic.Value
 	smartHTTPServers atomic.Value
 	smartTLSServers  atomic.Value
+	http3Servers     atomic.Value
+	http3Ports       atomic.Value
+	balancers        atomic.Value
+	sniRoutes        atomic.Value
+	limiters         atomic.Value
+
+	tlsALPNChallenges atomic.Value
 }
 
 // NewManager creates a traffic manager.
@@ -64,11 +72,17 @@ func NewManager() *Manager {
 	manager := &Manager{
 		listeners:        map[string]*entryPointRuntime{},
 		udpListeners:     map[string]*udpEntryPointRuntime{},
+		http3Listeners:   map[string]*http3EntryPointRuntime{},
 		lastReloadStatus: reloadStatusOK,
 	}
 	manager.tlsHTTPServers.Store(tlsHTTPServers{})
 	manager.smartHTTPServers.Store(smartHTTPServers{})
 	manager.smartTLSServers.Store(smartTLSServers{})
+	manager.http3Servers.Store(http3Servers{})
+	manager.http3Ports.Store(map[string]int{})
+	manager.balancers.Store(serviceBalancers{})
+	manager.sniRoutes.Store(sniMatchers{})
+	manager.limiters.Store(routerLimiters{})
 	return manager
 }
 
@@ -101,25 +115,52 @@ func (m *Manager) Apply(ctx context.Context, graph *domain.TrafficGraph) error {
 		trafficWarn(ctx).Err(err).Msg("failed to prepare udp traffic listeners")
 		return err
 	}
+	newHTTP3Listeners, createdHTTP3Listeners, http3Updates, err := m.prepareHTTP3Listeners(ctx, &nextGraph)
+	if err != nil {
+		m.lastReloadStatus = reloadStatusError
+		m.lastReloadError = err.Error()
+		stopTCPRuntimes(ctx, createdListeners, effectiveTCPOptions(snapshotTCPOptions(&nextGraph)).DrainTimeout)
+		stopUDPRuntimes(ctx, createdUDPListeners, effectiveUDPOptions(snapshotUDPOptions(&nextGraph)).DrainTimeout)
+		m.mu.Unlock()
+		trafficWarn(ctx).Err(err).Msg("failed to prepare http3 traffic listeners")
+		return err
+	}
 
 	for _, update := range tcpUpdates {
-		update.runtime.updateEntryPoint(update.entryPoint, update.trusted, update.rawTrusted)
+		update.runtime.updateEntryPoint(update.entryPoint, update.trusted, update.rawTrusted, update.proxyTrusted)
 	}
 	for _, update := range udpUpdates {
 		update.runtime.updateEntryPoint(update.entryPoint, update.trusted)
 	}
+	for _, update := range http3Updates {
+		update.runtime.updateEntryPoint(update.entryPoint, update.trusted)
+	}
 
 	oldListeners := m.listeners
 	oldUDPListeners := m.udpListeners
+	oldHTTP3Listeners := m.http3Listeners
 	m.listeners = newListeners
 	m.udpListeners = newUDPListeners
+	m.http3Listeners = newHTTP3Listeners
+	m.storeHTTP3Ports(newHTTP3Listeners)
+	oldBalancers := m.serviceBalancers()
+	newBalancers, createdBalancers := buildBalancers(&nextGraph, oldBalancers)
+	m.balancers.Store(newBalancers)
+	m.sniRoutes.Store(buildSNIMatchers(&nextGraph, m.sniMatchers()))
+	m.limiters.Store(buildRouterLimiters(&nextGraph, m.routerLimiters()))
 	m.snapshot.Store(&nextGraph)
+	for _, balancer := range createdBalancers {
+		balancer.startHealthChecks(ctx)
+	}
 	for _, runtime := range createdListeners {
 		runtime.start()
 	}
 	for _, runtime := range createdUDPListeners {
 		runtime.start()
 	}
+	for _, runtime := range createdHTTP3Listeners {
+		runtime.start()
+	}
 	m.lastReloadStatus = reloadStatusOK
 	m.lastReloadError = ""
 	m.mu.Unlock()
@@ -137,8 +178,8 @@ func (m *Manager) Apply(ctx context.Context, graph *domain.TrafficGraph) error {
 	stoppedUDP := 0
 	for _, runtime := range oldUDPListeners {
 		if udpRuntimeRetained(newUDPListeners, runtime) {
-			if backend, ok := runtime.resolveUDPBackend(); ok {
-				runtime.drainSessionsNotMatchingAfter(backend, udpDrainTimeout)
+			if _, balancer, ok := runtime.resolveUDPBalancer(); ok {
+				runtime.drainSessionsNotMatchingAfter(balancer, udpDrainTimeout)
 			} else {
 				runtime.drainSessionsAfter(udpDrainTimeout)
 			}
@@ -147,10 +188,51 @@ func (m *Manager) Apply(ctx context.Context, graph *domain.TrafficGraph) error {
 		stoppedUDP++
 		runtime.stop(ctx, udpDrainTimeout)
 	}
-	logAppliedTrafficGraph(ctx, newListeners, newUDPListeners, createdListeners, createdUDPListeners, stoppedTCP, stoppedUDP)
+	for _, runtime := range oldHTTP3Listeners {
+		if http3RuntimeRetained(newHTTP3Listeners, runtime) {
+			continue
+		}
+		stoppedUDP++
+		runtime.stop(ctx, udpDrainTimeout)
+	}
+	for name, balancer := range oldBalancers {
+		if newBalancers[name] != balancer {
+			balancer.stopHealthChecks()
+		}
+	}
+	logAppliedTrafficGraph(ctx, newListeners, newUDPListeners, newHTTP3Listeners, createdListeners, createdUDPListeners, createdHTTP3Listeners, stoppedTCP, stoppedUDP)
 	return nil
 }
 
+func (m *Manager) sniMatchers() sniMatchers {
+	matchers, _ := m.sniRoutes.Load().(sniMatchers)
+	return matchers
+}
+
+func (m *Manager) routerLimiters() routerLimiters {
+	limiters, _ := m.limiters.Load().(routerLimiters)
+	return limiters
+}
+
+// routerLimiter returns the limiter of router, or nil when it has no limits.
+func (m *Manager) routerLimiter(router string) *routerLimiter {
+	return m.routerLimiters()[router]
+}
+
+func (m *Manager) serviceBalancers() serviceBalancers {
+	balancers, _ := m.balancers.Load().(serviceBalancers)
+	return balancers
+}
+
+// balancerFor returns the balancer of an L4 service whose backends speak protocol.
+func (m *Manager) balancerFor(service string, protocol domain.NetworkProtocol) (*serviceBalancer, bool) {
+	balancer := m.serviceBalancers()[service]
+	if balancer == nil || balancer.protocol() != protocol {
+		return nil, false
+	}
+	return balancer, true
+}
+
 func tcpRuntimeRetained(listeners map[string]*entryPointRuntime, runtime *entryPointRuntime) bool {
 	for _, candidate := range listeners {
 		if candidate == runtime {
@@ -169,24 +251,35 @@ func udpRuntimeRetained(listeners map[string]*udpEntryPointRuntime, runtime *udp
 	return false
 }
 
+func http3RuntimeRetained(listeners map[string]*http3EntryPointRuntime, runtime *http3EntryPointRuntime) bool {
+	for _, candidate := range listeners {
+		if candidate == runtime {
+			return true
+		}
+	}
+	return false
+}
+
 func logAppliedTrafficGraph(
 	ctx context.Context,
 	newListeners map[string]*entryPointRuntime,
 	newUDPListeners map[string]*udpEntryPointRuntime,
+	newHTTP3Listeners map[string]*http3EntryPointRuntime,
 	createdListeners []*entryPointRuntime,
 	createdUDPListeners []*udpEntryPointRuntime,
+	createdHTTP3Listeners []*http3EntryPointRuntime,
 	stoppedTCP int,
 	stoppedUDP int,
 ) {
 	logEvent := trafficInfo(ctx)
-	if len(newListeners) == 0 && len(newUDPListeners) == 0 && stoppedTCP == 0 && stoppedUDP == 0 {
+	if len(newListeners) == 0 && len(newUDPListeners) == 0 && len(newHTTP3Listeners) == 0 && stoppedTCP == 0 && stoppedUDP == 0 {
 		logEvent = trafficDebug(ctx)
 	}
 	logEvent.
 		Int("tcp_listeners", len(newListeners)).
-		Int("udp_listeners", len(newUDPListeners)).
+		Int("udp_listeners", len(newUDPListeners)+len(newHTTP3Listeners)).
 		Int("created_tcp_listeners", len(createdListeners)).
-		Int("created_udp_listeners", len(createdUDPListeners)).
+		Int("created_udp_listeners", len(createdUDPListeners)+len(createdHTTP3Listeners)).
 		Int("stopped_tcp_listeners", stoppedTCP).
 		Int("stopped_udp_listeners", stoppedUDP).
 		Msg("traffic graph applied")
@@ -200,10 +293,18 @@ func (m *Manager) Shutdown(ctx context.Context) error {
 
 	listeners := m.listeners
 	udpListeners := m.udpListeners
+	http3Listeners := m.http3Listeners
 	m.listeners = map[string]*entryPointRuntime{}
 	m.udpListeners = map[string]*udpEntryPointRuntime{}
+	m.http3Listeners = map[string]*http3EntryPointRuntime{}
+	m.http3Ports.Store(map[string]int{})
+	balancers := m.serviceBalancers()
+	m.balancers.Store(serviceBalancers{})
 	graph := m.snapshot.Load()
 	m.snapshot.Store(nil)
+	for _, balancer := range balancers {
+		balancer.stopHealthChecks()
+	}
 
 	tcpDrainTimeout := defaultTCPOptions().DrainTimeout
 	udpDrainTimeout := defaultUDPOptions().DrainTimeout
@@ -217,7 +318,10 @@ func (m *Manager) Shutdown(ctx context.Context) error {
 	for _, runtime := range udpListeners {
 		runtime.stop(ctx, udpDrainTimeout)
 	}
-	trafficInfo(ctx).Int("tcp_listeners", len(listeners)).Int("udp_listeners", len(udpListeners)).Msg("traffic manager shut down")
+	for _, runtime := range http3Listeners {
+		runtime.stop(ctx, udpDrainTimeout)
+	}
+	trafficInfo(ctx).Int("tcp_listeners", len(listeners)).Int("udp_listeners", len(udpListeners)+len(http3Listeners)).Msg("traffic manager shut down")
 	return nil
 }
 
@@ -228,6 +332,8 @@ func (m *Manager) Status() domain.TrafficStatus {
 	maps.Copy(listeners, m.listeners)
 	udpListeners := make(map[string]*udpEntryPointRuntime, len(m.udpListeners))
 	maps.Copy(udpListeners, m.udpListeners)
+	http3Listeners := make(map[string]*http3EntryPointRuntime, len(m.http3Listeners))
+	maps.Copy(http3Listeners, m.http3Listeners)
 	status := domain.TrafficStatus{LastReloadStatus: m.lastReloadStatus, LastReloadError: m.lastReloadError}
 	graph := m.snapshot.Load()
 	m.mu.Unlock()
@@ -236,9 +342,9 @@ func (m *Manager) Status() domain.TrafficStatus {
 		return status
 	}
 
-	status.EntryPoints = entryPointStatuses(graph.EntryPoints, listeners, udpListeners)
-	status.Routers = routerStatuses(graph.Routers, listeners, udpListeners)
-	status.Services = serviceStatuses(graph.Services)
+	status.EntryPoints = entryPointStatuses(graph.EntryPoints, listeners, udpListeners, http3Listeners)
+	status.Routers = routerStatuses(graph.Routers, listeners, udpListeners, m.sniMatchers().matchCounts())
+	status.Services = serviceStatuses(graph.Services, m.serviceBalancers())
 	status.Counters = aggregateCounters(status.EntryPoints)
 	return status
 }
@@ -253,10 +359,11 @@ func (m *Manager) recordReloadError(ctx context.Context, err error) error {
 }
 
 type tcpRuntimeUpdate struct {
-	runtime    *entryPointRuntime
-	entryPoint domain.EntryPoint
-	trusted    []*net.IPNet
-	rawTrusted []*net.IPNet
+	runtime      *entryPointRuntime
+	entryPoint   domain.EntryPoint
+	trusted      []*net.IPNet
+	rawTrusted   []*net.IPNet
+	proxyTrusted []*net.IPNet
 }
 
 type udpRuntimeUpdate struct {
@@ -265,6 +372,12 @@ type udpRuntimeUpdate struct {
 	trusted    []*net.IPNet
 }
 
+type http3RuntimeUpdate struct {
+	runtime    *http3EntryPointRuntime
+	entryPoint domain.EntryPoint
+	trusted    []*net.IPNet
+}
+
 func (m *Manager) prepareTCPListeners(ctx context.Context, graph *domain.TrafficGraph) (map[string]*entryPointRuntime, []*entryPointRuntime, []tcpRuntimeUpdate, error) {
 	current := make(map[string]*entryPointRuntime, len(m.listeners))
 	maps.Copy(current, m.listeners)
@@ -292,8 +405,13 @@ func (m *Manager) prepareTCPListeners(ctx context.Context, graph *domain.Traffic
 				stopTCPRuntimes(ctx, created, effectiveTCPOptions(graph.Options.TCP).DrainTimeout)
 				return nil, nil, nil, fmt.Errorf("parse raw fallback trusted cidrs for tcp entrypoint %q: %w", entryPoint.Name, err)
 			}
+			proxyTrusted, err := parseTrustedCIDRs(entryPoint.ProxyProtocolTrustedCIDRs)
+			if err != nil {
+				stopTCPRuntimes(ctx, created, effectiveTCPOptions(graph.Options.TCP).DrainTimeout)
+				return nil, nil, nil, fmt.Errorf("parse proxy protocol trusted cidrs for tcp entrypoint %q: %w", entryPoint.Name, err)
+			}
 			trafficDebug(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Msg("reusing tcp traffic listener for same-address entrypoint update")
-			updates = append(updates, tcpRuntimeUpdate{runtime: runtime, entryPoint: entryPoint, trusted: trusted, rawTrusted: rawTrusted})
+			updates = append(updates, tcpRuntimeUpdate{runtime: runtime, entryPoint: entryPoint, trusted: trusted, rawTrusted: rawTrusted, proxyTrusted: proxyTrusted})
 			next[entryPoint.Name] = runtime
 			delete(current, runtime.entryPointSnapshot().Name)
 			continue
@@ -349,8 +467,13 @@ func (m *Manager) bindTCPEntryPoint(ctx context.Context, entryPoint domain.Entry
 		_ = listener.Close()
 		return nil, fmt.Errorf("parse raw fallback trusted cidrs for tcp entrypoint %q: %w", entryPoint.Name, err)
 	}
-	trafficInfo(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Str("protocol", string(entryPoint.Protocol)).Msg("bound tcp traffic entrypoint")
-	runtime := newEntryPointRuntime(ctx, m, entryPoint, listener, trusted, rawTrusted)
+	proxyTrusted, err := parseTrustedCIDRs(entryPoint.ProxyProtocolTrustedCIDRs)
+	if err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("parse proxy protocol trusted cidrs for tcp entrypoint %q: %w", entryPoint.Name, err)
+	}
+	trafficInfo(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Str("protocol", string(entryPoint.Protocol)).Bool("proxy_protocol", entryPoint.ProxyProtocol).Msg("bound tcp traffic entrypoint")
+	runtime := newEntryPointRuntime(ctx, m, entryPoint, listener, trusted, rawTrusted, proxyTrusted)
 	return runtime, nil
 }
 
@@ -416,6 +539,74 @@ func (m *Manager) bindUDPEntryPoint(ctx context.Context, entryPoint domain.Entry
 	return newUDPEntryPointRuntime(ctx, m, entryPoint, packetConn, trusted), nil
 }
 
+func (m *Manager) prepareHTTP3Listeners(ctx context.Context, graph *domain.TrafficGraph) (map[string]*http3EntryPointRuntime, []*http3EntryPointRuntime, []http3RuntimeUpdate, error) {
+	current := make(map[string]*http3EntryPointRuntime, len(m.http3Listeners))
+	maps.Copy(current, m.http3Listeners)
+
+	next := make(map[string]*http3EntryPointRuntime, len(current))
+	created := []*http3EntryPointRuntime{}
+	updates := []http3RuntimeUpdate{}
+	for _, entryPoint := range graph.EntryPoints {
+		if entryPoint.Protocol != domain.EntryPointProtocolHTTP3 {
+			continue
+		}
+		if runtime := current[entryPoint.Name]; runtime != nil && runtime.matches(entryPoint) {
+			next[entryPoint.Name] = runtime
+			delete(current, entryPoint.Name)
+			continue
+		}
+		if runtime := conflictingHTTP3Runtime(current, entryPoint); runtime != nil {
+			trusted, err := parseTrustedCIDRs(entryPoint.TrustedCIDRs)
+			if err != nil {
+				stopHTTP3Runtimes(ctx, created, effectiveUDPOptions(graph.Options.UDP).DrainTimeout)
+				return nil, nil, nil, fmt.Errorf("parse trusted cidrs for http3 entrypoint %q: %w", entryPoint.Name, err)
+			}
+			trafficDebug(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Msg("reusing http3 traffic listener for same-address entrypoint update")
+			updates = append(updates, http3RuntimeUpdate{runtime: runtime, entryPoint: entryPoint, trusted: trusted})
+			next[entryPoint.Name] = runtime
+			delete(current, runtime.entryPointSnapshot().Name)
+			continue
+		}
+		runtime, err := m.bindHTTP3EntryPoint(ctx, entryPoint)
+		if err != nil {
+			stopHTTP3Runtimes(ctx, created, effectiveUDPOptions(graph.Options.UDP).DrainTimeout)
+			return nil, nil, nil, err
+		}
+		next[entryPoint.Name] = runtime
+		created = append(created, runtime)
+	}
+	return next, created, updates, nil
+}
+
+func stopHTTP3Runtimes(ctx context.Context, runtimes []*http3EntryPointRuntime, drainTimeout time.Duration) {
+	for _, runtime := range runtimes {
+		runtime.stop(ctx, drainTimeout)
+	}
+}
+
+func conflictingHTTP3Runtime(current map[string]*http3EntryPointRuntime, entryPoint domain.EntryPoint) *http3EntryPointRuntime {
+	for _, runtime := range current {
+		if runtime.sameAddress(entryPoint) {
+			return runtime
+		}
+	}
+	return nil
+}
+
+func (m *Manager) bindHTTP3EntryPoint(ctx context.Context, entryPoint domain.EntryPoint) (*http3EntryPointRuntime, error) {
+	packetConn, err := (&net.ListenConfig{}).ListenPacket(ctx, "udp", entryPoint.Address)
+	if err != nil {
+		return nil, fmt.Errorf("bind http3 entrypoint %q on %s: %w", entryPoint.Name, entryPoint.Address, err)
+	}
+	trusted, err := parseTrustedCIDRs(entryPoint.TrustedCIDRs)
+	if err != nil {
+		_ = packetConn.Close()
+		return nil, fmt.Errorf("parse trusted cidrs for http3 entrypoint %q: %w", entryPoint.Name, err)
+	}
+	trafficInfo(ctx).Str("entrypoint", entryPoint.Name).Str("address", entryPoint.Address).Str("protocol", string(entryPoint.Protocol)).Msg("bound http3 traffic entrypoint")
+	return newHTTP3EntryPointRuntime(ctx, m, entryPoint, packetConn, trusted), nil
+}
+
 func parseTrustedCIDRs(values []string) ([]*net.IPNet, error) {
 	if len(values) == 0 {
 		return nil, nil
@@ -474,7 +665,7 @@ func trustedRemoteAddr(trusted []*net.IPNet, addr net.Addr) bool {
 	return false
 }
 
-func entryPointStatuses(entries []domain.EntryPoint, listeners map[string]*entryPointRuntime, udpListeners map[string]*udpEntryPointRuntime) []domain.EntryPointStatus {
+func entryPointStatuses(entries []domain.EntryPoint, listeners map[string]*entryPointRuntime, udpListeners map[string]*udpEntryPointRuntime, http3Listeners map[string]*http3EntryPointRuntime) []domain.EntryPointStatus {
 	statuses := make([]domain.EntryPointStatus, 0, len(entries))
 	for _, entry := range entries {
 		status := domain.EntryPointStatus{Name: entry.Name, Address: entry.Address, Protocol: entry.Protocol}
@@ -488,6 +679,8 @@ func entryPointStatuses(entries []domain.EntryPoint, listeners map[string]*entry
 			status.BytesIn = counters.BytesIn
 			status.BytesOut = counters.BytesOut
 			status.SmartTCP = counters.SmartTCP
+			status.ProxyProtocol = counters.ProxyProtocol
+			status.Limits = counters.Limits
 		}
 		if runtime := udpListeners[entry.Name]; runtime != nil {
 			counters := runtime.counters.snapshot()
@@ -498,6 +691,15 @@ func entryPointStatuses(entries []domain.EntryPoint, listeners map[string]*entry
 			status.TotalErrors = counters.TotalErrors
 			status.BytesIn = counters.BytesIn
 			status.BytesOut = counters.BytesOut
+			status.Limits = counters.Limits
+		}
+		if runtime := http3Listeners[entry.Name]; runtime != nil {
+			counters := runtime.counters.snapshot()
+			status.Active = !runtime.isClosed()
+			status.TotalAccepted = counters.TotalAccepted
+			status.TotalRefused = counters.TotalRefused
+			status.TotalErrors = counters.TotalErrors
+			status.QUIC = counters.QUIC
 		}
 		statuses = append(statuses, status)
 	}
@@ -505,7 +707,7 @@ func entryPointStatuses(entries []domain.EntryPoint, listeners map[string]*entry
 	return statuses
 }
 
-func routerStatuses(routers []domain.TrafficRouter, listeners map[string]*entryPointRuntime, udpListeners map[string]*udpEntryPointRuntime) []domain.TrafficRouterStatus {
+func routerStatuses(routers []domain.TrafficRouter, listeners map[string]*entryPointRuntime, udpListeners map[string]*udpEntryPointRuntime, matches map[string]int64) []domain.TrafficRouterStatus {
 	statuses := make([]domain.TrafficRouterStatus, 0, len(routers))
 	for _, router := range routers {
 		tcpActive := false
@@ -518,21 +720,23 @@ func routerStatuses(routers []domain.TrafficRouter, listeners map[string]*entryP
 		}
 		statuses = append(statuses, domain.TrafficRouterStatus{
 			Name: router.Name, EntryPoint: router.EntryPoint, Protocol: router.Protocol,
-			Rule: router.Rule, Service: router.Service, Active: tcpActive || udpActive,
+			Rule: router.Rule, Service: router.Service, ProxyProtocol: router.ProxyProtocol, Active: tcpActive || udpActive,
+			Matches: matches[router.Name],
 		})
 	}
 	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
 	return statuses
 }
 
-func serviceStatuses(services []domain.TrafficService) []domain.TrafficServiceStatus {
+func serviceStatuses(services []domain.TrafficService, balancers serviceBalancers) []domain.TrafficServiceStatus {
 	statuses := make([]domain.TrafficServiceStatus, 0, len(services))
+	now := time.Now()
 	for _, service := range services {
-		status := domain.TrafficServiceStatus{Name: service.Name, Active: true, Backends: make([]domain.TrafficBackendStatus, 0, len(service.Backends))}
-		for _, backend := range service.Backends {
-			status.Backends = append(status.Backends, domain.TrafficBackendStatus{Name: backend.Name, Host: backend.Host, Port: backend.Port, Protocol: backend.Protocol, Active: true})
+		if balancer := balancers[service.Name]; balancer != nil {
+			statuses = append(statuses, balancer.serviceStatus(now))
+			continue
 		}
-		statuses = append(statuses, status)
+		statuses = append(statuses, domain.TrafficServiceStatus{Name: service.Name, Active: true, Backends: []domain.TrafficBackendStatus{}})
 	}
 	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
 	return statuses
@@ -560,6 +764,16 @@ func aggregateCounters(entries []domain.EntryPointStatus) domain.TrafficCounters
 		counters.SmartTCP.MalformedRejected += entry.SmartTCP.MalformedRejected
 		counters.SmartTCP.SniffTimeout += entry.SmartTCP.SniffTimeout
 		counters.SmartTCP.ClientHelloTooLarge += entry.SmartTCP.ClientHelloTooLarge
+		counters.ProxyProtocol.Accepted += entry.ProxyProtocol.Accepted
+		counters.ProxyProtocol.Rejected += entry.ProxyProtocol.Rejected
+		counters.QUIC.SessionsAccepted += entry.QUIC.SessionsAccepted
+		counters.QUIC.SessionsRefused += entry.QUIC.SessionsRefused
+		counters.QUIC.ActiveSessions += entry.QUIC.ActiveSessions
+		counters.QUIC.Requests += entry.QUIC.Requests
+		counters.Limits.RouterRefused += entry.Limits.RouterRefused
+		counters.Limits.SourceRefused += entry.Limits.SourceRefused
+		counters.Limits.RateRefused += entry.Limits.RateRefused
+		counters.Limits.BandwidthDropped += entry.Limits.BandwidthDropped
 	}
 	return counters
 }
@@ -570,6 +784,7 @@ func cloneTrafficGraph(graph domain.TrafficGraph) domain.TrafficGraph {
 	for i := range clone.EntryPoints {
 		clone.EntryPoints[i].TrustedCIDRs = append([]string(nil), graph.EntryPoints[i].TrustedCIDRs...)
 		clone.EntryPoints[i].RawFallbackTrustedCIDRs = append([]string(nil), graph.EntryPoints[i].RawFallbackTrustedCIDRs...)
+		clone.EntryPoints[i].ProxyProtocolTrustedCIDRs = append([]string(nil), graph.EntryPoints[i].ProxyProtocolTrustedCIDRs...)
 	}
 	clone.Routers = append([]domain.TrafficRouter{}, graph.Routers...)
 	clone.Services = append([]domain.TrafficService{}, graph.Services...)
@@ -602,6 +817,28 @@ type trafficCounters struct {
 	bytesIn              atomic.Int64
 	bytesOut             atomic.Int64
 	smartTCP             smartTCPCounterSet
+	proxyProtocol        proxyProtocolCounterSet
+	quic                 quicCounterSet
+	limits               limitCounterSet
+}
+
+type limitCounterSet struct {
+	routerRefused    atomic.Int64
+	sourceRefused    atomic.Int64
+	rateRefused      atomic.Int64
+	bandwidthDropped atomic.Int64
+}
+
+type quicCounterSet struct {
+	sessionsAccepted atomic.Int64
+	sessionsRefused  atomic.Int64
+	activeSessions   atomic.Int64
+	requests         atomic.Int64
+}
+
+type proxyProtocolCounterSet struct {
+	accepted atomic.Int64
+	rejected atomic.Int64
 }
 
 type smartTCPCounterSet struct {
@@ -642,6 +879,22 @@ func (c *trafficCounters) snapshot() domain.TrafficCounters {
 			SniffTimeout:             c.smartTCP.sniffTimeout.Load(),
 			ClientHelloTooLarge:      c.smartTCP.clientHelloTooLarge.Load(),
 		},
+		ProxyProtocol: domain.ProxyProtocolCounters{
+			Accepted: c.proxyProtocol.accepted.Load(),
+			Rejected: c.proxyProtocol.rejected.Load(),
+		},
+		QUIC: domain.QUICCounters{
+			SessionsAccepted: c.quic.sessionsAccepted.Load(),
+			SessionsRefused:  c.quic.sessionsRefused.Load(),
+			ActiveSessions:   c.quic.activeSessions.Load(),
+			Requests:         c.quic.requests.Load(),
+		},
+		Limits: domain.LimitCounters{
+			RouterRefused:    c.limits.routerRefused.Load(),
+			SourceRefused:    c.limits.sourceRefused.Load(),
+			RateRefused:      c.limits.rateRefused.Load(),
+			BandwidthDropped: c.limits.bandwidthDropped.Load(),
+		},
 	}
 }
 
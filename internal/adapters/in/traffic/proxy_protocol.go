@@ -0,0 +1,246 @@
+package traffic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	proxyV1Prefix         = "PROXY "
+	maxProxyV1HeaderBytes = 107
+	proxyV2HeaderBytes    = 16
+	maxProxyV2HeaderBytes = 4096
+
+	proxyV2CommandLocal = 0x20
+	proxyV2CommandProxy = 0x21
+	proxyV2FamilyTCP4   = 0x11
+	proxyV2FamilyTCP6   = 0x21
+)
+
+var errMalformedPROXYHeader = errors.New("malformed PROXY header")
+
+// proxyHeader is the connection endpoint announced by a PROXY header. A nil
+// source means the header carried no usable address (v1 UNKNOWN, v2 LOCAL
+// or a non-TCP family) and the peer socket address is kept.
+type proxyHeader struct {
+	source      *net.TCPAddr
+	destination *net.TCPAddr
+}
+
+// proxyProtocolConn reports the client and destination announced by a
+// PROXY header instead of the peer socket addresses.
+type proxyProtocolConn struct {
+	net.Conn
+	remote net.Addr
+	local  net.Addr
+}
+
+func (c proxyProtocolConn) RemoteAddr() net.Addr { return c.remote }
+
+func (c proxyProtocolConn) LocalAddr() net.Addr { return c.local }
+
+// readPROXYHeader consumes an optional PROXY v1/v2 header from conn. The
+// returned conn replays any bytes read past the header and, when the header
+// announced a TCP client, reports it as RemoteAddr. found is false when the
+// stream does not start with a PROXY signature.
+func readPROXYHeader(conn net.Conn, timeout time.Duration) (net.Conn, bool, error) {
+	if timeout > 0 {
+		if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+			return nil, false, fmt.Errorf("set PROXY header read deadline: %w", err)
+		}
+	}
+
+	buf := make([]byte, 0, 128)
+	for len(buf) < maxProxyV2HeaderBytes {
+		tmp := make([]byte, min(128, maxProxyV2HeaderBytes-len(buf)))
+		n, err := conn.Read(tmp)
+		if n > 0 {
+			buf = append(buf, tmp[:n]...)
+			header, consumed, found, complete, parseErr := parsePROXYHeader(buf)
+			if parseErr != nil {
+				return nil, true, parseErr
+			}
+			if complete {
+				if err := conn.SetReadDeadline(time.Time{}); err != nil {
+					return nil, found, fmt.Errorf("clear PROXY header read deadline: %w", err)
+				}
+				return header.wrap(conn, buf[consumed:]), found, nil
+			}
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("read PROXY header: %w", err)
+		}
+	}
+	return nil, true, fmt.Errorf("%w: larger than %d bytes", errMalformedPROXYHeader, maxProxyV2HeaderBytes)
+}
+
+func (h proxyHeader) wrap(conn net.Conn, rest []byte) net.Conn {
+	if h.source != nil {
+		conn = proxyProtocolConn{Conn: conn, remote: h.source, local: h.destination}
+	}
+	return replayConn{Conn: conn, reader: bytes.NewReader(rest)}
+}
+
+// parsePROXYHeader parses a PROXY header at the start of buf. complete is
+// false while more bytes are needed to decide.
+func parsePROXYHeader(buf []byte) (header proxyHeader, consumed int, found bool, complete bool, err error) {
+	switch {
+	case bytes.HasPrefix(buf, []byte(proxyV2Signature)):
+		header, consumed, complete, err = parsePROXYv2Header(buf)
+		return header, consumed, true, complete, err
+	case bytes.HasPrefix(buf, []byte(proxyV1Prefix)):
+		header, consumed, complete, err = parsePROXYv1Header(buf)
+		return header, consumed, true, complete, err
+	case hasAnyPrefix([]byte(proxyV2Signature), buf), hasAnyPrefix([]byte(proxyV1Prefix), buf):
+		return proxyHeader{}, 0, false, false, nil
+	default:
+		return proxyHeader{}, 0, false, true, nil
+	}
+}
+
+func parsePROXYv1Header(buf []byte) (proxyHeader, int, bool, error) {
+	end := bytes.Index(buf, []byte("\r\n"))
+	if end < 0 {
+		if len(buf) >= maxProxyV1HeaderBytes {
+			return proxyHeader{}, 0, false, fmt.Errorf("%w: v1 header exceeds %d bytes", errMalformedPROXYHeader, maxProxyV1HeaderBytes)
+		}
+		return proxyHeader{}, 0, false, nil
+	}
+	consumed := end + 2
+	if consumed > maxProxyV1HeaderBytes {
+		return proxyHeader{}, 0, false, fmt.Errorf("%w: v1 header exceeds %d bytes", errMalformedPROXYHeader, maxProxyV1HeaderBytes)
+	}
+
+	fields := strings.Split(string(buf[len(proxyV1Prefix):end]), " ")
+	if fields[0] == "UNKNOWN" {
+		return proxyHeader{}, consumed, true, nil
+	}
+	if len(fields) != 5 || (fields[0] != "TCP4" && fields[0] != "TCP6") {
+		return proxyHeader{}, 0, false, fmt.Errorf("%w: invalid v1 header %q", errMalformedPROXYHeader, buf[:end])
+	}
+	source, err := parsePROXYv1Address(fields[0], fields[1], fields[3])
+	if err != nil {
+		return proxyHeader{}, 0, false, err
+	}
+	destination, err := parsePROXYv1Address(fields[0], fields[2], fields[4])
+	if err != nil {
+		return proxyHeader{}, 0, false, err
+	}
+	return proxyHeader{source: source, destination: destination}, consumed, true, nil
+}
+
+func parsePROXYv1Address(family, host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil || (family == "TCP4") != (ip.To4() != nil) {
+		return nil, fmt.Errorf("%w: invalid %s address %q", errMalformedPROXYHeader, family, host)
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 0 || portNumber > 65535 || (len(port) > 1 && port[0] == '0') {
+		return nil, fmt.Errorf("%w: invalid port %q", errMalformedPROXYHeader, port)
+	}
+	return &net.TCPAddr{IP: ip, Port: portNumber}, nil
+}
+
+func parsePROXYv2Header(buf []byte) (proxyHeader, int, bool, error) {
+	if len(buf) < proxyV2HeaderBytes {
+		return proxyHeader{}, 0, false, nil
+	}
+	command := buf[12]
+	family := buf[13]
+	consumed := proxyV2HeaderBytes + int(binary.BigEndian.Uint16(buf[14:16]))
+	if command != proxyV2CommandLocal && command != proxyV2CommandProxy {
+		return proxyHeader{}, 0, false, fmt.Errorf("%w: unsupported v2 version/command 0x%02x", errMalformedPROXYHeader, command)
+	}
+	if consumed > maxProxyV2HeaderBytes {
+		return proxyHeader{}, 0, false, fmt.Errorf("%w: v2 header exceeds %d bytes", errMalformedPROXYHeader, maxProxyV2HeaderBytes)
+	}
+	if len(buf) < consumed {
+		return proxyHeader{}, 0, false, nil
+	}
+	if command == proxyV2CommandLocal {
+		return proxyHeader{}, consumed, true, nil
+	}
+
+	addresses := buf[proxyV2HeaderBytes:consumed]
+	var ipLen int
+	switch family {
+	case proxyV2FamilyTCP4:
+		ipLen = net.IPv4len
+	case proxyV2FamilyTCP6:
+		ipLen = net.IPv6len
+	default:
+		// UDP and UNIX sockets have no meaning for a TCP entrypoint; keep
+		// the peer address as the spec asks receivers to do.
+		return proxyHeader{}, consumed, true, nil
+	}
+	if len(addresses) < 2*ipLen+4 {
+		return proxyHeader{}, 0, false, fmt.Errorf("%w: v2 address block too short", errMalformedPROXYHeader)
+	}
+	source := &net.TCPAddr{
+		IP:   net.IP(bytes.Clone(addresses[:ipLen])),
+		Port: int(binary.BigEndian.Uint16(addresses[2*ipLen:])),
+	}
+	destination := &net.TCPAddr{
+		IP:   net.IP(bytes.Clone(addresses[ipLen : 2*ipLen])),
+		Port: int(binary.BigEndian.Uint16(addresses[2*ipLen+2:])),
+	}
+	return proxyHeader{source: source, destination: destination}, consumed, true, nil
+}
+
+// writePROXYHeader sends a PROXY header describing client to a backend.
+func writePROXYHeader(backend net.Conn, version int, client net.Conn) error {
+	header := buildPROXYHeader(version, client.RemoteAddr(), client.LocalAddr())
+	if _, err := backend.Write(header); err != nil {
+		return fmt.Errorf("write PROXY v%d header: %w", version, err)
+	}
+	return nil
+}
+
+func buildPROXYHeader(version int, source, destination net.Addr) []byte {
+	src, srcOK := source.(*net.TCPAddr)
+	dst, dstOK := destination.(*net.TCPAddr)
+	ok := srcOK && dstOK && (src.IP.To4() != nil) == (dst.IP.To4() != nil)
+	if version == domain.ProxyProtocolV1 {
+		return buildPROXYv1Header(src, dst, ok)
+	}
+	return buildPROXYv2Header(src, dst, ok)
+}
+
+func buildPROXYv1Header(src, dst *net.TCPAddr, ok bool) []byte {
+	if !ok {
+		return []byte(proxyV1Prefix + "UNKNOWN\r\n")
+	}
+	family := "TCP6"
+	if src.IP.To4() != nil {
+		family = "TCP4"
+	}
+	return fmt.Appendf(nil, "%s%s %s %s %d %d\r\n", proxyV1Prefix, family, src.IP, dst.IP, src.Port, dst.Port)
+}
+
+func buildPROXYv2Header(src, dst *net.TCPAddr, ok bool) []byte {
+	header := append([]byte(proxyV2Signature), proxyV2CommandProxy, 0, 0, 0)
+	if !ok {
+		header[12] = proxyV2CommandLocal
+		return header
+	}
+	srcIP, dstIP := src.IP.To4(), dst.IP.To4()
+	header[13] = proxyV2FamilyTCP4
+	if srcIP == nil {
+		srcIP, dstIP = src.IP.To16(), dst.IP.To16()
+		header[13] = proxyV2FamilyTCP6
+	}
+	header = append(header, srcIP...)
+	header = append(header, dstIP...)
+	header = binary.BigEndian.AppendUint16(header, uint16(src.Port))
+	header = binary.BigEndian.AppendUint16(header, uint16(dst.Port))
+	binary.BigEndian.PutUint16(header[14:16], uint16(len(header)-proxyV2HeaderBytes))
+	return header
+}
@@ -0,0 +1,177 @@
+package traffic
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestReadPROXYHeader(t *testing.T) {
+	source := &net.TCPAddr{IP: net.ParseIP("198.51.100.7"), Port: 40000}
+	destination := &net.TCPAddr{IP: net.ParseIP("203.0.113.1"), Port: 443}
+	source6 := &net.TCPAddr{IP: net.ParseIP("2001:db8::7"), Port: 40000}
+	destination6 := &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443}
+
+	tests := []struct {
+		name       string
+		header     []byte
+		wantRemote string
+	}{
+		{name: "v1 tcp4", header: buildPROXYHeader(domain.ProxyProtocolV1, source, destination), wantRemote: "198.51.100.7:40000"},
+		{name: "v1 tcp6", header: buildPROXYHeader(domain.ProxyProtocolV1, source6, destination6), wantRemote: "[2001:db8::7]:40000"},
+		{name: "v1 unknown keeps peer", header: []byte("PROXY UNKNOWN\r\n"), wantRemote: "pipe"},
+		{name: "v2 tcp4", header: buildPROXYHeader(domain.ProxyProtocolV2, source, destination), wantRemote: "198.51.100.7:40000"},
+		{name: "v2 tcp6", header: buildPROXYHeader(domain.ProxyProtocolV2, source6, destination6), wantRemote: "[2001:db8::7]:40000"},
+		{name: "v2 local keeps peer", header: buildPROXYHeader(domain.ProxyProtocolV2, nil, nil), wantRemote: "pipe"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, found, err := readPROXYHeader(pipeConnWithData(t, append(tt.header, "payload"...)), time.Second)
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, tt.wantRemote, conn.RemoteAddr().String())
+			assertReplayPrefix(t, conn, []byte("payload"))
+		})
+	}
+}
+
+func TestReadPROXYHeaderWithoutHeaderReplaysBytes(t *testing.T) {
+	conn, found, err := readPROXYHeader(pipeConnWithData(t, []byte("POST / HTTP/1.1\r\n")), time.Second)
+	require.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, "pipe", conn.RemoteAddr().String())
+	assertReplayPrefix(t, conn, []byte("POST / HTTP/1.1\r\n"))
+}
+
+func TestReadPROXYHeaderRejectsMalformedHeaders(t *testing.T) {
+	tests := map[string][]byte{
+		"v1 bad family":   []byte("PROXY UDP4 192.0.2.1 192.0.2.2 1 2\r\n"),
+		"v1 family mixup": []byte("PROXY TCP4 2001:db8::1 192.0.2.2 1 2\r\n"),
+		"v1 bad port":     []byte("PROXY TCP4 192.0.2.1 192.0.2.2 70000 2\r\n"),
+		"v1 missing port": []byte("PROXY TCP4 192.0.2.1 192.0.2.2 1\r\n"),
+		"v1 too long":     append([]byte("PROXY TCP4 "), make([]byte, maxProxyV1HeaderBytes)...),
+		"v2 bad command":  append([]byte(proxyV2Signature), 0x22, proxyV2FamilyTCP4, 0, 12),
+		"v2 short block":  append([]byte(proxyV2Signature), proxyV2CommandProxy, proxyV2FamilyTCP4, 0, 4, 1, 2, 3, 4),
+	}
+	for name, header := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, found, err := readPROXYHeader(pipeConnWithData(t, header), time.Second)
+			require.ErrorIs(t, err, errMalformedPROXYHeader)
+			assert.True(t, found)
+		})
+	}
+}
+
+func TestTCPProxyProtocolUsesAnnouncedClientForTrustedCIDRs(t *testing.T) {
+	backend := startTCPEchoServer(t, 0)
+	graph := tcpGraph(t, freeTCPAddress(t), backend.address)
+	graph.EntryPoints[0].TrustedCIDRs = []string{"198.51.100.0/24"}
+	graph.EntryPoints[0].ProxyProtocol = true
+	graph.EntryPoints[0].ProxyProtocolTrustedCIDRs = []string{"127.0.0.0/8"}
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	conn := dialTCP(t, graph.EntryPoints[0].Address)
+	defer conn.Close()
+	_, err := conn.Write([]byte("PROXY TCP4 198.51.100.7 127.0.0.1 40000 5432\r\n"))
+	require.NoError(t, err)
+	assertRoundTrip(t, conn, "via proxy")
+
+	blocked := dialTCP(t, graph.EntryPoints[0].Address)
+	defer blocked.Close()
+	_, err = blocked.Write([]byte("PROXY TCP4 192.0.2.7 127.0.0.1 40000 5432\r\n"))
+	require.NoError(t, err)
+	_, err = bufio.NewReader(blocked).ReadByte()
+	require.Error(t, err)
+
+	assert.Eventually(t, func() bool {
+		status := manager.Status().Counters
+		return status.ProxyProtocol.Accepted == 2 && status.TotalRefused == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTCPProxyProtocolRejectsMalformedHeaderFromTrustedPeer(t *testing.T) {
+	backend := startTCPEchoServer(t, 0)
+	graph := tcpGraph(t, freeTCPAddress(t), backend.address)
+	graph.EntryPoints[0].ProxyProtocol = true
+	graph.EntryPoints[0].ProxyProtocolTrustedCIDRs = []string{"127.0.0.0/8"}
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	assertSmartTCPWriteRejected(t, graph.EntryPoints[0].Address, []byte("PROXY TCP4 not-an-ip 127.0.0.1 1 2\r\n"))
+	assert.Eventually(t, func() bool {
+		return manager.Status().Counters.ProxyProtocol.Rejected == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTCPRouterSendsProxyProtocolToBackend(t *testing.T) {
+	headers := make(chan string, 1)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		client, _, err := readPROXYHeader(conn, time.Second)
+		if err != nil {
+			headers <- err.Error()
+			return
+		}
+		headers <- client.RemoteAddr().String()
+		_, _ = io.Copy(client, client)
+	}()
+
+	graph := tcpGraph(t, freeTCPAddress(t), listener.Addr().String())
+	graph.EntryPoints[0].ProxyProtocol = true
+	graph.EntryPoints[0].ProxyProtocolTrustedCIDRs = []string{"127.0.0.0/8"}
+	graph.Routers[0].ProxyProtocol = domain.ProxyProtocolV2
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	conn := dialTCP(t, graph.EntryPoints[0].Address)
+	defer conn.Close()
+	_, err = conn.Write([]byte("PROXY TCP4 198.51.100.7 127.0.0.1 40000 5432\r\n"))
+	require.NoError(t, err)
+	assertRoundTrip(t, conn, "to backend")
+	assert.Equal(t, "198.51.100.7:40000", <-headers)
+}
+
+func TestSmartTCPProxyProtocolSetsHTTPRemoteAddr(t *testing.T) {
+	seen := make(chan string, 1)
+	manager := NewManager()
+	graph := smartTCPGraph(t, freeTCPAddress(t), nil, nil)
+	graph.EntryPoints[0].ProxyProtocol = true
+	graph.EntryPoints[0].ProxyProtocolTrustedCIDRs = []string{"127.0.0.0/8"}
+	manager.SetSmartTCPHTTPServer("edge", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen <- r.RemoteAddr
+		_, _ = w.Write([]byte("ok"))
+	}), nil)
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	conn := dialTCP(t, graph.EntryPoints[0].Address)
+	defer conn.Close()
+	header := buildPROXYHeader(domain.ProxyProtocolV2, &net.TCPAddr{IP: net.ParseIP("2001:db8::7"), Port: 40000}, &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 443})
+	_, err := conn.Write(append(header, "GET / HTTP/1.1\r\nHost: app.example.com\r\nConnection: close\r\n\r\n"...))
+	require.NoError(t, err)
+	resp, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	require.NoError(t, err)
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, "[2001:db8::7]:40000", <-seen)
+}
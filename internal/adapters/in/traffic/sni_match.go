@@ -0,0 +1,120 @@
+package traffic
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// sniMatchers maps entrypoint names to their tls_passthrough SNI matchers.
+type sniMatchers map[string]*sniMatcher
+
+// sniMatcher resolves a ClientHello SNI to a tls_passthrough router: exact
+// rules first, then the longest matching wildcard, then regexes in router
+// name order.
+type sniMatcher struct {
+	exact     map[string]*sniRoute
+	wildcards []*sniRoute
+	regexes   []*sniRoute
+}
+
+type sniRoute struct {
+	router  domain.TrafficRouter
+	sni     string
+	pattern *regexp.Regexp
+	matches *atomic.Int64
+}
+
+// buildSNIMatchers compiles tls_passthrough rules per entrypoint. Match
+// counters carry over for routers whose name, entrypoint and rule are unchanged.
+func buildSNIMatchers(graph *domain.TrafficGraph, current sniMatchers) sniMatchers {
+	previous := map[string]*sniRoute{}
+	for _, matcher := range current {
+		for _, route := range matcher.routes() {
+			previous[route.router.Name] = route
+		}
+	}
+	next := sniMatchers{}
+	for _, router := range graph.Routers {
+		if router.Protocol != domain.RouterProtocolTLSPassthrough {
+			continue
+		}
+		matcher := next[router.EntryPoint]
+		if matcher == nil {
+			matcher = &sniMatcher{exact: map[string]*sniRoute{}}
+			next[router.EntryPoint] = matcher
+		}
+		route := &sniRoute{router: router, matches: &atomic.Int64{}}
+		if old := previous[router.Name]; old != nil && old.router.EntryPoint == router.EntryPoint && old.router.Rule == router.Rule {
+			route.matches = old.matches
+		}
+		matcher.add(route)
+	}
+	for _, matcher := range next {
+		sort.SliceStable(matcher.wildcards, func(i, j int) bool {
+			return len(matcher.wildcards[i].sni) > len(matcher.wildcards[j].sni)
+		})
+		sort.Slice(matcher.regexes, func(i, j int) bool { return matcher.regexes[i].router.Name < matcher.regexes[j].router.Name })
+	}
+	return next
+}
+
+func (m *sniMatcher) add(route *sniRoute) {
+	rule := route.router.Rule
+	if rule.SNIRegex != "" {
+		pattern, err := domain.CompileSNIRegex(rule.SNIRegex)
+		if err != nil {
+			// Graph validation compiles the same pattern; skip rather than panic.
+			return
+		}
+		route.pattern = pattern
+		m.regexes = append(m.regexes, route)
+		return
+	}
+	route.sni = normalizeTLSName(rule.SNI)
+	if strings.HasPrefix(route.sni, "*.") {
+		m.wildcards = append(m.wildcards, route)
+		return
+	}
+	m.exact[route.sni] = route
+}
+
+func (m *sniMatcher) match(sni string) (*sniRoute, bool) {
+	if route := m.exact[sni]; route != nil {
+		return route, true
+	}
+	for _, route := range m.wildcards {
+		if domain.HostMatchesSNIWildcard(sni, route.sni) {
+			return route, true
+		}
+	}
+	for _, route := range m.regexes {
+		if route.pattern.MatchString(sni) {
+			return route, true
+		}
+	}
+	return nil, false
+}
+
+func (m *sniMatcher) routes() []*sniRoute {
+	routes := make([]*sniRoute, 0, len(m.exact)+len(m.wildcards)+len(m.regexes))
+	for _, route := range m.exact {
+		routes = append(routes, route)
+	}
+	routes = append(routes, m.wildcards...)
+	return append(routes, m.regexes...)
+}
+
+// matchCounts returns the match counter of every tls_passthrough router.
+func (m sniMatchers) matchCounts() map[string]int64 {
+	counts := map[string]int64{}
+	for _, matcher := range m {
+		for _, route := range matcher.routes() {
+			counts[route.router.Name] = route.matches.Load()
+		}
+	}
+	return counts
+}
@@ -43,9 +43,9 @@ func TestSniffSmartTCPClassifiesTLSAndAllowsClientHelloParsing(t *testing.T) {
 	result := sniffBytes(t, hello, time.Second)
 	require.Equal(t, dispatchTLS, result.kind)
 
-	sni, replayed, err := peekClientHelloSNI(result.conn)
+	peeked, replayed, err := peekClientHello(result.conn)
 	require.NoError(t, err)
-	assert.Equal(t, "sniff.example.com", sni)
+	assert.Equal(t, "sniff.example.com", peeked.sni)
 	assertReplayPrefix(t, replayed, hello)
 }
 
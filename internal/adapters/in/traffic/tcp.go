@@ -8,7 +8,6 @@ import (
 	"net"
 	"net/http"
 	"strconv"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -51,6 +50,7 @@ type entryPointRuntime struct {
 	smartTLSDone      chan struct{}
 
 	rawFallbackTrusted []*net.IPNet
+	proxyTrusted       []*net.IPNet
 }
 
 type trackedTCPConn struct {
@@ -63,6 +63,18 @@ type trackedTCPConn struct {
 	rawFallbackName string
 }
 
+func (c *trackedTCPConn) setClient(client net.Conn) {
+	c.mu.Lock()
+	c.client = client
+	c.mu.Unlock()
+}
+
+func (c *trackedTCPConn) clientConn() net.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client
+}
+
 func (c *trackedTCPConn) setBackend(backend net.Conn) {
 	c.mu.Lock()
 	if c.closing {
@@ -106,7 +118,7 @@ func (c *trackedTCPConn) rawFallback() (string, bool) {
 	return c.rawFallbackName, c.rawFallbackName != ""
 }
 
-func newEntryPointRuntime(parentCtx context.Context, manager *Manager, entryPoint domain.EntryPoint, listener net.Listener, trusted []*net.IPNet, rawTrusted []*net.IPNet) *entryPointRuntime {
+func newEntryPointRuntime(parentCtx context.Context, manager *Manager, entryPoint domain.EntryPoint, listener net.Listener, trusted []*net.IPNet, rawTrusted []*net.IPNet, proxyTrusted []*net.IPNet) *entryPointRuntime {
 	ctx, cancel := context.WithCancel(context.WithoutCancel(parentCtx))
 	return &entryPointRuntime{
 		manager:            manager,
@@ -114,6 +126,7 @@ func newEntryPointRuntime(parentCtx context.Context, manager *Manager, entryPoin
 		listener:           listener,
 		trusted:            trusted,
 		rawFallbackTrusted: rawTrusted,
+		proxyTrusted:       proxyTrusted,
 		ctx:                ctx,
 		cancel:             cancel,
 		acceptDone:         make(chan struct{}),
@@ -170,7 +183,7 @@ func (r *entryPointRuntime) replaceTLSHTTPServer(entryPoint domain.EntryPoint, c
 	}
 	listener := newTLSHTTPListener(r.listener.Addr())
 	server := &http.Server{
-		Handler:           config.Handler,
+		Handler:           r.altSvcHandler(config.Handler),
 		TLSConfig:         config.TLSConfig.Clone(),
 		ReadHeaderTimeout: 5 * time.Second,
 		ReadTimeout:       10 * time.Second,
@@ -263,20 +276,60 @@ func (r *entryPointRuntime) acceptLoop() {
 			}
 			continue
 		}
-		if !trustedRemoteAddr(r.trustedSnapshot(), conn.RemoteAddr()) {
-			r.counters.totalRefused.Add(1)
-			if r.entryPointSnapshot().Protocol == domain.EntryPointProtocolSmartTCP {
-				r.counters.smartTCP.entrypointCIDRRefused.Add(1)
-			}
-			_ = conn.Close()
+		// Peers allowed to send a PROXY header are checked against
+		// trusted_cidrs once the header has announced the real client.
+		proxied := r.acceptsPROXYFrom(conn.RemoteAddr())
+		if !proxied && !trustedRemoteAddr(r.trustedSnapshot(), conn.RemoteAddr()) {
+			r.refuseUntrusted(conn)
 			continue
 		}
 		r.activeWG.Add(1)
-		go r.handleTCPConn(conn)
+		go r.handleTCPConn(conn, proxied)
+	}
+}
+
+func (r *entryPointRuntime) refuseUntrusted(conn net.Conn) {
+	r.counters.totalRefused.Add(1)
+	if r.entryPointSnapshot().Protocol == domain.EntryPointProtocolSmartTCP {
+		r.counters.smartTCP.entrypointCIDRRefused.Add(1)
+	}
+	_ = conn.Close()
+}
+
+func (r *entryPointRuntime) acceptsPROXYFrom(addr net.Addr) bool {
+	if !r.entryPointSnapshot().ProxyProtocol {
+		return false
+	}
+	trusted := r.proxyTrustedSnapshot()
+	return len(trusted) > 0 && trustedRemoteAddr(trusted, addr)
+}
+
+// acceptPROXYHeader replaces the tracked client with one reporting the
+// address announced by its PROXY header, then applies trusted_cidrs to it.
+func (r *entryPointRuntime) acceptPROXYHeader(tracked *trackedTCPConn, options domain.TCPOptions) bool {
+	client, found, err := readPROXYHeader(tracked.client, clientHelloTimeout(options))
+	if err != nil {
+		if found {
+			r.counters.totalRefused.Add(1)
+			r.counters.proxyProtocol.rejected.Add(1)
+		} else {
+			r.counters.totalErrors.Add(1)
+		}
+		_ = tracked.client.Close()
+		return false
+	}
+	if found {
+		r.counters.proxyProtocol.accepted.Add(1)
+	}
+	tracked.setClient(client)
+	if !trustedRemoteAddr(r.trustedSnapshot(), client.RemoteAddr()) {
+		r.refuseUntrusted(client)
+		return false
 	}
+	return true
 }
 
-func (r *entryPointRuntime) handleTCPConn(client net.Conn) {
+func (r *entryPointRuntime) handleTCPConn(client net.Conn, proxied bool) {
 	options := effectiveTCPOptions(snapshotTCPOptions(r.manager.snapshot.Load()))
 	if !r.reserveConnection(options.MaxConnections) {
 		r.counters.totalRefused.Add(1)
@@ -303,6 +356,9 @@ func (r *entryPointRuntime) handleTCPConn(client net.Conn) {
 			tracked.complete()
 		}
 	}()
+	if proxied && !r.acceptPROXYHeader(tracked, options) {
+		return
+	}
 
 	switch r.entryPointSnapshot().Protocol {
 	case domain.EntryPointProtocolTCP:
@@ -322,13 +378,13 @@ func (r *entryPointRuntime) handleTCPConn(client net.Conn) {
 }
 
 func (r *entryPointRuntime) handlePlainTCP(tracked *trackedTCPConn, options domain.TCPOptions) {
-	_, backend, ok := r.resolveTCPBackend()
+	router, balancer, ok := r.resolveTCPBackend()
 	if !ok {
 		r.counters.totalRefused.Add(1)
 		_ = tracked.client.Close()
 		return
 	}
-	r.proxyToBackend(tracked, tracked.client, backend, options)
+	r.proxyToBackend(tracked, tracked.client, router, balancer, options)
 }
 
 func (r *entryPointRuntime) handleSmartTCP(tracked *trackedTCPConn, options domain.TCPOptions) bool {
@@ -376,8 +432,11 @@ func (r *entryPointRuntime) handleSmartTCPTLS(tracked *trackedTCPConn, conn net.
 	if err != nil {
 		return r.handleSmartTCPTLSPeekError(conn, err)
 	}
-	if backend, ok := r.resolveTLSBackend(peeked.sni); ok {
-		r.proxyToBackendAfterDial(tracked, peeked.conn, backend, options, func() {
+	if r.handleTLSALPNChallenge(peeked, options) {
+		return false
+	}
+	if router, balancer, ok := r.resolveTLSBackend(peeked.sni); ok {
+		r.proxyToBackendAfterDial(tracked, peeked.conn, router, balancer, options, func() {
 			r.counters.smartTCP.tlsPassthroughAccepted.Add(1)
 		})
 		return false
@@ -402,10 +461,10 @@ func (r *entryPointRuntime) handleSmartTCPTLSPeekError(conn net.Conn, err error)
 }
 
 func (r *entryPointRuntime) handleSmartTCPUnknown(tracked *trackedTCPConn, conn net.Conn, options domain.TCPOptions) bool {
-	backend, rawFallbackName, ok, rawCIDRRefused := r.resolveRawFallbackBackendDetailed(conn.RemoteAddr())
+	router, balancer, ok, rawCIDRRefused := r.resolveRawFallbackBackendDetailed(conn.RemoteAddr())
 	if ok {
-		tracked.markRawFallback(rawFallbackName)
-		r.proxyToBackendAfterDial(tracked, conn, backend, options, func() {
+		tracked.markRawFallback(router.Name)
+		r.proxyToBackendAfterDial(tracked, conn, router, balancer, options, func() {
 			r.counters.smartTCP.rawFallbackAccepted.Add(1)
 		})
 		return false
@@ -432,8 +491,11 @@ func (r *entryPointRuntime) handleTLSMux(tracked *trackedTCPConn, options domain
 		_ = tracked.client.Close()
 		return false
 	}
-	if backend, ok := r.resolveTLSBackend(peeked.sni); ok {
-		r.proxyToBackend(tracked, peeked.conn, backend, options)
+	if r.handleTLSALPNChallenge(peeked, options) {
+		return false
+	}
+	if router, balancer, ok := r.resolveTLSBackend(peeked.sni); ok {
+		r.proxyToBackend(tracked, peeked.conn, router, balancer, options)
 		return false
 	}
 	if r.routeToHTTPS(tracked, peeked.conn) {
@@ -449,14 +511,14 @@ func (r *entryPointRuntime) peekTLSClientHello(client net.Conn, options domain.T
 	if err := client.SetReadDeadline(time.Now().Add(clientHelloTimeout(options))); err != nil {
 		return peekedTLSConn{}, fmt.Errorf("set client hello read deadline for %s: %w", client.RemoteAddr(), err)
 	}
-	sni, replayed, err := peekClientHelloSNI(client)
+	hello, replayed, err := peekClientHello(client)
 	if clearErr := client.SetReadDeadline(time.Time{}); err == nil && clearErr != nil {
 		return peekedTLSConn{}, fmt.Errorf("clear client hello read deadline for %s: %w", client.RemoteAddr(), clearErr)
 	}
 	if err != nil {
 		return peekedTLSConn{}, fmt.Errorf("peek client hello from %s: %w", client.RemoteAddr(), err)
 	}
-	return peekedTLSConn{sni: sni, conn: replayed}, nil
+	return peekedTLSConn{sni: hello.sni, alpn: hello.alpn, conn: replayed}, nil
 }
 
 func isClientHelloTooLargeError(err error) bool {
@@ -473,142 +535,117 @@ func clientHelloTimeout(options domain.TCPOptions) time.Duration {
 
 type peekedTLSConn struct {
 	sni  string
+	alpn []string
 	conn net.Conn
 }
 
-func (r *entryPointRuntime) proxyToBackend(tracked *trackedTCPConn, client net.Conn, backend domain.TrafficBackend, options domain.TCPOptions) bool {
-	return r.proxyToBackendAfterDial(tracked, client, backend, options, nil)
+func (r *entryPointRuntime) proxyToBackend(tracked *trackedTCPConn, client net.Conn, router domain.TrafficRouter, balancer *serviceBalancer, options domain.TCPOptions) bool {
+	return r.proxyToBackendAfterDial(tracked, client, router, balancer, options, nil)
 }
 
-func (r *entryPointRuntime) proxyToBackendAfterDial(tracked *trackedTCPConn, client net.Conn, backend domain.TrafficBackend, options domain.TCPOptions, afterDial func()) bool {
-	dialCtx, cancel := context.WithTimeout(r.ctx, options.DialTimeout)
-	defer cancel()
-	backendConn, err := (&net.Dialer{}).DialContext(dialCtx, "tcp", net.JoinHostPort(backend.Host, strconv.Itoa(backend.Port)))
+func (r *entryPointRuntime) proxyToBackendAfterDial(tracked *trackedTCPConn, client net.Conn, router domain.TrafficRouter, balancer *serviceBalancer, options domain.TCPOptions, afterDial func()) bool {
+	limiter := r.manager.routerLimiter(router.Name)
+	if refusal := limiter.acquire(client.RemoteAddr()); refusal != limitAllowed {
+		r.counters.refuseLimit(refusal)
+		_ = client.Close()
+		return false
+	}
+	defer limiter.release(client.RemoteAddr())
+
+	backendConn, state, err := balancer.dialTCP(r.ctx, client.RemoteAddr(), options.DialTimeout)
 	if err != nil {
 		r.counters.totalErrors.Add(1)
 		_ = client.Close()
 		return false
 	}
+	defer state.active.Add(-1)
+
+	if router.ProxyProtocol != 0 {
+		if err := writePROXYHeader(backendConn, router.ProxyProtocol, client); err != nil {
+			r.counters.totalErrors.Add(1)
+			_ = backendConn.Close()
+			_ = client.Close()
+			return false
+		}
+	}
 
 	r.setBackend(tracked, backendConn)
 	r.counters.totalAccepted.Add(1)
 	if afterDial != nil {
 		afterDial()
 	}
-	r.proxyTCP(client, backendConn, options.IdleTimeout)
+	r.proxyTCP(client, backendConn, options.IdleTimeout, router.Limits.BandwidthBytesPerSecond)
 	return true
 }
 
-func (r *entryPointRuntime) resolveRawFallbackBackendDetailed(remote net.Addr) (domain.TrafficBackend, string, bool, bool) {
+func (r *entryPointRuntime) resolveRawFallbackBackendDetailed(remote net.Addr) (domain.TrafficRouter, *serviceBalancer, bool, bool) {
 	graph := r.manager.snapshot.Load()
 	if graph == nil {
-		return domain.TrafficBackend{}, "", false, false
+		return domain.TrafficRouter{}, nil, false, false
 	}
 	entryPoint := r.entryPointSnapshot()
 	if entryPoint.RawFallback == "" {
-		return domain.TrafficBackend{}, "", false, false
+		return domain.TrafficRouter{}, nil, false, false
 	}
 	if !entryPoint.AllowPublicRawFallback {
 		trusted := r.rawFallbackTrustedSnapshot()
 		if len(trusted) == 0 || !trustedRemoteAddr(trusted, remote) {
-			return domain.TrafficBackend{}, "", false, true
+			return domain.TrafficRouter{}, nil, false, true
 		}
 	}
 	for _, router := range graph.Routers {
 		if router.Name == entryPoint.RawFallback && router.EntryPoint == entryPoint.Name && router.Protocol == domain.RouterProtocolTCP {
-			backend, ok := r.backendForRouter(graph, router)
-			return backend, entryPoint.RawFallback, ok, false
+			balancer, ok := r.manager.balancerFor(router.Service, domain.NetworkProtocolTCP)
+			return router, balancer, ok, false
 		}
 	}
-	return domain.TrafficBackend{}, "", false, false
+	return domain.TrafficRouter{}, nil, false, false
 }
 
-func (r *entryPointRuntime) resolveTCPBackend() (domain.TrafficRouter, domain.TrafficBackend, bool) {
+func (r *entryPointRuntime) resolveTCPBackend() (domain.TrafficRouter, *serviceBalancer, bool) {
 	graph := r.manager.snapshot.Load()
 	if graph == nil {
-		return domain.TrafficRouter{}, domain.TrafficBackend{}, false
+		return domain.TrafficRouter{}, nil, false
 	}
 	entryPoint := r.entryPointSnapshot()
 	var router domain.TrafficRouter
 	for _, candidate := range graph.Routers {
 		if candidate.EntryPoint == entryPoint.Name && candidate.Protocol == domain.RouterProtocolTCP {
 			if router.Name != "" {
-				return domain.TrafficRouter{}, domain.TrafficBackend{}, false
+				return domain.TrafficRouter{}, nil, false
 			}
 			router = candidate
 		}
 	}
 	if router.Name == "" {
-		return domain.TrafficRouter{}, domain.TrafficBackend{}, false
-	}
-	for _, service := range graph.Services {
-		if service.Name != router.Service || len(service.Backends) != 1 {
-			continue
-		}
-		backend := service.Backends[0]
-		if backend.Protocol != domain.NetworkProtocolTCP {
-			return domain.TrafficRouter{}, domain.TrafficBackend{}, false
-		}
-		return router, backend, true
+		return domain.TrafficRouter{}, nil, false
 	}
-	return domain.TrafficRouter{}, domain.TrafficBackend{}, false
+	balancer, ok := r.manager.balancerFor(router.Service, domain.NetworkProtocolTCP)
+	return router, balancer, ok
 }
 
-func (r *entryPointRuntime) resolveTLSBackend(sni string) (domain.TrafficBackend, bool) {
+func (r *entryPointRuntime) resolveTLSBackend(sni string) (domain.TrafficRouter, *serviceBalancer, bool) {
 	graph := r.manager.snapshot.Load()
 	sni = normalizeTLSName(sni)
 	if graph == nil || sni == "" {
-		return domain.TrafficBackend{}, false
-	}
-	if router, ok := r.findExactTLSRouter(graph, sni); ok {
-		return r.backendForRouter(graph, router)
-	}
-	if router, ok := r.findWildcardTLSRouter(graph, sni); ok {
-		return r.backendForRouter(graph, router)
-	}
-	return domain.TrafficBackend{}, false
-}
-
-func (r *entryPointRuntime) findExactTLSRouter(graph *domain.TrafficGraph, sni string) (domain.TrafficRouter, bool) {
-	entryPoint := r.entryPointSnapshot()
-	for _, router := range graph.Routers {
-		if router.EntryPoint == entryPoint.Name && router.Protocol == domain.RouterProtocolTLSPassthrough && normalizeTLSName(router.Rule.SNI) == sni {
-			return router, true
-		}
+		return domain.TrafficRouter{}, nil, false
 	}
-	return domain.TrafficRouter{}, false
-}
-
-func (r *entryPointRuntime) findWildcardTLSRouter(graph *domain.TrafficGraph, sni string) (domain.TrafficRouter, bool) {
-	entryPoint := r.entryPointSnapshot()
-	for _, router := range graph.Routers {
-		wildcard := normalizeTLSName(router.Rule.SNI)
-		if router.EntryPoint == entryPoint.Name && router.Protocol == domain.RouterProtocolTLSPassthrough && hostMatchesTLSWildcard(sni, wildcard) {
-			return router, true
-		}
+	matcher := r.manager.sniMatchers()[r.entryPointSnapshot().Name]
+	if matcher == nil {
+		return domain.TrafficRouter{}, nil, false
 	}
-	return domain.TrafficRouter{}, false
-}
-
-func (r *entryPointRuntime) backendForRouter(graph *domain.TrafficGraph, router domain.TrafficRouter) (domain.TrafficBackend, bool) {
-	for _, service := range graph.Services {
-		if service.Name == router.Service && len(service.Backends) == 1 && service.Backends[0].Protocol == domain.NetworkProtocolTCP {
-			return service.Backends[0], true
-		}
+	route, ok := matcher.match(sni)
+	if !ok {
+		return domain.TrafficRouter{}, nil, false
 	}
-	return domain.TrafficBackend{}, false
-}
-
-func hostMatchesTLSWildcard(host string, wildcard string) bool {
-	suffix, ok := strings.CutPrefix(wildcard, "*.")
-	if !ok || host == suffix {
-		return false
+	balancer, ok := r.manager.balancerFor(route.router.Service, domain.NetworkProtocolTCP)
+	if ok {
+		route.matches.Add(1)
 	}
-	prefix, ok := strings.CutSuffix(host, "."+suffix)
-	return ok && prefix != "" && !strings.Contains(prefix, ".")
+	return route.router, balancer, ok
 }
 
-func (r *entryPointRuntime) proxyTCP(client net.Conn, backend net.Conn, idleTimeout time.Duration) {
+func (r *entryPointRuntime) proxyTCP(client net.Conn, backend net.Conn, idleTimeout time.Duration, bandwidth int64) {
 	defer client.Close()
 	defer backend.Close()
 
@@ -616,7 +653,7 @@ func (r *entryPointRuntime) proxyTCP(client net.Conn, backend net.Conn, idleTime
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		n, err := copyWithIdleTimeout(backend, client, idleTimeout)
+		n, err := r.copyWithLimits(backend, client, idleTimeout, bandwidth)
 		r.counters.bytesIn.Add(n)
 		if err != nil && !isExpectedCopyError(err) {
 			r.counters.totalErrors.Add(1)
@@ -625,7 +662,7 @@ func (r *entryPointRuntime) proxyTCP(client net.Conn, backend net.Conn, idleTime
 	}()
 	go func() {
 		defer wg.Done()
-		n, err := copyWithIdleTimeout(client, backend, idleTimeout)
+		n, err := r.copyWithLimits(client, backend, idleTimeout, bandwidth)
 		r.counters.bytesOut.Add(n)
 		if err != nil && !isExpectedCopyError(err) {
 			r.counters.totalErrors.Add(1)
@@ -635,6 +672,22 @@ func (r *entryPointRuntime) proxyTCP(client net.Conn, backend net.Conn, idleTime
 	wg.Wait()
 }
 
+// copyWithLimits copies src to dst under the idle timeout and, when bandwidth
+// is positive, at most bandwidth bytes per second.
+func (r *entryPointRuntime) copyWithLimits(dst net.Conn, src net.Conn, idleTimeout time.Duration, bandwidth int64) (int64, error) {
+	limiter := newBandwidthLimiter(bandwidth, 0)
+	if limiter == nil {
+		return copyWithIdleTimeout(dst, src, idleTimeout)
+	}
+	var writer io.Writer = dst
+	var reader io.Reader = src
+	if idleTimeout > 0 {
+		writer = deadlineWriter{Conn: dst, idleTimeout: idleTimeout}
+		reader = idleConn{Conn: src, idleTimeout: idleTimeout}
+	}
+	return io.Copy(writer, throttledReader{ctx: r.ctx, reader: reader, limiter: limiter})
+}
+
 func copyWithIdleTimeout(dst net.Conn, src net.Conn, idleTimeout time.Duration) (int64, error) {
 	if idleTimeout <= 0 {
 		return io.Copy(dst, src)
@@ -830,23 +883,25 @@ func (r *entryPointRuntime) closeActiveConns() {
 
 func (r *entryPointRuntime) matches(entryPoint domain.EntryPoint) bool {
 	current := r.entryPointSnapshot()
-	return current.Name == entryPoint.Name && current.Address == entryPoint.Address && current.Protocol == entryPoint.Protocol && trustedCIDRsEqual(current.TrustedCIDRs, entryPoint.TrustedCIDRs) && current.RawFallback == entryPoint.RawFallback && trustedCIDRsEqual(current.RawFallbackTrustedCIDRs, entryPoint.RawFallbackTrustedCIDRs) && current.AllowPublicRawFallback == entryPoint.AllowPublicRawFallback
+	return current.Name == entryPoint.Name && current.Address == entryPoint.Address && current.Protocol == entryPoint.Protocol && trustedCIDRsEqual(current.TrustedCIDRs, entryPoint.TrustedCIDRs) && current.RawFallback == entryPoint.RawFallback && trustedCIDRsEqual(current.RawFallbackTrustedCIDRs, entryPoint.RawFallbackTrustedCIDRs) && current.AllowPublicRawFallback == entryPoint.AllowPublicRawFallback &&
+		current.ProxyProtocol == entryPoint.ProxyProtocol && trustedCIDRsEqual(current.ProxyProtocolTrustedCIDRs, entryPoint.ProxyProtocolTrustedCIDRs)
 }
 
 func (r *entryPointRuntime) sameAddress(entryPoint domain.EntryPoint) bool {
 	return r.entryPointSnapshot().Address == entryPoint.Address
 }
 
-func (r *entryPointRuntime) updateEntryPoint(entryPoint domain.EntryPoint, trusted []*net.IPNet, rawTrusted []*net.IPNet) {
+func (r *entryPointRuntime) updateEntryPoint(entryPoint domain.EntryPoint, trusted []*net.IPNet, rawTrusted []*net.IPNet, proxyTrusted []*net.IPNet) {
 	r.mu.Lock()
 	previousName := r.entryPoint.Name
 	previousProtocol := r.entryPoint.Protocol
 	r.entryPoint = entryPoint
 	r.trusted = trusted
 	r.rawFallbackTrusted = rawTrusted
+	r.proxyTrusted = proxyTrusted
 	stale := make([]*trackedTCPConn, 0)
 	for conn := range r.activeConns {
-		if !trustedRemoteAddr(trusted, conn.client.RemoteAddr()) || rawFallbackConnStale(conn, entryPoint, rawTrusted) {
+		if !trustedRemoteAddr(trusted, conn.clientConn().RemoteAddr()) || rawFallbackConnStale(conn, entryPoint, rawTrusted) {
 			stale = append(stale, conn)
 		}
 	}
@@ -885,7 +940,7 @@ func rawFallbackConnStale(conn *trackedTCPConn, entryPoint domain.EntryPoint, ra
 	if entryPoint.AllowPublicRawFallback {
 		return false
 	}
-	return len(rawTrusted) == 0 || !trustedRemoteAddr(rawTrusted, conn.client.RemoteAddr())
+	return len(rawTrusted) == 0 || !trustedRemoteAddr(rawTrusted, conn.clientConn().RemoteAddr())
 }
 
 func (r *entryPointRuntime) entryPointSnapshot() domain.EntryPoint {
@@ -906,6 +961,12 @@ func (r *entryPointRuntime) rawFallbackTrustedSnapshot() []*net.IPNet {
 	return r.rawFallbackTrusted
 }
 
+func (r *entryPointRuntime) proxyTrustedSnapshot() []*net.IPNet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.proxyTrusted
+}
+
 func (r *entryPointRuntime) isClosed() bool {
 	return r.closed.Load()
 }
@@ -0,0 +1,67 @@
+package traffic
+
+import (
+	"crypto/tls"
+	"slices"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// acmeTLS1Protocol is the ALPN protocol an ACME server offers when it
+// validates a TLS-ALPN-01 challenge (RFC 8737).
+const acmeTLS1Protocol = "acme-tls/1"
+
+// TLSALPNChallengeProvider returns pending TLS-ALPN-01 challenge certificates.
+type TLSALPNChallengeProvider interface {
+	GetTLSALPN01Certificate(serverName string) (*tls.Certificate, bool)
+}
+
+type tlsALPNChallengeSource struct {
+	provider TLSALPNChallengeProvider
+}
+
+// SetTLSALPNChallengeProvider installs or removes the provider answering
+// TLS-ALPN-01 validation handshakes on smart_tcp and tls_mux entrypoints.
+func (m *Manager) SetTLSALPNChallengeProvider(provider TLSALPNChallengeProvider) {
+	m.tlsALPNChallenges.Store(tlsALPNChallengeSource{provider: provider})
+}
+
+func (m *Manager) tlsALPNChallengeCertificate(serverName string) (*tls.Certificate, bool) {
+	source, _ := m.tlsALPNChallenges.Load().(tlsALPNChallengeSource)
+	if source.provider == nil || serverName == "" {
+		return nil, false
+	}
+	return source.provider.GetTLSALPN01Certificate(serverName)
+}
+
+// handleTLSALPNChallenge answers an ACME TLS-ALPN-01 validation handshake
+// with the pending challenge certificate for the SNI. It reports false
+// without touching the connection when the ClientHello is not a validation
+// request for a pending challenge, so regular routing continues.
+func (r *entryPointRuntime) handleTLSALPNChallenge(peeked peekedTLSConn, options domain.TCPOptions) bool {
+	if !slices.Equal(peeked.alpn, []string{acmeTLS1Protocol}) {
+		return false
+	}
+	cert, ok := r.manager.tlsALPNChallengeCertificate(peeked.sni)
+	if !ok {
+		return false
+	}
+
+	conn := tls.Server(peeked.conn, &tls.Config{
+		Certificates: []tls.Certificate{*cert},
+		NextProtos:   []string{acmeTLS1Protocol},
+		MinVersion:   tls.VersionTLS12,
+	})
+	defer func() { _ = conn.Close() }()
+	if err := conn.SetDeadline(time.Now().Add(clientHelloTimeout(options))); err != nil {
+		r.counters.totalErrors.Add(1)
+		return true
+	}
+	if err := conn.Handshake(); err != nil {
+		r.counters.totalErrors.Add(1)
+		return true
+	}
+	r.counters.totalAccepted.Add(1)
+	return true
+}
@@ -0,0 +1,111 @@
+package traffic
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticTLSALPNProvider map[string]tls.Certificate
+
+func (p staticTLSALPNProvider) GetTLSALPN01Certificate(serverName string) (*tls.Certificate, bool) {
+	cert, ok := p[serverName]
+	return &cert, ok
+}
+
+func TestClientHelloPeekALPN(t *testing.T) {
+	client, server := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = tls.Client(client, &tls.Config{ServerName: "app.example.com", NextProtos: []string{acmeTLS1Protocol}, InsecureSkipVerify: true}).Handshake()
+		_ = client.Close()
+	}()
+	peeked, replayed, err := peekClientHello(server)
+	require.NoError(t, err)
+	_ = replayed.Close()
+	<-done
+
+	assert.Equal(t, "app.example.com", peeked.sni)
+	assert.Equal(t, []string{acmeTLS1Protocol}, peeked.alpn)
+}
+
+func TestParseALPNExtensionRejectsMalformedList(t *testing.T) {
+	_, err := parseALPNExtension([]byte{0, 3, 5, 'h', '2'})
+	assert.Error(t, err)
+	_, err = parseALPNExtension([]byte{0, 1, 0})
+	assert.Error(t, err)
+
+	protocols, err := parseALPNExtension([]byte{0, 6, 2, 'h', '2', 2, 'h', '3'})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"h2", "h3"}, protocols)
+}
+
+func TestSmartTCPAnswersTLSALPNChallenge(t *testing.T) {
+	manager, graph, _ := startSmartTCP(t, nil, nil)
+	defer shutdownManager(t, manager)
+	challengeCert, err := testCertificate("acme-challenge")
+	require.NoError(t, err)
+	manager.SetTLSALPNChallengeProvider(staticTLSALPNProvider{"app.example.com": challengeCert})
+
+	assertTLSALPNHandshake(t, graph.EntryPoints[0].Address, "app.example.com", acmeTLS1Protocol, "acme-challenge")
+	// Without a pending challenge the hello reaches the HTTPS server, which
+	// does not speak acme-tls/1.
+	assert.Error(t, dialTLSALPN(graph.EntryPoints[0].Address, "other.example.com"))
+	assertHTTPSBody(t, graph.EntryPoints[0].Address, "app.example.com", "smart https")
+	assert.Eventually(t, func() bool {
+		return manager.Status().Counters.SmartTCP.HTTPSFallbackAccepted == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTLSMuxAnswersTLSALPNChallenge(t *testing.T) {
+	graph := tlsGraph(t, freeTCPAddress(t), nil)
+	manager := NewManager()
+	manager.SetTLSHTTPServer("websecure", http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("https route"))
+	}), testTLSConfig(t, "app.example.com"))
+	challengeCert, err := testCertificate("acme-challenge")
+	require.NoError(t, err)
+	manager.SetTLSALPNChallengeProvider(staticTLSALPNProvider{"app.example.com": challengeCert})
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	assertTLSALPNHandshake(t, graph.EntryPoints[0].Address, "app.example.com", acmeTLS1Protocol, "acme-challenge")
+	assertHTTPSBody(t, graph.EntryPoints[0].Address, "app.example.com", "https route")
+
+	manager.SetTLSALPNChallengeProvider(nil)
+	assert.Error(t, dialTLSALPN(graph.EntryPoints[0].Address, "app.example.com"))
+}
+
+func assertTLSALPNHandshake(t *testing.T, address, serverName, wantProtocol, wantCommonName string) {
+	t.Helper()
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", address, &tls.Config{
+		ServerName:         serverName,
+		NextProtos:         []string{acmeTLS1Protocol},
+		InsecureSkipVerify: true,
+	})
+	require.NoError(t, err)
+	defer conn.Close()
+	state := conn.ConnectionState()
+	assert.Equal(t, wantProtocol, state.NegotiatedProtocol)
+	require.NotEmpty(t, state.PeerCertificates)
+	assert.Equal(t, wantCommonName, state.PeerCertificates[0].Subject.CommonName)
+}
+
+func dialTLSALPN(address, serverName string) error {
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: time.Second}, "tcp", address, &tls.Config{
+		ServerName:         serverName,
+		NextProtos:         []string{acmeTLS1Protocol},
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -65,13 +65,19 @@ func (m *Manager) loadTLSHTTPServers() tlsHTTPServers {
 	return value.(tlsHTTPServers)
 }
 
-func peekClientHelloSNI(conn net.Conn) (string, net.Conn, error) {
-	return peekClientHelloSNIWithLimit(conn, maxClientHelloBytes)
+// clientHello holds the ClientHello fields used for dispatch.
+type clientHello struct {
+	sni  string
+	alpn []string
 }
 
-func peekClientHelloSNIWithLimit(conn net.Conn, maxBytes int) (string, net.Conn, error) {
+func peekClientHello(conn net.Conn) (clientHello, net.Conn, error) {
+	return peekClientHelloWithLimit(conn, maxClientHelloBytes)
+}
+
+func peekClientHelloWithLimit(conn net.Conn, maxBytes int) (clientHello, net.Conn, error) {
 	if err := conn.SetReadDeadline(time.Now().Add(clientHelloReadTimeout)); err != nil {
-		return "", nil, fmt.Errorf("set client hello read deadline: %w", err)
+		return clientHello{}, nil, fmt.Errorf("set client hello read deadline: %w", err)
 	}
 	defer func() { _ = conn.SetReadDeadline(time.Time{}) }()
 
@@ -83,61 +89,61 @@ func peekClientHelloSNIWithLimit(conn net.Conn, maxBytes int) (string, net.Conn,
 		n, err := conn.Read(tmp)
 		if n > 0 {
 			buf = append(buf, tmp[:n]...)
-			sni, complete, parseErr := parseClientHelloSNI(buf)
+			hello, complete, parseErr := parseClientHello(buf)
 			if parseErr != nil {
-				return "", nil, fmt.Errorf("parse client hello sni: %w", parseErr)
+				return clientHello{}, nil, fmt.Errorf("parse client hello: %w", parseErr)
 			}
 			if complete {
-				return sni, replayConn{Conn: conn, reader: bytes.NewReader(buf)}, nil
+				return hello, replayConn{Conn: conn, reader: bytes.NewReader(buf)}, nil
 			}
 		}
 		if err != nil {
-			return "", nil, fmt.Errorf("read client hello: %w", err)
+			return clientHello{}, nil, fmt.Errorf("read client hello: %w", err)
 		}
 	}
-	return "", nil, fmt.Errorf("%w: %d bytes", domain.ErrClientHelloTooLarge, maxBytes)
+	return clientHello{}, nil, fmt.Errorf("%w: %d bytes", domain.ErrClientHelloTooLarge, maxBytes)
 }
 
-func parseClientHelloSNI(data []byte) (string, bool, error) {
+func parseClientHello(data []byte) (clientHello, bool, error) {
 	if len(data) < 5 {
-		return "", false, nil
+		return clientHello{}, false, nil
 	}
 	if data[0] != 22 {
-		return "", false, fmt.Errorf("tls record is not a handshake")
+		return clientHello{}, false, fmt.Errorf("tls record is not a handshake")
 	}
 	record, consumed, complete, err := tlsRecordPayload(data, 0)
 	if err != nil || !complete {
-		return "", complete, err
+		return clientHello{}, complete, err
 	}
 	handshake := append([]byte(nil), record...)
 	for len(handshake) < 4 {
 		if len(data) < consumed+5 {
-			return "", false, nil
+			return clientHello{}, false, nil
 		}
 		next, nextConsumed, nextComplete, err := tlsRecordPayload(data, consumed)
 		if err != nil || !nextComplete {
-			return "", nextComplete, err
+			return clientHello{}, nextComplete, err
 		}
 		handshake = append(handshake, next...)
 		consumed = nextConsumed
 	}
 	if handshake[0] != 1 {
-		return "", true, fmt.Errorf("tls handshake is not client hello")
+		return clientHello{}, true, fmt.Errorf("tls handshake is not client hello")
 	}
 	handshakeLen := int(handshake[1])<<16 | int(handshake[2])<<8 | int(handshake[3])
 	for len(handshake)-4 < handshakeLen {
 		if len(data) < consumed+5 {
-			return "", false, nil
+			return clientHello{}, false, nil
 		}
 		next, nextConsumed, nextComplete, err := tlsRecordPayload(data, consumed)
 		if err != nil || !nextComplete {
-			return "", nextComplete, err
+			return clientHello{}, nextComplete, err
 		}
 		handshake = append(handshake, next...)
 		consumed = nextConsumed
 	}
-	sni, err := parseClientHelloExtensions(handshake[4 : 4+handshakeLen])
-	return sni, true, err
+	hello, err := parseClientHelloExtensions(handshake[4 : 4+handshakeLen])
+	return hello, true, err
 }
 
 func tlsRecordPayload(data []byte, offset int) ([]byte, int, bool, error) {
@@ -155,51 +161,59 @@ func tlsRecordPayload(data []byte, offset int) ([]byte, int, bool, error) {
 	return data[offset+5 : end], end, true, nil
 }
 
-func parseClientHelloExtensions(body []byte) (string, error) {
+func parseClientHelloExtensions(body []byte) (clientHello, error) {
 	reader := bytes.NewReader(body)
 	if reader.Len() < 34 {
-		return "", fmt.Errorf("client hello is truncated")
+		return clientHello{}, fmt.Errorf("client hello is truncated")
 	}
 	_, _ = readBytes(reader, 34) // legacy_version + random
 	if err := skipLengthPrefixed(reader, 1); err != nil {
-		return "", err
+		return clientHello{}, err
 	}
 	if err := skipLengthPrefixed(reader, 2); err != nil {
-		return "", err
+		return clientHello{}, err
 	}
 	if err := skipLengthPrefixed(reader, 1); err != nil {
-		return "", err
+		return clientHello{}, err
 	}
 	if reader.Len() == 0 {
-		return "", nil
+		return clientHello{}, nil
 	}
 	extLen, err := readUint16(reader)
 	if err != nil {
-		return "", err
+		return clientHello{}, err
 	}
 	if extLen > reader.Len() {
-		return "", fmt.Errorf("client hello extensions are truncated")
+		return clientHello{}, fmt.Errorf("client hello extensions are truncated")
 	}
 	extBytes, _ := readBytes(reader, extLen)
 	extensions := bytes.NewReader(extBytes)
+	var hello clientHello
 	for extensions.Len() > 0 {
 		extType, err := readUint16(extensions)
 		if err != nil {
-			return "", err
+			return clientHello{}, err
 		}
 		extDataLen, err := readUint16(extensions)
 		if err != nil {
-			return "", err
+			return clientHello{}, err
 		}
 		if extDataLen > extensions.Len() {
-			return "", fmt.Errorf("client hello extension is truncated")
+			return clientHello{}, fmt.Errorf("client hello extension is truncated")
 		}
 		extData, _ := readBytes(extensions, extDataLen)
-		if extType == 0 {
-			return parseSNIExtension(extData)
+		switch extType {
+		case 0:
+			if hello.sni, err = parseSNIExtension(extData); err != nil {
+				return clientHello{}, err
+			}
+		case 16:
+			if hello.alpn, err = parseALPNExtension(extData); err != nil {
+				return clientHello{}, err
+			}
 		}
 	}
-	return "", nil
+	return hello, nil
 }
 
 func parseSNIExtension(data []byte) (string, error) {
@@ -234,6 +248,32 @@ func parseSNIExtension(data []byte) (string, error) {
 	return "", nil
 }
 
+func parseALPNExtension(data []byte) ([]string, error) {
+	reader := bytes.NewReader(data)
+	listLen, err := readUint16(reader)
+	if err != nil {
+		return nil, err
+	}
+	if listLen > reader.Len() {
+		return nil, fmt.Errorf("alpn extension is truncated")
+	}
+	listBytes, _ := readBytes(reader, listLen)
+	list := bytes.NewReader(listBytes)
+	var protocols []string
+	for list.Len() > 0 {
+		nameLen, err := readLength(list, 1)
+		if err != nil {
+			return nil, err
+		}
+		if nameLen == 0 || nameLen > list.Len() {
+			return nil, fmt.Errorf("alpn protocol name is invalid")
+		}
+		name, _ := readBytes(list, nameLen)
+		protocols = append(protocols, string(name))
+	}
+	return protocols, nil
+}
+
 func skipLengthPrefixed(reader *bytes.Reader, size int) error {
 	length, err := readLength(reader, size)
 	if err != nil {
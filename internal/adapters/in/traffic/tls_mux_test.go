@@ -32,7 +32,7 @@ func TestClientHelloTooLargeUsesDomainSentinel(t *testing.T) {
 		_, _ = client.Write(clientHelloBytes(t, "large.example.com"))
 		_ = client.Close()
 	}()
-	_, _, err := peekClientHelloSNIWithLimit(server, 1)
+	_, _, err := peekClientHelloWithLimit(server, 1)
 	require.Error(t, err)
 	assert.True(t, errors.Is(err, domain.ErrClientHelloTooLarge))
 	_ = server.Close()
@@ -60,7 +60,7 @@ func TestClientHelloPeek(t *testing.T) {
 			_, _ = client.Write([]byte("not tls"))
 			_ = client.Close()
 		}()
-		_, _, err := peekClientHelloSNI(server)
+		_, _, err := peekClientHello(server)
 		require.Error(t, err)
 	})
 
@@ -73,9 +73,9 @@ func TestClientHelloPeek(t *testing.T) {
 			}
 			_ = client.Close()
 		}()
-		sni, replayed, err := peekClientHelloSNI(server)
+		peeked, replayed, err := peekClientHello(server)
 		require.NoError(t, err)
-		assert.Equal(t, "fragmented.example.com", sni)
+		assert.Equal(t, "fragmented.example.com", peeked.sni)
 		buf := make([]byte, len(hello))
 		_, err = io.ReadFull(replayed, buf)
 		require.NoError(t, err)
@@ -89,9 +89,9 @@ func TestClientHelloPeek(t *testing.T) {
 			_, _ = client.Write(hello)
 			_ = client.Close()
 		}()
-		sni, replayed, err := peekClientHelloSNI(server)
+		peeked, replayed, err := peekClientHello(server)
 		require.NoError(t, err)
-		assert.Equal(t, "multi-record.example.com", sni)
+		assert.Equal(t, "multi-record.example.com", peeked.sni)
 		buf := make([]byte, len(hello))
 		_, err = io.ReadFull(replayed, buf)
 		require.NoError(t, err)
@@ -105,9 +105,9 @@ func TestClientHelloPeek(t *testing.T) {
 			_, _ = client.Write(hello)
 			_ = client.Close()
 		}()
-		sni, replayed, err := peekClientHelloSNI(server)
+		peeked, replayed, err := peekClientHello(server)
 		require.NoError(t, err)
-		assert.Equal(t, "split-header.example.com", sni)
+		assert.Equal(t, "split-header.example.com", peeked.sni)
 		buf := make([]byte, len(hello))
 		_, err = io.ReadFull(replayed, buf)
 		require.NoError(t, err)
@@ -151,6 +151,37 @@ func TestTLSPassthrough(t *testing.T) {
 	assertTLSGreeting(t, graph.EntryPoints[0].Address, "api.example.com", "backend-exact\n")
 }
 
+func TestTLSPassthroughSNIPriority(t *testing.T) {
+	exact := startTLSGreetingServer(t, "exact", "exact\n")
+	wild := startTLSGreetingServer(t, "wild", "wild\n")
+	regexA := startTLSGreetingServer(t, "regex-a", "regex-a\n")
+	regexB := startTLSGreetingServer(t, "regex-b", "regex-b\n")
+
+	graph := tlsGraph(t, freeTCPAddress(t), []tlsRoute{
+		{name: "z-regex", sniRegex: `[a-z0-9-]+\.shop\.example\.com`, service: "regex-b", backend: regexB.address},
+		{name: "a-regex", sniRegex: `[a-z0-9]+\.shop\.example\.com`, service: "regex-a", backend: regexA.address},
+		{name: "wild", sni: "*.example.com", service: "wild", backend: wild.address},
+		{name: "exact", sni: "api.example.com", service: "exact", backend: exact.address},
+	})
+	manager := NewManager()
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	defer shutdownManager(t, manager)
+
+	address := graph.EntryPoints[0].Address
+	assertTLSGreeting(t, address, "api.example.com", "exact\n")
+	assertTLSGreeting(t, address, "shop.example.com", "wild\n")
+	assertTLSGreeting(t, address, "v1.shop.example.com", "regex-a\n")
+	assertTLSGreeting(t, address, "TENANT-7.Shop.Example.com", "regex-b\n")
+
+	require.NoError(t, manager.Apply(context.Background(), &graph))
+	assertTLSGreeting(t, address, "v2.shop.example.com", "regex-a\n")
+	matches := map[string]int64{}
+	for _, router := range manager.Status().Routers {
+		matches[router.Name] = router.Matches
+	}
+	assert.Equal(t, map[string]int64{"exact": 1, "wild": 1, "a-regex": 2, "z-regex": 1}, matches, "counters survive an unchanged reload")
+}
+
 func TestTLSMuxUnknownClosesWithoutHTTPSRoute(t *testing.T) {
 	backend := startTLSGreetingServer(t, "backend", "backend\n")
 	graph := tlsGraph(t, freeTCPAddress(t), []tlsRoute{{name: "raw", sni: "raw.example.com", service: "raw", backend: backend.address}})
@@ -272,13 +303,13 @@ func peekHelloFromTLSClient(t *testing.T, serverName string) (string, net.Conn)
 		_ = tls.Client(client, &tls.Config{ServerName: serverName, InsecureSkipVerify: true}).Handshake()
 		_ = client.Close()
 	}()
-	sni, replayed, err := peekClientHelloSNI(server)
+	peeked, replayed, err := peekClientHello(server)
 	require.NoError(t, err)
 	t.Cleanup(func() {
 		_ = replayed.Close()
 		<-done
 	})
-	return sni, replayed
+	return peeked.sni, replayed
 }
 
 func clientHelloBytes(t *testing.T, serverName string) []byte {
@@ -291,10 +322,11 @@ func clientHelloBytes(t *testing.T, serverName string) []byte {
 }
 
 type tlsRoute struct {
-	name    string
-	sni     string
-	service string
-	backend string
+	name     string
+	sni      string
+	sniRegex string
+	service  string
+	backend  string
 }
 
 func tlsGraph(t *testing.T, listenAddress string, routes []tlsRoute) domain.TrafficGraph {
@@ -307,7 +339,7 @@ func tlsGraph(t *testing.T, listenAddress string, routes []tlsRoute) domain.Traf
 		backend, err := backendFromAddress(route.service+":tls", route.backend)
 		require.NoError(t, err)
 		ref := serviceRef(route.service, "tls")
-		graph.Routers = append(graph.Routers, domain.TrafficRouter{Name: route.name, EntryPoint: "websecure", Protocol: domain.RouterProtocolTLSPassthrough, Rule: domain.TrafficRule{SNI: route.sni}, Service: ref})
+		graph.Routers = append(graph.Routers, domain.TrafficRouter{Name: route.name, EntryPoint: "websecure", Protocol: domain.RouterProtocolTLSPassthrough, Rule: domain.TrafficRule{SNI: route.sni, SNIRegex: route.sniRegex}, Service: ref})
 		graph.Services = append(graph.Services, domain.TrafficService{Name: ref, Backends: []domain.TrafficBackend{backend}})
 	}
 	require.NoError(t, graph.Validate())
@@ -426,9 +458,9 @@ func testCertificate(commonName string) (tls.Certificate, error) {
 }
 
 func TestHostMatchesTLSWildcard(t *testing.T) {
-	assert.True(t, hostMatchesTLSWildcard("api.example.com", "*.example.com"))
-	assert.False(t, hostMatchesTLSWildcard("example.com", "*.example.com"))
-	assert.False(t, hostMatchesTLSWildcard("v1.api.example.com", "*.example.com"))
-	assert.False(t, hostMatchesTLSWildcard("api.other.com", "*.example.com"))
+	assert.True(t, domain.HostMatchesSNIWildcard("api.example.com", "*.example.com"))
+	assert.False(t, domain.HostMatchesSNIWildcard("example.com", "*.example.com"))
+	assert.False(t, domain.HostMatchesSNIWildcard("v1.api.example.com", "*.example.com"))
+	assert.False(t, domain.HostMatchesSNIWildcard("api.other.com", "*.example.com"))
 	assert.True(t, strings.EqualFold(normalizeTLSName("API.EXAMPLE.COM."), "api.example.com"))
 }
@@ -4,11 +4,12 @@ import (
 	"context"
 	"errors"
 	"net"
-	"strconv"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/bnema/gordon/internal/domain"
 )
 
@@ -48,6 +49,11 @@ type udpSession struct {
 	clientAddr net.Addr
 	backend    net.Conn
 	backendRef domain.TrafficBackend
+	balancer   *serviceBalancer
+	state      *backendState
+	limiter    *routerLimiter
+	upstream   *rate.Limiter
+	downstream *rate.Limiter
 	lastSeen   atomic.Int64
 	done       chan struct{}
 	once       sync.Once
@@ -139,6 +145,10 @@ func (r *udpEntryPointRuntime) handleDatagram(clientAddr net.Addr, packet []byte
 	if !ok {
 		return
 	}
+	if session.upstream != nil && !session.upstream.AllowN(time.Now(), len(packet)) {
+		r.counters.limits.bandwidthDropped.Add(1)
+		return
+	}
 	n, err := session.backend.Write(packet)
 	r.counters.bytesIn.Add(int64(n))
 	if err != nil {
@@ -156,7 +166,7 @@ func (r *udpEntryPointRuntime) session(key string, clientAddr net.Addr, options
 	}
 	r.mu.Unlock()
 
-	backend, ok := r.resolveUDPBackend()
+	router, balancer, ok := r.resolveUDPBalancer()
 	if !ok {
 		r.counters.totalRefused.Add(1)
 		return nil, false
@@ -169,25 +179,34 @@ func (r *udpEntryPointRuntime) session(key string, clientAddr net.Addr, options
 		return nil, false
 	}
 	r.mu.Unlock()
-	dialCtx, cancel := context.WithTimeout(r.ctx, udpDialTimeout(options))
-	backendConn, err := (&net.Dialer{}).DialContext(dialCtx, "udp", net.JoinHostPort(backend.Host, strconv.Itoa(backend.Port)))
-	cancel()
+	limiter := r.manager.routerLimiter(router.Name)
+	if refusal := limiter.acquire(clientAddr); refusal != limitAllowed {
+		r.counters.refuseLimit(refusal)
+		return nil, false
+	}
+	backendConn, state, err := r.dialUDPBackend(balancer, clientAddr, options)
 	if err != nil {
+		limiter.release(clientAddr)
 		r.counters.totalErrors.Add(1)
 		return nil, false
 	}
-	session := &udpSession{clientAddr: clientAddr, backend: backendConn, backendRef: backend, done: make(chan struct{})}
+	session := &udpSession{
+		clientAddr: clientAddr, backend: backendConn, backendRef: state.backend, balancer: balancer, state: state, limiter: limiter,
+		upstream:   newBandwidthLimiter(router.Limits.BandwidthBytesPerSecond, udpBufferSize),
+		downstream: newBandwidthLimiter(router.Limits.BandwidthBytesPerSecond, udpBufferSize),
+		done:       make(chan struct{}),
+	}
 	session.touch()
 
 	r.mu.Lock()
 	if existing := r.sessions[key]; existing != nil {
 		r.mu.Unlock()
-		_ = backendConn.Close()
+		session.close()
 		return existing, true
 	}
 	if options.MaxSessions > 0 && len(r.sessions) >= options.MaxSessions {
 		r.mu.Unlock()
-		_ = backendConn.Close()
+		session.close()
 		r.counters.totalRefused.Add(1)
 		return nil, false
 	}
@@ -200,6 +219,25 @@ func (r *udpEntryPointRuntime) session(key string, clientAddr net.Addr, options
 	return session, true
 }
 
+// dialUDPBackend picks the backend for a new session. The session keeps it
+// until it expires, which gives each client address backend affinity.
+func (r *udpEntryPointRuntime) dialUDPBackend(balancer *serviceBalancer, clientAddr net.Addr, options domain.UDPOptions) (net.Conn, *backendState, error) {
+	var lastErr error
+	for _, state := range balancer.candidates(clientAddr) {
+		dialCtx, cancel := context.WithTimeout(r.ctx, udpDialTimeout(options))
+		conn, err := (&net.Dialer{}).DialContext(dialCtx, "udp", state.address(state.backend.Port))
+		cancel()
+		if err != nil {
+			lastErr = err
+			balancer.recordFailure(r.ctx, state, err)
+			continue
+		}
+		state.active.Add(1)
+		return conn, state, nil
+	}
+	return nil, nil, lastErr
+}
+
 func trafficBackendEqual(left domain.TrafficBackend, right domain.TrafficBackend) bool {
 	return left.Name == right.Name && left.Host == right.Host && left.Port == right.Port && left.Protocol == right.Protocol
 }
@@ -214,14 +252,24 @@ func udpDialTimeout(options domain.UDPOptions) time.Duration {
 func (r *udpEntryPointRuntime) backendLoop(key string, session *udpSession) {
 	defer r.removeSession(key)
 	buf := make([]byte, udpBufferSize)
+	replied := false
 	for {
 		n, err := session.backend.Read(buf)
 		if err != nil {
 			if !errors.Is(err, net.ErrClosed) && !r.isClosed() {
 				r.counters.totalErrors.Add(1)
+				session.balancer.recordFailure(r.ctx, session.state, err)
 			}
 			return
 		}
+		if !replied {
+			replied = true
+			session.state.recordSuccess()
+		}
+		if session.downstream != nil && !session.downstream.AllowN(time.Now(), n) {
+			r.counters.limits.bandwidthDropped.Add(1)
+			continue
+		}
 		written, err := r.packetConn.WriteTo(buf[:n], session.clientAddr)
 		r.counters.bytesOut.Add(int64(written))
 		if err != nil {
@@ -280,30 +328,26 @@ func (r *udpEntryPointRuntime) removeSession(key string) {
 	r.counters.activeUDPSessions.Add(-1)
 }
 
-func (r *udpEntryPointRuntime) resolveUDPBackend() (domain.TrafficBackend, bool) {
+func (r *udpEntryPointRuntime) resolveUDPBalancer() (domain.TrafficRouter, *serviceBalancer, bool) {
 	graph := r.manager.snapshot.Load()
 	if graph == nil {
-		return domain.TrafficBackend{}, false
+		return domain.TrafficRouter{}, nil, false
 	}
 	entryPoint := r.entryPointSnapshot()
 	var router domain.TrafficRouter
 	for _, candidate := range graph.Routers {
 		if candidate.EntryPoint == entryPoint.Name && candidate.Protocol == domain.RouterProtocolUDP {
 			if router.Name != "" {
-				return domain.TrafficBackend{}, false
+				return domain.TrafficRouter{}, nil, false
 			}
 			router = candidate
 		}
 	}
 	if router.Name == "" {
-		return domain.TrafficBackend{}, false
-	}
-	for _, service := range graph.Services {
-		if service.Name == router.Service && len(service.Backends) == 1 && service.Backends[0].Protocol == domain.NetworkProtocolUDP {
-			return service.Backends[0], true
-		}
+		return domain.TrafficRouter{}, nil, false
 	}
-	return domain.TrafficBackend{}, false
+	balancer, ok := r.manager.balancerFor(router.Service, domain.NetworkProtocolUDP)
+	return router, balancer, ok
 }
 
 func (r *udpEntryPointRuntime) stop(ctx context.Context, drainTimeout time.Duration) {
@@ -340,9 +384,9 @@ func (r *udpEntryPointRuntime) drainSessionsAfter(drainTimeout time.Duration) {
 	r.drainSessionsMatchingAfter(func(*udpSession) bool { return true }, drainTimeout)
 }
 
-func (r *udpEntryPointRuntime) drainSessionsNotMatchingAfter(backend domain.TrafficBackend, drainTimeout time.Duration) {
+func (r *udpEntryPointRuntime) drainSessionsNotMatchingAfter(balancer *serviceBalancer, drainTimeout time.Duration) {
 	trafficDebug(r.ctx).Str("entrypoint", r.entryPointSnapshot().Name).Dur("drain_timeout", drainTimeout).Msg("scheduled stale udp session drain after backend update")
-	r.drainSessionsMatchingAfter(func(session *udpSession) bool { return !trafficBackendEqual(session.backendRef, backend) }, drainTimeout)
+	r.drainSessionsMatchingAfter(func(session *udpSession) bool { return !balancer.contains(session.backendRef) }, drainTimeout)
 }
 
 func (r *udpEntryPointRuntime) drainSessionsMatchingAfter(match func(*udpSession) bool, drainTimeout time.Duration) {
@@ -454,6 +498,8 @@ func (s *udpSession) touch() { s.lastSeen.Store(time.Now().UnixNano()) }
 
 func (s *udpSession) close() {
 	s.once.Do(func() {
+		s.state.active.Add(-1)
+		s.limiter.release(s.clientAddr)
 		_ = s.backend.Close()
 		close(s.done)
 	})
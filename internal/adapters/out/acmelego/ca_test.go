@@ -0,0 +1,461 @@
+package acmelego
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+)
+
+const testEABKeyID = "kid-1"
+
+var testEABKey = []byte("0123456789abcdef0123456789abcdef")
+
+// testCA is a minimal RFC 8555 certificate authority standing in for Pebble.
+// Authorizations are created valid so no challenge is solved, and finalize
+// signs the CSR with a throwaway root.
+type testCA struct {
+	*httptest.Server
+	key    *ecdsa.PrivateKey
+	root   *x509.Certificate
+	rootPM []byte
+
+	// requireEAB rejects accounts without a valid HS256 binding for testEABKeyID.
+	requireEAB bool
+	// orderProblem, when set, is returned by newOrder.
+	orderProblem *testProblem
+
+	mu       sync.Mutex
+	nonce    int
+	accounts []string
+	orders   map[string][]string
+	certs    map[string][]byte
+}
+
+type testProblem struct {
+	status int
+	typ    string
+}
+
+type testJWS struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	root, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	ca := &testCA{
+		key:    key,
+		root:   root,
+		rootPM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		orders: map[string][]string{},
+		certs:  map[string][]byte{},
+	}
+	ca.Server = httptest.NewTLSServer(http.HandlerFunc(ca.serveHTTP))
+	t.Cleanup(ca.Close)
+	return ca
+}
+
+func (ca *testCA) directoryURL() string { return ca.URL + "/directory" }
+
+func (ca *testCA) registeredAccounts() []string {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return append([]string(nil), ca.accounts...)
+}
+
+func (ca *testCA) issuedCount() int {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	return len(ca.certs)
+}
+
+func (ca *testCA) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	ca.mu.Lock()
+	ca.nonce++
+	w.Header().Set("Replay-Nonce", "nonce-"+strconv.Itoa(ca.nonce))
+	ca.mu.Unlock()
+
+	if r.URL.Path == "/directory" {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"newNonce":   ca.URL + "/new-nonce",
+			"newAccount": ca.URL + "/new-account",
+			"newOrder":   ca.URL + "/new-order",
+			"revokeCert": ca.URL + "/revoke-cert",
+			"keyChange":  ca.URL + "/key-change",
+		})
+		return
+	}
+	if r.URL.Path == "/new-nonce" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	var body testJWS
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed")
+		return
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body.Payload)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed")
+		return
+	}
+
+	resource, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	switch resource {
+	case "new-account":
+		ca.newAccount(w, payload)
+	case "new-order":
+		ca.newOrder(w, payload)
+	case "authz":
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":     "valid",
+			"identifier": map[string]string{"type": "dns", "value": id},
+			"challenges": []any{},
+		})
+	case "finalize":
+		ca.finalize(w, id, payload)
+	case "cert":
+		ca.mu.Lock()
+		chain := ca.certs[id]
+		ca.mu.Unlock()
+		w.Header().Set("Content-Type", "application/pem-certificate-chain")
+		_, _ = w.Write(chain)
+	default:
+		writeProblem(w, http.StatusNotFound, "malformed")
+	}
+}
+
+func (ca *testCA) newAccount(w http.ResponseWriter, payload []byte) {
+	var req struct {
+		ExternalAccountBinding *testJWS `json:"externalAccountBinding"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed")
+		return
+	}
+	kid := ""
+	if req.ExternalAccountBinding != nil {
+		kid = verifyTestEAB(*req.ExternalAccountBinding)
+		if kid == "" {
+			writeProblem(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+	}
+	if ca.requireEAB && kid == "" {
+		writeProblem(w, http.StatusUnauthorized, "externalAccountRequired")
+		return
+	}
+
+	ca.mu.Lock()
+	ca.accounts = append(ca.accounts, kid)
+	location := fmt.Sprintf("%s/account/%d", ca.URL, len(ca.accounts))
+	ca.mu.Unlock()
+	w.Header().Set("Location", location)
+	writeJSON(w, http.StatusCreated, map[string]string{"status": "valid"})
+}
+
+func verifyTestEAB(eab testJWS) string {
+	protected, err := base64.RawURLEncoding.DecodeString(eab.Protected)
+	if err != nil {
+		return ""
+	}
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(protected, &header); err != nil || header.Alg != "HS256" || header.Kid != testEABKeyID {
+		return ""
+	}
+	mac := hmac.New(sha256.New, testEABKey)
+	mac.Write([]byte(eab.Protected + "." + eab.Payload))
+	if base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) != eab.Signature {
+		return ""
+	}
+	return header.Kid
+}
+
+func (ca *testCA) newOrder(w http.ResponseWriter, payload []byte) {
+	if ca.orderProblem != nil {
+		writeProblem(w, ca.orderProblem.status, ca.orderProblem.typ)
+		return
+	}
+	var req struct {
+		Identifiers []struct {
+			Value string `json:"value"`
+		} `json:"identifiers"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed")
+		return
+	}
+	names := make([]string, 0, len(req.Identifiers))
+	for _, identifier := range req.Identifiers {
+		names = append(names, identifier.Value)
+	}
+
+	ca.mu.Lock()
+	id := strconv.Itoa(len(ca.orders) + 1)
+	ca.orders[id] = names
+	ca.mu.Unlock()
+	w.Header().Set("Location", ca.URL+"/order/"+id)
+	writeJSON(w, http.StatusCreated, ca.order(id, names, "pending"))
+}
+
+func (ca *testCA) finalize(w http.ResponseWriter, id string, payload []byte) {
+	var req struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed")
+		return
+	}
+	der, err := base64.RawURLEncoding.DecodeString(req.CSR)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "badCSR")
+		return
+	}
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "badCSR")
+		return
+	}
+	leaf := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: csr.Subject.CommonName},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leaf, ca.root, csr.PublicKey, ca.key)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "serverInternal")
+		return
+	}
+
+	ca.mu.Lock()
+	ca.certs[id] = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER}), ca.rootPM...)
+	names := ca.orders[id]
+	ca.mu.Unlock()
+	writeJSON(w, http.StatusOK, ca.order(id, names, "valid"))
+}
+
+func (ca *testCA) order(id string, names []string, status string) map[string]any {
+	identifiers := make([]map[string]string, 0, len(names))
+	authorizations := make([]string, 0, len(names))
+	for _, name := range names {
+		identifiers = append(identifiers, map[string]string{"type": "dns", "value": name})
+		authorizations = append(authorizations, ca.URL+"/authz/"+name)
+	}
+	order := map[string]any{
+		"status":         status,
+		"identifiers":    identifiers,
+		"authorizations": authorizations,
+		"finalize":       ca.URL + "/finalize/" + id,
+	}
+	if status == "valid" {
+		order["certificate"] = ca.URL + "/cert/" + id
+	}
+	return order
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, status int, typ string) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":   "urn:ietf:params:acme:error:" + typ,
+		"detail": typ,
+		"status": status,
+	})
+}
+
+// memoryAccountStore is a CertificateStore mock keeping accounts per CA.
+func memoryAccountStore(t *testing.T) (*outmocks.MockCertificateStore, map[string]out.ACMEAccount) {
+	accounts := map[string]out.ACMEAccount{}
+	var mu sync.Mutex
+	store := outmocks.NewMockCertificateStore(t)
+	store.EXPECT().LoadAccount(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, directoryURL string) (*out.ACMEAccount, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if acct, ok := accounts[directoryURL]; ok {
+			return &acct, nil
+		}
+		return nil, nil
+	}).Maybe()
+	store.EXPECT().SaveAccount(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, acct out.ACMEAccount) error {
+		mu.Lock()
+		defer mu.Unlock()
+		accounts[acct.DirectoryURL] = acct
+		return nil
+	}).Maybe()
+	return store, accounts
+}
+
+// newTestCAIssuer creates an issuer trusting the test CAs. httptest servers
+// share one certificate, so the first CA's client reaches all of them.
+func newTestCAIssuer(t *testing.T, store out.CertificateStore, cas ...*testCA) *Issuer {
+	t.Helper()
+	configs := make([]CAConfig, len(cas))
+	for i, ca := range cas {
+		configs[i] = CAConfig{DirectoryURL: ca.directoryURL()}
+	}
+	return newTestCAIssuerWithConfig(t, store, cas[0].Client(), configs...)
+}
+
+func newTestCAIssuerWithConfig(t *testing.T, store out.CertificateStore, client *http.Client, cas ...CAConfig) *Issuer {
+	t.Helper()
+	issuer, err := NewIssuer(Config{
+		Email:             "ops@example.com",
+		Store:             store,
+		HTTPChallengeSink: outmocks.NewMockHTTPChallengeSink(t),
+		CAs:               cas,
+		HTTPClient:        client,
+	})
+	require.NoError(t, err)
+	return issuer
+}
+
+func TestIssuerObtainsFromCA(t *testing.T) {
+	ca := newTestCA(t)
+	store, accounts := memoryAccountStore(t)
+	issuer := newTestCAIssuer(t, store, ca)
+
+	cert, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app.example.com"}, cert.Certificate.Leaf.DNSNames)
+	assert.Equal(t, ca.root.Subject.CommonName, cert.Certificate.Leaf.Issuer.CommonName)
+	require.Contains(t, accounts, ca.directoryURL())
+	assert.Equal(t, ca.directoryURL(), accounts[ca.directoryURL()].DirectoryURL)
+
+	_, err = issuer.Obtain(context.Background(), out.CertificateOrder{ID: "api", Names: []string{"api.example.com"}})
+	require.NoError(t, err)
+	assert.Len(t, ca.registeredAccounts(), 1, "the account is registered once")
+}
+
+func TestIssuerRegistersWithExternalAccountBinding(t *testing.T) {
+	ca := newTestCA(t)
+	ca.requireEAB = true
+	store, _ := memoryAccountStore(t)
+
+	issuer := newTestCAIssuer(t, store, ca)
+	_, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "externalAccountRequired")
+
+	issuer = newTestCAIssuerWithConfig(t, store, ca.Client(), CAConfig{
+		DirectoryURL: ca.directoryURL(),
+		EABKeyID:     testEABKeyID,
+		EABHMAC:      base64.RawURLEncoding.EncodeToString(testEABKey),
+	})
+	_, err = issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testEABKeyID}, ca.registeredAccounts())
+}
+
+func TestIssuerFallsBackWhenCARateLimits(t *testing.T) {
+	primary := newTestCA(t)
+	primary.orderProblem = &testProblem{status: http.StatusTooManyRequests, typ: "rateLimited"}
+	secondary := newTestCA(t)
+	store, accounts := memoryAccountStore(t)
+	issuer := newTestCAIssuer(t, store, primary, secondary)
+
+	cert, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, secondary.root.Subject.CommonName, cert.Certificate.Leaf.Issuer.CommonName)
+	assert.Equal(t, 1, secondary.issuedCount())
+	assert.Contains(t, accounts, primary.directoryURL())
+	assert.Contains(t, accounts, secondary.directoryURL())
+	assert.NotEqual(t, accounts[primary.directoryURL()].PrivateKeyPEM, accounts[secondary.directoryURL()].PrivateKeyPEM)
+}
+
+func TestIssuerFallsBackWhenCAIsDown(t *testing.T) {
+	down := newTestCA(t)
+	down.Close()
+	secondary := newTestCA(t)
+	store, _ := memoryAccountStore(t)
+	issuer := newTestCAIssuer(t, store, down, secondary)
+
+	_, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, secondary.issuedCount())
+}
+
+func TestIssuerDoesNotFallBackOnOrderRejection(t *testing.T) {
+	primary := newTestCA(t)
+	primary.orderProblem = &testProblem{status: http.StatusBadRequest, typ: "rejectedIdentifier"}
+	secondary := newTestCA(t)
+	store, _ := memoryAccountStore(t)
+	issuer := newTestCAIssuer(t, store, primary, secondary)
+
+	_, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "rejectedIdentifier")
+	assert.Empty(t, secondary.registeredAccounts())
+}
+
+func TestIssuerReportsEveryCAFailure(t *testing.T) {
+	primary := newTestCA(t)
+	primary.orderProblem = &testProblem{status: http.StatusTooManyRequests, typ: "rateLimited"}
+	secondary := newTestCA(t)
+	secondary.orderProblem = &testProblem{status: http.StatusServiceUnavailable, typ: "serverInternal"}
+	store, _ := memoryAccountStore(t)
+	issuer := newTestCAIssuer(t, store, primary, secondary)
+
+	_, err := issuer.Obtain(context.Background(), out.CertificateOrder{ID: "app", Names: []string{"app.example.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), primary.directoryURL())
+	assert.Contains(t, err.Error(), secondary.directoryURL())
+	_, rateLimited := errors.AsType[*acme.RateLimitedError](err)
+	assert.True(t, rateLimited)
+}
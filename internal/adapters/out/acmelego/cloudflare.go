@@ -49,7 +49,7 @@ type CloudflareZoneResolver struct {
 }
 
 type zoneCacheEntry struct {
-	zone      out.CloudflareZone
+	zone      out.DNSZone
 	err       error
 	createdAt time.Time
 	ttl       time.Duration
@@ -97,17 +97,17 @@ func NewCloudflareZoneResolver(token string, opts ...CloudflareZoneResolverOptio
 }
 
 // compile-time interface check
-var _ out.CloudflareZoneResolver = (*CloudflareZoneResolver)(nil)
+var _ out.DNSZoneResolver = (*CloudflareZoneResolver)(nil)
 
 // FindZone finds the most specific Cloudflare zone for the given domain.
 // It searches from the full hostname down to the TLD (e.g. for
 // api.prod.example.com it tries api.prod.example.com, prod.example.com,
 // example.com, com) and returns the first active zone found.
-func (r *CloudflareZoneResolver) FindZone(ctx context.Context, domainName string) (out.CloudflareZone, error) {
+func (r *CloudflareZoneResolver) FindZone(ctx context.Context, domainName string) (out.DNSZone, error) {
 	domainName = strings.TrimSuffix(strings.TrimSpace(domainName), ".")
 	domainName = strings.ToLower(domainName)
 	if domainName == "" {
-		return out.CloudflareZone{}, fmt.Errorf("cloudflare zone resolver: empty domain")
+		return out.DNSZone{}, fmt.Errorf("cloudflare zone resolver: empty domain")
 	}
 
 	if cached, ok := r.cached(domainName); ok {
@@ -150,8 +150,8 @@ func (r *CloudflareZoneResolver) FindZone(ctx context.Context, domainName string
 	}
 
 	err := fmt.Errorf("cloudflare zone resolver: no active zone found for %q: %w", domainName, errors.Join(candidateErrs...))
-	r.storeCache(domainName, out.CloudflareZone{}, err)
-	return out.CloudflareZone{}, err
+	r.storeCache(domainName, out.DNSZone{}, err)
+	return out.DNSZone{}, err
 }
 
 func (r *CloudflareZoneResolver) cached(name string) (zoneCacheEntry, bool) {
@@ -174,7 +174,7 @@ func (r *CloudflareZoneResolver) cached(name string) (zoneCacheEntry, bool) {
 	return entry, true
 }
 
-func (r *CloudflareZoneResolver) storeCache(name string, zone out.CloudflareZone, err error) {
+func (r *CloudflareZoneResolver) storeCache(name string, zone out.DNSZone, err error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if len(r.cache) >= defaultCacheMaxSize {
@@ -198,10 +198,10 @@ func (r *CloudflareZoneResolver) storeCache(name string, zone out.CloudflareZone
 }
 
 // findZoneByName queries the Cloudflare API for an active zone with the exact given name.
-func (r *CloudflareZoneResolver) findZoneByName(ctx context.Context, name string) (out.CloudflareZone, error) {
+func (r *CloudflareZoneResolver) findZoneByName(ctx context.Context, name string) (out.DNSZone, error) {
 	u, err := url.Parse(r.baseURL + "/zones")
 	if err != nil {
-		return out.CloudflareZone{}, fmt.Errorf("parse url: %w", err)
+		return out.DNSZone{}, fmt.Errorf("parse url: %w", err)
 	}
 
 	q := u.Query()
@@ -211,33 +211,33 @@ func (r *CloudflareZoneResolver) findZoneByName(ctx context.Context, name string
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 	if err != nil {
-		return out.CloudflareZone{}, fmt.Errorf("create request: %w", err)
+		return out.DNSZone{}, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+r.token)
 	req.Header.Set("Accept", "application/json")
 
 	resp, err := r.client.Do(req)
 	if err != nil {
-		return out.CloudflareZone{}, fmt.Errorf("request failed: %w", err)
+		return out.DNSZone{}, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	lr := io.LimitReader(resp.Body, defaultMaxResponseSize+1)
 	body, err := io.ReadAll(lr)
 	if err != nil {
-		return out.CloudflareZone{}, fmt.Errorf("read response: %w", err)
+		return out.DNSZone{}, fmt.Errorf("read response: %w", err)
 	}
 	if len(body) > defaultMaxResponseSize {
-		return out.CloudflareZone{}, fmt.Errorf("cloudflare api: response body too large (%d bytes)", len(body))
+		return out.DNSZone{}, fmt.Errorf("cloudflare api: response body too large (%d bytes)", len(body))
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return out.CloudflareZone{}, fmt.Errorf("cloudflare api: status %d", resp.StatusCode)
+		return out.DNSZone{}, fmt.Errorf("cloudflare api: status %d", resp.StatusCode)
 	}
 
 	var apiResp zoneAPIResponse
 	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return out.CloudflareZone{}, fmt.Errorf("parse response: %w", err)
+		return out.DNSZone{}, fmt.Errorf("parse response: %w", err)
 	}
 
 	if !apiResp.Success {
@@ -245,17 +245,17 @@ func (r *CloudflareZoneResolver) findZoneByName(ctx context.Context, name string
 		if len(apiResp.Errors) > 0 {
 			errMsg = apiResp.Errors[0].Message
 		}
-		return out.CloudflareZone{}, fmt.Errorf("cloudflare api: %s", errMsg)
+		return out.DNSZone{}, fmt.Errorf("cloudflare api: %s", errMsg)
 	}
 
 	for _, z := range apiResp.Result {
 		if z.Name == name && z.Status == "active" {
-			return out.CloudflareZone{
+			return out.DNSZone{
 				ID:   z.ID,
 				Name: z.Name,
 			}, nil
 		}
 	}
 
-	return out.CloudflareZone{}, fmt.Errorf("no active zone found for %q", name)
+	return out.DNSZone{}, fmt.Errorf("no active zone found for %q", name)
 }
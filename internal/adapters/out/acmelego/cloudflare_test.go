@@ -139,7 +139,7 @@ func TestCloudflareZoneResolverNoZoneFound(t *testing.T) {
 
 func TestCloudflareZoneResolverCachesErrorsWithShortTTL(t *testing.T) {
 	resolver := NewCloudflareZoneResolver("token")
-	resolver.storeCache("example.com", out.CloudflareZone{}, errors.New("temporary failure"))
+	resolver.storeCache("example.com", out.DNSZone{}, errors.New("temporary failure"))
 
 	entry, ok := resolver.cached("example.com")
 	require.True(t, ok)
@@ -173,7 +173,7 @@ func TestCloudflareZoneResolverUnsuccessfulAPI(t *testing.T) {
 
 // Ensure the interface compliance.
 func TestCloudflareZoneResolverImplementsInterface(t *testing.T) {
-	var _ out.CloudflareZoneResolver = (*CloudflareZoneResolver)(nil)
+	var _ out.DNSZoneResolver = (*CloudflareZoneResolver)(nil)
 
 	// Test NewCloudflareZoneResolver returns non-nil
 	r := NewCloudflareZoneResolver("token")
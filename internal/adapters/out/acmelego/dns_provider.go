@@ -0,0 +1,237 @@
+package acmelego
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge"
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/go-acme/lego/v4/providers/dns/cloudflare"
+	"github.com/go-acme/lego/v4/providers/dns/rfc2136"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	defaultRFC2136Timeout = 10 * time.Second
+	defaultExecTimeout    = 2 * time.Minute
+	maxExecOutputBytes    = 4096
+)
+
+// RFC2136Config configures DNS-01 through RFC 2136 dynamic updates.
+type RFC2136Config struct {
+	// Nameserver is the host:port of the authoritative primary accepting updates.
+	Nameserver string
+
+	// TSIGKey is the TSIG key name. Updates are unsigned when empty.
+	TSIGKey string
+
+	// TSIGSecret is the base64 TSIG secret for TSIGKey.
+	TSIGSecret string
+
+	// TSIGAlgorithm is the TSIG algorithm name, e.g. "hmac-sha256.".
+	TSIGAlgorithm string
+}
+
+// ExecConfig configures DNS-01 through an external hook program.
+type ExecConfig struct {
+	// Command is the hook program. It is run as "<command> present <fqdn> <value>"
+	// and "<command> cleanup <fqdn> <value>".
+	Command string
+
+	// Timeout bounds each hook invocation. Defaults to two minutes.
+	Timeout time.Duration
+}
+
+// validateDNSProviderConfig checks the provider-specific settings of a DNS-01
+// challenge mode.
+func validateDNSProviderConfig(cfg Config) error {
+	switch cfg.Challenge {
+	case domain.ACMEChallengeCloudflareDNS01:
+		if cfg.Token == "" {
+			return fmt.Errorf("acmelego: %w", domain.ErrCloudflareTokenMissing)
+		}
+	case domain.ACMEChallengeRFC2136DNS01:
+		if cfg.RFC2136.Nameserver == "" {
+			return fmt.Errorf("acmelego: %w: RFC2136.Nameserver is required", domain.ErrDNSProviderConfigInvalid)
+		}
+		if cfg.RFC2136.TSIGKey != "" && cfg.RFC2136.TSIGSecret == "" {
+			return fmt.Errorf("acmelego: %w", domain.ErrTSIGSecretMissing)
+		}
+	case domain.ACMEChallengeExecDNS01:
+		if cfg.Exec.Command == "" {
+			return fmt.Errorf("acmelego: %w: Exec.Command is required", domain.ErrDNSProviderConfigInvalid)
+		}
+	}
+	return nil
+}
+
+// newDNSProvider creates the lego DNS-01 provider for the configured challenge mode.
+func newDNSProvider(cfg Config) (challenge.Provider, error) {
+	switch cfg.Challenge {
+	case domain.ACMEChallengeCloudflareDNS01:
+		provider, err := cloudflare.NewDNSProviderConfig(newCloudflareDNSProviderConfig(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("create cloudflare dns provider: %w", err)
+		}
+		return provider, nil
+	case domain.ACMEChallengeRFC2136DNS01:
+		provider, err := rfc2136.NewDNSProviderConfig(newRFC2136DNSProviderConfig(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("create rfc2136 dns provider: %w", err)
+		}
+		return provider, nil
+	case domain.ACMEChallengeExecDNS01:
+		return NewExecDNSProvider(cfg.Exec, cfg.DNSPropagationTimeout, cfg.DNSPollingInterval), nil
+	default:
+		return nil, fmt.Errorf("%w: %s is not a dns-01 challenge", domain.ErrACMEChallengeInvalid, cfg.Challenge)
+	}
+}
+
+// newRFC2136DNSProviderConfig creates an rfc2136 DNS provider config from the issuer config.
+func newRFC2136DNSProviderConfig(cfg Config) *rfc2136.Config {
+	rfcCfg := rfc2136.NewDefaultConfig()
+	rfcCfg.Nameserver = cfg.RFC2136.Nameserver
+	rfcCfg.TSIGKey = cfg.RFC2136.TSIGKey
+	rfcCfg.TSIGSecret = cfg.RFC2136.TSIGSecret
+	rfcCfg.TSIGAlgorithm = cfg.RFC2136.TSIGAlgorithm
+	rfcCfg.DNSTimeout = defaultRFC2136Timeout
+	rfcCfg.TTL = dns01.DefaultTTL
+	rfcCfg.PropagationTimeout = cfg.DNSPropagationTimeout
+	rfcCfg.PollingInterval = cfg.DNSPollingInterval
+	return rfcCfg
+}
+
+// NewDNSZoneResolver returns the zone resolver matching a DNS-01 challenge
+// mode: the Cloudflare API for cloudflare-dns-01, the update nameserver for
+// rfc2136-dns-01 and the recursive resolvers for exec-dns-01. It returns nil
+// for non-DNS-01 modes.
+func NewDNSZoneResolver(cfg Config) out.DNSZoneResolver {
+	switch cfg.Challenge {
+	case domain.ACMEChallengeCloudflareDNS01:
+		return NewCloudflareZoneResolver(cfg.Token)
+	case domain.ACMEChallengeRFC2136DNS01:
+		return NewSOAZoneResolver([]string{cfg.RFC2136.Nameserver})
+	case domain.ACMEChallengeExecDNS01:
+		return NewSOAZoneResolver(cfg.DNSResolvers)
+	default:
+		return nil
+	}
+}
+
+// SOAZoneResolver resolves a hostname to its DNS zone by walking up the
+// labels until a nameserver answers with an SOA record.
+type SOAZoneResolver struct {
+	nameservers []string
+}
+
+// NewSOAZoneResolver creates a SOAZoneResolver querying the given host:port
+// nameservers.
+func NewSOAZoneResolver(nameservers []string) *SOAZoneResolver {
+	return &SOAZoneResolver{nameservers: append([]string(nil), nameservers...)}
+}
+
+// compile-time interface check
+var _ out.DNSZoneResolver = (*SOAZoneResolver)(nil)
+
+// FindZone finds the zone apex for the given domain.
+func (r *SOAZoneResolver) FindZone(ctx context.Context, domainName string) (out.DNSZone, error) {
+	domainName = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domainName)), ".")
+	if domainName == "" {
+		return out.DNSZone{}, fmt.Errorf("soa zone resolver: empty domain")
+	}
+	if err := ctx.Err(); err != nil {
+		return out.DNSZone{}, err
+	}
+	zone, err := dns01.FindZoneByFqdnCustom(dns01.ToFqdn(domainName), r.nameservers)
+	if err != nil {
+		return out.DNSZone{}, fmt.Errorf("soa zone resolver: %w", err)
+	}
+	return out.DNSZone{Name: dns01.UnFqdn(zone)}, nil
+}
+
+// ExecDNSProvider publishes DNS-01 records by running a hook program, for
+// DNS hosts without a built-in provider.
+type ExecDNSProvider struct {
+	command            string
+	timeout            time.Duration
+	propagationTimeout time.Duration
+	pollingInterval    time.Duration
+}
+
+// NewExecDNSProvider creates an ExecDNSProvider.
+func NewExecDNSProvider(cfg ExecConfig, propagationTimeout, pollingInterval time.Duration) *ExecDNSProvider {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	return &ExecDNSProvider{
+		command:            cfg.Command,
+		timeout:            timeout,
+		propagationTimeout: propagationTimeout,
+		pollingInterval:    pollingInterval,
+	}
+}
+
+// compile-time interface checks
+var (
+	_ challenge.Provider        = (*ExecDNSProvider)(nil)
+	_ challenge.ProviderTimeout = (*ExecDNSProvider)(nil)
+)
+
+// Present runs "<command> present <fqdn> <value>".
+func (p *ExecDNSProvider) Present(domainName, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domainName, keyAuth)
+	return p.run("present", info.EffectiveFQDN, info.Value)
+}
+
+// CleanUp runs "<command> cleanup <fqdn> <value>".
+func (p *ExecDNSProvider) CleanUp(domainName, _, keyAuth string) error {
+	info := dns01.GetChallengeInfo(domainName, keyAuth)
+	return p.run("cleanup", info.EffectiveFQDN, info.Value)
+}
+
+// Timeout returns the DNS propagation timeout and polling interval.
+func (p *ExecDNSProvider) Timeout() (timeout, interval time.Duration) {
+	return p.propagationTimeout, p.pollingInterval
+}
+
+func (p *ExecDNSProvider) run(action, fqdn, value string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.command, action, fqdn, value)
+	cmd.Stdout = &limitedBuffer{buf: &output, limit: maxExecOutputBytes}
+	cmd.Stderr = cmd.Stdout
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", p.timeout)
+		}
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("exec dns hook %s %s: %w: %s", action, fqdn, err, msg)
+		}
+		return fmt.Errorf("exec dns hook %s %s: %w", action, fqdn, err)
+	}
+	return nil
+}
+
+// limitedBuffer keeps the first limit bytes written and discards the rest so
+// a chatty hook cannot grow memory without bound.
+type limitedBuffer struct {
+	buf   *bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		b.buf.Write(p[:min(len(p), remaining)])
+	}
+	return len(p), nil
+}
@@ -0,0 +1,257 @@
+package acmelego
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/challenge/dns01"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	testTSIGKey    = "gordon."
+	testTSIGSecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQtc2VjcmV0LXNlY3JldA=="
+)
+
+// testNameserver is an authoritative nameserver for example.com that
+// records RFC 2136 updates and requires TSIG.
+type testNameserver struct {
+	address string
+
+	mu      sync.Mutex
+	updates []testUpdate
+}
+
+type testUpdate struct {
+	zone    string
+	tsigErr error
+	records []dns.RR
+}
+
+func startTestNameserver(t *testing.T) *testNameserver {
+	t.Helper()
+	dns01.ClearFqdnCache()
+	t.Cleanup(dns01.ClearFqdnCache)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ns := &testNameserver{address: conn.LocalAddr().String()}
+
+	started := make(chan struct{})
+	server := &dns.Server{
+		PacketConn:        conn,
+		TsigSecret:        map[string]string{testTSIGKey: testTSIGSecret},
+		Handler:           dns.HandlerFunc(ns.serveDNS),
+		NotifyStartedFunc: func() { close(started) },
+		// The default accept func rejects UPDATE messages.
+		MsgAcceptFunc: func(dns.Header) dns.MsgAcceptAction { return dns.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return ns
+}
+
+func (ns *testNameserver) serveDNS(w dns.ResponseWriter, r *dns.Msg) {
+	reply := new(dns.Msg).SetReply(r)
+	soa := &dns.SOA{
+		Hdr:    dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeSOA, Class: dns.ClassINET, Ttl: 300},
+		Ns:     "ns1.example.com.",
+		Mbox:   "hostmaster.example.com.",
+		Serial: 1,
+	}
+
+	switch {
+	case r.Opcode == dns.OpcodeUpdate:
+		ns.mu.Lock()
+		ns.updates = append(ns.updates, testUpdate{zone: r.Question[0].Name, tsigErr: w.TsigStatus(), records: r.Ns})
+		ns.mu.Unlock()
+		if w.TsigStatus() != nil {
+			reply.Rcode = dns.RcodeNotAuth
+		} else if tsig := r.IsTsig(); tsig != nil {
+			reply.SetTsig(tsig.Hdr.Name, tsig.Algorithm, 300, time.Now().Unix())
+		}
+	case r.Question[0].Qtype == dns.TypeSOA && r.Question[0].Name == "example.com.":
+		reply.Answer = append(reply.Answer, soa)
+	case dns.IsSubDomain("example.com.", r.Question[0].Name):
+		reply.Ns = append(reply.Ns, soa)
+	default:
+		reply.Rcode = dns.RcodeRefused
+	}
+	_ = w.WriteMsg(reply)
+}
+
+func (ns *testNameserver) recordedUpdates() []testUpdate {
+	ns.mu.Lock()
+	defer ns.mu.Unlock()
+	return append([]testUpdate(nil), ns.updates...)
+}
+
+func TestRFC2136ProviderSendsSignedUpdates(t *testing.T) {
+	ns := startTestNameserver(t)
+	provider, err := newDNSProvider(Config{
+		Challenge: domain.ACMEChallengeRFC2136DNS01,
+		RFC2136: RFC2136Config{
+			Nameserver:    ns.address,
+			TSIGKey:       testTSIGKey,
+			TSIGSecret:    testTSIGSecret,
+			TSIGAlgorithm: dns.HmacSHA256,
+		},
+		DNSPropagationTimeout: time.Minute,
+		DNSPollingInterval:    time.Second,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, provider.Present("app.example.com", "token", "key-auth"))
+	require.NoError(t, provider.CleanUp("app.example.com", "token", "key-auth"))
+
+	updates := ns.recordedUpdates()
+	require.Len(t, updates, 2)
+	info := dns01.GetChallengeInfo("app.example.com", "key-auth")
+	for _, update := range updates {
+		assert.Equal(t, "example.com.", update.zone)
+		require.NoError(t, update.tsigErr)
+		var txt *dns.TXT
+		for _, rr := range update.records {
+			if record, ok := rr.(*dns.TXT); ok {
+				txt = record
+			}
+		}
+		require.NotNil(t, txt)
+		assert.Equal(t, info.EffectiveFQDN, txt.Hdr.Name)
+		assert.Equal(t, []string{info.Value}, txt.Txt)
+	}
+	assert.Equal(t, uint16(dns.ClassINET), updates[0].records[len(updates[0].records)-1].Header().Class, "present inserts the record")
+	assert.Equal(t, uint16(dns.ClassNONE), updates[1].records[0].Header().Class, "cleanup deletes the record")
+}
+
+func TestRFC2136ProviderRejectedWithWrongTSIGSecret(t *testing.T) {
+	ns := startTestNameserver(t)
+	provider, err := newDNSProvider(Config{
+		Challenge: domain.ACMEChallengeRFC2136DNS01,
+		RFC2136: RFC2136Config{
+			Nameserver:    ns.address,
+			TSIGKey:       testTSIGKey,
+			TSIGSecret:    "d3Jvbmctc2VjcmV0",
+			TSIGAlgorithm: dns.HmacSHA256,
+		},
+		DNSPropagationTimeout: time.Minute,
+		DNSPollingInterval:    time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Error(t, provider.Present("app.example.com", "token", "key-auth"))
+}
+
+func TestSOAZoneResolverFindsZoneApex(t *testing.T) {
+	ns := startTestNameserver(t)
+	resolver := NewDNSZoneResolver(Config{
+		Challenge: domain.ACMEChallengeRFC2136DNS01,
+		RFC2136:   RFC2136Config{Nameserver: ns.address},
+	})
+
+	for _, host := range []string{"example.com", "app.example.com", "api.prod.example.com."} {
+		zone, err := resolver.FindZone(context.Background(), host)
+		require.NoError(t, err, host)
+		assert.Equal(t, "example.com", zone.Name, host)
+	}
+
+	_, err := resolver.FindZone(context.Background(), "app.example.org")
+	assert.Error(t, err)
+}
+
+func TestNewDNSZoneResolverPerMode(t *testing.T) {
+	assert.IsType(t, &CloudflareZoneResolver{}, NewDNSZoneResolver(Config{Challenge: domain.ACMEChallengeCloudflareDNS01, Token: "token"}))
+	assert.IsType(t, &SOAZoneResolver{}, NewDNSZoneResolver(Config{Challenge: domain.ACMEChallengeExecDNS01, DNSResolvers: []string{"1.1.1.1:53"}}))
+	assert.Nil(t, NewDNSZoneResolver(Config{Challenge: domain.ACMEChallengeHTTP01}))
+}
+
+func writeHookScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "dns-hook.sh")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755))
+	return path
+}
+
+func TestExecDNSProviderRunsHookWithPresentAndCleanup(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "calls.log")
+	hook := writeHookScript(t, `echo "$1 $2 $3" >> `+logPath)
+	provider := NewExecDNSProvider(ExecConfig{Command: hook}, time.Minute, time.Second)
+
+	require.NoError(t, provider.Present("app.example.com", "token", "key-auth"))
+	require.NoError(t, provider.CleanUp("app.example.com", "token", "key-auth"))
+
+	data, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	info := dns01.GetChallengeInfo("app.example.com", "key-auth")
+	assert.Equal(t, []string{
+		"present " + info.EffectiveFQDN + " " + info.Value,
+		"cleanup " + info.EffectiveFQDN + " " + info.Value,
+	}, strings.Split(strings.TrimSpace(string(data)), "\n"))
+
+	timeout, interval := provider.Timeout()
+	assert.Equal(t, time.Minute, timeout)
+	assert.Equal(t, time.Second, interval)
+}
+
+func TestExecDNSProviderReportsHookFailure(t *testing.T) {
+	hook := writeHookScript(t, `echo "zone not managed" >&2; exit 3`)
+	provider := NewExecDNSProvider(ExecConfig{Command: hook}, time.Minute, time.Second)
+
+	err := provider.Present("app.example.com", "token", "key-auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exec dns hook present")
+	assert.Contains(t, err.Error(), "zone not managed")
+}
+
+func TestExecDNSProviderTimesOut(t *testing.T) {
+	hook := writeHookScript(t, `exec sleep 5`)
+	provider := NewExecDNSProvider(ExecConfig{Command: hook, Timeout: 50 * time.Millisecond}, time.Minute, time.Second)
+
+	err := provider.Present("app.example.com", "token", "key-auth")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out")
+}
+
+func TestNewIssuerValidatesDNSProviderConfig(t *testing.T) {
+	base := Config{
+		Email:                 "test@example.com",
+		Store:                 outmocks.NewMockCertificateStore(t),
+		DNSResolvers:          []string{"1.1.1.1:53"},
+		DNSPropagationTimeout: 5 * time.Minute,
+		DNSPollingInterval:    5 * time.Second,
+	}
+
+	cfg := base
+	cfg.Challenge = domain.ACMEChallengeRFC2136DNS01
+	_, err := NewIssuer(cfg)
+	assert.ErrorIs(t, err, domain.ErrDNSProviderConfigInvalid)
+
+	cfg.RFC2136 = RFC2136Config{Nameserver: "192.0.2.53:53", TSIGKey: testTSIGKey}
+	_, err = NewIssuer(cfg)
+	assert.ErrorIs(t, err, domain.ErrTSIGSecretMissing)
+
+	cfg.RFC2136.TSIGSecret = testTSIGSecret
+	_, err = NewIssuer(cfg)
+	require.NoError(t, err)
+
+	cfg = base
+	cfg.Challenge = domain.ACMEChallengeExecDNS01
+	_, err = NewIssuer(cfg)
+	assert.ErrorIs(t, err, domain.ErrDNSProviderConfigInvalid)
+
+	cfg.Exec.Command = "/usr/local/bin/dns-hook"
+	_, err = NewIssuer(cfg)
+	require.NoError(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bnema/zerowrap"
 	"github.com/go-acme/lego/v4/acme"
 	"github.com/go-acme/lego/v4/certcrypto"
 	"github.com/go-acme/lego/v4/certificate"
@@ -34,9 +36,15 @@ type Config struct {
 	// Challenge is the ACME challenge mode to use.
 	Challenge domain.ACMEChallengeMode
 
-	// Token is the Cloudflare API token (required for DNS-01 challenge).
+	// Token is the Cloudflare API token (required for Cloudflare DNS-01 challenge).
 	Token string
 
+	// RFC2136 configures the rfc2136-dns-01 provider.
+	RFC2136 RFC2136Config
+
+	// Exec configures the exec-dns-01 provider.
+	Exec ExecConfig
+
 	// DNSResolvers are recursive resolvers used for DNS-01 propagation checks.
 	DNSResolvers []string
 
@@ -52,16 +60,42 @@ type Config struct {
 	// HTTPChallengeSink is used for HTTP-01 challenge token storage.
 	HTTPChallengeSink out.HTTPChallengeSink
 
-	// CADirectoryURL is the ACME directory URL. If empty, letsencrypt production is used.
-	CADirectoryURL string
+	// TLSALPNChallengeSink is used for TLS-ALPN-01 challenge certificate storage.
+	TLSALPNChallengeSink out.TLSALPNChallengeSink
+
+	// CAs lists the ACME certificate authorities in order of preference.
+	// Obtain and Renew move on to the next CA when one is rate limiting or
+	// unavailable. If empty, letsencrypt production is used.
+	CAs []CAConfig
 
 	// HTTPClient is an optional HTTP client for the ACME client. If nil, a default is used.
 	HTTPClient *http.Client
+
+	// Log receives CA fallback warnings. The zero value discards them.
+	Log zerowrap.Logger
+}
+
+// CAConfig describes one ACME certificate authority.
+type CAConfig struct {
+	// DirectoryURL is the ACME directory URL. If empty, letsencrypt production is used.
+	DirectoryURL string
+
+	// EABKeyID is the External Account Binding key identifier issued by the CA.
+	EABKeyID string
+
+	// EABHMAC is the base64url-encoded External Account Binding HMAC key.
+	EABHMAC string
 }
 
 // Issuer implements out.PublicCertificateIssuer using the lego ACME client.
 type Issuer struct {
-	cfg          Config
+	cfg Config
+	cas []*caClient
+}
+
+// caClient is the lazily initialized lego client and account for one CA.
+type caClient struct {
+	ca           CAConfig
 	mu           sync.Mutex
 	client       *lego.Client
 	user         *AccountUser
@@ -78,9 +112,11 @@ func NewIssuer(cfg Config) (*Issuer, error) {
 	if cfg.Store == nil {
 		return nil, fmt.Errorf("acmelego: %w", domain.ErrCertificateStoreRequired)
 	}
-	if cfg.CADirectoryURL != "" && !strings.HasPrefix(cfg.CADirectoryURL, "https://") {
-		return nil, fmt.Errorf("acmelego: CADirectoryURL must use HTTPS, got %q", cfg.CADirectoryURL)
+	cas, err := normalizeCAs(cfg.CAs)
+	if err != nil {
+		return nil, err
 	}
+	cfg.CAs = cas
 	if cfg.Challenge == "" {
 		cfg.Challenge = domain.ACMEChallengeHTTP01
 	}
@@ -89,9 +125,13 @@ func NewIssuer(cfg Config) (*Issuer, error) {
 		if cfg.HTTPChallengeSink == nil {
 			return nil, fmt.Errorf("acmelego: %w", domain.ErrHTTPChallengeSinkRequired)
 		}
-	case domain.ACMEChallengeCloudflareDNS01:
-		if cfg.Token == "" {
-			return nil, fmt.Errorf("acmelego: %w", domain.ErrCloudflareTokenMissing)
+	case domain.ACMEChallengeTLSALPN01:
+		if cfg.TLSALPNChallengeSink == nil {
+			return nil, fmt.Errorf("acmelego: %w", domain.ErrTLSALPNChallengeSinkRequired)
+		}
+	case domain.ACMEChallengeCloudflareDNS01, domain.ACMEChallengeRFC2136DNS01, domain.ACMEChallengeExecDNS01:
+		if err := validateDNSProviderConfig(cfg); err != nil {
+			return nil, err
 		}
 		resolvers, err := normalizeDNSResolvers(cfg.DNSResolvers)
 		if err != nil {
@@ -111,7 +151,42 @@ func NewIssuer(cfg Config) (*Issuer, error) {
 		return nil, fmt.Errorf("acmelego: %w: %s", domain.ErrACMEChallengeInvalid, cfg.Challenge)
 	}
 
-	return &Issuer{cfg: cfg}, nil
+	issuer := &Issuer{cfg: cfg}
+	for _, ca := range cfg.CAs {
+		issuer.cas = append(issuer.cas, &caClient{ca: ca})
+	}
+	return issuer, nil
+}
+
+// normalizeCAs defaults an empty list to letsencrypt production and checks
+// each CA for an HTTPS directory, complete EAB credentials and duplicates.
+func normalizeCAs(cas []CAConfig) ([]CAConfig, error) {
+	if len(cas) == 0 {
+		cas = []CAConfig{{}}
+	}
+
+	normalized := make([]CAConfig, len(cas))
+	seen := make(map[string]bool, len(cas))
+	for i, ca := range cas {
+		ca.DirectoryURL = strings.TrimSpace(ca.DirectoryURL)
+		ca.EABKeyID = strings.TrimSpace(ca.EABKeyID)
+		ca.EABHMAC = strings.TrimSpace(ca.EABHMAC)
+		if ca.DirectoryURL == "" {
+			ca.DirectoryURL = domain.DefaultACMEDirectoryURL
+		}
+		if !strings.HasPrefix(ca.DirectoryURL, "https://") {
+			return nil, fmt.Errorf("acmelego: %w: directory URL must use HTTPS, got %q", domain.ErrACMECAConfigInvalid, ca.DirectoryURL)
+		}
+		if (ca.EABKeyID == "") != (ca.EABHMAC == "") {
+			return nil, fmt.Errorf("acmelego: %w: %s: EAB key ID and HMAC must be set together", domain.ErrACMECAConfigInvalid, ca.DirectoryURL)
+		}
+		if seen[ca.DirectoryURL] {
+			return nil, fmt.Errorf("acmelego: %w: duplicate directory URL %q", domain.ErrACMECAConfigInvalid, ca.DirectoryURL)
+		}
+		seen[ca.DirectoryURL] = true
+		normalized[i] = ca
+	}
+	return normalized, nil
 }
 
 func normalizeDNSResolvers(resolvers []string) ([]string, error) {
@@ -148,16 +223,20 @@ var _ out.PublicCertificateIssuer = (*Issuer)(nil)
 // Obtain obtains a new certificate for the given order. The ACME client is
 // initialized lazily on the first call.
 func (i *Issuer) Obtain(ctx context.Context, order out.CertificateOrder) (*out.StoredCertificate, error) {
-	if err := i.ensureClient(ctx); err != nil {
-		return nil, err
-	}
-
-	resource, err := i.client.Certificate.Obtain(certificate.ObtainRequest{
-		Domains: order.Names,
-		Bundle:  true,
+	var resource *certificate.Resource
+	err := i.withFallback(ctx, func(client *lego.Client) error {
+		var err error
+		resource, err = client.Certificate.Obtain(certificate.ObtainRequest{
+			Domains: order.Names,
+			Bundle:  true,
+		})
+		if err != nil {
+			return fmt.Errorf("obtain certificate: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("obtain certificate: %w", err)
+		return nil, err
 	}
 
 	return resourceToStored(order, resource)
@@ -166,10 +245,6 @@ func (i *Issuer) Obtain(ctx context.Context, order out.CertificateOrder) (*out.S
 // Renew renews an existing certificate. The ACME client is initialized lazily
 // on the first call. Bundle and MustStaple are set to true/false respectively.
 func (i *Issuer) Renew(ctx context.Context, cert out.StoredCertificate) (*out.StoredCertificate, error) {
-	if err := i.ensureClient(ctx); err != nil {
-		return nil, err
-	}
-
 	domainName := ""
 	if len(cert.Names) > 0 {
 		domainName = cert.Names[0]
@@ -187,11 +262,19 @@ func (i *Issuer) Renew(ctx context.Context, cert out.StoredCertificate) (*out.St
 		legoCert.Certificate = cert.FullchainPEM
 	}
 
-	renewed, err := i.client.Certificate.RenewWithOptions(legoCert, &certificate.RenewOptions{
-		Bundle: true,
+	var renewed *certificate.Resource
+	err := i.withFallback(ctx, func(client *lego.Client) error {
+		var err error
+		renewed, err = client.Certificate.RenewWithOptions(legoCert, &certificate.RenewOptions{
+			Bundle: true,
+		})
+		if err != nil {
+			return fmt.Errorf("renew certificate: %w", err)
+		}
+		return nil
 	})
 	if err != nil {
-		return nil, fmt.Errorf("renew certificate: %w", err)
+		return nil, err
 	}
 
 	// Preserve the original order info
@@ -204,53 +287,98 @@ func (i *Issuer) Renew(ctx context.Context, cert out.StoredCertificate) (*out.St
 	return resourceToStored(order, renewed)
 }
 
-// ensureClient initializes the lego client and ACME account if not yet done.
-func (i *Issuer) ensureClient(ctx context.Context) error {
+// withFallback runs op against each CA in order until one succeeds. It moves
+// on to the next CA only when the current one cannot be reached, fails to
+// set up an account, is rate limiting or answers with a server error.
+func (i *Issuer) withFallback(ctx context.Context, op func(*lego.Client) error) error {
+	var errs []error
+	for idx, ca := range i.cas {
+		client, err := i.ensureClient(ctx, ca)
+		next := err != nil
+		if err == nil {
+			err = op(client)
+			if err == nil {
+				return nil
+			}
+			next = caUnavailable(err)
+		}
+		if len(i.cas) > 1 {
+			err = fmt.Errorf("ca %s: %w", ca.ca.DirectoryURL, err)
+		}
+		errs = append(errs, err)
+		if !next || ctx.Err() != nil || idx == len(i.cas)-1 {
+			break
+		}
+		i.cfg.Log.Warn().Err(err).
+			Str("ca", ca.ca.DirectoryURL).
+			Str("next_ca", i.cas[idx+1].ca.DirectoryURL).
+			Msg("ACME CA unavailable, trying next CA")
+	}
+	return errors.Join(errs...)
+}
+
+// caUnavailable reports whether err means the CA itself is the problem,
+// rather than the order, so another CA may succeed.
+func caUnavailable(err error) bool {
+	if _, ok := errors.AsType[*acme.RateLimitedError](err); ok {
+		return true
+	}
+	if problem, ok := errors.AsType[*acme.ProblemDetails](err); ok {
+		return problem.HTTPStatus >= http.StatusInternalServerError
+	}
+	_, ok := errors.AsType[net.Error](err)
+	return ok
+}
+
+// ensureClient initializes the lego client and ACME account for a CA if not
+// yet done.
+func (i *Issuer) ensureClient(ctx context.Context, ca *caClient) (*lego.Client, error) {
 	for {
-		i.mu.Lock()
-		if i.client != nil {
-			i.mu.Unlock()
-			return nil
+		ca.mu.Lock()
+		if ca.client != nil {
+			client := ca.client
+			ca.mu.Unlock()
+			return client, nil
 		}
-		if i.initializing {
-			done := i.initDone
-			i.mu.Unlock()
+		if ca.initializing {
+			done := ca.initDone
+			ca.mu.Unlock()
 			select {
 			case <-done:
 				continue
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			}
 		}
-		i.initializing = true
-		i.initDone = make(chan struct{})
-		done := i.initDone
-		i.mu.Unlock()
+		ca.initializing = true
+		ca.initDone = make(chan struct{})
+		done := ca.initDone
+		ca.mu.Unlock()
 
-		user, client, err := i.buildClient(ctx)
+		user, client, err := i.buildClient(ctx, ca.ca)
 
-		i.mu.Lock()
+		ca.mu.Lock()
 		if err == nil {
-			i.user = user
-			i.client = client
+			ca.user = user
+			ca.client = client
 		}
-		i.initializing = false
+		ca.initializing = false
 		close(done)
-		i.initDone = nil
-		i.mu.Unlock()
+		ca.initDone = nil
+		ca.mu.Unlock()
 
-		return err
+		return client, err
 	}
 }
 
-func (i *Issuer) buildClient(ctx context.Context) (*AccountUser, *lego.Client, error) {
+func (i *Issuer) buildClient(ctx context.Context, ca CAConfig) (*AccountUser, *lego.Client, error) {
 	// Load or create ACME account
-	user, err := i.loadOrCreateAccount(ctx)
+	user, err := i.loadOrCreateAccount(ctx, ca)
 	if err != nil {
 		return nil, nil, fmt.Errorf("acme account: %w", err)
 	}
 
-	client, err := lego.NewClient(i.newLegoConfig(user))
+	client, err := lego.NewClient(i.newLegoConfig(ca, user))
 	if err != nil {
 		return nil, nil, fmt.Errorf("create lego client: %w", err)
 	}
@@ -261,12 +389,16 @@ func (i *Issuer) buildClient(ctx context.Context) (*AccountUser, *lego.Client, e
 		if err := client.Challenge.SetHTTP01Provider(NewHTTPProvider(i.cfg.HTTPChallengeSink)); err != nil {
 			return nil, nil, fmt.Errorf("set http-01 provider: %w", err)
 		}
-	case domain.ACMEChallengeCloudflareDNS01:
-		cfProvider, err := cloudflare.NewDNSProviderConfig(newCloudflareDNSProviderConfig(i.cfg))
+	case domain.ACMEChallengeTLSALPN01:
+		if err := client.Challenge.SetTLSALPN01Provider(NewTLSALPNProvider(i.cfg.TLSALPNChallengeSink)); err != nil {
+			return nil, nil, fmt.Errorf("set tls-alpn-01 provider: %w", err)
+		}
+	case domain.ACMEChallengeCloudflareDNS01, domain.ACMEChallengeRFC2136DNS01, domain.ACMEChallengeExecDNS01:
+		provider, err := newDNSProvider(i.cfg)
 		if err != nil {
-			return nil, nil, fmt.Errorf("create cloudflare dns provider: %w", err)
+			return nil, nil, err
 		}
-		if err := client.Challenge.SetDNS01Provider(cfProvider, dns01.AddRecursiveNameservers(i.cfg.DNSResolvers)); err != nil {
+		if err := client.Challenge.SetDNS01Provider(provider, dns01.AddRecursiveNameservers(i.cfg.DNSResolvers)); err != nil {
 			return nil, nil, fmt.Errorf("set dns-01 provider: %w", err)
 		}
 	}
@@ -274,10 +406,11 @@ func (i *Issuer) buildClient(ctx context.Context) (*AccountUser, *lego.Client, e
 	return user, client, nil
 }
 
-// loadOrCreateAccount loads an existing ACME account from the store or creates
-// a new one. If creating, it registers the account with the ACME server.
-func (i *Issuer) loadOrCreateAccount(ctx context.Context) (*AccountUser, error) {
-	stored, err := i.cfg.Store.LoadAccount(ctx)
+// loadOrCreateAccount loads the ACME account for a CA from the store or
+// creates a new one. If creating, it registers the account with the ACME
+// server, binding it to the CA's external account when EAB is configured.
+func (i *Issuer) loadOrCreateAccount(ctx context.Context, ca CAConfig) (*AccountUser, error) {
+	stored, err := i.cfg.Store.LoadAccount(ctx, ca.DirectoryURL)
 	if err != nil {
 		return nil, fmt.Errorf("load account: %w", err)
 	}
@@ -295,14 +428,23 @@ func (i *Issuer) loadOrCreateAccount(ctx context.Context) (*AccountUser, error)
 	user := NewAccountUser(i.cfg.Email, privateKey, nil)
 
 	// We need a temporary client to register the account
-	tmpClient, err := lego.NewClient(i.newLegoConfig(user))
+	tmpClient, err := lego.NewClient(i.newLegoConfig(ca, user))
 	if err != nil {
 		return nil, fmt.Errorf("create temporary lego client: %w", err)
 	}
 
-	reg, err := tmpClient.Registration.Register(registration.RegisterOptions{
-		TermsOfServiceAgreed: true,
-	})
+	var reg *registration.Resource
+	if ca.EABKeyID != "" {
+		reg, err = tmpClient.Registration.RegisterWithExternalAccountBinding(registration.RegisterEABOptions{
+			TermsOfServiceAgreed: true,
+			Kid:                  ca.EABKeyID,
+			HmacEncoded:          ca.EABHMAC,
+		})
+	} else {
+		reg, err = tmpClient.Registration.Register(registration.RegisterOptions{
+			TermsOfServiceAgreed: true,
+		})
+	}
 	if err != nil {
 		return nil, fmt.Errorf("register acme account: %w", err)
 	}
@@ -318,6 +460,7 @@ func (i *Issuer) loadOrCreateAccount(ctx context.Context) (*AccountUser, error)
 	privateKeyPEM := certcrypto.PEMEncode(privateKey)
 
 	if err := i.cfg.Store.SaveAccount(ctx, out.ACMEAccount{
+		DirectoryURL:    ca.DirectoryURL,
 		Email:           i.cfg.Email,
 		PrivateKeyPEM:   privateKeyPEM,
 		RegistrationURI: reg.URI,
@@ -351,13 +494,12 @@ func restoreAccount(stored *out.ACMEAccount) (*AccountUser, error) {
 	return NewAccountUser(stored.Email, privateKey, reg), nil
 }
 
-// newLegoConfig creates a lego.Config with common settings from the Issuer config.
-func (i *Issuer) newLegoConfig(user *AccountUser) *lego.Config {
+// newLegoConfig creates a lego.Config for a CA with common settings from the
+// Issuer config.
+func (i *Issuer) newLegoConfig(ca CAConfig, user *AccountUser) *lego.Config {
 	legoCfg := lego.NewConfig(user)
 	legoCfg.Certificate.KeyType = certcrypto.EC256 // ECDSA P-256
-	if i.cfg.CADirectoryURL != "" {
-		legoCfg.CADirURL = i.cfg.CADirectoryURL
-	}
+	legoCfg.CADirURL = ca.DirectoryURL
 	if i.cfg.HTTPClient != nil {
 		legoCfg.HTTPClient = i.cfg.HTTPClient
 	}
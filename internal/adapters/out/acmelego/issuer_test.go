@@ -48,10 +48,24 @@ func TestNewIssuerValidatesHTTPChallengeSink(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrHTTPChallengeSinkRequired)
 }
 
+func TestNewIssuerValidatesTLSALPNChallengeSink(t *testing.T) {
+	cfg := Config{
+		Email:     "test@example.com",
+		Challenge: domain.ACMEChallengeTLSALPN01,
+		Store:     outmocks.NewMockCertificateStore(t),
+	}
+	_, err := NewIssuer(cfg)
+	assert.ErrorIs(t, err, domain.ErrTLSALPNChallengeSinkRequired)
+
+	cfg.TLSALPNChallengeSink = outmocks.NewMockTLSALPNChallengeSink(t)
+	_, err = NewIssuer(cfg)
+	assert.NoError(t, err)
+}
+
 func TestNewIssuerValidatesChallengeMode(t *testing.T) {
 	_, err := NewIssuer(Config{
 		Email:             "test@example.com",
-		Challenge:         domain.ACMEChallengeMode("tls-alpn-01"),
+		Challenge:         domain.ACMEChallengeMode("dns-02"),
 		Store:             outmocks.NewMockCertificateStore(t),
 		HTTPChallengeSink: outmocks.NewMockHTTPChallengeSink(t),
 	})
@@ -154,9 +168,10 @@ func TestNewIssuerRejectsNonHTTPSURL(t *testing.T) {
 		Challenge:         domain.ACMEChallengeHTTP01,
 		Store:             outmocks.NewMockCertificateStore(t),
 		HTTPChallengeSink: outmocks.NewMockHTTPChallengeSink(t),
-		CADirectoryURL:    "http://acme.test/directory",
+		CAs:               []CAConfig{{DirectoryURL: "http://acme.test/directory"}},
 	})
 	require.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrACMECAConfigInvalid)
 	assert.Contains(t, err.Error(), "must use HTTPS")
 
 	// HTTPS should be accepted
@@ -165,11 +180,36 @@ func TestNewIssuerRejectsNonHTTPSURL(t *testing.T) {
 		Challenge:         domain.ACMEChallengeHTTP01,
 		Store:             outmocks.NewMockCertificateStore(t),
 		HTTPChallengeSink: outmocks.NewMockHTTPChallengeSink(t),
-		CADirectoryURL:    "https://acme.test/directory",
+		CAs:               []CAConfig{{DirectoryURL: "https://acme.test/directory"}},
 	})
 	require.NoError(t, err)
 }
 
+func TestNewIssuerValidatesCAs(t *testing.T) {
+	tests := []struct {
+		name string
+		cas  []CAConfig
+		want string
+	}{
+		{name: "eab kid without hmac", cas: []CAConfig{{DirectoryURL: "https://acme.test/directory", EABKeyID: "kid"}}, want: "set together"},
+		{name: "eab hmac without kid", cas: []CAConfig{{DirectoryURL: "https://acme.test/directory", EABHMAC: "hmac"}}, want: "set together"},
+		{name: "duplicate", cas: []CAConfig{{DirectoryURL: "https://acme.test/directory"}, {DirectoryURL: "https://acme.test/directory"}}, want: "duplicate"},
+		{name: "duplicate default", cas: []CAConfig{{}, {DirectoryURL: domain.DefaultACMEDirectoryURL}}, want: "duplicate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewIssuer(Config{
+				Email:             "test@example.com",
+				Store:             outmocks.NewMockCertificateStore(t),
+				HTTPChallengeSink: outmocks.NewMockHTTPChallengeSink(t),
+				CAs:               tt.cas,
+			})
+			require.ErrorIs(t, err, domain.ErrACMECAConfigInvalid)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
 func TestNewIssuerValidWithDefaults(t *testing.T) {
 	issuer, err := NewIssuer(Config{
 		Email:             "test@example.com",
@@ -179,6 +219,7 @@ func TestNewIssuerValidWithDefaults(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, issuer)
 	assert.Equal(t, domain.ACMEChallengeHTTP01, issuer.cfg.Challenge)
+	assert.Equal(t, []CAConfig{{DirectoryURL: domain.DefaultACMEDirectoryURL}}, issuer.cfg.CAs)
 }
 
 func TestCloudflareDNSProviderConfigUsesDNSSettings(t *testing.T) {
@@ -0,0 +1,139 @@
+package acmelego
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	defaultOCSPTimeout = 30 * time.Second
+	maxOCSPResponse    = 1 << 20 // 1 MiB
+)
+
+// OCSPResponder implements out.OCSPResponder by querying the OCSP responders
+// named in a certificate's Authority Information Access extension.
+type OCSPResponder struct {
+	client *http.Client
+}
+
+// NewOCSPResponder creates an OCSPResponder. If client is nil, a client with
+// a 30 second timeout is used.
+func NewOCSPResponder(client *http.Client) *OCSPResponder {
+	if client == nil {
+		client = &http.Client{Timeout: defaultOCSPTimeout}
+	}
+	return &OCSPResponder{client: client}
+}
+
+// FetchOCSP queries each OCSP responder of cert in turn and returns the first
+// response that is signed for cert by its issuer.
+func (r *OCSPResponder) FetchOCSP(ctx context.Context, cert out.StoredCertificate) (*out.OCSPStaple, error) {
+	leaf, issuer, err := ocspCertificates(cert)
+	if err != nil {
+		return nil, err
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return nil, domain.ErrOCSPNotSupported
+	}
+	request, err := ocsp.CreateRequest(leaf, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("create ocsp request: %w", err)
+	}
+
+	var errs []error
+	for _, server := range leaf.OCSPServer {
+		staple, err := r.query(ctx, server, request, leaf, issuer)
+		if err == nil {
+			return staple, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", server, err))
+	}
+	return nil, fmt.Errorf("fetch ocsp response: %w", errors.Join(errs...))
+}
+
+func (r *OCSPResponder) query(ctx context.Context, server string, request []byte, leaf, issuer *x509.Certificate) (*out.OCSPStaple, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server, bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOCSPResponse+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if len(body) > maxOCSPResponse {
+		return nil, fmt.Errorf("response exceeds %d bytes", maxOCSPResponse)
+	}
+
+	parsed, err := ocsp.ParseResponseForCert(body, leaf, issuer)
+	if err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+	return &out.OCSPStaple{
+		Response:   body,
+		Status:     ocspStatus(parsed.Status),
+		ThisUpdate: parsed.ThisUpdate,
+		NextUpdate: parsed.NextUpdate,
+	}, nil
+}
+
+// ocspCertificates returns the leaf and issuer certificates of cert. The
+// issuer is taken from the parsed chain, falling back to ChainPEM.
+func ocspCertificates(cert out.StoredCertificate) (*x509.Certificate, *x509.Certificate, error) {
+	chain := cert.Certificate.Certificate
+	if len(chain) == 0 {
+		return nil, nil, fmt.Errorf("certificate %s has no parsed chain", cert.ID)
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse leaf certificate: %w", err)
+	}
+	issuerDER := []byte(nil)
+	if len(chain) > 1 {
+		issuerDER = chain[1]
+	} else if block, _ := pem.Decode(cert.ChainPEM); block != nil && block.Type == "CERTIFICATE" {
+		issuerDER = block.Bytes
+	}
+	if issuerDER == nil {
+		return nil, nil, fmt.Errorf("certificate %s has no issuer certificate", cert.ID)
+	}
+	issuer, err := x509.ParseCertificate(issuerDER)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse issuer certificate: %w", err)
+	}
+	return leaf, issuer, nil
+}
+
+func ocspStatus(status int) domain.OCSPStatus {
+	switch status {
+	case ocsp.Good:
+		return domain.OCSPStatusGood
+	case ocsp.Revoked:
+		return domain.OCSPStatusRevoked
+	default:
+		return domain.OCSPStatusUnknown
+	}
+}
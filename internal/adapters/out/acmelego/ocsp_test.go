@@ -0,0 +1,148 @@
+package acmelego
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+type ocspTestCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newOCSPTestCA(t *testing.T) ocspTestCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test issuer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return ocspTestCA{cert: cert, key: key}
+}
+
+func (ca ocspTestCA) issue(t *testing.T, ocspServers ...string) out.StoredCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		DNSNames:     []string{"example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(12 * time.Hour),
+		OCSPServer:   ocspServers,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	return out.StoredCertificate{
+		ID:          "example.com",
+		Certificate: tls.Certificate{Certificate: [][]byte{der, ca.cert.Raw}, PrivateKey: key},
+	}
+}
+
+func (ca ocspTestCA) responder(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, ocsp.Response{
+			Status:       status,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute).Truncate(time.Second),
+			NextUpdate:   time.Now().Add(time.Hour).Truncate(time.Second),
+			RevokedAt:    time.Now().Add(-time.Minute).Truncate(time.Second),
+		}, ca.key)
+		if !assert.NoError(t, err) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(resp)
+	}))
+}
+
+func TestOCSPResponderFetchOCSP(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   domain.OCSPStatus
+	}{
+		{name: "good", status: ocsp.Good, want: domain.OCSPStatusGood},
+		{name: "revoked", status: ocsp.Revoked, want: domain.OCSPStatusRevoked},
+		{name: "unknown", status: ocsp.Unknown, want: domain.OCSPStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ca := newOCSPTestCA(t)
+			server := ca.responder(t, tt.status)
+			defer server.Close()
+
+			staple, err := NewOCSPResponder(server.Client()).FetchOCSP(t.Context(), ca.issue(t, server.URL))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, staple.Status)
+			assert.NotEmpty(t, staple.Response)
+			assert.True(t, staple.NextUpdate.After(staple.ThisUpdate))
+		})
+	}
+}
+
+func TestOCSPResponderTriesNextServer(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	server := ca.responder(t, ocsp.Good)
+	defer server.Close()
+
+	staple, err := NewOCSPResponder(nil).FetchOCSP(t.Context(), ca.issue(t, broken.URL, server.URL))
+	require.NoError(t, err)
+	assert.Equal(t, domain.OCSPStatusGood, staple.Status)
+}
+
+func TestOCSPResponderRejectsForeignSignature(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	server := newOCSPTestCA(t).responder(t, ocsp.Good)
+	defer server.Close()
+
+	_, err := NewOCSPResponder(nil).FetchOCSP(t.Context(), ca.issue(t, server.URL))
+	require.Error(t, err)
+}
+
+func TestOCSPResponderWithoutResponder(t *testing.T) {
+	ca := newOCSPTestCA(t)
+	_, err := NewOCSPResponder(nil).FetchOCSP(t.Context(), ca.issue(t))
+	assert.ErrorIs(t, err, domain.ErrOCSPNotSupported)
+}
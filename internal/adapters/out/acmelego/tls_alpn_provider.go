@@ -0,0 +1,48 @@
+package acmelego
+
+import (
+	"fmt"
+
+	"github.com/go-acme/lego/v4/challenge/tlsalpn01"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+)
+
+// TLSALPNProvider adapts an out.TLSALPNChallengeSink to the lego
+// challenge.Provider interface for TLS-ALPN-01 challenge solving. The
+// challenge certificate is served by the TLS entrypoint, not by lego.
+type TLSALPNProvider struct {
+	sink out.TLSALPNChallengeSink
+}
+
+// NewTLSALPNProvider creates a new TLSALPNProvider.
+func NewTLSALPNProvider(sink out.TLSALPNChallengeSink) *TLSALPNProvider {
+	return &TLSALPNProvider{sink: sink}
+}
+
+// Present builds the acmeIdentifier challenge certificate for the domain and
+// stores it via the sink.
+func (p *TLSALPNProvider) Present(domainName, _token, keyAuth string) error {
+	if p.sink == nil {
+		return fmt.Errorf("tls-alpn-01 sink is nil: cannot Present")
+	}
+	cert, err := tlsalpn01.ChallengeCert(domainName, keyAuth)
+	if err != nil {
+		return fmt.Errorf("create tls-alpn-01 challenge certificate: %w", err)
+	}
+	if err := p.sink.Present(domainName, *cert); err != nil {
+		return fmt.Errorf("present tls-alpn-01 challenge: %w", err)
+	}
+	return nil
+}
+
+// CleanUp removes the challenge certificate for the domain via the sink.
+func (p *TLSALPNProvider) CleanUp(domainName, _token, _keyAuth string) error {
+	if p.sink == nil {
+		return fmt.Errorf("tls-alpn-01 sink is nil: cannot CleanUp")
+	}
+	if err := p.sink.CleanUp(domainName); err != nil {
+		return fmt.Errorf("cleanup tls-alpn-01 challenge: %w", err)
+	}
+	return nil
+}
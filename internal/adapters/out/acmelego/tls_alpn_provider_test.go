@@ -0,0 +1,44 @@
+package acmelego
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+)
+
+func TestTLSALPNProviderStoresAndCleansChallenge(t *testing.T) {
+	var presented tls.Certificate
+	mockSink := outmocks.NewMockTLSALPNChallengeSink(t)
+	mockSink.EXPECT().Present("example.com", mock.Anything).
+		Run(func(_ string, cert tls.Certificate) { presented = cert }).
+		Return(nil)
+	mockSink.EXPECT().CleanUp("example.com").Return(nil)
+
+	provider := NewTLSALPNProvider(mockSink)
+
+	require.NoError(t, provider.Present("example.com", "token", "key-auth"))
+	require.NotEmpty(t, presented.Certificate)
+	leaf, err := x509.ParseCertificate(presented.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"example.com"}, leaf.DNSNames)
+
+	assert.NoError(t, provider.CleanUp("example.com", "token", "key-auth"))
+}
+
+func TestTLSALPNProviderNilSink(t *testing.T) {
+	provider := NewTLSALPNProvider(nil)
+
+	err := provider.Present("example.com", "token", "key-auth")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sink is nil")
+
+	err = provider.CleanUp("example.com", "token", "key-auth")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sink is nil")
+}
@@ -2,7 +2,9 @@ package acmestore
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,11 +20,20 @@ import (
 
 // certMetadata is the JSON structure persisted in each certificate directory.
 type certMetadata struct {
-	ID        string    `json:"id"`
-	Names     []string  `json:"names"`
-	Challenge string    `json:"challenge"`
-	NotAfter  time.Time `json:"not_after"`
-	LastError string    `json:"last_error,omitempty"`
+	ID              string    `json:"id"`
+	Names           []string  `json:"names"`
+	Challenge       string    `json:"challenge"`
+	NotAfter        time.Time `json:"not_after"`
+	LastError       string    `json:"last_error,omitempty"`
+	RenewalFailures int       `json:"renewal_failures,omitempty"`
+	OCSP            *ocspMeta `json:"ocsp,omitempty"`
+}
+
+// ocspMeta describes the cached OCSP response stored next to a certificate.
+type ocspMeta struct {
+	Status     string    `json:"status"`
+	ThisUpdate time.Time `json:"this_update"`
+	NextUpdate time.Time `json:"next_update"`
 }
 
 // storeState is the JSON structure persisted for ACME reconciliation state.
@@ -33,6 +44,7 @@ type storeState struct {
 const (
 	lockFile      = ".lock"
 	accountFile   = "account.json"
+	accountsDir   = "accounts"
 	stateFile     = "state.json"
 	certDir       = "certs"
 	backupSuffix  = ".old"
@@ -42,6 +54,7 @@ const (
 	fullchainFile = "fullchain.pem"
 	privkeyFile   = "privkey.pem"
 	metaFile      = "metadata.json"
+	ocspFile      = "ocsp.der"
 
 	dirMode     os.FileMode = 0700
 	privKeyMode os.FileMode = 0600
@@ -73,36 +86,77 @@ func New(root string) (*Store, error) {
 	return &Store{root: root}, nil
 }
 
-// LoadAccount reads the account from <root>/account.json.
-// It returns nil, nil when the file does not exist.
-func (s *Store) LoadAccount(_ context.Context) (*out.ACMEAccount, error) {
-	path := filepath.Join(s.root, accountFile)
-	data, err := os.ReadFile(path)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return nil, nil
-		}
-		return nil, fmt.Errorf("acmestore: read account: %w", err)
+// LoadAccount reads the account registered with the CA at directoryURL from
+// <root>/accounts/<hash>.json. Accounts written before per-CA storage live in
+// <root>/account.json and belong to the default Let's Encrypt directory.
+// It returns nil, nil when no account exists.
+func (s *Store) LoadAccount(_ context.Context, directoryURL string) (*out.ACMEAccount, error) {
+	directoryURL = normalizeDirectoryURL(directoryURL)
+	acct, err := readAccount(s.accountPath(directoryURL))
+	if err != nil || acct != nil {
+		return acct, err
 	}
-	var acct out.ACMEAccount
-	if err := json.Unmarshal(data, &acct); err != nil {
-		return nil, fmt.Errorf("acmestore: unmarshal account: %w", err)
+	if directoryURL != domain.DefaultACMEDirectoryURL {
+		return nil, nil
 	}
-	return &acct, nil
+	acct, err = readAccount(filepath.Join(s.root, accountFile))
+	if err != nil || acct == nil {
+		return acct, err
+	}
+	if acct.DirectoryURL != "" && normalizeDirectoryURL(acct.DirectoryURL) != directoryURL {
+		return nil, nil
+	}
+	acct.DirectoryURL = directoryURL
+	return acct, nil
 }
 
-// SaveAccount writes the account to <root>/account.json atomically with mode 0600.
+// SaveAccount writes the account to <root>/accounts/<hash>.json atomically
+// with mode 0600.
 func (s *Store) SaveAccount(_ context.Context, account out.ACMEAccount) error {
+	account.DirectoryURL = normalizeDirectoryURL(account.DirectoryURL)
 	data, err := json.MarshalIndent(account, "", "  ")
 	if err != nil {
 		return fmt.Errorf("acmestore: marshal account: %w", err)
 	}
-	if err := writeAtomic(filepath.Join(s.root, accountFile), data, accountMode); err != nil {
+	if err := os.MkdirAll(filepath.Join(s.root, accountsDir), dirMode); err != nil {
+		return fmt.Errorf("acmestore: mkdir accounts: %w", err)
+	}
+	if err := writeAtomic(s.accountPath(account.DirectoryURL), data, accountMode); err != nil {
 		return fmt.Errorf("acmestore: save account: %w", err)
 	}
 	return nil
 }
 
+// accountPath returns the account file for a CA. Directory URLs are hashed
+// so any URL maps to a safe file name.
+func (s *Store) accountPath(directoryURL string) string {
+	sum := sha256.Sum256([]byte(directoryURL))
+	return filepath.Join(s.root, accountsDir, hex.EncodeToString(sum[:16])+".json")
+}
+
+func normalizeDirectoryURL(directoryURL string) string {
+	directoryURL = strings.TrimSpace(directoryURL)
+	if directoryURL == "" {
+		return domain.DefaultACMEDirectoryURL
+	}
+	return directoryURL
+}
+
+func readAccount(path string) (*out.ACMEAccount, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("acmestore: read account: %w", err)
+	}
+	var acct out.ACMEAccount
+	if err := json.Unmarshal(data, &acct); err != nil {
+		return nil, fmt.Errorf("acmestore: unmarshal account: %w", err)
+	}
+	return &acct, nil
+}
+
 // LoadState reads ACME reconciliation state from <root>/state.json.
 // It returns zero state when the file does not exist.
 func (s *Store) LoadState(_ context.Context) (out.CertificateStoreState, error) {
@@ -215,17 +269,42 @@ func (s *Store) loadOne(id string) (*out.StoredCertificate, error) {
 		return nil, fmt.Errorf("acmestore: challenge %s: %w", id, err)
 	}
 
+	staple, err := readOCSP(dir, meta.OCSP)
+	if err != nil {
+		return nil, fmt.Errorf("acmestore: read %s ocsp.der: %w", id, err)
+	}
+
 	return &out.StoredCertificate{
-		ID:            id,
-		Names:         meta.Names,
-		Challenge:     challenge,
-		Certificate:   tlsCert,
-		CertPEM:       certPEM,
-		ChainPEM:      chainPEM,
-		FullchainPEM:  fullchainPEM,
-		PrivateKeyPEM: privKeyPEM,
-		NotAfter:      meta.NotAfter,
-		LastError:     meta.LastError,
+		ID:              id,
+		Names:           meta.Names,
+		Challenge:       challenge,
+		Certificate:     tlsCert,
+		CertPEM:         certPEM,
+		ChainPEM:        chainPEM,
+		FullchainPEM:    fullchainPEM,
+		PrivateKeyPEM:   privKeyPEM,
+		NotAfter:        meta.NotAfter,
+		LastError:       meta.LastError,
+		RenewalFailures: meta.RenewalFailures,
+		OCSP:            staple,
+	}, nil
+}
+
+// readOCSP reads the cached OCSP response described by meta. A missing
+// response file drops the cache rather than failing the load.
+func readOCSP(dir string, meta *ocspMeta) (*out.OCSPStaple, error) {
+	if meta == nil {
+		return nil, nil
+	}
+	response, err := readPEM(filepath.Join(dir, ocspFile))
+	if err != nil || len(response) == 0 {
+		return nil, err
+	}
+	return &out.OCSPStaple{
+		Response:   response,
+		Status:     domain.OCSPStatus(meta.Status),
+		ThisUpdate: meta.ThisUpdate,
+		NextUpdate: meta.NextUpdate,
 	}, nil
 }
 
@@ -286,11 +365,22 @@ func (s *Store) Save(_ context.Context, cert out.StoredCertificate) error {
 
 func writeCertificateFiles(dir string, cert out.StoredCertificate) error {
 	meta := certMetadata{
-		ID:        cert.ID,
-		Names:     cert.Names,
-		Challenge: string(cert.Challenge),
-		NotAfter:  cert.NotAfter,
-		LastError: cert.LastError,
+		ID:              cert.ID,
+		Names:           cert.Names,
+		Challenge:       string(cert.Challenge),
+		NotAfter:        cert.NotAfter,
+		LastError:       cert.LastError,
+		RenewalFailures: cert.RenewalFailures,
+	}
+	if cert.OCSP != nil && len(cert.OCSP.Response) > 0 {
+		meta.OCSP = &ocspMeta{
+			Status:     string(cert.OCSP.Status),
+			ThisUpdate: cert.OCSP.ThisUpdate,
+			NextUpdate: cert.OCSP.NextUpdate,
+		}
+		if err := writeAtomic(filepath.Join(dir, ocspFile), cert.OCSP.Response, pemMode); err != nil {
+			return fmt.Errorf("acmestore: write ocsp.der: %w", err)
+		}
 	}
 
 	if err := writeFileIfNonNil(filepath.Join(dir, certFile), cert.CertPEM, pemMode); err != nil {
@@ -72,14 +72,16 @@ func TestStoreSaveLoadAll(t *testing.T) {
 	fullchainPEM, privKeyPEM := generateTestCertPEM(t)
 
 	cert := out.StoredCertificate{
-		ID:            "dns01-example.com",
-		Names:         []string{"example.com", "*.example.com"},
-		Challenge:     domain.ACMEChallengeCloudflareDNS01,
-		CertPEM:       []byte("dummy-cert"),
-		ChainPEM:      []byte("dummy-chain"),
-		FullchainPEM:  fullchainPEM,
-		PrivateKeyPEM: privKeyPEM,
-		NotAfter:      now,
+		ID:              "dns01-example.com",
+		Names:           []string{"example.com", "*.example.com"},
+		Challenge:       domain.ACMEChallengeCloudflareDNS01,
+		CertPEM:         []byte("dummy-cert"),
+		ChainPEM:        []byte("dummy-chain"),
+		FullchainPEM:    fullchainPEM,
+		PrivateKeyPEM:   privKeyPEM,
+		NotAfter:        now,
+		LastError:       "renew: rate limited",
+		RenewalFailures: 3,
 	}
 
 	err = store.Save(ctx, cert)
@@ -92,10 +94,48 @@ func TestStoreSaveLoadAll(t *testing.T) {
 	assert.Equal(t, "dns01-example.com", certs[0].ID)
 	assert.Equal(t, []string{"example.com", "*.example.com"}, certs[0].Names)
 	assert.Equal(t, privKeyPEM, certs[0].PrivateKeyPEM)
+	assert.Equal(t, "renew: rate limited", certs[0].LastError)
+	assert.Equal(t, 3, certs[0].RenewalFailures)
 	// Verify tls.Certificate is populated from valid PEM
 	assert.NotEmpty(t, certs[0].Certificate.Certificate, "tls.Certificate.Certificate should be populated")
 }
 
+func TestStoreSaveLoadOCSP(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	fullchainPEM, privKeyPEM := generateTestCertPEM(t)
+	thisUpdate := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := out.StoredCertificate{
+		ID:            "example.com",
+		Names:         []string{"example.com"},
+		Challenge:     domain.ACMEChallengeHTTP01,
+		FullchainPEM:  fullchainPEM,
+		PrivateKeyPEM: privKeyPEM,
+		OCSP: &out.OCSPStaple{
+			Response:   []byte{0x30, 0x03, 0x0a, 0x01, 0x00},
+			Status:     domain.OCSPStatusGood,
+			ThisUpdate: thisUpdate,
+			NextUpdate: thisUpdate.Add(7 * 24 * time.Hour),
+		},
+	}
+	require.NoError(t, store.Save(ctx, cert))
+
+	certs, err := store.LoadAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, certs, 1)
+	assert.Equal(t, cert.OCSP, certs[0].OCSP)
+
+	// A renewed certificate comes without a response and drops the old one.
+	cert.OCSP = nil
+	require.NoError(t, store.Save(ctx, cert))
+	certs, err = store.LoadAll(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, certs[0].OCSP)
+	assert.NoFileExists(t, filepath.Join(store.root, certDir, cert.ID, ocspFile))
+}
+
 func TestStorePrivateKeyMode(t *testing.T) {
 	root := t.TempDir()
 	ctx := context.Background()
@@ -220,6 +260,7 @@ func TestStoreSaveLoadAccount(t *testing.T) {
 	require.NoError(t, err)
 
 	acct := out.ACMEAccount{
+		DirectoryURL:    "https://acme.test/directory",
 		Email:           "admin@example.com",
 		PrivateKeyPEM:   []byte("key"),
 		RegistrationURI: "https://acme.test/acct/1",
@@ -229,16 +270,65 @@ func TestStoreSaveLoadAccount(t *testing.T) {
 	err = store.SaveAccount(ctx, acct)
 	require.NoError(t, err)
 
-	loaded, err := store.LoadAccount(ctx)
+	loaded, err := store.LoadAccount(ctx, "https://acme.test/directory")
 	require.NoError(t, err)
 	require.NotNil(t, loaded)
 
+	assert.Equal(t, "https://acme.test/directory", loaded.DirectoryURL)
 	assert.Equal(t, "admin@example.com", loaded.Email)
 	assert.Equal(t, []byte("key"), loaded.PrivateKeyPEM)
 	assert.Equal(t, "https://acme.test/acct/1", loaded.RegistrationURI)
 	assert.Equal(t, []byte(`{"status":"valid"}`), loaded.BodyJSON)
 }
 
+func TestStoreAccountsArePerCA(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, store.SaveAccount(ctx, out.ACMEAccount{Email: "le@example.com", PrivateKeyPEM: []byte("le-key")}))
+	require.NoError(t, store.SaveAccount(ctx, out.ACMEAccount{
+		DirectoryURL:  "https://acme.zerossl.com/v2/DV90",
+		Email:         "zerossl@example.com",
+		PrivateKeyPEM: []byte("zerossl-key"),
+	}))
+
+	loaded, err := store.LoadAccount(ctx, "")
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, domain.DefaultACMEDirectoryURL, loaded.DirectoryURL)
+	assert.Equal(t, []byte("le-key"), loaded.PrivateKeyPEM)
+
+	loaded, err = store.LoadAccount(ctx, "https://acme.zerossl.com/v2/DV90")
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, []byte("zerossl-key"), loaded.PrivateKeyPEM)
+
+	loaded, err = store.LoadAccount(ctx, "https://ca.internal/acme/acme/directory")
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+}
+
+func TestStoreLoadsLegacyAccountForDefaultCA(t *testing.T) {
+	root := t.TempDir()
+	ctx := context.Background()
+	store, err := New(root)
+	require.NoError(t, err)
+
+	legacy := `{"Email":"admin@example.com","PrivateKeyPEM":"a2V5","RegistrationURI":"https://acme-v02.api.letsencrypt.org/acme/acct/1"}`
+	require.NoError(t, os.WriteFile(filepath.Join(root, accountFile), []byte(legacy), 0600))
+
+	loaded, err := store.LoadAccount(ctx, domain.DefaultACMEDirectoryURL)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, domain.DefaultACMEDirectoryURL, loaded.DirectoryURL)
+	assert.Equal(t, []byte("key"), loaded.PrivateKeyPEM)
+
+	loaded, err = store.LoadAccount(ctx, "https://acme.zerossl.com/v2/DV90")
+	require.NoError(t, err)
+	assert.Nil(t, loaded, "the legacy account belongs to the default CA only")
+}
+
 func TestStoreAccountFileMode(t *testing.T) {
 	root := t.TempDir()
 	ctx := context.Background()
@@ -256,7 +346,7 @@ func TestStoreAccountFileMode(t *testing.T) {
 	err = store.SaveAccount(ctx, acct)
 	require.NoError(t, err)
 
-	accountPath := filepath.Join(root, accountFile)
+	accountPath := store.accountPath(domain.DefaultACMEDirectoryURL)
 	info, err := os.Stat(accountPath)
 	require.NoError(t, err)
 
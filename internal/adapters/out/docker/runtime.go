@@ -15,6 +15,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -23,6 +24,7 @@ import (
 
 	"github.com/bnema/zerowrap"
 	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
@@ -158,6 +160,7 @@ func (r *Runtime) CreateContainer(ctx context.Context, config *domain.ContainerC
 		Cmd:          config.Cmd,
 		Labels:       config.Labels,
 		User:         config.User,
+		Tty:          config.Tty,
 	}
 
 	resources := container.Resources{
@@ -178,6 +181,11 @@ func (r *Runtime) CreateContainer(ctx context.Context, config *domain.ContainerC
 	if config.CapAdd != nil {
 		capAdd = strslice.StrSlice(config.CapAdd)
 	}
+	for _, capability := range config.ExtraCapAdd {
+		if !slices.Contains(capAdd, capability) {
+			capAdd = append(capAdd[:len(capAdd):len(capAdd)], capability)
+		}
+	}
 	hostConfig := &container.HostConfig{
 		PortBindings:   portBindings,
 		AutoRemove:     config.AutoRemove,
@@ -188,6 +196,7 @@ func (r *Runtime) CreateContainer(ctx context.Context, config *domain.ContainerC
 		CapDrop:        capDrop,
 		CapAdd:         capAdd,
 		ReadonlyRootfs: config.ReadOnlyRootFS,
+		Tmpfs:          config.Tmpfs,
 	}
 	if config.RestartPolicy != "" {
 		hostConfig.RestartPolicy = container.RestartPolicy{Name: container.RestartPolicyMode(config.RestartPolicy)}
@@ -355,6 +364,25 @@ func (r *Runtime) RestartContainer(ctx context.Context, containerID string) erro
 	return nil
 }
 
+// SignalContainer sends a signal (e.g. "SIGHUP") to a running container.
+func (r *Runtime) SignalContainer(ctx context.Context, containerID, signal string) error {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:    "adapter",
+		zerowrap.FieldAdapter:  "docker",
+		zerowrap.FieldAction:   "SignalContainer",
+		zerowrap.FieldEntityID: containerID,
+		"signal":               signal,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	if err := r.client.ContainerKill(ctx, containerID, signal); err != nil {
+		return log.WrapErr(err, "failed to signal container")
+	}
+
+	log.Info().Msg("container signaled")
+	return nil
+}
+
 // RemoveContainer removes a container.
 func (r *Runtime) RemoveContainer(ctx context.Context, containerID string, force bool) error {
 	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
@@ -535,6 +563,43 @@ func (r *Runtime) GetContainerLogs(ctx context.Context, containerID string, foll
 	return logs, nil
 }
 
+// StreamContainerOutput follows a container's output until it exits,
+// demultiplexing stdout and stderr unless the container has a TTY.
+func (r *Runtime) StreamContainerOutput(ctx context.Context, containerID string, stdout, stderr io.Writer) error {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:    "adapter",
+		zerowrap.FieldAdapter:  "docker",
+		zerowrap.FieldAction:   "StreamContainerOutput",
+		zerowrap.FieldEntityID: containerID,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	info, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return log.WrapErr(err, "failed to inspect container")
+	}
+
+	logs, err := r.client.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return log.WrapErr(err, "failed to attach container output")
+	}
+	defer logs.Close()
+
+	if info.Config != nil && info.Config.Tty {
+		_, err = io.Copy(stdout, logs)
+	} else {
+		_, err = stdcopy.StdCopy(stdout, stderr, logs)
+	}
+	if err != nil {
+		return log.WrapErr(err, "failed to stream container output")
+	}
+	return nil
+}
+
 // PullImage pulls an image.
 func (r *Runtime) PullImage(ctx context.Context, imageRef string) error {
 	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
@@ -865,6 +930,73 @@ func hasConfiguredHealthcheck(cfg *container.Config) bool {
 	return !strings.EqualFold(strings.TrimSpace(cfg.Healthcheck.Test[0]), "NONE")
 }
 
+// ContainerStats reads the current resource counters of a running container.
+func (r *Runtime) ContainerStats(ctx context.Context, containerID string) (*domain.ContainerResourceUsage, error) {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:    "adapter",
+		zerowrap.FieldAdapter:  "docker",
+		zerowrap.FieldAction:   "ContainerStats",
+		zerowrap.FieldEntityID: containerID,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	resp, err := r.client.ContainerStatsOneShot(ctx, containerID)
+	if err != nil {
+		return nil, log.WrapErr(err, "failed to get container stats")
+	}
+	defer resp.Body.Close()
+
+	var stats container.StatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, log.WrapErr(err, "failed to decode container stats")
+	}
+	return resourceUsageFromStats(&stats), nil
+}
+
+// resourceUsageFromStats converts a Docker stats response into cumulative
+// counters, following the docker CLI: the inactive page cache is not counted
+// as used memory, and traffic is summed over all interfaces.
+func resourceUsageFromStats(stats *container.StatsResponse) *domain.ContainerResourceUsage {
+	usage := &domain.ContainerResourceUsage{
+		Time:        stats.Read,
+		CPUUsage:    stats.CPUStats.CPUUsage.TotalUsage,
+		SystemCPU:   stats.CPUStats.SystemUsage,
+		OnlineCPUs:  int(stats.CPUStats.OnlineCPUs),
+		MemoryUsage: stats.MemoryStats.Usage,
+		MemoryLimit: stats.MemoryStats.Limit,
+		PIDs:        stats.PidsStats.Current,
+	}
+	if usage.Time.IsZero() {
+		usage.Time = time.Now()
+	}
+	if usage.OnlineCPUs == 0 {
+		usage.OnlineCPUs = len(stats.CPUStats.CPUUsage.PercpuUsage)
+	}
+
+	// cgroup v1 reports total_inactive_file, cgroup v2 inactive_file.
+	cache, ok := stats.MemoryStats.Stats["total_inactive_file"]
+	if !ok {
+		cache = stats.MemoryStats.Stats["inactive_file"]
+	}
+	if cache < usage.MemoryUsage {
+		usage.MemoryUsage -= cache
+	}
+
+	for _, n := range stats.Networks {
+		usage.NetworkRx += n.RxBytes
+		usage.NetworkTx += n.TxBytes
+	}
+	for _, entry := range stats.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			usage.BlockRead += entry.Value
+		case "write":
+			usage.BlockWrite += entry.Value
+		}
+	}
+	return usage
+}
+
 // GetContainerPort gets the host port for a container's internal port.
 func (r *Runtime) GetContainerPort(ctx context.Context, containerID string, internalPort int) (int, error) {
 	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
@@ -1727,6 +1859,109 @@ func (r *Runtime) ExecInContainer(ctx context.Context, containerID string, cmd [
 	}, nil
 }
 
+// StartExec starts an interactive command in a running container with stdin
+// attached. The session stays open until the command exits or it is closed.
+func (r *Runtime) StartExec(ctx context.Context, containerID string, req domain.ExecRequest) (out.ExecSession, error) {
+	if len(req.Command) == 0 {
+		return nil, fmt.Errorf("command cannot be empty")
+	}
+
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:    "adapter",
+		zerowrap.FieldAdapter:  "docker",
+		zerowrap.FieldAction:   "StartExec",
+		zerowrap.FieldEntityID: containerID,
+		"command":              req.Command[0],
+		"arg_count":            len(req.Command) - 1,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	var consoleSize *[2]uint
+	if req.TTY && req.Rows > 0 && req.Cols > 0 {
+		consoleSize = &[2]uint{req.Rows, req.Cols}
+	}
+
+	execResp, err := r.client.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          req.Command,
+		Tty:          req.TTY,
+		ConsoleSize:  consoleSize,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return nil, log.WrapErr(err, "failed to create exec")
+	}
+
+	attachResp, err := r.client.ContainerExecAttach(ctx, execResp.ID, container.ExecAttachOptions{
+		Tty:         req.TTY,
+		ConsoleSize: consoleSize,
+	})
+	if err != nil {
+		return nil, log.WrapErr(err, "failed to attach to exec")
+	}
+
+	return &execSession{client: r.client, execID: execResp.ID, tty: req.TTY, conn: attachResp}, nil
+}
+
+// execSession bridges an attached exec to the out.ExecSession port.
+type execSession struct {
+	client *client.Client
+	execID string
+	tty    bool
+	conn   types.HijackedResponse
+}
+
+func (s *execSession) Write(p []byte) (int, error) {
+	return s.conn.Conn.Write(p)
+}
+
+func (s *execSession) CloseStdin() error {
+	return s.conn.CloseWrite()
+}
+
+func (s *execSession) Resize(ctx context.Context, rows, cols uint) error {
+	if !s.tty {
+		return nil
+	}
+	return s.client.ContainerExecResize(ctx, s.execID, container.ResizeOptions{Height: rows, Width: cols})
+}
+
+func (s *execSession) Wait(ctx context.Context, stdout, stderr io.Writer) (int, error) {
+	copyErr := make(chan error, 1)
+	go func() {
+		var err error
+		if s.tty {
+			_, err = io.Copy(stdout, s.conn.Reader)
+		} else {
+			_, err = stdcopy.StdCopy(stdout, stderr, s.conn.Reader)
+		}
+		copyErr <- err
+	}()
+
+	select {
+	case <-ctx.Done():
+		s.conn.Close()
+		<-copyErr
+		return 0, ctx.Err()
+	case err := <-copyErr:
+		if err != nil {
+			return 0, fmt.Errorf("failed to read exec output: %w", err)
+		}
+	}
+
+	inspectResp, err := s.client.ContainerExecInspect(ctx, s.execID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect exec result: %w", err)
+	}
+	return inspectResp.ExitCode, nil
+}
+
+func (s *execSession) Close() error {
+	s.conn.Close()
+	return nil
+}
+
 const maxExecOutputSize = 8 << 20 // 8 MiB per stream
 
 type boundedBuffer struct {
@@ -1802,6 +2037,60 @@ func (r *Runtime) CopyFromContainer(ctx context.Context, containerID, srcPath st
 	return reader, nil
 }
 
+// CopyToContainer writes files into an existing directory of a container.
+// Files keep the mode and ownership set on each domain.ContainerFile.
+func (r *Runtime) CopyToContainer(ctx context.Context, containerID, dstDir string, files []domain.ContainerFile) error {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:    "adapter",
+		zerowrap.FieldAdapter:  "docker",
+		zerowrap.FieldAction:   "CopyToContainer",
+		zerowrap.FieldEntityID: containerID,
+		"dst_dir":              dstDir,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	archive, err := buildFilesTar(files)
+	if err != nil {
+		return log.WrapErr(err, "failed to build tar archive")
+	}
+	err = r.client.CopyToContainer(ctx, containerID, dstDir, archive, container.CopyToContainerOptions{CopyUIDGID: true})
+	if err != nil {
+		return log.WrapErr(err, "failed to copy to container")
+	}
+	return nil
+}
+
+// buildFilesTar packs files into a flat tar archive.
+func buildFilesTar(files []domain.ContainerFile) (*bytes.Buffer, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	now := time.Now()
+	for _, file := range files {
+		if file.Name == "" || strings.ContainsAny(file.Name, `/\`) || file.Name == "." || file.Name == ".." {
+			return nil, fmt.Errorf("invalid file name %q", file.Name)
+		}
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     file.Name,
+			Size:     int64(len(file.Data)),
+			Mode:     file.Mode,
+			Uid:      file.UID,
+			Gid:      file.GID,
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(file.Data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
 // extractFileFromTar extracts a single file from a tar archive.
 func extractFileFromTar(reader io.ReadCloser, targetPath string) (io.ReadCloser, error) {
 	tr := tar.NewReader(reader)
@@ -151,3 +151,34 @@ func TestReadExtractedEnvFileRejectsOversizedContent(t *testing.T) {
 	assert.Nil(t, data)
 	assert.Contains(t, err.Error(), "exceeds")
 }
+
+func TestBuildFilesTarKeepsModeAndOwnership(t *testing.T) {
+	buf, err := buildFilesTar([]domain.ContainerFile{
+		{Name: "tls.crt", Data: []byte("cert"), Mode: 0o644, UID: 999, GID: 999},
+		{Name: "tls.key", Data: []byte("key"), Mode: 0o600, UID: 999, GID: 999},
+	})
+	require.NoError(t, err)
+
+	tr := tar.NewReader(buf)
+	header, err := tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "tls.crt", header.Name)
+	assert.Equal(t, int64(0o644), header.Mode)
+	assert.Equal(t, 999, header.Uid)
+	header, err = tr.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "tls.key", header.Name)
+	assert.Equal(t, int64(0o600), header.Mode)
+	data, err := io.ReadAll(tr)
+	require.NoError(t, err)
+	assert.Equal(t, "key", string(data))
+	_, err = tr.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestBuildFilesTarRejectsNestedNames(t *testing.T) {
+	for _, name := range []string{"", "..", "certs/tls.crt", `..\tls.key`} {
+		_, err := buildFilesTar([]domain.ContainerFile{{Name: name, Data: []byte("x")}})
+		assert.Error(t, err, name)
+	}
+}
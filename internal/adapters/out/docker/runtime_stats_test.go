@@ -0,0 +1,50 @@
+package docker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntime_ContainerStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/v1.41/containers/test-container/stats", r.URL.Path)
+		assert.Equal(t, "0", r.URL.Query().Get("stream"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"read":"2026-02-07T12:00:00Z",
+			"cpu_stats":{"cpu_usage":{"total_usage":4000000000},"system_cpu_usage":90000000000,"online_cpus":4},
+			"memory_stats":{"usage":104857600,"limit":536870912,"stats":{"inactive_file":4857600}},
+			"pids_stats":{"current":12},
+			"networks":{"eth0":{"rx_bytes":1000,"tx_bytes":2000},"eth1":{"rx_bytes":500,"tx_bytes":50}},
+			"blkio_stats":{"io_service_bytes_recursive":[
+				{"major":8,"minor":0,"op":"read","value":4096},
+				{"major":8,"minor":0,"op":"write","value":8192},
+				{"major":8,"minor":16,"op":"Read","value":1024}
+			]}
+		}`))
+	}))
+	defer server.Close()
+
+	runtime := newRuntimeForHTTPServer(t, server)
+	usage, err := runtime.ContainerStats(context.Background(), "test-container")
+
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4000000000), usage.CPUUsage)
+	assert.Equal(t, uint64(90000000000), usage.SystemCPU)
+	assert.Equal(t, 4, usage.OnlineCPUs)
+	assert.Equal(t, uint64(100000000), usage.MemoryUsage, "inactive page cache is not counted")
+	assert.Equal(t, uint64(536870912), usage.MemoryLimit)
+	assert.Equal(t, uint64(1500), usage.NetworkRx)
+	assert.Equal(t, uint64(2050), usage.NetworkTx)
+	assert.Equal(t, uint64(5120), usage.BlockRead)
+	assert.Equal(t, uint64(8192), usage.BlockWrite)
+	assert.Equal(t, uint64(12), usage.PIDs)
+	assert.Equal(t, 2026, usage.Time.Year())
+}
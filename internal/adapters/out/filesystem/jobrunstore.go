@@ -0,0 +1,166 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+type jobRunStoreData struct {
+	// Runs maps "<route>/<job>" to the job's runs, newest first.
+	Runs map[string][]domain.JobRun `json:"runs"`
+}
+
+// JobRunStore persists scheduled job runs to a JSON file, keeping the most
+// recent runs of each job.
+type JobRunStore struct {
+	path string
+	keep int
+	mu   sync.Mutex
+}
+
+// NewJobRunStore creates a filesystem-backed job run store that keeps up to
+// keep runs per job (domain.DefaultJobRunHistory when keep <= 0).
+func NewJobRunStore(path string, keep int) *JobRunStore {
+	if keep <= 0 {
+		keep = domain.DefaultJobRunHistory
+	}
+	return &JobRunStore{path: path, keep: keep}
+}
+
+func (s *JobRunStore) SaveRun(_ context.Context, run domain.JobRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Re-read the file on every save: the local CLI and the server may both
+	// record runs.
+	store, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	key := jobRunKey(run.Route, run.Job)
+	runs := store.Runs[key]
+	replaced := false
+	for i := range runs {
+		if runs[i].ID == run.ID {
+			runs[i] = run
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		runs = append([]domain.JobRun{run}, runs...)
+	}
+	if len(runs) > s.keep {
+		runs = runs[:s.keep]
+	}
+	store.Runs[key] = runs
+
+	return s.save(store)
+}
+
+func (s *JobRunStore) ListRuns(_ context.Context, route, job string) ([]domain.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return store.Runs[jobRunKey(route, job)], nil
+}
+
+// InterruptRunningRuns marks every run still recorded as running as
+// interrupted, finished at at, and returns how many it marked. The server
+// calls it when it opens the store, before any job runs: a run left running
+// then was lost to a crash or restart.
+func (s *JobRunStore) InterruptRunningRuns(_ context.Context, at time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	store, err := s.load()
+	if err != nil {
+		return 0, err
+	}
+
+	interrupted := 0
+	for _, runs := range store.Runs {
+		for i := range runs {
+			if runs[i].Status != domain.JobRunRunning {
+				continue
+			}
+			runs[i].Status = domain.JobRunInterrupted
+			runs[i].FinishedAt = at
+			runs[i].Error = "gordon stopped before the run finished"
+			interrupted++
+		}
+	}
+	if interrupted == 0 {
+		return 0, nil
+	}
+	return interrupted, s.save(store)
+}
+
+func jobRunKey(route, job string) string {
+	return route + "/" + job
+}
+
+func (s *JobRunStore) load() (jobRunStoreData, error) {
+	store := jobRunStoreData{Runs: make(map[string][]domain.JobRun)}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return store, nil
+		}
+		return store, err
+	}
+	if err := json.Unmarshal(data, &store); err != nil {
+		return store, err
+	}
+	if store.Runs == nil {
+		store.Runs = make(map[string][]domain.JobRun)
+	}
+	return store, nil
+}
+
+func (s *JobRunStore) save(store jobRunStoreData) error {
+	data, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Atomic write: temp file → rename
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".job-runs-*.json.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return os.Rename(tmpName, s.path)
+}
@@ -0,0 +1,78 @@
+package filesystem
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRunStore_SaveReplacesAndOrdersNewestFirst(t *testing.T) {
+	store := NewJobRunStore(filepath.Join(t.TempDir(), "jobs", "runs.json"), 0)
+	ctx := context.Background()
+	started := time.Now().UTC().Truncate(time.Second)
+
+	first := domain.JobRun{ID: "a", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunRunning, StartedAt: started}
+	second := domain.JobRun{ID: "b", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunRunning, StartedAt: started.Add(time.Minute)}
+	require.NoError(t, store.SaveRun(ctx, first))
+	require.NoError(t, store.SaveRun(ctx, second))
+
+	first.Status = domain.JobRunSucceeded
+	first.FinishedAt = started.Add(30 * time.Second)
+	require.NoError(t, store.SaveRun(ctx, first))
+
+	runs, err := store.ListRuns(ctx, "app.example.com", "cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.JobRun{second, first}, runs)
+
+	other, err := store.ListRuns(ctx, "app.example.com", "report")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestJobRunStore_InterruptRunningRuns(t *testing.T) {
+	store := NewJobRunStore(filepath.Join(t.TempDir(), "runs.json"), 0)
+	ctx := context.Background()
+	started := time.Now().UTC().Truncate(time.Second)
+
+	done := domain.JobRun{ID: "a", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunSucceeded, StartedAt: started, FinishedAt: started.Add(time.Second)}
+	lost := domain.JobRun{ID: "b", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunRunning, StartedAt: started.Add(time.Minute)}
+	require.NoError(t, store.SaveRun(ctx, done))
+	require.NoError(t, store.SaveRun(ctx, lost))
+
+	restarted := started.Add(time.Hour)
+	n, err := store.InterruptRunningRuns(ctx, restarted)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	runs, err := store.ListRuns(ctx, "app.example.com", "cleanup")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, domain.JobRunInterrupted, runs[0].Status)
+	assert.Equal(t, restarted, runs[0].FinishedAt)
+	assert.NotEmpty(t, runs[0].Error)
+	assert.Equal(t, done, runs[1])
+
+	n, err = store.InterruptRunningRuns(ctx, restarted)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestJobRunStore_KeepsMostRecentRuns(t *testing.T) {
+	store := NewJobRunStore(filepath.Join(t.TempDir(), "runs.json"), 2)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, store.SaveRun(ctx, domain.JobRun{ID: id, Route: "app.example.com", Job: "cleanup"}))
+	}
+
+	runs, err := store.ListRuns(ctx, "app.example.com", "cleanup")
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "c", runs[0].ID)
+	assert.Equal(t, "b", runs[1].ID)
+}
@@ -59,6 +59,14 @@ type CA struct {
 	interKey  crypto.Signer
 
 	renewMu sync.Mutex
+
+	// clientMu guards the client revocation list file and the cached
+	// revocation and trust bundle state below.
+	clientMu   sync.Mutex
+	revoked    map[string]struct{}
+	crlModTime time.Time
+	crlErr     error
+	bundles    map[string]clientBundle
 }
 
 // NewCA loads or generates the root and intermediate CA certificates.
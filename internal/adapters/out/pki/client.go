@@ -0,0 +1,307 @@
+package pki
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const clientCRLFile = "clients.crl"
+
+// clientNamePattern restricts client certificate names to characters that
+// are safe in a certificate subject and in the file names the CLI writes.
+var clientNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]{0,63}$`)
+
+// clientBundle is a parsed client trust bundle, reloaded when the file changes.
+type clientBundle struct {
+	modTime time.Time
+	size    int64
+	pool    *x509.CertPool
+}
+
+// IssueClientCertificate mints a client authentication certificate signed by
+// the root CA. Client certificates outlive the short intermediate, so they
+// chain directly to the root; the lifetime is capped at the root expiry.
+func (ca *CA) IssueClientCertificate(name string, ttl time.Duration) (*domain.ClientCertificate, error) {
+	if !clientNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", domain.ErrClientCertificateName, name)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("client certificate ttl must be positive, got %s", ttl)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate client key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiry := now.Add(ttl)
+	if expiry.After(ca.rootCert.NotAfter) {
+		expiry = ca.rootCert.NotAfter.Add(-1 * time.Minute)
+		if !expiry.After(now) {
+			return nil, fmt.Errorf("root CA too close to expiry to issue client certificates")
+		}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   name,
+			Organization: []string{"Gordon"},
+		},
+		NotBefore:   now.Add(-5 * time.Minute),
+		NotAfter:    expiry,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if strings.Contains(name, "@") {
+		template.EmailAddresses = []string{name}
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.rootCert, key.Public(), ca.rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("create client cert: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal client key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	clear(keyDER)
+
+	fingerprint := sha256.Sum256(certDER)
+	ca.log.Info().Str("name", name).Str("serial", serial.Text(16)).Time("expires", expiry).Msg("issued client certificate")
+	return &domain.ClientCertificate{
+		Name:        name,
+		Serial:      serial.Text(16),
+		Fingerprint: hex.EncodeToString(fingerprint[:]),
+		NotAfter:    expiry,
+		CertPEM:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		KeyPEM:      keyPEM,
+	}, nil
+}
+
+// RevokeClientCertificate adds a serial to the client revocation list and
+// re-signs it. Revoking an already revoked serial is a no-op.
+func (ca *CA) RevokeClientCertificate(serial string) error {
+	number, err := parseClientSerial(serial)
+	if err != nil {
+		return err
+	}
+
+	ca.clientMu.Lock()
+	defer ca.clientMu.Unlock()
+
+	current, err := ca.readClientCRL()
+	if err != nil {
+		return err
+	}
+	var entries []x509.RevocationListEntry
+	crlNumber := big.NewInt(0)
+	if current != nil {
+		entries = current.RevokedCertificateEntries
+		crlNumber = current.Number
+		for _, entry := range entries {
+			if entry.SerialNumber.Cmp(number) == 0 {
+				return nil
+			}
+		}
+	}
+	entries = append(entries, x509.RevocationListEntry{SerialNumber: number, RevocationTime: time.Now()})
+
+	if _, err := ca.writeClientCRL(entries, new(big.Int).Add(crlNumber, big.NewInt(1))); err != nil {
+		return err
+	}
+	ca.log.Info().Str("serial", number.Text(16)).Msg("revoked client certificate")
+	return nil
+}
+
+// ClientRevocationList returns the client revocation list in PEM format,
+// creating an empty one on first use.
+func (ca *CA) ClientRevocationList() ([]byte, error) {
+	ca.clientMu.Lock()
+	defer ca.clientMu.Unlock()
+
+	data, err := os.ReadFile(ca.clientCRLPath())
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read client CRL: %w", err)
+	}
+	return ca.writeClientCRL(nil, big.NewInt(1))
+}
+
+// IsClientCertificateRevoked reports whether a root-issued client certificate
+// is on the revocation list. The list is reloaded when the file changes, so
+// revocations made by the CLI apply to a running server. An unreadable list
+// fails closed.
+func (ca *CA) IsClientCertificateRevoked(cert *x509.Certificate) bool {
+	if cert == nil || !bytes.Equal(cert.RawIssuer, ca.rootCert.RawSubject) {
+		return false
+	}
+
+	ca.clientMu.Lock()
+	defer ca.clientMu.Unlock()
+
+	if err := ca.refreshRevokedLocked(); err != nil {
+		return true
+	}
+	_, revoked := ca.revoked[cert.SerialNumber.Text(16)]
+	return revoked
+}
+
+// ClientCAPool returns the trust pool for client certificates. An empty path
+// trusts Gordon's root CA; otherwise the PEM bundle at bundlePath is loaded
+// and cached until the file changes.
+func (ca *CA) ClientCAPool(bundlePath string) (*x509.CertPool, error) {
+	if bundlePath == "" {
+		pool := x509.NewCertPool()
+		pool.AddCert(ca.rootCert)
+		return pool, nil
+	}
+
+	info, err := os.Stat(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("client CA bundle: %w", err)
+	}
+
+	ca.clientMu.Lock()
+	defer ca.clientMu.Unlock()
+
+	if cached, ok := ca.bundles[bundlePath]; ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.pool, nil
+	}
+	data, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("client CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("client CA bundle %s contains no PEM certificates", bundlePath)
+	}
+	if ca.bundles == nil {
+		ca.bundles = make(map[string]clientBundle)
+	}
+	ca.bundles[bundlePath] = clientBundle{modTime: info.ModTime(), size: info.Size(), pool: pool}
+	return pool, nil
+}
+
+func (ca *CA) clientCRLPath() string {
+	return filepath.Join(ca.dataDir, pkiDir, clientCRLFile)
+}
+
+// refreshRevokedLocked reloads the revoked serials when the CRL file changed.
+// The caller must hold clientMu.
+func (ca *CA) refreshRevokedLocked() error {
+	info, err := os.Stat(ca.clientCRLPath())
+	if errors.Is(err, os.ErrNotExist) {
+		ca.revoked = nil
+		ca.crlModTime = time.Time{}
+		ca.crlErr = nil
+		return nil
+	}
+	if err != nil {
+		ca.log.Warn().Err(err).Msg("cannot stat client CRL, rejecting root-issued client certificates")
+		return err
+	}
+	if ca.revoked != nil && info.ModTime().Equal(ca.crlModTime) {
+		return ca.crlErr
+	}
+
+	crl, err := ca.readClientCRL()
+	ca.crlModTime = info.ModTime()
+	ca.crlErr = err
+	ca.revoked = map[string]struct{}{}
+	if err != nil {
+		ca.log.Warn().Err(err).Msg("invalid client CRL, rejecting root-issued client certificates")
+		return err
+	}
+	if crl != nil {
+		for _, entry := range crl.RevokedCertificateEntries {
+			ca.revoked[entry.SerialNumber.Text(16)] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// readClientCRL reads and verifies the client CRL. It returns nil without an
+// error when no certificate has been revoked yet.
+func (ca *CA) readClientCRL() (*x509.RevocationList, error) {
+	data, err := os.ReadFile(ca.clientCRLPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read client CRL: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "X509 CRL" {
+		return nil, fmt.Errorf("failed to decode client CRL PEM")
+	}
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse client CRL: %w", err)
+	}
+	if err := crl.CheckSignatureFrom(ca.rootCert); err != nil {
+		return nil, fmt.Errorf("client CRL not signed by current root: %w", err)
+	}
+	return crl, nil
+}
+
+// writeClientCRL signs and stores a client CRL and drops the cached serials.
+// The list is valid until the root expires; it is re-signed on every
+// revocation. The caller must hold clientMu.
+func (ca *CA) writeClientCRL(entries []x509.RevocationListEntry, number *big.Int) ([]byte, error) {
+	now := time.Now()
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    number,
+		ThisUpdate:                now,
+		NextUpdate:                ca.rootCert.NotAfter,
+		RevokedCertificateEntries: entries,
+	}, ca.rootCert, ca.rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("create client CRL: %w", err)
+	}
+	crlPEM := pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crlDER})
+	if err := writeSecure(ca.clientCRLPath(), crlPEM, 0644); err != nil {
+		return nil, err
+	}
+	ca.revoked = nil
+	return crlPEM, nil
+}
+
+// parseClientSerial parses a hex serial number, accepting the colon
+// separated form printed by openssl.
+func parseClientSerial(serial string) (*big.Int, error) {
+	text := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(serial), ":", ""))
+	text = strings.TrimPrefix(text, "0x")
+	number, ok := new(big.Int).SetString(text, 16)
+	if !ok || number.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", domain.ErrClientCertificateSerial, serial)
+	}
+	return number, nil
+}
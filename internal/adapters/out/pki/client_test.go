@@ -0,0 +1,131 @@
+package pki_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/out/pki"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func parseClientCert(t *testing.T, issued *domain.ClientCertificate) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(issued.CertPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCA_IssueClientCertificate(t *testing.T) {
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	issued, err := ca.IssueClientCertificate("alice@example.com", 24*time.Hour)
+	require.NoError(t, err)
+	cert := parseClientCert(t, issued)
+
+	assert.Equal(t, "alice@example.com", cert.Subject.CommonName)
+	assert.Equal(t, []string{"alice@example.com"}, cert.EmailAddresses)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.Equal(t, cert.SerialNumber.Text(16), issued.Serial)
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), cert.NotAfter, time.Minute)
+	_, err = tls.X509KeyPair(issued.CertPEM, issued.KeyPEM)
+	require.NoError(t, err)
+
+	pool, err := ca.ClientCAPool("")
+	require.NoError(t, err)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+}
+
+func TestCA_IssueClientCertificateRejectsInvalidInput(t *testing.T) {
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	for _, name := range []string{"", "../laptop", "team laptop", "-flag"} {
+		_, err := ca.IssueClientCertificate(name, time.Hour)
+		assert.ErrorIs(t, err, domain.ErrClientCertificateName, name)
+	}
+	_, err = ca.IssueClientCertificate("laptop", 0)
+	assert.Error(t, err)
+}
+
+func TestCA_RevokeClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := pki.NewCA(dir, testLogger())
+	require.NoError(t, err)
+
+	revoked, err := ca.IssueClientCertificate("old-laptop", time.Hour)
+	require.NoError(t, err)
+	kept, err := ca.IssueClientCertificate("new-laptop", time.Hour)
+	require.NoError(t, err)
+	assert.False(t, ca.IsClientCertificateRevoked(parseClientCert(t, revoked)))
+
+	require.NoError(t, ca.RevokeClientCertificate(revoked.Serial))
+	require.NoError(t, ca.RevokeClientCertificate(revoked.Serial), "revoking twice is a no-op")
+	assert.True(t, ca.IsClientCertificateRevoked(parseClientCert(t, revoked)))
+	assert.False(t, ca.IsClientCertificateRevoked(parseClientCert(t, kept)))
+
+	crlPEM, err := ca.ClientRevocationList()
+	require.NoError(t, err)
+	block, _ := pem.Decode(crlPEM)
+	require.NotNil(t, block)
+	assert.Equal(t, "X509 CRL", block.Type)
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	require.NoError(t, err)
+	require.Len(t, crl.RevokedCertificateEntries, 1)
+	assert.Equal(t, revoked.Serial, crl.RevokedCertificateEntries[0].SerialNumber.Text(16))
+
+	// A second CA instance (the CLI) revoking is picked up by the running one.
+	cli, err := pki.NewCA(dir, testLogger())
+	require.NoError(t, err)
+	require.NoError(t, cli.RevokeClientCertificate(kept.Serial))
+	assert.True(t, ca.IsClientCertificateRevoked(parseClientCert(t, kept)))
+
+	assert.ErrorIs(t, ca.RevokeClientCertificate("not-hex"), domain.ErrClientCertificateSerial)
+}
+
+func TestCA_IsClientCertificateRevokedFailsClosedOnCorruptCRL(t *testing.T) {
+	dir := t.TempDir()
+	ca, err := pki.NewCA(dir, testLogger())
+	require.NoError(t, err)
+	issued, err := ca.IssueClientCertificate("laptop", time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pki", "clients.crl"), []byte("garbage"), 0o644))
+	assert.True(t, ca.IsClientCertificateRevoked(parseClientCert(t, issued)))
+}
+
+func TestCA_ClientCAPoolLoadsBundle(t *testing.T) {
+	other, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	bundle := filepath.Join(t.TempDir(), "team-ca.pem")
+	require.NoError(t, os.WriteFile(bundle, other.RootCertificate(), 0o644))
+	pool, err := ca.ClientCAPool(bundle)
+	require.NoError(t, err)
+
+	issued, err := other.IssueClientCertificate("laptop", time.Hour)
+	require.NoError(t, err)
+	_, err = parseClientCert(t, issued).Verify(x509.VerifyOptions{Roots: pool, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(bundle, []byte("not pem"), 0o644))
+	require.NoError(t, os.Chtimes(bundle, time.Now(), time.Now().Add(time.Second)))
+	_, err = ca.ClientCAPool(bundle)
+	assert.ErrorContains(t, err, "no PEM certificates")
+
+	_, err = ca.ClientCAPool(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+}
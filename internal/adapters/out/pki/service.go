@@ -0,0 +1,129 @@
+package pki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// serviceHostnamePattern matches a lowercase DNS name made of RFC 1123
+// labels. Wildcards are not allowed in service certificates.
+var serviceHostnamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+// IssueServiceCertificate mints a server certificate for a workload that
+// terminates TLS itself. Like client certificates it is signed by the root
+// CA so its lifetime is not bound to the short intermediate. The certificate
+// also allows client authentication, for services that connect to each other
+// with mutual TLS. When sans is empty, name is used as the only DNS SAN.
+func (ca *CA) IssueServiceCertificate(name string, sans []string, ttl time.Duration) (*domain.ServiceCertificate, error) {
+	if !clientNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("%w: %q", domain.ErrClientCertificateName, name)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("service certificate ttl must be positive, got %s", ttl)
+	}
+	if len(sans) == 0 {
+		sans = []string{name}
+	}
+	dnsNames, ips, normalized, err := parseServiceSANs(sans)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate service key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiry := now.Add(ttl)
+	if expiry.After(ca.rootCert.NotAfter) {
+		expiry = ca.rootCert.NotAfter.Add(-1 * time.Minute)
+		if !expiry.After(now) {
+			return nil, fmt.Errorf("root CA too close to expiry to issue service certificates")
+		}
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   name,
+			Organization: []string{"Gordon"},
+		},
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+		NotBefore:   now.Add(-5 * time.Minute),
+		NotAfter:    expiry,
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca.rootCert, key.Public(), ca.rootKey)
+	if err != nil {
+		return nil, fmt.Errorf("create service cert: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshal service key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	clear(keyDER)
+
+	ca.log.Info().Str("name", name).Strs("sans", normalized).Str("serial", serial.Text(16)).Time("expires", expiry).Msg("issued service certificate")
+	return &domain.ServiceCertificate{
+		Name:     name,
+		SANs:     normalized,
+		Serial:   serial.Text(16),
+		NotAfter: expiry,
+		CertPEM:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+		KeyPEM:   keyPEM,
+		CAPEM:    append([]byte(nil), ca.rootPEM...),
+	}, nil
+}
+
+// parseServiceSANs splits SANs into DNS names and IP addresses, dropping
+// duplicates. DNS names are lowercased.
+func parseServiceSANs(sans []string) ([]string, []net.IP, []string, error) {
+	var dnsNames []string
+	var ips []net.IP
+	normalized := make([]string, 0, len(sans))
+	seen := make(map[string]struct{}, len(sans))
+	for _, raw := range sans {
+		san := strings.ToLower(strings.TrimSuffix(strings.TrimSpace(raw), "."))
+		if ip := net.ParseIP(san); ip != nil {
+			san = ip.String()
+			if _, ok := seen[san]; !ok {
+				ips = append(ips, ip)
+			}
+		} else {
+			if len(san) > 253 || !serviceHostnamePattern.MatchString(san) {
+				return nil, nil, nil, fmt.Errorf("%w: %q", domain.ErrServiceCertificateSAN, raw)
+			}
+			if _, ok := seen[san]; !ok {
+				dnsNames = append(dnsNames, san)
+			}
+		}
+		if _, ok := seen[san]; ok {
+			continue
+		}
+		seen[san] = struct{}{}
+		normalized = append(normalized, san)
+	}
+	return dnsNames, ips, normalized, nil
+}
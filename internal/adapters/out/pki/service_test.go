@@ -0,0 +1,68 @@
+package pki_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/out/pki"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestCA_IssueServiceCertificate(t *testing.T) {
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	issued, err := ca.IssueServiceCertificate("postgres", []string{"Postgres.Internal", "db", "10.0.0.5", "db"}, 30*24*time.Hour)
+	require.NoError(t, err)
+	block, _ := pem.Decode(issued.CertPEM)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, "postgres", cert.Subject.CommonName)
+	assert.Equal(t, []string{"postgres.internal", "db"}, cert.DNSNames)
+	require.Len(t, cert.IPAddresses, 1)
+	assert.True(t, cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.5")))
+	assert.Equal(t, []string{"postgres.internal", "db", "10.0.0.5"}, issued.SANs)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.Equal(t, cert.SerialNumber.Text(16), issued.Serial)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), issued.NotAfter, time.Minute)
+	assert.Equal(t, ca.RootCertificate(), issued.CAPEM)
+	_, err = tls.X509KeyPair(issued.CertPEM, issued.KeyPEM)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(issued.CAPEM))
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "db"})
+	require.NoError(t, err)
+}
+
+func TestCA_IssueServiceCertificateDefaultsSANToName(t *testing.T) {
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	issued, err := ca.IssueServiceCertificate("redis", nil, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"redis"}, issued.SANs)
+}
+
+func TestCA_IssueServiceCertificateRejectsInvalidInput(t *testing.T) {
+	ca, err := pki.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	_, err = ca.IssueServiceCertificate("../db", nil, time.Hour)
+	assert.ErrorIs(t, err, domain.ErrClientCertificateName)
+	for _, san := range []string{"*.example.com", "db_1", "-db", "a..b", ""} {
+		_, err := ca.IssueServiceCertificate("db", []string{san}, time.Hour)
+		assert.ErrorIs(t, err, domain.ErrServiceCertificateSAN, san)
+	}
+	_, err = ca.IssueServiceCertificate("db", nil, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,283 @@
+package responsecache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	entrySuffix = ".entry"
+	tempPattern = "*.tmp"
+
+	dirMode os.FileMode = 0700
+
+	// maxMetaSize bounds the JSON header read from an entry file.
+	maxMetaSize = 1 << 20
+)
+
+// entryMeta is the JSON header persisted in front of the body of each entry
+// file. The file layout is a 4-byte big-endian header length, the header,
+// then the raw body.
+type entryMeta struct {
+	Key        string              `json:"key"`
+	Domain     string              `json:"domain"`
+	Path       string              `json:"path"`
+	StatusCode int                 `json:"status_code"`
+	Header     map[string][]string `json:"header,omitempty"`
+	Vary       []string            `json:"vary,omitempty"`
+	StoredAt   time.Time           `json:"stored_at"`
+	FreshUntil time.Time           `json:"fresh_until"`
+	StaleUntil time.Time           `json:"stale_until"`
+}
+
+// diskItem indexes one entry file.
+type diskItem struct {
+	key        string
+	domain     string
+	path       string
+	size       int64
+	staleUntil time.Time
+	modTime    time.Time
+}
+
+// diskTier is an LRU of entry files bounded by their total size. The index
+// lives in memory and is rebuilt from the directory on startup.
+type diskTier struct {
+	dir     string
+	maxSize int64
+	size    int64
+	order   *list.List // front is most recently used
+	items   map[string]*list.Element
+}
+
+func openDiskTier(dir string, maxSize int64, now time.Time) (*diskTier, error) {
+	if err := os.MkdirAll(dir, dirMode); err != nil {
+		return nil, fmt.Errorf("responsecache: mkdir %s: %w", dir, err)
+	}
+	d := &diskTier{
+		dir:     dir,
+		maxSize: maxSize,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+	}
+	if err := d.loadIndex(now); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// loadIndex indexes the entry files in dir, oldest first so the most
+// recently written files end up at the front. Expired, corrupt and
+// leftover temporary files are removed.
+func (d *diskTier) loadIndex(now time.Time) error {
+	dirEntries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return fmt.Errorf("responsecache: read %s: %w", d.dir, err)
+	}
+
+	items := make([]*diskItem, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		path := filepath.Join(d.dir, name)
+		if dirEntry.IsDir() {
+			continue
+		}
+		if !strings.HasSuffix(name, entrySuffix) {
+			if matched, _ := filepath.Match(tempPattern, name); matched {
+				_ = os.Remove(path)
+			}
+			continue
+		}
+		item, err := readItem(path)
+		if err != nil || !now.Before(item.staleUntil) {
+			_ = os.Remove(path)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].modTime.Before(items[j].modTime) })
+	for _, item := range items {
+		d.add(item)
+	}
+	return nil
+}
+
+func (d *diskTier) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+entrySuffix)
+}
+
+func (d *diskTier) lookup(key string) (*diskItem, bool) {
+	elem, ok := d.items[key]
+	if !ok {
+		return nil, false
+	}
+	d.order.MoveToFront(elem)
+	return elem.Value.(*diskItem), true
+}
+
+func (d *diskTier) add(item *diskItem) {
+	if elem, ok := d.items[item.key]; ok {
+		d.order.Remove(elem)
+		d.size -= elem.Value.(*diskItem).size
+	}
+	d.items[item.key] = d.order.PushFront(item)
+	d.size += item.size
+	for d.size > d.maxSize {
+		d.removeItem(d.order.Back().Value.(*diskItem))
+	}
+}
+
+// removeItem drops item from the index and deletes its file, unless the key
+// has since been replaced by a newer write.
+func (d *diskTier) removeItem(item *diskItem) {
+	elem, ok := d.items[item.key]
+	if !ok || elem.Value.(*diskItem) != item {
+		return
+	}
+	d.order.Remove(elem)
+	delete(d.items, item.key)
+	d.size -= item.size
+	_ = os.Remove(d.path(item.key))
+}
+
+func (d *diskTier) purge(match func(domain, path string) bool) map[string]struct{} {
+	purged := make(map[string]struct{})
+	for key, elem := range d.items {
+		item := elem.Value.(*diskItem)
+		if match(item.domain, item.path) {
+			d.removeItem(item)
+			purged[key] = struct{}{}
+		}
+	}
+	return purged
+}
+
+// writeEntry atomically writes entry to path and returns the file size.
+func writeEntry(dir, path string, entry *domain.CachedResponse) (int64, error) {
+	meta, err := json.Marshal(entryMeta{
+		Key:        entry.Key,
+		Domain:     entry.Domain,
+		Path:       entry.Path,
+		StatusCode: entry.StatusCode,
+		Header:     entry.Header,
+		Vary:       entry.Vary,
+		StoredAt:   entry.StoredAt,
+		FreshUntil: entry.FreshUntil,
+		StaleUntil: entry.StaleUntil,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("responsecache: encode entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, tempPattern)
+	if err != nil {
+		return 0, fmt.Errorf("responsecache: create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	var header [4]byte
+	binary.BigEndian.PutUint32(header[:], uint32(len(meta)))
+	for _, chunk := range [][]byte{header[:], meta, entry.Body} {
+		if _, err := tmp.Write(chunk); err != nil {
+			tmp.Close()
+			return 0, fmt.Errorf("responsecache: write entry: %w", err)
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("responsecache: close entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("responsecache: rename entry: %w", err)
+	}
+	return int64(len(header) + len(meta) + len(entry.Body)), nil
+}
+
+// readEntry reads a full entry file.
+func readEntry(path string) (*domain.CachedResponse, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	meta, err := readMeta(f)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("responsecache: read body: %w", err)
+	}
+	return &domain.CachedResponse{
+		Key:        meta.Key,
+		Domain:     meta.Domain,
+		Path:       meta.Path,
+		StatusCode: meta.StatusCode,
+		Header:     meta.Header,
+		Body:       body,
+		Vary:       meta.Vary,
+		StoredAt:   meta.StoredAt,
+		FreshUntil: meta.FreshUntil,
+		StaleUntil: meta.StaleUntil,
+	}, nil
+}
+
+// readItem reads only the header of an entry file to index it.
+func readItem(path string) (*diskItem, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := readMeta(f)
+	if err != nil {
+		return nil, err
+	}
+	return &diskItem{
+		key:        meta.Key,
+		domain:     meta.Domain,
+		path:       meta.Path,
+		size:       info.Size(),
+		staleUntil: meta.StaleUntil,
+		modTime:    info.ModTime(),
+	}, nil
+}
+
+func readMeta(r io.Reader) (*entryMeta, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("responsecache: read header: %w", err)
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > maxMetaSize {
+		return nil, fmt.Errorf("responsecache: header too large (%d bytes)", size)
+	}
+	raw := make([]byte, size)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return nil, fmt.Errorf("responsecache: read header: %w", err)
+	}
+	var meta entryMeta
+	if err := json.Unmarshal(raw, &meta); err != nil {
+		return nil, fmt.Errorf("responsecache: decode header: %w", err)
+	}
+	return &meta, nil
+}
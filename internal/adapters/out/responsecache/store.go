@@ -0,0 +1,192 @@
+// Package responsecache implements storage for the proxy edge cache.
+package responsecache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+var _ out.ResponseCacheStore = (*Store)(nil)
+
+// Store implements out.ResponseCacheStore with a size-bounded in-memory LRU
+// in front of an optional size-bounded directory on disk. Entries are written
+// to both tiers; disk hits are promoted back into memory.
+type Store struct {
+	mu     sync.Mutex
+	memory *memoryTier
+	disk   *diskTier // nil when the disk tier is disabled
+	now    func() time.Time
+}
+
+// New creates a Store. A zero maxMemory disables the memory tier, and an
+// empty dir or zero maxDisk disables the disk tier. Entries already on disk
+// are indexed so they survive restarts.
+func New(dir string, maxMemory, maxDisk int64) (*Store, error) {
+	s := &Store{
+		memory: newMemoryTier(maxMemory),
+		now:    time.Now,
+	}
+	if dir != "" && maxDisk > 0 {
+		disk, err := openDiskTier(dir, maxDisk, s.now())
+		if err != nil {
+			return nil, err
+		}
+		s.disk = disk
+	}
+	return s, nil
+}
+
+// Get returns the entry stored under key, if it has not expired.
+func (s *Store) Get(_ context.Context, key string) (*domain.CachedResponse, bool) {
+	now := s.now()
+
+	s.mu.Lock()
+	if entry, ok := s.memory.get(key); ok {
+		if !entry.Expired(now) {
+			s.mu.Unlock()
+			return entry, true
+		}
+		s.memory.remove(key)
+	}
+	if s.disk == nil {
+		s.mu.Unlock()
+		return nil, false
+	}
+	item, ok := s.disk.lookup(key)
+	s.mu.Unlock()
+	if !ok {
+		return nil, false
+	}
+
+	entry, err := readEntry(s.disk.path(key))
+	if err != nil || entry.Key != key || entry.Expired(now) {
+		s.mu.Lock()
+		s.disk.removeItem(item)
+		s.mu.Unlock()
+		return nil, false
+	}
+
+	s.mu.Lock()
+	s.memory.put(entry)
+	s.mu.Unlock()
+	return entry, true
+}
+
+// Put stores entry in every enabled tier, evicting least recently used
+// entries to stay within the size limits.
+func (s *Store) Put(_ context.Context, entry *domain.CachedResponse) error {
+	s.mu.Lock()
+	s.memory.put(entry)
+	s.mu.Unlock()
+
+	if s.disk == nil || entry.Size() > s.disk.maxSize {
+		return nil
+	}
+	size, err := writeEntry(s.disk.dir, s.disk.path(entry.Key), entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.disk.add(&diskItem{
+		key:        entry.Key,
+		domain:     entry.Domain,
+		path:       entry.Path,
+		size:       size,
+		staleUntil: entry.StaleUntil,
+	})
+	s.mu.Unlock()
+	return nil
+}
+
+// Purge removes the entries of domainName, or only those for path when it
+// is not empty, from every tier.
+func (s *Store) Purge(_ context.Context, domainName, path string) (int, error) {
+	match := func(entryDomain, entryPath string) bool {
+		return entryDomain == domainName && (path == "" || entryPath == path)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	purged := s.memory.purge(match)
+	if s.disk != nil {
+		purged = unionKeys(purged, s.disk.purge(match))
+	}
+	return len(purged), nil
+}
+
+func unionKeys(a, b map[string]struct{}) map[string]struct{} {
+	for key := range b {
+		a[key] = struct{}{}
+	}
+	return a
+}
+
+// memoryTier is an LRU of entries bounded by their total size.
+type memoryTier struct {
+	maxSize int64
+	size    int64
+	order   *list.List // front is most recently used
+	items   map[string]*list.Element
+}
+
+func newMemoryTier(maxSize int64) *memoryTier {
+	return &memoryTier{
+		maxSize: maxSize,
+		order:   list.New(),
+		items:   make(map[string]*list.Element),
+	}
+}
+
+func (m *memoryTier) get(key string) (*domain.CachedResponse, bool) {
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*domain.CachedResponse), true
+}
+
+func (m *memoryTier) put(entry *domain.CachedResponse) {
+	m.remove(entry.Key)
+	size := entry.Size()
+	if size > m.maxSize {
+		return
+	}
+	m.items[entry.Key] = m.order.PushFront(entry)
+	m.size += size
+	for m.size > m.maxSize {
+		m.removeElement(m.order.Back())
+	}
+}
+
+func (m *memoryTier) remove(key string) {
+	if elem, ok := m.items[key]; ok {
+		m.removeElement(elem)
+	}
+}
+
+func (m *memoryTier) removeElement(elem *list.Element) {
+	entry := elem.Value.(*domain.CachedResponse)
+	m.order.Remove(elem)
+	delete(m.items, entry.Key)
+	m.size -= entry.Size()
+}
+
+func (m *memoryTier) purge(match func(domain, path string) bool) map[string]struct{} {
+	purged := make(map[string]struct{})
+	for key, elem := range m.items {
+		entry := elem.Value.(*domain.CachedResponse)
+		if match(entry.Domain, entry.Path) {
+			m.removeElement(elem)
+			purged[key] = struct{}{}
+		}
+	}
+	return purged
+}
@@ -0,0 +1,137 @@
+package responsecache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func testEntry(key, domainName, path, body string, now time.Time) *domain.CachedResponse {
+	return &domain.CachedResponse{
+		Key:        key,
+		Domain:     domainName,
+		Path:       path,
+		StatusCode: 200,
+		Header:     map[string][]string{"Content-Type": {"text/plain"}},
+		Body:       []byte(body),
+		StoredAt:   now,
+		FreshUntil: now.Add(time.Minute),
+		StaleUntil: now.Add(2 * time.Minute),
+	}
+}
+
+func TestStore_MemoryEvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	a := testEntry("app.example.com/a", "app.example.com", "/a", strings.Repeat("a", 100), now)
+	b := testEntry("app.example.com/b", "app.example.com", "/b", strings.Repeat("b", 100), now)
+	c := testEntry("app.example.com/c", "app.example.com", "/c", strings.Repeat("c", 100), now)
+
+	store, err := New("", a.Size()+b.Size(), 0)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, a))
+	require.NoError(t, store.Put(ctx, b))
+	_, ok := store.Get(ctx, a.Key) // a becomes most recently used
+	require.True(t, ok)
+	require.NoError(t, store.Put(ctx, c))
+
+	_, ok = store.Get(ctx, b.Key)
+	assert.False(t, ok, "least recently used entry should be evicted")
+	_, ok = store.Get(ctx, a.Key)
+	assert.True(t, ok)
+	_, ok = store.Get(ctx, c.Key)
+	assert.True(t, ok)
+}
+
+func TestStore_DropsExpiredEntries(t *testing.T) {
+	ctx := context.Background()
+	store, err := New("", 1<<20, 0)
+	require.NoError(t, err)
+
+	entry := testEntry("app.example.com/", "app.example.com", "/", "hello", time.Now().Add(-time.Hour))
+	require.NoError(t, store.Put(ctx, entry))
+
+	_, ok := store.Get(ctx, entry.Key)
+	assert.False(t, ok)
+}
+
+func TestStore_DiskSurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "responses")
+	now := time.Now()
+
+	store, err := New(dir, 1<<20, 1<<20)
+	require.NoError(t, err)
+	entry := testEntry("app.example.com/index.html", "app.example.com", "/index.html", "<html></html>", now)
+	entry.Vary = []string{"Accept-Encoding"}
+	require.NoError(t, store.Put(ctx, entry))
+
+	// Leftover temp files from an interrupted write are cleaned up.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "123.tmp"), []byte("partial"), 0600))
+
+	reopened, err := New(dir, 1<<20, 1<<20)
+	require.NoError(t, err)
+	got, ok := reopened.Get(ctx, entry.Key)
+	require.True(t, ok)
+	assert.Equal(t, entry.Body, got.Body)
+	assert.Equal(t, entry.Header, got.Header)
+	assert.Equal(t, entry.Vary, got.Vary)
+	assert.True(t, entry.FreshUntil.Equal(got.FreshUntil))
+
+	_, err = os.Stat(filepath.Join(dir, "123.tmp"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStore_DiskEvictsToSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Now()
+
+	store, err := New(dir, 0, 2000)
+	require.NoError(t, err)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		require.NoError(t, store.Put(ctx, testEntry("app.example.com"+path, "app.example.com", path, strings.Repeat("x", 500), now)))
+	}
+
+	_, ok := store.Get(ctx, "app.example.com/a")
+	assert.False(t, ok)
+	_, ok = store.Get(ctx, "app.example.com/c")
+	assert.True(t, ok)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+entrySuffix))
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestStore_Purge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store, err := New(t.TempDir(), 1<<20, 1<<20)
+	require.NoError(t, err)
+
+	require.NoError(t, store.Put(ctx, testEntry("app.example.com/a", "app.example.com", "/a", "a", now)))
+	require.NoError(t, store.Put(ctx, testEntry("app.example.com/a?v=2", "app.example.com", "/a", "a2", now)))
+	require.NoError(t, store.Put(ctx, testEntry("app.example.com/b", "app.example.com", "/b", "b", now)))
+	require.NoError(t, store.Put(ctx, testEntry("other.example.com/a", "other.example.com", "/a", "o", now)))
+
+	purged, err := store.Purge(ctx, "app.example.com", "/a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, purged)
+	_, ok := store.Get(ctx, "app.example.com/b")
+	assert.True(t, ok)
+
+	purged, err = store.Purge(ctx, "app.example.com", "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	_, ok = store.Get(ctx, "other.example.com/a")
+	assert.True(t, ok)
+}
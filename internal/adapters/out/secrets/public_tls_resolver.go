@@ -14,7 +14,10 @@ import (
 	"github.com/bnema/gordon/internal/domain"
 )
 
-const defaultCloudflareTokenPassName = "gordon/cloudflare/api-token"
+const (
+	defaultCloudflareTokenPassName = "gordon/cloudflare/api-token"
+	defaultTSIGSecretPassName      = "gordon/rfc2136/tsig-secret"
+)
 
 // Package-level disabled logger and pass provider reused across calls.
 var disabledLogger = zerowrap.New(zerowrap.Config{Level: "disabled"})
@@ -26,7 +29,7 @@ type PublicTLSResolverConfig struct {
 	EnvLookup  func(key string) string
 }
 
-// PublicTLSResolver resolves Cloudflare API tokens using pass, file, or env.
+// PublicTLSResolver resolves DNS-01 provider credentials using pass, file, or env.
 type PublicTLSResolver struct {
 	passLookup func(ctx context.Context, name string) (string, error)
 	envLookup  func(key string) string
@@ -53,20 +56,30 @@ func NewPublicTLSResolver(cfg PublicTLSResolverConfig) *PublicTLSResolver {
 // ResolveCloudflareToken resolves the Cloudflare API token.
 // Lookup order: pass, token file env, token env.
 func (r *PublicTLSResolver) ResolveCloudflareToken(ctx context.Context) (out.SecretValue, error) {
+	return r.resolve(ctx, defaultCloudflareTokenPassName, "GORDON_CLOUDFLARE_API_TOKEN_FILE", "GORDON_CLOUDFLARE_API_TOKEN")
+}
+
+// ResolveTSIGSecret resolves the RFC 2136 TSIG secret.
+// Lookup order: pass, secret file env, secret env.
+func (r *PublicTLSResolver) ResolveTSIGSecret(ctx context.Context) (out.SecretValue, error) {
+	return r.resolve(ctx, defaultTSIGSecretPassName, "GORDON_RFC2136_TSIG_SECRET_FILE", "GORDON_RFC2136_TSIG_SECRET")
+}
+
+func (r *PublicTLSResolver) resolve(ctx context.Context, passName, fileEnv, valueEnv string) (out.SecretValue, error) {
 	var passErr error
 	var fileErr error
 
 	// 1. Try pass
-	if token, err := r.passLookup(ctx, defaultCloudflareTokenPassName); err == nil {
-		if trimmed := strings.TrimSpace(token); trimmed != "" {
+	if secret, err := r.passLookup(ctx, passName); err == nil {
+		if trimmed := strings.TrimSpace(secret); trimmed != "" {
 			return out.SecretValue{Value: trimmed, Source: domain.ACMETokenSourcePass}, nil
 		}
 	} else if !errors.Is(err, os.ErrNotExist) {
 		passErr = err
 	}
 
-	// 2. Try token file env
-	if filePath := r.envLookup("GORDON_CLOUDFLARE_API_TOKEN_FILE"); filePath != "" {
+	// 2. Try secret file env
+	if filePath := r.envLookup(fileEnv); filePath != "" {
 		if data, err := readFileWithTimeout(ctx, filePath, 5*time.Second); err == nil {
 			if trimmed := strings.TrimSpace(string(data)); trimmed != "" {
 				return out.SecretValue{Value: trimmed, Source: domain.ACMETokenSourceFile}, nil
@@ -76,14 +89,14 @@ func (r *PublicTLSResolver) ResolveCloudflareToken(ctx context.Context) (out.Sec
 		}
 	}
 
-	// 3. Try token env
-	if token := r.envLookup("GORDON_CLOUDFLARE_API_TOKEN"); token != "" {
-		if trimmed := strings.TrimSpace(token); trimmed != "" {
+	// 3. Try secret env
+	if secret := r.envLookup(valueEnv); secret != "" {
+		if trimmed := strings.TrimSpace(secret); trimmed != "" {
 			return out.SecretValue{Value: trimmed, Source: domain.ACMETokenSourceEnv}, nil
 		}
 	}
 
-	// 4. No token found — return remembered errors if any
+	// 4. No secret found — return remembered errors if any
 	if passErr != nil {
 		return out.SecretValue{Source: domain.ACMETokenSourceNone}, fmt.Errorf("resolve pass token: %w", passErr)
 	}
@@ -96,8 +109,8 @@ func (r *PublicTLSResolver) ResolveCloudflareToken(ctx context.Context) (out.Sec
 // defaultPassLookup executes "pass show <name>" with a 2-second timeout.
 // Returns the raw output with trailing newline. Never logs token values.
 func defaultPassLookup(ctx context.Context, name string) (string, error) {
-	// Only allow the known Cloudflare token pass name.
-	if name != defaultCloudflareTokenPassName {
+	// Only allow the known DNS provider credential pass names.
+	if name != defaultCloudflareTokenPassName && name != defaultTSIGSecretPassName {
 		return "", fmt.Errorf("pass lookup: disallowed name %q", name)
 	}
 
@@ -195,3 +195,26 @@ func TestPublicTLSResolverEmptyPassValueFallback(t *testing.T) {
 
 // Ensure PublicTLSResolver implements out.SecretResolver at compile time.
 var _ out.SecretResolver = (*PublicTLSResolver)(nil)
+
+func TestPublicTLSResolverResolvesTSIGSecret(t *testing.T) {
+	var looked []string
+	env := map[string]string{
+		"GORDON_CLOUDFLARE_API_TOKEN": "cf-token",
+		"GORDON_RFC2136_TSIG_SECRET":  " tsig-secret\n",
+	}
+	resolver := NewPublicTLSResolver(PublicTLSResolverConfig{
+		PassLookup: func(_ context.Context, name string) (string, error) {
+			looked = append(looked, name)
+			return "", os.ErrNotExist
+		},
+		EnvLookup: func(key string) string {
+			return env[key]
+		},
+	})
+
+	sv, err := resolver.ResolveTSIGSecret(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "tsig-secret", sv.Value)
+	assert.Equal(t, domain.ACMETokenSourceEnv, sv.Source)
+	assert.Equal(t, []string{defaultTSIGSecretPassName}, looked)
+}
@@ -1,8 +1,14 @@
 package telemetry
 
 import (
+	"context"
+	"math"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+
+	"github.com/bnema/gordon/internal/domain"
 )
 
 // Metrics holds Gordon-specific OTel metrics instruments.
@@ -15,6 +21,7 @@ type Metrics struct {
 	// Container lifecycle
 	ContainerRestarts   metric.Int64Counter
 	ContainerCrashLoops metric.Int64Counter
+	LivenessFailures    metric.Int64Counter
 	ManagedContainers   metric.Int64UpDownCounter
 
 	// Registry
@@ -24,6 +31,9 @@ type Metrics struct {
 	// Events
 	EventsProcessed metric.Int64Counter
 	EventsDropped   metric.Int64Counter
+
+	// Public TLS
+	TLSCertificateAlerts metric.Int64Counter
 }
 
 // NewMetrics creates and registers all Gordon metric instruments.
@@ -56,6 +66,10 @@ func NewMetrics() (*Metrics, error) {
 		metric.WithDescription("Total crash loop detections")); err != nil {
 		return nil, err
 	}
+	if m.LivenessFailures, err = meter.Int64Counter("gordon.container.liveness_failures",
+		metric.WithDescription("Total liveness probes that reached their failure threshold")); err != nil {
+		return nil, err
+	}
 	if m.ManagedContainers, err = meter.Int64UpDownCounter("gordon.container.managed",
 		metric.WithDescription("Currently managed containers")); err != nil {
 		return nil, err
@@ -77,6 +91,81 @@ func NewMetrics() (*Metrics, error) {
 		metric.WithDescription("Total events dropped")); err != nil {
 		return nil, err
 	}
+	if m.TLSCertificateAlerts, err = meter.Int64Counter("gordon.tls.certificate_alerts",
+		metric.WithDescription("Total public TLS certificate alerts raised")); err != nil {
+		return nil, err
+	}
 
 	return m, nil
 }
+
+// ObserveContainerStats registers gauges reporting the resource usage of
+// managed containers. stats is called on every collection and returns the
+// latest samples.
+func (m *Metrics) ObserveContainerStats(stats func(context.Context) []domain.ContainerStats) error {
+	meter := otel.Meter("gordon")
+
+	cpu, err := meter.Float64ObservableGauge("gordon.container.cpu.utilization",
+		metric.WithDescription("Container CPU usage, 100 is one full core"),
+		metric.WithUnit("%"))
+	if err != nil {
+		return err
+	}
+	memory, err := meter.Int64ObservableGauge("gordon.container.memory.usage",
+		metric.WithDescription("Container memory usage excluding the inactive page cache"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	memoryLimit, err := meter.Int64ObservableGauge("gordon.container.memory.limit",
+		metric.WithDescription("Container memory limit"),
+		metric.WithUnit("By"))
+	if err != nil {
+		return err
+	}
+	network, err := meter.Float64ObservableGauge("gordon.container.network.io.rate",
+		metric.WithDescription("Container network traffic rate"),
+		metric.WithUnit("By/s"))
+	if err != nil {
+		return err
+	}
+	disk, err := meter.Float64ObservableGauge("gordon.container.disk.io.rate",
+		metric.WithDescription("Container block I/O rate"),
+		metric.WithUnit("By/s"))
+	if err != nil {
+		return err
+	}
+	pids, err := meter.Int64ObservableGauge("gordon.container.pids",
+		metric.WithDescription("Processes running in the container"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		for _, st := range stats(ctx) {
+			attrs := []attribute.KeyValue{
+				attribute.String("kind", string(st.Kind)),
+				attribute.String("name", st.Name),
+				attribute.String("domain", st.Owner),
+			}
+			set := metric.WithAttributes(attrs...)
+			o.ObserveFloat64(cpu, st.Latest.CPUPercent, set)
+			o.ObserveInt64(memory, clampInt64(st.Latest.MemoryUsage), set)
+			o.ObserveInt64(memoryLimit, clampInt64(st.Latest.MemoryLimit), set)
+			o.ObserveInt64(pids, clampInt64(st.Latest.PIDs), set)
+			o.ObserveFloat64(network, st.Latest.NetworkRxRate, metric.WithAttributes(append(attrs, attribute.String("direction", "receive"))...))
+			o.ObserveFloat64(network, st.Latest.NetworkTxRate, metric.WithAttributes(append(attrs, attribute.String("direction", "transmit"))...))
+			o.ObserveFloat64(disk, st.Latest.BlockReadRate, metric.WithAttributes(append(attrs, attribute.String("direction", "read"))...))
+			o.ObserveFloat64(disk, st.Latest.BlockWriteRate, metric.WithAttributes(append(attrs, attribute.String("direction", "write"))...))
+		}
+		return nil
+	}, cpu, memory, memoryLimit, network, disk, pids)
+	return err
+}
+
+func clampInt64(v uint64) int64 {
+	if v > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(v)
+}
@@ -1,11 +1,18 @@
 package app
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/bnema/zerowrap"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/out/acmelego"
+	"github.com/bnema/gordon/internal/domain"
 )
 
 func TestLoadConfig_ACMEDefaults(t *testing.T) {
@@ -15,7 +22,68 @@ func TestLoadConfig_ACMEDefaults(t *testing.T) {
 	assert.Equal(t, "", v.GetString("tls.acme.email"))
 	assert.Equal(t, "auto", v.GetString("tls.acme.challenge"))
 	assert.Equal(t, 1, v.GetInt("tls.acme.obtain_batch_size"))
+	assert.Equal(t, domain.DefaultACMEDirectoryURL, v.GetString("tls.acme.directory_url"))
 	assert.Equal(t, []string{"1.1.1.1:53", "8.8.8.8:53"}, v.GetStringSlice("dns.resolvers"))
 	assert.Equal(t, "5m", v.GetString("dns.propagation_timeout"))
 	assert.Equal(t, "5s", v.GetString("dns.polling_interval"))
 }
+
+func TestLoadConfig_ACMECertificateAuthorities(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gordon.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[tls.acme]
+directory_url = "https://acme.zerossl.com/v2/DV90"
+eab_kid = "kid-1"
+eab_hmac = "acme/zerossl-eab"
+
+[[tls.acme.fallback]]
+directory_url = "https://acme-v02.api.letsencrypt.org/directory"
+`), 0600))
+
+	v := viper.New()
+	require.NoError(t, loadConfig(v, path))
+	var cfg Config
+	require.NoError(t, v.Unmarshal(&cfg))
+
+	assert.Equal(t, ACMECAConfig{DirectoryURL: "https://acme.zerossl.com/v2/DV90", EABKid: "kid-1", EABHMAC: "acme/zerossl-eab"}, cfg.TLS.ACME.ACMECAConfig)
+	assert.Equal(t, []ACMECAConfig{{DirectoryURL: "https://acme-v02.api.letsencrypt.org/directory"}}, cfg.TLS.ACME.Fallback)
+}
+
+func TestBuildACMECAs_LoadsEABKeyFromSecretsBackend(t *testing.T) {
+	dataDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dataDir, "secrets", "acme"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "secrets", "acme", "zerossl-eab"), []byte("hmac-key\n"), 0600))
+
+	var cfg Config
+	cfg.Server.DataDir = dataDir
+	cfg.Auth.SecretsBackend = string(domain.SecretsBackendUnsafe)
+	cfg.TLS.ACME.ACMECAConfig = ACMECAConfig{DirectoryURL: "https://acme.zerossl.com/v2/DV90", EABKid: "kid-1", EABHMAC: "acme/zerossl-eab"}
+	cfg.TLS.ACME.Fallback = []ACMECAConfig{{DirectoryURL: domain.DefaultACMEDirectoryURL}}
+
+	cas, err := buildACMECAs(context.Background(), cfg, zerowrap.Default())
+	require.NoError(t, err)
+	assert.Equal(t, []acmelego.CAConfig{
+		{DirectoryURL: "https://acme.zerossl.com/v2/DV90", EABKeyID: "kid-1", EABHMAC: "hmac-key"},
+		{DirectoryURL: domain.DefaultACMEDirectoryURL},
+	}, cas)
+}
+
+func TestBuildACMECAs_RejectsIncompleteEAB(t *testing.T) {
+	var cfg Config
+	cfg.Server.DataDir = t.TempDir()
+	cfg.Auth.SecretsBackend = string(domain.SecretsBackendUnsafe)
+
+	cfg.TLS.ACME.EABKid = "kid-1"
+	_, err := buildACMECAs(context.Background(), cfg, zerowrap.Default())
+	assert.ErrorIs(t, err, domain.ErrACMEEABSecretMissing)
+
+	cfg.TLS.ACME.EABHMAC = "acme/missing"
+	_, err = buildACMECAs(context.Background(), cfg, zerowrap.Default())
+	assert.ErrorIs(t, err, domain.ErrACMEEABSecretMissing)
+
+	cfg.TLS.ACME.ACMECAConfig = ACMECAConfig{}
+	cfg.TLS.ACME.Fallback = []ACMECAConfig{{DirectoryURL: "https://ca.internal/acme/acme/directory", EABHMAC: "acme/step"}}
+	_, err = buildACMECAs(context.Background(), cfg, zerowrap.Default())
+	assert.ErrorIs(t, err, domain.ErrACMECAConfigInvalid)
+	assert.Contains(t, err.Error(), "tls.acme.fallback[0].eab_hmac")
+}
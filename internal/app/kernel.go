@@ -28,6 +28,7 @@ type Kernel struct {
 	logSvc          in.LogService
 	volumeSvc       in.VolumeService
 	publicTLSSvc    in.PublicTLSService
+	jobSvc          in.JobService
 	cleanup         func()
 }
 
@@ -68,6 +69,12 @@ func newKernel(configPath string, initLog kernelLoggerInit) (*Kernel, error) {
 		// Wrap cleanup to stop public TLS service (with its renewal loop)
 		// before the logger is cleaned up.
 		wrappedCleanup := func() {
+			if svc.jobSvc != nil {
+				svc.jobSvc.Stop()
+			}
+			if svc.containerSvc != nil {
+				svc.containerSvc.StopStatsSampler()
+			}
 			if svc.publicTLSSvc != nil {
 				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
@@ -78,7 +85,7 @@ func newKernel(configPath string, initLog kernelLoggerInit) (*Kernel, error) {
 			cleanup()
 		}
 
-		return &Kernel{
+		kernel := &Kernel{
 			authEnabled:     cfg.Auth.Enabled,
 			configSvc:       svc.configSvc,
 			secretSvc:       svc.secretSvc,
@@ -91,7 +98,11 @@ func newKernel(configPath string, initLog kernelLoggerInit) (*Kernel, error) {
 			volumeSvc:       svc.volumeSvc,
 			publicTLSSvc:    svc.publicTLSSvc,
 			cleanup:         wrappedCleanup,
-		}, nil
+		}
+		if svc.jobSvc != nil {
+			kernel.jobSvc = svc.jobSvc
+		}
+		return kernel, nil
 	} else {
 		log.Warn().Err(fullErr).Msg("local kernel running in minimal mode")
 	}
@@ -150,4 +161,6 @@ func (k *Kernel) Volumes() in.VolumeService { return k.volumeSvc }
 
 func (k *Kernel) PublicTLS() in.PublicTLSService { return k.publicTLSSvc }
 
+func (k *Kernel) Jobs() in.JobService { return k.jobSvc }
+
 func (k *Kernel) AuthEnabled() bool { return k != nil && k.authEnabled }
@@ -153,6 +153,35 @@ func TestPublicTLSReadinessRequiresHTTP01ActuallyBoundExternalPort80(t *testing.
 	})
 }
 
+func TestPublicTLSReadinessRequiresTLSALPN01EntrypointOnPort443(t *testing.T) {
+	t.Run("allows smart tcp or tls mux entrypoint on port 443", func(t *testing.T) {
+		for _, protocol := range []domain.EntryPointProtocol{domain.EntryPointProtocolSmartTCP, domain.EntryPointProtocolTLSMux} {
+			cfg := Config{}
+			cfg.TLS.ACME.Challenge = string(domain.ACMEChallengeTLSALPN01)
+			cfg.EntryPoints = map[string]traffic.EntryPointConfig{
+				"public": {Address: ":443", Protocol: protocol},
+			}
+
+			require.NoError(t, validatePublicTLSReadiness(cfg), protocol)
+			assert.Equal(t, 443, effectiveTLSALPN01Port(cfg), protocol)
+		}
+	})
+
+	t.Run("rejects tls entrypoint on another port", func(t *testing.T) {
+		cfg := Config{}
+		cfg.TLS.ACME.Challenge = string(domain.ACMEChallengeTLSALPN01)
+		cfg.EntryPoints = map[string]traffic.EntryPointConfig{
+			traffic.DefaultEdgeEntryPointName: {Address: ":8443", Protocol: domain.EntryPointProtocolSmartTCP},
+		}
+
+		require.ErrorIs(t, validatePublicTLSReadiness(cfg), domain.ErrACMEChallengeInvalid)
+		assert.Equal(t, 0, effectiveTLSALPN01Port(cfg))
+
+		effective := publictls.EffectiveChallenge{ConfiguredMode: domain.ACMEChallengeAuto, Mode: domain.ACMEChallengeTLSALPN01}
+		require.ErrorIs(t, validateEffectivePublicTLSReadiness(cfg, effective), domain.ErrACMEChallengeInvalid)
+	})
+}
+
 func TestPKIInitializesForSmartTCPEvenWithoutLegacyTLSPort(t *testing.T) {
 	si := &serviceInit{
 		ctx: context.Background(),
@@ -39,6 +39,7 @@ import (
 	"github.com/bnema/gordon/internal/adapters/out/logwriter"
 	pkiadapter "github.com/bnema/gordon/internal/adapters/out/pki"
 	"github.com/bnema/gordon/internal/adapters/out/ratelimit"
+	"github.com/bnema/gordon/internal/adapters/out/responsecache"
 	s3storage "github.com/bnema/gordon/internal/adapters/out/s3"
 	"github.com/bnema/gordon/internal/adapters/out/secrets"
 	"github.com/bnema/gordon/internal/adapters/out/telemetry"
@@ -79,6 +80,7 @@ import (
 	cronSvc "github.com/bnema/gordon/internal/usecase/cron"
 	"github.com/bnema/gordon/internal/usecase/health"
 	"github.com/bnema/gordon/internal/usecase/images"
+	jobsSvc "github.com/bnema/gordon/internal/usecase/jobs"
 	"github.com/bnema/gordon/internal/usecase/logs"
 	pkiusecase "github.com/bnema/gordon/internal/usecase/pki"
 	"github.com/bnema/gordon/internal/usecase/proxy"
@@ -182,6 +184,10 @@ type Config struct {
 	Traffic         traffic.Config                      `mapstructure:"traffic"`
 	NetworkServices []traffic.NetworkServiceConfig      `mapstructure:"network_services"`
 	Services        []servicecfg.Config                 `mapstructure:"services"`
+	Jobs            []jobsSvc.Config                    `mapstructure:"jobs"`
+
+	AttachmentReadiness map[string]AttachmentReadinessConfig `mapstructure:"attachment_readiness"`
+	AttachmentTLS       map[string]AttachmentTLSConfig       `mapstructure:"attachment_tls"`
 
 	Backups struct {
 		// Legacy database backup keys. Prefer backups.databases.* for new configs.
@@ -252,6 +258,20 @@ type Config struct {
 			Email           string `mapstructure:"email"`
 			Challenge       string `mapstructure:"challenge"`
 			ObtainBatchSize int    `mapstructure:"obtain_batch_size"`
+			OCSPStapling    bool   `mapstructure:"ocsp_stapling"`
+			ExpiryAlertDays int    `mapstructure:"expiry_alert_days"`         // alert when a certificate expires within this many days
+			RenewalFailures int    `mapstructure:"renewal_failure_threshold"` // alert after this many consecutive failed renewals
+			ACMECAConfig    `mapstructure:",squash"`
+			Fallback        []ACMECAConfig `mapstructure:"fallback"` // CAs tried in order when the primary is unavailable
+			RFC2136         struct {
+				Nameserver    string `mapstructure:"nameserver"`
+				TSIGKey       string `mapstructure:"tsig_key"`
+				TSIGAlgorithm string `mapstructure:"tsig_algorithm"`
+			} `mapstructure:"rfc2136"`
+			Exec struct {
+				Command string `mapstructure:"command"`
+				Timeout string `mapstructure:"timeout"`
+			} `mapstructure:"exec"`
 		} `mapstructure:"acme"`
 	} `mapstructure:"tls"`
 
@@ -260,6 +280,58 @@ type Config struct {
 		PropagationTimeout string   `mapstructure:"propagation_timeout"`
 		PollingInterval    string   `mapstructure:"polling_interval"`
 	} `mapstructure:"dns"`
+
+	Compression struct {
+		Enabled      bool     `mapstructure:"enabled"`
+		Algorithms   []string `mapstructure:"algorithms"`    // Content-Encoding tokens in preference order
+		MinSize      string   `mapstructure:"min_size"`      // e.g., "1KB"
+		ContentTypes []string `mapstructure:"content_types"` // e.g., "text/", "application/json"
+	} `mapstructure:"compression"`
+
+	Cache struct {
+		Enabled       bool   `mapstructure:"enabled"`
+		MemorySize    string `mapstructure:"memory_size"`     // e.g., "64MB"
+		DiskSize      string `mapstructure:"disk_size"`       // e.g., "1GB"; "0B" disables the disk tier
+		MaxObjectSize string `mapstructure:"max_object_size"` // e.g., "10MB"
+	} `mapstructure:"cache"`
+
+	Upstream struct {
+		DialTimeout      string `mapstructure:"dial_timeout"`      // e.g., "10s"
+		HeaderTimeout    string `mapstructure:"header_timeout"`    // e.g., "30s"; "0s" waits forever
+		IdleTimeout      string `mapstructure:"idle_timeout"`      // keep-alive connection lifetime, e.g., "90s"
+		Retries          int    `mapstructure:"retries"`           // retries of idempotent requests on connection errors
+		BreakerThreshold int    `mapstructure:"breaker_threshold"` // consecutive errors that open the circuit (0 = off)
+		BreakerCooldown  string `mapstructure:"breaker_cooldown"`  // e.g., "30s"
+	} `mapstructure:"upstream"`
+}
+
+// ACMECAConfig selects an ACME certificate authority. EABHMAC is a path in the
+// secrets backend, not the key itself.
+type ACMECAConfig struct {
+	DirectoryURL string `mapstructure:"directory_url"`
+	EABKid       string `mapstructure:"eab_kid"`
+	EABHMAC      string `mapstructure:"eab_hmac"`
+}
+
+// AttachmentReadinessConfig declares when the attachment service it is keyed
+// by is ready: "auto", "tcp", "command" or "log".
+type AttachmentReadinessConfig struct {
+	Type     string   `mapstructure:"type"`
+	Port     int      `mapstructure:"port"`     // tcp: container port, defaults to the first exposed port
+	Command  []string `mapstructure:"command"`  // command: ready when it exits with code 0
+	Contains string   `mapstructure:"contains"` // log: text the container output must contain
+	Timeout  string   `mapstructure:"timeout"`  // e.g., "60s"; defaults to deploy.attachment_readiness_timeout
+}
+
+// AttachmentTLSConfig asks Gordon to write a certificate from its internal
+// CA into the attachment service it is keyed by, like tls = true on a
+// [[services]] entry.
+type AttachmentTLSConfig struct {
+	SANs   []string `mapstructure:"sans"`   // Extra DNS names or IP addresses
+	Path   string   `mapstructure:"path"`   // Container directory, default "/run/gordon/tls"
+	TTL    string   `mapstructure:"ttl"`    // e.g., "720h"
+	Owner  string   `mapstructure:"owner"`  // Numeric "uid:gid" owning the files
+	Reload string   `mapstructure:"reload"` // "restart" or a signal such as "SIGHUP"
 }
 
 // services holds all the services used by the application.
@@ -284,7 +356,9 @@ type services struct {
 	logSvc                *logs.Service
 	imageSvc              *images.Service
 	volumeSvc             *volumesSvc.Service
+	jobSvc                *jobsSvc.Service
 	proxySvc              *proxy.Service
+	responseCache         *responsecache.Store
 	standaloneServiceSvc  in.StandaloneServiceService
 	serviceSecretProvider out.SecretProvider
 	authSvc               *auth.Service
@@ -307,6 +381,7 @@ type services struct {
 	trafficManager        *trafficadapter.Manager
 	tlsHTTPEntryPoints    map[string]struct{}
 	smartHTTPEntryPoints  map[string]struct{}
+	http3EntryPoints      map[string]struct{}
 	registryHandler       interface {
 		UpdateBlobLimits(maxBlobChunkSize, maxBlobSize int64)
 	}
@@ -625,9 +700,26 @@ func (si *serviceInit) initPublicTLS() error {
 		Challenge:       si.cfg.TLS.ACME.Challenge,
 		HTTPPort:        effectiveHTTP01Port(si.cfg),
 		TLSPort:         effectivePublicTLSPort(si.cfg),
+		TLSALPNPort:     effectiveTLSALPN01Port(si.cfg),
 		DataDir:         resolveDataDir(si.cfg.Server.DataDir),
 		ObtainBatchSize: si.cfg.TLS.ACME.ObtainBatchSize,
 		DNS:             dnsCfg,
+		RFC2136: publictls.RFC2136Config{
+			Nameserver:    si.cfg.TLS.ACME.RFC2136.Nameserver,
+			TSIGKey:       si.cfg.TLS.ACME.RFC2136.TSIGKey,
+			TSIGAlgorithm: si.cfg.TLS.ACME.RFC2136.TSIGAlgorithm,
+		},
+		Exec:                    publictls.ExecDNSConfig{Command: si.cfg.TLS.ACME.Exec.Command},
+		ExpiryAlertDays:         si.cfg.TLS.ACME.ExpiryAlertDays,
+		RenewalFailureThreshold: si.cfg.TLS.ACME.RenewalFailures,
+	}
+	if err := publicTLSCfg.ValidateDNSProvider(); err != nil {
+		return log.WrapErr(err, "invalid ACME DNS provider configuration")
+	}
+
+	execTimeout, err := parseACMEExecTimeout(si.cfg)
+	if err != nil {
+		return log.WrapErr(err, "invalid ACME exec configuration")
 	}
 
 	tokenResolver := secrets.NewPublicTLSResolver(secrets.PublicTLSResolverConfig{})
@@ -640,41 +732,66 @@ func (si *serviceInit) initPublicTLS() error {
 		return err
 	}
 
+	cas, err := buildACMECAs(ctx, si.cfg, log)
+	if err != nil {
+		return log.WrapErr(err, "invalid ACME certificate authority configuration")
+	}
+
 	store, err := acmestore.New(filepath.Join(resolveDataDir(si.cfg.Server.DataDir), "acme"))
 	if err != nil {
 		return log.WrapErr(err, "create ACME store")
 	}
 
 	challenges := publictls.NewHTTP01Challenges()
+	tlsALPNChallenges := publictls.NewTLSALPN01Challenges()
 
-	var zoneResolver *acmelego.CloudflareZoneResolver
-	if effective.Mode == domain.ACMEChallengeCloudflareDNS01 {
-		zoneResolver = acmelego.NewCloudflareZoneResolver(effective.Token)
-	}
-
-	issuer, err := acmelego.NewIssuer(acmelego.Config{
+	issuerCfg := acmelego.Config{
 		Email:                 si.cfg.TLS.ACME.Email,
 		Challenge:             effective.Mode,
-		Token:                 effective.Token,
 		Store:                 store,
 		HTTPChallengeSink:     challenges,
+		TLSALPNChallengeSink:  tlsALPNChallenges,
 		DNSResolvers:          publicTLSCfg.DNS.Resolvers,
 		DNSPropagationTimeout: publicTLSCfg.DNS.PropagationTimeout,
 		DNSPollingInterval:    publicTLSCfg.DNS.PollingInterval,
-	})
+		CAs:                   cas,
+		Log:                   log,
+	}
+	switch effective.Mode {
+	case domain.ACMEChallengeCloudflareDNS01:
+		issuerCfg.Token = effective.Token
+	case domain.ACMEChallengeRFC2136DNS01:
+		issuerCfg.RFC2136 = acmelego.RFC2136Config{
+			Nameserver:    publicTLSCfg.RFC2136.Nameserver,
+			TSIGKey:       publicTLSCfg.RFC2136.TSIGKey,
+			TSIGSecret:    effective.Token,
+			TSIGAlgorithm: publicTLSCfg.RFC2136.TSIGAlgorithm,
+		}
+	case domain.ACMEChallengeExecDNS01:
+		issuerCfg.Exec = acmelego.ExecConfig{Command: publicTLSCfg.Exec.Command, Timeout: execTimeout}
+	}
+	zoneResolver := acmelego.NewDNSZoneResolver(issuerCfg)
+
+	issuer, err := acmelego.NewIssuer(issuerCfg)
 	if err != nil {
 		return log.WrapErr(err, "create ACME issuer")
 	}
 
-	svc := publictls.NewService(publicTLSCfg, publictls.ServiceDeps{
+	deps := publictls.ServiceDeps{
 		Routes:          si.svc.configSvc,
 		Issuer:          issuer,
 		Store:           store,
 		ZoneResolver:    zoneResolver,
 		Challenges:      challenges,
+		TLSALPN:         tlsALPNChallenges,
 		Effective:       effective,
 		AdditionalHosts: []string{si.cfg.Server.GordonDomain},
-	})
+		Events:          si.svc.eventBus,
+	}
+	if si.cfg.TLS.ACME.OCSPStapling {
+		deps.OCSP = acmelego.NewOCSPResponder(nil)
+	}
+	svc := publictls.NewService(publicTLSCfg, deps)
 
 	if err := svc.Load(ctx); err != nil {
 		log.Warn().Err(err).Msg("failed to load ACME certificates, continuing")
@@ -683,6 +800,7 @@ func (si *serviceInit) initPublicTLS() error {
 	log.Info().
 		Str("email", si.cfg.TLS.ACME.Email).
 		Str("challenge", string(effective.Mode)).
+		Int("cas", len(cas)).
 		Msg("public ACME TLS initialized (runtime start deferred)")
 
 	si.svc.publicTLSSvc = svc
@@ -752,6 +870,9 @@ func (si *serviceInit) initRuntimeAndProxy() error {
 	si.svc.registrySvc = registrySvc.NewService(si.svc.blobStorage, si.svc.manifestStorage, si.svc.eventBus, registryState)
 	si.svc.imageSvc = images.NewService(si.svc.runtime, si.svc.manifestStorage, si.svc.blobStorage, si.log, registryState)
 	si.svc.volumeSvc = volumesSvc.NewService(si.svc.runtime)
+	if si.svc.jobSvc, err = createJobService(si.ctx, si.cfg, si.svc, si.log); err != nil {
+		return err
+	}
 
 	injectTelemetryMetrics(si.cfg, si.svc, si.log)
 
@@ -762,15 +883,94 @@ func (si *serviceInit) initRuntimeAndProxy() error {
 	si.svc.maxBlobChunkSize = proxyCfg.maxBlobChunkSize
 	si.svc.maxBlobSize = proxyCfg.maxBlobSize
 	si.svc.proxySvc = proxy.NewService(si.svc.runtime, si.svc.containerSvc, si.svc.configSvc, proxyCfg.proxyConfig)
-	si.svc.standaloneServiceSvc = servicecfg.NewServiceWithSecretProvider(si.svc.runtime, si.svc.serviceSecretProvider)
+	standaloneServiceSvc := servicecfg.NewServiceWithSecretProvider(si.svc.runtime, si.svc.serviceSecretProvider)
+	if si.svc.caAdapter != nil {
+		// Service certificates renew once a third of their lifetime is left,
+		// so an hourly check is plenty.
+		standaloneServiceSvc.SetCertificateAuthority(si.svc.caAdapter)
+		standaloneServiceSvc.StartCertificateRenewal(si.ctx, time.Hour)
+		si.svc.containerSvc.SetCertificateAuthority(si.svc.caAdapter)
+		si.svc.containerSvc.StartAttachmentCertificateRenewal(si.ctx, time.Hour)
+	}
+	si.svc.standaloneServiceSvc = standaloneServiceSvc
 
 	// Wire synchronous proxy cache invalidation for zero-downtime deployments.
 	// The proxy service implements out.ProxyCacheInvalidator via InvalidateTarget().
 	si.svc.containerSvc.SetProxyCacheInvalidator(si.svc.proxySvc)
 	si.svc.containerSvc.SetProxyDrainWaiter(si.svc.proxySvc)
+
+	// The response cache store is always created so cache can be enabled on
+	// reload; its size limits only apply at startup.
+	if si.svc.responseCache, err = createResponseCacheStore(si.cfg, si.log); err != nil {
+		si.log.Warn().Err(err).Msg("response cache unavailable, proxy responses will not be cached")
+	} else {
+		si.svc.proxySvc.SetResponseCache(si.svc.responseCache)
+	}
 	return nil
 }
 
+// createJobService registers the [[jobs]] of cfg. Their run history lives
+// under the data dir so the local CLI sees the runs of the server.
+func createJobService(ctx context.Context, cfg Config, svc *services, log zerowrap.Logger) (*jobsSvc.Service, error) {
+	store := filesystem.NewJobRunStore(filepath.Join(resolveDataDir(cfg.Server.DataDir), "jobs", "runs.json"), domain.DefaultJobRunHistory)
+	// No job runs yet, so a run still recorded as running did not survive
+	// the last shutdown.
+	if n, err := store.InterruptRunningRuns(ctx, time.Now()); err != nil {
+		log.Warn().Err(err).Msg("failed to mark interrupted job runs")
+	} else if n > 0 {
+		log.Warn().Int("runs", n).Msg("marked job runs left running by the previous server as interrupted")
+	}
+	jobSvc := jobsSvc.NewService(svc.containerSvc, svc.configSvc, store, log)
+	if err := reconcileJobs(ctx, jobSvc, cfg); err != nil {
+		return nil, err
+	}
+	return jobSvc, nil
+}
+
+func reconcileJobs(ctx context.Context, jobSvc *jobsSvc.Service, cfg Config) error {
+	if jobSvc == nil {
+		return nil
+	}
+	jobs, err := jobsSvc.ToDomain(cfg.Jobs)
+	if err != nil {
+		return fmt.Errorf("convert jobs config: %w", err)
+	}
+	if err := jobSvc.Reconcile(ctx, jobs); err != nil {
+		return fmt.Errorf("reconcile jobs: %w", err)
+	}
+	return nil
+}
+
+// createResponseCacheStore opens the proxy response cache under the data dir.
+func createResponseCacheStore(cfg Config, log zerowrap.Logger) (*responsecache.Store, error) {
+	sizes := []struct {
+		key   string
+		value string
+		size  int64
+	}{
+		{"cache.memory_size", cfg.Cache.MemorySize, domain.DefaultResponseCacheMemorySize},
+		{"cache.disk_size", cfg.Cache.DiskSize, domain.DefaultResponseCacheDiskSize},
+	}
+	for i := range sizes {
+		if sizes[i].value == "" {
+			continue
+		}
+		parsedSize, err := bytesize.Parse(sizes[i].value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", sizes[i].key, err)
+		}
+		sizes[i].size = parsedSize
+	}
+
+	dir := filepath.Join(resolveDataDir(cfg.Server.DataDir), "cache", "responses")
+	store, err := responsecache.New(dir, sizes[0].size, sizes[1].size)
+	if err != nil {
+		return nil, err
+	}
+	log.Debug().Str("dir", dir).Int64("memory_size", sizes[0].size).Int64("disk_size", sizes[1].size).Msg("response cache store ready")
+	return store, nil
+}
+
 // initHandlers creates the auth, health, log, preview, and admin handlers.
 func (si *serviceInit) registerReloadCoordinatorHooks() {
 	if si.svc.reloadCoordinator == nil || si.svc.containerSvc == nil {
@@ -802,9 +1002,13 @@ func (si *serviceInit) registerReloadCoordinatorHooks() {
 		}
 		si.svc.tlsHTTPEntryPoints = registerTLSMuxHTTPServers(si.svc.trafficManager, reloadCfg, si.svc.httpsProxyHandler, tlsConfig, si.svc.tlsHTTPEntryPoints)
 		si.svc.smartHTTPEntryPoints = registerSmartTCPHTTPServers(si.svc.trafficManager, reloadCfg, si.svc.httpProxyHandler, si.svc.httpsProxyHandler, tlsConfig, si.svc.smartHTTPEntryPoints)
+		si.svc.http3EntryPoints = registerHTTP3Servers(si.svc.trafficManager, reloadCfg, si.svc.httpsProxyHandler, tlsConfig, si.svc.http3EntryPoints)
 		if err := reconcileStandaloneServices(reloadCtx, si.svc.standaloneServiceSvc, reloadCfg); err != nil {
 			return err
 		}
+		if err := reconcileJobs(reloadCtx, si.svc.jobSvc, reloadCfg); err != nil {
+			return err
+		}
 		si.svc.containerSvc.UpdateConfig(containerCfg)
 		return nil
 	})
@@ -846,6 +1050,12 @@ func (si *serviceInit) initHandlers() {
 		VolumeSvc:       si.svc.volumeSvc,
 		PublicTLSSvc:    si.svc.publicTLSSvc,
 		TrafficSvc:      si.svc.trafficManager,
+		CacheSvc:        si.svc.proxySvc,
+		UpstreamSvc:     si.svc.proxySvc,
+		TaskSvc:         si.svc.containerSvc,
+		ExecSvc:         si.svc.containerSvc,
+		JobSvc:          si.svc.jobSvc,
+		StatsSvc:        si.svc.containerSvc,
 	})
 }
 
@@ -1024,6 +1234,15 @@ func injectTelemetryMetrics(cfg Config, svc *services, log zerowrap.Logger) {
 	svc.containerSvc.SetMetrics(gordonMetrics)
 	svc.registrySvc.SetMetrics(gordonMetrics)
 	svc.eventBus.SetMetrics(gordonMetrics)
+	if err := gordonMetrics.ObserveContainerStats(func(ctx context.Context) []domain.ContainerStats {
+		stats, _ := svc.containerSvc.ListStats(ctx)
+		return stats
+	}); err != nil {
+		log.Warn().Err(err).Msg("failed to register container stats gauges")
+	}
+	if publicTLS, ok := svc.publicTLSSvc.(*publictls.Service); ok {
+		publicTLS.SetMetrics(gordonMetrics)
+	}
 }
 
 func setupInternalRegistryAuth(svc *services, log zerowrap.Logger) error {
@@ -1919,6 +2138,21 @@ func buildProxyConfig(cfg Config, log zerowrap.Logger) (*proxyConfigResult, erro
 	}
 	// 0 means no limit (as documented in proxy.Config)
 
+	compression, err := buildCompressionPolicy(cfg)
+	if err != nil {
+		return nil, log.WrapErr(err, "invalid compression configuration")
+	}
+
+	responseCache, err := buildResponseCachePolicy(cfg)
+	if err != nil {
+		return nil, log.WrapErr(err, "invalid cache configuration")
+	}
+
+	upstream, err := buildUpstreamPolicy(cfg)
+	if err != nil {
+		return nil, log.WrapErr(err, "invalid upstream configuration")
+	}
+
 	registryDomain, _ := resolveRegistryDomains(cfg)
 
 	return &proxyConfigResult{
@@ -1928,12 +2162,159 @@ func buildProxyConfig(cfg Config, log zerowrap.Logger) (*proxyConfigResult, erro
 			MaxBodySize:        maxProxyBodySize,
 			MaxResponseSize:    maxProxyResponseSize,
 			MaxConcurrentConns: maxConcurrentConns,
+			Compression:        compression,
+			ResponseCache:      responseCache,
+			Upstream:           upstream,
 		},
 		maxBlobChunkSize: maxBlobChunkSize,
 		maxBlobSize:      maxBlobSize,
 	}, nil
 }
 
+// buildCompressionPolicy parses the compression config section into the
+// global response compression policy. Routes may override Enabled.
+func buildCompressionPolicy(cfg Config) (domain.CompressionPolicy, error) {
+	policy := domain.CompressionPolicy{
+		Enabled:      cfg.Compression.Enabled,
+		Algorithms:   cfg.Compression.Algorithms,
+		MinSize:      domain.DefaultCompressionMinSize,
+		ContentTypes: cfg.Compression.ContentTypes,
+	}
+	if len(policy.Algorithms) == 0 {
+		policy.Algorithms = domain.DefaultCompressionAlgorithms()
+	}
+	if len(policy.ContentTypes) == 0 {
+		policy.ContentTypes = domain.DefaultCompressionContentTypes()
+	}
+	if err := domain.ValidateCompressionAlgorithms(policy.Algorithms); err != nil {
+		return domain.CompressionPolicy{}, err
+	}
+	if cfg.Compression.MinSize != "" {
+		parsedSize, err := bytesize.Parse(cfg.Compression.MinSize)
+		if err != nil {
+			return domain.CompressionPolicy{}, fmt.Errorf("invalid compression.min_size: %w", err)
+		}
+		policy.MinSize = parsedSize
+	}
+	return policy, nil
+}
+
+// buildResponseCachePolicy parses the cache config section into the global
+// response cache policy. Routes may override Enabled.
+func buildResponseCachePolicy(cfg Config) (domain.ResponseCachePolicy, error) {
+	policy := domain.ResponseCachePolicy{
+		Enabled:       cfg.Cache.Enabled,
+		MaxObjectSize: domain.DefaultResponseCacheMaxObjectSize,
+	}
+	if cfg.Cache.MaxObjectSize != "" {
+		parsedSize, err := bytesize.Parse(cfg.Cache.MaxObjectSize)
+		if err != nil {
+			return domain.ResponseCachePolicy{}, fmt.Errorf("invalid cache.max_object_size: %w", err)
+		}
+		if parsedSize <= 0 {
+			return domain.ResponseCachePolicy{}, fmt.Errorf("cache.max_object_size must be greater than zero")
+		}
+		policy.MaxObjectSize = parsedSize
+	}
+	return policy, nil
+}
+
+// buildUpstreamPolicy parses the upstream config section into the global
+// upstream policy. Routes may override every field.
+func buildUpstreamPolicy(cfg Config) (domain.UpstreamPolicy, error) {
+	policy := domain.DefaultUpstreamPolicy()
+
+	durations := []struct {
+		field  string
+		raw    string
+		target *time.Duration
+		zeroOK bool
+	}{
+		{"upstream.dial_timeout", cfg.Upstream.DialTimeout, &policy.DialTimeout, false},
+		{"upstream.header_timeout", cfg.Upstream.HeaderTimeout, &policy.HeaderTimeout, true},
+		{"upstream.idle_timeout", cfg.Upstream.IdleTimeout, &policy.IdleTimeout, true},
+		{"upstream.breaker_cooldown", cfg.Upstream.BreakerCooldown, &policy.BreakerCooldown, false},
+	}
+	for _, d := range durations {
+		if d.raw == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(d.raw)
+		if err != nil {
+			return domain.UpstreamPolicy{}, fmt.Errorf("invalid %s: %w", d.field, err)
+		}
+		if parsed < 0 || (parsed == 0 && !d.zeroOK) {
+			return domain.UpstreamPolicy{}, fmt.Errorf("%s must be greater than zero", d.field)
+		}
+		*d.target = parsed
+	}
+
+	if cfg.Upstream.Retries < 0 {
+		return domain.UpstreamPolicy{}, fmt.Errorf("upstream.retries must not be negative")
+	}
+	if cfg.Upstream.BreakerThreshold < 0 {
+		return domain.UpstreamPolicy{}, fmt.Errorf("upstream.breaker_threshold must not be negative")
+	}
+	policy.Retries = cfg.Upstream.Retries
+	policy.BreakerThreshold = cfg.Upstream.BreakerThreshold
+	return policy, nil
+}
+
+// buildACMECAs returns the issuer CAs in order: tls.acme first, then each
+// tls.acme.fallback entry. EAB HMAC keys are read from the secrets backend.
+func buildACMECAs(ctx context.Context, cfg Config, log zerowrap.Logger) ([]acmelego.CAConfig, error) {
+	entries := append([]ACMECAConfig{cfg.TLS.ACME.ACMECAConfig}, cfg.TLS.ACME.Fallback...)
+	cas := make([]acmelego.CAConfig, 0, len(entries))
+	for i, entry := range entries {
+		key := "tls.acme"
+		if i > 0 {
+			key = fmt.Sprintf("tls.acme.fallback[%d]", i-1)
+		}
+		ca := acmelego.CAConfig{
+			DirectoryURL: strings.TrimSpace(entry.DirectoryURL),
+			EABKeyID:     strings.TrimSpace(entry.EABKid),
+		}
+		hmacPath := strings.TrimSpace(entry.EABHMAC)
+		switch {
+		case ca.EABKeyID == "" && hmacPath != "":
+			return nil, fmt.Errorf("%w: %s.eab_hmac requires eab_kid", domain.ErrACMECAConfigInvalid, key)
+		case ca.EABKeyID != "" && hmacPath == "":
+			return nil, fmt.Errorf("%w: %s.eab_kid requires eab_hmac", domain.ErrACMEEABSecretMissing, key)
+		case hmacPath != "":
+			backend, err := resolveSecretsBackend(cfg.Auth.SecretsBackend)
+			if err != nil {
+				return nil, err
+			}
+			hmacKey, err := loadSecret(ctx, backend, hmacPath, resolveDataDir(cfg.Server.DataDir), log)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s.eab_hmac: %v", domain.ErrACMEEABSecretMissing, key, err)
+			}
+			ca.EABHMAC = strings.TrimSpace(hmacKey)
+			if ca.EABHMAC == "" {
+				return nil, fmt.Errorf("%w: %s.eab_hmac is empty", domain.ErrACMEEABSecretMissing, key)
+			}
+		}
+		cas = append(cas, ca)
+	}
+	return cas, nil
+}
+
+// parseACMEExecTimeout parses tls.acme.exec.timeout; zero selects the
+// provider default.
+func parseACMEExecTimeout(cfg Config) (time.Duration, error) {
+	if cfg.TLS.ACME.Exec.Timeout == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(cfg.TLS.ACME.Exec.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("invalid tls.acme.exec.timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("invalid tls.acme.exec.timeout: must be positive")
+	}
+	return timeout, nil
+}
+
 // buildDNSConfig parses the raw dns config section into a publictls.DNSConfig.
 func buildDNSConfig(cfg Config) (publictls.DNSConfig, error) {
 	defaults := publictls.DefaultDNSConfig()
@@ -1995,6 +2376,16 @@ func buildContainerServiceConfig(ctx context.Context, v *viper.Viper, cfg Config
 		defaultNanoCPUs = int64(cfg.Containers.CPULimit * 1e9)
 	}
 
+	attachmentReadiness, err := attachmentReadinessToDomain(cfg.AttachmentReadiness)
+	if err != nil {
+		return container.Config{}, err
+	}
+
+	attachmentTLS, err := attachmentTLSToDomain(cfg.AttachmentTLS)
+	if err != nil {
+		return container.Config{}, err
+	}
+
 	attachmentConfig := svc.configSvc.GetAttachmentConfig()
 	registryDomain, legacyRegistryDomains := resolveRegistryDomains(cfg)
 
@@ -2014,6 +2405,8 @@ func buildContainerServiceConfig(ctx context.Context, v *viper.Viper, cfg Config
 		NetworkGroups:              attachmentConfig.NetworkGroups,
 		NetworkInternal:            v.GetBool("network_isolation.internal"),
 		Attachments:                attachmentConfig.Attachments,
+		AttachmentReadiness:        attachmentReadiness,
+		AttachmentTLS:              attachmentTLS,
 		AllowedRegistries:          cfg.Images.AllowedRegistries,
 		RequireImageDigest:         cfg.Images.RequireDigest,
 		SecurityProfile:            cfg.Containers.SecurityProfile,
@@ -2030,6 +2423,17 @@ func buildContainerServiceConfig(ctx context.Context, v *viper.Viper, cfg Config
 		DefaultNanoCPUs:            defaultNanoCPUs,
 		DefaultPidsLimit:           cfg.Containers.PidsLimit,
 		AttachmentReadinessTimeout: v.GetDuration("deploy.attachment_readiness_timeout"),
+		LivenessEnabled:            v.GetBool("deploy.liveness.enabled"),
+		LivenessPath:               v.GetString("deploy.liveness.path"),
+		LivenessInterval:           v.GetDuration("deploy.liveness.interval"),
+		LivenessTimeout:            v.GetDuration("deploy.liveness.timeout"),
+		LivenessFailureThreshold:   v.GetInt("deploy.liveness.failure_threshold"),
+		StatsEnabled:               v.GetBool("containers.stats.enabled"),
+		StatsInterval:              v.GetDuration("containers.stats.interval"),
+		StatsHistory:               v.GetInt("containers.stats.history"),
+	}
+	if err := validateLivenessConfig(containerConfig); err != nil {
+		return container.Config{}, err
 	}
 	if v.IsSet("deploy.drain_delay") {
 		containerConfig.DrainDelayConfigured = true
@@ -2061,6 +2465,93 @@ func buildContainerServiceConfig(ctx context.Context, v *viper.Viper, cfg Config
 	return containerConfig, nil
 }
 
+// validateLivenessConfig rejects liveness settings the monitor cannot honor.
+func validateLivenessConfig(cfg container.Config) error {
+	if !cfg.LivenessEnabled {
+		return nil
+	}
+	if cfg.LivenessPath != "" && !strings.HasPrefix(cfg.LivenessPath, "/") {
+		return fmt.Errorf("deploy.liveness.path must start with / (got %q)", cfg.LivenessPath)
+	}
+	if cfg.LivenessInterval <= 0 {
+		return fmt.Errorf("deploy.liveness.interval must be positive (got %s)", cfg.LivenessInterval)
+	}
+	if cfg.LivenessTimeout <= 0 || cfg.LivenessTimeout > cfg.LivenessInterval {
+		return fmt.Errorf("deploy.liveness.timeout must be positive and at most deploy.liveness.interval (got %s)", cfg.LivenessTimeout)
+	}
+	if cfg.LivenessFailureThreshold < 1 {
+		return fmt.Errorf("deploy.liveness.failure_threshold must be >= 1 (got %d)", cfg.LivenessFailureThreshold)
+	}
+	return nil
+}
+
+// attachmentReadinessToDomain converts [attachment_readiness.<service>] tables,
+// keyed by the attachment service name (the image name before the colon).
+func attachmentReadinessToDomain(cfgs map[string]AttachmentReadinessConfig) (map[string]domain.AttachmentReadiness, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]domain.AttachmentReadiness, len(cfgs))
+	for name, c := range cfgs {
+		readiness := domain.AttachmentReadiness{
+			Type:     strings.ToLower(strings.TrimSpace(c.Type)),
+			Port:     c.Port,
+			Command:  append([]string(nil), c.Command...),
+			Contains: c.Contains,
+		}
+		if c.Timeout != "" {
+			timeout, err := time.ParseDuration(c.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("attachment_readiness.%s.timeout %q is invalid: %w", name, c.Timeout, err)
+			}
+			if timeout <= 0 {
+				return nil, fmt.Errorf("attachment_readiness.%s.timeout must be positive (got %q)", name, c.Timeout)
+			}
+			readiness.Timeout = timeout
+		}
+		if err := readiness.Validate(name); err != nil {
+			return nil, err
+		}
+		result[name] = readiness
+	}
+	return result, nil
+}
+
+// attachmentTLSToDomain converts [attachment_tls.<service>] tables, keyed by
+// the attachment service name (the image name before the colon).
+func attachmentTLSToDomain(cfgs map[string]AttachmentTLSConfig) (map[string]domain.StandaloneServiceTLS, error) {
+	if len(cfgs) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]domain.StandaloneServiceTLS, len(cfgs))
+	for name, c := range cfgs {
+		tlsCfg := domain.StandaloneServiceTLS{
+			SANs:   append([]string(nil), c.SANs...),
+			Path:   c.Path,
+			Reload: c.Reload,
+		}
+		if c.TTL != "" {
+			ttl, err := time.ParseDuration(c.TTL)
+			if err != nil {
+				return nil, fmt.Errorf("attachment_tls.%s.ttl %q is invalid: %w", name, c.TTL, err)
+			}
+			tlsCfg.TTL = ttl
+		}
+		if c.Owner != "" {
+			uid, gid, ok := domain.ParseTLSOwner(c.Owner)
+			if !ok {
+				return nil, fmt.Errorf("attachment_tls.%s.owner %q must be a numeric uid or uid:gid", name, c.Owner)
+			}
+			tlsCfg.UID, tlsCfg.GID = uid, gid
+		}
+		if err := tlsCfg.Validate(fmt.Sprintf("attachment %q", name)); err != nil {
+			return nil, err
+		}
+		result[name] = tlsCfg
+	}
+	return result, nil
+}
+
 // createContainerService creates the container service with configuration.
 func createContainerService(ctx context.Context, v *viper.Viper, cfg Config, svc *services, log zerowrap.Logger) (*container.Service, error) {
 	containerConfig, err := buildContainerServiceConfig(ctx, v, cfg, svc, log)
@@ -2418,6 +2909,9 @@ func createHTTPHandlers(svc *services, cfg Config, log zerowrap.Logger, accessWr
 
 	// Proxy handler
 	proxyHandler := proxyadapter.NewHandler(svc.proxySvc, trustedNets, log)
+	if svc.responseCache != nil {
+		proxyHandler.SetResponseCache(svc.responseCache)
+	}
 
 	// HTTP proxy handler chain: HTTPS redirect for non-proxy clients, then CIDR allowlist
 	proxyAllowedNets, proxyCIDRMiddleware := buildProxyCIDRAllowlistMiddleware(cfg, trustedNets, log)
@@ -2892,6 +3386,7 @@ func runServers(ctx context.Context, v *viper.Viper, cfg Config, svc *services,
 	}
 	svc.tlsHTTPEntryPoints = tlsMuxHTTPServerNames(cfg)
 	svc.smartHTTPEntryPoints = smartTCPHTTPServerNames(cfg)
+	svc.http3EntryPoints = http3ServerNames(cfg)
 
 	// Wait for the registry and HTTP proxy to bind before applying the traffic graph.
 	// This prevents auto-start races while keeping the TLS mux under one owner.
@@ -2923,6 +3418,12 @@ func runServers(ctx context.Context, v *viper.Viper, cfg Config, svc *services,
 	// Recover configured routes after servers are listening (registry port is now bound).
 	syncAndRecoverConfiguredRoutes(ctx, svc.configSvc, svc.containerSvc, log)
 
+	// Put routes with an idle_timeout to sleep once they stop receiving traffic.
+	svc.proxySvc.StartIdleLoop(ctx, 0)
+
+	// Sample container resource usage for gordon top and the stats gauges.
+	svc.containerSvc.StartStatsSampler(ctx)
+
 	waitForShutdown(ctx, errChan, reloadChan, deployChan, reload, svc.eventBus, log)
 	cleanupHandlers() // Stop debounce timers before draining containers
 	gracefulShutdown(registrySrv, proxySrv, tlsSrv, svc.containerSvc, svc.proxySvc, svc.pkiSvc, svc.publicTLSSvc, svc.trafficManager, log)
@@ -3014,11 +3515,18 @@ func startOptionalSchedulers(ctx context.Context, cfg Config, svc *services, log
 		schedulers = append(schedulers, imageScheduler)
 	}
 
-	if len(schedulers) == 0 {
+	if svc != nil && svc.jobSvc != nil {
+		svc.jobSvc.Start(ctx)
+	}
+
+	if len(schedulers) == 0 && (svc == nil || svc.jobSvc == nil) {
 		return nil, nil
 	}
 
 	return func() {
+		if svc.jobSvc != nil {
+			svc.jobSvc.Stop()
+		}
 		for i := len(schedulers) - 1; i >= 0; i-- {
 			schedulers[i].Stop()
 		}
@@ -3268,6 +3776,7 @@ func gracefulShutdown(registrySrv, proxySrv, tlsSrv *http.Server, containerSvc *
 	}
 
 	containerSvc.StopMonitor()
+	containerSvc.StopStatsSampler()
 
 	if err := containerSvc.Shutdown(shutdownCtx); err != nil {
 		log.Warn().Err(err).Msg("error during container shutdown")
@@ -3387,6 +3896,10 @@ func startProxyServers(cfg Config, httpHandler, httpsHandler http.Handler, pkiSv
 	}
 	registerTLSMuxHTTPServers(trafficManager, cfg, httpsHandler, tlsConfig, nil)
 	registerSmartTCPHTTPServers(trafficManager, cfg, httpHandler, httpsHandler, tlsConfig, nil)
+	registerHTTP3Servers(trafficManager, cfg, httpsHandler, tlsConfig, nil)
+	if publicTLS != nil {
+		trafficManager.SetTLSALPNChallengeProvider(publicTLS)
+	}
 
 	return httpSrv, httpReady, nil, nil, nil
 }
@@ -3403,7 +3916,7 @@ func hasSmartTCPEntrypoint(cfg Config) bool {
 func hasTLSCapableEntrypoint(cfg Config) bool {
 	for _, entryPoint := range cfg.EntryPoints {
 		switch entryPoint.Protocol {
-		case domain.EntryPointProtocolSmartTCP, domain.EntryPointProtocolTLSMux:
+		case domain.EntryPointProtocolSmartTCP, domain.EntryPointProtocolTLSMux, domain.EntryPointProtocolHTTP3:
 			return true
 		}
 	}
@@ -3466,26 +3979,57 @@ func effectiveHTTP01Port(cfg Config) int {
 	return 0
 }
 
+// effectiveTLSALPN01Port returns 443 when a smart_tcp or tls_mux entrypoint
+// listens there, since ACME servers validate TLS-ALPN-01 on port 443 only.
+func effectiveTLSALPN01Port(cfg Config) int {
+	if hasTLSALPN01Entrypoint(cfg) {
+		return 443
+	}
+	return 0
+}
+
 func validatePublicTLSReadiness(cfg Config) error {
 	mode, err := domain.ParseACMEChallengeMode(cfg.TLS.ACME.Challenge)
 	if err != nil {
 		return err
 	}
 	switch mode {
-	case domain.ACMEChallengeCloudflareDNS01, domain.ACMEChallengeAuto:
+	case domain.ACMEChallengeCloudflareDNS01, domain.ACMEChallengeRFC2136DNS01, domain.ACMEChallengeExecDNS01, domain.ACMEChallengeAuto:
 		return nil
 	case domain.ACMEChallengeHTTP01:
 		return validateHTTP01ChallengeReadiness(cfg)
+	case domain.ACMEChallengeTLSALPN01:
+		return validateTLSALPN01ChallengeReadiness(cfg)
 	default:
 		return fmt.Errorf("%w: %q", domain.ErrACMEChallengeInvalid, mode)
 	}
 }
 
 func validateEffectivePublicTLSReadiness(cfg Config, effective publictls.EffectiveChallenge) error {
-	if effective.Mode != domain.ACMEChallengeHTTP01 {
+	switch effective.Mode {
+	case domain.ACMEChallengeHTTP01:
+		return validateHTTP01ChallengeReadiness(cfg)
+	case domain.ACMEChallengeTLSALPN01:
+		return validateTLSALPN01ChallengeReadiness(cfg)
+	default:
+		return nil
+	}
+}
+
+func validateTLSALPN01ChallengeReadiness(cfg Config) error {
+	if hasTLSALPN01Entrypoint(cfg) {
 		return nil
 	}
-	return validateHTTP01ChallengeReadiness(cfg)
+	return fmt.Errorf("%w: tls-alpn-01 requires a smart_tcp or tls_mux entrypoint bound on external :443", domain.ErrACMEChallengeInvalid)
+}
+
+func hasTLSALPN01Entrypoint(cfg Config) bool {
+	for _, entryPoint := range cfg.EntryPoints {
+		if tlsCapableEntryPoint(entryPoint.Protocol) && portFromAddress(entryPoint.Address) == 443 {
+			return true
+		}
+	}
+	return false
 }
 
 func validateHTTP01ChallengeReadiness(cfg Config) error {
@@ -3538,11 +4082,19 @@ func proxyTLSConfig(cfg Config, pkiSvc *pkiusecase.Service, publicTLS in.PublicT
 		publicTLS:   publicTLS,
 		localPKI:    pkiSvc,
 	}
-	return &tls.Config{
+	tlsConfig := &tls.Config{
 		MinVersion:     tls.VersionTLS12,
 		GetCertificate: selector.GetCertificate,
 		NextProtos:     []string{"h2", "http/1.1"},
-	}, nil
+	}
+	if pkiSvc != nil {
+		// Routes with client_auth get a per-handshake copy that asks for
+		// client certificates; every other SNI keeps this config.
+		tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			return pkiSvc.ClientAuthTLSConfig(hello, tlsConfig)
+		}
+	}
+	return tlsConfig, nil
 }
 
 func registerSmartTCPHTTPServers(manager *trafficadapter.Manager, cfg Config, httpHandler, httpsHandler http.Handler, tlsConfig *tls.Config, previous map[string]struct{}) map[string]struct{} {
@@ -3610,6 +4162,37 @@ func tlsMuxHTTPServerNames(cfg Config) map[string]struct{} {
 	return names
 }
 
+// registerHTTP3Servers serves http3 entrypoints with the same handler and
+// certificates as the TCP TLS fallback.
+func registerHTTP3Servers(manager *trafficadapter.Manager, cfg Config, httpsHandler http.Handler, tlsConfig *tls.Config, previous map[string]struct{}) map[string]struct{} {
+	if manager == nil {
+		return previous
+	}
+	next := http3ServerNames(cfg)
+	for name := range previous {
+		if _, ok := next[name]; !ok {
+			manager.SetHTTP3Server(name, nil, nil)
+		}
+	}
+	if httpsHandler == nil || tlsConfig == nil {
+		return next
+	}
+	for name := range next {
+		manager.SetHTTP3Server(name, httpsHandler, tlsConfig)
+	}
+	return next
+}
+
+func http3ServerNames(cfg Config) map[string]struct{} {
+	names := map[string]struct{}{}
+	for name, entryPoint := range cfg.EntryPoints {
+		if entryPoint.Protocol == domain.EntryPointProtocolHTTP3 {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
+
 // startServer starts an HTTP server, returning the server instance and a channel
 // that closes once the listening socket is bound. This lets callers wait for the
 // port to be ready before taking actions that depend on it (e.g. auto-start
@@ -3906,9 +4489,22 @@ func loadConfig(v *viper.Viper, configPath string) error {
 	v.SetDefault("tls.acme.email", "")
 	v.SetDefault("tls.acme.challenge", "auto")
 	v.SetDefault("tls.acme.obtain_batch_size", 1)
+	v.SetDefault("tls.acme.ocsp_stapling", true)
+	v.SetDefault("tls.acme.expiry_alert_days", domain.DefaultTLSExpiryAlertDays)
+	v.SetDefault("tls.acme.renewal_failure_threshold", domain.DefaultTLSRenewalFailureThreshold)
+	v.SetDefault("tls.acme.directory_url", domain.DefaultACMEDirectoryURL)
+	v.SetDefault("tls.acme.eab_kid", "")
+	v.SetDefault("tls.acme.eab_hmac", "")
+	v.SetDefault("tls.acme.rfc2136.nameserver", "")
+	v.SetDefault("tls.acme.rfc2136.tsig_key", "")
+	v.SetDefault("tls.acme.rfc2136.tsig_algorithm", publictls.DefaultTSIGAlgorithm)
+	v.SetDefault("tls.acme.exec.command", "")
+	v.SetDefault("tls.acme.exec.timeout", "2m")
 	v.SetDefault("dns.resolvers", publictls.DefaultDNSResolvers)
 	v.SetDefault("dns.propagation_timeout", "5m")
 	v.SetDefault("dns.polling_interval", "5s")
+	v.SetDefault("compression.enabled", false)
+	v.SetDefault("cache.enabled", false)
 	v.SetDefault("server.force_https_redirect", false)
 	v.SetDefault("server.data_dir", DefaultDataDir())
 	v.SetDefault("server.runtime", "auto")
@@ -3997,6 +4593,14 @@ func loadConfig(v *viper.Viper, configPath string) error {
 	v.SetDefault("deploy.attachment_readiness_timeout", "30s")
 	v.SetDefault("deploy.drain_mode", "auto")
 	v.SetDefault("deploy.drain_timeout", "30s")
+	v.SetDefault("deploy.liveness.enabled", false)
+	v.SetDefault("deploy.liveness.path", "")
+	v.SetDefault("deploy.liveness.interval", "30s")
+	v.SetDefault("deploy.liveness.timeout", "5s")
+	v.SetDefault("deploy.liveness.failure_threshold", 3)
+	v.SetDefault("containers.stats.enabled", true)
+	v.SetDefault("containers.stats.interval", "10s")
+	v.SetDefault("containers.stats.history", 60)
 
 	ConfigureViper(v, configPath)
 
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestAttachmentReadinessToDomain(t *testing.T) {
+	readiness, err := attachmentReadinessToDomain(map[string]AttachmentReadinessConfig{
+		"postgres": {Type: "Command", Command: []string{"pg_isready", "-U", "postgres"}, Timeout: "90s"},
+		"redis":    {Type: "tcp", Port: 6379},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.AttachmentReadiness{
+		Type:    domain.AttachmentReadinessCommand,
+		Command: []string{"pg_isready", "-U", "postgres"},
+		Timeout: 90 * time.Second,
+	}, readiness["postgres"])
+	assert.Equal(t, domain.AttachmentReadiness{Type: domain.AttachmentReadinessTCP, Port: 6379}, readiness["redis"])
+
+	empty, err := attachmentReadinessToDomain(nil)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestAttachmentReadinessToDomainRejectsInvalidConfig(t *testing.T) {
+	tests := map[string]AttachmentReadinessConfig{
+		"timeout \"soon\" is invalid": {Type: "tcp", Timeout: "soon"},
+		"timeout must be positive":    {Type: "tcp", Timeout: "0s"},
+		"requires a command":          {Type: "command"},
+		"must be auto, tcp, command":  {Type: "http"},
+	}
+	for want, cfg := range tests {
+		_, err := attachmentReadinessToDomain(map[string]AttachmentReadinessConfig{"postgres": cfg})
+		require.ErrorContains(t, err, want)
+	}
+}
+
+func TestAttachmentTLSToDomain(t *testing.T) {
+	tlsCfgs, err := attachmentTLSToDomain(map[string]AttachmentTLSConfig{
+		"postgres": {SANs: []string{"db.internal"}, Path: "/certs", TTL: "72h", Owner: "999:998", Reload: "SIGHUP"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StandaloneServiceTLS{
+		SANs:   []string{"db.internal"},
+		Path:   "/certs",
+		TTL:    72 * time.Hour,
+		UID:    999,
+		GID:    998,
+		Reload: "SIGHUP",
+	}, tlsCfgs["postgres"])
+
+	empty, err := attachmentTLSToDomain(nil)
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestAttachmentTLSToDomainRejectsInvalidConfig(t *testing.T) {
+	tests := map[string]AttachmentTLSConfig{
+		"ttl \"soon\" is invalid":          {TTL: "soon"},
+		"must be a numeric uid or uid:gid": {Owner: "postgres"},
+		"must be an absolute container":    {Path: "certs"},
+		"reload must be restart":           {Reload: "reload"},
+	}
+	for want, cfg := range tests {
+		_, err := attachmentTLSToDomain(map[string]AttachmentTLSConfig{"postgres": cfg})
+		require.ErrorContains(t, err, want)
+	}
+}
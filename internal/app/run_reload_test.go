@@ -277,6 +277,9 @@ func TestReloadCoordinator_ApplyLoadedConfig_RebuildsProxyConfigAndPublishesEven
 		MaxBodySize:        5 << 20,
 		MaxResponseSize:    7 << 20,
 		MaxConcurrentConns: 99,
+		Compression:        defaultCompressionPolicy(t),
+		ResponseCache:      domain.ResponseCachePolicy{MaxObjectSize: domain.DefaultResponseCacheMaxObjectSize},
+		Upstream:           domain.DefaultUpstreamPolicy(),
 	}, proxySvc.config)
 }
 
@@ -547,6 +550,9 @@ func TestReloadCoordinator_DebouncesRepeatedWatchCallbacks(t *testing.T) {
 		MaxBodySize:        5 << 20,
 		MaxResponseSize:    7 << 20,
 		MaxConcurrentConns: 99,
+		Compression:        defaultCompressionPolicy(t),
+		ResponseCache:      domain.ResponseCachePolicy{MaxObjectSize: domain.DefaultResponseCacheMaxObjectSize},
+		Upstream:           domain.DefaultUpstreamPolicy(),
 	}, proxySvc.config)
 }
 
@@ -670,3 +676,94 @@ func TestReloadCoordinator_SerializesOverlappingReloadRequests(t *testing.T) {
 	require.Equal(t, 1, reloadSvc.Calls())
 	assert.Equal(t, 1, proxySvc.calls)
 }
+
+func TestBuildProxyConfig_Compression(t *testing.T) {
+	result, err := buildProxyConfig(Config{}, zerowrap.Default())
+	require.NoError(t, err)
+	assert.False(t, result.proxyConfig.Compression.Enabled)
+	assert.Equal(t, domain.DefaultCompressionAlgorithms(), result.proxyConfig.Compression.Algorithms)
+	assert.Equal(t, int64(domain.DefaultCompressionMinSize), result.proxyConfig.Compression.MinSize)
+
+	cfg := Config{}
+	cfg.Compression.Enabled = true
+	cfg.Compression.Algorithms = []string{"gzip"}
+	cfg.Compression.MinSize = "2KB"
+	result, err = buildProxyConfig(cfg, zerowrap.Default())
+	require.NoError(t, err)
+	assert.True(t, result.proxyConfig.Compression.Enabled)
+	assert.Equal(t, []string{"gzip"}, result.proxyConfig.Compression.Algorithms)
+	assert.Equal(t, int64(2<<10), result.proxyConfig.Compression.MinSize)
+
+	cfg.Compression.Algorithms = []string{"deflate"}
+	_, err = buildProxyConfig(cfg, zerowrap.Default())
+	assert.Error(t, err)
+}
+
+func TestBuildProxyConfig_ResponseCache(t *testing.T) {
+	result, err := buildProxyConfig(Config{}, zerowrap.Default())
+	require.NoError(t, err)
+	assert.False(t, result.proxyConfig.ResponseCache.Enabled)
+	assert.Equal(t, int64(domain.DefaultResponseCacheMaxObjectSize), result.proxyConfig.ResponseCache.MaxObjectSize)
+
+	cfg := Config{}
+	cfg.Cache.Enabled = true
+	cfg.Cache.MaxObjectSize = "2MB"
+	result, err = buildProxyConfig(cfg, zerowrap.Default())
+	require.NoError(t, err)
+	assert.True(t, result.proxyConfig.ResponseCache.Enabled)
+	assert.Equal(t, int64(2<<20), result.proxyConfig.ResponseCache.MaxObjectSize)
+
+	cfg.Cache.MaxObjectSize = "0B"
+	_, err = buildProxyConfig(cfg, zerowrap.Default())
+	assert.Error(t, err)
+}
+
+func TestBuildProxyConfig_Upstream(t *testing.T) {
+	result, err := buildProxyConfig(Config{}, zerowrap.Default())
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultUpstreamPolicy(), result.proxyConfig.Upstream)
+
+	cfg := Config{}
+	cfg.Upstream.HeaderTimeout = "0s"
+	cfg.Upstream.Retries = 2
+	cfg.Upstream.BreakerThreshold = 5
+	cfg.Upstream.BreakerCooldown = "1m"
+	result, err = buildProxyConfig(cfg, zerowrap.Default())
+	require.NoError(t, err)
+	assert.Zero(t, result.proxyConfig.Upstream.HeaderTimeout)
+	assert.Equal(t, domain.DefaultUpstreamDialTimeout, result.proxyConfig.Upstream.DialTimeout)
+	assert.Equal(t, 2, result.proxyConfig.Upstream.Retries)
+	assert.Equal(t, 5, result.proxyConfig.Upstream.BreakerThreshold)
+	assert.Equal(t, time.Minute, result.proxyConfig.Upstream.BreakerCooldown)
+
+	cfg.Upstream.DialTimeout = "0s"
+	_, err = buildProxyConfig(cfg, zerowrap.Default())
+	assert.Error(t, err)
+
+	cfg.Upstream.DialTimeout = ""
+	cfg.Upstream.Retries = -1
+	_, err = buildProxyConfig(cfg, zerowrap.Default())
+	assert.Error(t, err)
+}
+
+func TestCreateResponseCacheStore(t *testing.T) {
+	cfg := Config{}
+	cfg.Server.DataDir = t.TempDir()
+	cfg.Cache.MemorySize = "1MB"
+	cfg.Cache.DiskSize = "10MB"
+	store, err := createResponseCacheStore(cfg, zerowrap.Default())
+	require.NoError(t, err)
+	require.NotNil(t, store)
+	assert.DirExists(t, filepath.Join(cfg.Server.DataDir, "cache", "responses"))
+
+	cfg.Cache.DiskSize = "lots"
+	_, err = createResponseCacheStore(cfg, zerowrap.Default())
+	assert.Error(t, err)
+}
+
+func defaultCompressionPolicy(t *testing.T) domain.CompressionPolicy {
+	t.Helper()
+	policy, err := buildCompressionPolicy(Config{})
+	require.NoError(t, err)
+	return policy
+}
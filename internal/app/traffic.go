@@ -80,7 +80,7 @@ func trafficManagerRouterProtocol(protocol domain.RouterProtocol) bool {
 
 func trafficManagerOwnsEntryPoint(entryPoint domain.EntryPoint) bool {
 	switch entryPoint.Protocol {
-	case domain.EntryPointProtocolTLSMux, domain.EntryPointProtocolSmartTCP, domain.EntryPointProtocolTCP, domain.EntryPointProtocolUDP:
+	case domain.EntryPointProtocolTLSMux, domain.EntryPointProtocolSmartTCP, domain.EntryPointProtocolTCP, domain.EntryPointProtocolUDP, domain.EntryPointProtocolHTTP3:
 		return true
 	default:
 		return false
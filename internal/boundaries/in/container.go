@@ -31,6 +31,13 @@ type ContainerService interface {
 	// If withAttachments is true, also restarts attachment containers.
 	Restart(ctx context.Context, domain string, withAttachments bool) error
 
+	// Sleep stops an idle route container while keeping it tracked so the
+	// next request can wake it.
+	Sleep(ctx context.Context, domain string) error
+
+	// Wake starts a sleeping route container and waits for it to be ready.
+	Wake(ctx context.Context, domain string) (*domain.Container, error)
+
 	// List returns all managed containers.
 	List(ctx context.Context) map[string]*domain.Container
 
@@ -40,6 +47,10 @@ type ContainerService interface {
 	// ListAttachments returns attachments for a domain.
 	ListAttachments(ctx context.Context, domain string) []domain.Attachment
 
+	// ListProcesses returns the declared processes of a route and those
+	// that still have a container, with the container running each.
+	ListProcesses(ctx context.Context, route domain.Route) []domain.Process
+
 	// ListOrphanedAttachments returns running attachment containers no longer configured.
 	ListOrphanedAttachments(ctx context.Context) ([]domain.CleanupAttachment, error)
 
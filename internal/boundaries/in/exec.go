@@ -0,0 +1,16 @@
+package in
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// ExecService opens interactive sessions in a route's running containers.
+type ExecService interface {
+	// StartExec starts the command in the route's running container, or in
+	// the attachment whose service name is attachment when it is not empty.
+	// The caller must close the returned session.
+	StartExec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error)
+}
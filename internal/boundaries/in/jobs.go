@@ -0,0 +1,18 @@
+package in
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// JobService manages the scheduled jobs configured with [[jobs]].
+type JobService interface {
+	// ListJobs returns every configured job with its next and last run.
+	ListJobs(ctx context.Context) ([]domain.JobStatus, error)
+	// RunJob starts a run of the job now, subject to its overlap policy, and
+	// returns the run as recorded. The run continues in the background.
+	RunJob(ctx context.Context, route, name string) (domain.JobRun, error)
+	// ListJobRuns returns the recorded runs of a job, newest first.
+	ListJobRuns(ctx context.Context, route, name string) ([]domain.JobRun, error)
+}
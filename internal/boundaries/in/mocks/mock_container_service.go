@@ -594,6 +594,65 @@ func (_c *MockContainerService_ListOrphanedAttachments_Call) RunAndReturn(run fu
 	return _c
 }
 
+// ListProcesses provides a mock function for the type MockContainerService
+func (_mock *MockContainerService) ListProcesses(ctx context.Context, route domain.Route) []domain.Process {
+	ret := _mock.Called(ctx, route)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProcesses")
+	}
+
+	var r0 []domain.Process
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.Route) []domain.Process); ok {
+		r0 = returnFunc(ctx, route)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Process)
+		}
+	}
+	return r0
+}
+
+// MockContainerService_ListProcesses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProcesses'
+type MockContainerService_ListProcesses_Call struct {
+	*mock.Call
+}
+
+// ListProcesses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - route domain.Route
+func (_e *MockContainerService_Expecter) ListProcesses(ctx any, route any) *MockContainerService_ListProcesses_Call {
+	return &MockContainerService_ListProcesses_Call{Call: _e.mock.On("ListProcesses", ctx, route)}
+}
+
+func (_c *MockContainerService_ListProcesses_Call) Run(run func(ctx context.Context, route domain.Route)) *MockContainerService_ListProcesses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 domain.Route
+		if args[1] != nil {
+			arg1 = args[1].(domain.Route)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerService_ListProcesses_Call) Return(processs []domain.Process) *MockContainerService_ListProcesses_Call {
+	_c.Call.Return(processs)
+	return _c
+}
+
+func (_c *MockContainerService_ListProcesses_Call) RunAndReturn(run func(ctx context.Context, route domain.Route) []domain.Process) *MockContainerService_ListProcesses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListRoutesWithDetails provides a mock function for the type MockContainerService
 func (_mock *MockContainerService) ListRoutesWithDetails(ctx context.Context) []domain.RouteInfo {
 	ret := _mock.Called(ctx)
@@ -892,6 +951,63 @@ func (_c *MockContainerService_Shutdown_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// Sleep provides a mock function for the type MockContainerService
+func (_mock *MockContainerService) Sleep(ctx context.Context, domain1 string) error {
+	ret := _mock.Called(ctx, domain1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sleep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, domain1)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockContainerService_Sleep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sleep'
+type MockContainerService_Sleep_Call struct {
+	*mock.Call
+}
+
+// Sleep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain1 string
+func (_e *MockContainerService_Expecter) Sleep(ctx any, domain1 any) *MockContainerService_Sleep_Call {
+	return &MockContainerService_Sleep_Call{Call: _e.mock.On("Sleep", ctx, domain1)}
+}
+
+func (_c *MockContainerService_Sleep_Call) Run(run func(ctx context.Context, domain1 string)) *MockContainerService_Sleep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerService_Sleep_Call) Return(err error) *MockContainerService_Sleep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockContainerService_Sleep_Call) RunAndReturn(run func(ctx context.Context, domain1 string) error) *MockContainerService_Sleep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Stop provides a mock function for the type MockContainerService
 func (_mock *MockContainerService) Stop(ctx context.Context, containerID string) error {
 	ret := _mock.Called(ctx, containerID)
@@ -1039,3 +1155,71 @@ func (_c *MockContainerService_UpdateAttachments_Call) RunAndReturn(run func(att
 	_c.Run(run)
 	return _c
 }
+
+// Wake provides a mock function for the type MockContainerService
+func (_mock *MockContainerService) Wake(ctx context.Context, domain1 string) (*domain.Container, error) {
+	ret := _mock.Called(ctx, domain1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Wake")
+	}
+
+	var r0 *domain.Container
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Container, error)); ok {
+		return returnFunc(ctx, domain1)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Container); ok {
+		r0 = returnFunc(ctx, domain1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Container)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, domain1)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockContainerService_Wake_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Wake'
+type MockContainerService_Wake_Call struct {
+	*mock.Call
+}
+
+// Wake is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domain1 string
+func (_e *MockContainerService_Expecter) Wake(ctx any, domain1 any) *MockContainerService_Wake_Call {
+	return &MockContainerService_Wake_Call{Call: _e.mock.On("Wake", ctx, domain1)}
+}
+
+func (_c *MockContainerService_Wake_Call) Run(run func(ctx context.Context, domain1 string)) *MockContainerService_Wake_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerService_Wake_Call) Return(container *domain.Container, err error) *MockContainerService_Wake_Call {
+	_c.Call.Return(container, err)
+	return _c
+}
+
+func (_c *MockContainerService_Wake_Call) RunAndReturn(run func(ctx context.Context, domain1 string) (*domain.Container, error)) *MockContainerService_Wake_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -39,6 +39,74 @@ func (_m *MockProxyService) EXPECT() *MockProxyService_Expecter {
 	return &MockProxyService_Expecter{mock: &_m.Mock}
 }
 
+// AcquireUpstream provides a mock function for the type MockProxyService
+func (_mock *MockProxyService) AcquireUpstream(domainName string, policy domain.UpstreamPolicy) (func(error), error) {
+	ret := _mock.Called(domainName, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcquireUpstream")
+	}
+
+	var r0 func(error)
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, domain.UpstreamPolicy) (func(error), error)); ok {
+		return returnFunc(domainName, policy)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, domain.UpstreamPolicy) func(error)); ok {
+		r0 = returnFunc(domainName, policy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(func(error))
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, domain.UpstreamPolicy) error); ok {
+		r1 = returnFunc(domainName, policy)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProxyService_AcquireUpstream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcquireUpstream'
+type MockProxyService_AcquireUpstream_Call struct {
+	*mock.Call
+}
+
+// AcquireUpstream is a helper method to define mock.On call
+//   - domainName string
+//   - policy domain.UpstreamPolicy
+func (_e *MockProxyService_Expecter) AcquireUpstream(domainName any, policy any) *MockProxyService_AcquireUpstream_Call {
+	return &MockProxyService_AcquireUpstream_Call{Call: _e.mock.On("AcquireUpstream", domainName, policy)}
+}
+
+func (_c *MockProxyService_AcquireUpstream_Call) Run(run func(domainName string, policy domain.UpstreamPolicy)) *MockProxyService_AcquireUpstream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 domain.UpstreamPolicy
+		if args[1] != nil {
+			arg1 = args[1].(domain.UpstreamPolicy)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProxyService_AcquireUpstream_Call) Return(fn func(error), err error) *MockProxyService_AcquireUpstream_Call {
+	_c.Call.Return(fn, err)
+	return _c
+}
+
+func (_c *MockProxyService_AcquireUpstream_Call) RunAndReturn(run func(domainName string, policy domain.UpstreamPolicy) (func(error), error)) *MockProxyService_AcquireUpstream_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetTarget provides a mock function for the type MockProxyService
 func (_mock *MockProxyService) GetTarget(ctx context.Context, domain1 string) (*domain.ProxyTarget, error) {
 	ret := _mock.Called(ctx, domain1)
@@ -167,6 +167,68 @@ func (_c *MockPublicTLSService_GetHTTP01Challenge_Call) RunAndReturn(run func(ct
 	return _c
 }
 
+// GetTLSALPN01Certificate provides a mock function for the type MockPublicTLSService
+func (_mock *MockPublicTLSService) GetTLSALPN01Certificate(serverName string) (*tls.Certificate, bool) {
+	ret := _mock.Called(serverName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTLSALPN01Certificate")
+	}
+
+	var r0 *tls.Certificate
+	var r1 bool
+	if returnFunc, ok := ret.Get(0).(func(string) (*tls.Certificate, bool)); ok {
+		return returnFunc(serverName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *tls.Certificate); ok {
+		r0 = returnFunc(serverName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tls.Certificate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = returnFunc(serverName)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	return r0, r1
+}
+
+// MockPublicTLSService_GetTLSALPN01Certificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTLSALPN01Certificate'
+type MockPublicTLSService_GetTLSALPN01Certificate_Call struct {
+	*mock.Call
+}
+
+// GetTLSALPN01Certificate is a helper method to define mock.On call
+//   - serverName string
+func (_e *MockPublicTLSService_Expecter) GetTLSALPN01Certificate(serverName any) *MockPublicTLSService_GetTLSALPN01Certificate_Call {
+	return &MockPublicTLSService_GetTLSALPN01Certificate_Call{Call: _e.mock.On("GetTLSALPN01Certificate", serverName)}
+}
+
+func (_c *MockPublicTLSService_GetTLSALPN01Certificate_Call) Run(run func(serverName string)) *MockPublicTLSService_GetTLSALPN01Certificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockPublicTLSService_GetTLSALPN01Certificate_Call) Return(certificate *tls.Certificate, b bool) *MockPublicTLSService_GetTLSALPN01Certificate_Call {
+	_c.Call.Return(certificate, b)
+	return _c
+}
+
+func (_c *MockPublicTLSService_GetTLSALPN01Certificate_Call) RunAndReturn(run func(serverName string) (*tls.Certificate, bool)) *MockPublicTLSService_GetTLSALPN01Certificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Reconcile provides a mock function for the type MockPublicTLSService
 func (_mock *MockPublicTLSService) Reconcile(ctx context.Context) error {
 	ret := _mock.Called(ctx)
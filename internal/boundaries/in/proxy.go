@@ -31,6 +31,13 @@ type ProxyService interface {
 	// Returns a release function that must be called when the request completes.
 	TrackInFlight(containerID string) func()
 
+	// AcquireUpstream checks the circuit breaker of a route before a request
+	// is forwarded. It returns domain.ErrUpstreamCircuitOpen while the circuit
+	// is open; otherwise the returned function must be called with the
+	// upstream error, nil on success, or context.Canceled when the client
+	// went away first, once the request completes.
+	AcquireUpstream(domainName string, policy domain.UpstreamPolicy) (func(error), error)
+
 	// TrackRegistryRequest increments the registry in-flight counter.
 	TrackRegistryRequest()
 
@@ -18,6 +18,10 @@ type PublicTLSService interface {
 	// GetHTTP01Challenge returns the key authorization for an HTTP-01 challenge token.
 	GetHTTP01Challenge(ctx context.Context, token string) (keyAuth string, ok bool)
 
+	// GetTLSALPN01Certificate returns the pending TLS-ALPN-01 challenge
+	// certificate for the given SNI host.
+	GetTLSALPN01Certificate(serverName string) (*tls.Certificate, bool)
+
 	// Status returns the current public TLS status.
 	Status(ctx context.Context) domain.PublicTLSStatus
 
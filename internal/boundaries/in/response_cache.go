@@ -0,0 +1,11 @@
+package in
+
+import "context"
+
+// ResponseCacheService manages the proxy response cache.
+type ResponseCacheService interface {
+	// PurgeResponseCache removes the cached responses of a route domain, or
+	// only those for path when it is not empty, and returns how many were
+	// removed.
+	PurgeResponseCache(ctx context.Context, domainName, path string) (int, error)
+}
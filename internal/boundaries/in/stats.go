@@ -0,0 +1,18 @@
+package in
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// StatsService exposes the sampled resource usage of running containers.
+type StatsService interface {
+	// ListStats returns the recent resource usage of every running route,
+	// attachment and service container. It returns domain.ErrStatsDisabled
+	// when sampling is off.
+	ListStats(ctx context.Context) ([]domain.ContainerStats, error)
+	// GetStats returns the recent resource usage of a route's container and
+	// its attachments, or of the standalone service called name.
+	GetStats(ctx context.Context, name string) ([]domain.ContainerStats, error)
+}
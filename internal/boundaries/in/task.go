@@ -0,0 +1,17 @@
+package in
+
+import (
+	"context"
+	"io"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// TaskService runs one-off commands next to a route's deployed container.
+type TaskService interface {
+	// RunTask starts the command in an ephemeral container built from the
+	// route's running image, env, volumes and network, copies its output to
+	// stdout and stderr, and returns its exit code. The container is removed
+	// once the command ends or ctx is canceled.
+	RunTask(ctx context.Context, route domain.Route, task domain.TaskRequest, stdout, stderr io.Writer) (int, error)
+}
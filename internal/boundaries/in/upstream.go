@@ -0,0 +1,13 @@
+package in
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// UpstreamStatusService reports the health of route upstreams as seen by the proxy.
+type UpstreamStatusService interface {
+	// UpstreamStatus returns the circuit breaker state of a route's upstream.
+	UpstreamStatus(ctx context.Context, domainName string) (domain.UpstreamStatus, error)
+}
@@ -0,0 +1,15 @@
+package out
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// JobRunStore persists the run history of scheduled jobs.
+type JobRunStore interface {
+	// SaveRun inserts run, or replaces the stored run with the same ID.
+	SaveRun(ctx context.Context, run domain.JobRun) error
+	// ListRuns returns the stored runs of a job, newest first.
+	ListRuns(ctx context.Context, route, job string) ([]domain.JobRun, error)
+}
@@ -9,6 +9,7 @@ import (
 	"crypto/x509"
 	"time"
 
+	"github.com/bnema/gordon/internal/domain"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -39,6 +40,123 @@ func (_m *MockCertificateAuthority) EXPECT() *MockCertificateAuthority_Expecter
 	return &MockCertificateAuthority_Expecter{mock: &_m.Mock}
 }
 
+// ClientCAPool provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) ClientCAPool(bundlePath string) (*x509.CertPool, error) {
+	ret := _mock.Called(bundlePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientCAPool")
+	}
+
+	var r0 *x509.CertPool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string) (*x509.CertPool, error)); ok {
+		return returnFunc(bundlePath)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string) *x509.CertPool); ok {
+		r0 = returnFunc(bundlePath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*x509.CertPool)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string) error); ok {
+		r1 = returnFunc(bundlePath)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCertificateAuthority_ClientCAPool_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClientCAPool'
+type MockCertificateAuthority_ClientCAPool_Call struct {
+	*mock.Call
+}
+
+// ClientCAPool is a helper method to define mock.On call
+//   - bundlePath string
+func (_e *MockCertificateAuthority_Expecter) ClientCAPool(bundlePath any) *MockCertificateAuthority_ClientCAPool_Call {
+	return &MockCertificateAuthority_ClientCAPool_Call{Call: _e.mock.On("ClientCAPool", bundlePath)}
+}
+
+func (_c *MockCertificateAuthority_ClientCAPool_Call) Run(run func(bundlePath string)) *MockCertificateAuthority_ClientCAPool_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_ClientCAPool_Call) Return(certPool *x509.CertPool, err error) *MockCertificateAuthority_ClientCAPool_Call {
+	_c.Call.Return(certPool, err)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_ClientCAPool_Call) RunAndReturn(run func(bundlePath string) (*x509.CertPool, error)) *MockCertificateAuthority_ClientCAPool_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClientRevocationList provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) ClientRevocationList() ([]byte, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClientRevocationList")
+	}
+
+	var r0 []byte
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]byte, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []byte); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCertificateAuthority_ClientRevocationList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClientRevocationList'
+type MockCertificateAuthority_ClientRevocationList_Call struct {
+	*mock.Call
+}
+
+// ClientRevocationList is a helper method to define mock.On call
+func (_e *MockCertificateAuthority_Expecter) ClientRevocationList() *MockCertificateAuthority_ClientRevocationList_Call {
+	return &MockCertificateAuthority_ClientRevocationList_Call{Call: _e.mock.On("ClientRevocationList")}
+}
+
+func (_c *MockCertificateAuthority_ClientRevocationList_Call) Run(run func()) *MockCertificateAuthority_ClientRevocationList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_ClientRevocationList_Call) Return(bytes []byte, err error) *MockCertificateAuthority_ClientRevocationList_Call {
+	_c.Call.Return(bytes, err)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_ClientRevocationList_Call) RunAndReturn(run func() ([]byte, error)) *MockCertificateAuthority_ClientRevocationList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // InstallRoot provides a mock function for the type MockCertificateAuthority
 func (_mock *MockCertificateAuthority) InstallRoot(cert *x509.Certificate) error {
 	ret := _mock.Called(cert)
@@ -178,6 +296,57 @@ func (_c *MockCertificateAuthority_IntermediateLifetime_Call) RunAndReturn(run f
 	return _c
 }
 
+// IsClientCertificateRevoked provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) IsClientCertificateRevoked(cert *x509.Certificate) bool {
+	ret := _mock.Called(cert)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsClientCertificateRevoked")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func(*x509.Certificate) bool); ok {
+		r0 = returnFunc(cert)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// MockCertificateAuthority_IsClientCertificateRevoked_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsClientCertificateRevoked'
+type MockCertificateAuthority_IsClientCertificateRevoked_Call struct {
+	*mock.Call
+}
+
+// IsClientCertificateRevoked is a helper method to define mock.On call
+//   - cert *x509.Certificate
+func (_e *MockCertificateAuthority_Expecter) IsClientCertificateRevoked(cert any) *MockCertificateAuthority_IsClientCertificateRevoked_Call {
+	return &MockCertificateAuthority_IsClientCertificateRevoked_Call{Call: _e.mock.On("IsClientCertificateRevoked", cert)}
+}
+
+func (_c *MockCertificateAuthority_IsClientCertificateRevoked_Call) Run(run func(cert *x509.Certificate)) *MockCertificateAuthority_IsClientCertificateRevoked_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 *x509.Certificate
+		if args[0] != nil {
+			arg0 = args[0].(*x509.Certificate)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IsClientCertificateRevoked_Call) Return(b bool) *MockCertificateAuthority_IsClientCertificateRevoked_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IsClientCertificateRevoked_Call) RunAndReturn(run func(cert *x509.Certificate) bool) *MockCertificateAuthority_IsClientCertificateRevoked_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IssueCertificate provides a mock function for the type MockCertificateAuthority
 func (_mock *MockCertificateAuthority) IssueCertificate(domain string) (*tls.Certificate, error) {
 	ret := _mock.Called(domain)
@@ -240,6 +409,148 @@ func (_c *MockCertificateAuthority_IssueCertificate_Call) RunAndReturn(run func(
 	return _c
 }
 
+// IssueClientCertificate provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) IssueClientCertificate(name string, ttl time.Duration) (*domain.ClientCertificate, error) {
+	ret := _mock.Called(name, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueClientCertificate")
+	}
+
+	var r0 *domain.ClientCertificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, time.Duration) (*domain.ClientCertificate, error)); ok {
+		return returnFunc(name, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, time.Duration) *domain.ClientCertificate); ok {
+		r0 = returnFunc(name, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ClientCertificate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, time.Duration) error); ok {
+		r1 = returnFunc(name, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCertificateAuthority_IssueClientCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueClientCertificate'
+type MockCertificateAuthority_IssueClientCertificate_Call struct {
+	*mock.Call
+}
+
+// IssueClientCertificate is a helper method to define mock.On call
+//   - name string
+//   - ttl time.Duration
+func (_e *MockCertificateAuthority_Expecter) IssueClientCertificate(name any, ttl any) *MockCertificateAuthority_IssueClientCertificate_Call {
+	return &MockCertificateAuthority_IssueClientCertificate_Call{Call: _e.mock.On("IssueClientCertificate", name, ttl)}
+}
+
+func (_c *MockCertificateAuthority_IssueClientCertificate_Call) Run(run func(name string, ttl time.Duration)) *MockCertificateAuthority_IssueClientCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 time.Duration
+		if args[1] != nil {
+			arg1 = args[1].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IssueClientCertificate_Call) Return(clientCertificate *domain.ClientCertificate, err error) *MockCertificateAuthority_IssueClientCertificate_Call {
+	_c.Call.Return(clientCertificate, err)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IssueClientCertificate_Call) RunAndReturn(run func(name string, ttl time.Duration) (*domain.ClientCertificate, error)) *MockCertificateAuthority_IssueClientCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IssueServiceCertificate provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) IssueServiceCertificate(name string, sans []string, ttl time.Duration) (*domain.ServiceCertificate, error) {
+	ret := _mock.Called(name, sans, ttl)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueServiceCertificate")
+	}
+
+	var r0 *domain.ServiceCertificate
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, []string, time.Duration) (*domain.ServiceCertificate, error)); ok {
+		return returnFunc(name, sans, ttl)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, []string, time.Duration) *domain.ServiceCertificate); ok {
+		r0 = returnFunc(name, sans, ttl)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ServiceCertificate)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, []string, time.Duration) error); ok {
+		r1 = returnFunc(name, sans, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockCertificateAuthority_IssueServiceCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IssueServiceCertificate'
+type MockCertificateAuthority_IssueServiceCertificate_Call struct {
+	*mock.Call
+}
+
+// IssueServiceCertificate is a helper method to define mock.On call
+//   - name string
+//   - sans []string
+//   - ttl time.Duration
+func (_e *MockCertificateAuthority_Expecter) IssueServiceCertificate(name any, sans any, ttl any) *MockCertificateAuthority_IssueServiceCertificate_Call {
+	return &MockCertificateAuthority_IssueServiceCertificate_Call{Call: _e.mock.On("IssueServiceCertificate", name, sans, ttl)}
+}
+
+func (_c *MockCertificateAuthority_IssueServiceCertificate_Call) Run(run func(name string, sans []string, ttl time.Duration)) *MockCertificateAuthority_IssueServiceCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IssueServiceCertificate_Call) Return(serviceCertificate *domain.ServiceCertificate, err error) *MockCertificateAuthority_IssueServiceCertificate_Call {
+	_c.Call.Return(serviceCertificate, err)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_IssueServiceCertificate_Call) RunAndReturn(run func(name string, sans []string, ttl time.Duration) (*domain.ServiceCertificate, error)) *MockCertificateAuthority_IssueServiceCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LeafLifetime provides a mock function for the type MockCertificateAuthority
 func (_mock *MockCertificateAuthority) LeafLifetime() time.Duration {
 	ret := _mock.Called()
@@ -328,6 +639,57 @@ func (_c *MockCertificateAuthority_RenewIntermediate_Call) RunAndReturn(run func
 	return _c
 }
 
+// RevokeClientCertificate provides a mock function for the type MockCertificateAuthority
+func (_mock *MockCertificateAuthority) RevokeClientCertificate(serial string) error {
+	ret := _mock.Called(serial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeClientCertificate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string) error); ok {
+		r0 = returnFunc(serial)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockCertificateAuthority_RevokeClientCertificate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeClientCertificate'
+type MockCertificateAuthority_RevokeClientCertificate_Call struct {
+	*mock.Call
+}
+
+// RevokeClientCertificate is a helper method to define mock.On call
+//   - serial string
+func (_e *MockCertificateAuthority_Expecter) RevokeClientCertificate(serial any) *MockCertificateAuthority_RevokeClientCertificate_Call {
+	return &MockCertificateAuthority_RevokeClientCertificate_Call{Call: _e.mock.On("RevokeClientCertificate", serial)}
+}
+
+func (_c *MockCertificateAuthority_RevokeClientCertificate_Call) Run(run func(serial string)) *MockCertificateAuthority_RevokeClientCertificate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockCertificateAuthority_RevokeClientCertificate_Call) Return(err error) *MockCertificateAuthority_RevokeClientCertificate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockCertificateAuthority_RevokeClientCertificate_Call) RunAndReturn(run func(serial string) error) *MockCertificateAuthority_RevokeClientCertificate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RootCertificate provides a mock function for the type MockCertificateAuthority
 func (_mock *MockCertificateAuthority) RootCertificate() []byte {
 	ret := _mock.Called()
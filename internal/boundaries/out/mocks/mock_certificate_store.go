@@ -39,8 +39,8 @@ func (_m *MockCertificateStore) EXPECT() *MockCertificateStore_Expecter {
 }
 
 // LoadAccount provides a mock function for the type MockCertificateStore
-func (_mock *MockCertificateStore) LoadAccount(ctx context.Context) (*out.ACMEAccount, error) {
-	ret := _mock.Called(ctx)
+func (_mock *MockCertificateStore) LoadAccount(ctx context.Context, directoryURL string) (*out.ACMEAccount, error) {
+	ret := _mock.Called(ctx, directoryURL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LoadAccount")
@@ -48,18 +48,18 @@ func (_mock *MockCertificateStore) LoadAccount(ctx context.Context) (*out.ACMEAc
 
 	var r0 *out.ACMEAccount
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*out.ACMEAccount, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*out.ACMEAccount, error)); ok {
+		return returnFunc(ctx, directoryURL)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *out.ACMEAccount); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *out.ACMEAccount); ok {
+		r0 = returnFunc(ctx, directoryURL)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*out.ACMEAccount)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, directoryURL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -73,18 +73,24 @@ type MockCertificateStore_LoadAccount_Call struct {
 
 // LoadAccount is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockCertificateStore_Expecter) LoadAccount(ctx any) *MockCertificateStore_LoadAccount_Call {
-	return &MockCertificateStore_LoadAccount_Call{Call: _e.mock.On("LoadAccount", ctx)}
+//   - directoryURL string
+func (_e *MockCertificateStore_Expecter) LoadAccount(ctx any, directoryURL any) *MockCertificateStore_LoadAccount_Call {
+	return &MockCertificateStore_LoadAccount_Call{Call: _e.mock.On("LoadAccount", ctx, directoryURL)}
 }
 
-func (_c *MockCertificateStore_LoadAccount_Call) Run(run func(ctx context.Context)) *MockCertificateStore_LoadAccount_Call {
+func (_c *MockCertificateStore_LoadAccount_Call) Run(run func(ctx context.Context, directoryURL string)) *MockCertificateStore_LoadAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -95,7 +101,7 @@ func (_c *MockCertificateStore_LoadAccount_Call) Return(aCMEAccount *out.ACMEAcc
 	return _c
 }
 
-func (_c *MockCertificateStore_LoadAccount_Call) RunAndReturn(run func(ctx context.Context) (*out.ACMEAccount, error)) *MockCertificateStore_LoadAccount_Call {
+func (_c *MockCertificateStore_LoadAccount_Call) RunAndReturn(run func(ctx context.Context, directoryURL string) (*out.ACMEAccount, error)) *MockCertificateStore_LoadAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1,105 +0,0 @@
-// Code generated by mockery; DO NOT EDIT.
-// github.com/vektra/mockery
-// template: testify
-
-package mocks
-
-import (
-	"context"
-
-	"github.com/bnema/gordon/internal/boundaries/out"
-	mock "github.com/stretchr/testify/mock"
-)
-
-// NewMockCloudflareZoneResolver creates a new instance of MockCloudflareZoneResolver. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockCloudflareZoneResolver(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockCloudflareZoneResolver {
-	mock := &MockCloudflareZoneResolver{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockCloudflareZoneResolver is an autogenerated mock type for the CloudflareZoneResolver type
-type MockCloudflareZoneResolver struct {
-	mock.Mock
-}
-
-type MockCloudflareZoneResolver_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockCloudflareZoneResolver) EXPECT() *MockCloudflareZoneResolver_Expecter {
-	return &MockCloudflareZoneResolver_Expecter{mock: &_m.Mock}
-}
-
-// FindZone provides a mock function for the type MockCloudflareZoneResolver
-func (_mock *MockCloudflareZoneResolver) FindZone(ctx context.Context, domain string) (out.CloudflareZone, error) {
-	ret := _mock.Called(ctx, domain)
-
-	if len(ret) == 0 {
-		panic("no return value specified for FindZone")
-	}
-
-	var r0 out.CloudflareZone
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (out.CloudflareZone, error)); ok {
-		return returnFunc(ctx, domain)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) out.CloudflareZone); ok {
-		r0 = returnFunc(ctx, domain)
-	} else {
-		r0 = ret.Get(0).(out.CloudflareZone)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, domain)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockCloudflareZoneResolver_FindZone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindZone'
-type MockCloudflareZoneResolver_FindZone_Call struct {
-	*mock.Call
-}
-
-// FindZone is a helper method to define mock.On call
-//   - ctx context.Context
-//   - domain string
-func (_e *MockCloudflareZoneResolver_Expecter) FindZone(ctx any, domain any) *MockCloudflareZoneResolver_FindZone_Call {
-	return &MockCloudflareZoneResolver_FindZone_Call{Call: _e.mock.On("FindZone", ctx, domain)}
-}
-
-func (_c *MockCloudflareZoneResolver_FindZone_Call) Run(run func(ctx context.Context, domain string)) *MockCloudflareZoneResolver_FindZone_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 context.Context
-		if args[0] != nil {
-			arg0 = args[0].(context.Context)
-		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		run(
-			arg0,
-			arg1,
-		)
-	})
-	return _c
-}
-
-func (_c *MockCloudflareZoneResolver_FindZone_Call) Return(cloudflareZone out.CloudflareZone, err error) *MockCloudflareZoneResolver_FindZone_Call {
-	_c.Call.Return(cloudflareZone, err)
-	return _c
-}
-
-func (_c *MockCloudflareZoneResolver_FindZone_Call) RunAndReturn(run func(ctx context.Context, domain string) (out.CloudflareZone, error)) *MockCloudflareZoneResolver_FindZone_Call {
-	_c.Call.Return(run)
-	return _c
-}
@@ -103,6 +103,74 @@ func (_c *MockContainerRuntime_ConnectContainerToNetwork_Call) RunAndReturn(run
 	return _c
 }
 
+// ContainerStats provides a mock function for the type MockContainerRuntime
+func (_mock *MockContainerRuntime) ContainerStats(ctx context.Context, containerID string) (*domain.ContainerResourceUsage, error) {
+	ret := _mock.Called(ctx, containerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerStats")
+	}
+
+	var r0 *domain.ContainerResourceUsage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.ContainerResourceUsage, error)); ok {
+		return returnFunc(ctx, containerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.ContainerResourceUsage); ok {
+		r0 = returnFunc(ctx, containerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ContainerResourceUsage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, containerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockContainerRuntime_ContainerStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContainerStats'
+type MockContainerRuntime_ContainerStats_Call struct {
+	*mock.Call
+}
+
+// ContainerStats is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+func (_e *MockContainerRuntime_Expecter) ContainerStats(ctx any, containerID any) *MockContainerRuntime_ContainerStats_Call {
+	return &MockContainerRuntime_ContainerStats_Call{Call: _e.mock.On("ContainerStats", ctx, containerID)}
+}
+
+func (_c *MockContainerRuntime_ContainerStats_Call) Run(run func(ctx context.Context, containerID string)) *MockContainerRuntime_ContainerStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerRuntime_ContainerStats_Call) Return(containerResourceUsage *domain.ContainerResourceUsage, err error) *MockContainerRuntime_ContainerStats_Call {
+	_c.Call.Return(containerResourceUsage, err)
+	return _c
+}
+
+func (_c *MockContainerRuntime_ContainerStats_Call) RunAndReturn(run func(ctx context.Context, containerID string) (*domain.ContainerResourceUsage, error)) *MockContainerRuntime_ContainerStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CopyFromContainer provides a mock function for the type MockContainerRuntime
 func (_mock *MockContainerRuntime) CopyFromContainer(ctx context.Context, containerID string, srcPath string) (io.ReadCloser, error) {
 	ret := _mock.Called(ctx, containerID, srcPath)
@@ -177,6 +245,75 @@ func (_c *MockContainerRuntime_CopyFromContainer_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// CopyToContainer provides a mock function for the type MockContainerRuntime
+func (_mock *MockContainerRuntime) CopyToContainer(ctx context.Context, containerID string, dstDir string, files []domain.ContainerFile) error {
+	ret := _mock.Called(ctx, containerID, dstDir, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CopyToContainer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []domain.ContainerFile) error); ok {
+		r0 = returnFunc(ctx, containerID, dstDir, files)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockContainerRuntime_CopyToContainer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CopyToContainer'
+type MockContainerRuntime_CopyToContainer_Call struct {
+	*mock.Call
+}
+
+// CopyToContainer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+//   - dstDir string
+//   - files []domain.ContainerFile
+func (_e *MockContainerRuntime_Expecter) CopyToContainer(ctx any, containerID any, dstDir any, files any) *MockContainerRuntime_CopyToContainer_Call {
+	return &MockContainerRuntime_CopyToContainer_Call{Call: _e.mock.On("CopyToContainer", ctx, containerID, dstDir, files)}
+}
+
+func (_c *MockContainerRuntime_CopyToContainer_Call) Run(run func(ctx context.Context, containerID string, dstDir string, files []domain.ContainerFile)) *MockContainerRuntime_CopyToContainer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []domain.ContainerFile
+		if args[3] != nil {
+			arg3 = args[3].([]domain.ContainerFile)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerRuntime_CopyToContainer_Call) Return(err error) *MockContainerRuntime_CopyToContainer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockContainerRuntime_CopyToContainer_Call) RunAndReturn(run func(ctx context.Context, containerID string, dstDir string, files []domain.ContainerFile) error) *MockContainerRuntime_CopyToContainer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateContainer provides a mock function for the type MockContainerRuntime
 func (_mock *MockContainerRuntime) CreateContainer(ctx context.Context, config *domain.ContainerConfig) (*domain.Container, error) {
 	ret := _mock.Called(ctx, config)
@@ -2261,6 +2398,69 @@ func (_c *MockContainerRuntime_RestartContainer_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// SignalContainer provides a mock function for the type MockContainerRuntime
+func (_mock *MockContainerRuntime) SignalContainer(ctx context.Context, containerID string, signal string) error {
+	ret := _mock.Called(ctx, containerID, signal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SignalContainer")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, containerID, signal)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockContainerRuntime_SignalContainer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SignalContainer'
+type MockContainerRuntime_SignalContainer_Call struct {
+	*mock.Call
+}
+
+// SignalContainer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+//   - signal string
+func (_e *MockContainerRuntime_Expecter) SignalContainer(ctx any, containerID any, signal any) *MockContainerRuntime_SignalContainer_Call {
+	return &MockContainerRuntime_SignalContainer_Call{Call: _e.mock.On("SignalContainer", ctx, containerID, signal)}
+}
+
+func (_c *MockContainerRuntime_SignalContainer_Call) Run(run func(ctx context.Context, containerID string, signal string)) *MockContainerRuntime_SignalContainer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerRuntime_SignalContainer_Call) Return(err error) *MockContainerRuntime_SignalContainer_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockContainerRuntime_SignalContainer_Call) RunAndReturn(run func(ctx context.Context, containerID string, signal string) error) *MockContainerRuntime_SignalContainer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StartContainer provides a mock function for the type MockContainerRuntime
 func (_mock *MockContainerRuntime) StartContainer(ctx context.Context, containerID string) error {
 	ret := _mock.Called(ctx, containerID)
@@ -2318,6 +2518,80 @@ func (_c *MockContainerRuntime_StartContainer_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
+// StartExec provides a mock function for the type MockContainerRuntime
+func (_mock *MockContainerRuntime) StartExec(ctx context.Context, containerID string, req domain.ExecRequest) (out.ExecSession, error) {
+	ret := _mock.Called(ctx, containerID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartExec")
+	}
+
+	var r0 out.ExecSession
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.ExecRequest) (out.ExecSession, error)); ok {
+		return returnFunc(ctx, containerID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.ExecRequest) out.ExecSession); ok {
+		r0 = returnFunc(ctx, containerID, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(out.ExecSession)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.ExecRequest) error); ok {
+		r1 = returnFunc(ctx, containerID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockContainerRuntime_StartExec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StartExec'
+type MockContainerRuntime_StartExec_Call struct {
+	*mock.Call
+}
+
+// StartExec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+//   - req domain.ExecRequest
+func (_e *MockContainerRuntime_Expecter) StartExec(ctx any, containerID any, req any) *MockContainerRuntime_StartExec_Call {
+	return &MockContainerRuntime_StartExec_Call{Call: _e.mock.On("StartExec", ctx, containerID, req)}
+}
+
+func (_c *MockContainerRuntime_StartExec_Call) Run(run func(ctx context.Context, containerID string, req domain.ExecRequest)) *MockContainerRuntime_StartExec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 domain.ExecRequest
+		if args[2] != nil {
+			arg2 = args[2].(domain.ExecRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerRuntime_StartExec_Call) Return(execSession out.ExecSession, err error) *MockContainerRuntime_StartExec_Call {
+	_c.Call.Return(execSession, err)
+	return _c
+}
+
+func (_c *MockContainerRuntime_StartExec_Call) RunAndReturn(run func(ctx context.Context, containerID string, req domain.ExecRequest) (out.ExecSession, error)) *MockContainerRuntime_StartExec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // StopContainer provides a mock function for the type MockContainerRuntime
 func (_mock *MockContainerRuntime) StopContainer(ctx context.Context, containerID string) error {
 	ret := _mock.Called(ctx, containerID)
@@ -2375,6 +2649,75 @@ func (_c *MockContainerRuntime_StopContainer_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// StreamContainerOutput provides a mock function for the type MockContainerRuntime
+func (_mock *MockContainerRuntime) StreamContainerOutput(ctx context.Context, containerID string, stdout io.Writer, stderr io.Writer) error {
+	ret := _mock.Called(ctx, containerID, stdout, stderr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamContainerOutput")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, io.Writer, io.Writer) error); ok {
+		r0 = returnFunc(ctx, containerID, stdout, stderr)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockContainerRuntime_StreamContainerOutput_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamContainerOutput'
+type MockContainerRuntime_StreamContainerOutput_Call struct {
+	*mock.Call
+}
+
+// StreamContainerOutput is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+//   - stdout io.Writer
+//   - stderr io.Writer
+func (_e *MockContainerRuntime_Expecter) StreamContainerOutput(ctx any, containerID any, stdout any, stderr any) *MockContainerRuntime_StreamContainerOutput_Call {
+	return &MockContainerRuntime_StreamContainerOutput_Call{Call: _e.mock.On("StreamContainerOutput", ctx, containerID, stdout, stderr)}
+}
+
+func (_c *MockContainerRuntime_StreamContainerOutput_Call) Run(run func(ctx context.Context, containerID string, stdout io.Writer, stderr io.Writer)) *MockContainerRuntime_StreamContainerOutput_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 io.Writer
+		if args[2] != nil {
+			arg2 = args[2].(io.Writer)
+		}
+		var arg3 io.Writer
+		if args[3] != nil {
+			arg3 = args[3].(io.Writer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockContainerRuntime_StreamContainerOutput_Call) Return(err error) *MockContainerRuntime_StreamContainerOutput_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockContainerRuntime_StreamContainerOutput_Call) RunAndReturn(run func(ctx context.Context, containerID string, stdout io.Writer, stderr io.Writer) error) *MockContainerRuntime_StreamContainerOutput_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TagImage provides a mock function for the type MockContainerRuntime
 func (_mock *MockContainerRuntime) TagImage(ctx context.Context, sourceRef string, targetRef string) error {
 	ret := _mock.Called(ctx, sourceRef, targetRef)
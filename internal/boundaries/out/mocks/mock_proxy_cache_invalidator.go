@@ -78,3 +78,75 @@ func (_c *MockProxyCacheInvalidator_InvalidateTarget_Call) RunAndReturn(run func
 	_c.Run(run)
 	return _c
 }
+
+// PurgeResponseCache provides a mock function for the type MockProxyCacheInvalidator
+func (_mock *MockProxyCacheInvalidator) PurgeResponseCache(ctx context.Context, domainName string, path string) (int, error) {
+	ret := _mock.Called(ctx, domainName, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeResponseCache")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (int, error)); ok {
+		return returnFunc(ctx, domainName, path)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) int); ok {
+		r0 = returnFunc(ctx, domainName, path)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, domainName, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProxyCacheInvalidator_PurgeResponseCache_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeResponseCache'
+type MockProxyCacheInvalidator_PurgeResponseCache_Call struct {
+	*mock.Call
+}
+
+// PurgeResponseCache is a helper method to define mock.On call
+//   - ctx context.Context
+//   - domainName string
+//   - path string
+func (_e *MockProxyCacheInvalidator_Expecter) PurgeResponseCache(ctx any, domainName any, path any) *MockProxyCacheInvalidator_PurgeResponseCache_Call {
+	return &MockProxyCacheInvalidator_PurgeResponseCache_Call{Call: _e.mock.On("PurgeResponseCache", ctx, domainName, path)}
+}
+
+func (_c *MockProxyCacheInvalidator_PurgeResponseCache_Call) Run(run func(ctx context.Context, domainName string, path string)) *MockProxyCacheInvalidator_PurgeResponseCache_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProxyCacheInvalidator_PurgeResponseCache_Call) Return(n int, err error) *MockProxyCacheInvalidator_PurgeResponseCache_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockProxyCacheInvalidator_PurgeResponseCache_Call) RunAndReturn(run func(ctx context.Context, domainName string, path string) (int, error)) *MockProxyCacheInvalidator_PurgeResponseCache_Call {
+	_c.Call.Return(run)
+	return _c
+}
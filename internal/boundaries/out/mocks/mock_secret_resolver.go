@@ -97,3 +97,63 @@ func (_c *MockSecretResolver_ResolveCloudflareToken_Call) RunAndReturn(run func(
 	_c.Call.Return(run)
 	return _c
 }
+
+// ResolveTSIGSecret provides a mock function for the type MockSecretResolver
+func (_mock *MockSecretResolver) ResolveTSIGSecret(ctx context.Context) (out.SecretValue, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveTSIGSecret")
+	}
+
+	var r0 out.SecretValue
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (out.SecretValue, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) out.SecretValue); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(out.SecretValue)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSecretResolver_ResolveTSIGSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveTSIGSecret'
+type MockSecretResolver_ResolveTSIGSecret_Call struct {
+	*mock.Call
+}
+
+// ResolveTSIGSecret is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockSecretResolver_Expecter) ResolveTSIGSecret(ctx any) *MockSecretResolver_ResolveTSIGSecret_Call {
+	return &MockSecretResolver_ResolveTSIGSecret_Call{Call: _e.mock.On("ResolveTSIGSecret", ctx)}
+}
+
+func (_c *MockSecretResolver_ResolveTSIGSecret_Call) Run(run func(ctx context.Context)) *MockSecretResolver_ResolveTSIGSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockSecretResolver_ResolveTSIGSecret_Call) Return(secretValue out.SecretValue, err error) *MockSecretResolver_ResolveTSIGSecret_Call {
+	_c.Call.Return(secretValue, err)
+	return _c
+}
+
+func (_c *MockSecretResolver_ResolveTSIGSecret_Call) RunAndReturn(run func(ctx context.Context) (out.SecretValue, error)) *MockSecretResolver_ResolveTSIGSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -56,4 +56,29 @@ type CertificateAuthority interface {
 
 	// UninstallRoot removes the root CA certificate from the system trust store.
 	UninstallRoot(cert *x509.Certificate) error
+
+	// IssueClientCertificate mints a client authentication certificate for
+	// name, signed by the root CA and valid for ttl.
+	IssueClientCertificate(name string, ttl time.Duration) (*domain.ClientCertificate, error)
+
+	// RevokeClientCertificate adds the serial (hex) to the client
+	// certificate revocation list.
+	RevokeClientCertificate(serial string) error
+
+	// ClientRevocationList returns the client certificate revocation list
+	// in PEM format, signed by the root CA.
+	ClientRevocationList() ([]byte, error)
+
+	// IsClientCertificateRevoked reports whether a client certificate issued
+	// by the root CA is on the revocation list.
+	IsClientCertificateRevoked(cert *x509.Certificate) bool
+
+	// ClientCAPool returns the trust pool for client certificates: the root
+	// CA when bundlePath is empty, otherwise the PEM bundle at bundlePath.
+	ClientCAPool(bundlePath string) (*x509.CertPool, error)
+
+	// IssueServiceCertificate mints a server certificate for name covering
+	// the given DNS names and IP addresses, signed by the root CA and valid
+	// for ttl. When sans is empty, name is the only SAN.
+	IssueServiceCertificate(name string, sans []string, ttl time.Duration) (*domain.ServiceCertificate, error)
 }
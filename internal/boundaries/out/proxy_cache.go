@@ -8,8 +8,11 @@ import (
 // ProxyCacheInvalidator defines the contract for synchronously invalidating
 // proxy target cache entries. This is used during zero-downtime deployments
 // to ensure the proxy stops routing to an old container before it is stopped.
+// PurgeResponseCache drops cached responses once a route serves a different
+// container image.
 type ProxyCacheInvalidator interface {
 	InvalidateTarget(ctx context.Context, domainName string)
+	PurgeResponseCache(ctx context.Context, domainName, path string) (int, error)
 }
 
 // ProxyDrainWaiter defines the contract for waiting until no in-flight
@@ -29,10 +29,25 @@ type StoredCertificate struct {
 	PrivateKeyPEM []byte
 	NotAfter      time.Time
 	LastError     string
+	// RenewalFailures counts consecutive failed renewals; it is persisted so
+	// the renewal alert survives restarts.
+	RenewalFailures int
+	OCSP            *OCSPStaple
 }
 
-// ACMEAccount represents a stored ACME account registration.
+// OCSPStaple is a cached OCSP response for a stored certificate. Response is
+// the DER-encoded response as stapled in the TLS handshake.
+type OCSPStaple struct {
+	Response   []byte
+	Status     domain.OCSPStatus
+	ThisUpdate time.Time
+	NextUpdate time.Time
+}
+
+// ACMEAccount represents a stored ACME account registration. Accounts are
+// specific to the certificate authority identified by DirectoryURL.
 type ACMEAccount struct {
+	DirectoryURL    string
 	Email           string
 	PrivateKeyPEM   []byte
 	RegistrationURI string
@@ -50,8 +65,9 @@ type SecretValue struct {
 	Source domain.ACMETokenSource
 }
 
-// CloudflareZone represents a Cloudflare DNS zone.
-type CloudflareZone struct {
+// DNSZone represents an authoritative DNS zone. ID is provider-specific and
+// may be empty.
+type DNSZone struct {
 	ID   string
 	Name string
 }
@@ -66,6 +82,16 @@ type HTTPChallengeSink interface {
 	CleanUp(token string) error
 }
 
+// TLSALPNChallengeSink defines the contract for storing TLS-ALPN-01 challenge
+// certificates while an ACME issuer completes domain validation.
+type TLSALPNChallengeSink interface {
+	// Present stores the challenge certificate for a domain.
+	Present(domain string, cert tls.Certificate) error
+
+	// CleanUp removes the challenge certificate for a domain.
+	CleanUp(domain string) error
+}
+
 // PublicCertificateIssuer defines the contract for obtaining and renewing
 // TLS certificates via ACME.
 type PublicCertificateIssuer interface {
@@ -76,12 +102,21 @@ type PublicCertificateIssuer interface {
 	Renew(ctx context.Context, cert StoredCertificate) (*StoredCertificate, error)
 }
 
+// OCSPResponder defines the contract for fetching OCSP responses for
+// certificates issued via ACME.
+type OCSPResponder interface {
+	// FetchOCSP queries the responder named in the certificate. It returns
+	// domain.ErrOCSPNotSupported when the certificate names no responder.
+	FetchOCSP(ctx context.Context, cert StoredCertificate) (*OCSPStaple, error)
+}
+
 // CertificateStore defines the contract for persisting ACME accounts and certificates.
 type CertificateStore interface {
-	// LoadAccount retrieves an ACME account.
-	LoadAccount(ctx context.Context) (*ACMEAccount, error)
+	// LoadAccount retrieves the ACME account registered with the CA at
+	// directoryURL. It returns nil when no account exists.
+	LoadAccount(ctx context.Context, directoryURL string) (*ACMEAccount, error)
 
-	// SaveAccount persists an ACME account.
+	// SaveAccount persists an ACME account under its DirectoryURL.
 	SaveAccount(ctx context.Context, account ACMEAccount) error
 
 	// LoadAll returns all stored certificates.
@@ -100,14 +135,18 @@ type CertificateStore interface {
 	Lock(ctx context.Context) (unlock func() error, err error)
 }
 
-// SecretResolver defines the contract for resolving Cloudflare API tokens.
+// SecretResolver defines the contract for resolving DNS provider credentials.
 type SecretResolver interface {
 	// ResolveCloudflareToken retrieves the Cloudflare API token from the configured source.
 	ResolveCloudflareToken(ctx context.Context) (SecretValue, error)
+
+	// ResolveTSIGSecret retrieves the RFC 2136 TSIG secret from the configured source.
+	ResolveTSIGSecret(ctx context.Context) (SecretValue, error)
 }
 
-// CloudflareZoneResolver defines the contract for resolving DNS zones.
-type CloudflareZoneResolver interface {
-	// FindZone finds the Cloudflare zone for the given domain.
-	FindZone(ctx context.Context, domain string) (CloudflareZone, error)
+// DNSZoneResolver defines the contract for resolving the authoritative DNS
+// zone of a hostname for DNS-01 challenges.
+type DNSZoneResolver interface {
+	// FindZone finds the DNS zone for the given domain.
+	FindZone(ctx context.Context, domain string) (DNSZone, error)
 }
@@ -0,0 +1,22 @@
+package out
+
+import (
+	"context"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// ResponseCacheStore stores responses for the proxy edge cache.
+// Implementations drop entries once they are expired.
+type ResponseCacheStore interface {
+	// Get returns the entry stored under key, if any.
+	Get(ctx context.Context, key string) (*domain.CachedResponse, bool)
+
+	// Put stores entry under entry.Key, replacing any previous entry.
+	Put(ctx context.Context, entry *domain.CachedResponse) error
+
+	// Purge removes the entries of a route domain and returns how many were
+	// removed. An empty path purges the whole domain; otherwise only entries
+	// for that exact request path are removed, whatever their query string.
+	Purge(ctx context.Context, domainName, path string) (int, error)
+}
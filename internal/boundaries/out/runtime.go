@@ -18,6 +18,7 @@ type ContainerRuntime interface {
 	StartContainer(ctx context.Context, containerID string) error
 	StopContainer(ctx context.Context, containerID string) error
 	RestartContainer(ctx context.Context, containerID string) error
+	SignalContainer(ctx context.Context, containerID, signal string) error
 	RemoveContainer(ctx context.Context, containerID string, force bool) error
 	RenameContainer(ctx context.Context, containerID, newName string) error
 
@@ -25,6 +26,9 @@ type ContainerRuntime interface {
 	ListContainers(ctx context.Context, all bool) ([]*domain.Container, error)
 	InspectContainer(ctx context.Context, containerID string) (*domain.Container, error)
 	GetContainerLogs(ctx context.Context, containerID string, follow bool) (io.ReadCloser, error)
+	// StreamContainerOutput copies a container's output to stdout and stderr
+	// until it exits. Containers with a TTY write everything to stdout.
+	StreamContainerOutput(ctx context.Context, containerID string, stdout, stderr io.Writer) error
 
 	// Image operations
 	PullImage(ctx context.Context, image string) error
@@ -41,6 +45,7 @@ type ContainerRuntime interface {
 	// Health and status
 	IsContainerRunning(ctx context.Context, containerID string) (bool, error)
 	GetContainerHealthStatus(ctx context.Context, containerID string) (status string, hasHealthcheck bool, err error)
+	ContainerStats(ctx context.Context, containerID string) (*domain.ContainerResourceUsage, error)
 	GetContainerPort(ctx context.Context, containerID string, internalPort int) (int, error)
 
 	// Image and port inspection
@@ -67,7 +72,9 @@ type ContainerRuntime interface {
 
 	// In-container operations
 	ExecInContainer(ctx context.Context, containerID string, cmd []string) (*ExecResult, error)
+	StartExec(ctx context.Context, containerID string, req domain.ExecRequest) (ExecSession, error)
 	CopyFromContainer(ctx context.Context, containerID, srcPath string) (io.ReadCloser, error)
+	CopyToContainer(ctx context.Context, containerID, dstDir string, files []domain.ContainerFile) error
 
 	// Network management
 	CreateNetwork(ctx context.Context, name string, config domain.NetworkConfig) error
@@ -89,3 +96,19 @@ type ExecResult struct {
 	Stdout   []byte
 	Stderr   []byte
 }
+
+// ExecSession is an interactive command started in a container with
+// StartExec. Writes go to the command's stdin.
+type ExecSession interface {
+	io.WriteCloser
+
+	// CloseStdin closes the command's stdin so it sees end of input.
+	CloseStdin() error
+
+	// Resize sets the terminal size of a TTY session.
+	Resize(ctx context.Context, rows, cols uint) error
+
+	// Wait copies the command's output to stdout and stderr until it exits
+	// and returns its exit code. A TTY session writes everything to stdout.
+	Wait(ctx context.Context, stdout, stderr io.Writer) (int, error)
+}
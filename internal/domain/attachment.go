@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// AttachmentReadinessAuto runs the built-in cascade: Docker healthcheck,
+	// then a TCP probe of the first exposed port, then the readiness delay.
+	AttachmentReadinessAuto    = "auto"
+	AttachmentReadinessTCP     = "tcp"
+	AttachmentReadinessCommand = "command"
+	AttachmentReadinessLog     = "log"
+)
+
+// AttachmentReadiness declares when an attachment is ready to serve the route
+// that depends on it. The route container is not started until every
+// attachment of its domain and network group passes its check.
+type AttachmentReadiness struct {
+	Type     string
+	Port     int           // tcp: container port, defaults to the first exposed port
+	Command  []string      // command: run in the container, ready on exit code 0
+	Contains string        // log: text the container output must contain
+	Timeout  time.Duration // 0 uses deploy.attachment_readiness_timeout
+}
+
+// Declared reports whether the readiness replaces the built-in cascade.
+func (r AttachmentReadiness) Declared() bool {
+	return r.Type != "" && r.Type != AttachmentReadinessAuto
+}
+
+// Validate checks that the readiness of the attachment called name carries
+// the fields its type needs.
+func (r AttachmentReadiness) Validate(name string) error {
+	if r.Timeout < 0 {
+		return fmt.Errorf("attachment %q readiness timeout must be positive when set", name)
+	}
+	if r.Port < 0 || r.Port > 65535 {
+		return fmt.Errorf("attachment %q readiness port %d is out of range", name, r.Port)
+	}
+	switch r.Type {
+	case "", AttachmentReadinessAuto, AttachmentReadinessTCP:
+		return nil
+	case AttachmentReadinessCommand:
+		if len(r.Command) == 0 || strings.TrimSpace(r.Command[0]) == "" {
+			return fmt.Errorf("attachment %q command readiness requires a command", name)
+		}
+		return nil
+	case AttachmentReadinessLog:
+		if strings.TrimSpace(r.Contains) == "" {
+			return fmt.Errorf("attachment %q log readiness contains is required", name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("attachment %q readiness type must be auto, tcp, command, or log", name)
+	}
+}
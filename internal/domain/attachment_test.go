@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentReadinessValidate(t *testing.T) {
+	valid := []AttachmentReadiness{
+		{},
+		{Type: AttachmentReadinessAuto},
+		{Type: AttachmentReadinessTCP},
+		{Type: AttachmentReadinessTCP, Port: 5432, Timeout: time.Minute},
+		{Type: AttachmentReadinessCommand, Command: []string{"pg_isready", "-U", "postgres"}},
+		{Type: AttachmentReadinessLog, Contains: "ready to accept connections"},
+	}
+	for _, readiness := range valid {
+		require.NoError(t, readiness.Validate("postgres"), "%+v", readiness)
+	}
+
+	invalid := map[string]AttachmentReadiness{
+		"must be auto, tcp, command, or log": {Type: "http"},
+		"requires a command":                 {Type: AttachmentReadinessCommand},
+		"contains is required":               {Type: AttachmentReadinessLog},
+		"out of range":                       {Type: AttachmentReadinessTCP, Port: 70000},
+		"timeout must be positive":           {Type: AttachmentReadinessTCP, Timeout: -time.Second},
+	}
+	for want, readiness := range invalid {
+		require.ErrorContains(t, readiness.Validate("postgres"), want)
+	}
+}
+
+func TestAttachmentReadinessDeclared(t *testing.T) {
+	assert.False(t, AttachmentReadiness{}.Declared())
+	assert.False(t, AttachmentReadiness{Type: AttachmentReadinessAuto}.Declared())
+	assert.True(t, AttachmentReadiness{Type: AttachmentReadinessCommand, Command: []string{"true"}}.Declared())
+}
@@ -82,6 +82,7 @@ const (
 	AdminResourceStatus  = "status"
 	AdminResourceLogs    = "logs"
 	AdminResourceVolumes = "volumes"
+	AdminResourceExec    = "exec"
 	AdminResourceAll     = "*"
 )
 
@@ -169,7 +170,7 @@ func (s *Scope) IsRepositoryScope() bool {
 // AdminScope represents an admin API scope (admin:resource:actions).
 // Format: admin:routes:read,write or admin:*:* for full access.
 type AdminScope struct {
-	Resource string   // routes, secrets, config, status, logs, volumes, or *
+	Resource string   // routes, secrets, config, status, logs, volumes, exec, or *
 	Actions  []string // read, write, or *
 }
 
@@ -310,6 +311,12 @@ func AdminScopeVolumes(actions ...string) string {
 	return fmt.Sprintf("%s:%s:%s", ScopeTypeAdmin, AdminResourceVolumes, strings.Join(actions, ","))
 }
 
+// AdminScopeExec creates an admin scope for interactive exec sessions with
+// the given actions.
+func AdminScopeExec(actions ...string) string {
+	return fmt.Sprintf("%s:%s:%s", ScopeTypeAdmin, AdminResourceExec, strings.Join(actions, ","))
+}
+
 // AuthStatus represents status of an authentication session.
 type AuthStatus struct {
 	Valid     bool
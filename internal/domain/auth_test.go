@@ -519,6 +519,20 @@ func TestScopesGrantAdminAccess(t *testing.T) {
 			action:   AdminActionRead,
 			want:     true,
 		},
+		{
+			name:     "exec write grants exec resource",
+			granted:  []string{"admin:exec:write"},
+			resource: AdminResourceExec,
+			action:   AdminActionWrite,
+			want:     true,
+		},
+		{
+			name:     "config write does not grant exec resource",
+			granted:  []string{"admin:config:write", "admin:secrets:read"},
+			resource: AdminResourceExec,
+			action:   AdminActionWrite,
+			want:     false,
+		},
 		{
 			name:     "repository scope is ignored for admin access",
 			granted:  []string{"repository:myrepo:push"},
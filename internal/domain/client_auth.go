@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ClientAuthMode controls whether a route asks TLS clients for a certificate.
+type ClientAuthMode string
+
+const (
+	// ClientAuthRequire rejects requests without a verified client certificate.
+	ClientAuthRequire ClientAuthMode = "require"
+	// ClientAuthOptional asks for a certificate and verifies it when one is
+	// sent, but lets requests without one through.
+	ClientAuthOptional ClientAuthMode = "optional"
+)
+
+// ParseClientAuthMode parses a route client_auth value. "off" and the empty
+// string disable client authentication and return "".
+func ParseClientAuthMode(value string) (ClientAuthMode, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "off":
+		return "", nil
+	case string(ClientAuthRequire):
+		return ClientAuthRequire, nil
+	case string(ClientAuthOptional):
+		return ClientAuthOptional, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrClientAuthInvalid, value)
+	}
+}
+
+// ClientAuthPolicy is the client certificate requirement of a route.
+type ClientAuthPolicy struct {
+	Mode   ClientAuthMode
+	CAFile string // PEM trust bundle for client certificates ("" = Gordon's root CA)
+}
+
+// ClientCertificate is a client certificate minted by Gordon's CA.
+type ClientCertificate struct {
+	Name        string
+	Serial      string // Lowercase hex serial number, as used for revocation
+	Fingerprint string // Lowercase hex SHA-256 of the DER certificate
+	NotAfter    time.Time
+	CertPEM     []byte
+	KeyPEM      []byte
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClientAuthMode(t *testing.T) {
+	for value, want := range map[string]ClientAuthMode{
+		"":          "",
+		"off":       "",
+		"require":   ClientAuthRequire,
+		" Require ": ClientAuthRequire,
+		"optional":  ClientAuthOptional,
+	} {
+		got, err := ParseClientAuthMode(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseClientAuthMode("verify")
+	assert.ErrorIs(t, err, ErrClientAuthInvalid)
+}
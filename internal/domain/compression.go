@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Compression algorithms supported for proxied responses, named by their
+// Content-Encoding token.
+const (
+	CompressionZstd   = "zstd"
+	CompressionBrotli = "br"
+	CompressionGzip   = "gzip"
+)
+
+// DefaultCompressionMinSize is the smallest response body worth compressing.
+const DefaultCompressionMinSize = 1024
+
+// CompressionPolicy controls compression of proxied responses.
+type CompressionPolicy struct {
+	Enabled      bool
+	Algorithms   []string // Content-Encoding tokens in server preference order
+	MinSize      int64    // Responses with a smaller Content-Length are sent as-is
+	ContentTypes []string // Media types to compress; a trailing "/" matches a whole family ("text/")
+}
+
+// DefaultCompressionAlgorithms returns the default server preference order.
+func DefaultCompressionAlgorithms() []string {
+	return []string{CompressionZstd, CompressionBrotli, CompressionGzip}
+}
+
+// DefaultCompressionContentTypes returns the media types compressed by default.
+func DefaultCompressionContentTypes() []string {
+	return []string{
+		"text/",
+		"application/javascript",
+		"application/json",
+		"application/manifest+json",
+		"application/wasm",
+		"application/xml",
+		"application/xhtml+xml",
+		"application/rss+xml",
+		"application/atom+xml",
+		"image/svg+xml",
+		"font/ttf",
+		"font/otf",
+	}
+}
+
+// ValidateCompressionAlgorithms checks that every algorithm is supported.
+func ValidateCompressionAlgorithms(algorithms []string) error {
+	for _, algorithm := range algorithms {
+		switch algorithm {
+		case CompressionZstd, CompressionBrotli, CompressionGzip:
+		default:
+			return fmt.Errorf("%w: unsupported compression algorithm %q", ErrInvalidConfig, algorithm)
+		}
+	}
+	return nil
+}
+
+// MatchesContentType reports whether a Content-Type header value is in the
+// policy allowlist. Parameters such as charset are ignored.
+func (p CompressionPolicy) MatchesContentType(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" {
+		return false
+	}
+	for _, allowed := range p.ContentTypes {
+		allowed = strings.ToLower(allowed)
+		if strings.HasSuffix(allowed, "/") {
+			if strings.HasPrefix(mediaType, allowed) {
+				return true
+			}
+			continue
+		}
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionPolicyMatchesContentType(t *testing.T) {
+	policy := CompressionPolicy{ContentTypes: []string{"text/", "application/json"}}
+
+	assert.True(t, policy.MatchesContentType("text/html; charset=utf-8"))
+	assert.True(t, policy.MatchesContentType("Text/CSS"))
+	assert.True(t, policy.MatchesContentType("application/json"))
+	assert.False(t, policy.MatchesContentType("application/jsonp"))
+	assert.False(t, policy.MatchesContentType("image/png"))
+	assert.False(t, policy.MatchesContentType(""))
+}
+
+func TestValidateCompressionAlgorithms(t *testing.T) {
+	require.NoError(t, ValidateCompressionAlgorithms(DefaultCompressionAlgorithms()))
+	assert.ErrorIs(t, ValidateCompressionAlgorithms([]string{"gzip", "lz4"}), ErrInvalidConfig)
+}
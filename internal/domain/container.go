@@ -95,6 +95,18 @@ type ContainerConfig struct {
 	User            string            // User to run as
 	CapDrop         []string          // Linux capabilities to drop; nil uses runtime compat defaults
 	CapAdd          []string          // Linux capabilities to add; nil uses runtime compat defaults
+	ExtraCapAdd     []string          // Linux capabilities added on top of CapAdd or the runtime defaults
+	Tmpfs           map[string]string // tmpfs mounts: container path -> mount options
+	Tty             bool              // Allocate a pseudo-terminal
+}
+
+// ContainerFile is a file written into a container directory.
+type ContainerFile struct {
+	Name string // File name relative to the destination directory
+	Data []byte
+	Mode int64
+	UID  int
+	GID  int
 }
 
 // ContainerStatus represents the current state of a container.
@@ -106,7 +118,11 @@ const (
 	ContainerStatusCreated ContainerStatus = "created"
 	ContainerStatusExited  ContainerStatus = "exited"
 	ContainerStatusPaused  ContainerStatus = "paused"
+	ContainerStatusDead    ContainerStatus = "dead"
 	ContainerStatusUnknown ContainerStatus = "unknown"
+	// ContainerStatusSleeping marks a route container stopped by its idle
+	// timeout. It is still tracked and is started again on the next request.
+	ContainerStatusSleeping ContainerStatus = "sleeping"
 )
 
 const (
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrContainerOverrideInvalid is returned for a malformed per-route container setting.
+var ErrContainerOverrideInvalid = errors.New("invalid container override")
+
+// ContainerOverrides holds the container settings a route or image sets
+// explicitly; zero fields inherit the global container defaults.
+type ContainerOverrides struct {
+	MemoryLimit int64             // Memory limit in bytes
+	NanoCPUs    int64             // CPU quota in nanoseconds (1e9 = 1 core)
+	PidsLimit   int64             // Max number of PIDs
+	Command     []string          // Replaces the image CMD
+	User        string            // User to run as
+	WorkingDir  string            // Working directory inside the container
+	CapAdd      []string          // Capabilities added on top of the security profile
+	Tmpfs       map[string]string // tmpfs mounts: container path -> mount options
+}
+
+// IsZero reports whether no setting is overridden.
+func (o *ContainerOverrides) IsZero() bool {
+	return o == nil || (o.MemoryLimit == 0 && o.NanoCPUs == 0 && o.PidsLimit == 0 &&
+		len(o.Command) == 0 && o.User == "" && o.WorkingDir == "" && len(o.CapAdd) == 0 && len(o.Tmpfs) == 0)
+}
+
+// Merge returns o with the settings of over applied on top. Capabilities
+// and tmpfs mounts are combined; every other set field of over wins.
+func (o *ContainerOverrides) Merge(over *ContainerOverrides) *ContainerOverrides {
+	if over.IsZero() {
+		return o
+	}
+	if o.IsZero() {
+		return over
+	}
+	merged := *o
+	if over.MemoryLimit != 0 {
+		merged.MemoryLimit = over.MemoryLimit
+	}
+	if over.NanoCPUs != 0 {
+		merged.NanoCPUs = over.NanoCPUs
+	}
+	if over.PidsLimit != 0 {
+		merged.PidsLimit = over.PidsLimit
+	}
+	if len(over.Command) > 0 {
+		merged.Command = over.Command
+	}
+	if over.User != "" {
+		merged.User = over.User
+	}
+	if over.WorkingDir != "" {
+		merged.WorkingDir = over.WorkingDir
+	}
+	merged.CapAdd = slices.Compact(slices.Sorted(slices.Values(append(slices.Clone(o.CapAdd), over.CapAdd...))))
+	if len(over.Tmpfs) > 0 {
+		merged.Tmpfs = maps.Clone(o.Tmpfs)
+		if merged.Tmpfs == nil {
+			merged.Tmpfs = map[string]string{}
+		}
+		maps.Copy(merged.Tmpfs, over.Tmpfs)
+	}
+	return &merged
+}
+
+// ConfigHash returns a SHA-256 hash of the overrides, or "" when nothing is
+// overridden. Deployed containers carry it so a changed setting triggers a
+// redeploy.
+func (o *ContainerOverrides) ConfigHash() string {
+	if o.IsZero() {
+		return ""
+	}
+	canonical := *o
+	canonical.CapAdd = slices.Sorted(slices.Values(o.CapAdd))
+	// json.Marshal sorts map keys, so equal overrides hash equally.
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var capabilityPattern = regexp.MustCompile(`^[A-Z][A-Z_]*$`)
+
+// NormalizeCapability returns name as an upper-case Linux capability without
+// the CAP_ prefix, e.g. "cap_sys_ptrace" becomes "SYS_PTRACE".
+func NormalizeCapability(name string) (string, error) {
+	normalized := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(name)), "CAP_")
+	if !capabilityPattern.MatchString(normalized) || normalized == "ALL" {
+		return "", fmt.Errorf("%w: capability %q", ErrContainerOverrideInvalid, name)
+	}
+	return normalized, nil
+}
+
+// ParseTmpfsMount splits a "path[:options]" tmpfs spec, e.g. "/tmp:size=64m".
+func ParseTmpfsMount(spec string) (string, string, error) {
+	mountPath, options, _ := strings.Cut(strings.TrimSpace(spec), ":")
+	if !path.IsAbs(mountPath) || path.Clean(mountPath) == "/" {
+		return "", "", fmt.Errorf("%w: tmpfs %q needs an absolute path other than /", ErrContainerOverrideInvalid, spec)
+	}
+	return path.Clean(mountPath), options, nil
+}
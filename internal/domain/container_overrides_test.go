@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainerOverrides_Merge(t *testing.T) {
+	var none *ContainerOverrides
+	labels := &ContainerOverrides{MemoryLimit: 256 << 20, User: "app", CapAdd: []string{"SYS_NICE"}, Tmpfs: map[string]string{"/tmp": ""}}
+	route := &ContainerOverrides{MemoryLimit: 4 << 30, CapAdd: []string{"NET_RAW"}, Tmpfs: map[string]string{"/tmp": "size=64m"}}
+
+	assert.Same(t, labels, labels.Merge(none))
+	assert.Same(t, route, none.Merge(route))
+	assert.Equal(t, &ContainerOverrides{
+		MemoryLimit: 4 << 30,
+		User:        "app",
+		CapAdd:      []string{"NET_RAW", "SYS_NICE"},
+		Tmpfs:       map[string]string{"/tmp": "size=64m"},
+	}, labels.Merge(route))
+	assert.Equal(t, map[string]string{"/tmp": ""}, labels.Tmpfs, "merge leaves the base untouched")
+}
+
+func TestContainerOverrides_ConfigHash(t *testing.T) {
+	var none *ContainerOverrides
+	assert.Empty(t, none.ConfigHash())
+	assert.Empty(t, (&ContainerOverrides{}).ConfigHash())
+
+	a := &ContainerOverrides{NanoCPUs: 1_500_000_000, CapAdd: []string{"SYS_NICE", "NET_RAW"}, Tmpfs: map[string]string{"/tmp": "", "/run": ""}}
+	b := &ContainerOverrides{NanoCPUs: 1_500_000_000, CapAdd: []string{"NET_RAW", "SYS_NICE"}, Tmpfs: map[string]string{"/run": "", "/tmp": ""}}
+	require.NotEmpty(t, a.ConfigHash())
+	assert.Equal(t, a.ConfigHash(), b.ConfigHash())
+
+	b.NanoCPUs = 2_000_000_000
+	assert.NotEqual(t, a.ConfigHash(), b.ConfigHash())
+}
+
+func TestNormalizeCapability(t *testing.T) {
+	for name, want := range map[string]string{"SYS_NICE": "SYS_NICE", "cap_net_raw": "NET_RAW", " sys_ptrace ": "SYS_PTRACE"} {
+		got, err := NormalizeCapability(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got)
+	}
+	for _, name := range []string{"", "ALL", "NET RAW", "1CAP"} {
+		_, err := NormalizeCapability(name)
+		assert.ErrorIs(t, err, ErrContainerOverrideInvalid, name)
+	}
+}
+
+func TestParseTmpfsMount(t *testing.T) {
+	mountPath, options, err := ParseTmpfsMount("/var/cache/:size=64m,mode=1777")
+	require.NoError(t, err)
+	assert.Equal(t, "/var/cache", mountPath)
+	assert.Equal(t, "size=64m,mode=1777", options)
+
+	for _, spec := range []string{"tmp", "/", ":size=1m"} {
+		_, _, err := ParseTmpfsMount(spec)
+		assert.ErrorIs(t, err, ErrContainerOverrideInvalid, spec)
+	}
+}
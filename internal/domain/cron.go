@@ -1,15 +1,23 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // CronSchedule represents a recurring schedule.
 //
-// Preset schedules currently use fixed UTC times by design (MVP scope).
-// Timezone-aware scheduling is deferred until a timezone field is added.
-// When timezone support lands, host system timezone should be the default.
+// Exactly one of Preset, Interval or Expression is set. Preset and
+// Expression schedules are evaluated in Location; a nil Location means UTC,
+// which keeps the fixed UTC times of the internal presets. User-facing
+// schedules such as [[jobs]] default Location to the host timezone.
 type CronSchedule struct {
-	Preset   BackupSchedule
-	Interval time.Duration
+	Preset     BackupSchedule
+	Interval   time.Duration
+	Expression string         // Standard 5-field cron expression or @macro
+	Location   *time.Location // Timezone of Preset and Expression (nil = UTC)
 }
 
 // CronEntry represents a registered cron job.
@@ -21,3 +29,213 @@ type CronEntry struct {
 	NextRun  time.Time
 	Running  bool
 }
+
+// CronExpression is a parsed 5-field cron expression: minute, hour, day of
+// month, month and day of week.
+type CronExpression struct {
+	minute, hour, dom, month, dow uint64
+
+	// domStar and dowStar record whether the day fields start with "*".
+	// Like Vixie cron, a day matches when both fields match if either is a
+	// wildcard, and when either field matches if both are restricted.
+	domStar, dowStar bool
+}
+
+// cronMacros maps the supported @ shorthands to their expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinuteField = cronField{name: "minute", min: 0, max: 59}
+	cronHourField   = cronField{name: "hour", min: 0, max: 23}
+	cronDOMField    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as Sunday, folded onto 0 after parsing.
+	cronDOWField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseCronExpression parses a standard 5-field cron expression such as
+// "*/15 * * * *" or "30 2 * * mon-fri". Fields accept "*", values, ranges,
+// lists and steps; months and weekdays also accept three-letter names. The
+// @yearly, @monthly, @weekly, @daily and @hourly macros are supported too.
+func ParseCronExpression(expr string) (CronExpression, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return CronExpression{}, fmt.Errorf("cron expression %q must have 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+
+	var c CronExpression
+	var err error
+	for i, target := range []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{cronMinuteField, &c.minute},
+		{cronHourField, &c.hour},
+		{cronDOMField, &c.dom},
+		{cronMonthField, &c.month},
+		{cronDOWField, &c.dow},
+	} {
+		if *target.bits, err = target.field.parse(fields[i]); err != nil {
+			return CronExpression{}, fmt.Errorf("cron expression %q: %w", expr, err)
+		}
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow = c.dow&^(1<<7) | 1
+	}
+	c.domStar = strings.HasPrefix(fields[2], "*")
+	c.dowStar = strings.HasPrefix(fields[4], "*")
+	return c, nil
+}
+
+func (f cronField) parse(raw string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(raw, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid %s step %q", f.name, stepPart)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			loRaw, hiRaw, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(loRaw); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(hiRaw); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid %s range %q", f.name, rangePart)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rangePart); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if hasStep {
+				// "5/10" means every 10 starting at 5.
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(raw string) (int, error) {
+	if v, ok := f.names[strings.ToLower(raw)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", f.name, raw)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("%s %d out of range %d-%d", f.name, v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t that matches the expression, in t's
+// location. Wall-clock times skipped by a daylight saving change do not
+// match. When the clocks go back, an expression with a fixed hour matches a
+// repeated wall-clock time only once, like Vixie cron; one that runs every
+// hour keeps running through the repeated hour. The zero time is returned
+// when nothing matches within five years, as with "0 0 30 2 *".
+func (c CronExpression) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = cronAdvance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = cronAdvance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = cronAdvance(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 || (c.hour != cronEveryHour && cronRepeatedWallTime(t)) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// cronEveryHour is the hour field of an expression that runs every hour.
+const cronEveryHour = 1<<24 - 1
+
+// cronRepeatedWallTime reports whether t's wall-clock time already occurred
+// earlier, in the interval repeated when daylight saving time ends.
+func cronRepeatedWallTime(t time.Time) bool {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return false
+	}
+	_, offset := t.Zone()
+	_, before := start.Add(-time.Second).Zone()
+	shift := time.Duration(before-offset) * time.Second
+	return shift > 0 && t.Sub(start) < shift
+}
+
+// cronAdvance returns next, or the start of the next hour when next falls in
+// a daylight saving gap that time.Date resolved to a time before t.
+func cronAdvance(t, next time.Time) time.Time {
+	if next.After(t) {
+		return next
+	}
+	return t.Add(time.Duration(60-t.Minute()) * time.Minute)
+}
+
+func (c CronExpression) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronExpressionNext(t *testing.T) {
+	from := time.Date(2026, 2, 7, 12, 34, 20, 0, time.UTC) // Saturday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 2, 7, 12, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2026, 2, 7, 13, 0, 0, 0, time.UTC)},
+		{"30 2 * * *", time.Date(2026, 2, 8, 2, 30, 0, 0, time.UTC)},
+		{"0 9 * * mon-fri", time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 jan,jul *", time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)},
+		{"5/20 12 * * *", time.Date(2026, 2, 7, 12, 45, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: either may match.
+		{"0 0 15 * sun", time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := ParseCronExpression(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Next(from))
+		})
+	}
+}
+
+func TestCronExpressionNextSkipsMissingDSTHour(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	expr, err := ParseCronExpression("30 2 * * *")
+	require.NoError(t, err)
+
+	// 02:30 does not exist on 2026-03-08 in New York.
+	next := expr.Next(time.Date(2026, 3, 7, 12, 0, 0, 0, ny))
+
+	assert.True(t, next.Equal(time.Date(2026, 3, 9, 2, 30, 0, 0, ny)), "got %s", next)
+}
+
+func TestCronExpressionNextRunsRepeatedDSTTimeOnce(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	daily, err := ParseCronExpression("30 1 * * *")
+	require.NoError(t, err)
+
+	// 01:00-01:59 happens twice on 2026-11-01 in New York, first in EDT.
+	first := daily.Next(time.Date(2026, 10, 31, 12, 0, 0, 0, ny))
+	_, offset := first.Zone()
+	require.Equal(t, -4*60*60, offset, "got %s", first)
+
+	next := daily.Next(first)
+	assert.True(t, next.Equal(time.Date(2026, 11, 2, 1, 30, 0, 0, ny)), "got %s", next)
+
+	// A schedule that runs every hour keeps running in the repeated hour.
+	halfHourly, err := ParseCronExpression("*/30 * * * *")
+	require.NoError(t, err)
+	next = halfHourly.Next(first)
+	assert.True(t, next.Equal(first.Add(30*time.Minute)), "got %s", next)
+	assert.Equal(t, 1, next.Hour())
+}
+
+func TestCronExpressionNextNeverMatches(t *testing.T) {
+	expr, err := ParseCronExpression("0 0 30 2 *")
+	require.NoError(t, err)
+
+	assert.True(t, expr.Next(time.Now()).IsZero())
+}
+
+func TestParseCronExpressionErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"10-5 * * * *",
+		"* * * foo *",
+		"@every 5m",
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCronExpression(expr)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultReleaseTimeout bounds a release or post-deploy command when the
+// route sets no release_timeout.
+const DefaultReleaseTimeout = 10 * time.Minute
+
+// ErrReleaseCommandFailed is returned when a release command exits non-zero
+// or does not finish in time.
+var ErrReleaseCommandFailed = errors.New("release command failed")
+
+// DeployHookPhase names when a deploy hook runs.
+type DeployHookPhase string
+
+const (
+	// DeployHookRelease runs before the new container is created; a failure
+	// aborts the deploy and keeps the old container serving.
+	DeployHookRelease DeployHookPhase = "release"
+	// DeployHookPostDeploy runs once the new container serves traffic; a
+	// failure is logged but does not roll the deploy back.
+	DeployHookPostDeploy DeployHookPhase = "post-deploy"
+)
+
+// DeployHooks holds the one-off commands run with a route's new image.
+type DeployHooks struct {
+	Release    []string      // Runs before traffic switches, e.g. ["./manage", "migrate"]
+	PostDeploy []string      // Runs after traffic switched
+	Timeout    time.Duration // Limit per command (0 = DefaultReleaseTimeout)
+}
+
+// IsZero reports whether no hook is configured.
+func (h *DeployHooks) IsZero() bool {
+	return h == nil || (len(h.Release) == 0 && len(h.PostDeploy) == 0 && h.Timeout == 0)
+}
+
+// Command returns the command of phase, or nil when it is not set.
+func (h *DeployHooks) Command(phase DeployHookPhase) []string {
+	if h == nil {
+		return nil
+	}
+	if phase == DeployHookPostDeploy {
+		return h.PostDeploy
+	}
+	return h.Release
+}
+
+// EffectiveTimeout returns the per-command limit, defaulting to DefaultReleaseTimeout.
+func (h *DeployHooks) EffectiveTimeout() time.Duration {
+	if h == nil || h.Timeout <= 0 {
+		return DefaultReleaseTimeout
+	}
+	return h.Timeout
+}
+
+// Merge returns h with the set fields of over applied on top.
+func (h *DeployHooks) Merge(over *DeployHooks) *DeployHooks {
+	if over.IsZero() {
+		return h
+	}
+	if h.IsZero() {
+		return over
+	}
+	merged := *h
+	if len(over.Release) > 0 {
+		merged.Release = over.Release
+	}
+	if len(over.PostDeploy) > 0 {
+		merged.PostDeploy = over.PostDeploy
+	}
+	if over.Timeout > 0 {
+		merged.Timeout = over.Timeout
+	}
+	return &merged
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployHooks_Merge(t *testing.T) {
+	var none *DeployHooks
+	labels := &DeployHooks{Release: []string{"migrate"}, PostDeploy: []string{"warm"}}
+	route := &DeployHooks{Release: []string{"./manage", "migrate"}, Timeout: time.Minute}
+
+	assert.Same(t, labels, labels.Merge(none))
+	assert.Same(t, route, none.Merge(route))
+	assert.Equal(t, &DeployHooks{Release: []string{"./manage", "migrate"}, PostDeploy: []string{"warm"}, Timeout: time.Minute}, labels.Merge(route))
+}
+
+func TestDeployHooks_CommandAndTimeout(t *testing.T) {
+	var none *DeployHooks
+	assert.Nil(t, none.Command(DeployHookRelease))
+	assert.Equal(t, DefaultReleaseTimeout, none.EffectiveTimeout())
+
+	hooks := &DeployHooks{Release: []string{"migrate"}, PostDeploy: []string{"warm"}, Timeout: time.Minute}
+	assert.Equal(t, []string{"migrate"}, hooks.Command(DeployHookRelease))
+	assert.Equal(t, []string{"warm"}, hooks.Command(DeployHookPostDeploy))
+	assert.Equal(t, time.Minute, hooks.EffectiveTimeout())
+}
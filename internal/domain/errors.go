@@ -26,6 +26,7 @@ var (
 	ErrRouteImageEmpty    = errors.New("route image cannot be empty")
 	ErrRouteDomainInvalid = errors.New("route domain is not a valid public hostname")
 	ErrNoTargetAvailable  = errors.New("no target available for route")
+	ErrRouteWaking        = errors.New("route is waking up")
 
 	// Registry errors
 	ErrManifestNotFound   = errors.New("manifest not found")
@@ -50,13 +51,14 @@ var (
 	ErrPreviewNotFound = errors.New("preview not found")
 
 	// Config errors
-	ErrTrafficGraphRequired = errors.New("traffic graph is required")
-	ErrClientHelloTooLarge  = errors.New("client hello exceeds maximum")
-	ErrConfigNotFound       = errors.New("configuration not found")
-	ErrInvalidConfig        = errors.New("invalid configuration")
-	ErrConfigLoadFailed     = errors.New("failed to load configuration")
-	ErrInvalidDomainPattern = errors.New("invalid domain pattern")
-	ErrRouteConflict        = errors.New("route conflicts with existing configuration")
+	ErrTrafficGraphRequired   = errors.New("traffic graph is required")
+	ErrClientHelloTooLarge    = errors.New("client hello exceeds maximum")
+	ErrHTTP3ServerUnavailable = errors.New("no http3 server configured for entrypoint")
+	ErrConfigNotFound         = errors.New("configuration not found")
+	ErrInvalidConfig          = errors.New("invalid configuration")
+	ErrConfigLoadFailed       = errors.New("failed to load configuration")
+	ErrInvalidDomainPattern   = errors.New("invalid domain pattern")
+	ErrRouteConflict          = errors.New("route conflicts with existing configuration")
 
 	// Environment errors
 	ErrEnvFileNotFound             = errors.New("environment file not found")
@@ -99,17 +101,42 @@ var (
 	ErrAttachmentNotDeployed = errors.New("configured attachment is not deployed; run gordon deploy to create it")
 
 	// TLS / ACME errors
-	ErrACMEDisabled              = errors.New("acme disabled")
-	ErrACMEEmailRequired         = errors.New("acme email required")
-	ErrACMEChallengeInvalid      = errors.New("acme challenge invalid")
-	ErrDNSConfigInvalid          = errors.New("dns configuration invalid")
-	ErrCloudflareTokenMissing    = errors.New("cloudflare api token missing")
-	ErrCertificateStoreRequired  = errors.New("certificate store required")
-	ErrCertificateIssuerRequired = errors.New("certificate issuer required")
-	ErrRouteSourceRequired       = errors.New("route source required")
-	ErrHTTPChallengeSinkRequired = errors.New("http challenge sink required")
-	ErrTLSRouteNotCovered        = errors.New("tls route not covered by public certificate")
+	ErrACMEDisabled                 = errors.New("acme disabled")
+	ErrACMEEmailRequired            = errors.New("acme email required")
+	ErrACMEChallengeInvalid         = errors.New("acme challenge invalid")
+	ErrACMECAConfigInvalid          = errors.New("acme certificate authority configuration invalid")
+	ErrACMEEABSecretMissing         = errors.New("acme external account binding hmac missing")
+	ErrDNSConfigInvalid             = errors.New("dns configuration invalid")
+	ErrCloudflareTokenMissing       = errors.New("cloudflare api token missing")
+	ErrDNSProviderConfigInvalid     = errors.New("dns-01 provider configuration invalid")
+	ErrTSIGSecretMissing            = errors.New("rfc2136 tsig secret missing")
+	ErrCertificateStoreRequired     = errors.New("certificate store required")
+	ErrCertificateIssuerRequired    = errors.New("certificate issuer required")
+	ErrRouteSourceRequired          = errors.New("route source required")
+	ErrHTTPChallengeSinkRequired    = errors.New("http challenge sink required")
+	ErrTLSALPNChallengeSinkRequired = errors.New("tls-alpn challenge sink required")
+	ErrTLSRouteNotCovered           = errors.New("tls route not covered by public certificate")
+	ErrOCSPNotSupported             = errors.New("certificate has no ocsp responder")
 
 	// Traffic errors
 	ErrTrafficStatusUnavailable = errors.New("traffic status unavailable")
+
+	// Response cache errors
+	ErrResponseCacheUnavailable = errors.New("response cache unavailable")
+
+	// Upstream errors
+	ErrUpstreamCircuitOpen       = errors.New("upstream circuit open")
+	ErrUpstreamStatusUnavailable = errors.New("upstream status unavailable")
+
+	// Resource stats errors
+	ErrStatsDisabled = errors.New("resource stats sampling disabled")
+
+	// Client certificate errors
+	ErrClientAuthInvalid        = errors.New("client auth mode invalid")
+	ErrClientCertificateName    = errors.New("client certificate name invalid")
+	ErrClientCertificateRevoked = errors.New("client certificate revoked")
+	ErrClientCertificateSerial  = errors.New("client certificate serial invalid")
+
+	// Service certificate errors
+	ErrServiceCertificateSAN = errors.New("service certificate SAN invalid")
 )
@@ -18,6 +18,7 @@ const (
 	EventContainerHealthCheck EventType = "container.health_check"
 	EventContainerDeployed    EventType = "container.deployed"
 	EventSecretsChanged       EventType = "secrets.changed"
+	EventTLSCertificateAlert  EventType = "tls.certificate_alert"
 )
 
 // Event represents a domain event that occurred in the system.
@@ -48,6 +49,17 @@ type ContainerEventPayload struct {
 	Action      string
 }
 
+// ContainerHealthCheckPayload contains data for container.health_check
+// events, published when a liveness probe reaches its failure threshold.
+type ContainerHealthCheckPayload struct {
+	ContainerID string
+	Domain      string
+	URL         string
+	Failures    int
+	LastError   string
+	Action      string // "restarted", "restart_failed", or "backoff"
+}
+
 // ConfigReloadPayload contains data for config.reload events.
 type ConfigReloadPayload struct {
 	Source        string // "file" or "manual"
@@ -68,6 +80,16 @@ type SecretsChangedPayload struct {
 	Keys      []string // Secret key names (not values)
 }
 
+// TLSCertificateAlertPayload contains data for tls.certificate_alert events.
+type TLSCertificateAlertPayload struct {
+	CertificateID   string
+	Names           []string
+	Reason          TLSCertificateAlert
+	NotAfter        time.Time
+	RenewalFailures int
+	LastError       string
+}
+
 // Context keys for domain-level concerns.
 type contextKey string
 
@@ -105,3 +127,21 @@ func IsSkipReadiness(ctx context.Context) bool {
 	v, ok := ctx.Value(ContextKeySkipReadiness).(bool)
 	return ok && v
 }
+
+const (
+	// ContextKeyWakePage indicates the caller can render a "waking up" page
+	// (e.g., a browser navigation) instead of holding the request while a
+	// sleeping route container starts.
+	ContextKeyWakePage contextKey = "wake_page"
+)
+
+// WithWakePage returns a context that accepts a "waking up" page response.
+func WithWakePage(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ContextKeyWakePage, true)
+}
+
+// IsWakePage checks if the context accepts a "waking up" page response.
+func IsWakePage(ctx context.Context) bool {
+	v, ok := ctx.Value(ContextKeyWakePage).(bool)
+	return ok && v
+}
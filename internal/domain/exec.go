@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Exec session errors.
+var (
+	// ErrExecCommandRequired is returned when an exec session has no command.
+	ErrExecCommandRequired = errors.New("exec command required")
+	// ErrExecSignalUnsupported is returned when a signal cannot be delivered
+	// to an exec session.
+	ErrExecSignalUnsupported = errors.New("signal not supported for exec session")
+)
+
+// ExecRequest describes an interactive command run inside a route's
+// running container or one of its attachments.
+type ExecRequest struct {
+	Command []string // Command and arguments
+	TTY     bool     // Allocate a pseudo-terminal; stdout and stderr are merged
+	Rows    uint     // Initial terminal height of a TTY session
+	Cols    uint     // Initial terminal width of a TTY session
+}
+
+// TTYSignalInput returns the control character that makes a terminal's line
+// discipline deliver sig to the foreground process. Docker cannot signal an
+// exec process directly, so this is how signals reach TTY exec sessions.
+// Both "INT" and "SIGINT" forms are accepted.
+func TTYSignalInput(sig string) ([]byte, bool) {
+	switch strings.TrimPrefix(strings.ToUpper(sig), "SIG") {
+	case "INT":
+		return []byte{0x03}, true // ^C
+	case "QUIT":
+		return []byte{0x1c}, true // ^\
+	case "TSTP":
+		return []byte{0x1a}, true // ^Z
+	default:
+		return nil, false
+	}
+}
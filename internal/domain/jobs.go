@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultJobTimeout bounds a scheduled job run when the job sets no timeout.
+	DefaultJobTimeout = time.Hour
+	// MaxJobRunOutput is the number of trailing output bytes kept per run.
+	MaxJobRunOutput = 64 * 1024
+	// DefaultJobRunHistory is the number of runs kept per job.
+	DefaultJobRunHistory = 50
+)
+
+var (
+	// ErrJobNotFound is returned when no job with the given route and name is configured.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunNotFound is returned when a job has no run with the given ID.
+	ErrJobRunNotFound = errors.New("job run not found")
+)
+
+// JobOverlapPolicy decides what happens when a job is due while an earlier
+// run of it is still active.
+type JobOverlapPolicy string
+
+const (
+	// JobOverlapForbid skips the new run and records it as skipped.
+	JobOverlapForbid JobOverlapPolicy = "forbid"
+	// JobOverlapAllow starts the new run alongside the active ones.
+	JobOverlapAllow JobOverlapPolicy = "allow"
+	// JobOverlapReplace cancels the active runs and starts the new one.
+	JobOverlapReplace JobOverlapPolicy = "replace"
+)
+
+// ScheduledJob is a command run on a schedule in an ephemeral container with
+// the image, env and network of a route.
+type ScheduledJob struct {
+	Route    string
+	Name     string // Unique per route; defaults to the command's base name
+	Schedule CronSchedule
+	Command  []string
+	Timeout  time.Duration    // Limit per run (0 = DefaultJobTimeout)
+	Overlap  JobOverlapPolicy // Empty means JobOverlapForbid
+}
+
+// ID returns the identifier of the job, unique across routes.
+func (j ScheduledJob) ID() string {
+	return j.Route + "/" + j.Name
+}
+
+// EffectiveTimeout returns the per-run limit, defaulting to DefaultJobTimeout.
+func (j ScheduledJob) EffectiveTimeout() time.Duration {
+	if j.Timeout <= 0 {
+		return DefaultJobTimeout
+	}
+	return j.Timeout
+}
+
+// EffectiveOverlap returns the overlap policy, defaulting to JobOverlapForbid.
+func (j ScheduledJob) EffectiveOverlap() JobOverlapPolicy {
+	if j.Overlap == "" {
+		return JobOverlapForbid
+	}
+	return j.Overlap
+}
+
+// JobTrigger records what started a job run.
+type JobTrigger string
+
+const (
+	JobTriggerSchedule JobTrigger = "schedule"
+	JobTriggerManual   JobTrigger = "manual"
+)
+
+// JobRunStatus is the state of a job run.
+type JobRunStatus string
+
+const (
+	JobRunRunning   JobRunStatus = "running"
+	JobRunSucceeded JobRunStatus = "succeeded"
+	JobRunFailed    JobRunStatus = "failed"
+	JobRunTimedOut  JobRunStatus = "timed_out"
+	JobRunSkipped   JobRunStatus = "skipped"
+	JobRunCanceled  JobRunStatus = "canceled"
+	// JobRunInterrupted marks a run that Gordon stopped tracking without
+	// recording its end, e.g. after a crash.
+	JobRunInterrupted JobRunStatus = "interrupted"
+)
+
+// Finished reports whether the run is over.
+func (s JobRunStatus) Finished() bool {
+	return s != JobRunRunning
+}
+
+// JobRun is one execution of a scheduled job.
+type JobRun struct {
+	ID         string
+	Route      string
+	Job        string
+	Trigger    JobTrigger
+	Status     JobRunStatus
+	StartedAt  time.Time
+	FinishedAt time.Time
+	ExitCode   int
+	Output     string // Trailing MaxJobRunOutput bytes of stdout and stderr
+	Error      string
+}
+
+// JobStatus describes a configured job and its most recent run.
+type JobStatus struct {
+	Job     ScheduledJob
+	NextRun time.Time
+	LastRun *JobRun
+	Active  int // Runs in progress in this process
+}
@@ -14,6 +14,10 @@ const (
 	// variables at deploy time, used to detect env drift without
 	// exposing secret values.
 	LabelEnvHash = "gordon.env-hash"
+	// LabelConfigHash stores a hash of the route's container overrides at
+	// deploy time, or of an attachment's certificate settings, so a changed
+	// setting triggers a redeploy.
+	LabelConfigHash = "gordon.config-hash"
 
 	// Standalone service labels identify Gordon-managed L4 service containers.
 	LabelService                       = "gordon.service"
@@ -23,6 +27,18 @@ const (
 	LabelServiceCleanupPreserveVolumes = "gordon.service.cleanup.preserve-volumes"
 	LabelServiceCleanupRemoveContainer = "gordon.service.cleanup.remove-container"
 
+	// Deploy hook labels identify one-off release and post-deploy containers.
+	LabelHook      = "gordon.hook"
+	LabelHookRoute = "gordon.hook.route"
+
+	// LabelTask marks a one-off `gordon run` container with its route domain.
+	LabelTask = "gordon.task"
+
+	// LabelProcess names the route process a container runs; the container
+	// also carries LabelRoute but not LabelDomain, so it is never mistaken
+	// for the route's web container.
+	LabelProcess = "gordon.process"
+
 	// LabelProxyPort specifies the container port to proxy HTTP traffic to.
 	LabelProxyPort = "gordon.proxy.port"
 
@@ -40,4 +56,26 @@ const (
 	LabelPort = "gordon.port"
 	// LabelEnvFile specifies the path to .env file inside the image.
 	LabelEnvFile = "gordon.env-file"
+
+	// Container override image labels; a route's own settings take precedence.
+	// The user and capabilities have no label: they may only be set by the
+	// operator in the route table.
+	// LabelMemory sets the memory limit (e.g. "512MB").
+	LabelMemory = "gordon.memory"
+	// LabelCPUs sets the CPU quota in cores (e.g. "1.5").
+	LabelCPUs = "gordon.cpus"
+	// LabelPidsLimit sets the maximum number of processes.
+	LabelPidsLimit = "gordon.pids-limit"
+	// LabelCommand replaces the image CMD (JSON array or whitespace-separated words).
+	LabelCommand = "gordon.command"
+	// LabelWorkingDir sets the working directory inside the container.
+	LabelWorkingDir = "gordon.working-dir"
+	// LabelTmpfs mounts tmpfs filesystems (whitespace-separated "path:options").
+	LabelTmpfs = "gordon.tmpfs"
+
+	// Deploy hook image labels; a route's own commands take precedence.
+	// LabelRelease is run before traffic switches (JSON array or whitespace-separated words).
+	LabelRelease = "gordon.release"
+	// LabelPostDeploy is run after traffic switched (JSON array or whitespace-separated words).
+	LabelPostDeploy = "gordon.post-deploy"
 )
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ErrProcessInvalid is returned for a malformed route process.
+var ErrProcessInvalid = errors.New("invalid process")
+
+// Process is a named command a route runs from its image next to the web
+// container, such as a background job worker.
+type Process struct {
+	Name        string
+	Command     []string // Declared command; nil when the process is no longer declared
+	ContainerID string   // "" when no container runs the process
+	Status      string
+	ConfigHash  string // ProcessConfigHash the container was created with
+}
+
+var processNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// ValidateProcess checks a process name and command. Names are lower-case
+// letters, digits and underscores so that process container names never
+// collide with the -new and -next names of route containers.
+func ValidateProcess(name string, command []string) error {
+	if !processNamePattern.MatchString(name) || len(name) > 32 {
+		return fmt.Errorf("%w: name %q must be lower-case letters, digits and underscores", ErrProcessInvalid, name)
+	}
+	if name == "new" || name == "next" {
+		return fmt.Errorf("%w: name %q is reserved", ErrProcessInvalid, name)
+	}
+	if len(command) == 0 || command[0] == "" {
+		return fmt.Errorf("%w: %s needs a command", ErrProcessInvalid, name)
+	}
+	return nil
+}
+
+// ProcessConfigHash returns a SHA-256 hash of a process command and the
+// container settings of its route. Process containers carry it so a changed
+// command or setting rolls them.
+func ProcessConfigHash(command []string, overrides *ContainerOverrides) string {
+	data, err := json.Marshal(struct {
+		Command   []string `json:"command"`
+		Overrides string   `json:"overrides,omitempty"`
+	}{command, overrides.ConfigHash()})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProcess(t *testing.T) {
+	assert.NoError(t, ValidateProcess("worker", []string{"bundle", "exec", "sidekiq"}))
+	assert.NoError(t, ValidateProcess("mail_queue2", []string{"run"}))
+
+	for _, name := range []string{"", "Worker", "2worker", "web-worker", "new", "next", "a_very_long_process_name_that_goes_on"} {
+		assert.ErrorIs(t, ValidateProcess(name, []string{"run"}), ErrProcessInvalid, name)
+	}
+	assert.ErrorIs(t, ValidateProcess("worker", nil), ErrProcessInvalid)
+	assert.ErrorIs(t, ValidateProcess("worker", []string{""}), ErrProcessInvalid)
+}
+
+func TestProcessConfigHash(t *testing.T) {
+	command := []string{"bundle", "exec", "sidekiq"}
+	base := ProcessConfigHash(command, nil)
+
+	assert.Equal(t, base, ProcessConfigHash([]string{"bundle", "exec", "sidekiq"}, &ContainerOverrides{}))
+	assert.NotEqual(t, base, ProcessConfigHash([]string{"bundle", "exec", "sidekiq", "-q", "mail"}, nil))
+	assert.NotEqual(t, base, ProcessConfigHash(command, &ContainerOverrides{MemoryLimit: 1 << 30}))
+}
@@ -11,10 +11,25 @@ type ACMEChallengeMode string
 const (
 	ACMEChallengeAuto            ACMEChallengeMode = "auto"
 	ACMEChallengeHTTP01          ACMEChallengeMode = "http-01"
+	ACMEChallengeTLSALPN01       ACMEChallengeMode = "tls-alpn-01"
 	ACMEChallengeCloudflareDNS01 ACMEChallengeMode = "cloudflare-dns-01"
+	ACMEChallengeRFC2136DNS01    ACMEChallengeMode = "rfc2136-dns-01"
+	ACMEChallengeExecDNS01       ACMEChallengeMode = "exec-dns-01"
+
+	// DefaultACMEDirectoryURL is the Let's Encrypt production directory used
+	// when no certificate authority is configured.
+	DefaultACMEDirectoryURL = "https://acme-v02.api.letsencrypt.org/directory"
 
 	MaxHTTP01TokenLength = 256
 	TLSRenewalWindow     = 30 * 24 * time.Hour
+
+	// DefaultTLSExpiryAlertDays is how close to expiry a managed certificate
+	// gets before it raises an alert. It sits well inside the renewal window
+	// so an alert means renewal has been failing for a while.
+	DefaultTLSExpiryAlertDays = 14
+	// DefaultTLSRenewalFailureThreshold is the number of consecutive failed
+	// renewals that raises an alert.
+	DefaultTLSRenewalFailureThreshold = 3
 )
 
 func IsValidHTTP01Token(token string) bool {
@@ -27,13 +42,29 @@ func ParseACMEChallengeMode(value string) (ACMEChallengeMode, error) {
 		return ACMEChallengeAuto, nil
 	case string(ACMEChallengeHTTP01):
 		return ACMEChallengeHTTP01, nil
+	case string(ACMEChallengeTLSALPN01):
+		return ACMEChallengeTLSALPN01, nil
 	case string(ACMEChallengeCloudflareDNS01):
 		return ACMEChallengeCloudflareDNS01, nil
+	case string(ACMEChallengeRFC2136DNS01):
+		return ACMEChallengeRFC2136DNS01, nil
+	case string(ACMEChallengeExecDNS01):
+		return ACMEChallengeExecDNS01, nil
 	default:
 		return "", fmt.Errorf("%w: %q", ErrACMEChallengeInvalid, value)
 	}
 }
 
+// IsDNS01 reports whether the mode validates through a DNS-01 provider.
+func (m ACMEChallengeMode) IsDNS01() bool {
+	switch m {
+	case ACMEChallengeCloudflareDNS01, ACMEChallengeRFC2136DNS01, ACMEChallengeExecDNS01:
+		return true
+	default:
+		return false
+	}
+}
+
 type ACMETokenSource string
 
 const (
@@ -53,14 +84,38 @@ const (
 	TLSCertificateStatusError   TLSCertificateStatus = "error"
 )
 
+// OCSPStatus is the revocation status reported by a certificate's OCSP
+// responder.
+type OCSPStatus string
+
+const (
+	OCSPStatusGood    OCSPStatus = "good"
+	OCSPStatusRevoked OCSPStatus = "revoked"
+	OCSPStatusUnknown OCSPStatus = "unknown"
+)
+
+// TLSCertificateAlert is the reason a managed certificate needs attention
+// before it expires. The empty value means no alert.
+type TLSCertificateAlert string
+
+const (
+	TLSCertificateAlertExpiring       TLSCertificateAlert = "expiring"
+	TLSCertificateAlertRenewalFailing TLSCertificateAlert = "renewal_failing"
+	TLSCertificateAlertRevoked        TLSCertificateAlert = "revoked"
+)
+
 type ManagedCertificate struct {
-	ID             string
-	Names          []string
-	Challenge      ACMEChallengeMode
-	Status         TLSCertificateStatus
-	NotAfter       time.Time
-	LastError      string
-	RenewalPending bool
+	ID              string
+	Names           []string
+	Challenge       ACMEChallengeMode
+	Status          TLSCertificateStatus
+	NotAfter        time.Time
+	LastError       string
+	RenewalPending  bool
+	RenewalFailures int
+	Alert           TLSCertificateAlert
+	OCSPStatus      OCSPStatus
+	OCSPNextUpdate  time.Time
 }
 
 func (c ManagedCertificate) Covers(host string) bool {
@@ -40,7 +40,10 @@ func TestACMEChallengeModeParse(t *testing.T) {
 		{"", ACMEChallengeAuto, true},
 		{"auto", ACMEChallengeAuto, true},
 		{"http-01", ACMEChallengeHTTP01, true},
+		{"tls-alpn-01", ACMEChallengeTLSALPN01, true},
 		{"cloudflare-dns-01", ACMEChallengeCloudflareDNS01, true},
+		{"rfc2136-dns-01", ACMEChallengeRFC2136DNS01, true},
+		{"exec-dns-01", ACMEChallengeExecDNS01, true},
 		{"dns", "", false},
 	}
 
@@ -56,6 +59,15 @@ func TestACMEChallengeModeParse(t *testing.T) {
 	}
 }
 
+func TestACMEChallengeModeIsDNS01(t *testing.T) {
+	assert.True(t, ACMEChallengeCloudflareDNS01.IsDNS01())
+	assert.True(t, ACMEChallengeRFC2136DNS01.IsDNS01())
+	assert.True(t, ACMEChallengeExecDNS01.IsDNS01())
+	assert.False(t, ACMEChallengeHTTP01.IsDNS01())
+	assert.False(t, ACMEChallengeTLSALPN01.IsDNS01())
+	assert.False(t, ACMEChallengeAuto.IsDNS01())
+}
+
 func TestManagedCertificateCovers(t *testing.T) {
 	apexCert := ManagedCertificate{Names: []string{"example.com", "*.example.com"}}
 	prodCert := ManagedCertificate{Names: []string{"prod.example.com", "*.prod.example.com"}}
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// Response cache defaults.
+const (
+	DefaultResponseCacheMemorySize    = 64 << 20 // 64MB
+	DefaultResponseCacheDiskSize      = 1 << 30  // 1GB
+	DefaultResponseCacheMaxObjectSize = 10 << 20 // 10MB
+)
+
+// ResponseCachePolicy controls edge caching of proxied responses.
+type ResponseCachePolicy struct {
+	Enabled       bool
+	MaxObjectSize int64 // Largest response body stored; bigger responses are streamed uncached
+}
+
+// CachedResponse is an upstream response stored by the proxy edge cache.
+//
+// A response that varies on request headers is stored twice: a primary entry
+// keyed by the request URL that only carries Vary, and the full response
+// keyed by the URL plus the values of those request headers.
+type CachedResponse struct {
+	Key        string
+	Domain     string // Canonical route domain, used for purging
+	Path       string // Request path without query, used for purging
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+	Vary       []string  // Canonical request header names selecting a variant
+	StoredAt   time.Time // When the response was generated, adjusted for Age
+	FreshUntil time.Time // End of the freshness lifetime
+	StaleUntil time.Time // End of the stale-while-revalidate window
+}
+
+// Size returns the approximate number of bytes the entry occupies.
+func (c *CachedResponse) Size() int64 {
+	size := int64(len(c.Key) + len(c.Domain) + len(c.Path) + len(c.Body))
+	for name, values := range c.Header {
+		size += int64(len(name))
+		for _, value := range values {
+			size += int64(len(value))
+		}
+	}
+	for _, name := range c.Vary {
+		size += int64(len(name))
+	}
+	return size
+}
+
+// Fresh reports whether the entry can be served without revalidation.
+func (c *CachedResponse) Fresh(now time.Time) bool {
+	return now.Before(c.FreshUntil)
+}
+
+// Expired reports whether the entry can no longer be served at all.
+func (c *CachedResponse) Expired(now time.Time) bool {
+	return !now.Before(c.StaleUntil)
+}
@@ -1,11 +1,22 @@
 package domain
 
+import "time"
+
 // Route represents a mapping from a domain to a container image.
 type Route struct {
-	Domain string
-	Image  string
-	HTTPS  bool
-	Env    []string // Pre-resolved env vars ("KEY=VALUE"); when set, Deploy skips EnvLoader lookup.
+	Domain      string
+	Image       string
+	HTTPS       bool
+	Env         []string            // Pre-resolved env vars ("KEY=VALUE"); when set, Deploy skips EnvLoader lookup.
+	IdleTimeout time.Duration       // Stop the container after this long without requests (0 = never sleep)
+	WakePage    bool                // Serve a "waking up" page to browsers instead of holding the request
+	Compression *bool               // Per-route response compression override (nil = global setting)
+	Cache       *bool               // Per-route response cache override (nil = global setting)
+	Upstream    *UpstreamOverrides  // Per-route upstream settings (nil = global settings)
+	ClientAuth  *ClientAuthPolicy   // Per-route client certificate requirement (nil = no client auth)
+	Container   *ContainerOverrides // Per-route container resources and runtime settings (nil = global defaults)
+	Hooks       *DeployHooks        // Release and post-deploy commands (nil = none)
+	Processes   map[string][]string // Commands run from the route image next to the web container, by process name
 }
 
 // ProxyTarget represents the destination for proxying requests.
@@ -17,6 +28,22 @@ type ProxyTarget struct {
 	Protocol     string // "" (default HTTP/1.1) or "h2c" (cleartext HTTP/2)
 	OriginalHost string // Original hostname before DNS resolution (for external-route Host header)
 	RouteHost    string // Canonical matched route domain for managed-route Host header
+
+	// Compression is the effective response compression policy for the
+	// route; nil disables compression.
+	Compression *CompressionPolicy
+
+	// Cache is the effective response cache policy for the route; nil
+	// disables caching.
+	Cache *ResponseCachePolicy
+
+	// Upstream is the effective upstream policy for the route; nil uses the
+	// proxy defaults without retries or circuit breaking.
+	Upstream *UpstreamPolicy
+
+	// ClientAuth is the client certificate mode of the route; empty when
+	// the route does not ask for client certificates.
+	ClientAuth ClientAuthMode
 }
 
 // RouteMatch represents the result of matching a request to a route.
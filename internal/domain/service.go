@@ -15,6 +15,15 @@ const (
 	StandaloneServiceReadinessLog  = "log"
 )
 
+const (
+	// StandaloneServiceTLSReloadRestart restarts the container after its
+	// certificate is rotated. Any other reload value is a signal name.
+	StandaloneServiceTLSReloadRestart = "restart"
+
+	DefaultStandaloneServiceTLSPath = "/run/gordon/tls"
+	DefaultStandaloneServiceTLSTTL  = 30 * 24 * time.Hour
+)
+
 type StandaloneService struct {
 	Name      string
 	Image     string
@@ -26,6 +35,7 @@ type StandaloneService struct {
 	Cleanup   StandaloneServiceCleanup
 	Ports     []StandaloneServicePort
 	Volumes   []StandaloneServiceVolume
+	TLS       *StandaloneServiceTLS // nil when the service has no managed certificate
 }
 
 type StandaloneServiceStatus struct {
@@ -70,14 +80,42 @@ type StandaloneServiceCleanup struct {
 	RemoveContainer bool
 }
 
+// StandaloneServiceTLS asks Gordon to write a certificate from its internal
+// CA into the service container and rotate it before it expires.
+type StandaloneServiceTLS struct {
+	SANs   []string      // Extra DNS names or IP addresses for the certificate
+	Path   string        // Container directory receiving tls.crt, tls.key and ca.crt
+	TTL    time.Duration // Certificate lifetime
+	UID    int           // Owner of the written files
+	GID    int
+	Reload string // "restart" or a signal name such as "SIGHUP"
+}
+
 func (s StandaloneService) WithDefaults() StandaloneService {
 	if s.Readiness.Type == "" {
 		s.Readiness.Type = StandaloneServiceReadinessNone
 	}
 	s.Cleanup = s.Cleanup.WithDefaults()
+	if s.TLS != nil {
+		tlsCfg := s.TLS.WithDefaults()
+		s.TLS = &tlsCfg
+	}
 	return s
 }
 
+func (t StandaloneServiceTLS) WithDefaults() StandaloneServiceTLS {
+	if t.Path == "" {
+		t.Path = DefaultStandaloneServiceTLSPath
+	}
+	if t.TTL == 0 {
+		t.TTL = DefaultStandaloneServiceTLSTTL
+	}
+	if t.Reload == "" {
+		t.Reload = StandaloneServiceTLSReloadRestart
+	}
+	return t
+}
+
 func (c StandaloneServiceCleanup) WithDefaults() StandaloneServiceCleanup {
 	if !c.PreserveVolumes && !c.RemoveContainer {
 		return StandaloneServiceCleanup{PreserveVolumes: true, RemoveContainer: true}
@@ -112,6 +150,9 @@ func (s StandaloneService) Validate() error {
 	if err := validateStandaloneServiceReadiness(s); err != nil {
 		return err
 	}
+	if err := validateStandaloneServiceTLS(s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -256,3 +297,74 @@ func validateStandaloneServiceReadiness(s StandaloneService) error {
 		return fmt.Errorf("standalone service %q readiness type must be none, tcp, or log", s.Name)
 	}
 }
+
+func validateStandaloneServiceTLS(s StandaloneService) error {
+	if s.TLS == nil {
+		return nil
+	}
+	if err := s.TLS.Validate(fmt.Sprintf("standalone service %q", s.Name)); err != nil {
+		return err
+	}
+	tlsPath := path.Clean(s.TLS.WithDefaults().Path)
+	for _, volume := range s.Volumes {
+		if path.Clean(strings.TrimSpace(volume.Target)) == tlsPath {
+			return fmt.Errorf("standalone service %q tls path %q is already a volume target", s.Name, tlsPath)
+		}
+	}
+	return nil
+}
+
+// Validate checks the certificate settings after defaults are applied.
+// subject names their owner in errors, e.g. `attachment "postgres"`.
+func (t StandaloneServiceTLS) Validate(subject string) error {
+	tlsCfg := t.WithDefaults()
+	if !path.IsAbs(tlsCfg.Path) || path.Clean(tlsCfg.Path) == "/" {
+		return fmt.Errorf("%s tls path %q must be an absolute container directory", subject, tlsCfg.Path)
+	}
+	if tlsCfg.TTL < time.Hour {
+		return fmt.Errorf("%s tls ttl must be at least 1h", subject)
+	}
+	if tlsCfg.UID < 0 || tlsCfg.GID < 0 {
+		return fmt.Errorf("%s tls owner must not be negative", subject)
+	}
+	for _, san := range tlsCfg.SANs {
+		if strings.TrimSpace(san) == "" {
+			return fmt.Errorf("%s tls sans must not contain empty values", subject)
+		}
+	}
+	if tlsCfg.Reload != StandaloneServiceTLSReloadRestart && !isSignalName(tlsCfg.Reload) {
+		return fmt.Errorf("%s tls reload must be restart or a signal name such as SIGHUP", subject)
+	}
+	return nil
+}
+
+// ParseTLSOwner parses a numeric "uid:gid" or "uid" certificate owner. A
+// bare uid also sets the gid.
+func ParseTLSOwner(value string) (uid, gid int, ok bool) {
+	uidValue, gidValue, hasGID := strings.Cut(value, ":")
+	if !hasGID {
+		gidValue = uidValue
+	}
+	uid, err := strconv.Atoi(uidValue)
+	if err != nil || uid < 0 {
+		return 0, 0, false
+	}
+	gid, err = strconv.Atoi(gidValue)
+	if err != nil || gid < 0 {
+		return 0, 0, false
+	}
+	return uid, gid, true
+}
+
+func isSignalName(value string) bool {
+	name, ok := strings.CutPrefix(value, "SIG")
+	if !ok || name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '+' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// ServiceCertificate is a server certificate minted by Gordon's CA for a
+// workload that terminates TLS itself, such as a database.
+type ServiceCertificate struct {
+	Name     string
+	SANs     []string
+	Serial   string // Lowercase hex serial number
+	NotAfter time.Time
+	CertPEM  []byte
+	KeyPEM   []byte
+	CAPEM    []byte // Root CA certificate clients should trust
+}
@@ -133,3 +133,34 @@ func TestStandaloneServiceValidateRejectsDuplicateVolumeTargets(t *testing.T) {
 	}
 	require.ErrorContains(t, svc.Validate(), "duplicate volume target")
 }
+
+func TestStandaloneServiceValidateTLS(t *testing.T) {
+	svc := StandaloneService{Name: "db", Image: "postgres:16", Enabled: true, TLS: &StandaloneServiceTLS{}}
+	require.NoError(t, svc.Validate())
+
+	withDefaults := svc.WithDefaults()
+	require.Equal(t, DefaultStandaloneServiceTLSPath, withDefaults.TLS.Path)
+	require.Equal(t, DefaultStandaloneServiceTLSTTL, withDefaults.TLS.TTL)
+	require.Equal(t, StandaloneServiceTLSReloadRestart, withDefaults.TLS.Reload)
+	require.Empty(t, svc.TLS.Path, "WithDefaults must not mutate the original")
+
+	svc.TLS = &StandaloneServiceTLS{Reload: "SIGHUP", SANs: []string{"db.internal"}}
+	require.NoError(t, svc.Validate())
+
+	svc.TLS = &StandaloneServiceTLS{Path: "certs"}
+	require.ErrorContains(t, svc.Validate(), "tls path")
+
+	svc.TLS = &StandaloneServiceTLS{Path: "/data"}
+	svc.Volumes = []StandaloneServiceVolume{{Source: "db-data", Target: "/data"}}
+	require.ErrorContains(t, svc.Validate(), "already a volume target")
+	svc.Volumes = nil
+
+	svc.TLS = &StandaloneServiceTLS{TTL: time.Minute}
+	require.ErrorContains(t, svc.Validate(), "tls ttl")
+
+	svc.TLS = &StandaloneServiceTLS{Reload: "reload"}
+	require.ErrorContains(t, svc.Validate(), "tls reload")
+
+	svc.TLS = &StandaloneServiceTLS{SANs: []string{" "}}
+	require.ErrorContains(t, svc.Validate(), "tls sans")
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// ContainerStatsKind tells what a sampled container runs.
+type ContainerStatsKind string
+
+const (
+	ContainerStatsKindRoute      ContainerStatsKind = "route"
+	ContainerStatsKindProcess    ContainerStatsKind = "process"
+	ContainerStatsKindAttachment ContainerStatsKind = "attachment"
+	ContainerStatsKindService    ContainerStatsKind = "service"
+)
+
+// ContainerResourceUsage is a point-in-time reading of a container's resource
+// counters as reported by the runtime. CPU, network and block I/O values are
+// cumulative since the container started; rates are derived by comparing two
+// readings.
+type ContainerResourceUsage struct {
+	Time        time.Time
+	CPUUsage    uint64 // container CPU time in nanoseconds
+	SystemCPU   uint64 // host CPU time in nanoseconds
+	OnlineCPUs  int
+	MemoryUsage uint64 // bytes, excluding the inactive page cache
+	MemoryLimit uint64 // bytes
+	NetworkRx   uint64 // bytes received on all interfaces
+	NetworkTx   uint64 // bytes sent on all interfaces
+	BlockRead   uint64 // bytes
+	BlockWrite  uint64 // bytes
+	PIDs        uint64
+}
+
+// ResourceSample is one sample of a container's resource usage, with rates
+// computed over the interval since the previous sample.
+type ResourceSample struct {
+	Time           time.Time
+	CPUPercent     float64 // 100 is one full core
+	MemoryUsage    uint64
+	MemoryLimit    uint64
+	NetworkRxRate  float64 // bytes per second
+	NetworkTxRate  float64 // bytes per second
+	BlockReadRate  float64 // bytes per second
+	BlockWriteRate float64 // bytes per second
+	NetworkRx      uint64
+	NetworkTx      uint64
+	BlockRead      uint64
+	BlockWrite     uint64
+	PIDs           uint64
+}
+
+// ContainerStats is the recent resource usage of one running Gordon-managed
+// container.
+type ContainerStats struct {
+	Kind          ContainerStatsKind
+	Name          string // route domain, attachment container name or service name
+	Owner         string // route domain the container belongs to, empty for services
+	ContainerID   string
+	ContainerName string
+	Latest        ResourceSample
+	History       []ResourceSample // oldest first, including Latest
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "errors"
+
+// ErrTaskCommandRequired is returned when a one-off task has no command.
+var ErrTaskCommandRequired = errors.New("task command required")
+
+// TaskRequest describes a one-off command run with a route's current image,
+// environment, volumes and network.
+type TaskRequest struct {
+	Command []string // Command and arguments, replacing the image CMD
+	TTY     bool     // Allocate a pseudo-terminal; stdout and stderr are merged
+}
@@ -2,7 +2,10 @@ package domain
 
 import (
 	"fmt"
+	"maps"
 	"net"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +19,9 @@ const (
 	EntryPointProtocolSmartTCP EntryPointProtocol = "smart_tcp"
 	EntryPointProtocolTCP      EntryPointProtocol = "tcp"
 	EntryPointProtocolUDP      EntryPointProtocol = "udp"
+	// EntryPointProtocolHTTP3 serves HTTP/3 over QUIC on a UDP address with
+	// the same certificates and HTTP handler as the TLS fallback.
+	EntryPointProtocolHTTP3 EntryPointProtocol = "http3"
 )
 
 type RouterProtocol string
@@ -67,24 +73,115 @@ type EntryPoint struct {
 	RawFallback             string
 	RawFallbackTrustedCIDRs []string
 	AllowPublicRawFallback  bool
+	// ProxyProtocol enables PROXY protocol v1/v2 parsing for peers in
+	// ProxyProtocolTrustedCIDRs; the announced client address then replaces
+	// the peer socket address for CIDR checks and HTTP handlers.
+	ProxyProtocol             bool
+	ProxyProtocolTrustedCIDRs []string
 }
 
+// PROXY protocol versions a router can send to its backend.
+const (
+	ProxyProtocolV1 = 1
+	ProxyProtocolV2 = 2
+)
+
 type TrafficRouter struct {
 	Name       string
 	EntryPoint string
 	Protocol   RouterProtocol
 	Rule       TrafficRule
 	Service    string
+	// ProxyProtocol is the PROXY protocol version sent to the backend before
+	// any client bytes (0 = none). Only tcp and tls_passthrough routers support it.
+	ProxyProtocol int
+	Limits        TrafficLimits
+}
+
+// TrafficLimits caps the load one L4 router accepts. Zero disables a limit.
+type TrafficLimits struct {
+	// MaxConnections caps concurrent TCP connections or UDP sessions on the router.
+	MaxConnections int
+	// MaxConnectionsPerIP caps concurrent connections or sessions per source IP.
+	MaxConnectionsPerIP int
+	// ConnectionRate caps new connections or sessions per second per source IP.
+	// ConnectionBurst allows short spikes above it and defaults to the rate.
+	ConnectionRate  int
+	ConnectionBurst int
+	// BandwidthBytesPerSecond caps each connection or session in each direction.
+	BandwidthBytesPerSecond int64
 }
 
 type TrafficRule struct {
 	Host string
 	SNI  string
+	// SNIRegex matches the whole lowercase SNI of tls_passthrough routers.
+	// Exact SNI rules win over wildcards, and wildcards over regexes.
+	SNIRegex string
+}
+
+// CompileSNIRegex compiles a sni_regex rule anchored to the whole server name.
+func CompileSNIRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?i:" + pattern + ")$")
+}
+
+// HostMatchesSNIWildcard reports whether host matches a "*.suffix" rule; the
+// wildcard covers exactly one label, as in TLS certificates.
+func HostMatchesSNIWildcard(host string, wildcard string) bool {
+	suffix, ok := strings.CutPrefix(wildcard, "*.")
+	if !ok {
+		return false
+	}
+	prefix, ok := strings.CutSuffix(host, "."+suffix)
+	return ok && prefix != "" && !strings.Contains(prefix, ".")
 }
 
 type TrafficService struct {
 	Name     string
 	Backends []TrafficBackend
+	// LoadBalancer selects among Backends and tracks their health. Only pool
+	// services carry more than one backend.
+	LoadBalancer TrafficLoadBalancer
+}
+
+// LoadBalancerStrategy selects the backend for a new TCP connection or UDP session.
+type LoadBalancerStrategy string
+
+const (
+	LoadBalancerRoundRobin       LoadBalancerStrategy = "round_robin"
+	LoadBalancerLeastConnections LoadBalancerStrategy = "least_connections"
+	LoadBalancerSourceIPHash     LoadBalancerStrategy = "source_ip_hash"
+)
+
+// TrafficLoadBalancer configures backend selection, active health checks and
+// passive ejection for an L4 service. The zero value is round-robin without
+// health tracking.
+type TrafficLoadBalancer struct {
+	Strategy    LoadBalancerStrategy
+	HealthCheck TrafficHealthCheck
+	// MaxFailures consecutive dial or session failures eject a backend for
+	// EjectDuration (0 disables passive ejection).
+	MaxFailures   int
+	EjectDuration time.Duration
+}
+
+// TrafficHealthCheck configures active TCP connect checks. A zero Interval
+// disables them; Port overrides the backend port and is required for UDP
+// backends.
+type TrafficHealthCheck struct {
+	Interval           time.Duration
+	Timeout            time.Duration
+	Port               int
+	HealthyThreshold   int
+	UnhealthyThreshold int
+}
+
+// EffectiveStrategy returns the configured strategy, defaulting to round-robin.
+func (lb TrafficLoadBalancer) EffectiveStrategy() LoadBalancerStrategy {
+	if lb.Strategy == "" {
+		return LoadBalancerRoundRobin
+	}
+	return lb.Strategy
 }
 
 type TrafficBackend struct {
@@ -116,29 +213,54 @@ type EntryPointStatus struct {
 	BytesIn              int64
 	BytesOut             int64
 	SmartTCP             SmartTCPCounters
+	ProxyProtocol        ProxyProtocolCounters
+	QUIC                 QUICCounters
+	Limits               LimitCounters
 }
 
 type TrafficRouterStatus struct {
-	Name       string
-	EntryPoint string
-	Protocol   RouterProtocol
-	Rule       TrafficRule
-	Service    string
-	Active     bool
+	Name          string
+	EntryPoint    string
+	Protocol      RouterProtocol
+	Rule          TrafficRule
+	Service       string
+	ProxyProtocol int
+	Active        bool
+	// Matches counts connections whose SNI selected this tls_passthrough router.
+	Matches int64
 }
 
 type TrafficServiceStatus struct {
 	Name     string
 	Active   bool
+	Strategy LoadBalancerStrategy
 	Backends []TrafficBackendStatus
 }
 
+// BackendHealth is the health verdict the traffic plane holds for a backend.
+type BackendHealth string
+
+const (
+	// BackendHealthUnknown means no active check has completed yet.
+	BackendHealthUnknown   BackendHealth = "unknown"
+	BackendHealthHealthy   BackendHealth = "healthy"
+	BackendHealthUnhealthy BackendHealth = "unhealthy"
+	// BackendHealthEjected means passive ejection removed the backend after
+	// consecutive failures on real traffic.
+	BackendHealthEjected BackendHealth = "ejected"
+)
+
 type TrafficBackendStatus struct {
 	Name     string
 	Host     string
 	Port     int
 	Protocol NetworkProtocol
-	Active   bool
+	// Active reports whether the backend receives new connections.
+	Active            bool
+	Health            BackendHealth
+	ActiveConnections int64
+	TotalFailures     int64
+	LastError         string
 }
 
 type TrafficCounters struct {
@@ -150,6 +272,32 @@ type TrafficCounters struct {
 	BytesIn              int64
 	BytesOut             int64
 	SmartTCP             SmartTCPCounters
+	ProxyProtocol        ProxyProtocolCounters
+	QUIC                 QUICCounters
+	Limits               LimitCounters
+}
+
+// LimitCounters count connections and sessions refused by router limits and
+// UDP datagrams dropped by bandwidth caps.
+type LimitCounters struct {
+	RouterRefused    int64
+	SourceRefused    int64
+	RateRefused      int64
+	BandwidthDropped int64
+}
+
+// QUICCounters count QUIC sessions and HTTP/3 requests on http3 entrypoints.
+type QUICCounters struct {
+	SessionsAccepted int64
+	SessionsRefused  int64
+	ActiveSessions   int64
+	Requests         int64
+}
+
+// ProxyProtocolCounters count PROXY headers received from trusted peers.
+type ProxyProtocolCounters struct {
+	Accepted int64
+	Rejected int64
 }
 
 type SmartTCPCounters struct {
@@ -174,6 +322,7 @@ const (
 	TrafficServiceRefExternalRoute  TrafficServiceRefKind = "external_route"
 	TrafficServiceRefNetworkService TrafficServiceRefKind = "network_service"
 	TrafficServiceRefService        TrafficServiceRefKind = "service"
+	TrafficServiceRefPool           TrafficServiceRefKind = "pool"
 	TrafficServiceRefStatic         TrafficServiceRefKind = "static"
 )
 
@@ -199,6 +348,11 @@ func ParseTrafficServiceRef(value string) (TrafficServiceRef, error) {
 		return parseNamedPortServiceRef(value, rest, TrafficServiceRefNetworkService, "network service")
 	case TrafficServiceRefService:
 		return parseNamedPortServiceRef(value, rest, TrafficServiceRefService, "service")
+	case TrafficServiceRefPool:
+		if strings.Contains(rest, ":") {
+			return TrafficServiceRef{}, fmt.Errorf("invalid pool ref %q", value)
+		}
+		return TrafficServiceRef{Kind: TrafficServiceRefPool, Name: rest}, nil
 	case TrafficServiceRefStatic:
 		return TrafficServiceRef{Kind: TrafficServiceRefStatic, Name: rest, Reserved: true}, nil
 	default:
@@ -283,7 +437,7 @@ func newProtocolListenAddressSet() *protocolListenAddressSet {
 }
 
 func (s *protocolListenAddressSet) add(entryPoint EntryPoint, addr listenAddress) error {
-	if entryPoint.Protocol == EntryPointProtocolUDP {
+	if entryPoint.Protocol.BindsUDP() {
 		return s.udp.add(addr, entryPoint.Name, "udp")
 	}
 	return s.tcp.add(addr, entryPoint.Name, "tcp")
@@ -305,6 +459,9 @@ func validateTrafficEntryPoint(entryPoint EntryPoint, existing map[string]EntryP
 	if err := validateTrustedCIDRs(entryPoint); err != nil {
 		return err
 	}
+	if err := validateEntryPointProxyProtocol(entryPoint); err != nil {
+		return err
+	}
 	addr, err := parseListenAddress(entryPoint.Address)
 	if err != nil {
 		return fmt.Errorf("invalid entrypoint address for %q: %w", entryPoint.Name, err)
@@ -333,10 +490,56 @@ func validateTrafficService(service TrafficService, existing map[string]TrafficS
 	if _, err := ParseTrafficServiceRef(service.Name); err != nil {
 		return fmt.Errorf("invalid traffic service ref %q: %w", service.Name, err)
 	}
+	backendNames := make(map[string]struct{}, len(service.Backends))
 	for _, backend := range service.Backends {
 		if err := validateTrafficBackend(service.Name, backend); err != nil {
 			return err
 		}
+		if _, exists := backendNames[backend.Name]; exists {
+			return fmt.Errorf("duplicate backend %q for service %q", backend.Name, service.Name)
+		}
+		backendNames[backend.Name] = struct{}{}
+	}
+	return validateTrafficLoadBalancer(service)
+}
+
+func validateTrafficLoadBalancer(service TrafficService) error {
+	lb := service.LoadBalancer
+	switch lb.EffectiveStrategy() {
+	case LoadBalancerRoundRobin, LoadBalancerLeastConnections, LoadBalancerSourceIPHash:
+	default:
+		return fmt.Errorf("invalid load balancer strategy %q for service %q", lb.Strategy, service.Name)
+	}
+	if lb.MaxFailures < 0 {
+		return fmt.Errorf("max_failures for service %q must be non-negative", service.Name)
+	}
+	if lb.EjectDuration < 0 || (lb.MaxFailures > 0 && lb.EjectDuration == 0) {
+		return fmt.Errorf("eject_duration for service %q must be positive", service.Name)
+	}
+	return validateTrafficHealthCheck(service)
+}
+
+func validateTrafficHealthCheck(service TrafficService) error {
+	check := service.LoadBalancer.HealthCheck
+	if check.Interval < 0 {
+		return fmt.Errorf("health_check.interval for service %q must be positive", service.Name)
+	}
+	if check.Interval == 0 {
+		return nil
+	}
+	if check.Timeout <= 0 {
+		return fmt.Errorf("health_check.timeout for service %q must be positive", service.Name)
+	}
+	if check.HealthyThreshold < 1 || check.UnhealthyThreshold < 1 {
+		return fmt.Errorf("health_check thresholds for service %q must be at least 1", service.Name)
+	}
+	if check.Port < 0 || check.Port > 65535 {
+		return fmt.Errorf("invalid health_check.port %d for service %q", check.Port, service.Name)
+	}
+	for _, backend := range service.Backends {
+		if backend.Protocol == NetworkProtocolUDP && check.Port == 0 {
+			return fmt.Errorf("health_check.port is required for udp backends of service %q", service.Name)
+		}
 	}
 	return nil
 }
@@ -369,6 +572,7 @@ type routerValidationState struct {
 	routersByName map[string]TrafficRouter
 	exactSNI      map[string]map[string]string
 	wildSNI       map[string]map[string]string
+	regexSNI      map[string][]sniRegexRule
 	httpHosts     map[string]map[string]string
 	tcpRouters    map[string]string
 	udpRouters    map[string]string
@@ -380,6 +584,7 @@ func newRouterValidationState() *routerValidationState {
 		routersByName: map[string]TrafficRouter{},
 		exactSNI:      map[string]map[string]string{},
 		wildSNI:       map[string]map[string]string{},
+		regexSNI:      map[string][]sniRegexRule{},
 		httpHosts:     map[string]map[string]string{},
 		tcpRouters:    map[string]string{},
 		udpRouters:    map[string]string{},
@@ -400,6 +605,12 @@ func (s *routerValidationState) validateRouter(router TrafficRouter, entryPoints
 	if err := validateRouterServiceRef(router, serviceRef, service); err != nil {
 		return err
 	}
+	if err := validateRouterProxyProtocol(router); err != nil {
+		return err
+	}
+	if err := validateRouterLimits(router); err != nil {
+		return err
+	}
 	if err := s.validateRouterRule(router, entryPoint); err != nil {
 		return err
 	}
@@ -480,13 +691,32 @@ func (s *routerValidationState) validateHTTPRuleTLSConflicts(entryPoint string,
 	if wildcard := matchingWildcardSNI(host, s.wildSNI[entryPoint]); wildcard != "" {
 		return fmt.Errorf("http host conflicts with wildcard tls passthrough sni %q on entrypoint %q", wildcard, entryPoint)
 	}
+	for _, rule := range s.regexSNI[entryPoint] {
+		if rule.re.MatchString(host) {
+			return fmt.Errorf("http host conflicts with tls passthrough sni_regex %q on entrypoint %q", rule.pattern, entryPoint)
+		}
+	}
 	return nil
 }
 
+type sniRegexRule struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
 func (s *routerValidationState) validateTLSPassthroughRule(router TrafficRouter, entryPoint EntryPoint) error {
 	if router.Protocol != RouterProtocolTLSPassthrough {
+		if router.Rule.SNIRegex != "" {
+			return fmt.Errorf("sni_regex on router %q is only supported on tls_passthrough routers", router.Name)
+		}
 		return nil
 	}
+	if router.Rule.SNIRegex != "" {
+		if strings.TrimSpace(router.Rule.SNI) != "" {
+			return fmt.Errorf("tls passthrough router %q must set only one of sni and sni_regex", router.Name)
+		}
+		return s.validateRegexSNI(entryPoint.Name, router.Rule.SNIRegex, router.Name)
+	}
 	if strings.TrimSpace(router.Rule.SNI) == "" {
 		return fmt.Errorf("tls passthrough router %q requires sni", router.Name)
 	}
@@ -507,10 +737,72 @@ func (s *routerValidationState) validateWildcardSNI(entryPoint string, sni strin
 	if host := matchingHTTPHost(sni, s.httpHosts[entryPoint]); host != "" {
 		return fmt.Errorf("http host conflicts with wildcard tls passthrough sni %q on entrypoint %q for host %q", sni, entryPoint, host)
 	}
+	if err := s.validateSNIAgainstRegexes(entryPoint, sni); err != nil {
+		return err
+	}
 	putNested(s.wildSNI, entryPoint, sni, routerName)
 	return nil
 }
 
+func (s *routerValidationState) validateRegexSNI(entryPoint string, pattern string, routerName string) error {
+	re, err := CompileSNIRegex(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid sni_regex for router %q: %w", routerName, err)
+	}
+	for _, rule := range s.regexSNI[entryPoint] {
+		if rule.pattern == pattern {
+			return fmt.Errorf("duplicate tls sni_regex %q on entrypoint %q", pattern, entryPoint)
+		}
+	}
+	for host := range s.httpHosts[entryPoint] {
+		if re.MatchString(host) {
+			return fmt.Errorf("http host conflicts with tls passthrough sni_regex %q on entrypoint %q for host %q", pattern, entryPoint, host)
+		}
+	}
+	rule := sniRegexRule{pattern: pattern, re: re}
+	for _, sni := range slices.Sorted(maps.Keys(s.exactSNI[entryPoint])) {
+		if rule.overlaps(sni) {
+			return fmt.Errorf("tls sni_regex %q overlaps tls sni %q on entrypoint %q", pattern, sni, entryPoint)
+		}
+	}
+	for _, sni := range slices.Sorted(maps.Keys(s.wildSNI[entryPoint])) {
+		if rule.overlaps(sni) {
+			return fmt.Errorf("tls sni_regex %q overlaps tls sni %q on entrypoint %q", pattern, sni, entryPoint)
+		}
+	}
+	s.regexSNI[entryPoint] = append(s.regexSNI[entryPoint], rule)
+	return nil
+}
+
+func (s *routerValidationState) validateSNIAgainstRegexes(entryPoint string, sni string) error {
+	for _, rule := range s.regexSNI[entryPoint] {
+		if rule.overlaps(sni) {
+			return fmt.Errorf("tls sni %q overlaps tls sni_regex %q on entrypoint %q", sni, rule.pattern, entryPoint)
+		}
+	}
+	return nil
+}
+
+// sniWildcardProbes stand in for the label a wildcard sni covers when it is
+// checked against a regex; "*" catches regexes that accept any label.
+var sniWildcardProbes = []string{"*", "a", "0", "www"}
+
+// overlaps reports whether the regex matches an exact sni, or a name covered
+// by a wildcard sni. Wildcards are checked against probe labels, so a regex
+// that only matches unusual labels under a wildcard is not caught.
+func (r sniRegexRule) overlaps(sni string) bool {
+	suffix, wildcard := strings.CutPrefix(sni, "*.")
+	if !wildcard {
+		return r.re.MatchString(sni)
+	}
+	for _, label := range sniWildcardProbes {
+		if r.re.MatchString(label + "." + suffix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *routerValidationState) validateExactSNI(entryPoint string, sni string, routerName string) error {
 	if s.exactSNI[entryPoint][sni] != "" {
 		return fmt.Errorf("duplicate exact tls sni %q on entrypoint %q", sni, entryPoint)
@@ -518,6 +810,9 @@ func (s *routerValidationState) validateExactSNI(entryPoint string, sni string,
 	if s.httpHosts[entryPoint][sni] != "" {
 		return fmt.Errorf("http host conflicts with tls passthrough sni %q on entrypoint %q", sni, entryPoint)
 	}
+	if err := s.validateSNIAgainstRegexes(entryPoint, sni); err != nil {
+		return err
+	}
 	putNested(s.exactSNI, entryPoint, sni, routerName)
 	return nil
 }
@@ -560,18 +855,24 @@ func validateL4RouterService(router TrafficRouter, ref TrafficServiceRef, servic
 	if !ok {
 		return nil
 	}
-	if ref.Kind != TrafficServiceRefNetworkService && ref.Kind != TrafficServiceRefService {
-		if ref.Kind == TrafficServiceRefStatic {
-			return fmt.Errorf("static traffic service ref %q is unsupported in traffic graph validation", router.Service)
+	switch ref.Kind {
+	case TrafficServiceRefNetworkService, TrafficServiceRefService:
+		if len(service.Backends) != 1 {
+			return fmt.Errorf("l4 router service must have exactly one backend for router %q", router.Name)
 		}
-		return fmt.Errorf("l4 router %q requires network_service or service service ref", router.Name)
-	}
-	if len(service.Backends) != 1 {
-		return fmt.Errorf("l4 router service must have exactly one backend for router %q", router.Name)
+	case TrafficServiceRefPool:
+		if len(service.Backends) == 0 {
+			return fmt.Errorf("l4 router pool must have at least one backend for router %q", router.Name)
+		}
+	case TrafficServiceRefStatic:
+		return fmt.Errorf("static traffic service ref %q is unsupported in traffic graph validation", router.Service)
+	default:
+		return fmt.Errorf("l4 router %q requires network_service, service or pool service ref", router.Name)
 	}
-	backend := service.Backends[0]
-	if backend.Protocol != want {
-		return fmt.Errorf("backend protocol %s does not match router protocol %s for router %q (expected backend protocol %s)", backend.Protocol, router.Protocol, router.Name, want)
+	for _, backend := range service.Backends {
+		if backend.Protocol != want {
+			return fmt.Errorf("backend protocol %s does not match router protocol %s for router %q (expected backend protocol %s)", backend.Protocol, router.Protocol, router.Name, want)
+		}
 	}
 	return nil
 }
@@ -628,6 +929,68 @@ func validateTrustedCIDRs(entryPoint EntryPoint) error {
 	return nil
 }
 
+func validateEntryPointProxyProtocol(entryPoint EntryPoint) error {
+	if !entryPoint.ProxyProtocol {
+		if len(entryPoint.ProxyProtocolTrustedCIDRs) > 0 {
+			return fmt.Errorf("proxy_protocol_trusted_cidrs on entrypoint %q requires proxy_protocol", entryPoint.Name)
+		}
+		return nil
+	}
+	if entryPoint.Protocol.BindsUDP() {
+		return fmt.Errorf("proxy_protocol is not supported on %s entrypoint %q", entryPoint.Protocol, entryPoint.Name)
+	}
+	if len(entryPoint.ProxyProtocolTrustedCIDRs) == 0 {
+		return fmt.Errorf("proxy_protocol on entrypoint %q requires proxy_protocol_trusted_cidrs", entryPoint.Name)
+	}
+	for _, cidr := range entryPoint.ProxyProtocolTrustedCIDRs {
+		if _, _, err := net.ParseCIDR(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid proxy_protocol_trusted_cidrs entry %q for entrypoint %q: %w", cidr, entryPoint.Name, err)
+		}
+	}
+	return nil
+}
+
+func validateRouterProxyProtocol(router TrafficRouter) error {
+	if router.ProxyProtocol == 0 {
+		return nil
+	}
+	if router.Protocol != RouterProtocolTCP && router.Protocol != RouterProtocolTLSPassthrough {
+		return fmt.Errorf("proxy_protocol is only supported on tcp and tls passthrough routers, not %s router %q", router.Protocol, router.Name)
+	}
+	if router.ProxyProtocol != ProxyProtocolV1 && router.ProxyProtocol != ProxyProtocolV2 {
+		return fmt.Errorf("invalid proxy_protocol version %d for router %q: must be 1 or 2", router.ProxyProtocol, router.Name)
+	}
+	return nil
+}
+
+func validateRouterLimits(router TrafficRouter) error {
+	limits := router.Limits
+	if limits == (TrafficLimits{}) {
+		return nil
+	}
+	if router.Protocol == RouterProtocolHTTP {
+		return fmt.Errorf("limits are only supported on tcp, udp and tls passthrough routers, not %s router %q", router.Protocol, router.Name)
+	}
+	if limits.MaxConnections < 0 || limits.MaxConnectionsPerIP < 0 || limits.ConnectionRate < 0 || limits.ConnectionBurst < 0 || limits.BandwidthBytesPerSecond < 0 {
+		return fmt.Errorf("invalid limits for router %q: values must not be negative", router.Name)
+	}
+	if limits.ConnectionBurst > 0 && limits.ConnectionRate == 0 {
+		return fmt.Errorf("invalid limits for router %q: connection_burst requires connection_rate", router.Name)
+	}
+	if limits.MaxConnections > 0 && limits.MaxConnectionsPerIP > limits.MaxConnections {
+		return fmt.Errorf("invalid limits for router %q: max_connections_per_ip exceeds max_connections", router.Name)
+	}
+	return nil
+}
+
+// EffectiveConnectionBurst returns ConnectionBurst, defaulting to ConnectionRate.
+func (l TrafficLimits) EffectiveConnectionBurst() int {
+	if l.ConnectionBurst > 0 {
+		return l.ConnectionBurst
+	}
+	return l.ConnectionRate
+}
+
 func validateRawFallbackRouters(entryPoints map[string]EntryPoint, routers map[string]TrafficRouter) error {
 	for _, entryPoint := range entryPoints {
 		if entryPoint.RawFallback == "" {
@@ -649,13 +1012,18 @@ func validateRawFallbackRouters(entryPoints map[string]EntryPoint, routers map[s
 
 func validateEntryPointProtocol(protocol EntryPointProtocol) error {
 	switch protocol {
-	case EntryPointProtocolTLSMux, EntryPointProtocolSmartTCP, EntryPointProtocolTCP, EntryPointProtocolUDP:
+	case EntryPointProtocolTLSMux, EntryPointProtocolSmartTCP, EntryPointProtocolTCP, EntryPointProtocolUDP, EntryPointProtocolHTTP3:
 		return nil
 	default:
 		return fmt.Errorf("unsupported traffic entrypoint protocol %q", protocol)
 	}
 }
 
+// BindsUDP reports whether entrypoints of this protocol listen on UDP.
+func (p EntryPointProtocol) BindsUDP() bool {
+	return p == EntryPointProtocolUDP || p == EntryPointProtocolHTTP3
+}
+
 func validateRouterProtocol(protocol RouterProtocol) error {
 	switch protocol {
 	case RouterProtocolHTTP, RouterProtocolTCP, RouterProtocolUDP, RouterProtocolTLSPassthrough:
@@ -734,7 +1102,7 @@ func normalizeHost(host string) string {
 
 func matchingWildcardSNI(host string, wildcards map[string]string) string {
 	for wildcard := range wildcards {
-		if hostMatchesWildcard(host, wildcard) {
+		if HostMatchesSNIWildcard(host, wildcard) {
 			return wildcard
 		}
 	}
@@ -743,7 +1111,7 @@ func matchingWildcardSNI(host string, wildcards map[string]string) string {
 
 func matchingHTTPHost(wildcard string, hosts map[string]string) string {
 	for host := range hosts {
-		if hostMatchesWildcard(host, wildcard) {
+		if HostMatchesSNIWildcard(host, wildcard) {
 			return host
 		}
 	}
@@ -768,14 +1136,6 @@ func wildcardSNIOverlap(left string, right string) bool {
 	return leftSuffix == rightSuffix || strings.HasSuffix(leftSuffix, "."+rightSuffix) || strings.HasSuffix(rightSuffix, "."+leftSuffix)
 }
 
-func hostMatchesWildcard(host string, wildcard string) bool {
-	suffix, ok := strings.CutPrefix(wildcard, "*.")
-	if !ok || host == suffix {
-		return false
-	}
-	return strings.HasSuffix(host, "."+suffix)
-}
-
 func putNested(values map[string]map[string]string, key string, nestedKey string, value string) {
 	if values[key] == nil {
 		values[key] = map[string]string{}
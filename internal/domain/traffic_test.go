@@ -193,6 +193,28 @@ func TestTrafficGraphValidateEntryPoints(t *testing.T) {
 				{Name: "udp", Address: ":53", Protocol: EntryPointProtocolUDP},
 			}},
 		},
+		{
+			name: "http3 shares the port of a tcp edge",
+			graph: TrafficGraph{EntryPoints: []EntryPoint{
+				{Name: "edge", Address: ":443", Protocol: EntryPointProtocolSmartTCP},
+				{Name: "edge-h3", Address: ":443", Protocol: EntryPointProtocolHTTP3},
+			}},
+		},
+		{
+			name: "http3 and udp same address rejected",
+			graph: TrafficGraph{EntryPoints: []EntryPoint{
+				{Name: "edge-h3", Address: ":443", Protocol: EntryPointProtocolHTTP3},
+				{Name: "game", Address: ":443", Protocol: EntryPointProtocolUDP},
+			}},
+			wantErr: "duplicate udp entrypoint address",
+		},
+		{
+			name: "raw fallback rejected on http3",
+			graph: TrafficGraph{EntryPoints: []EntryPoint{
+				{Name: "edge-h3", Address: ":443", Protocol: EntryPointProtocolHTTP3, RawFallback: "ssh"},
+			}},
+			wantErr: "raw_fallback is only supported on smart_tcp",
+		},
 		{
 			name: "invalid trusted cidr rejected",
 			graph: TrafficGraph{EntryPoints: []EntryPoint{
@@ -400,6 +422,91 @@ func TestTrafficGraphValidateTLSRules(t *testing.T) {
 			},
 			wantErr: "ambiguous wildcard tls sni",
 		},
+		{
+			name: "regex sni alongside exact and wildcard allowed",
+			routers: []TrafficRouter{
+				{Name: "exact", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "db.tenant.example.com"}, Service: "network_service:app:https"},
+				{Name: "wild", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "*.tenant.example.com"}, Service: "network_service:app:https"},
+				{Name: "regex", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `[a-z0-9-]+\.[a-z]+\.tenant\.example\.com`}, Service: "network_service:app:https"},
+			},
+		},
+		{
+			name: "regex sni matching exact sni rejected",
+			routers: []TrafficRouter{
+				{Name: "exact", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "tenant-1.example.com"}, Service: "network_service:app:https"},
+				{Name: "regex", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `tenant-[0-9]+\.example\.com`}, Service: "network_service:app:https"},
+			},
+			wantErr: "overlaps tls sni \"tenant-1.example.com\"",
+		},
+		{
+			name: "exact sni matching earlier regex sni rejected",
+			routers: []TrafficRouter{
+				{Name: "regex", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `tenant-[0-9]+\.example\.com`}, Service: "network_service:app:https"},
+				{Name: "exact", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "Tenant-1.example.com"}, Service: "network_service:app:https"},
+			},
+			wantErr: "tls sni \"tenant-1.example.com\" overlaps tls sni_regex",
+		},
+		{
+			name: "regex sni matching wildcard sni rejected",
+			routers: []TrafficRouter{
+				{Name: "wild", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "*.example.com"}, Service: "network_service:app:https"},
+				{Name: "regex", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `[^.]+\.example\.com`}, Service: "network_service:app:https"},
+			},
+			wantErr: "overlaps tls sni \"*.example.com\"",
+		},
+		{
+			name: "wildcard sni matching earlier regex sni rejected",
+			routers: []TrafficRouter{
+				{Name: "regex", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `(www|api)\.example\.com`}, Service: "network_service:app:https"},
+				{Name: "wild", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "*.example.com"}, Service: "network_service:app:https"},
+			},
+			wantErr: "tls sni \"*.example.com\" overlaps tls sni_regex",
+		},
+		{
+			name: "duplicate regex sni rejected",
+			routers: []TrafficRouter{
+				{Name: "one", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `.+\.example\.com`}, Service: "network_service:app:https"},
+				{Name: "two", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `.+\.example\.com`}, Service: "network_service:app:https"},
+			},
+			wantErr: "duplicate tls sni_regex",
+		},
+		{
+			name: "invalid regex sni rejected",
+			routers: []TrafficRouter{
+				{Name: "bad", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `(`}, Service: "network_service:app:https"},
+			},
+			wantErr: "invalid sni_regex for router \"bad\"",
+		},
+		{
+			name: "sni and regex sni together rejected",
+			routers: []TrafficRouter{
+				{Name: "both", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "db.example.com", SNIRegex: `db\.example\.com`}, Service: "network_service:app:https"},
+			},
+			wantErr: "must set only one of sni and sni_regex",
+		},
+		{
+			name: "http host conflicts with regex sni",
+			routers: []TrafficRouter{
+				{Name: "http", EntryPoint: "tls", Protocol: RouterProtocolHTTP, Rule: TrafficRule{Host: "app.example.com"}, Service: "route:app.example.com"},
+				{Name: "pass", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `.+\.example\.com`}, Service: "network_service:app:https"},
+			},
+			wantErr: "http host conflicts with tls passthrough sni_regex",
+		},
+		{
+			name: "regex sni conflicts with later http host",
+			routers: []TrafficRouter{
+				{Name: "pass", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNIRegex: `APP\.example\.com`}, Service: "network_service:app:https"},
+				{Name: "http", EntryPoint: "tls", Protocol: RouterProtocolHTTP, Rule: TrafficRule{Host: "app.example.com"}, Service: "route:app.example.com"},
+			},
+			wantErr: "http host conflicts with tls passthrough sni_regex",
+		},
+		{
+			name: "http host deeper than wildcard sni allowed",
+			routers: []TrafficRouter{
+				{Name: "pass", EntryPoint: "tls", Protocol: RouterProtocolTLSPassthrough, Rule: TrafficRule{SNI: "*.example.com"}, Service: "network_service:app:https"},
+				{Name: "http", EntryPoint: "tls", Protocol: RouterProtocolHTTP, Rule: TrafficRule{Host: "v1.app.example.com"}, Service: "route:app.example.com"},
+			},
+		},
 		{
 			name: "tls passthrough requires sni",
 			routers: []TrafficRouter{
@@ -509,7 +616,7 @@ func TestTrafficGraphValidateBackendsAndL4Routers(t *testing.T) {
 			name:     "l4 router rejects route ref",
 			router:   TrafficRouter{Name: "tcp", EntryPoint: "tcp", Protocol: RouterProtocolTCP, Service: "route:app.example.com"},
 			services: []TrafficService{{Name: "route:app.example.com", Backends: []TrafficBackend{{Name: "app", Host: "10.0.0.2", Port: 443, Protocol: NetworkProtocolTCP}}}},
-			wantErr:  "requires network_service or service service ref",
+			wantErr:  "requires network_service, service or pool service ref",
 		},
 		{
 			name:     "static ref parses but graph validation rejects unsupported",
@@ -535,6 +642,43 @@ func TestTrafficGraphValidateBackendsAndL4Routers(t *testing.T) {
 	}
 }
 
+func TestTrafficGraphValidatePools(t *testing.T) {
+	backends := []TrafficBackend{
+		{Name: "a:game", Host: "10.0.0.2", Port: 7777, Protocol: NetworkProtocolUDP},
+		{Name: "b:game", Host: "10.0.0.3", Port: 7777, Protocol: NetworkProtocolUDP},
+	}
+	tests := []struct {
+		name    string
+		service TrafficService
+		wantErr string
+	}{
+		{name: "pool with several backends", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{Strategy: LoadBalancerSourceIPHash}}},
+		{name: "pool with health checks", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{MaxFailures: 3, EjectDuration: time.Second, HealthCheck: TrafficHealthCheck{Interval: time.Second, Timeout: time.Second, Port: 27015, HealthyThreshold: 1, UnhealthyThreshold: 2}}}},
+		{name: "empty pool", service: TrafficService{Name: "pool:game"}, wantErr: "l4 router pool must have at least one backend"},
+		{name: "mixed backend protocols", service: TrafficService{Name: "pool:game", Backends: []TrafficBackend{backends[0], {Name: "c:game", Host: "10.0.0.4", Port: 7777, Protocol: NetworkProtocolTCP}}}, wantErr: "backend protocol tcp does not match router protocol udp"},
+		{name: "duplicate backend names", service: TrafficService{Name: "pool:game", Backends: []TrafficBackend{backends[0], backends[0]}}, wantErr: "duplicate backend \"a:game\""},
+		{name: "unknown strategy", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{Strategy: "random"}}, wantErr: "invalid load balancer strategy \"random\""},
+		{name: "ejection without duration", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{MaxFailures: 3}}, wantErr: "eject_duration for service \"pool:game\" must be positive"},
+		{name: "health check without timeout", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{HealthCheck: TrafficHealthCheck{Interval: time.Second, Port: 27015, HealthyThreshold: 1, UnhealthyThreshold: 1}}}, wantErr: "health_check.timeout"},
+		{name: "udp health check without port", service: TrafficService{Name: "pool:game", Backends: backends, LoadBalancer: TrafficLoadBalancer{HealthCheck: TrafficHealthCheck{Interval: time.Second, Timeout: time.Second, HealthyThreshold: 1, UnhealthyThreshold: 1}}}, wantErr: "health_check.port is required for udp backends"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			graph := TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "udp", Address: ":7777", Protocol: EntryPointProtocolUDP}},
+				Routers:     []TrafficRouter{{Name: "game", EntryPoint: "udp", Protocol: RouterProtocolUDP, Service: "pool:game"}},
+				Services:    []TrafficService{tt.service},
+			}
+			err := graph.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
 func TestTrafficGraphValidateL4ServiceRefs(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -646,6 +790,7 @@ func TestParseTrafficServiceRef(t *testing.T) {
 		{input: "network_service:web:http", want: TrafficServiceRef{Kind: TrafficServiceRefNetworkService, Name: "web", PortName: "http"}},
 		{input: "network_service:game:game", want: TrafficServiceRef{Kind: TrafficServiceRefNetworkService, Name: "game", PortName: "game"}},
 		{input: "service:rust:game", want: TrafficServiceRef{Kind: TrafficServiceRefService, Name: "rust", PortName: "game"}},
+		{input: "pool:game", want: TrafficServiceRef{Kind: TrafficServiceRefPool, Name: "game"}},
 		{input: "static:maintenance", want: TrafficServiceRef{Kind: TrafficServiceRefStatic, Name: "maintenance", Reserved: true}},
 	}
 
@@ -657,7 +802,7 @@ func TestParseTrafficServiceRef(t *testing.T) {
 		})
 	}
 
-	invalid := []string{"", "route:", "external_route:", "network_service:", "network_service:postgres", "network_service:postgres:", "network_service::db", "network_service:postgres:db:extra", "service:", "service:rust", "service:rust:", "service::game", "service:rust:game:extra", "static:", "unknown:value"}
+	invalid := []string{"", "route:", "external_route:", "network_service:", "network_service:postgres", "network_service:postgres:", "network_service::db", "network_service:postgres:db:extra", "service:", "service:rust", "service:rust:", "service::game", "service:rust:game:extra", "pool:", "pool:game:extra", "static:", "unknown:value"}
 	for _, input := range invalid {
 		t.Run("invalid "+input, func(t *testing.T) {
 			_, err := ParseTrafficServiceRef(input)
@@ -717,3 +862,131 @@ func TestTrafficDomainTypesMatchSpecFields(t *testing.T) {
 	require.True(t, status.Services[0].Backends[0].Active)
 	require.Equal(t, int64(7), status.Counters.BytesOut)
 }
+
+func TestTrafficGraphValidateProxyProtocol(t *testing.T) {
+	tcpService := TrafficService{Name: "network_service:app:tcp", Backends: []TrafficBackend{{Name: "app:tcp", Host: "app", Port: 5432, Protocol: NetworkProtocolTCP}}}
+	udpService := TrafficService{Name: "network_service:app:udp", Backends: []TrafficBackend{{Name: "app:udp", Host: "app", Port: 7777, Protocol: NetworkProtocolUDP}}}
+
+	tests := []struct {
+		name    string
+		graph   TrafficGraph
+		wantErr string
+	}{
+		{
+			name: "trusted entrypoint and v2 router",
+			graph: TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "tcp", Address: ":5432", Protocol: EntryPointProtocolTCP, ProxyProtocol: true, ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"}}},
+				Routers:     []TrafficRouter{{Name: "db", EntryPoint: "tcp", Protocol: RouterProtocolTCP, Service: tcpService.Name, ProxyProtocol: ProxyProtocolV2}},
+				Services:    []TrafficService{tcpService},
+			},
+		},
+		{
+			name:    "entrypoint requires trusted cidrs",
+			graph:   TrafficGraph{EntryPoints: []EntryPoint{{Name: "edge", Address: ":443", Protocol: EntryPointProtocolSmartTCP, ProxyProtocol: true}}},
+			wantErr: "requires proxy_protocol_trusted_cidrs",
+		},
+		{
+			name:    "trusted cidrs require proxy protocol",
+			graph:   TrafficGraph{EntryPoints: []EntryPoint{{Name: "edge", Address: ":443", Protocol: EntryPointProtocolSmartTCP, ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"}}}},
+			wantErr: "requires proxy_protocol",
+		},
+		{
+			name:    "invalid trusted cidr",
+			graph:   TrafficGraph{EntryPoints: []EntryPoint{{Name: "edge", Address: ":443", Protocol: EntryPointProtocolSmartTCP, ProxyProtocol: true, ProxyProtocolTrustedCIDRs: []string{"10.0.0.1"}}}},
+			wantErr: "invalid proxy_protocol_trusted_cidrs entry",
+		},
+		{
+			name:    "udp entrypoint",
+			graph:   TrafficGraph{EntryPoints: []EntryPoint{{Name: "game", Address: ":7777", Protocol: EntryPointProtocolUDP, ProxyProtocol: true, ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"}}}},
+			wantErr: "not supported on udp entrypoint",
+		},
+		{
+			name:    "http3 entrypoint",
+			graph:   TrafficGraph{EntryPoints: []EntryPoint{{Name: "edge-h3", Address: ":443", Protocol: EntryPointProtocolHTTP3, ProxyProtocol: true, ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"}}}},
+			wantErr: "not supported on http3 entrypoint",
+		},
+		{
+			name: "udp router",
+			graph: TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "game", Address: ":7777", Protocol: EntryPointProtocolUDP}},
+				Routers:     []TrafficRouter{{Name: "game", EntryPoint: "game", Protocol: RouterProtocolUDP, Service: udpService.Name, ProxyProtocol: ProxyProtocolV1}},
+				Services:    []TrafficService{udpService},
+			},
+			wantErr: "only supported on tcp and tls passthrough routers",
+		},
+		{
+			name: "unknown version",
+			graph: TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "tcp", Address: ":5432", Protocol: EntryPointProtocolTCP}},
+				Routers:     []TrafficRouter{{Name: "db", EntryPoint: "tcp", Protocol: RouterProtocolTCP, Service: tcpService.Name, ProxyProtocol: 3}},
+				Services:    []TrafficService{tcpService},
+			},
+			wantErr: "must be 1 or 2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.graph.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTrafficGraphValidateRouterLimits(t *testing.T) {
+	tcpService := TrafficService{Name: "network_service:app:tcp", Backends: []TrafficBackend{{Name: "app:tcp", Host: "app", Port: 5432, Protocol: NetworkProtocolTCP}}}
+	udpService := TrafficService{Name: "network_service:app:udp", Backends: []TrafficBackend{{Name: "app:udp", Host: "app", Port: 7777, Protocol: NetworkProtocolUDP}}}
+	tcpGraph := func(limits TrafficLimits) TrafficGraph {
+		return TrafficGraph{
+			EntryPoints: []EntryPoint{{Name: "tcp", Address: ":5432", Protocol: EntryPointProtocolTCP}},
+			Routers:     []TrafficRouter{{Name: "db", EntryPoint: "tcp", Protocol: RouterProtocolTCP, Service: tcpService.Name, Limits: limits}},
+			Services:    []TrafficService{tcpService},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		graph   TrafficGraph
+		wantErr string
+	}{
+		{name: "tcp limits", graph: tcpGraph(TrafficLimits{MaxConnections: 100, MaxConnectionsPerIP: 10, ConnectionRate: 5, ConnectionBurst: 20, BandwidthBytesPerSecond: 1 << 20})},
+		{
+			name: "udp limits",
+			graph: TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "game", Address: ":7777", Protocol: EntryPointProtocolUDP}},
+				Routers:     []TrafficRouter{{Name: "game", EntryPoint: "game", Protocol: RouterProtocolUDP, Service: udpService.Name, Limits: TrafficLimits{MaxConnectionsPerIP: 2}}},
+				Services:    []TrafficService{udpService},
+			},
+		},
+		{name: "negative value", graph: tcpGraph(TrafficLimits{BandwidthBytesPerSecond: -1}), wantErr: "must not be negative"},
+		{name: "burst without rate", graph: tcpGraph(TrafficLimits{ConnectionBurst: 5}), wantErr: "connection_burst requires connection_rate"},
+		{name: "per ip above router cap", graph: tcpGraph(TrafficLimits{MaxConnections: 2, MaxConnectionsPerIP: 3}), wantErr: "max_connections_per_ip exceeds max_connections"},
+		{
+			name: "http router",
+			graph: TrafficGraph{
+				EntryPoints: []EntryPoint{{Name: "edge", Address: ":443", Protocol: EntryPointProtocolSmartTCP}},
+				Routers:     []TrafficRouter{{Name: "route:app.example.com", EntryPoint: "edge", Protocol: RouterProtocolHTTP, Rule: TrafficRule{Host: "app.example.com"}, Service: "route:app.example.com", Limits: TrafficLimits{MaxConnections: 1}}},
+				Services:    []TrafficService{{Name: "route:app.example.com"}},
+			},
+			wantErr: "limits are only supported on tcp, udp and tls passthrough routers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.graph.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestTrafficLimitsEffectiveConnectionBurst(t *testing.T) {
+	require.Equal(t, 5, TrafficLimits{ConnectionRate: 5}.EffectiveConnectionBurst())
+	require.Equal(t, 20, TrafficLimits{ConnectionRate: 5, ConnectionBurst: 20}.EffectiveConnectionBurst())
+}
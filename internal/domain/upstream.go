@@ -0,0 +1,93 @@
+package domain
+
+import "time"
+
+// Upstream connection defaults, matching the proxy's historical transport.
+const (
+	DefaultUpstreamDialTimeout     = 10 * time.Second
+	DefaultUpstreamHeaderTimeout   = 30 * time.Second
+	DefaultUpstreamIdleTimeout     = 90 * time.Second
+	DefaultUpstreamBreakerCooldown = 30 * time.Second
+)
+
+// UpstreamPolicy controls how the proxy talks to a route's upstream.
+type UpstreamPolicy struct {
+	DialTimeout      time.Duration // Time allowed to open a connection
+	HeaderTimeout    time.Duration // Time allowed for response headers after the request is sent (0 = no limit)
+	IdleTimeout      time.Duration // How long idle keep-alive connections are kept
+	Retries          int           // Extra attempts for idempotent requests that hit a connection error
+	BreakerThreshold int           // Consecutive upstream errors that open the circuit (0 = no breaker)
+	BreakerCooldown  time.Duration // How long an open circuit fast-fails before a trial request
+}
+
+// DefaultUpstreamPolicy returns the upstream policy used when nothing is configured.
+func DefaultUpstreamPolicy() UpstreamPolicy {
+	return UpstreamPolicy{
+		DialTimeout:     DefaultUpstreamDialTimeout,
+		HeaderTimeout:   DefaultUpstreamHeaderTimeout,
+		IdleTimeout:     DefaultUpstreamIdleTimeout,
+		BreakerCooldown: DefaultUpstreamBreakerCooldown,
+	}
+}
+
+// UpstreamOverrides holds the upstream settings a route sets explicitly;
+// nil fields inherit the global policy.
+type UpstreamOverrides struct {
+	DialTimeout      *time.Duration
+	HeaderTimeout    *time.Duration
+	IdleTimeout      *time.Duration
+	Retries          *int
+	BreakerThreshold *int
+	BreakerCooldown  *time.Duration
+}
+
+// IsZero reports whether no setting is overridden.
+func (o *UpstreamOverrides) IsZero() bool {
+	return o == nil || *o == UpstreamOverrides{}
+}
+
+// WithOverrides returns the policy with the route overrides applied.
+func (p UpstreamPolicy) WithOverrides(o *UpstreamOverrides) UpstreamPolicy {
+	if o == nil {
+		return p
+	}
+	if o.DialTimeout != nil {
+		p.DialTimeout = *o.DialTimeout
+	}
+	if o.HeaderTimeout != nil {
+		p.HeaderTimeout = *o.HeaderTimeout
+	}
+	if o.IdleTimeout != nil {
+		p.IdleTimeout = *o.IdleTimeout
+	}
+	if o.Retries != nil {
+		p.Retries = *o.Retries
+	}
+	if o.BreakerThreshold != nil {
+		p.BreakerThreshold = *o.BreakerThreshold
+	}
+	if o.BreakerCooldown != nil {
+		p.BreakerCooldown = *o.BreakerCooldown
+	}
+	return p
+}
+
+// Circuit breaker states.
+const (
+	CircuitClosed   = "closed"    // Requests flow normally
+	CircuitOpen     = "open"      // Requests fail fast until the cooldown ends
+	CircuitHalfOpen = "half-open" // One trial request decides whether to close
+	CircuitDisabled = "disabled"  // The route has no breaker
+)
+
+// UpstreamStatus describes the circuit breaker of a route's upstream.
+type UpstreamStatus struct {
+	Domain              string
+	State               string
+	ConsecutiveFailures int
+	Threshold           int
+	Cooldown            time.Duration
+	OpenedAt            time.Time // When the circuit last opened; zero when closed
+	LastError           string
+	LastErrorAt         time.Time
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamPolicy_WithOverrides(t *testing.T) {
+	base := DefaultUpstreamPolicy()
+	assert.Equal(t, base, base.WithOverrides(nil))
+
+	retries := 0
+	headerTimeout := 10 * time.Minute
+	threshold := 5
+	base.Retries = 2
+
+	got := base.WithOverrides(&UpstreamOverrides{
+		HeaderTimeout:    &headerTimeout,
+		Retries:          &retries,
+		BreakerThreshold: &threshold,
+	})
+
+	assert.Equal(t, DefaultUpstreamDialTimeout, got.DialTimeout)
+	assert.Equal(t, 10*time.Minute, got.HeaderTimeout)
+	assert.Equal(t, 0, got.Retries)
+	assert.Equal(t, 5, got.BreakerThreshold)
+	assert.Equal(t, DefaultUpstreamBreakerCooldown, got.BreakerCooldown)
+}
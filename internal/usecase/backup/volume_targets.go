@@ -62,6 +62,10 @@ func selectedVolumeBackupContainerDomain(c *domain.Container, domainFilter strin
 	if c.Labels == nil || c.Labels[domain.LabelManaged] != "true" {
 		return "", false
 	}
+	// Process containers mount the volumes of their route's web container.
+	if c.Labels[domain.LabelProcess] != "" {
+		return "", false
+	}
 	domainName := volumeBackupDomain(c)
 	if domainName == "" || (domainFilter != "" && domainName != domainFilter) {
 		return "", false
@@ -19,6 +19,16 @@ func TestSelectVolumeBackupTargets(t *testing.T) {
 			},
 			VolumeMounts: []domain.ContainerVolumeMount{{Name: "gordon-app-data", Type: "volume", Destination: "/data"}},
 		},
+		{
+			ID:   "worker",
+			Name: "a-worker",
+			Labels: map[string]string{
+				domain.LabelManaged: "true",
+				domain.LabelRoute:   "app.example.com",
+				domain.LabelProcess: "worker",
+			},
+			VolumeMounts: []domain.ContainerVolumeMount{{Name: "gordon-app-example-com-data", Type: "volume", Destination: "/data"}},
+		},
 		{
 			ID:   "app",
 			Name: "app",
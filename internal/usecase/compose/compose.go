@@ -0,0 +1,430 @@
+// Package compose maps a docker compose app to a Gordon route, its
+// attachments and standalone services, for gordon import compose.
+package compose
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// composeFile is the subset of the Compose specification that
+// gordon import compose understands.
+type composeFile struct {
+	Name     string                    `yaml:"name"`
+	Services map[string]composeService `yaml:"services"`
+	Volumes  map[string]composeVolume  `yaml:"volumes"`
+}
+
+type composeService struct {
+	Image       string              `yaml:"image"`
+	Build       any                 `yaml:"build"`
+	Command     composeCommand      `yaml:"command"`
+	Environment composeEnvironment  `yaml:"environment"`
+	EnvFile     composeEnvFiles     `yaml:"env_file"`
+	Ports       []composePort       `yaml:"ports"`
+	Volumes     []composeMount      `yaml:"volumes"`
+	DependsOn   composeDependsOn    `yaml:"depends_on"`
+	Healthcheck *composeHealthcheck `yaml:"healthcheck"`
+	User        string              `yaml:"user"`
+	WorkingDir  string              `yaml:"working_dir"`
+}
+
+type composeVolume struct {
+	Name     string `yaml:"name"`
+	External bool   `yaml:"external"`
+}
+
+type composeHealthcheck struct {
+	Test    composeHealthcheckTest `yaml:"test"`
+	Disable bool                   `yaml:"disable"`
+}
+
+// composeCommand is a command given either as a list or as a string that
+// is split like a shell would.
+type composeCommand []string
+
+func (c *composeCommand) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		args, err := splitComposeCommand(node.Value)
+		if err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	case yaml.SequenceNode:
+		var args []string
+		if err := node.Decode(&args); err != nil {
+			return err
+		}
+		*c = args
+		return nil
+	default:
+		return fmt.Errorf("line %d: command must be a string or a list", node.Line)
+	}
+}
+
+// composeHealthcheckTest is a healthcheck test in its raw form: a string
+// run by the shell, or ["CMD", ...], ["CMD-SHELL", "..."] or ["NONE"].
+type composeHealthcheckTest []string
+
+func (t *composeHealthcheckTest) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*t = []string{"CMD-SHELL", node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var test []string
+		if err := node.Decode(&test); err != nil {
+			return err
+		}
+		*t = test
+		return nil
+	default:
+		return fmt.Errorf("line %d: healthcheck test must be a string or a list", node.Line)
+	}
+}
+
+// command returns the command the test runs in the container, or nil when
+// the healthcheck is disabled.
+func (t composeHealthcheckTest) command() []string {
+	if len(t) == 0 {
+		return nil
+	}
+	switch t[0] {
+	case "CMD":
+		return slices.Clone(t[1:])
+	case "CMD-SHELL":
+		return []string{"/bin/sh", "-c", strings.Join(t[1:], " ")}
+	default:
+		return nil
+	}
+}
+
+// composeEnvironment maps variable names to values. A nil value means the
+// variable is passed through from the environment of gordon import.
+type composeEnvironment map[string]*string
+
+func (e *composeEnvironment) UnmarshalYAML(node *yaml.Node) error {
+	env := composeEnvironment{}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if value.Tag == "!!null" {
+				env[key] = nil
+				continue
+			}
+			v := value.Value
+			env[key] = &v
+		}
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			key, value, ok := strings.Cut(item.Value, "=")
+			if !ok {
+				env[key] = nil
+				continue
+			}
+			env[key] = &value
+		}
+	default:
+		return fmt.Errorf("line %d: environment must be a mapping or a list", node.Line)
+	}
+	*e = env
+	return nil
+}
+
+type composeEnvFile struct {
+	Path     string `yaml:"path"`
+	Required *bool  `yaml:"required"`
+}
+
+type composeEnvFiles []composeEnvFile
+
+func (f *composeEnvFiles) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*f = composeEnvFiles{{Path: node.Value}}
+		return nil
+	case yaml.SequenceNode:
+		files := make(composeEnvFiles, 0, len(node.Content))
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				files = append(files, composeEnvFile{Path: item.Value})
+				continue
+			}
+			var file composeEnvFile
+			if err := item.Decode(&file); err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+		*f = files
+		return nil
+	default:
+		return fmt.Errorf("line %d: env_file must be a string or a list", node.Line)
+	}
+}
+
+// composePort is a port published by a service.
+type composePort struct {
+	HostIP    string
+	Published string
+	Target    int
+	Protocol  string
+}
+
+func (p *composePort) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var long struct {
+			Target    int    `yaml:"target"`
+			Published string `yaml:"published"`
+			HostIP    string `yaml:"host_ip"`
+			Protocol  string `yaml:"protocol"`
+		}
+		if err := node.Decode(&long); err != nil {
+			return err
+		}
+		*p = composePort{HostIP: long.HostIP, Published: long.Published, Target: long.Target, Protocol: long.Protocol}
+	} else {
+		port, err := parseComposePort(node.Value)
+		if err != nil {
+			return fmt.Errorf("line %d: %w", node.Line, err)
+		}
+		*p = port
+	}
+	if p.Protocol == "" {
+		p.Protocol = "tcp"
+	}
+	return nil
+}
+
+// parseComposePort parses the short syntax "[[host_ip:]published:]target[/protocol]".
+func parseComposePort(value string) (composePort, error) {
+	spec, protocol, _ := strings.Cut(value, "/")
+	var port composePort
+	port.Protocol = protocol
+
+	parts := strings.Split(spec, ":")
+	targetPart := parts[len(parts)-1]
+	target, err := strconv.Atoi(targetPart)
+	if err != nil {
+		return composePort{}, fmt.Errorf("port %q: port ranges and non-numeric ports are not supported", value)
+	}
+	port.Target = target
+	if len(parts) >= 2 {
+		port.Published = parts[len(parts)-2]
+	}
+	if len(parts) >= 3 {
+		port.HostIP = strings.Trim(strings.Join(parts[:len(parts)-2], ":"), "[]")
+	}
+	return port, nil
+}
+
+// composeMount is an entry of a service's volumes list.
+type composeMount struct {
+	Type     string // "volume", "bind" or "tmpfs"
+	Source   string
+	Target   string
+	ReadOnly bool
+}
+
+func (m *composeMount) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.MappingNode {
+		var long struct {
+			Type     string `yaml:"type"`
+			Source   string `yaml:"source"`
+			Target   string `yaml:"target"`
+			ReadOnly bool   `yaml:"read_only"`
+		}
+		if err := node.Decode(&long); err != nil {
+			return err
+		}
+		*m = composeMount{Type: long.Type, Source: long.Source, Target: long.Target, ReadOnly: long.ReadOnly}
+		return nil
+	}
+
+	parts := strings.Split(node.Value, ":")
+	switch len(parts) {
+	case 1:
+		*m = composeMount{Type: "volume", Target: parts[0]}
+		return nil
+	case 2, 3:
+		*m = composeMount{Source: parts[0], Target: parts[1]}
+		if len(parts) == 3 {
+			m.ReadOnly = slices.Contains(strings.Split(parts[2], ","), "ro")
+		}
+	default:
+		return fmt.Errorf("line %d: volume %q is not in source:target[:mode] form", node.Line, node.Value)
+	}
+	m.Type = "volume"
+	if strings.HasPrefix(m.Source, ".") || strings.HasPrefix(m.Source, "/") || strings.HasPrefix(m.Source, "~") {
+		m.Type = "bind"
+	}
+	return nil
+}
+
+// composeDependsOn lists the services a service depends on, from either
+// the list or the mapping syntax.
+type composeDependsOn []string
+
+func (d *composeDependsOn) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var names []string
+		if err := node.Decode(&names); err != nil {
+			return err
+		}
+		*d = names
+	case yaml.MappingNode:
+		names := make([]string, 0, len(node.Content)/2)
+		for i := 0; i < len(node.Content); i += 2 {
+			names = append(names, node.Content[i].Value)
+		}
+		*d = names
+	default:
+		return fmt.Errorf("line %d: depends_on must be a list or a mapping", node.Line)
+	}
+	return nil
+}
+
+// loadFile reads and interpolates a compose file. Variables are
+// looked up in the environment first, then in the .env file next to it.
+func loadFile(path string, lookupEnv func(string) (string, bool)) (*composeFile, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dotEnv := map[string]string{}
+	if envData, err := os.ReadFile(filepath.Join(filepath.Dir(path), ".env")); err == nil {
+		if dotEnv, err = domain.ParseEnvData(envData); err != nil {
+			return nil, nil, fmt.Errorf("read .env: %w", err)
+		}
+	}
+	lookup := func(name string) (string, bool) {
+		if value, ok := lookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotEnv[name]
+		return value, ok
+	}
+
+	interpolated, unset, err := interpolateCompose(string(data), lookup)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var file composeFile
+	if err := yaml.Unmarshal([]byte(interpolated), &file); err != nil {
+		return nil, nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+	if len(file.Services) == 0 {
+		return nil, nil, fmt.Errorf("%s defines no services", filepath.Base(path))
+	}
+
+	var warnings []string
+	for _, name := range unset {
+		warnings = append(warnings, fmt.Sprintf("variable %s is not set; using an empty value", name))
+	}
+	return &file, warnings, nil
+}
+
+var composeVariablePattern = regexp.MustCompile(`\$(\$|\{[^}]*\}|[A-Za-z_][A-Za-z0-9_]*)`)
+
+// interpolateCompose expands $VAR, ${VAR}, ${VAR:-default}, ${VAR-default},
+// ${VAR:?error} and ${VAR?error} the way docker compose does, with $$ as an
+// escaped dollar sign. It returns the names of unset variables without a
+// default.
+func interpolateCompose(data string, lookup func(string) (string, bool)) (string, []string, error) {
+	var unset []string
+	var firstErr error
+	result := composeVariablePattern.ReplaceAllStringFunc(data, func(match string) string {
+		expr := match[1:]
+		if expr == "$" {
+			return "$"
+		}
+		expr = strings.TrimSuffix(strings.TrimPrefix(expr, "{"), "}")
+
+		name, op, arg := expr, "", ""
+		for _, candidate := range []string{":-", ":?", "-", "?"} {
+			if before, after, ok := strings.Cut(expr, candidate); ok {
+				name, op, arg = before, candidate, after
+				break
+			}
+		}
+		value, ok := lookup(name)
+		switch op {
+		case ":-":
+			if !ok || value == "" {
+				return arg
+			}
+		case "-":
+			if !ok {
+				return arg
+			}
+		case ":?", "?":
+			if !ok || (op == ":?" && value == "") {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("variable %s is required: %s", name, arg)
+				}
+				return ""
+			}
+		}
+		if !ok && !slices.Contains(unset, name) {
+			unset = append(unset, name)
+		}
+		return value
+	})
+	if firstErr != nil {
+		return "", nil, firstErr
+	}
+	return result, unset, nil
+}
+
+// splitComposeCommand splits a command string on whitespace, honouring
+// single and double quotes.
+func splitComposeCommand(value string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range value {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("command %q has an unterminated quote", value)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,58 @@
+package compose
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateCompose(t *testing.T) {
+	lookup := testLookupEnv(map[string]string{"SET": "value", "EMPTY": ""})
+
+	got, unset, err := interpolateCompose("$SET ${SET} ${EMPTY:-d1} ${EMPTY-d2} ${MISSING-d3} $$SET ${MISSING}", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "value value d1  d3 $SET ", got)
+	assert.Equal(t, []string{"MISSING"}, unset)
+
+	_, _, err = interpolateCompose("${MISSING:?must be set}", lookup)
+	assert.ErrorContains(t, err, "variable MISSING is required: must be set")
+}
+
+func TestParseComposePort(t *testing.T) {
+	tests := []struct {
+		in   string
+		want composePort
+	}{
+		{"80", composePort{Target: 80}},
+		{"8080:80", composePort{Published: "8080", Target: 80}},
+		{"127.0.0.1:8080:80/udp", composePort{HostIP: "127.0.0.1", Published: "8080", Target: 80, Protocol: "udp"}},
+		{"[::1]:8080:80", composePort{HostIP: "::1", Published: "8080", Target: 80}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseComposePort(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := parseComposePort("8000-8010:8000-8010")
+	assert.Error(t, err)
+}
+
+func TestComposeStartOrder(t *testing.T) {
+	file := &composeFile{Services: map[string]composeService{
+		"web":    {DependsOn: composeDependsOn{"cache", "db"}},
+		"cache":  {DependsOn: composeDependsOn{"db"}},
+		"db":     {},
+		"search": {},
+	}}
+	assert.Equal(t, []string{"db", "cache", "search", "web"}, composeStartOrder(file))
+}
+
+func TestAttachmentServiceName(t *testing.T) {
+	assert.Equal(t, "postgres", attachmentServiceName("postgres:16"))
+	assert.Equal(t, "redis", attachmentServiceName("docker.io/library/redis:7"))
+	assert.Equal(t, "cache", attachmentServiceName("reg.example.com/my-cache:latest"))
+}
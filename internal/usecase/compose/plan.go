@@ -0,0 +1,430 @@
+package compose
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// Options selects how compose services map to Gordon.
+type Options struct {
+	Domain   string   // route domain the web service is served on
+	Image    string   // route image; defaults to the web service's image
+	Web      string   // compose service served on Domain; detected when empty
+	Services []string // compose services imported as standalone services
+}
+
+// Plan is what a compose file becomes in Gordon. The route, attachments
+// and secrets are applied through the control plane; standalone services
+// and attachment readiness only exist in gordon.toml, so ManualConfig
+// renders them for the operator to add by hand.
+type Plan struct {
+	Domain      string
+	Image       string
+	Env         map[string]string
+	Attachments []Attachment
+	Services    []StandaloneService
+	Readiness   map[string]Readiness
+	Warnings    []string
+}
+
+// Attachment is a compose service imported as an attachment.
+type Attachment struct {
+	Service string // compose service name
+	Name    string // Gordon service name, also its hostname on the route network
+	Image   string
+	Env     map[string]string
+}
+
+// Readiness is the attachment_readiness entry derived from a healthcheck.
+type Readiness struct {
+	Type    string   `toml:"type"`
+	Command []string `toml:"command"`
+}
+
+// StandaloneService is a compose service imported as a [[services]] entry.
+type StandaloneService struct {
+	Name    string          `toml:"name"`
+	Image   string          `toml:"image"`
+	Enabled bool            `toml:"enabled"`
+	Env     []string        `toml:"env,omitempty"`
+	Ports   []ServicePort   `toml:"ports,omitempty"`
+	Volumes []ServiceVolume `toml:"volumes,omitempty"`
+}
+
+// ServicePort is a port of a StandaloneService.
+type ServicePort struct {
+	Name      string `toml:"name"`
+	Container int    `toml:"container"`
+	Protocol  string `toml:"protocol"`
+	Publish   string `toml:"publish,omitempty"`
+}
+
+// ServiceVolume is a volume of a StandaloneService.
+type ServiceVolume struct {
+	Source   string `toml:"source"`
+	Target   string `toml:"target"`
+	ReadOnly bool   `toml:"read_only,omitempty"`
+}
+
+// Load reads the compose file at path and plans its import. Variables and
+// pass-through environment values are looked up with lookupEnv.
+func Load(path string, opts Options, lookupEnv func(string) (string, bool)) (*Plan, error) {
+	file, warnings, err := loadFile(path, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planImport(file, filepath.Dir(path), opts, lookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	plan.Warnings = append(warnings, plan.Warnings...)
+	return plan, nil
+}
+
+// ManualConfig renders the gordon.toml entries the control plane cannot
+// set: standalone services and attachment readiness. It is empty when the
+// plan has neither.
+func (p *Plan) ManualConfig() (string, error) {
+	if len(p.Services) == 0 && len(p.Readiness) == 0 {
+		return "", nil
+	}
+	data, err := toml.Marshal(struct {
+		AttachmentReadiness map[string]Readiness `toml:"attachment_readiness,omitempty"`
+		Services            []StandaloneService  `toml:"services,omitempty"`
+	}{p.Readiness, p.Services})
+	if err != nil {
+		return "", fmt.Errorf("failed to render configuration: %w", err)
+	}
+	return string(data), nil
+}
+
+// planImport maps the services of a compose file to a route, its
+// attachments and standalone services. dir resolves relative env_file
+// paths.
+func planImport(file *composeFile, dir string, opts Options, lookupEnv func(string) (string, bool)) (*Plan, error) {
+	if opts.Domain == "" {
+		return nil, fmt.Errorf("domain is required")
+	}
+	for _, name := range opts.Services {
+		if _, ok := file.Services[name]; !ok {
+			return nil, fmt.Errorf("standalone service %q is not a service of the compose file", name)
+		}
+	}
+
+	webName, err := composeWebService(file, opts)
+	if err != nil {
+		return nil, err
+	}
+	web := file.Services[webName]
+	plan := &Plan{Domain: opts.Domain, Image: cmp.Or(opts.Image, web.Image)}
+	if plan.Image == "" {
+		return nil, fmt.Errorf("service %q builds its image from source; set the image name you push it as", webName)
+	}
+
+	if plan.Env, err = composeServiceEnv(web, dir, lookupEnv); err != nil {
+		return nil, fmt.Errorf("service %q: %w", webName, err)
+	}
+	plan.warnRouteSettings(webName, web)
+	plan.warnMounts(webName, web, "the route image")
+
+	names := make(map[string]string) // Gordon attachment name -> compose service
+	for _, name := range composeStartOrder(file) {
+		if name == webName {
+			continue
+		}
+		svc := file.Services[name]
+		switch {
+		case slices.Contains(opts.Services, name):
+			standalone, err := composeStandalone(file, dir, name, svc, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			plan.Services = append(plan.Services, standalone)
+			plan.warnMounts(name, svc, "")
+		case svc.Build != nil || svc.Image == plan.Image || svc.Image == web.Image:
+			if domain.ValidateProcess(name, svc.Command) == nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("service %q runs the app image; set processes = { %s = %s } on the route", name, name, tomlStringArray(svc.Command)))
+				continue
+			}
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("service %q runs the app image and was not imported", name))
+		case svc.Image == "":
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf("service %q has no image and was not imported", name))
+		default:
+			attachment, err := plan.addAttachment(name, svc, dir, lookupEnv)
+			if err != nil {
+				return nil, err
+			}
+			if previous, ok := names[attachment.Name]; ok {
+				return nil, fmt.Errorf("services %q and %q would both be attached as %q; import one of them as a standalone service", previous, name, attachment.Name)
+			}
+			names[attachment.Name] = name
+		}
+	}
+	return plan, nil
+}
+
+func (p *Plan) addAttachment(name string, svc composeService, dir string, lookupEnv func(string) (string, bool)) (Attachment, error) {
+	env, err := composeServiceEnv(svc, dir, lookupEnv)
+	if err != nil {
+		return Attachment{}, fmt.Errorf("service %q: %w", name, err)
+	}
+	attachment := Attachment{Service: name, Name: attachmentServiceName(svc.Image), Image: svc.Image, Env: env}
+	p.Attachments = append(p.Attachments, attachment)
+
+	if attachment.Name != name {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q is reachable as %q on the route network; update hostnames that point to %q", name, attachment.Name, name))
+	}
+	if len(svc.Ports) > 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q publishes ports; attachments are only reachable from the route network", name))
+	}
+	if len(svc.Command) > 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q overrides its command; attachments run the image CMD, so bake it into the image", name))
+	}
+	if hc := svc.Healthcheck; hc != nil && !hc.Disable {
+		if command := hc.Test.command(); len(command) > 0 {
+			if p.Readiness == nil {
+				p.Readiness = map[string]Readiness{}
+			}
+			p.Readiness[attachment.Name] = Readiness{Type: domain.AttachmentReadinessCommand, Command: command}
+		}
+	}
+	p.warnMounts(name, svc, "the image")
+	return attachment, nil
+}
+
+func (p *Plan) warnRouteSettings(name string, svc composeService) {
+	if len(svc.Command) > 0 {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q overrides its command; set command = %s on the route", name, tomlStringArray(svc.Command)))
+	}
+	if svc.User != "" {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q sets a user; set user = %q on the route", name, svc.User))
+	}
+	if svc.WorkingDir != "" {
+		p.Warnings = append(p.Warnings, fmt.Sprintf("service %q sets a working directory; set working_dir = %q on the route", name, svc.WorkingDir))
+	}
+}
+
+// warnMounts explains what happens to the volumes of a route or attachment,
+// whose data lives in volumes Gordon creates for the image's VOLUME paths.
+// Standalone services keep their named volumes and pass an empty owner.
+func (p *Plan) warnMounts(name string, svc composeService, owner string) {
+	for _, mount := range svc.Volumes {
+		switch {
+		case mount.Type == "bind":
+			p.Warnings = append(p.Warnings, fmt.Sprintf("service %q bind mount %s was not imported", name, mount.Target))
+		case mount.Type != "volume" || owner == "":
+		default:
+			p.Warnings = append(p.Warnings, fmt.Sprintf("service %q volume %s is kept only if %s declares VOLUME %s", name, mount.Target, owner, mount.Target))
+		}
+	}
+}
+
+// composeWebService returns the service served on the route domain: the one
+// named in opts.Web, else the only service built from source, else the only
+// one of those publishing ports, else the only service publishing ports.
+func composeWebService(file *composeFile, opts Options) (string, error) {
+	if opts.Web != "" {
+		if _, ok := file.Services[opts.Web]; !ok {
+			return "", fmt.Errorf("web service %q is not a service of the compose file", opts.Web)
+		}
+		return opts.Web, nil
+	}
+
+	var built, builtPublished, published []string
+	for name, svc := range file.Services {
+		if slices.Contains(opts.Services, name) {
+			continue
+		}
+		if svc.Build != nil {
+			built = append(built, name)
+		}
+		if len(svc.Ports) > 0 {
+			published = append(published, name)
+			if svc.Build != nil {
+				builtPublished = append(builtPublished, name)
+			}
+		}
+	}
+	switch {
+	case len(built) == 1:
+		return built[0], nil
+	case len(builtPublished) == 1:
+		return builtPublished[0], nil
+	case len(built) == 0 && len(published) == 1:
+		return published[0], nil
+	}
+	return "", fmt.Errorf("cannot tell which service is the web app; name the web service")
+}
+
+// composeStartOrder returns the services so that each one comes after the
+// services it depends on, otherwise in name order.
+func composeStartOrder(file *composeFile) []string {
+	names := make([]string, 0, len(file.Services))
+	for name := range file.Services {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	order := make([]string, 0, len(names))
+	state := make(map[string]int) // 1 = visiting, 2 = done
+	var visit func(string)
+	visit = func(name string) {
+		if state[name] != 0 {
+			return // done, or a dependency cycle compose itself rejects
+		}
+		state[name] = 1
+		deps := slices.Clone([]string(file.Services[name].DependsOn))
+		slices.Sort(deps)
+		for _, dep := range deps {
+			if _, ok := file.Services[dep]; ok {
+				visit(dep)
+			}
+		}
+		state[name] = 2
+		order = append(order, name)
+	}
+	for _, name := range names {
+		visit(name)
+	}
+	return order
+}
+
+// composeServiceEnv merges a service's env files and environment, the
+// latter winning. Variables listed without a value are taken from the
+// environment of gordon import and skipped when unset.
+func composeServiceEnv(svc composeService, dir string, lookupEnv func(string) (string, bool)) (map[string]string, error) {
+	env := make(map[string]string)
+	for _, file := range svc.EnvFile {
+		path := file.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) && file.Required != nil && !*file.Required {
+				continue
+			}
+			return nil, fmt.Errorf("env_file: %w", err)
+		}
+		values, err := domain.ParseEnvData(data)
+		if err != nil {
+			return nil, fmt.Errorf("env_file %s: %w", file.Path, err)
+		}
+		for key, value := range values {
+			env[key] = value
+		}
+	}
+	for key, value := range svc.Environment {
+		if value == nil {
+			passed, ok := lookupEnv(key)
+			if !ok {
+				continue
+			}
+			value = &passed
+		}
+		env[key] = *value
+	}
+	for key := range env {
+		if err := domain.ValidateEnvKey(key); err != nil {
+			return nil, fmt.Errorf("env %q: %w", key, err)
+		}
+	}
+	return env, nil
+}
+
+func composeStandalone(file *composeFile, dir, name string, svc composeService, lookupEnv func(string) (string, bool)) (StandaloneService, error) {
+	if svc.Image == "" {
+		return StandaloneService{}, fmt.Errorf("service %q has no image and cannot be a standalone service", name)
+	}
+	env, err := composeServiceEnv(svc, dir, lookupEnv)
+	if err != nil {
+		return StandaloneService{}, fmt.Errorf("service %q: %w", name, err)
+	}
+	standalone := StandaloneService{Name: name, Image: svc.Image, Enabled: true}
+	for _, key := range sortedKeys(env) {
+		standalone.Env = append(standalone.Env, key+"="+env[key])
+	}
+	for _, port := range svc.Ports {
+		servicePort := ServicePort{
+			Name:      fmt.Sprintf("%s-%d", port.Protocol, port.Target),
+			Container: port.Target,
+			Protocol:  port.Protocol,
+		}
+		if port.Published != "" {
+			// Services publish on loopback for the traffic manager to dial.
+			servicePort.Publish = net.JoinHostPort(cmp.Or(port.HostIP, "127.0.0.1"), port.Published)
+		}
+		standalone.Ports = append(standalone.Ports, servicePort)
+	}
+	for _, mount := range svc.Volumes {
+		if mount.Type != "volume" {
+			continue
+		}
+		volume := ServiceVolume{Target: mount.Target, ReadOnly: mount.ReadOnly}
+		if mount.Source != "" {
+			volume.Source = composeVolumeName(file, dir, mount.Source)
+		}
+		standalone.Volumes = append(standalone.Volumes, volume)
+	}
+	return standalone, nil
+}
+
+var composeProjectNameInvalid = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// composeVolumeName returns the Docker volume docker compose created for a
+// named volume, so that a standalone service keeps the data.
+func composeVolumeName(file *composeFile, dir, name string) string {
+	volume := file.Volumes[name]
+	if volume.Name != "" {
+		return volume.Name
+	}
+	if volume.External {
+		return name
+	}
+	project := file.Name
+	if project == "" {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		project = composeProjectNameInvalid.ReplaceAllString(strings.ToLower(filepath.Base(dir)), "")
+	}
+	return project + "_" + name
+}
+
+// attachmentServiceName mirrors how Gordon names an attachment: the image
+// name without registry, tag and a "my-" prefix.
+func attachmentServiceName(image string) string {
+	name, _, _ := strings.Cut(image, ":")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimPrefix(name, "my-")
+}
+
+func tomlStringArray(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
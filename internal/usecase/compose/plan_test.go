@@ -0,0 +1,154 @@
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const testComposeFile = `
+name: shop
+services:
+  web:
+    build: .
+    image: ${REGISTRY:-reg.example.com}/shop:latest
+    command: bundle exec puma -C "config/puma.rb"
+    ports: ["3000:3000"]
+    env_file: .env.web
+    environment:
+      DATABASE_URL: postgres://shop:${DB_PASSWORD}@db:5432/shop
+      RAILS_ENV: production
+      FROM_SHELL:
+    depends_on:
+      db:
+        condition: service_healthy
+      cache:
+        condition: service_started
+  worker:
+    build: .
+    command: ["bundle", "exec", "sidekiq"]
+  db:
+    image: postgres:16
+    environment:
+      - POSTGRES_PASSWORD=${DB_PASSWORD}
+      - POSTGRES_USER=shop
+    volumes:
+      - pgdata:/var/lib/postgresql/data
+    healthcheck:
+      test: ["CMD-SHELL", "pg_isready -U shop"]
+  cache:
+    image: redis:7
+    depends_on: [db]
+  mail:
+    image: axllent/mailpit:latest
+    ports:
+      - "8025:8025"
+      - target: 1025
+        published: "1025"
+    volumes:
+      - maildata:/data
+      - ./certs:/certs:ro
+volumes:
+  pgdata:
+  maildata:
+`
+
+func writeTestCompose(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "compose.yaml"), []byte(testComposeFile), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("DB_PASSWORD=s3cret\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env.web"), []byte("SECRET_KEY_BASE=abc\nRAILS_ENV=development\n"), 0o600))
+	return filepath.Join(dir, "compose.yaml")
+}
+
+func testLookupEnv(values map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	path := writeTestCompose(t)
+	lookup := testLookupEnv(map[string]string{"FROM_SHELL": "yes"})
+	file, warnings, err := loadFile(path, lookup)
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	plan, err := planImport(file, filepath.Dir(path), Options{
+		Domain:   "shop.example.com",
+		Services: []string{"mail"},
+	}, lookup)
+	require.NoError(t, err)
+
+	assert.Equal(t, "reg.example.com/shop:latest", plan.Image)
+	assert.Equal(t, map[string]string{
+		"DATABASE_URL":    "postgres://shop:s3cret@db:5432/shop",
+		"RAILS_ENV":       "production",
+		"SECRET_KEY_BASE": "abc",
+		"FROM_SHELL":      "yes",
+	}, plan.Env)
+
+	require.Len(t, plan.Attachments, 2)
+	assert.Equal(t, Attachment{
+		Service: "db",
+		Name:    "postgres",
+		Image:   "postgres:16",
+		Env:     map[string]string{"POSTGRES_PASSWORD": "s3cret", "POSTGRES_USER": "shop"},
+	}, plan.Attachments[0])
+	assert.Equal(t, "redis", plan.Attachments[1].Name)
+
+	assert.Equal(t, map[string]Readiness{
+		"postgres": {Type: domain.AttachmentReadinessCommand, Command: []string{"/bin/sh", "-c", "pg_isready -U shop"}},
+	}, plan.Readiness)
+
+	require.Len(t, plan.Services, 1)
+	assert.Equal(t, StandaloneService{
+		Name:    "mail",
+		Image:   "axllent/mailpit:latest",
+		Enabled: true,
+		Ports: []ServicePort{
+			{Name: "tcp-8025", Container: 8025, Protocol: "tcp", Publish: "127.0.0.1:8025"},
+			{Name: "tcp-1025", Container: 1025, Protocol: "tcp", Publish: "127.0.0.1:1025"},
+		},
+		Volumes: []ServiceVolume{{Source: "shop_maildata", Target: "/data"}},
+	}, plan.Services[0])
+
+	assert.Contains(t, plan.Warnings, `service "worker" runs the app image; set processes = { worker = ["bundle", "exec", "sidekiq"] } on the route`)
+	assert.Contains(t, plan.Warnings, `service "web" overrides its command; set command = ["bundle", "exec", "puma", "-C", "config/puma.rb"] on the route`)
+	assert.Contains(t, plan.Warnings, `service "db" is reachable as "postgres" on the route network; update hostnames that point to "db"`)
+	assert.Contains(t, plan.Warnings, `service "db" volume /var/lib/postgresql/data is kept only if the image declares VOLUME /var/lib/postgresql/data`)
+	assert.Contains(t, plan.Warnings, `service "mail" bind mount /certs was not imported`)
+}
+
+func TestPlanImport_Errors(t *testing.T) {
+	file := &composeFile{Services: map[string]composeService{
+		"web": {Build: "."},
+		"api": {Build: "."},
+	}}
+	lookup := testLookupEnv(nil)
+
+	_, err := planImport(file, t.TempDir(), Options{Domain: "app.example.com"}, lookup)
+	assert.ErrorContains(t, err, "name the web service")
+
+	_, err = planImport(file, t.TempDir(), Options{Domain: "app.example.com", Web: "web"}, lookup)
+	assert.ErrorContains(t, err, "set the image name")
+
+	file = &composeFile{Services: map[string]composeService{
+		"web":  {Image: "app:latest", Ports: []composePort{{Target: 80}}},
+		"db":   {Image: "postgres:16"},
+		"db2":  {Image: "postgres:15"},
+		"mail": {Image: "mailpit"},
+	}}
+	_, err = planImport(file, t.TempDir(), Options{Domain: "app.example.com"}, lookup)
+	assert.ErrorContains(t, err, `would both be attached as "postgres"`)
+
+	_, err = planImport(file, t.TempDir(), Options{Domain: "app.example.com", Services: []string{"missing"}}, lookup)
+	assert.ErrorContains(t, err, `standalone service "missing"`)
+}
@@ -5,7 +5,9 @@ import (
 	"cmp"
 	"context"
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"regexp"
@@ -23,6 +25,7 @@ import (
 
 	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/bytesize"
 )
 
 // Config holds the loaded configuration.
@@ -55,8 +58,18 @@ type Config struct {
 }
 
 type routeConfig struct {
-	Image string `toml:"image"`
-	HTTPS bool   `toml:"https"`
+	Image       string                     `toml:"image"`
+	HTTPS       bool                       `toml:"https"`
+	IdleTimeout time.Duration              `toml:"idle_timeout"`
+	WakePage    bool                       `toml:"wake_page"`
+	Compression *bool                      `toml:"compression"`
+	Cache       *bool                      `toml:"cache"`
+	Upstream    *domain.UpstreamOverrides  `toml:"upstream"`
+	ClientAuth  domain.ClientAuthMode      `toml:"client_auth"`
+	ClientCA    string                     `toml:"client_ca"`
+	Container   *domain.ContainerOverrides `toml:"-"`
+	Hooks       *domain.DeployHooks        `toml:"-"`
+	Processes   map[string][]string        `toml:"-"`
 }
 
 // Service implements the ConfigService interface.
@@ -367,16 +380,397 @@ func parseRouteTable(domainName string, raw map[string]any) (routeConfig, error)
 		return routeConfig{}, fmt.Errorf("route %q has invalid image field", domainName)
 	}
 
-	httpsValue, ok := raw["https"]
-	if !ok {
-		return routeConfig{Image: imageValue, HTTPS: true}, nil
+	route := routeConfig{Image: imageValue, HTTPS: true}
+	if httpsValue, ok := raw["https"]; ok {
+		https, ok := httpsValue.(bool)
+		if !ok {
+			return routeConfig{}, fmt.Errorf("route %q has invalid https field", domainName)
+		}
+		route.HTTPS = https
 	}
-	https, ok := httpsValue.(bool)
-	if !ok {
-		return routeConfig{}, fmt.Errorf("route %q has invalid https field", domainName)
+
+	if err := parseRouteOptions(domainName, raw, &route); err != nil {
+		return routeConfig{}, err
+	}
+
+	return route, nil
+}
+
+// parseRouteOptions reads the optional per-route tuning fields of a route table.
+func parseRouteOptions(domainName string, raw map[string]any, route *routeConfig) error {
+	if value, ok := raw["idle_timeout"]; ok {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("route %q has invalid idle_timeout field", domainName)
+		}
+		idleTimeout, err := time.ParseDuration(text)
+		if err != nil || idleTimeout < 0 {
+			return fmt.Errorf("route %q has invalid idle_timeout %q", domainName, text)
+		}
+		route.IdleTimeout = idleTimeout
+	}
+
+	if value, ok := raw["wake_page"]; ok {
+		wakePage, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("route %q has invalid wake_page field", domainName)
+		}
+		route.WakePage = wakePage
+	}
+
+	if value, ok := raw["compression"]; ok {
+		compression, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("route %q has invalid compression field", domainName)
+		}
+		route.Compression = &compression
+	}
+
+	if value, ok := raw["cache"]; ok {
+		cache, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("route %q has invalid cache field", domainName)
+		}
+		route.Cache = &cache
+	}
+
+	if value, ok := raw["upstream"]; ok {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("route %q has invalid upstream field", domainName)
+		}
+		upstream, err := parseRouteUpstream(domainName, table)
+		if err != nil {
+			return err
+		}
+		route.Upstream = upstream
+	}
+
+	if value, ok := raw["client_auth"]; ok {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("route %q has invalid client_auth field", domainName)
+		}
+		mode, err := domain.ParseClientAuthMode(text)
+		if err != nil {
+			return fmt.Errorf("route %q: %w", domainName, err)
+		}
+		route.ClientAuth = mode
+	}
+
+	if value, ok := raw["client_ca"]; ok {
+		text, ok := value.(string)
+		if !ok || strings.TrimSpace(text) == "" {
+			return fmt.Errorf("route %q has invalid client_ca field", domainName)
+		}
+		if route.ClientAuth == "" {
+			return fmt.Errorf("route %q sets client_ca without client_auth", domainName)
+		}
+		route.ClientCA = strings.TrimSpace(text)
+	}
+
+	container, err := parseRouteContainer(domainName, raw)
+	if err != nil {
+		return err
+	}
+	if !container.IsZero() {
+		route.Container = container
+	}
+
+	hooks, err := parseRouteHooks(domainName, raw)
+	if err != nil {
+		return err
+	}
+	if !hooks.IsZero() {
+		route.Hooks = hooks
+	}
+
+	if value, ok := raw["processes"]; ok {
+		table, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("route %q has invalid processes field", domainName)
+		}
+		processes, err := parseRouteProcesses(domainName, table)
+		if err != nil {
+			return err
+		}
+		route.Processes = processes
+	}
+
+	return nil
+}
+
+// parseRouteProcesses reads the processes inline table of a route, e.g.
+// processes = { worker = ["bundle", "exec", "sidekiq"] }.
+func parseRouteProcesses(domainName string, raw map[string]any) (map[string][]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	processes := make(map[string][]string, len(raw))
+	for name, value := range raw {
+		command, ok := routeStringList(value)
+		if !ok {
+			return nil, fmt.Errorf("route %q has invalid processes.%s field", domainName, name)
+		}
+		if err := domain.ValidateProcess(name, command); err != nil {
+			return nil, fmt.Errorf("route %q: %w", domainName, err)
+		}
+		processes[name] = command
+	}
+	return processes, nil
+}
+
+// parseRouteHooks reads the deploy hook fields of a route table, e.g.
+// release_command = ["./manage", "migrate"], release_timeout = "5m".
+func parseRouteHooks(domainName string, raw map[string]any) (*domain.DeployHooks, error) {
+	hooks := &domain.DeployHooks{}
+	for key, target := range map[string]*[]string{"release_command": &hooks.Release, "post_deploy_command": &hooks.PostDeploy} {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		command, ok := routeStringList(value)
+		if !ok || len(command) == 0 || command[0] == "" {
+			return nil, fmt.Errorf("route %q has invalid %s field", domainName, key)
+		}
+		*target = command
 	}
 
-	return routeConfig{Image: imageValue, HTTPS: https}, nil
+	if value, ok := raw["release_timeout"]; ok {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("route %q has invalid release_timeout field", domainName)
+		}
+		timeout, err := time.ParseDuration(text)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("route %q has invalid release_timeout %q", domainName, text)
+		}
+		if len(hooks.Release) == 0 && len(hooks.PostDeploy) == 0 {
+			return nil, fmt.Errorf("route %q sets release_timeout without release_command or post_deploy_command", domainName)
+		}
+		hooks.Timeout = timeout
+	}
+	return hooks, nil
+}
+
+// parseRouteContainer reads the container resource and runtime fields of a
+// route table, e.g. memory = "2GB", cpus = 1.5, command = ["worker"].
+func parseRouteContainer(domainName string, raw map[string]any) (*domain.ContainerOverrides, error) {
+	container := &domain.ContainerOverrides{}
+	if value, ok := raw["memory"]; ok {
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("route %q has invalid memory field", domainName)
+		}
+		memory, err := bytesize.Parse(text)
+		if err != nil || memory <= 0 {
+			return nil, fmt.Errorf("route %q has invalid memory %q", domainName, text)
+		}
+		container.MemoryLimit = memory
+	}
+
+	if value, ok := raw["cpus"]; ok {
+		var cpus float64
+		switch v := value.(type) {
+		case int64:
+			cpus = float64(v)
+		case float64:
+			cpus = v
+		default:
+			return nil, fmt.Errorf("route %q has invalid cpus field", domainName)
+		}
+		if cpus <= 0 || cpus > maxRouteCPUs {
+			return nil, fmt.Errorf("route %q has invalid cpus %v", domainName, value)
+		}
+		container.NanoCPUs = int64(cpus * 1e9)
+	}
+
+	if value, ok := raw["pids_limit"]; ok {
+		n, ok := value.(int64)
+		if !ok || n <= 0 {
+			return nil, fmt.Errorf("route %q has invalid pids_limit field", domainName)
+		}
+		container.PidsLimit = n
+	}
+
+	if value, ok := raw["command"]; ok {
+		command, ok := routeStringList(value)
+		if !ok || len(command) == 0 || command[0] == "" {
+			return nil, fmt.Errorf("route %q has invalid command field", domainName)
+		}
+		container.Command = command
+	}
+
+	for key, target := range map[string]*string{"user": &container.User, "working_dir": &container.WorkingDir} {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		text, ok := value.(string)
+		if !ok || strings.TrimSpace(text) == "" {
+			return nil, fmt.Errorf("route %q has invalid %s field", domainName, key)
+		}
+		*target = strings.TrimSpace(text)
+	}
+	if container.WorkingDir != "" && !path.IsAbs(container.WorkingDir) {
+		return nil, fmt.Errorf("route %q has invalid working_dir %q: must be absolute", domainName, container.WorkingDir)
+	}
+
+	if value, ok := raw["cap_add"]; ok {
+		names, ok := routeStringList(value)
+		if !ok {
+			return nil, fmt.Errorf("route %q has invalid cap_add field", domainName)
+		}
+		for _, name := range names {
+			capability, err := domain.NormalizeCapability(name)
+			if err != nil {
+				return nil, fmt.Errorf("route %q: %w", domainName, err)
+			}
+			if !slices.Contains(container.CapAdd, capability) {
+				container.CapAdd = append(container.CapAdd, capability)
+			}
+		}
+	}
+
+	if value, ok := raw["tmpfs"]; ok {
+		specs, ok := routeStringList(value)
+		if !ok {
+			return nil, fmt.Errorf("route %q has invalid tmpfs field", domainName)
+		}
+		container.Tmpfs = make(map[string]string, len(specs))
+		for _, spec := range specs {
+			mountPath, options, err := domain.ParseTmpfsMount(spec)
+			if err != nil {
+				return nil, fmt.Errorf("route %q: %w", domainName, err)
+			}
+			container.Tmpfs[mountPath] = options
+		}
+	}
+	return container, nil
+}
+
+// maxRouteCPUs bounds the cpus of a route.
+const maxRouteCPUs = 1024
+
+// routeStringList converts a TOML array into strings, reporting false when
+// value is not an array of strings.
+func routeStringList(value any) ([]string, bool) {
+	switch v := value.(type) {
+	case []string:
+		return v, true
+	case []any:
+		list := make([]string, 0, len(v))
+		for _, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			list = append(list, text)
+		}
+		return list, true
+	default:
+		return nil, false
+	}
+}
+
+// maxRouteUpstreamCount bounds the retries and breaker_threshold of a route.
+const maxRouteUpstreamCount = 100
+
+// parseRouteUpstream reads the upstream inline table of a route, e.g.
+// upstream = { header_timeout = "5m", retries = 2, breaker_threshold = 5 }.
+func parseRouteUpstream(domainName string, raw map[string]any) (*domain.UpstreamOverrides, error) {
+	upstream := &domain.UpstreamOverrides{}
+	for key, value := range raw {
+		switch key {
+		case "dial_timeout", "header_timeout", "idle_timeout", "breaker_cooldown":
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("route %q has invalid upstream.%s field", domainName, key)
+			}
+			d, err := time.ParseDuration(text)
+			if err != nil || d < 0 || (d == 0 && (key == "dial_timeout" || key == "breaker_cooldown")) {
+				return nil, fmt.Errorf("route %q has invalid upstream.%s %q", domainName, key, text)
+			}
+			switch key {
+			case "dial_timeout":
+				upstream.DialTimeout = &d
+			case "header_timeout":
+				upstream.HeaderTimeout = &d
+			case "idle_timeout":
+				upstream.IdleTimeout = &d
+			default:
+				upstream.BreakerCooldown = &d
+			}
+		case "retries", "breaker_threshold":
+			n, ok := value.(int64)
+			if !ok || n < 0 || n > maxRouteUpstreamCount {
+				return nil, fmt.Errorf("route %q has invalid upstream.%s field", domainName, key)
+			}
+			count := int(n)
+			if key == "retries" {
+				upstream.Retries = &count
+			} else {
+				upstream.BreakerThreshold = &count
+			}
+		default:
+			return nil, fmt.Errorf("route %q has unknown upstream.%s field", domainName, key)
+		}
+	}
+	return upstream, nil
+}
+
+// toDomainRoute converts a stored route entry into its domain representation.
+func (r routeConfig) toDomainRoute(domainName string) domain.Route {
+	return domain.Route{
+		Domain:      domainName,
+		Image:       r.Image,
+		HTTPS:       r.HTTPS,
+		IdleTimeout: r.IdleTimeout,
+		WakePage:    r.WakePage,
+		Compression: r.Compression,
+		Cache:       r.Cache,
+		Upstream:    r.Upstream,
+		ClientAuth:  r.clientAuthPolicy(),
+		Container:   r.Container,
+		Hooks:       r.Hooks,
+		Processes:   r.Processes,
+	}
+}
+
+// clientAuthPolicy returns the client certificate policy of the route, or
+// nil when client authentication is off.
+func (r routeConfig) clientAuthPolicy() *domain.ClientAuthPolicy {
+	if r.ClientAuth == "" {
+		return nil
+	}
+	return &domain.ClientAuthPolicy{Mode: r.ClientAuth, CAFile: r.ClientCA}
+}
+
+// routeConfigFromDomain converts a domain route into its stored representation.
+func routeConfigFromDomain(route domain.Route) routeConfig {
+	cfg := routeConfig{
+		Image:       route.Image,
+		HTTPS:       route.HTTPS,
+		IdleTimeout: route.IdleTimeout,
+		WakePage:    route.WakePage,
+		Compression: route.Compression,
+		Cache:       route.Cache,
+		Upstream:    route.Upstream,
+	}
+	if !route.Container.IsZero() {
+		cfg.Container = route.Container
+	}
+	if !route.Hooks.IsZero() {
+		cfg.Hooks = route.Hooks
+	}
+	if len(route.Processes) > 0 {
+		cfg.Processes = route.Processes
+	}
+	if route.ClientAuth != nil && route.ClientAuth.Mode != "" {
+		cfg.ClientAuth = route.ClientAuth.Mode
+		cfg.ClientCA = route.ClientAuth.CAFile
+	}
+	return cfg
 }
 
 // GetRoutes returns all configured routes.
@@ -389,7 +783,7 @@ func (s *Service) GetRoutes(_ context.Context) []domain.Route {
 		if strings.HasPrefix(domainName, "http://") {
 			continue
 		}
-		routes = append(routes, domain.Route{Domain: domainName, Image: route.Image, HTTPS: route.HTTPS})
+		routes = append(routes, route.toDomainRoute(domainName))
 	}
 
 	return routes
@@ -409,7 +803,7 @@ func (s *Service) GetRoute(_ context.Context, domainName string) (*domain.Route,
 		return nil, domain.ErrRouteNotFound
 	}
 
-	routeValue := domain.Route{Domain: domainName, Image: routeCfg.Image, HTTPS: routeCfg.HTTPS}
+	routeValue := routeCfg.toDomainRoute(domainName)
 	route := &routeValue
 
 	return route, nil
@@ -428,7 +822,7 @@ func (s *Service) FindRoutesByImage(_ context.Context, imageName string) []domai
 			continue
 		}
 		if matchesImageName(imageName, route.Image, s.config.RegistryDomain, s.config.LegacyRegistryDomains) {
-			routes = append(routes, domain.Route{Domain: domainName, Image: route.Image, HTTPS: route.HTTPS})
+			routes = append(routes, route.toDomainRoute(domainName))
 		}
 	}
 
@@ -563,8 +957,8 @@ func (s *Service) AddRoute(ctx context.Context, route domain.Route) error {
 	previousCanonicalRoute, canonicalExisted := currentConfig.Routes[route.Domain]
 	legacyKey := legacyRouteStorageKey(route.Domain)
 	_, legacyExisted := currentConfig.Routes[legacyKey]
-	newRoute := routeConfig{Image: route.Image, HTTPS: route.HTTPS}
-	if canonicalExisted && previousCanonicalRoute == newRoute && !legacyExisted {
+	newRoute := routeConfigFromDomain(route)
+	if canonicalExisted && reflect.DeepEqual(previousCanonicalRoute, newRoute) && !legacyExisted {
 		s.mu.Unlock()
 		return nil
 	}
@@ -961,17 +1355,172 @@ func renderCanonicalRoutesSection(routes map[string]routeConfig) string {
 		b.WriteString(" = { image = ")
 		b.WriteString(strconv.Quote(route.Image))
 		b.WriteString(", https = ")
-		if route.HTTPS {
-			b.WriteString("true")
-		} else {
-			b.WriteString("false")
-		}
+		b.WriteString(strconv.FormatBool(route.HTTPS))
+		writeRouteOptions(&b, route)
 		b.WriteString(" }\n")
 	}
 
 	return b.String()
 }
 
+// writeRouteOptions appends the optional route fields that differ from their
+// defaults, so plain routes keep rendering as { image, https }.
+func writeRouteOptions(b *strings.Builder, route routeConfig) {
+	if route.IdleTimeout > 0 {
+		b.WriteString(", idle_timeout = ")
+		b.WriteString(strconv.Quote(formatRouteDuration(route.IdleTimeout)))
+	}
+	if route.WakePage {
+		b.WriteString(", wake_page = true")
+	}
+	if route.Compression != nil {
+		b.WriteString(", compression = ")
+		b.WriteString(strconv.FormatBool(*route.Compression))
+	}
+	if route.Cache != nil {
+		b.WriteString(", cache = ")
+		b.WriteString(strconv.FormatBool(*route.Cache))
+	}
+	if !route.Upstream.IsZero() {
+		b.WriteString(", upstream = { ")
+		b.WriteString(strings.Join(routeUpstreamFields(route.Upstream), ", "))
+		b.WriteString(" }")
+	}
+	if route.ClientAuth != "" {
+		b.WriteString(", client_auth = ")
+		b.WriteString(strconv.Quote(string(route.ClientAuth)))
+		if route.ClientCA != "" {
+			b.WriteString(", client_ca = ")
+			b.WriteString(strconv.Quote(route.ClientCA))
+		}
+	}
+	if !route.Container.IsZero() {
+		for _, field := range routeContainerFields(route.Container) {
+			b.WriteString(", ")
+			b.WriteString(field)
+		}
+	}
+	if !route.Hooks.IsZero() {
+		if len(route.Hooks.Release) > 0 {
+			b.WriteString(", release_command = ")
+			b.WriteString(quoteRouteList(route.Hooks.Release))
+		}
+		if len(route.Hooks.PostDeploy) > 0 {
+			b.WriteString(", post_deploy_command = ")
+			b.WriteString(quoteRouteList(route.Hooks.PostDeploy))
+		}
+		if route.Hooks.Timeout > 0 {
+			b.WriteString(", release_timeout = ")
+			b.WriteString(strconv.Quote(formatRouteDuration(route.Hooks.Timeout)))
+		}
+	}
+	if len(route.Processes) > 0 {
+		fields := make([]string, 0, len(route.Processes))
+		for _, name := range slices.Sorted(maps.Keys(route.Processes)) {
+			fields = append(fields, name+" = "+quoteRouteList(route.Processes[name]))
+		}
+		b.WriteString(", processes = { ")
+		b.WriteString(strings.Join(fields, ", "))
+		b.WriteString(" }")
+	}
+}
+
+// quoteRouteList renders values as a TOML array of strings.
+func quoteRouteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// routeContainerFields renders the set container fields of a route.
+func routeContainerFields(container *domain.ContainerOverrides) []string {
+	var fields []string
+	if container.MemoryLimit > 0 {
+		fields = append(fields, "memory = "+strconv.Quote(formatRouteMemory(container.MemoryLimit)))
+	}
+	if container.NanoCPUs > 0 {
+		fields = append(fields, "cpus = "+strconv.FormatFloat(float64(container.NanoCPUs)/1e9, 'f', -1, 64))
+	}
+	if container.PidsLimit > 0 {
+		fields = append(fields, "pids_limit = "+strconv.FormatInt(container.PidsLimit, 10))
+	}
+	if len(container.Command) > 0 {
+		fields = append(fields, "command = "+quoteRouteList(container.Command))
+	}
+	if container.User != "" {
+		fields = append(fields, "user = "+strconv.Quote(container.User))
+	}
+	if container.WorkingDir != "" {
+		fields = append(fields, "working_dir = "+strconv.Quote(container.WorkingDir))
+	}
+	if len(container.CapAdd) > 0 {
+		fields = append(fields, "cap_add = "+quoteRouteList(container.CapAdd))
+	}
+	if len(container.Tmpfs) > 0 {
+		specs := make([]string, 0, len(container.Tmpfs))
+		for _, mountPath := range slices.Sorted(maps.Keys(container.Tmpfs)) {
+			spec := mountPath
+			if options := container.Tmpfs[mountPath]; options != "" {
+				spec += ":" + options
+			}
+			specs = append(specs, spec)
+		}
+		fields = append(fields, "tmpfs = "+quoteRouteList(specs))
+	}
+	return fields
+}
+
+// formatRouteMemory renders a byte count in the largest unit that divides it
+// exactly (2GB instead of 2147483648B) so it parses back to the same value.
+func formatRouteMemory(bytes int64) string {
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}} {
+		if bytes%unit.size == 0 {
+			return strconv.FormatInt(bytes/unit.size, 10) + unit.suffix
+		}
+	}
+	return strconv.FormatInt(bytes, 10) + "B"
+}
+
+// routeUpstreamFields renders the set fields of a route upstream table.
+func routeUpstreamFields(upstream *domain.UpstreamOverrides) []string {
+	var fields []string
+	addDuration := func(key string, d *time.Duration) {
+		if d != nil {
+			fields = append(fields, key+" = "+strconv.Quote(formatRouteDuration(*d)))
+		}
+	}
+	addCount := func(key string, n *int) {
+		if n != nil {
+			fields = append(fields, key+" = "+strconv.Itoa(*n))
+		}
+	}
+	addDuration("dial_timeout", upstream.DialTimeout)
+	addDuration("header_timeout", upstream.HeaderTimeout)
+	addDuration("idle_timeout", upstream.IdleTimeout)
+	addCount("retries", upstream.Retries)
+	addCount("breaker_threshold", upstream.BreakerThreshold)
+	addDuration("breaker_cooldown", upstream.BreakerCooldown)
+	return fields
+}
+
+// formatRouteDuration renders a duration without trailing zero units
+// (15m instead of 15m0s) to match how durations are written by hand.
+func formatRouteDuration(d time.Duration) string {
+	text := d.String()
+	if strings.HasSuffix(text, "m0s") {
+		text = strings.TrimSuffix(text, "0s")
+	}
+	if strings.HasSuffix(text, "h0m") {
+		text = strings.TrimSuffix(text, "0m")
+	}
+	return text
+}
+
 func backupConfigFile(configFile string) error {
 	src, err := os.ReadFile(configFile)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/bnema/zerowrap"
 	"github.com/spf13/viper"
@@ -149,6 +150,297 @@ func TestService_Load_CanonicalInlineRouteDefaultsHTTPS(t *testing.T) {
 	assert.True(t, route.HTTPS)
 }
 
+func TestService_Load_RouteIdleTimeout(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "gordon.toml")
+	err := os.WriteFile(configFile, []byte(`[routes]
+"sleepy.example.com" = { image = "myapp:latest", idle_timeout = "15m", wake_page = true }
+`), 0600)
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	require.NoError(t, v.ReadInConfig())
+
+	eventBus := mocks.NewMockEventPublisher(t)
+	svc := NewService(v, eventBus)
+	ctx := testContext()
+
+	err = svc.Load(ctx)
+	require.NoError(t, err)
+
+	route, err := svc.GetRoute(ctx, "sleepy.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, route.IdleTimeout)
+	assert.True(t, route.WakePage)
+	assert.True(t, route.HTTPS)
+
+	err = svc.AddRoute(ctx, domain.Route{Domain: "other.example.com", Image: "other:v1", HTTPS: true})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"sleepy.example.com" = { image = "myapp:latest", https = true, idle_timeout = "15m", wake_page = true }`)
+	assert.Contains(t, string(content), `"other.example.com" = { image = "other:v1", https = true }`)
+}
+
+func TestParseRouteTable_Compression(t *testing.T) {
+	route, err := parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "compression": false})
+	require.NoError(t, err)
+	require.NotNil(t, route.Compression)
+	assert.False(t, *route.Compression)
+
+	route, err = parseRouteTable("app.example.com", map[string]any{"image": "app:v1"})
+	require.NoError(t, err)
+	assert.Nil(t, route.Compression)
+
+	_, err = parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "compression": "gzip"})
+	assert.ErrorContains(t, err, "invalid compression field")
+
+	var b strings.Builder
+	writeRouteOptions(&b, route)
+	assert.Empty(t, b.String())
+	disabled := false
+	writeRouteOptions(&b, routeConfig{Compression: &disabled})
+	assert.Equal(t, ", compression = false", b.String())
+}
+
+func TestParseRouteTable_Cache(t *testing.T) {
+	route, err := parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "cache": true})
+	require.NoError(t, err)
+	require.NotNil(t, route.Cache)
+	assert.True(t, *route.Cache)
+
+	_, err = parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "cache": "yes"})
+	assert.ErrorContains(t, err, "invalid cache field")
+
+	var b strings.Builder
+	writeRouteOptions(&b, route)
+	assert.Equal(t, ", cache = true", b.String())
+}
+
+func TestService_Load_RouteUpstream(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "gordon.toml")
+	err := os.WriteFile(configFile, []byte(`[routes]
+"reports.example.com" = { image = "reports:latest", upstream = { header_timeout = "10m", retries = 2, breaker_threshold = 5 } }
+`), 0600)
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	require.NoError(t, v.ReadInConfig())
+
+	svc := NewService(v, mocks.NewMockEventPublisher(t))
+	ctx := testContext()
+	require.NoError(t, svc.Load(ctx))
+
+	route, err := svc.GetRoute(ctx, "reports.example.com")
+	require.NoError(t, err)
+	require.NotNil(t, route.Upstream)
+	require.NotNil(t, route.Upstream.HeaderTimeout)
+	assert.Equal(t, 10*time.Minute, *route.Upstream.HeaderTimeout)
+	require.NotNil(t, route.Upstream.Retries)
+	assert.Equal(t, 2, *route.Upstream.Retries)
+	require.NotNil(t, route.Upstream.BreakerThreshold)
+	assert.Equal(t, 5, *route.Upstream.BreakerThreshold)
+	assert.Nil(t, route.Upstream.DialTimeout)
+
+	err = svc.AddRoute(ctx, domain.Route{Domain: "other.example.com", Image: "other:v1", HTTPS: true})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"reports.example.com" = { image = "reports:latest", https = true, upstream = { header_timeout = "10m", retries = 2, breaker_threshold = 5 } }`)
+}
+
+func TestParseRouteTable_RejectsInvalidUpstream(t *testing.T) {
+	tests := map[string]map[string]any{
+		"invalid upstream field":                   {"upstream": "fast"},
+		"invalid upstream.header_timeout \"soon\"": {"upstream": map[string]any{"header_timeout": "soon"}},
+		"invalid upstream.dial_timeout \"0s\"":     {"upstream": map[string]any{"dial_timeout": "0s"}},
+		"invalid upstream.retries field":           {"upstream": map[string]any{"retries": int64(-1)}},
+		"invalid upstream.breaker_threshold field": {"upstream": map[string]any{"breaker_threshold": "5"}},
+		"unknown upstream.timeout field":           {"upstream": map[string]any{"timeout": "5s"}},
+	}
+	for want, raw := range tests {
+		raw["image"] = "app:v1"
+		_, err := parseRouteTable("app.example.com", raw)
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+func TestService_Load_RouteContainer(t *testing.T) {
+	tmpDir := t.TempDir()
+	configFile := filepath.Join(tmpDir, "gordon.toml")
+	err := os.WriteFile(configFile, []byte(`[routes]
+"worker.example.com" = { image = "worker:latest", memory = "4GB", cpus = 1.5, pids_limit = 512, command = ["worker", "--queue", "images"], user = "1000:1000", working_dir = "/srv", cap_add = ["cap_sys_nice"], tmpfs = ["/tmp:size=64m", "/run"] }
+`), 0600)
+	require.NoError(t, err)
+
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	require.NoError(t, v.ReadInConfig())
+
+	svc := NewService(v, mocks.NewMockEventPublisher(t))
+	ctx := testContext()
+	require.NoError(t, svc.Load(ctx))
+
+	route, err := svc.GetRoute(ctx, "worker.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ContainerOverrides{
+		MemoryLimit: 4 << 30,
+		NanoCPUs:    1_500_000_000,
+		PidsLimit:   512,
+		Command:     []string{"worker", "--queue", "images"},
+		User:        "1000:1000",
+		WorkingDir:  "/srv",
+		CapAdd:      []string{"SYS_NICE"},
+		Tmpfs:       map[string]string{"/tmp": "size=64m", "/run": ""},
+	}, route.Container)
+
+	err = svc.AddRoute(ctx, domain.Route{Domain: "other.example.com", Image: "other:v1", HTTPS: true})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"worker.example.com" = { image = "worker:latest", https = true, memory = "4GB", cpus = 1.5, pids_limit = 512, command = ["worker", "--queue", "images"], user = "1000:1000", working_dir = "/srv", cap_add = ["SYS_NICE"], tmpfs = ["/run", "/tmp:size=64m"] }`)
+	assert.Contains(t, string(content), `"other.example.com" = { image = "other:v1", https = true }`)
+}
+
+func TestParseRouteTable_RejectsInvalidContainer(t *testing.T) {
+	tests := map[string]map[string]any{
+		"invalid memory field":                  {"memory": int64(512)},
+		"invalid memory \"lots\"":               {"memory": "lots"},
+		"invalid cpus field":                    {"cpus": "2"},
+		"invalid cpus 0":                        {"cpus": int64(0)},
+		"invalid pids_limit field":              {"pids_limit": int64(-1)},
+		"invalid command field":                 {"command": "worker --queue images"},
+		"invalid user field":                    {"user": ""},
+		"working_dir \"srv\": must be absolute": {"working_dir": "srv"},
+		"capability \"all\"":                    {"cap_add": []any{"all"}},
+		"invalid tmpfs field":                   {"tmpfs": []any{int64(1)}},
+		"tmpfs \"tmp\"":                         {"tmpfs": []any{"tmp"}},
+	}
+	for want, raw := range tests {
+		raw["image"] = "app:v1"
+		_, err := parseRouteTable("app.example.com", raw)
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+func TestParseRouteTable_Hooks(t *testing.T) {
+	route, err := parseRouteTable("app.example.com", map[string]any{
+		"image":               "app:v1",
+		"release_command":     []any{"./manage", "migrate"},
+		"post_deploy_command": []any{"./manage", "warm-cache"},
+		"release_timeout":     "5m",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DeployHooks{Release: []string{"./manage", "migrate"}, PostDeploy: []string{"./manage", "warm-cache"}, Timeout: 5 * time.Minute}, route.toDomainRoute("app.example.com").Hooks)
+	assert.Equal(t, route, routeConfigFromDomain(route.toDomainRoute("app.example.com")))
+
+	var b strings.Builder
+	writeRouteOptions(&b, route)
+	assert.Equal(t, `, release_command = ["./manage", "migrate"], post_deploy_command = ["./manage", "warm-cache"], release_timeout = "5m"`, b.String())
+
+	tests := map[string]map[string]any{
+		"invalid release_command field":     {"release_command": "./manage migrate"},
+		"invalid post_deploy_command field": {"post_deploy_command": []any{}},
+		"invalid release_timeout \"soon\"":  {"release_command": []any{"migrate"}, "release_timeout": "soon"},
+		"release_timeout without":           {"release_timeout": "5m"},
+	}
+	for want, raw := range tests {
+		raw["image"] = "app:v1"
+		_, err := parseRouteTable("app.example.com", raw)
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+func TestParseRouteTable_Processes(t *testing.T) {
+	route, err := parseRouteTable("app.example.com", map[string]any{
+		"image": "app:v1",
+		"processes": map[string]any{
+			"worker": []any{"bundle", "exec", "sidekiq"},
+			"clock":  []any{"bundle", "exec", "clockwork", "clock.rb"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"worker": {"bundle", "exec", "sidekiq"},
+		"clock":  {"bundle", "exec", "clockwork", "clock.rb"},
+	}, route.toDomainRoute("app.example.com").Processes)
+	assert.Equal(t, route, routeConfigFromDomain(route.toDomainRoute("app.example.com")))
+
+	var b strings.Builder
+	writeRouteOptions(&b, route)
+	assert.Equal(t, `, processes = { clock = ["bundle", "exec", "clockwork", "clock.rb"], worker = ["bundle", "exec", "sidekiq"] }`, b.String())
+
+	tests := map[string]map[string]any{
+		"invalid processes field":        {"processes": []any{"worker"}},
+		"invalid processes.worker field": {"processes": map[string]any{"worker": "sidekiq"}},
+		"needs a command":                {"processes": map[string]any{"worker": []any{}}},
+		`name "next" is reserved`:        {"processes": map[string]any{"next": []any{"run"}}},
+	}
+	for want, raw := range tests {
+		raw["image"] = "app:v1"
+		_, err := parseRouteTable("app.example.com", raw)
+		assert.ErrorContains(t, err, want)
+	}
+}
+
+func TestFormatRouteMemory(t *testing.T) {
+	assert.Equal(t, "2GB", formatRouteMemory(2<<30))
+	assert.Equal(t, "1536MB", formatRouteMemory(1536<<20))
+	assert.Equal(t, "1000B", formatRouteMemory(1000))
+}
+
+func TestParseRouteTable_ClientAuth(t *testing.T) {
+	route, err := parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_auth": "require"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ClientAuthPolicy{Mode: domain.ClientAuthRequire}, route.toDomainRoute("admin.example.com").ClientAuth)
+
+	route, err = parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_auth": "optional", "client_ca": "/etc/gordon/team-ca.pem"})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ClientAuthPolicy{Mode: domain.ClientAuthOptional, CAFile: "/etc/gordon/team-ca.pem"}, route.toDomainRoute("admin.example.com").ClientAuth)
+	assert.Equal(t, route, routeConfigFromDomain(route.toDomainRoute("admin.example.com")))
+
+	var b strings.Builder
+	writeRouteOptions(&b, route)
+	assert.Equal(t, `, client_auth = "optional", client_ca = "/etc/gordon/team-ca.pem"`, b.String())
+
+	route, err = parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_auth": "off"})
+	require.NoError(t, err)
+	assert.Nil(t, route.toDomainRoute("admin.example.com").ClientAuth)
+
+	_, err = parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_auth": "always"})
+	assert.ErrorIs(t, err, domain.ErrClientAuthInvalid)
+
+	_, err = parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_auth": true})
+	assert.ErrorContains(t, err, "invalid client_auth field")
+
+	_, err = parseRouteTable("admin.example.com", map[string]any{"image": "admin:v1", "client_ca": "/etc/gordon/team-ca.pem"})
+	assert.ErrorContains(t, err, "client_ca without client_auth")
+}
+
+func TestParseRouteTable_RejectsInvalidIdleTimeout(t *testing.T) {
+	_, err := parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "idle_timeout": "soon"})
+	assert.ErrorContains(t, err, "invalid idle_timeout")
+
+	_, err = parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "idle_timeout": int64(60)})
+	assert.ErrorContains(t, err, "invalid idle_timeout field")
+
+	_, err = parseRouteTable("app.example.com", map[string]any{"image": "app:v1", "wake_page": "yes"})
+	assert.ErrorContains(t, err, "invalid wake_page field")
+}
+
+func TestFormatRouteDuration(t *testing.T) {
+	assert.Equal(t, "15m", formatRouteDuration(15*time.Minute))
+	assert.Equal(t, "1h", formatRouteDuration(time.Hour))
+	assert.Equal(t, "1h30m", formatRouteDuration(90*time.Minute))
+	assert.Equal(t, "45s", formatRouteDuration(45*time.Second))
+}
+
 func TestService_Reload_InvalidRouteKeyPreservesPreviousState(t *testing.T) {
 	tmpDir := t.TempDir()
 	configFile := filepath.Join(tmpDir, "gordon.toml")
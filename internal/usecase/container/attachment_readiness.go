@@ -0,0 +1,183 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	defaultAttachmentReadinessTimeout = 30 * time.Second
+	attachmentCommandProbeInterval    = time.Second
+	attachmentLogProbeInterval        = 500 * time.Millisecond
+	attachmentProbeAttemptTimeout     = 5 * time.Second
+	maxAttachmentReadinessLog         = 1 << 20 // 1 MiB
+	maxAttachmentProbeOutput          = 256
+)
+
+// attachmentReadiness returns the readiness declared for the attachment
+// service called serviceName and the time allowed for it to pass.
+func (s *Service) attachmentReadiness(serviceName string) (domain.AttachmentReadiness, time.Duration) {
+	s.mu.RLock()
+	readiness := s.config.AttachmentReadiness[serviceName]
+	timeout := s.config.AttachmentReadinessTimeout
+	s.mu.RUnlock()
+
+	if readiness.Timeout > 0 {
+		timeout = readiness.Timeout
+	}
+	if timeout <= 0 {
+		timeout = defaultAttachmentReadinessTimeout
+	}
+	return readiness, timeout
+}
+
+// waitForAttachment waits for a freshly started attachment using its
+// declared readiness, or the built-in cascade when it declares none.
+func (s *Service) waitForAttachment(ctx context.Context, containerID, serviceName string, containerConfig *domain.ContainerConfig) error {
+	readiness, timeout := s.attachmentReadiness(serviceName)
+	if !readiness.Declared() {
+		return s.waitForAttachmentReady(ctx, containerID, containerConfig)
+	}
+	if err := s.pollContainerRunning(ctx, containerID); err != nil {
+		return err
+	}
+	return s.waitForDeclaredAttachmentReadiness(ctx, containerID, readiness, timeout)
+}
+
+// waitForReusedAttachment checks the declared readiness of an attachment
+// that was already running. Attachments without one are trusted as is.
+func (s *Service) waitForReusedAttachment(ctx context.Context, containerID, serviceName string) error {
+	readiness, timeout := s.attachmentReadiness(serviceName)
+	if !readiness.Declared() {
+		return nil
+	}
+	if err := s.waitForDeclaredAttachmentReadiness(ctx, containerID, readiness, timeout); err != nil {
+		return fmt.Errorf("attachment %q not ready: %w", serviceName, err)
+	}
+	return nil
+}
+
+func (s *Service) waitForDeclaredAttachmentReadiness(ctx context.Context, containerID string, readiness domain.AttachmentReadiness, timeout time.Duration) error {
+	log := zerowrap.FromCtx(ctx)
+
+	switch readiness.Type {
+	case domain.AttachmentReadinessTCP:
+		addr, err := s.attachmentTCPAddress(ctx, containerID, readiness.Port)
+		if err != nil {
+			return err
+		}
+		log.Info().Str("addr", addr).Dur("timeout", timeout).Msg("attachment readiness: using declared TCP probe")
+		return tcpProbe(ctx, addr, timeout)
+	case domain.AttachmentReadinessCommand:
+		log.Info().Strs("command", readiness.Command).Dur("timeout", timeout).Msg("attachment readiness: using declared command")
+		return s.commandProbe(ctx, containerID, readiness.Command, timeout)
+	case domain.AttachmentReadinessLog:
+		log.Info().Str("contains", readiness.Contains).Dur("timeout", timeout).Msg("attachment readiness: using declared log line")
+		return s.logProbe(ctx, containerID, readiness.Contains, timeout)
+	default:
+		return fmt.Errorf("unsupported attachment readiness type %q", readiness.Type)
+	}
+}
+
+// attachmentTCPAddress resolves where to dial port of an attachment,
+// preferring a host port binding over the container's network address.
+// Port 0 selects the first port the container exposes.
+func (s *Service) attachmentTCPAddress(ctx context.Context, containerID string, port int) (string, error) {
+	if port == 0 {
+		ports, err := s.runtime.GetContainerExposedPorts(ctx, containerID)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve attachment ports: %w", err)
+		}
+		if len(ports) == 0 {
+			return "", errors.New("tcp readiness requires a port: the attachment exposes none")
+		}
+		port = ports[0]
+	}
+	if hostPort, err := s.runtime.GetContainerPort(ctx, containerID, port); err == nil && hostPort > 0 {
+		return net.JoinHostPort("127.0.0.1", strconv.Itoa(hostPort)), nil
+	}
+	ip, _, err := s.runtime.GetContainerNetworkInfo(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve attachment address: %w", err)
+	}
+	if ip == "" {
+		return "", errors.New("failed to resolve attachment address: container has no IP")
+	}
+	return net.JoinHostPort(ip, strconv.Itoa(port)), nil
+}
+
+// commandProbe runs cmd in the container every second until it exits with
+// code 0, like a pg_isready or redis-cli ping check.
+func (s *Service) commandProbe(ctx context.Context, containerID string, cmd []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	attempts := 0
+	var lastResult string
+	err := probeLoop(ctx, deadline, attachmentCommandProbeInterval, attachmentProbeAttemptTimeout, func(attemptCtx context.Context) (bool, error) {
+		attempts++
+		result, err := s.runtime.ExecInContainer(attemptCtx, containerID, cmd)
+		if err != nil {
+			lastResult = err.Error()
+			return false, nil
+		}
+		if result.ExitCode == 0 {
+			return true, nil
+		}
+		output := result.Stderr
+		if len(bytes.TrimSpace(output)) == 0 {
+			output = result.Stdout
+		}
+		lastResult = fmt.Sprintf("exit code %d: %s", result.ExitCode, probeOutput(output))
+		return false, nil
+	})
+	if errors.Is(err, errProbeTimeout) {
+		return fmt.Errorf("command probe timeout after %s: %q did not succeed (attempts=%d, last_result=%s)", timeout, strings.Join(cmd, " "), attempts, lastResult)
+	}
+	return err
+}
+
+// logProbe polls the container output until it contains the given text.
+func (s *Service) logProbe(ctx context.Context, containerID, contains string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	needle := []byte(contains)
+	var lastErr error
+	err := probeLoop(ctx, deadline, attachmentLogProbeInterval, attachmentProbeAttemptTimeout, func(attemptCtx context.Context) (bool, error) {
+		logs, err := s.runtime.GetContainerLogs(attemptCtx, containerID, false)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		defer logs.Close()
+		data, err := io.ReadAll(io.LimitReader(logs, maxAttachmentReadinessLog))
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		return bytes.Contains(data, needle), nil
+	})
+	if errors.Is(err, errProbeTimeout) {
+		if lastErr != nil {
+			return fmt.Errorf("log probe timeout after %s: output never contained %q (last_error=%v)", timeout, contains, lastErr)
+		}
+		return fmt.Errorf("log probe timeout after %s: output never contained %q", timeout, contains)
+	}
+	return err
+}
+
+func probeOutput(output []byte) string {
+	text := strings.TrimSpace(string(output))
+	if len(text) > maxAttachmentProbeOutput {
+		text = text[:maxAttachmentProbeOutput] + "..."
+	}
+	return text
+}
@@ -0,0 +1,186 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func newAttachmentReadinessService(runtime *mocks.MockContainerRuntime, readiness domain.AttachmentReadiness) *Service {
+	return NewService(runtime, nil, nil, nil, Config{
+		ReadinessDelay:             time.Millisecond,
+		AttachmentReadinessTimeout: 5 * time.Second,
+		AttachmentReadiness:        map[string]domain.AttachmentReadiness{"postgres": readiness},
+	}, nil)
+}
+
+func TestService_WaitForAttachment_DeclaredCommandRetriesUntilSuccess(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	cmd := []string{"pg_isready", "-U", "postgres"}
+	svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{Type: domain.AttachmentReadinessCommand, Command: cmd})
+
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "pg-1").Return(true, nil).Once()
+	runtime.EXPECT().ExecInContainer(mock.Anything, "pg-1", cmd).
+		Return(&out.ExecResult{ExitCode: 2, Stdout: []byte("no response")}, nil).Once()
+	runtime.EXPECT().ExecInContainer(mock.Anything, "pg-1", cmd).
+		Return(&out.ExecResult{ExitCode: 0}, nil).Once()
+
+	err := svc.waitForAttachment(testContext(), "pg-1", "postgres", &domain.ContainerConfig{Ports: []int{5432}})
+	require.NoError(t, err)
+}
+
+func TestService_WaitForAttachment_DeclaredCommandTimeout(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	cmd := []string{"pg_isready"}
+	svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{
+		Type:    domain.AttachmentReadinessCommand,
+		Command: cmd,
+		Timeout: 200 * time.Millisecond,
+	})
+
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "pg-1").Return(true, nil).Once()
+	runtime.EXPECT().ExecInContainer(mock.Anything, "pg-1", cmd).
+		Return(&out.ExecResult{ExitCode: 2, Stderr: []byte("no response\n")}, nil)
+
+	err := svc.waitForAttachment(testContext(), "pg-1", "postgres", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "command probe timeout after 200ms")
+	assert.Contains(t, err.Error(), "last_result=exit code 2: no response")
+}
+
+func TestService_WaitForAttachment_DeclaredLogLine(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{
+		Type:     domain.AttachmentReadinessLog,
+		Contains: "ready to accept connections",
+	})
+
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "pg-1").Return(true, nil).Once()
+	runtime.EXPECT().GetContainerLogs(mock.Anything, "pg-1", false).
+		Return(dockerLogFrames(1, "initializing database"), nil).Once()
+	runtime.EXPECT().GetContainerLogs(mock.Anything, "pg-1", false).
+		Return(dockerLogFrames(1, "initializing database", "database system is ready to accept connections"), nil).Once()
+
+	err := svc.waitForAttachment(testContext(), "pg-1", "postgres", nil)
+	require.NoError(t, err)
+}
+
+func TestService_WaitForAttachment_DeclaredTCPUsesFirstExposedPort(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	port := ln.Addr().(*net.TCPAddr).Port
+
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{Type: domain.AttachmentReadinessTCP})
+
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "pg-1").Return(true, nil).Once()
+	runtime.EXPECT().GetContainerExposedPorts(mock.Anything, "pg-1").Return([]int{port, 9187}, nil).Once()
+	runtime.EXPECT().GetContainerPort(mock.Anything, "pg-1", port).Return(0, assert.AnError).Once()
+	runtime.EXPECT().GetContainerNetworkInfo(mock.Anything, "pg-1").Return("127.0.0.1", port, nil).Once()
+
+	err = svc.waitForAttachment(testContext(), "pg-1", "postgres", nil)
+	require.NoError(t, err)
+}
+
+func TestService_WaitForAttachment_UndeclaredUsesCascade(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{Type: domain.AttachmentReadinessCommand, Command: []string{"true"}})
+
+	// redis has no declared readiness: healthcheck, no ports, delay fallback.
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "redis-1").Return(true, nil).Twice()
+	runtime.EXPECT().GetContainerHealthStatus(mock.Anything, "redis-1").Return("", false, nil).Once()
+
+	err := svc.waitForAttachment(testContext(), "redis-1", "redis", &domain.ContainerConfig{})
+	require.NoError(t, err)
+}
+
+func TestService_WaitForReusedAttachment(t *testing.T) {
+	t.Run("undeclared readiness is trusted", func(t *testing.T) {
+		runtime := mocks.NewMockContainerRuntime(t)
+		svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{})
+
+		require.NoError(t, svc.waitForReusedAttachment(testContext(), "pg-1", "postgres"))
+	})
+
+	t.Run("declared readiness is checked again", func(t *testing.T) {
+		runtime := mocks.NewMockContainerRuntime(t)
+		svc := newAttachmentReadinessService(runtime, domain.AttachmentReadiness{
+			Type:     domain.AttachmentReadinessLog,
+			Contains: "ready to accept connections",
+			Timeout:  100 * time.Millisecond,
+		})
+		runtime.EXPECT().GetContainerLogs(mock.Anything, "pg-1", false).
+			RunAndReturn(func(_ context.Context, _ string, _ bool) (io.ReadCloser, error) {
+				return dockerLogFrames(1, "starting"), nil
+			})
+
+		err := svc.waitForReusedAttachment(testContext(), "pg-1", "postgres")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `attachment "postgres" not ready`)
+		assert.Contains(t, err.Error(), "log probe timeout")
+	})
+}
+
+func TestService_DeployAttachments_KeepsReusedAttachmentsOnFailure(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	envLoader := mocks.NewMockEnvLoader(t)
+	svc := NewService(runtime, envLoader, nil, nil, Config{
+		ReadinessDelay: time.Millisecond,
+		Attachments:    map[string][]string{"app.example.com": {"redis:7", "postgres:16"}},
+		AttachmentReadiness: map[string]domain.AttachmentReadiness{"postgres": {
+			Type:    domain.AttachmentReadinessCommand,
+			Command: []string{"pg_isready"},
+			Timeout: 100 * time.Millisecond,
+		}},
+	}, nil)
+	svc.attachments["app.example.com"] = []string{"redis-1", "pg-1"}
+
+	running := func(id, service, image string) *domain.Container {
+		return &domain.Container{
+			ID:     id,
+			Name:   fmt.Sprintf("gordon-%s-%s", sanitizeName("app.example.com"), service),
+			Status: string(domain.ContainerStatusRunning),
+			Labels: map[string]string{
+				domain.LabelManaged:    "true",
+				domain.LabelAttachment: "true",
+				domain.LabelAttachedTo: "app.example.com",
+				domain.LabelImage:      image,
+				domain.LabelEnvHash:    hashEnvironment([]string{}),
+			},
+		}
+	}
+	runtime.EXPECT().ListContainers(mock.Anything, true).
+		Return([]*domain.Container{running("redis-1", "redis", "redis:7"), running("pg-1", "postgres", "postgres:16")}, nil)
+	envLoader.EXPECT().LoadEnv(mock.Anything, mock.Anything).Return([]string{}, nil)
+	runtime.EXPECT().InspectImageEnv(mock.Anything, mock.Anything).Return([]string{}, nil)
+	runtime.EXPECT().ExecInContainer(mock.Anything, "pg-1", []string{"pg_isready"}).
+		Return(&out.ExecResult{ExitCode: 2}, nil)
+
+	err := svc.deployAttachments(testContext(), "app.example.com", "gordon-net")
+	require.ErrorContains(t, err, `failed to deploy attachment "postgres:16"`)
+
+	runtime.AssertNotCalled(t, "StopContainer", mock.Anything, mock.Anything)
+	runtime.AssertNotCalled(t, "RemoveContainer", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, []string{"redis-1", "pg-1"}, svc.attachments["app.example.com"])
+}
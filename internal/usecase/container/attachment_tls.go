@@ -0,0 +1,184 @@
+package container
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/internal/usecase/pki"
+)
+
+// SetCertificateAuthority enables attachments with [attachment_tls] settings.
+// Their certificates are issued by ca.
+func (s *Service) SetCertificateAuthority(ca out.CertificateAuthority) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ca = ca
+}
+
+// StartAttachmentCertificateRenewal calls RenewAttachmentCertificates on
+// every interval until ctx is cancelled.
+func (s *Service) StartAttachmentCertificateRenewal(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RenewAttachmentCertificates(ctx); err != nil {
+					log := zerowrap.FromCtx(ctx)
+					log.Warn().Err(err).Msg("attachment certificate renewal failed")
+				}
+			}
+		}
+	}()
+}
+
+// RenewAttachmentCertificates rotates the certificate of every running
+// attachment with TLS settings once a third of its lifetime is left, then
+// restarts or signals the container so it picks up the new files.
+func (s *Service) RenewAttachmentCertificates(ctx context.Context) error {
+	s.mu.RLock()
+	configured := len(s.config.AttachmentTLS) > 0
+	s.mu.RUnlock()
+	if !configured {
+		return nil
+	}
+
+	containers, err := s.runtime.ListContainers(ctx, false)
+	if err != nil {
+		return fmt.Errorf("list attachment containers: %w", err)
+	}
+	var errs []error
+	for _, c := range containers {
+		if c == nil || c.Labels[domain.LabelManaged] != "true" || c.Labels[domain.LabelAttachment] != "true" {
+			continue
+		}
+		if c.Status != string(domain.ContainerStatusRunning) {
+			continue
+		}
+		serviceName := extractServiceName(c.Labels[domain.LabelImage])
+		tlsCfg, ok := s.attachmentTLS(serviceName)
+		if !ok {
+			continue
+		}
+		if err := s.ensureAttachmentCertificate(ctx, c.ID, serviceName, tlsCfg, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// attachmentTLS returns the certificate settings of the attachment service
+// called serviceName, with defaults applied.
+func (s *Service) attachmentTLS(serviceName string) (domain.StandaloneServiceTLS, bool) {
+	s.mu.RLock()
+	tlsCfg, ok := s.config.AttachmentTLS[serviceName]
+	s.mu.RUnlock()
+	if !ok {
+		return domain.StandaloneServiceTLS{}, false
+	}
+	return tlsCfg.WithDefaults(), true
+}
+
+// attachmentTLSHash identifies the certificate settings an attachment was
+// created with, so changing them recreates it. It is empty without TLS.
+func (s *Service) attachmentTLSHash(serviceName string) string {
+	tlsCfg, ok := s.attachmentTLS(serviceName)
+	if !ok {
+		return ""
+	}
+	data, _ := json.Marshal(tlsCfg)
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// attachmentTLSVolume returns the managed volume mounted at the TLS
+// directory of an attachment. The files are written with CopyToContainer,
+// so the directory is a writable volume that survives restarts.
+func (s *Service) attachmentTLSVolume(ctx context.Context, containerName, tlsPath string) (string, error) {
+	s.mu.RLock()
+	prefix := s.config.VolumePrefix
+	s.mu.RUnlock()
+
+	name := generateVolumeName(prefix, containerName, tlsPath)
+	exists, err := s.runtime.VolumeExists(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("failed to check attachment tls volume %s: %w", name, err)
+	}
+	if !exists {
+		if err := s.runtime.CreateVolume(ctx, name); err != nil {
+			return "", fmt.Errorf("failed to create attachment tls volume %s: %w", name, err)
+		}
+	}
+	return name, nil
+}
+
+// ensureAttachmentCertificate installs a fresh certificate when the
+// attachment has none or its current one needs renewal. With reload set, the
+// container is restarted or signalled afterwards.
+func (s *Service) ensureAttachmentCertificate(ctx context.Context, containerID, serviceName string, tlsCfg domain.StandaloneServiceTLS, reload bool) error {
+	s.mu.RLock()
+	ca := s.ca
+	s.mu.RUnlock()
+
+	if !pki.ContainerCertificateNeedsRenewal(ctx, s.runtime, ca, containerID, tlsCfg) {
+		return nil
+	}
+	if err := s.installAttachmentCertificate(ctx, containerID, serviceName, tlsCfg); err != nil {
+		return err
+	}
+	log := zerowrap.FromCtx(ctx)
+	log.Info().Str("attachment", serviceName).Str(zerowrap.FieldEntityID, containerID).Msg("rotated attachment certificate")
+	if !reload {
+		return nil
+	}
+	if tlsCfg.Reload == domain.StandaloneServiceTLSReloadRestart {
+		if err := s.runtime.RestartContainer(ctx, containerID); err != nil {
+			return fmt.Errorf("restart attachment %q after certificate rotation: %w", serviceName, err)
+		}
+		return nil
+	}
+	if err := s.runtime.SignalContainer(ctx, containerID, tlsCfg.Reload); err != nil {
+		return fmt.Errorf("signal attachment %q after certificate rotation: %w", serviceName, err)
+	}
+	return nil
+}
+
+// installAttachmentCertificate issues a certificate for an attachment and
+// writes it, its key and the root CA into the attachment's TLS directory.
+func (s *Service) installAttachmentCertificate(ctx context.Context, containerID, serviceName string, tlsCfg domain.StandaloneServiceTLS) error {
+	s.mu.RLock()
+	ca := s.ca
+	s.mu.RUnlock()
+	if ca == nil {
+		return fmt.Errorf("attachment %q tls requires the internal CA; configure a smart_tcp or tls_mux entrypoint", serviceName)
+	}
+
+	cert, err := ca.IssueServiceCertificate(serviceName, attachmentCertificateSANs(serviceName, tlsCfg), tlsCfg.TTL)
+	if err != nil {
+		return fmt.Errorf("issue attachment %q certificate: %w", serviceName, err)
+	}
+	if err := pki.WriteContainerCertificate(ctx, s.runtime, containerID, cert, tlsCfg); err != nil {
+		return fmt.Errorf("write attachment %q certificate: %w", serviceName, err)
+	}
+	return nil
+}
+
+// attachmentCertificateSANs returns the configured SANs plus the names the
+// attachment is reachable under by default: its service name, which is its
+// alias on the app network, and the loopback address. Container names are
+// left out; the doubled underscores of sanitized domains are not valid DNS
+// names.
+func attachmentCertificateSANs(serviceName string, tlsCfg domain.StandaloneServiceTLS) []string {
+	sans := []string{serviceName, "localhost", "127.0.0.1"}
+	return append(sans, tlsCfg.SANs...)
+}
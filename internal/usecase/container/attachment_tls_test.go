@@ -0,0 +1,146 @@
+package container
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bnema/zerowrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkiadapter "github.com/bnema/gordon/internal/adapters/out/pki"
+	"github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func newAttachmentTLSService(t *testing.T, runtime *mocks.MockContainerRuntime, tlsCfg domain.StandaloneServiceTLS) *Service {
+	t.Helper()
+	svc := NewService(runtime, nil, nil, nil, Config{
+		VolumePrefix:  "gordon",
+		AttachmentTLS: map[string]domain.StandaloneServiceTLS{"postgres": tlsCfg},
+	}, nil)
+	ca, err := pkiadapter.NewCA(t.TempDir(), zerowrap.Default())
+	require.NoError(t, err)
+	svc.SetCertificateAuthority(ca)
+	return svc
+}
+
+func TestService_InstallAttachmentCertificate_WritesBundle(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentTLSService(t, runtime, domain.StandaloneServiceTLS{SANs: []string{"db.internal"}, UID: 999, GID: 999})
+	tlsCfg, ok := svc.attachmentTLS("postgres")
+	require.True(t, ok)
+
+	var files []domain.ContainerFile
+	runtime.EXPECT().CopyToContainer(mock.Anything, "pg-1", domain.DefaultStandaloneServiceTLSPath, mock.Anything).
+		Run(func(_ context.Context, _ string, _ string, f []domain.ContainerFile) { files = f }).
+		Return(nil).Once()
+
+	err := svc.installAttachmentCertificate(testContext(), "pg-1", "postgres", tlsCfg)
+	require.NoError(t, err)
+
+	require.Len(t, files, 3)
+	assert.Equal(t, []string{"ca.crt", "tls.crt", "tls.key"}, []string{files[0].Name, files[1].Name, files[2].Name})
+	assert.Equal(t, int64(0o600), files[2].Mode)
+	assert.Equal(t, 999, files[2].UID)
+
+	block, _ := pem.Decode(files[1].Data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"postgres", "localhost", "db.internal"}, cert.DNSNames)
+}
+
+func TestService_InstallAttachmentCertificate_RequiresCertificateAuthority(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{
+		AttachmentTLS: map[string]domain.StandaloneServiceTLS{"postgres": {}},
+	}, nil)
+	tlsCfg, _ := svc.attachmentTLS("postgres")
+
+	err := svc.installAttachmentCertificate(testContext(), "pg-1", "postgres", tlsCfg)
+	require.ErrorContains(t, err, "requires the internal CA")
+}
+
+func TestService_RenewAttachmentCertificates_RotatesAndRestarts(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentTLSService(t, runtime, domain.StandaloneServiceTLS{})
+
+	runtime.EXPECT().ListContainers(mock.Anything, false).Return([]*domain.Container{
+		{ID: "pg-1", Name: "gordon-app-example-com-postgres", Status: string(domain.ContainerStatusRunning), Labels: map[string]string{
+			domain.LabelManaged: "true", domain.LabelAttachment: "true", domain.LabelImage: "postgres:18",
+		}},
+		{ID: "redis-1", Name: "gordon-app-example-com-redis", Status: string(domain.ContainerStatusRunning), Labels: map[string]string{
+			domain.LabelManaged: "true", domain.LabelAttachment: "true", domain.LabelImage: "redis:7",
+		}},
+	}, nil).Once()
+	runtime.EXPECT().CopyFromContainer(mock.Anything, "pg-1", domain.DefaultStandaloneServiceTLSPath+"/tls.crt").
+		Return(nil, errors.New("no such file")).Once()
+	copyCall := runtime.EXPECT().CopyToContainer(mock.Anything, "pg-1", domain.DefaultStandaloneServiceTLSPath, mock.Anything).Return(nil).Once()
+	runtime.EXPECT().RestartContainer(mock.Anything, "pg-1").Return(nil).Once().NotBefore(copyCall)
+
+	require.NoError(t, svc.RenewAttachmentCertificates(testContext()))
+}
+
+func TestService_AttachmentTLSHash(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newAttachmentTLSService(t, runtime, domain.StandaloneServiceTLS{SANs: []string{"db.internal"}})
+
+	hash := svc.attachmentTLSHash("postgres")
+	assert.NotEmpty(t, hash)
+	assert.Empty(t, svc.attachmentTLSHash("redis"))
+
+	svc.config.AttachmentTLS["postgres"] = domain.StandaloneServiceTLS{SANs: []string{"db.example.com"}}
+	assert.NotEqual(t, hash, svc.attachmentTLSHash("postgres"))
+}
+
+func TestDeployAttachedService_InstallsCertificateBeforeStart(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	envLoader := mocks.NewMockEnvLoader(t)
+
+	cfg := testMinDelayConfig()
+	cfg.AttachmentTLS = map[string]domain.StandaloneServiceTLS{"postgres": {UID: 999, GID: 999}}
+	svc := NewService(runtime, envLoader, nil, nil, cfg, nil)
+	ca, err := pkiadapter.NewCA(t.TempDir(), zerowrap.Default())
+	require.NoError(t, err)
+	svc.SetCertificateAuthority(ca)
+	ctx := testContext()
+
+	containerName := fmt.Sprintf("gordon-%s-postgres", domain.SanitizeDomainForContainer("app.example.com"))
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{}, nil).Times(2)
+	runtime.EXPECT().ListImages(mock.Anything).Return([]string{"postgres:16"}, nil)
+	runtime.EXPECT().GetImageExposedPorts(mock.Anything, "postgres:16").Return([]int{5432}, nil)
+	envLoader.EXPECT().LoadEnv(mock.Anything, containerName).Return([]string{}, nil)
+	runtime.EXPECT().InspectImageEnv(mock.Anything, "postgres:16").Return([]string{}, nil)
+
+	var tlsVolume string
+	runtime.EXPECT().VolumeExists(mock.Anything, mock.Anything).Return(false, nil).Once()
+	runtime.EXPECT().CreateVolume(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, name string) { tlsVolume = name }).
+		Return(nil).Once()
+
+	var created *domain.ContainerConfig
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.AnythingOfType("*domain.ContainerConfig")).
+		Run(func(_ context.Context, c *domain.ContainerConfig) { created = c }).
+		Return(&domain.Container{ID: "pg-1", Name: containerName}, nil)
+	copyCall := runtime.EXPECT().CopyToContainer(mock.Anything, "pg-1", domain.DefaultStandaloneServiceTLSPath, mock.Anything).Return(nil).Once()
+	runtime.EXPECT().StartContainer(mock.Anything, "pg-1").Return(nil).NotBefore(copyCall)
+
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "pg-1").Return(true, nil).Times(2)
+	runtime.EXPECT().GetContainerHealthStatus(mock.Anything, "pg-1").Return("", false, nil)
+	runtime.EXPECT().GetContainerNetworkInfo(mock.Anything, "pg-1").Return("", 0, errors.New("no network"))
+
+	createdID, err := svc.deployAttachedService(ctx, "app.example.com", "postgres:16", "gordon-net")
+	require.NoError(t, err)
+	assert.Equal(t, "pg-1", createdID)
+
+	require.NotNil(t, created)
+	require.NotEmpty(t, tlsVolume)
+	assert.Equal(t, tlsVolume, created.Volumes[domain.DefaultStandaloneServiceTLSPath])
+	assert.Equal(t, svc.attachmentTLSHash("postgres"), created.Labels[domain.LabelConfigHash])
+}
@@ -127,13 +127,30 @@ func (h *ConfigReloadHandler) Handle(ctx context.Context, event domain.Event) er
 	for _, route := range routes {
 		if container, exists := activeRoutes[route.Domain]; exists {
 			currentImage := container.Labels[domain.LabelImage]
-			if currentImage != route.Image {
+			switch {
+			case currentImage != route.Image:
 				log.Info().
 					Str("domain", route.Domain).
 					Str("old_image", currentImage).
 					Str("new_image", route.Image).
 					Msg("image changed for route, redeploying")
 
+				if _, err := h.containerSvc.Deploy(domain.WithInternalDeploy(ctx), route); err != nil {
+					log.WrapErrWithFields(err, "failed to redeploy container", map[string]any{"domain": route.Domain})
+				}
+			case container.Labels[domain.LabelConfigHash] != route.Container.ConfigHash():
+				log.Info().
+					Str("domain", route.Domain).
+					Msg("container settings changed for route, redeploying")
+
+				if _, err := h.containerSvc.Deploy(domain.WithInternalDeploy(ctx), route); err != nil {
+					log.WrapErrWithFields(err, "failed to redeploy container", map[string]any{"domain": route.Domain})
+				}
+			case processesChanged(h.containerSvc.ListProcesses(ctx, route), route):
+				log.Info().
+					Str("domain", route.Domain).
+					Msg("processes changed for route, redeploying")
+
 				if _, err := h.containerSvc.Deploy(domain.WithInternalDeploy(ctx), route); err != nil {
 					log.WrapErrWithFields(err, "failed to redeploy container", map[string]any{"domain": route.Domain})
 				}
@@ -166,6 +183,21 @@ func (h *ConfigReloadHandler) Handle(ctx context.Context, event domain.Event) er
 	return nil
 }
 
+// processesChanged reports whether the process containers of route differ
+// from its declared processes: one is missing, runs another command or
+// settings, or is no longer declared.
+func processesChanged(processes []domain.Process, route domain.Route) bool {
+	for _, process := range processes {
+		if process.Command == nil || process.ContainerID == "" {
+			return true
+		}
+		if process.ConfigHash != domain.ProcessConfigHash(process.Command, route.Container) {
+			return true
+		}
+	}
+	return false
+}
+
 // CanHandle returns whether this handler can handle the given event type.
 func (h *ConfigReloadHandler) CanHandle(eventType domain.EventType) bool {
 	return eventType == domain.EventConfigReload
@@ -345,6 +345,49 @@ func TestConfigReloadHandler_Handle_RedeploysChangedImage(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestConfigReloadHandler_Handle_RedeploysChangedContainerSettings(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+
+	handler := NewConfigReloadHandler(testCtx(), containerSvc, configSvc)
+
+	containerSvc.EXPECT().SyncContainers(mock.Anything).Return(nil)
+	configSvc.EXPECT().GetAllAttachments(mock.Anything).Return(map[string][]string{})
+	containerSvc.EXPECT().UpdateAttachments(map[string][]string{}).Return()
+
+	deployed := &domain.ContainerOverrides{MemoryLimit: 128 << 20}
+	containerSvc.EXPECT().List(mock.Anything).Return(map[string]*domain.Container{
+		"app.example.com": {
+			ID: "container-1",
+			Labels: map[string]string{
+				"gordon.route":       "app.example.com",
+				"gordon.image":       "myapp:latest",
+				"gordon.config-hash": deployed.ConfigHash(),
+			},
+		},
+		"worker.example.com": {
+			ID: "container-2",
+			Labels: map[string]string{
+				"gordon.route":       "worker.example.com",
+				"gordon.image":       "worker:latest",
+				"gordon.config-hash": deployed.ConfigHash(),
+			},
+		},
+	})
+
+	// Same image; only the worker's memory changed.
+	app := domain.Route{Domain: "app.example.com", Image: "myapp:latest", Container: &domain.ContainerOverrides{MemoryLimit: 128 << 20}}
+	worker := domain.Route{Domain: "worker.example.com", Image: "worker:latest", Container: &domain.ContainerOverrides{MemoryLimit: 4 << 30}}
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{app, worker})
+	containerSvc.EXPECT().ListProcesses(mock.Anything, app).Return(nil)
+
+	containerSvc.EXPECT().Deploy(mock.Anything, worker).Return(&domain.Container{ID: "container-3"}, nil)
+
+	err := handler.Handle(context.Background(), domain.Event{ID: "event-123", Type: domain.EventConfigReload})
+
+	assert.NoError(t, err)
+}
+
 func TestConfigReloadHandler_Handle_NoChanges(t *testing.T) {
 	containerSvc := inmocks.NewMockContainerService(t)
 	configSvc := inmocks.NewMockConfigService(t)
@@ -369,9 +412,9 @@ func TestConfigReloadHandler_Handle_NoChanges(t *testing.T) {
 		},
 	})
 
-	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{
-		{Domain: "app.example.com", Image: "myapp:latest"},
-	})
+	route := domain.Route{Domain: "app.example.com", Image: "myapp:latest"}
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{route})
+	containerSvc.EXPECT().ListProcesses(mock.Anything, route).Return(nil)
 
 	// No Deploy, Stop, or Remove calls expected
 
@@ -385,6 +428,52 @@ func TestConfigReloadHandler_Handle_NoChanges(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestConfigReloadHandler_Handle_RedeploysChangedProcesses(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+
+	handler := NewConfigReloadHandler(testCtx(), containerSvc, configSvc)
+
+	containerSvc.EXPECT().SyncContainers(mock.Anything).Return(nil)
+	configSvc.EXPECT().GetAllAttachments(mock.Anything).Return(map[string][]string{})
+	containerSvc.EXPECT().UpdateAttachments(map[string][]string{}).Return()
+	containerSvc.EXPECT().List(mock.Anything).Return(map[string]*domain.Container{
+		"app.example.com":  {ID: "container-1", Labels: map[string]string{"gordon.route": "app.example.com", "gordon.image": "myapp:latest"}},
+		"shop.example.com": {ID: "container-2", Labels: map[string]string{"gordon.route": "shop.example.com", "gordon.image": "shop:latest"}},
+	})
+
+	worker := []string{"bundle", "exec", "sidekiq"}
+	app := domain.Route{Domain: "app.example.com", Image: "myapp:latest", Processes: map[string][]string{"worker": worker}}
+	shop := domain.Route{Domain: "shop.example.com", Image: "shop:latest", Processes: map[string][]string{"worker": worker}}
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{app, shop})
+
+	// app runs its worker already; shop's worker was given a new command.
+	containerSvc.EXPECT().ListProcesses(mock.Anything, app).Return([]domain.Process{
+		{Name: "worker", Command: worker, ContainerID: "worker-1", ConfigHash: domain.ProcessConfigHash(worker, nil)},
+	})
+	containerSvc.EXPECT().ListProcesses(mock.Anything, shop).Return([]domain.Process{
+		{Name: "worker", Command: worker, ContainerID: "worker-2", ConfigHash: domain.ProcessConfigHash([]string{"rake", "jobs:work"}, nil)},
+	})
+	containerSvc.EXPECT().Deploy(mock.Anything, shop).Return(&domain.Container{ID: "container-3"}, nil).Once()
+
+	err := handler.Handle(context.Background(), domain.Event{ID: "event-123", Type: domain.EventConfigReload})
+
+	assert.NoError(t, err)
+}
+
+func TestProcessesChanged(t *testing.T) {
+	worker := []string{"bundle", "exec", "sidekiq"}
+	route := domain.Route{Processes: map[string][]string{"worker": worker}}
+	running := domain.Process{Name: "worker", Command: worker, ContainerID: "c1", ConfigHash: domain.ProcessConfigHash(worker, nil)}
+
+	assert.False(t, processesChanged([]domain.Process{running}, route))
+	assert.True(t, processesChanged([]domain.Process{{Name: "worker", Command: worker}}, route), "declared process without a container")
+	assert.True(t, processesChanged([]domain.Process{running, {Name: "clock", ContainerID: "c2"}}, route), "process no longer declared")
+
+	route.Container = &domain.ContainerOverrides{MemoryLimit: 1 << 30}
+	assert.True(t, processesChanged([]domain.Process{running}, route), "route settings changed")
+}
+
 // ManualDeployHandler tests
 
 func TestConfigReloadHandlerUpdatesContainerConfig(t *testing.T) {
@@ -0,0 +1,85 @@
+package container
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bnema/zerowrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// StartExec starts an interactive command in the route's running container,
+// or in the named attachment when attachment is not empty. A route put to
+// sleep by its idle timeout is woken first so the session has a container
+// to run in.
+func (s *Service) StartExec(ctx context.Context, routeDomain, attachment string, req domain.ExecRequest) (out.ExecSession, error) {
+	if len(req.Command) == 0 || req.Command[0] == "" {
+		return nil, domain.ErrExecCommandRequired
+	}
+
+	ctx, span := tracer.Start(ctx, "container.start_exec",
+		trace.WithAttributes(attribute.String("domain", routeDomain)))
+	defer span.End()
+
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "StartExec",
+		"domain":              routeDomain,
+		"attachment":          attachment,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	containerID, err := s.resolveExecTarget(ctx, routeDomain, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := s.runtime.StartExec(ctx, containerID, req)
+	if err != nil {
+		return nil, log.WrapErr(err, "failed to start exec session")
+	}
+	return session, nil
+}
+
+// resolveExecTarget returns the ID of the running container an exec session
+// for routeDomain should use.
+func (s *Service) resolveExecTarget(ctx context.Context, routeDomain, attachment string) (string, error) {
+	if attachment == "" {
+		if s.isSleeping(routeDomain) {
+			woken, err := s.Wake(ctx, routeDomain)
+			if err != nil {
+				return "", err
+			}
+			return woken.ID, nil
+		}
+		existing, ok := s.resolveExistingContainer(ctx, routeDomain)
+		if !ok {
+			return "", fmt.Errorf("%w: %s has no deployed container", domain.ErrContainerNotFound, routeDomain)
+		}
+		return existing.ID, nil
+	}
+
+	running, err := s.runtime.ListContainers(ctx, false)
+	if err != nil {
+		return "", fmt.Errorf("failed to list running containers: %w", err)
+	}
+	for _, c := range running {
+		if c.Labels[domain.LabelManaged] != "true" ||
+			c.Labels[domain.LabelAttachment] != "true" ||
+			c.Labels[domain.LabelAttachedTo] != routeDomain {
+			continue
+		}
+		serviceName := c.Name
+		if labelImage := c.Labels[domain.LabelImage]; labelImage != "" {
+			serviceName = extractServiceName(labelImage)
+		}
+		if serviceName == attachment {
+			return c.ID, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s has no running attachment %q", domain.ErrAttachmentNotFound, routeDomain, attachment)
+}
@@ -0,0 +1,67 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestService_StartExec_TargetsRouteContainer(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	svc.containers["app.example.com"] = &domain.Container{ID: "app-1", Name: "gordon-app.example.com", Status: "running"}
+	req := domain.ExecRequest{Command: []string{"sh"}, TTY: true, Rows: 40, Cols: 120}
+	session := mocks.NewMockExecSession(t)
+
+	runtime.EXPECT().StartExec(mock.Anything, "app-1", req).Return(session, nil)
+
+	got, err := svc.StartExec(testContext(), "app.example.com", "", req)
+	require.NoError(t, err)
+	assert.Same(t, session, got)
+}
+
+func TestService_StartExec_TargetsNamedAttachment(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	req := domain.ExecRequest{Command: []string{"psql"}}
+	session := mocks.NewMockExecSession(t)
+
+	attachmentLabels := func(owner, image string) map[string]string {
+		return map[string]string{
+			domain.LabelManaged:    "true",
+			domain.LabelAttachment: "true",
+			domain.LabelAttachedTo: owner,
+			domain.LabelImage:      image,
+		}
+	}
+	runtime.EXPECT().ListContainers(mock.Anything, false).Return([]*domain.Container{
+		{ID: "redis-1", Labels: attachmentLabels("app.example.com", "redis:7")},
+		{ID: "other-pg", Labels: attachmentLabels("other.example.com", "postgres:16")},
+		{ID: "pg-1", Labels: attachmentLabels("app.example.com", "postgres:16")},
+	}, nil)
+	runtime.EXPECT().StartExec(mock.Anything, "pg-1", req).Return(session, nil)
+
+	got, err := svc.StartExec(testContext(), "app.example.com", "postgres", req)
+	require.NoError(t, err)
+	assert.Same(t, session, got)
+}
+
+func TestService_StartExec_RejectsUnknownTargets(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+
+	_, err := svc.StartExec(testContext(), "app.example.com", "", domain.ExecRequest{})
+	require.ErrorIs(t, err, domain.ErrExecCommandRequired)
+
+	runtime.EXPECT().ListContainers(mock.Anything, false).Return(nil, nil)
+	_, err = svc.StartExec(testContext(), "app.example.com", "", domain.ExecRequest{Command: []string{"sh"}})
+	require.ErrorIs(t, err, domain.ErrContainerNotFound)
+
+	_, err = svc.StartExec(testContext(), "app.example.com", "postgres", domain.ExecRequest{Command: []string{"sh"}})
+	assert.ErrorIs(t, err, domain.ErrAttachmentNotFound)
+}
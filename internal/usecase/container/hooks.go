@@ -0,0 +1,163 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// deployHooksFromLabels reads the gordon.release and gordon.post-deploy
+// labels of an image. It returns nil when the image sets neither.
+func deployHooksFromLabels(labels map[string]string) (*domain.DeployHooks, error) {
+	hooks := &domain.DeployHooks{}
+	for label, target := range map[string]*[]string{domain.LabelRelease: &hooks.Release, domain.LabelPostDeploy: &hooks.PostDeploy} {
+		value := strings.TrimSpace(labels[label])
+		if value == "" {
+			continue
+		}
+		command, err := parseCommandLabel(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s label %q", label, value)
+		}
+		*target = command
+	}
+	if hooks.IsZero() {
+		return nil, nil
+	}
+	return hooks, nil
+}
+
+// runDeployHook runs the route's command for phase in a one-off container
+// that uses the new image, env, volumes and network of the deploy. It is a
+// no-op when the phase has no command. A command that exits non-zero or
+// outlives the hook timeout yields a DeployFailureError carrying its logs.
+func (s *Service) runDeployHook(ctx context.Context, route domain.Route, phase domain.DeployHookPhase, resources *deployResources, existing *domain.Container) error {
+	command := resources.hooks.Command(phase)
+	if len(command) == 0 {
+		return nil
+	}
+
+	ctx, span := tracer.Start(ctx, "container.deploy_hook")
+	defer span.End()
+
+	ctx = zerowrap.CtxWithField(ctx, "hook", string(phase))
+	log := zerowrap.FromCtx(ctx)
+
+	config := s.oneOffContainerConfig(route, existing, resources, string(phase), command, map[string]string{
+		domain.LabelHook:      string(phase),
+		domain.LabelHookRoute: route.Domain,
+	})
+
+	timeout := resources.hooks.EffectiveTimeout()
+	hookCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Info().Strs("command", command).Msg("running deploy hook")
+	started := time.Now()
+
+	hookContainer, err := s.runtime.CreateContainer(hookCtx, config)
+	if err != nil {
+		return log.WrapErr(err, "failed to create deploy hook container")
+	}
+	defer s.cleanupFailedContainer(ctx, hookContainer.ID)
+	if hookContainer.Name == "" {
+		hookContainer.Name = config.Name
+	}
+
+	if err := s.runtime.StartContainer(hookCtx, hookContainer.ID); err != nil {
+		return s.newDeployFailure(hookContainer, fmt.Sprintf("%s command failed to start", phase), fmt.Errorf("%w: %w", domain.ErrReleaseCommandFailed, err), nil)
+	}
+
+	exitCode, err := s.waitForContainerExit(hookCtx, hookContainer.ID)
+	if err == nil && exitCode == 0 {
+		log.Info().Dur("duration", time.Since(started)).Msg("deploy hook completed")
+		return nil
+	}
+
+	cause := fmt.Sprintf("%s command exited with code %d", phase, exitCode)
+	switch {
+	case errors.Is(hookCtx.Err(), context.DeadlineExceeded):
+		cause = fmt.Sprintf("%s command did not finish within %s", phase, timeout)
+		err = fmt.Errorf("%w: %w", domain.ErrReleaseCommandFailed, err)
+	case err != nil:
+		cause = fmt.Sprintf("%s command could not be inspected", phase)
+		err = fmt.Errorf("%w: %w", domain.ErrReleaseCommandFailed, err)
+	default:
+		err = fmt.Errorf("%w: exit code %d", domain.ErrReleaseCommandFailed, exitCode)
+	}
+	return s.newDeployFailure(hookContainer, cause, err, s.captureRecentContainerLogs(ctx, hookContainer.ID))
+}
+
+// oneOffContainerConfig returns the route container configuration adapted to
+// run command once: no published ports, no restart policy, and only the
+// managed, image and extra labels so route tracking never picks it up.
+func (s *Service) oneOffContainerConfig(route domain.Route, existing *domain.Container, resources *deployResources, suffix string, command []string, labels map[string]string) *domain.ContainerConfig {
+	config := s.deployContainerConfig(route, existing, resources)
+	config.Name = managedContainerName(route.Domain) + "-" + suffix + "-" + strconv.FormatInt(time.Now().UnixMilli(), 10)
+	config.Cmd = command
+	config.Ports = nil
+	config.PortPublishes = nil
+	config.RestartPolicy = ""
+	config.Labels = map[string]string{
+		domain.LabelManaged: "true",
+		domain.LabelImage:   route.Image,
+	}
+	maps.Copy(config.Labels, labels)
+	return config
+}
+
+// waitForContainerExit polls a one-off container until it exits and returns its exit code.
+func (s *Service) waitForContainerExit(ctx context.Context, containerID string) (int, error) {
+	for {
+		info, err := s.runtime.InspectContainer(ctx, containerID)
+		if err != nil {
+			return 0, err
+		}
+		if info.Status == string(domain.ContainerStatusExited) || info.Status == string(domain.ContainerStatusDead) {
+			return info.ExitCode, nil
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// deployFailureLogs returns the captured container logs of a deploy failure.
+func deployFailureLogs(err error) []string {
+	var deployErr *domain.DeployFailureError
+	if errors.As(err, &deployErr) {
+		return deployErr.Logs
+	}
+	return nil
+}
+
+// startPostDeployHook runs the post-deploy command in the background once
+// unlocked is closed, so a slow command holds neither the deploy response nor
+// the route's deploy lock. The new container already serves traffic, so a
+// failure is reported but does not undo the deploy.
+func (s *Service) startPostDeployHook(ctx context.Context, route domain.Route, resources *deployResources, existing *domain.Container, unlocked <-chan struct{}) {
+	if len(resources.hooks.Command(domain.DeployHookPostDeploy)) == 0 {
+		return
+	}
+
+	hookCtx := context.WithoutCancel(ctx)
+	s.cleanupWg.Add(1)
+	go func() {
+		defer s.cleanupWg.Done()
+		<-unlocked
+		if err := s.runDeployHook(hookCtx, route, domain.DeployHookPostDeploy, resources, existing); err != nil {
+			log := zerowrap.FromCtx(hookCtx)
+			log.Warn().Err(err).Strs("logs", deployFailureLogs(err)).Msg("post-deploy command failed")
+		}
+	}()
+}
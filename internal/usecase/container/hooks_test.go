@@ -0,0 +1,134 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestDeployHooksFromLabels(t *testing.T) {
+	hooks, err := deployHooksFromLabels(map[string]string{
+		domain.LabelRelease:    `["./manage", "migrate"]`,
+		domain.LabelPostDeploy: "./manage warm-cache",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DeployHooks{Release: []string{"./manage", "migrate"}, PostDeploy: []string{"./manage", "warm-cache"}}, hooks)
+
+	hooks, err = deployHooksFromLabels(map[string]string{domain.LabelProxyPort: "3000"})
+	require.NoError(t, err)
+	assert.Nil(t, hooks)
+
+	_, err = deployHooksFromLabels(map[string]string{domain.LabelRelease: `["./manage"`})
+	assert.ErrorContains(t, err, domain.LabelRelease)
+}
+
+func TestService_RunDeployHook_Succeeds(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{Domain: "app.example.com", Image: "app:v2"}
+	resources := &deployResources{
+		networkName:    "gordon-app",
+		actualImageRef: "app:v2",
+		exposedPorts:   []int{8000},
+		envVars:        []string{"DATABASE_URL=postgres://db/app"},
+		hooks:          &domain.DeployHooks{Release: []string{"./manage", "migrate"}},
+	}
+
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return assert.Equal(t, []string{"./manage", "migrate"}, cfg.Cmd) &&
+			assert.Equal(t, "app:v2", cfg.Image) &&
+			assert.Equal(t, "gordon-app", cfg.NetworkMode) &&
+			assert.Equal(t, []string{"DATABASE_URL=postgres://db/app"}, cfg.Env) &&
+			assert.Empty(t, cfg.Ports) &&
+			assert.Empty(t, cfg.RestartPolicy) &&
+			assert.Equal(t, "release", cfg.Labels[domain.LabelHook]) &&
+			assert.Equal(t, "app.example.com", cfg.Labels[domain.LabelHookRoute]) &&
+			assert.NotContains(t, cfg.Labels, domain.LabelRoute)
+	})).Return(&domain.Container{ID: "hook-1"}, nil)
+	runtime.EXPECT().StartContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().InspectContainer(mock.Anything, "hook-1").Return(&domain.Container{ID: "hook-1", Status: string(domain.ContainerStatusExited)}, nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "hook-1", true).Return(nil)
+
+	require.NoError(t, svc.runDeployHook(testContext(), route, domain.DeployHookRelease, resources, nil))
+	assert.NoError(t, svc.runDeployHook(testContext(), route, domain.DeployHookPostDeploy, resources, nil), "phases without a command are skipped")
+}
+
+func TestService_RunDeployHook_FailureCapturesLogs(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{Domain: "app.example.com", Image: "app:v2"}
+	resources := &deployResources{actualImageRef: "app:v2", hooks: &domain.DeployHooks{Release: []string{"./manage", "migrate"}}}
+
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.Anything).Return(&domain.Container{ID: "hook-1", Name: "gordon-app.example.com-release-1"}, nil)
+	runtime.EXPECT().StartContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().InspectContainer(mock.Anything, "hook-1").Return(&domain.Container{ID: "hook-1", Status: string(domain.ContainerStatusExited), ExitCode: 2}, nil)
+	runtime.EXPECT().GetContainerLogs(mock.Anything, "hook-1", false).Return(dockerLogFrames(2, "relation \"users\" already exists\n"), nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "hook-1", true).Return(nil)
+
+	err := svc.runDeployHook(testContext(), route, domain.DeployHookRelease, resources, nil)
+
+	require.ErrorIs(t, err, domain.ErrReleaseCommandFailed)
+	var deployErr *domain.DeployFailureError
+	require.ErrorAs(t, err, &deployErr)
+	assert.Equal(t, "release command exited with code 2", deployErr.Cause)
+	assert.Equal(t, []string{`relation "users" already exists`}, deployErr.Logs)
+	assert.Equal(t, "gordon-app.example.com-release-1", deployErr.ContainerName)
+}
+
+func TestService_StartPostDeployHook_RunsDetachedAfterUnlock(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{Domain: "app.example.com", Image: "app:v2"}
+	resources := &deployResources{actualImageRef: "app:v2", hooks: &domain.DeployHooks{PostDeploy: []string{"./manage", "warm-cache"}}}
+
+	unlocked := make(chan struct{})
+	hookCtxErr := errors.New("post-deploy command did not run")
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return assert.Equal(t, "post-deploy", cfg.Labels[domain.LabelHook])
+	})).RunAndReturn(func(ctx context.Context, _ *domain.ContainerConfig) (*domain.Container, error) {
+		select {
+		case <-unlocked:
+		default:
+			t.Error("post-deploy command started while the deploy lock was held")
+		}
+		hookCtxErr = ctx.Err()
+		return &domain.Container{ID: "hook-1"}, nil
+	}).Once()
+	runtime.EXPECT().StartContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().InspectContainer(mock.Anything, "hook-1").Return(&domain.Container{ID: "hook-1", Status: string(domain.ContainerStatusExited)}, nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "hook-1", true).Return(nil)
+
+	// The deploy request returns, and its context ends, before the command runs.
+	ctx, cancel := context.WithCancel(testContext())
+	svc.startPostDeployHook(ctx, route, resources, nil, unlocked)
+	cancel()
+	close(unlocked)
+	svc.cleanupWg.Wait()
+
+	assert.NoError(t, hookCtxErr)
+}
+
+func TestService_CleanupOrphanedContainers_RemovesLeftoverHookContainers(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{
+		{ID: "hook-1", Name: "gordon-app.example.com-release-1", Status: "running", Labels: map[string]string{domain.LabelHookRoute: "app.example.com"}},
+		{ID: "hook-2", Name: "gordon-other.example.com-release-1", Status: "exited", Labels: map[string]string{domain.LabelHookRoute: "other.example.com"}},
+		{ID: "hook-3", Name: "gordon-app.example.com-post-deploy-1", Status: "running", Labels: map[string]string{domain.LabelHookRoute: "app.example.com", domain.LabelHook: "post-deploy"}},
+	}, nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "hook-1").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "hook-1", true).Return(nil)
+
+	require.NoError(t, svc.cleanupOrphanedContainers(testContext(), "app.example.com", ""))
+}
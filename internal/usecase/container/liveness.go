@@ -0,0 +1,277 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	livenessDefaultInterval     = 30 * time.Second
+	livenessDefaultTimeout      = 5 * time.Second
+	livenessDefaultThreshold    = 3
+	maxConcurrentLivenessProbes = 10
+)
+
+// livenessSettings is the effective liveness configuration.
+type livenessSettings struct {
+	enabled   bool
+	path      string
+	interval  time.Duration
+	timeout   time.Duration
+	threshold int
+}
+
+// livenessRecord counts the consecutive failed probes of a route container.
+type livenessRecord struct {
+	containerID string
+	failures    int
+}
+
+func (m *Monitor) livenessSettings() livenessSettings {
+	m.service.mu.RLock()
+	cfg := m.service.config
+	m.service.mu.RUnlock()
+
+	settings := livenessSettings{
+		enabled:   cfg.LivenessEnabled,
+		path:      cfg.LivenessPath,
+		interval:  cfg.LivenessInterval,
+		timeout:   cfg.LivenessTimeout,
+		threshold: cfg.LivenessFailureThreshold,
+	}
+	if settings.interval <= 0 {
+		settings.interval = livenessDefaultInterval
+	}
+	if settings.timeout <= 0 {
+		settings.timeout = livenessDefaultTimeout
+	}
+	if settings.threshold <= 0 {
+		settings.threshold = livenessDefaultThreshold
+	}
+	return settings
+}
+
+// runLiveness probes route containers until the monitor stops. The settings
+// are re-read on every round so a config reload can turn probing on or off.
+func (m *Monitor) runLiveness(ctx context.Context) {
+	defer close(m.livenessDone)
+
+	for {
+		settings := m.livenessSettings()
+		timer := time.NewTimer(settings.interval)
+		select {
+		case <-m.stopCh:
+			timer.Stop()
+			return
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if settings.enabled {
+			m.checkLiveness(ctx, settings)
+			continue
+		}
+		m.mu.Lock()
+		clear(m.liveness)
+		m.mu.Unlock()
+	}
+}
+
+func (m *Monitor) checkLiveness(ctx context.Context, settings livenessSettings) {
+	log := zerowrap.FromCtx(ctx)
+
+	m.service.mu.RLock()
+	snapshot := make(map[string]*domain.Container, len(m.service.containers))
+	for d, c := range m.service.containers {
+		snapshot[d] = c
+	}
+	m.service.mu.RUnlock()
+
+	// Forget the failures of routes that are gone.
+	m.mu.Lock()
+	for domainName := range m.liveness {
+		if _, ok := snapshot[domainName]; !ok {
+			delete(m.liveness, domainName)
+		}
+	}
+	m.mu.Unlock()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentLivenessProbes)
+	for domainName, tracked := range snapshot {
+		wg.Add(1)
+		go func(domainName string, tracked *domain.Container) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			m.probeLiveness(ctx, log, settings, domainName, tracked)
+		}(domainName, tracked)
+	}
+	wg.Wait()
+}
+
+func (m *Monitor) probeLiveness(ctx context.Context, log zerowrap.Logger, settings livenessSettings, domainName string, tracked *domain.Container) {
+	if tracked == nil || isSleepingContainer(tracked) || m.service.isSleeping(domainName) {
+		m.resetLiveness(domainName)
+		return
+	}
+
+	// The image's gordon.health label wins over the configured path; without
+	// either there is nothing meaningful to probe.
+	path := tracked.Labels[domain.LabelHealth]
+	if path == "" {
+		path = settings.path
+	}
+	if path == "" {
+		return
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	ip, port, err := m.service.resolveProbeEndpoint(ctx, tracked.ID, &domain.ContainerConfig{Labels: tracked.Labels})
+	if err != nil || ip == "" || port <= 0 {
+		log.Debug().Err(err).Str("domain", domainName).Msg("monitor: liveness probe skipped, could not resolve container endpoint")
+		return
+	}
+	url := fmt.Sprintf("http://%s%s", net.JoinHostPort(ip, strconv.Itoa(port)), path)
+
+	probeErr := livenessProbe(ctx, url, settings.timeout)
+	if probeErr == nil {
+		m.resetLiveness(domainName)
+		return
+	}
+
+	// A container that is no longer running is the crash handler's business.
+	running, err := m.service.runtime.IsContainerRunning(ctx, tracked.ID)
+	if err != nil || !running {
+		m.resetLiveness(domainName)
+		return
+	}
+
+	failures := m.recordLivenessFailure(domainName, tracked.ID)
+	log.Warn().Err(probeErr).Str("domain", domainName).Str("url", url).
+		Int("failures", failures).Int("threshold", settings.threshold).
+		Msg("monitor: liveness probe failed")
+	if failures < settings.threshold {
+		return
+	}
+
+	m.resetLiveness(domainName)
+	m.handleLivenessFailure(ctx, log, domainName, tracked, url, failures, probeErr)
+}
+
+func (m *Monitor) handleLivenessFailure(ctx context.Context, log zerowrap.Logger, domainName string, tracked *domain.Container, url string, failures int, probeErr error) {
+	if m.service.metrics != nil {
+		attrs := metric.WithAttributes(attribute.String("domain", domainName))
+		m.service.metrics.LivenessFailures.Add(ctx, 1, attrs)
+	}
+
+	payload := &domain.ContainerHealthCheckPayload{
+		ContainerID: tracked.ID,
+		Domain:      domainName,
+		URL:         url,
+		Failures:    failures,
+		LastError:   probeErr.Error(),
+	}
+
+	if !m.allowRestart(ctx, log, domainName, time.Now()) {
+		payload.Action = "backoff"
+		m.service.publishContainerHealthCheck(ctx, payload)
+		return
+	}
+
+	// A deploy may have replaced the container while it was being probed.
+	if !m.isContainerStillTracked(domainName, tracked.ID) {
+		log.Debug().Str("domain", domainName).Msg("monitor: container replaced by deploy, skipping liveness restart")
+		return
+	}
+
+	log.Warn().Str("domain", domainName).Str("container_id", tracked.ID).
+		Int("failures", failures).
+		Msg("monitor: container failed liveness probes, restarting")
+
+	if _, _, err := m.service.restartContainerWithRecovery(ctx, domainName, tracked, nil); err != nil {
+		log.Warn().Err(err).Str("domain", domainName).Msg("monitor: failed to restart container after liveness failures")
+		payload.Action = "restart_failed"
+		m.service.publishContainerHealthCheck(ctx, payload)
+		return
+	}
+
+	m.recordRestartMetric(ctx, domainName, "liveness")
+	payload.Action = "restarted"
+	m.service.publishContainerHealthCheck(ctx, payload)
+}
+
+// recordLivenessFailure counts a failed probe of containerID and returns the
+// number of consecutive failures. A new container starts from zero.
+func (m *Monitor) recordLivenessFailure(domainName, containerID string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rec := m.liveness[domainName]
+	if rec == nil || rec.containerID != containerID {
+		rec = &livenessRecord{containerID: containerID}
+		m.liveness[domainName] = rec
+	}
+	rec.failures++
+	return rec.failures
+}
+
+func (m *Monitor) resetLiveness(domainName string) {
+	m.mu.Lock()
+	delete(m.liveness, domainName)
+	m.mu.Unlock()
+}
+
+// livenessProbe performs a single HTTP GET of url. Like the readiness HTTP
+// probe, only a 2xx or 3xx response counts as alive.
+func livenessProbe(ctx context.Context, url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// publishContainerHealthCheck publishes a container.health_check event.
+func (s *Service) publishContainerHealthCheck(ctx context.Context, payload *domain.ContainerHealthCheckPayload) {
+	if s.eventBus == nil {
+		return
+	}
+	if err := s.eventBus.Publish(domain.EventContainerHealthCheck, payload); err != nil {
+		log := zerowrap.FromCtx(ctx)
+		log.Warn().Err(err).Msg("failed to publish container health check event")
+	}
+}
@@ -0,0 +1,117 @@
+package container
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// livenessTestServer returns a server answering status on /health and the
+// container labels pointing the probe at it.
+func livenessTestServer(t *testing.T, status int) (int, map[string]string) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	require.NoError(t, err)
+	return port, map[string]string{
+		domain.LabelHealth:    "/health",
+		domain.LabelProxyPort: strconv.Itoa(port),
+	}
+}
+
+func TestMonitor_LivenessRestartsAfterThreshold(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	events := mocks.NewMockEventPublisher(t)
+	svc := newTestService(runtime)
+	svc.eventBus = events
+	svc.config = Config{LivenessEnabled: true, LivenessFailureThreshold: 2}
+
+	port, labels := livenessTestServer(t, http.StatusServiceUnavailable)
+	svc.containers["app.example.com"] = &domain.Container{ID: "ctr-1", Labels: labels}
+
+	runtime.EXPECT().GetContainerPort(mock.Anything, "ctr-1", port).Return(port, nil)
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "ctr-1").Return(true, nil)
+	runtime.EXPECT().RestartContainer(mock.Anything, "ctr-1").Return(nil).Once()
+	events.EXPECT().Publish(domain.EventContainerHealthCheck, mock.MatchedBy(func(p *domain.ContainerHealthCheckPayload) bool {
+		return p.Domain == "app.example.com" && p.Action == "restarted" && p.Failures == 2
+	})).Return(nil).Once()
+
+	m := newMonitor(svc)
+	settings := m.livenessSettings()
+	m.checkLiveness(monitorTestContext(), settings)
+	runtime.AssertNotCalled(t, "RestartContainer", mock.Anything, mock.Anything)
+
+	m.checkLiveness(monitorTestContext(), settings)
+	assert.Empty(t, m.liveness, "failures reset after a restart")
+}
+
+func TestMonitor_LivenessSuccessResetsFailures(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newTestService(runtime)
+	svc.config = Config{LivenessEnabled: true}
+
+	port, labels := livenessTestServer(t, http.StatusOK)
+	svc.containers["app.example.com"] = &domain.Container{ID: "ctr-1", Labels: labels}
+	runtime.EXPECT().GetContainerPort(mock.Anything, "ctr-1", port).Return(port, nil)
+
+	m := newMonitor(svc)
+	m.liveness["app.example.com"] = &livenessRecord{containerID: "ctr-1", failures: 2}
+	m.checkLiveness(monitorTestContext(), m.livenessSettings())
+
+	assert.Empty(t, m.liveness)
+	runtime.AssertNotCalled(t, "RestartContainer", mock.Anything, mock.Anything)
+}
+
+func TestMonitor_LivenessSkipsContainersWithoutPath(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newTestService(runtime)
+	svc.config = Config{LivenessEnabled: true}
+	svc.containers["app.example.com"] = &domain.Container{ID: "ctr-1"}
+
+	m := newMonitor(svc)
+	m.checkLiveness(monitorTestContext(), m.livenessSettings())
+
+	// No runtime calls: without a gordon.health label or a configured path
+	// the container is not probed.
+	assert.Empty(t, m.liveness)
+}
+
+func TestMonitor_LivenessBacksOffLikeCrashLoops(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	events := mocks.NewMockEventPublisher(t)
+	svc := newTestService(runtime)
+	svc.eventBus = events
+	svc.config = Config{LivenessEnabled: true, LivenessFailureThreshold: 1}
+
+	port, labels := livenessTestServer(t, http.StatusInternalServerError)
+	svc.containers["app.example.com"] = &domain.Container{ID: "ctr-1", Labels: labels}
+	runtime.EXPECT().GetContainerPort(mock.Anything, "ctr-1", port).Return(port, nil)
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "ctr-1").Return(true, nil)
+	runtime.EXPECT().RestartContainer(mock.Anything, "ctr-1").Return(nil).Times(crashLoopThreshold - 1)
+	events.EXPECT().Publish(domain.EventContainerHealthCheck, mock.MatchedBy(func(p *domain.ContainerHealthCheckPayload) bool {
+		return p.Action == "restarted"
+	})).Return(nil).Times(crashLoopThreshold - 1)
+	events.EXPECT().Publish(domain.EventContainerHealthCheck, mock.MatchedBy(func(p *domain.ContainerHealthCheckPayload) bool {
+		return p.Action == "backoff"
+	})).Return(nil).Once()
+
+	m := newMonitor(svc)
+	for range crashLoopThreshold {
+		m.checkLiveness(monitorTestContext(), m.livenessSettings())
+	}
+}
@@ -28,24 +28,29 @@ type restartRecord struct {
 	lastSeen    time.Time // last time the container was seen running
 }
 
-// Monitor watches tracked containers and restarts crashed ones.
+// Monitor watches tracked containers and restarts crashed ones, and hung
+// ones when liveness probing is enabled.
 type Monitor struct {
-	service  *Service
-	stopCh   chan struct{}
-	stopped  chan struct{}
-	interval time.Duration
-	mu       sync.Mutex
-	history  map[string]*restartRecord // keyed by domain
+	service      *Service
+	stopCh       chan struct{}
+	stopped      chan struct{}
+	livenessDone chan struct{}
+	interval     time.Duration
+	mu           sync.Mutex
+	history      map[string]*restartRecord  // keyed by domain, or "domain/process" for processes
+	liveness     map[string]*livenessRecord // keyed by domain
 }
 
 // newMonitor creates a new container monitor.
 func newMonitor(service *Service) *Monitor {
 	return &Monitor{
-		service:  service,
-		stopCh:   make(chan struct{}),
-		stopped:  make(chan struct{}),
-		interval: monitorDefaultInterval,
-		history:  make(map[string]*restartRecord),
+		service:      service,
+		stopCh:       make(chan struct{}),
+		stopped:      make(chan struct{}),
+		livenessDone: make(chan struct{}),
+		interval:     monitorDefaultInterval,
+		history:      make(map[string]*restartRecord),
+		liveness:     make(map[string]*livenessRecord),
 	}
 }
 
@@ -55,12 +60,14 @@ func (m *Monitor) Start(ctx context.Context) {
 	log.Info().Dur("interval", m.interval).Msg("container monitor started")
 
 	go m.run(ctx)
+	go m.runLiveness(ctx)
 }
 
 // Stop signals the monitor to stop and waits for it to finish.
 func (m *Monitor) Stop() {
 	close(m.stopCh)
 	<-m.stopped
+	<-m.livenessDone
 }
 
 func (m *Monitor) run(ctx context.Context) {
@@ -90,6 +97,10 @@ func (m *Monitor) check(ctx context.Context) {
 	for d, c := range m.service.containers {
 		snapshot[d] = c
 	}
+	processes := make(map[string]*domain.Container, len(m.service.processes))
+	for key, c := range m.service.processes {
+		processes[key] = c
+	}
 	m.service.mu.RUnlock()
 
 	now := time.Now()
@@ -97,10 +108,55 @@ func (m *Monitor) check(ctx context.Context) {
 	for domainName, tracked := range snapshot {
 		m.checkContainer(ctx, log, domainName, tracked, now)
 	}
+	for key, tracked := range processes {
+		m.checkProcess(ctx, log, key, tracked, now)
+	}
+}
+
+// checkProcess restarts a route process container that crashed. Processes
+// keep running while their route sleeps, so sleep is not considered.
+func (m *Monitor) checkProcess(ctx context.Context, log zerowrap.Logger, key string, tracked *domain.Container, now time.Time) {
+	inspected, err := m.service.runtime.InspectContainer(ctx, tracked.ID)
+	if err != nil {
+		log.Debug().Err(err).Str("process", key).Str("container_id", tracked.ID).
+			Msg("monitor: failed to inspect process container, may have been replaced by deploy")
+		return
+	}
+
+	switch {
+	case inspected.Status == string(domain.ContainerStatusRunning):
+		m.clearStableHistory(log, key, now)
+
+	case inspected.Status == string(domain.ContainerStatusExited) && inspected.ExitCode != 0:
+		if !m.allowRestart(ctx, log, key, now) {
+			return
+		}
+		if !m.isProcessStillTracked(key, tracked.ID) {
+			log.Debug().Str("process", key).Msg("monitor: process container replaced by deploy, skipping restart")
+			return
+		}
+
+		log.Warn().Str("process", key).Str("container_id", tracked.ID).
+			Int("exit_code", inspected.ExitCode).
+			Msg("monitor: restarting crashed process container")
+
+		if err := m.service.runtime.StartContainer(ctx, tracked.ID); err != nil {
+			log.Warn().Err(err).Str("process", key).Msg("monitor: failed to restart process container")
+			return
+		}
+		m.recordRestartMetric(ctx, tracked.Labels[domain.LabelRoute], "monitor")
+	}
+}
+
+func (m *Monitor) isProcessStillTracked(key, containerID string) bool {
+	m.service.mu.RLock()
+	current := m.service.processes[key]
+	m.service.mu.RUnlock()
+	return current != nil && current.ID == containerID
 }
 
 func (m *Monitor) checkContainer(ctx context.Context, log zerowrap.Logger, domainName string, tracked *domain.Container, now time.Time) {
-	if tracked == nil {
+	if tracked == nil || isSleepingContainer(tracked) {
 		return
 	}
 
@@ -111,6 +167,12 @@ func (m *Monitor) checkContainer(ctx context.Context, log zerowrap.Logger, domai
 		return
 	}
 
+	// The route may have been put to sleep after the snapshot was taken; its
+	// stopped container must not be mistaken for a crash.
+	if m.service.isSleeping(domainName) {
+		return
+	}
+
 	switch {
 	case inspected.Status == string(domain.ContainerStatusRunning):
 		m.handleRunning(ctx, log, domainName, tracked.ID, now)
@@ -146,17 +208,23 @@ func (m *Monitor) handleRunning(ctx context.Context, log zerowrap.Logger, domain
 	}
 
 	// Container is running and healthy — clear backoff if stable long enough.
+	m.clearStableHistory(log, domainName, now)
+}
+
+// clearStableHistory forgets the crash history of key once its container
+// has been running for stableRunningDuration.
+func (m *Monitor) clearStableHistory(log zerowrap.Logger, key string, now time.Time) {
 	m.mu.Lock()
-	rec, exists := m.history[domainName]
+	rec, exists := m.history[key]
 	if exists {
 		if rec.lastSeen.IsZero() {
 			rec.lastSeen = now
 		}
 		if now.Sub(rec.lastSeen) >= stableRunningDuration {
 			// Container has been running for 5+ min — clear crash history.
-			delete(m.history, domainName)
+			delete(m.history, key)
 			m.mu.Unlock()
-			log.Info().Str("domain", domainName).Msg("monitor: container stable, cleared crash history")
+			log.Info().Str("domain", key).Msg("monitor: container stable, cleared crash history")
 			return
 		}
 	}
@@ -171,6 +239,32 @@ func (m *Monitor) isContainerStillTracked(domainName, containerID string) bool {
 }
 
 func (m *Monitor) handleCrash(ctx context.Context, log zerowrap.Logger, domainName, containerID string, exitCode int, now time.Time) {
+	if !m.allowRestart(ctx, log, domainName, now) {
+		return
+	}
+
+	// Verify container ID still matches tracked state (deploy may have replaced it).
+	if !m.isContainerStillTracked(domainName, containerID) {
+		log.Debug().Str("domain", domainName).Msg("monitor: container replaced by deploy, skipping restart")
+		return
+	}
+
+	log.Warn().Str("domain", domainName).Str("container_id", containerID).
+		Int("exit_code", exitCode).
+		Msg("monitor: restarting crashed container")
+
+	if err := m.service.runtime.StartContainer(ctx, containerID); err != nil {
+		log.Warn().Err(err).Str("domain", domainName).Msg("monitor: failed to restart container")
+		return
+	}
+
+	m.recordRestartMetric(ctx, domainName, "monitor")
+}
+
+// allowRestart records a restart attempt for domainName and reports whether
+// it may proceed. Too many attempts within crashLoopWindow put the domain
+// into an exponential backoff, during which every attempt is refused.
+func (m *Monitor) allowRestart(ctx context.Context, log zerowrap.Logger, domainName string, now time.Time) bool {
 	m.mu.Lock()
 	rec := m.history[domainName]
 	if rec == nil {
@@ -184,7 +278,7 @@ func (m *Monitor) handleCrash(ctx context.Context, log zerowrap.Logger, domainNa
 		log.Debug().Str("domain", domainName).
 			Time("backoff_until", rec.backoffEnd).
 			Msg("monitor: in crash loop backoff, skipping restart")
-		return
+		return false
 	}
 
 	// Record this crash attempt.
@@ -206,10 +300,11 @@ func (m *Monitor) handleCrash(ctx context.Context, log zerowrap.Logger, domainNa
 	if len(rec.attempts) >= crashLoopThreshold {
 		backoff := m.computeBackoff(rec.consecutive)
 		rec.backoffEnd = now.Add(backoff)
+		consecutive := rec.consecutive
 		m.mu.Unlock()
 
 		log.Error().Str("domain", domainName).
-			Int("consecutive", rec.consecutive).
+			Int("consecutive", consecutive).
 			Dur("backoff", backoff).
 			Msg("monitor: crash loop detected, backing off")
 
@@ -218,30 +313,18 @@ func (m *Monitor) handleCrash(ctx context.Context, log zerowrap.Logger, domainNa
 			attrs := metric.WithAttributes(attribute.String("domain", domainName))
 			m.service.metrics.ContainerCrashLoops.Add(ctx, 1, attrs)
 		}
-		return
+		return false
 	}
 	m.mu.Unlock()
+	return true
+}
 
-	// Verify container ID still matches tracked state (deploy may have replaced it).
-	if !m.isContainerStillTracked(domainName, containerID) {
-		log.Debug().Str("domain", domainName).Msg("monitor: container replaced by deploy, skipping restart")
-		return
-	}
-
-	log.Warn().Str("domain", domainName).Str("container_id", containerID).
-		Int("exit_code", exitCode).
-		Msg("monitor: restarting crashed container")
-
-	if err := m.service.runtime.StartContainer(ctx, containerID); err != nil {
-		log.Warn().Err(err).Str("domain", domainName).Msg("monitor: failed to restart container")
-		return
-	}
-
-	// Record restart metric only on successful restart
+// recordRestartMetric counts a successful restart made by source.
+func (m *Monitor) recordRestartMetric(ctx context.Context, domainName, source string) {
 	if m.service.metrics != nil {
 		attrs := metric.WithAttributes(
 			attribute.String("domain", domainName),
-			attribute.String("source", "monitor"),
+			attribute.String("source", source),
 		)
 		m.service.metrics.ContainerRestarts.Add(ctx, 1, attrs)
 	}
@@ -19,6 +19,7 @@ func newTestService(runtime *mocks.MockContainerRuntime) *Service {
 		config:      Config{},
 		containers:  make(map[string]*domain.Container),
 		attachments: make(map[string][]string),
+		processes:   make(map[string]*domain.Container),
 	}
 }
 
@@ -52,6 +53,27 @@ func TestMonitor_RestartsCrashedContainer(t *testing.T) {
 	runtime.AssertExpectations(t)
 }
 
+func TestMonitor_RestartsCrashedProcess(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newTestService(runtime)
+	svc.processes["app.example.com/worker"] = &domain.Container{
+		ID:     "worker-1",
+		Labels: map[string]string{domain.LabelRoute: "app.example.com", domain.LabelProcess: "worker"},
+	}
+
+	runtime.EXPECT().InspectContainer(mock.Anything, "worker-1").Return(&domain.Container{
+		ID:       "worker-1",
+		Status:   string(domain.ContainerStatusExited),
+		ExitCode: 137,
+	}, nil)
+	runtime.EXPECT().StartContainer(mock.Anything, "worker-1").Return(nil).Once()
+
+	m := newMonitor(svc)
+	m.check(monitorTestContext())
+
+	assert.Len(t, m.history["app.example.com/worker"].attempts, 1)
+}
+
 func TestMonitor_SkipsGracefulExit(t *testing.T) {
 	runtime := mocks.NewMockContainerRuntime(t)
 	svc := newTestService(runtime)
@@ -0,0 +1,113 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/bytesize"
+)
+
+// containerOverridesFromLabels reads the gordon.* container override labels
+// of an image. It returns nil when the image sets none. The user and extra
+// capabilities are never read from labels: anyone who can push an image would
+// otherwise loosen the operator's security profile. They come only from the
+// route table in gordon.toml.
+func containerOverridesFromLabels(labels map[string]string) (*domain.ContainerOverrides, error) {
+	overrides := &domain.ContainerOverrides{}
+	if value := strings.TrimSpace(labels[domain.LabelMemory]); value != "" {
+		memory, err := bytesize.Parse(value)
+		if err != nil || memory <= 0 {
+			return nil, fmt.Errorf("invalid %s label %q", domain.LabelMemory, value)
+		}
+		overrides.MemoryLimit = memory
+	}
+	if value := strings.TrimSpace(labels[domain.LabelCPUs]); value != "" {
+		cpus, err := strconv.ParseFloat(value, 64)
+		if err != nil || cpus <= 0 {
+			return nil, fmt.Errorf("invalid %s label %q", domain.LabelCPUs, value)
+		}
+		overrides.NanoCPUs = int64(cpus * 1e9)
+	}
+	if value := strings.TrimSpace(labels[domain.LabelPidsLimit]); value != "" {
+		pids, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || pids <= 0 {
+			return nil, fmt.Errorf("invalid %s label %q", domain.LabelPidsLimit, value)
+		}
+		overrides.PidsLimit = pids
+	}
+	if value := strings.TrimSpace(labels[domain.LabelCommand]); value != "" {
+		command, err := parseCommandLabel(value)
+		if err != nil {
+			return nil, err
+		}
+		overrides.Command = command
+	}
+	if value := strings.TrimSpace(labels[domain.LabelWorkingDir]); value != "" {
+		if !path.IsAbs(value) {
+			return nil, fmt.Errorf("invalid %s label %q: must be absolute", domain.LabelWorkingDir, value)
+		}
+		overrides.WorkingDir = value
+	}
+	for _, spec := range strings.Fields(labels[domain.LabelTmpfs]) {
+		mountPath, options, err := domain.ParseTmpfsMount(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s label: %w", domain.LabelTmpfs, err)
+		}
+		if overrides.Tmpfs == nil {
+			overrides.Tmpfs = map[string]string{}
+		}
+		overrides.Tmpfs[mountPath] = options
+	}
+	if overrides.IsZero() {
+		return nil, nil
+	}
+	return overrides, nil
+}
+
+// parseCommandLabel accepts a JSON array (the Dockerfile exec form) or
+// whitespace-separated words.
+func parseCommandLabel(value string) ([]string, error) {
+	if !strings.HasPrefix(value, "[") {
+		return strings.Fields(value), nil
+	}
+	var command []string
+	if err := json.Unmarshal([]byte(value), &command); err != nil || len(command) == 0 || command[0] == "" {
+		return nil, fmt.Errorf("invalid %s label %q", domain.LabelCommand, value)
+	}
+	return command, nil
+}
+
+// applyContainerOverrides sets the overridden fields on config, leaving the
+// global defaults and security profile in place for everything else.
+func applyContainerOverrides(config *domain.ContainerConfig, overrides *domain.ContainerOverrides) {
+	if overrides.IsZero() {
+		return
+	}
+	if overrides.MemoryLimit > 0 {
+		config.MemoryLimit = overrides.MemoryLimit
+	}
+	if overrides.NanoCPUs > 0 {
+		config.NanoCPUs = overrides.NanoCPUs
+	}
+	if overrides.PidsLimit > 0 {
+		config.PidsLimit = overrides.PidsLimit
+	}
+	if len(overrides.Command) > 0 {
+		config.Cmd = overrides.Command
+	}
+	if overrides.User != "" {
+		config.User = overrides.User
+	}
+	if overrides.WorkingDir != "" {
+		config.WorkingDir = overrides.WorkingDir
+	}
+	config.ExtraCapAdd = overrides.CapAdd
+	if len(overrides.Tmpfs) > 0 {
+		config.Tmpfs = maps.Clone(overrides.Tmpfs)
+	}
+}
@@ -0,0 +1,74 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestContainerOverridesFromLabels(t *testing.T) {
+	overrides, err := containerOverridesFromLabels(map[string]string{
+		domain.LabelMemory:     "512MB",
+		domain.LabelCPUs:       "0.5",
+		domain.LabelPidsLimit:  "256",
+		domain.LabelCommand:    `["worker", "--queue", "a b"]`,
+		domain.LabelWorkingDir: "/srv",
+		domain.LabelTmpfs:      "/tmp:size=64m,mode=1777 /run",
+		domain.LabelProxyPort:  "3000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ContainerOverrides{
+		MemoryLimit: 512 << 20,
+		NanoCPUs:    500_000_000,
+		PidsLimit:   256,
+		Command:     []string{"worker", "--queue", "a b"},
+		WorkingDir:  "/srv",
+		Tmpfs:       map[string]string{"/tmp": "size=64m,mode=1777", "/run": ""},
+	}, overrides)
+
+	overrides, err = containerOverridesFromLabels(map[string]string{domain.LabelCommand: "serve --port 8080"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"serve", "--port", "8080"}, overrides.Command)
+
+	overrides, err = containerOverridesFromLabels(map[string]string{domain.LabelProxyPort: "3000"})
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+}
+
+func TestContainerOverridesFromLabels_RejectsInvalidValues(t *testing.T) {
+	for label, value := range map[string]string{
+		domain.LabelMemory:     "lots",
+		domain.LabelCPUs:       "-1",
+		domain.LabelPidsLimit:  "0",
+		domain.LabelCommand:    `["worker"`,
+		domain.LabelWorkingDir: "srv",
+		domain.LabelTmpfs:      "tmp",
+	} {
+		_, err := containerOverridesFromLabels(map[string]string{label: value})
+		assert.ErrorContains(t, err, label, label)
+	}
+}
+
+func TestContainerOverridesFromLabels_IgnoresPrivilegeLabels(t *testing.T) {
+	overrides, err := containerOverridesFromLabels(map[string]string{
+		"gordon.cap-add": "SYS_ADMIN,NET_ADMIN",
+		"gordon.user":    "0:0",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, overrides)
+
+	overrides, err = containerOverridesFromLabels(map[string]string{
+		"gordon.cap-add":   "SYS_ADMIN",
+		domain.LabelMemory: "256MB",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, overrides.CapAdd)
+
+	config := &domain.ContainerConfig{}
+	applyContainerOverrides(config, overrides)
+	assert.Empty(t, config.ExtraCapAdd)
+	assert.Empty(t, config.User)
+}
@@ -0,0 +1,303 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// processRollout holds the process containers a deploy started and the ones
+// they replace. The replaced ones keep running until the deploy is kept.
+type processRollout struct {
+	started map[string]*domain.Container // by process name, including kept containers
+	retired []*domain.Container
+	created []*domain.Container // containers created by this rollout
+}
+
+// processKey is the tracking key of a route process.
+func processKey(domainName, name string) string {
+	return domainName + "/" + name
+}
+
+// processContainerName returns the name of a new container for a route
+// process. It alternates with a -next suffix so the replacement can start
+// while the current container still runs.
+func processContainerName(domainName, name string, current []*domain.Container) string {
+	base := managedContainerName(domainName) + "-" + name
+	used := make(map[string]bool, len(current))
+	for _, c := range current {
+		used[c.Name] = true
+	}
+	for _, candidate := range []string{base, base + "-next"} {
+		if !used[candidate] {
+			return candidate
+		}
+	}
+	// An interrupted deploy left both names behind; they are retired once
+	// this container runs.
+	return base + "-" + strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// processContainersForDomain returns the managed process containers of a
+// route, grouped by process name.
+func processContainersForDomain(containers []*domain.Container, domainName string) map[string][]*domain.Container {
+	byName := make(map[string][]*domain.Container)
+	for _, c := range containers {
+		if c == nil || c.Labels == nil || c.Labels[domain.LabelManaged] != "true" {
+			continue
+		}
+		name := c.Labels[domain.LabelProcess]
+		if name == "" || c.Labels[domain.LabelRoute] != domainName {
+			continue
+		}
+		byName[name] = append(byName[name], c)
+	}
+	return byName
+}
+
+// processContainerConfig returns the route container configuration adapted
+// to run a process: the process command, no published ports, and labels
+// that tie it to the route without making it the route's web container.
+func (s *Service) processContainerConfig(route domain.Route, resources *deployResources, name string, command []string, current []*domain.Container) *domain.ContainerConfig {
+	config := s.deployContainerConfig(route, nil, resources)
+	config.Name = processContainerName(route.Domain, name, current)
+	config.Cmd = command
+	config.Ports = nil
+	config.PortPublishes = nil
+	config.Labels = map[string]string{
+		domain.LabelManaged:    "true",
+		domain.LabelImage:      route.Image,
+		domain.LabelRoute:      route.Domain,
+		domain.LabelProcess:    name,
+		domain.LabelEnvHash:    resources.envHash,
+		domain.LabelConfigHash: domain.ProcessConfigHash(command, route.Container),
+	}
+	return config
+}
+
+// processUpToDate reports whether c already runs command from the deployed
+// image with the deployed env and settings.
+func processUpToDate(c *domain.Container, imageID, envHash, configHash string) bool {
+	return c.Status == string(domain.ContainerStatusRunning) &&
+		imageID != "" && c.ImageID == imageID &&
+		c.Labels[domain.LabelEnvHash] == envHash &&
+		c.Labels[domain.LabelConfigHash] == configHash
+}
+
+// rollProcesses starts a container for every process of route that does not
+// already run the deployed image, env and settings. Containers of processes
+// that are no longer declared are retired. When a container fails to start,
+// the containers started so far are removed and the current ones are left
+// running.
+func (s *Service) rollProcesses(ctx context.Context, route domain.Route, resources *deployResources) (*processRollout, error) {
+	rollout := &processRollout{started: make(map[string]*domain.Container)}
+	if len(route.Processes) == 0 && !s.hasTrackedProcesses(route.Domain) {
+		return rollout, nil
+	}
+
+	all, err := s.runtime.ListContainers(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list process containers: %w", err)
+	}
+	current := processContainersForDomain(all, route.Domain)
+
+	ctx, span := tracer.Start(ctx, "container.roll_processes")
+	defer span.End()
+	log := zerowrap.FromCtx(ctx)
+
+	imageID, err := s.runtime.GetImageID(ctx, resources.actualImageRef)
+	if err != nil {
+		log.Debug().Err(err).Msg("cannot resolve image ID, replacing all process containers")
+		imageID = ""
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(route.Processes)) {
+		command := route.Processes[name]
+		configHash := domain.ProcessConfigHash(command, route.Container)
+
+		var kept *domain.Container
+		for _, c := range current[name] {
+			if kept == nil && processUpToDate(c, imageID, resources.envHash, configHash) {
+				kept = c
+				continue
+			}
+			rollout.retired = append(rollout.retired, c)
+		}
+		if kept != nil {
+			rollout.started[name] = kept
+			continue
+		}
+
+		container, err := s.startProcessContainer(ctx, route, resources, name, command, current[name])
+		if err != nil {
+			s.abandonProcesses(ctx, rollout)
+			return nil, err
+		}
+		rollout.started[name] = container
+		rollout.created = append(rollout.created, container)
+	}
+
+	for name, containers := range current {
+		if _, declared := route.Processes[name]; !declared {
+			rollout.retired = append(rollout.retired, containers...)
+		}
+	}
+	return rollout, nil
+}
+
+// startProcessContainer creates and starts the container of one process and
+// waits until it runs.
+func (s *Service) startProcessContainer(ctx context.Context, route domain.Route, resources *deployResources, name string, command []string, current []*domain.Container) (*domain.Container, error) {
+	ctx = zerowrap.CtxWithField(ctx, "process", name)
+	log := zerowrap.FromCtx(ctx)
+
+	config := s.processContainerConfig(route, resources, name, command, current)
+	container, err := s.runtime.CreateContainer(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s process container: %w", name, err)
+	}
+	if container.Name == "" {
+		container.Name = config.Name
+	}
+	if err := s.runtime.StartContainer(ctx, container.ID); err != nil {
+		s.runtime.RemoveContainer(ctx, container.ID, true)
+		return nil, fmt.Errorf("failed to start %s process container: %w", name, err)
+	}
+	if !domain.IsSkipReadiness(ctx) {
+		if err := s.pollContainerRunning(ctx, container.ID); err != nil {
+			deployErr := s.newDeployFailure(container, fmt.Sprintf("%s process failed to start", name), err, s.captureRecentContainerLogs(ctx, container.ID))
+			s.cleanupFailedContainer(ctx, container.ID)
+			return nil, deployErr
+		}
+	}
+
+	inspected, err := s.runtime.InspectContainer(ctx, container.ID)
+	if err != nil {
+		s.cleanupFailedContainer(ctx, container.ID)
+		return nil, fmt.Errorf("failed to inspect %s process container: %w", name, err)
+	}
+	log.Info().Str(zerowrap.FieldEntityID, inspected.ID).Msg("process container started")
+	return inspected, nil
+}
+
+// abandonProcesses removes the containers a rollout created, leaving the
+// ones it would have retired in place.
+func (s *Service) abandonProcesses(ctx context.Context, rollout *processRollout) {
+	if rollout == nil {
+		return
+	}
+	for _, c := range rollout.created {
+		s.cleanupFailedContainer(ctx, c.ID)
+	}
+}
+
+// retireProcesses stops and removes the process containers a kept rollout
+// replaced.
+func (s *Service) retireProcesses(ctx context.Context, rollout *processRollout) {
+	log := zerowrap.FromCtx(ctx)
+	for _, c := range rollout.retired {
+		log.Info().Str(zerowrap.FieldEntityID, c.ID).Str("process", c.Labels[domain.LabelProcess]).Msg("removing replaced process container")
+		if err := s.runtime.StopContainer(ctx, c.ID); err != nil {
+			log.Warn().Err(err).Str(zerowrap.FieldEntityID, c.ID).Msg("failed to stop process container")
+		}
+		if err := s.runtime.RemoveContainer(ctx, c.ID, true); err != nil {
+			log.Warn().Err(err).Str(zerowrap.FieldEntityID, c.ID).Msg("failed to remove process container")
+		}
+	}
+}
+
+// trackProcesses replaces the tracked process containers of a route.
+func (s *Service) trackProcesses(domainName string, started map[string]*domain.Container) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.processes == nil {
+		s.processes = make(map[string]*domain.Container)
+	}
+	prefix := processKey(domainName, "")
+	for key := range s.processes {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.processes, key)
+		}
+	}
+	for name, c := range started {
+		s.processes[processKey(domainName, name)] = c
+	}
+}
+
+// hasTrackedProcesses reports whether any process container of a route is
+// tracked.
+func (s *Service) hasTrackedProcesses(domainName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for key := range s.processes {
+		if strings.HasPrefix(key, processKey(domainName, "")) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListProcesses returns the declared processes of route with the container
+// running each of them, followed by processes that are no longer declared
+// but still have a container.
+func (s *Service) ListProcesses(ctx context.Context, route domain.Route) []domain.Process {
+	log := zerowrap.FromCtx(ctx)
+
+	var current map[string][]*domain.Container
+	all, err := s.runtime.ListContainers(ctx, true)
+	if err != nil {
+		log.Warn().Err(err).Str("domain", route.Domain).Msg("failed to list process containers")
+	} else {
+		current = processContainersForDomain(all, route.Domain)
+	}
+
+	processes := make([]domain.Process, 0, len(route.Processes)+len(current))
+	for _, name := range slices.Sorted(maps.Keys(route.Processes)) {
+		process := domain.Process{Name: name, Command: route.Processes[name]}
+		if c := preferredProcessContainer(current[name]); c != nil {
+			process.ContainerID = c.ID
+			process.Status = c.Status
+			process.ConfigHash = c.Labels[domain.LabelConfigHash]
+		}
+		processes = append(processes, process)
+	}
+
+	var undeclared []string
+	for name := range current {
+		if _, declared := route.Processes[name]; !declared {
+			undeclared = append(undeclared, name)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, name := range undeclared {
+		c := preferredProcessContainer(current[name])
+		processes = append(processes, domain.Process{
+			Name:        name,
+			ContainerID: c.ID,
+			Status:      c.Status,
+			ConfigHash:  c.Labels[domain.LabelConfigHash],
+		})
+	}
+	return processes
+}
+
+// preferredProcessContainer picks the running container of a process when
+// a deploy left more than one.
+func preferredProcessContainer(containers []*domain.Container) *domain.Container {
+	var best *domain.Container
+	for _, c := range containers {
+		if best == nil || (c.Status == string(domain.ContainerStatusRunning) && best.Status != string(domain.ContainerStatusRunning)) {
+			best = c
+		}
+	}
+	return best
+}
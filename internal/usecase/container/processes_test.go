@@ -0,0 +1,158 @@
+package container
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func testProcessContainer(id, name, process, imageID, envHash, configHash string) *domain.Container {
+	return &domain.Container{
+		ID:      id,
+		Name:    name,
+		Status:  string(domain.ContainerStatusRunning),
+		ImageID: imageID,
+		Labels: map[string]string{
+			domain.LabelManaged:    "true",
+			domain.LabelRoute:      "app.example.com",
+			domain.LabelProcess:    process,
+			domain.LabelEnvHash:    envHash,
+			domain.LabelConfigHash: configHash,
+		},
+	}
+}
+
+func TestService_RollProcesses(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{
+		Domain: "app.example.com",
+		Image:  "app:v2",
+		Processes: map[string][]string{
+			"worker": {"bundle", "exec", "sidekiq"},
+			"clock":  {"bundle", "exec", "clockwork"},
+		},
+	}
+	resources := &deployResources{
+		networkName:    "gordon-app",
+		actualImageRef: "app:v2",
+		exposedPorts:   []int{3000},
+		envVars:        []string{"REDIS_URL=redis://redis"},
+		envHash:        "env-1",
+	}
+
+	clockHash := domain.ProcessConfigHash(route.Processes["clock"], nil)
+	clock := testProcessContainer("clock-1", "gordon-app.example.com-clock", "clock", "sha256:v2", "env-1", clockHash)
+	oldWorker := testProcessContainer("worker-1", "gordon-app.example.com-worker", "worker", "sha256:v1", "env-1", "old")
+	mailer := testProcessContainer("mailer-1", "gordon-app.example.com-mailer", "mailer", "sha256:v1", "env-1", "old")
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{clock, oldWorker, mailer, {ID: "web-1", Labels: map[string]string{domain.LabelManaged: "true", domain.LabelRoute: "app.example.com", domain.LabelDomain: "app.example.com"}}}, nil)
+	runtime.EXPECT().GetImageID(mock.Anything, "app:v2").Return("sha256:v2", nil)
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return assert.Equal(t, "gordon-app.example.com-worker-next", cfg.Name) &&
+			assert.Equal(t, []string{"bundle", "exec", "sidekiq"}, cfg.Cmd) &&
+			assert.Equal(t, "app:v2", cfg.Image) &&
+			assert.Equal(t, "gordon-app", cfg.NetworkMode) &&
+			assert.Equal(t, []string{"REDIS_URL=redis://redis"}, cfg.Env) &&
+			assert.Empty(t, cfg.Ports) &&
+			assert.Equal(t, domain.RestartPolicyAlways, cfg.RestartPolicy) &&
+			assert.Equal(t, "worker", cfg.Labels[domain.LabelProcess]) &&
+			assert.Equal(t, "app.example.com", cfg.Labels[domain.LabelRoute]) &&
+			assert.NotContains(t, cfg.Labels, domain.LabelDomain)
+	})).Return(&domain.Container{ID: "worker-2"}, nil).Once()
+	runtime.EXPECT().StartContainer(mock.Anything, "worker-2").Return(nil)
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "worker-2").Return(true, nil)
+	runtime.EXPECT().InspectContainer(mock.Anything, "worker-2").Return(&domain.Container{ID: "worker-2", Status: "running"}, nil)
+
+	rollout, err := svc.rollProcesses(testContext(), route, resources)
+	require.NoError(t, err)
+	assert.Equal(t, "clock-1", rollout.started["clock"].ID)
+	assert.Equal(t, "worker-2", rollout.started["worker"].ID)
+	assert.ElementsMatch(t, []*domain.Container{oldWorker, mailer}, rollout.retired)
+
+	runtime.EXPECT().StopContainer(mock.Anything, mock.Anything).Return(nil).Twice()
+	runtime.EXPECT().RemoveContainer(mock.Anything, "worker-1", true).Return(nil).Once()
+	runtime.EXPECT().RemoveContainer(mock.Anything, "mailer-1", true).Return(nil).Once()
+	svc.trackProcesses(route.Domain, rollout.started)
+	svc.retireProcesses(testContext(), rollout)
+	assert.Len(t, svc.processes, 2)
+	assert.Equal(t, "worker-2", svc.processes["app.example.com/worker"].ID)
+}
+
+func TestService_RollProcesses_FailureRemovesStartedContainers(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{
+		Domain: "app.example.com",
+		Image:  "app:v2",
+		Processes: map[string][]string{
+			"clock":  {"bundle", "exec", "clockwork"},
+			"worker": {"bundle", "exec", "sidekiq"},
+		},
+	}
+	resources := &deployResources{actualImageRef: "app:v2"}
+
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return(nil, nil)
+	runtime.EXPECT().GetImageID(mock.Anything, "app:v2").Return("sha256:v2", nil)
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return cfg.Labels[domain.LabelProcess] == "clock"
+	})).Return(&domain.Container{ID: "clock-1"}, nil)
+	runtime.EXPECT().StartContainer(mock.Anything, "clock-1").Return(nil)
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "clock-1").Return(true, nil)
+	runtime.EXPECT().InspectContainer(mock.Anything, "clock-1").Return(&domain.Container{ID: "clock-1", Status: "running"}, nil)
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return cfg.Labels[domain.LabelProcess] == "worker"
+	})).Return(nil, errors.New("no space left on device"))
+	runtime.EXPECT().StopContainer(mock.Anything, "clock-1").Return(nil).Once()
+	runtime.EXPECT().RemoveContainer(mock.Anything, "clock-1", true).Return(nil).Once()
+
+	_, err := svc.rollProcesses(testContext(), route, resources)
+	assert.ErrorContains(t, err, "failed to create worker process container")
+}
+
+func TestService_RollProcesses_SkipsRoutesWithoutProcesses(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+
+	rollout, err := svc.rollProcesses(testContext(), domain.Route{Domain: "app.example.com", Image: "app:v2"}, &deployResources{})
+	require.NoError(t, err)
+	assert.Empty(t, rollout.started)
+	assert.Empty(t, rollout.retired)
+}
+
+func TestService_ListProcesses(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{
+		Domain: "app.example.com",
+		Processes: map[string][]string{
+			"worker": {"bundle", "exec", "sidekiq"},
+			"clock":  {"bundle", "exec", "clockwork"},
+		},
+	}
+
+	stale := testProcessContainer("worker-1", "gordon-app.example.com-worker", "worker", "", "", "a")
+	stale.Status = string(domain.ContainerStatusExited)
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{
+		stale,
+		testProcessContainer("worker-2", "gordon-app.example.com-worker-next", "worker", "", "", "b"),
+		testProcessContainer("mailer-1", "gordon-app.example.com-mailer", "mailer", "", "", "c"),
+	}, nil)
+
+	assert.Equal(t, []domain.Process{
+		{Name: "clock", Command: []string{"bundle", "exec", "clockwork"}},
+		{Name: "worker", Command: []string{"bundle", "exec", "sidekiq"}, ContainerID: "worker-2", Status: "running", ConfigHash: "b"},
+		{Name: "mailer", ContainerID: "mailer-1", Status: "running", ConfigHash: "c"},
+	}, svc.ListProcesses(testContext(), route))
+}
+
+func TestProcessContainerName(t *testing.T) {
+	assert.Equal(t, "gordon-app.example.com-worker", processContainerName("app.example.com", "worker", nil))
+	assert.Equal(t, "gordon-app.example.com-worker-next", processContainerName("app.example.com", "worker", []*domain.Container{{Name: "gordon-app.example.com-worker"}}))
+	assert.Equal(t, "gordon-app.example.com-worker", processContainerName("app.example.com", "worker", []*domain.Container{{Name: "gordon-app.example.com-worker-next"}}))
+}
@@ -48,6 +48,8 @@ type Config struct {
 	NetworkGroups              map[string][]string
 	NetworkInternal            bool
 	Attachments                map[string][]string
+	AttachmentReadiness        map[string]domain.AttachmentReadiness  // Declared readiness by attachment service name
+	AttachmentTLS              map[string]domain.StandaloneServiceTLS // Managed certificates by attachment service name
 	AllowedRegistries          []string
 	RequireImageDigest         bool
 	SecurityProfile            string
@@ -62,6 +64,14 @@ type Config struct {
 	TCPProbeTimeout            time.Duration // TCP probe timeout (default 30s)
 	HTTPProbeTimeout           time.Duration // HTTP probe timeout (default 60s)
 	AttachmentReadinessTimeout time.Duration // Max wait for attachment readiness (default 30s)
+	LivenessEnabled            bool          // Probe running route containers over HTTP and restart hung ones
+	LivenessPath               string        // Probe path for images without a gordon.health label ("" = skip them)
+	LivenessInterval           time.Duration // Time between liveness probes (default 30s)
+	LivenessTimeout            time.Duration // Per-probe timeout (default 5s)
+	LivenessFailureThreshold   int           // Consecutive failed probes before a restart (default 3)
+	StatsEnabled               bool          // Sample resource usage of managed containers
+	StatsInterval              time.Duration // Time between resource samples (default 10s)
+	StatsHistory               int           // Samples kept per container (default 60)
 	DefaultMemoryLimit         int64         // Default memory limit in bytes for containers (0 = no limit)
 	DefaultNanoCPUs            int64         // Default CPU quota in nanoseconds for containers (0 = no limit)
 	DefaultPidsLimit           int64         // Default max PIDs for containers (0 = no limit)
@@ -91,16 +101,19 @@ type Service struct {
 	logWriter        out.ContainerLogWriter
 	cacheInvalidator out.ProxyCacheInvalidator
 	drainWaiter      out.ProxyDrainWaiter
+	ca               out.CertificateAuthority // issues attachment certificates (may be nil)
 	config           Config
 	configProvider   AttachmentConfigProvider // live config reads for attachments/networks (may be nil)
 	metrics          *telemetry.Metrics
 	containers       map[string]*domain.Container
-	attachments      map[string][]string // ownerDomain → []containerIDs
-	managedCount     int64               // tracks UpDownCounter value for delta computation
+	attachments      map[string][]string          // ownerDomain → []containerIDs
+	processes        map[string]*domain.Container // "domain/process" → container
+	managedCount     int64                        // tracks UpDownCounter value for delta computation
 	mu               sync.RWMutex
 	deployMu         sync.Map       // per-domain deploy locks (domain → *domainDeployLock)
 	cleanupWg        sync.WaitGroup // tracks background old-container cleanup goroutines
 	monitor          *Monitor
+	stats            *statsSampler
 }
 
 // domainDeployLock is a context-aware mutex using a buffered channel.
@@ -165,6 +178,7 @@ func NewService(
 		configProvider: configProvider,
 		containers:     make(map[string]*domain.Container),
 		attachments:    make(map[string][]string),
+		processes:      make(map[string]*domain.Container),
 	}
 }
 
@@ -241,6 +255,8 @@ type containerConfigInput struct {
 	Volumes      map[string]string
 	NetworkName  string
 	ImageLabels  map[string]string
+	Overrides    *domain.ContainerOverrides
+	ConfigHash   string
 	Existing     *domain.Container
 }
 
@@ -257,6 +273,9 @@ func (s *Service) buildContainerConfig(in containerConfigInput) *domain.Containe
 		domain.LabelManaged: "true",
 		domain.LabelRoute:   in.Domain,
 	}
+	if in.ConfigHash != "" {
+		labels[domain.LabelConfigHash] = in.ConfigHash
+	}
 
 	// Propagate proxy/port labels from image so readiness probes can find them
 	for _, key := range []string{domain.LabelProxyPort, domain.LabelPort, domain.LabelHealth} {
@@ -292,6 +311,7 @@ func (s *Service) buildContainerConfig(in containerConfigInput) *domain.Containe
 		PidsLimit:     cfg.DefaultPidsLimit,
 	}
 	applySecurityProfile(containerConfig, cfg)
+	applyContainerOverrides(containerConfig, in.Overrides)
 	return containerConfig
 }
 
@@ -313,7 +333,11 @@ func (s *Service) Deploy(ctx context.Context, route domain.Route) (*domain.Conta
 	if err != nil {
 		return nil, err
 	}
-	defer unlock()
+	unlocked := make(chan struct{})
+	defer func() {
+		unlock()
+		close(unlocked)
+	}()
 
 	// Enrich context with use case fields for all downstream logs
 	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
@@ -347,17 +371,41 @@ func (s *Service) Deploy(ctx context.Context, route domain.Route) (*domain.Conta
 			existingForSkip = s.containerForRedundantCheck(ctx, existingForSkip)
 		}
 		if existingForSkip.ImageID != "" {
-			if skip, container := s.skipRedundantDeploy(ctx, existingForSkip, resources.actualImageRef, resources.envHash); skip {
+			if skip, container := s.skipRedundantDeploy(ctx, existingForSkip, resources.actualImageRef, resources.envHash, resources.configHash); skip {
+				// The web container is current, but a process may have been
+				// added, removed or given a new command.
+				rollout, rollErr := s.rollProcesses(ctx, route, resources)
+				if rollErr != nil {
+					err = rollErr
+					return nil, err
+				}
+				s.trackProcesses(route.Domain, rollout.started)
+				s.retireProcesses(ctx, rollout)
 				return container, nil
 			}
 		}
 	}
 
+	// Release commands (e.g. migrations) run with the new image before any
+	// traffic moves; a failure leaves the old container serving.
+	if err = s.runDeployHook(ctx, route, domain.DeployHookRelease, resources, existing); err != nil {
+		return nil, err
+	}
+
 	newContainer, err := s.createStartedContainer(ctx, route, existing, resources)
 	if err != nil {
 		return nil, err
 	}
 
+	// Process containers run the same image, so they roll together with the
+	// web container; one that fails to start fails the deploy before any
+	// traffic moves.
+	rollout, err := s.rollProcesses(ctx, route, resources)
+	if err != nil {
+		s.cleanupFailedContainer(ctx, newContainer.ID)
+		return nil, err
+	}
+
 	invalidated := s.activateDeployedContainer(ctx, route.Domain, newContainer)
 
 	// Post-switch stabilization: verify new container stays running
@@ -367,13 +415,17 @@ func (s *Service) Deploy(ctx context.Context, route domain.Route) (*domain.Conta
 			// Both old and new containers are dead; assign to named return so
 			// the deferred recordDeployMetrics and span see the failure.
 			err = stabilizeErr
+			s.abandonProcesses(ctx, rollout)
 			return nil, err
 		}
 		if !stable {
-			// Rollback performed — old container is restored
+			// Rollback performed — old container is restored, and so are
+			// the old process containers.
+			s.abandonProcesses(ctx, rollout)
 			return existing, nil
 		}
 	}
+	s.trackProcesses(route.Domain, rollout.started)
 
 	// Finalize old container in the background — the new container is already
 	// serving traffic, so there's no reason to block the deploy response while
@@ -383,11 +435,14 @@ func (s *Service) Deploy(ctx context.Context, route domain.Route) (*domain.Conta
 	go func() {
 		defer s.cleanupWg.Done()
 		s.finalizePreviousContainer(context.WithoutCancel(ctx), route.Domain, existing, hasExisting, invalidated, newContainer.ID)
+		s.retireProcesses(context.WithoutCancel(ctx), rollout)
 	}()
 
 	// Start container log collection (non-blocking, errors don't fail deployment)
 	s.startLogCollection(ctx, newContainer.ID, route.Domain)
 
+	s.startPostDeployHook(ctx, route, resources, existing, unlocked)
+
 	log.Info().
 		Str("image", route.Image).
 		Str(zerowrap.FieldEntityID, newContainer.ID).
@@ -427,7 +482,7 @@ func (s *Service) recordDeployMetrics(ctx context.Context, span trace.Span, rout
 // When a push triggers both an event-based deploy and an explicit CLI deploy,
 // the second one arrives after the first has already completed; this avoids
 // a full redundant create-start-readiness cycle.
-func (s *Service) skipRedundantDeploy(ctx context.Context, existing *domain.Container, actualImageRef, envHash string) (bool, *domain.Container) {
+func (s *Service) skipRedundantDeploy(ctx context.Context, existing *domain.Container, actualImageRef, envHash, configHash string) (bool, *domain.Container) {
 	log := zerowrap.FromCtx(ctx)
 
 	newImageID, err := s.runtime.GetImageID(ctx, actualImageRef)
@@ -455,6 +510,12 @@ func (s *Service) skipRedundantDeploy(ctx context.Context, existing *domain.Cont
 				Msg("env changed, proceeding with deploy despite same image")
 			return false, nil
 		}
+		if existing.Labels[domain.LabelConfigHash] != configHash {
+			log.Info().
+				Str("container_id", existing.ID).
+				Msg("container settings changed, proceeding with deploy despite same image")
+			return false, nil
+		}
 	} else {
 		return false, nil
 	}
@@ -492,6 +553,9 @@ type deployResources struct {
 	actualImageRef string
 	exposedPorts   []int
 	imageLabels    map[string]string
+	overrides      *domain.ContainerOverrides
+	configHash     string
+	hooks          *domain.DeployHooks
 	envVars        []string
 	envHash        string
 	volumes        map[string]string
@@ -589,6 +653,15 @@ func (s *Service) prepareDeployResources(ctx context.Context, route domain.Route
 		return nil, log.WrapErr(err, "failed to deploy attachments")
 	}
 
+	return s.routeImageResources(ctx, route, networkName, actualImageRef)
+}
+
+// routeImageResources gathers what a container of route needs from the local
+// image actualImageRef: exposed ports, labels and their overrides, the merged
+// environment and the route volumes.
+func (s *Service) routeImageResources(ctx context.Context, route domain.Route, networkName, actualImageRef string) (*deployResources, error) {
+	log := zerowrap.FromCtx(ctx)
+
 	exposedPorts, err := s.runtime.GetImageExposedPorts(ctx, actualImageRef)
 	if err != nil {
 		log.WrapErr(err, "failed to get exposed ports, using defaults")
@@ -600,6 +673,16 @@ func (s *Service) prepareDeployResources(ctx context.Context, route domain.Route
 		log.Warn().Err(err).Msg("failed to get image labels, skipping label propagation")
 		imageLabels = nil
 	}
+	labelOverrides, err := containerOverridesFromLabels(imageLabels)
+	if err != nil {
+		log.Warn().Err(err).Msg("ignoring invalid container override labels")
+		labelOverrides = nil
+	}
+	labelHooks, err := deployHooksFromLabels(imageLabels)
+	if err != nil {
+		log.Warn().Err(err).Msg("ignoring invalid deploy hook labels")
+		labelHooks = nil
+	}
 
 	envVars, err := s.loadEnvironment(ctx, route.Env, route.Domain, actualImageRef)
 	if err != nil {
@@ -617,19 +700,19 @@ func (s *Service) prepareDeployResources(ctx context.Context, route domain.Route
 		actualImageRef: actualImageRef,
 		exposedPorts:   exposedPorts,
 		imageLabels:    imageLabels,
+		overrides:      labelOverrides.Merge(route.Container),
+		configHash:     route.Container.ConfigHash(),
+		hooks:          labelHooks.Merge(route.Hooks),
 		envVars:        envVars,
 		envHash:        envHash,
 		volumes:        volumes,
 	}, nil
 }
 
-func (s *Service) createStartedContainer(ctx context.Context, route domain.Route, existing *domain.Container, resources *deployResources) (*domain.Container, error) {
-	ctx, span := tracer.Start(ctx, "container.create_and_start")
-	defer span.End()
-
-	log := zerowrap.FromCtx(ctx)
-
-	containerConfig := s.buildContainerConfig(containerConfigInput{
+// deployContainerConfig builds the configuration of the route container for
+// the prepared deploy resources.
+func (s *Service) deployContainerConfig(route domain.Route, existing *domain.Container, resources *deployResources) *domain.ContainerConfig {
+	return s.buildContainerConfig(containerConfigInput{
 		Domain:       route.Domain,
 		Image:        route.Image,
 		ImageRef:     resources.actualImageRef,
@@ -639,8 +722,19 @@ func (s *Service) createStartedContainer(ctx context.Context, route domain.Route
 		Volumes:      resources.volumes,
 		NetworkName:  resources.networkName,
 		ImageLabels:  resources.imageLabels,
+		Overrides:    resources.overrides,
+		ConfigHash:   resources.configHash,
 		Existing:     existing,
 	})
+}
+
+func (s *Service) createStartedContainer(ctx context.Context, route domain.Route, existing *domain.Container, resources *deployResources) (*domain.Container, error) {
+	ctx, span := tracer.Start(ctx, "container.create_and_start")
+	defer span.End()
+
+	log := zerowrap.FromCtx(ctx)
+
+	containerConfig := s.deployContainerConfig(route, existing, resources)
 
 	newContainer, err := s.runtime.CreateContainer(ctx, containerConfig)
 	if err != nil {
@@ -879,12 +973,23 @@ func (s *Service) activateDeployedContainer(ctx context.Context, domainName stri
 	s.mu.RUnlock()
 	if inv != nil {
 		inv.InvalidateTarget(ctx, domainName)
+		purgeResponseCache(ctx, inv, domainName)
 		return true
 	}
 
 	return false
 }
 
+// purgeResponseCache drops the responses the proxy cached for domainName.
+// It runs when the container serving a route changes image, not on restarts
+// or sleep, so a route served from cache keeps its entries.
+func purgeResponseCache(ctx context.Context, inv out.ProxyCacheInvalidator, domainName string) {
+	if _, err := inv.PurgeResponseCache(ctx, domainName, ""); err != nil && !errors.Is(err, domain.ErrResponseCacheUnavailable) {
+		log := zerowrap.FromCtx(ctx)
+		log.Warn().Err(err).Str("domain", domainName).Msg("failed to purge response cache")
+	}
+}
+
 // stabilizeNewContainer monitors the new container briefly after traffic switch.
 // If it crashes during this window, rolls back to old container.
 // Returns true if stabilization succeeded, false if rollback was performed.
@@ -948,6 +1053,7 @@ func (s *Service) stabilizeNewContainer(ctx context.Context, domainName string,
 		s.mu.RUnlock()
 		if inv != nil {
 			inv.InvalidateTarget(ctx, domainName)
+			purgeResponseCache(ctx, inv, domainName)
 		}
 
 		// Cleanup failed new container
@@ -1079,6 +1185,15 @@ func (s *Service) Restart(ctx context.Context, domainName string, withAttachment
 		return domain.ErrContainerNotFound
 	}
 
+	// A sleeping container is stopped; restarting it means waking it up.
+	if isSleepingContainer(container) {
+		if _, err := s.Wake(ctx, domainName); err != nil {
+			return err
+		}
+		log.Info().Str(zerowrap.FieldEntityID, container.ID).Msg("sleeping container woken by restart")
+		return nil
+	}
+
 	// When attachments are requested, sync runtime state first to get accurate
 	// attachment tracking, then check if configured attachments are deployed.
 	// If fewer attachment containers are tracked than configured, fail early with
@@ -1245,6 +1360,7 @@ func (s *Service) ReconcileRemovedRoute(ctx context.Context, domainName string)
 	s.clearRouteContainerTracking(ctx, domainName)
 	if inv := s.proxyCacheInvalidator(); inv != nil {
 		inv.InvalidateTarget(ctx, domainName)
+		purgeResponseCache(ctx, inv, domainName)
 	}
 
 	report.PreservedAttachments = preservedAttachmentsForDomain(allContainers, domainName)
@@ -1359,6 +1475,11 @@ func (s *Service) clearRouteContainerTracking(ctx context.Context, domainName st
 		}
 		removed = true
 	}
+	for key := range s.processes {
+		if strings.HasPrefix(key, processKey(domainName, "")) {
+			delete(s.processes, key)
+		}
+	}
 	s.mu.Unlock()
 
 	if removed && s.metrics != nil {
@@ -1746,6 +1867,25 @@ func isTrackedManagedContainerStatus(status string) bool {
 	return status == "" || status == string(domain.ContainerStatusRunning) || status == "restarting"
 }
 
+// preserveSleepingContainers keeps sleeping route containers tracked across a
+// sync. The runtime reports them as stopped, which would otherwise drop them.
+func preserveSleepingContainers(managed, previous map[string]*domain.Container, all []*domain.Container) {
+	for domainName, tracked := range previous {
+		if !isSleepingContainer(tracked) {
+			continue
+		}
+		if _, exists := managed[domainName]; exists {
+			continue
+		}
+		for _, c := range all {
+			if c.ID == tracked.ID {
+				managed[domainName] = tracked
+				break
+			}
+		}
+	}
+}
+
 func (s *Service) SyncContainers(ctx context.Context) error {
 	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
 		zerowrap.FieldLayer:   "usecase",
@@ -1763,6 +1903,7 @@ func (s *Service) SyncContainers(ctx context.Context) error {
 
 	managed := make(map[string]*domain.Container)
 	attachments := make(map[string][]string)
+	processes := make(map[string]*domain.Container)
 	for _, c := range allContainers {
 		if !isTrackedManagedContainerStatus(c.Status) {
 			continue
@@ -1770,6 +1911,10 @@ func (s *Service) SyncContainers(ctx context.Context) error {
 		if c.Labels == nil || c.Labels[domain.LabelManaged] != "true" {
 			continue
 		}
+		if name := c.Labels[domain.LabelProcess]; name != "" {
+			processes[processKey(c.Labels[domain.LabelRoute], name)] = c
+			continue
+		}
 		if c.Labels[domain.LabelAttachment] == "true" {
 			owner := c.Labels[domain.LabelAttachedTo]
 			if owner != "" {
@@ -1783,8 +1928,10 @@ func (s *Service) SyncContainers(ctx context.Context) error {
 	}
 
 	s.mu.Lock()
+	preserveSleepingContainers(managed, s.containers, allContainers)
 	s.containers = managed
 	s.attachments = attachments
+	s.processes = processes
 	newCount := int64(len(managed))
 	delta := newCount - s.managedCount
 	s.managedCount = newCount
@@ -2649,6 +2796,27 @@ func (s *Service) cleanupOrphanedContainers(ctx context.Context, domainName stri
 	}
 
 	for _, c := range allContainers {
+		if c.Labels[domain.LabelHookRoute] == domainName {
+			// A post-deploy command of the previous deploy may still be
+			// running after its deploy returned; let it finish.
+			if c.Labels[domain.LabelHook] == string(domain.DeployHookPostDeploy) && c.Status == "running" {
+				continue
+			}
+			// Deploy hook containers are removed once they exit; one still
+			// present was left behind by an interrupted deploy.
+			log.Info().Str(zerowrap.FieldEntityID, c.ID).Str("container_name", c.Name).Msg("found leftover deploy hook container, removing")
+			s.cleanupFailedContainer(ctx, c.ID)
+			continue
+		}
+		if c.Labels[domain.LabelTask] == domainName {
+			// Task containers are removed once they exit; an exited one was
+			// left behind by a Gordon restart. Running tasks are kept.
+			if c.Status != "running" {
+				log.Info().Str(zerowrap.FieldEntityID, c.ID).Str("container_name", c.Name).Msg("found leftover task container, removing")
+				s.cleanupFailedContainer(ctx, c.ID)
+			}
+			continue
+		}
 		if (c.Name == expectedName || c.Name == expectedNewName || c.Name == expectedNextName) && c.ID != skipContainerID {
 			// Without a selected active container, preserve anything that may still
 			// be serving traffic. When an active container is selected, other active
@@ -2716,9 +2884,12 @@ func (s *Service) deployAttachments(ctx context.Context, domainName, networkName
 	}
 
 	log := zerowrap.FromCtx(ctx)
-	var deployed []string // track successfully deployed attachment IDs for rollback
+	// Only containers this deploy created are rolled back; reused attachments
+	// keep running even when one of them fails its readiness check.
+	var deployed []string
 	for _, svc := range attachments {
-		if err := s.deployAttachedService(ctx, domainName, svc, networkName); err != nil {
+		createdID, err := s.deployAttachedService(ctx, domainName, svc, networkName)
+		if err != nil {
 			log.WrapErrWithFields(err, "failed to deploy attachment", map[string]any{zerowrap.FieldService: svc, "domain": domainName})
 
 			// Rollback: clean up already-deployed attachments
@@ -2750,21 +2921,19 @@ func (s *Service) deployAttachments(ctx context.Context, domainName, networkName
 
 			return fmt.Errorf("failed to deploy attachment %q (rolled back %d already-deployed)", svc, len(deployed))
 		}
-		// Collect IDs of attachments tracked under this domain after successful deploy
-		s.mu.RLock()
-		ids := s.attachments[domainName]
-		if len(ids) > 0 {
-			latest := ids[len(ids)-1]
-			deployed = append(deployed, latest)
+		if createdID != "" {
+			deployed = append(deployed, createdID)
 		}
-		s.mu.RUnlock()
 	}
 	return nil
 }
 
-// resolveAttachmentsForDomain returns attachments for a domain by checking:
-// 1. Direct domain attachments (attachments[domain])
-// 2. Network group attachments (attachments[group] where domain is in network_groups[group])
+// resolveAttachmentsForDomain returns attachments for a domain in the order
+// they are started:
+// 1. Network group attachments (attachments[group] where domain is in network_groups[group]),
+// since they are shared dependencies the domain's own attachments may need
+// 2. Direct domain attachments (attachments[domain])
+// Each list keeps its declared order.
 func (s *Service) resolveAttachmentsForDomain(domainName string) []string {
 	var attachments map[string][]string
 	var networkGroups map[string][]string
@@ -2782,10 +2951,8 @@ func (s *Service) resolveAttachmentsForDomain(domainName string) []string {
 
 	seen := make(map[string]bool)
 	var result []string
-
-	// First, add domain-specific attachments
-	if domainAttachments, ok := attachments[domainName]; ok {
-		for _, img := range domainAttachments {
+	add := func(images []string) {
+		for _, img := range images {
 			if !seen[img] {
 				seen[img] = true
 				result = append(result, img)
@@ -2793,21 +2960,17 @@ func (s *Service) resolveAttachmentsForDomain(domainName string) []string {
 		}
 	}
 
-	// Then, find which network group this domain belongs to and add group attachments
+	// First, find which network group this domain belongs to and add group attachments
 	for groupName, domains := range networkGroups {
 		if slices.Contains(domains, domainName) {
-			if groupAttachments, ok := attachments[groupName]; ok {
-				for _, img := range groupAttachments {
-					if !seen[img] {
-						seen[img] = true
-						result = append(result, img)
-					}
-				}
-			}
+			add(attachments[groupName])
 			break // Domain can only be in one network group
 		}
 	}
 
+	// Then, add domain-specific attachments
+	add(attachments[domainName])
+
 	return result
 }
 
@@ -2903,7 +3066,10 @@ func (s *Service) filterAttachments(ctx context.Context, containers []*domain.Co
 	return attachments
 }
 
-func (s *Service) deployAttachedService(ctx context.Context, ownerDomain, serviceImage, networkName string) error {
+// deployAttachedService makes sure one attachment of ownerDomain is running
+// and ready. It returns the ID of the container it created, or "" when a
+// running attachment was reused.
+func (s *Service) deployAttachedService(ctx context.Context, ownerDomain, serviceImage, networkName string) (string, error) {
 	log := zerowrap.FromCtx(ctx)
 
 	// Parse service name from image (e.g., "my-postgres:latest" → "postgres")
@@ -2920,16 +3086,23 @@ func (s *Service) deployAttachedService(ctx context.Context, ownerDomain, servic
 	// Try new name first, then fall back to legacy name for backwards compatibility
 	existingContainer, err := s.resolveExistingAttachment(ctx, ownerDomain, serviceName)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	if existingContainer != nil {
 		shouldSkip, err := s.handleRunningAttachment(ctx, existingContainer, containerName, serviceImage)
 		if err != nil {
-			return err
+			return "", err
 		}
 		if shouldSkip {
-			return nil
+			if tlsCfg, ok := s.attachmentTLS(serviceName); ok {
+				if err := s.ensureAttachmentCertificate(ctx, existingContainer.ID, serviceName, tlsCfg, true); err != nil {
+					return "", err
+				}
+			}
+			// A running attachment may still be starting up, e.g. when a
+			// previous deploy failed. Check it again before the route starts.
+			return "", s.waitForReusedAttachment(ctx, existingContainer.ID, serviceName)
 		}
 		if existingContainer.Status == string(domain.ContainerStatusRunning) {
 			existingContainer = nil
@@ -2937,35 +3110,43 @@ func (s *Service) deployAttachedService(ctx context.Context, ownerDomain, servic
 	}
 
 	if err := s.removeStoppedAttachment(ctx, existingContainer, containerName); err != nil {
-		return err
+		return "", err
 	}
 
 	log.Info().Str(zerowrap.FieldService, serviceImage).Msg("deploying attached service")
 
 	config, err := s.buildAttachmentContainerConfig(ctx, ownerDomain, serviceName, serviceImage, containerName, networkName)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	container, err := s.runtime.CreateContainer(ctx, config)
 	if err != nil {
-		return log.WrapErr(err, "failed to create attachment container")
+		return "", log.WrapErr(err, "failed to create attachment container")
+	}
+
+	// The certificate must be in place before the service first reads it.
+	if tlsCfg, ok := s.attachmentTLS(serviceName); ok {
+		if err := s.installAttachmentCertificate(ctx, container.ID, serviceName, tlsCfg); err != nil {
+			s.runtime.RemoveContainer(ctx, container.ID, true)
+			return "", err
+		}
 	}
 
 	// Start container
 	if err := s.runtime.StartContainer(ctx, container.ID); err != nil {
 		s.runtime.RemoveContainer(ctx, container.ID, true)
-		return log.WrapErr(err, "failed to start attachment container")
+		return "", log.WrapErr(err, "failed to start attachment container")
 	}
 
 	// Wait for attachment to be ready before proceeding
-	if err := s.waitForAttachmentReady(ctx, container.ID, config); err != nil {
+	if err := s.waitForAttachment(ctx, container.ID, serviceName, config); err != nil {
 		log.WrapErr(err, "attachment readiness check failed, cleaning up")
 		if stopErr := s.runtime.StopContainer(ctx, container.ID); stopErr != nil {
 			log.Warn().Err(stopErr).Str(zerowrap.FieldEntityID, container.ID).Msg("failed to stop unready attachment")
 		}
 		s.runtime.RemoveContainer(ctx, container.ID, true)
-		return fmt.Errorf("attachment %q not ready: %w", serviceName, err)
+		return "", fmt.Errorf("attachment %q not ready: %w", serviceName, err)
 	}
 	// Track attachment
 	s.mu.Lock()
@@ -2976,7 +3157,7 @@ func (s *Service) deployAttachedService(ctx context.Context, ownerDomain, servic
 	s.startLogCollection(ctx, container.ID, containerName)
 
 	log.Info().Str(zerowrap.FieldEntityID, container.ID).Msg("attachment deployed successfully")
-	return nil
+	return container.ID, nil
 }
 
 func (s *Service) buildAttachmentContainerConfig(ctx context.Context, ownerDomain, serviceName, serviceImage, containerName, networkName string) (*domain.ContainerConfig, error) {
@@ -2998,6 +3179,13 @@ func (s *Service) buildAttachmentContainerConfig(ctx context.Context, ownerDomai
 	if err != nil {
 		return nil, err
 	}
+	if tlsCfg, ok := s.attachmentTLS(serviceName); ok {
+		tlsVolume, err := s.attachmentTLSVolume(ctx, containerName, tlsCfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		volumes[tlsCfg.Path] = tlsVolume
+	}
 
 	s.mu.RLock()
 	cfg := s.config
@@ -3024,6 +3212,9 @@ func (s *Service) buildAttachmentContainerConfig(ctx context.Context, ownerDomai
 		PidsLimit:     cfg.DefaultPidsLimit,
 		RestartPolicy: domain.RestartPolicyAlways,
 	}
+	if tlsHash := s.attachmentTLSHash(serviceName); tlsHash != "" {
+		config.Labels[domain.LabelConfigHash] = tlsHash
+	}
 	applySecurityProfile(config, cfg)
 	return config, nil
 }
@@ -3090,7 +3281,11 @@ func (s *Service) handleRunningAttachment(ctx context.Context, existing *domain.
 		return false, log.WrapErr(err, "failed to check attachment env drift")
 	}
 
-	if !envDrifted && !imageDrifted {
+	// Check certificate settings drift; the TLS volume is only mounted at
+	// create time.
+	tlsDrifted := existing.Labels[domain.LabelConfigHash] != s.attachmentTLSHash(extractServiceName(serviceImage))
+
+	if !envDrifted && !imageDrifted && !tlsDrifted {
 		log.Debug().Str("container_name", containerName).Msg("attachment already running with current env and image, skipping")
 		return true, nil
 	}
@@ -3101,6 +3296,9 @@ func (s *Service) handleRunningAttachment(ctx context.Context, existing *domain.
 	if envDrifted {
 		log.Info().Str("container_name", containerName).Msg("attachment env changed, recreating")
 	}
+	if tlsDrifted {
+		log.Info().Str("container_name", containerName).Msg("attachment tls settings changed, recreating")
+	}
 
 	if err := s.runtime.StopContainer(ctx, existing.ID); err != nil {
 		return false, log.WrapErr(err, "failed to stop attachment for drift update")
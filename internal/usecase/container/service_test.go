@@ -172,6 +172,22 @@ func TestService_ResolveAttachments_GroupBasedViaProvider(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestService_ResolveAttachments_GroupFirstInDeclaredOrder(t *testing.T) {
+	svc := NewService(nil, nil, nil, nil, Config{
+		Attachments: map[string][]string{
+			"backend":         {"postgres:16", "redis:7"},
+			"app.example.com": {"migrator:latest", "postgres:16", "search:1"},
+			"api.example.com": {"other:1"},
+		},
+		NetworkGroups: map[string][]string{
+			"backend": {"app.example.com", "api.example.com"},
+		},
+	}, nil)
+
+	result := svc.resolveAttachmentsForDomain("app.example.com")
+	assert.Equal(t, []string{"postgres:16", "redis:7", "migrator:latest", "search:1"}, result)
+}
+
 func TestService_GetNetworkForApp_UsesLiveConfigProvider(t *testing.T) {
 	provider := mocks.NewMockAttachmentConfigProvider(t)
 	provider.EXPECT().GetAttachmentConfig().Return(out.AttachmentConfigSnapshot{
@@ -812,6 +828,7 @@ func TestService_Deploy_ReplacesExistingContainer(t *testing.T) {
 
 	// Synchronous cache invalidation before old container cleanup
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// Now cleanup old container (after new one is ready + cache invalidated + stabilization + drain delay)
 	runtime.EXPECT().StopContainer(mock.Anything, "old-container").Return(nil)
@@ -908,6 +925,7 @@ func TestService_Deploy_ResolvesExistingFromRuntime_WhenMemoryStale(t *testing.T
 
 	// Synchronous cache invalidation
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// AFTER new container is ready: stop and remove old runtime-discovered container
 	// This is the key assertion — StopContainer on the runtime-active container
@@ -2475,6 +2493,7 @@ func TestService_Deploy_OrphanCleanupSkipsTrackedContainer(t *testing.T) {
 
 	// Synchronous cache invalidation
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// NOW (after new container is ready) the old container should be stopped and removed
 	// This is the correct zero-downtime sequence - not during orphan cleanup
@@ -2640,6 +2659,7 @@ func TestService_Deploy_OrphanCleanupRemovesRunningStaleCandidate(t *testing.T)
 
 	// Synchronous cache invalidation
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	runtime.EXPECT().StopContainer(mock.Anything, "tracked-container-123").Return(nil)
 	runtime.EXPECT().RemoveContainer(mock.Anything, "tracked-container-123", true).Return(nil)
@@ -2705,6 +2725,7 @@ func TestService_Deploy_TrackedTempContainerUsesAlternateTempName(t *testing.T)
 
 	// Synchronous cache invalidation
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	runtime.EXPECT().StopContainer(mock.Anything, "tracked-temp-container").Return(nil)
 	runtime.EXPECT().RemoveContainer(mock.Anything, "tracked-temp-container", true).Return(nil)
@@ -2926,6 +2947,7 @@ func TestService_Deploy_ConcurrentSameDomain(t *testing.T) {
 
 	// Both deploys call InvalidateTarget (to ensure proxy picks up the new container).
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return().Times(2)
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil).Times(2)
 
 	// which means it will also stop+remove+rename the old container.
 	runtime.EXPECT().StopContainer(mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
@@ -3043,6 +3065,7 @@ func TestService_Deploy_CacheInvalidationBeforeOldContainerStop(t *testing.T) {
 			callOrder = append(callOrder, "invalidate_cache")
 			orderMu.Unlock()
 		}).Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// Track: stop should happen after invalidation
 	runtime.EXPECT().StopContainer(mock.Anything, "old-container").
@@ -3288,6 +3311,7 @@ func TestService_Deploy_DoesNotSkipWhenImageIDDiffers(t *testing.T) {
 	}, nil)
 	eventBus.EXPECT().Publish(domain.EventContainerDeployed, mock.AnythingOfType("*domain.ContainerEventPayload")).Return(nil)
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 	runtime.EXPECT().StopContainer(mock.Anything, "old-container").Return(nil)
 	runtime.EXPECT().RemoveContainer(mock.Anything, "old-container", true).Return(nil)
 	runtime.EXPECT().RenameContainer(mock.Anything, "new-container", "gordon-test.example.com").Return(nil)
@@ -3776,6 +3800,7 @@ func TestService_Deploy_RollbackOnPostSwitchCrash(t *testing.T) {
 
 	// InvalidateTarget called TWICE: once during activate, once during rollback
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return().Times(2)
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil).Times(2)
 
 	// Cleanup failed new container during rollback
 	runtime.EXPECT().StopContainer(mock.Anything, "new-container").Return(nil)
@@ -3865,6 +3890,7 @@ func TestService_Deploy_StabilizationSuccess(t *testing.T) {
 
 	// Cache invalidation (once, during activate)
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// Old container finalized normally
 	runtime.EXPECT().StopContainer(mock.Anything, "old-container").Return(nil)
@@ -3957,6 +3983,7 @@ func TestService_Deploy_ZeroDowntime_OldNeverStoppedBeforeNewReady(t *testing.T)
 
 	// Synchronous cache invalidation
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "test.example.com").Return()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "test.example.com", "").Return(0, nil)
 
 	// STRICT ORDERING: StopContainer on old container must NOT happen before
 	// InspectContainer on new container (readiness completion)
@@ -4423,7 +4450,7 @@ func TestDeployAttachedService_SetsAliasOnContainerConfig(t *testing.T) {
 	// TCP probe: resolve endpoint fails -> falls back to delay
 	runtime.EXPECT().GetContainerNetworkInfo(mock.Anything, "pg-container-1").Return("", 0, errors.New("no network"))
 
-	err := svc.deployAttachedService(ctx, ownerDomain, serviceImage, networkName)
+	_, err := svc.deployAttachedService(ctx, ownerDomain, serviceImage, networkName)
 	require.NoError(t, err)
 
 	// Verify the attachment is tracked
@@ -4551,6 +4578,35 @@ func TestService_BuildContainerConfig_CompatSecurityProfileDefault(t *testing.T)
 	assert.Nil(t, cfg.CapAdd)
 }
 
+func TestService_BuildContainerConfig_AppliesContainerOverrides(t *testing.T) {
+	svc := NewService(nil, nil, nil, nil, Config{SecurityProfile: "strict", DefaultMemoryLimit: 128 << 20, DefaultNanoCPUs: 500_000_000, DefaultPidsLimit: 100}, nil)
+	overrides := &domain.ContainerOverrides{
+		MemoryLimit: 4 << 30,
+		Command:     []string{"worker", "--queue", "images"},
+		User:        "1000",
+		WorkingDir:  "/srv",
+		CapAdd:      []string{"SYS_NICE"},
+		Tmpfs:       map[string]string{"/tmp": "size=64m"},
+	}
+
+	cfg := svc.buildContainerConfig(containerConfigInput{Domain: "worker.example.com", Image: "worker:latest", ImageRef: "worker:latest", Overrides: overrides, ConfigHash: overrides.ConfigHash()})
+
+	assert.Equal(t, int64(4<<30), cfg.MemoryLimit)
+	assert.Equal(t, int64(500_000_000), cfg.NanoCPUs, "unset overrides keep the global default")
+	assert.Equal(t, int64(100), cfg.PidsLimit)
+	assert.Equal(t, []string{"worker", "--queue", "images"}, cfg.Cmd)
+	assert.Equal(t, "1000", cfg.User)
+	assert.Equal(t, "/srv", cfg.WorkingDir)
+	assert.Equal(t, []string{"NET_BIND_SERVICE"}, cfg.CapAdd, "the security profile still applies")
+	assert.Equal(t, []string{"SYS_NICE"}, cfg.ExtraCapAdd)
+	assert.Equal(t, map[string]string{"/tmp": "size=64m"}, cfg.Tmpfs)
+	assert.Equal(t, overrides.ConfigHash(), cfg.Labels[domain.LabelConfigHash])
+
+	plain := svc.buildContainerConfig(containerConfigInput{Domain: "app.example.com", Image: "app:latest", ImageRef: "app:latest"})
+	assert.NotContains(t, plain.Labels, domain.LabelConfigHash)
+	assert.Equal(t, int64(128<<20), plain.MemoryLimit)
+}
+
 func TestService_BuildContainerConfig_SetsRestartPolicyAlways(t *testing.T) {
 	svc := NewService(nil, nil, nil, nil, Config{}, nil)
 
@@ -4764,6 +4820,7 @@ func TestService_ReconcileRemovedRoute_InvalidatesProxyCacheAndMetric(t *testing
 
 	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{mainContainer}, nil).Once()
 	cacheInvalidator.EXPECT().InvalidateTarget(mock.Anything, "app.example.com").Return().Once()
+	cacheInvalidator.EXPECT().PurgeResponseCache(mock.Anything, "app.example.com", "").Return(0, nil).Once()
 	runtime.EXPECT().StopContainer(mock.Anything, mainContainer.ID).Return(nil).Once()
 	runtime.EXPECT().RemoveContainer(mock.Anything, mainContainer.ID, true).Return(nil).Once()
 
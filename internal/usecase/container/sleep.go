@@ -0,0 +1,154 @@
+package container
+
+import (
+	"context"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// isSleepingContainer reports whether a tracked container was put to sleep by
+// its route idle timeout.
+func isSleepingContainer(c *domain.Container) bool {
+	return c != nil && c.Status == string(domain.ContainerStatusSleeping)
+}
+
+// isSleeping reports whether the tracked container for domainName is sleeping.
+func (s *Service) isSleeping(domainName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return isSleepingContainer(s.containers[domainName])
+}
+
+// Sleep stops the route container for domainName to release its resources
+// while keeping it tracked, so the next request can start it again with Wake.
+// Attachments keep running. Sleeping an already sleeping route is a no-op.
+func (s *Service) Sleep(ctx context.Context, domainName string) error {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "Sleep",
+		"domain":              domainName,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	unlock, err := s.acquireDomainDeployLock(ctx, domainName)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	s.mu.RLock()
+	tracked := s.containers[domainName]
+	s.mu.RUnlock()
+
+	if tracked == nil {
+		return domain.ErrContainerNotFound
+	}
+	if isSleepingContainer(tracked) {
+		return nil
+	}
+
+	if s.logWriter != nil {
+		if err := s.logWriter.StopLogging(tracked.ID); err != nil {
+			log.Warn().Err(err).Msg("failed to stop container log collection")
+		}
+	}
+
+	if err := s.runtime.StopContainer(ctx, tracked.ID); err != nil {
+		return log.WrapErr(err, "failed to stop idle container")
+	}
+
+	asleep := *tracked
+	asleep.Status = string(domain.ContainerStatusSleeping)
+	s.mu.Lock()
+	if current := s.containers[domainName]; current != nil && current.ID == tracked.ID {
+		s.containers[domainName] = &asleep
+	}
+	s.mu.Unlock()
+
+	if inv := s.proxyCacheInvalidator(); inv != nil {
+		inv.InvalidateTarget(ctx, domainName)
+	}
+
+	s.publishContainerEvent(ctx, domain.EventContainerStop, domainName, &asleep, "sleep")
+	log.Info().Str(zerowrap.FieldEntityID, tracked.ID).Msg("idle container put to sleep")
+	return nil
+}
+
+// Wake starts the sleeping route container for domainName and waits for it
+// to pass the readiness checks. Concurrent callers are serialized by the
+// per-domain deploy lock; later callers get the already awake container.
+func (s *Service) Wake(ctx context.Context, domainName string) (*domain.Container, error) {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "Wake",
+		"domain":              domainName,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	unlock, err := s.acquireDomainDeployLock(ctx, domainName)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	s.mu.RLock()
+	tracked := s.containers[domainName]
+	s.mu.RUnlock()
+
+	if tracked == nil {
+		return nil, domain.ErrContainerNotFound
+	}
+	if !isSleepingContainer(tracked) {
+		return tracked, nil
+	}
+
+	if err := s.runtime.StartContainer(ctx, tracked.ID); err != nil {
+		return nil, log.WrapErr(err, "failed to start sleeping container")
+	}
+
+	// Probe with the labels and ports the container was created with, so the
+	// readiness cascade picks the same signal it used on deploy.
+	probeConfig := &domain.ContainerConfig{Labels: tracked.Labels}
+	if inspected, inspectErr := s.runtime.InspectContainer(ctx, tracked.ID); inspectErr == nil && inspected != nil {
+		probeConfig.Labels = inspected.Labels
+		probeConfig.Ports = inspected.Ports
+	}
+	if err := s.waitForReady(ctx, tracked.ID, probeConfig); err != nil {
+		return nil, log.WrapErr(err, "woken container failed readiness check")
+	}
+
+	awake := *tracked
+	awake.Status = string(domain.ContainerStatusRunning)
+	s.mu.Lock()
+	if current := s.containers[domainName]; current != nil && current.ID == tracked.ID {
+		s.containers[domainName] = &awake
+	}
+	s.mu.Unlock()
+
+	s.startLogCollection(ctx, tracked.ID, domainName)
+
+	if inv := s.proxyCacheInvalidator(); inv != nil {
+		inv.InvalidateTarget(ctx, domainName)
+	}
+
+	s.publishContainerEvent(ctx, domain.EventContainerStart, domainName, &awake, "wake")
+	log.Info().Str(zerowrap.FieldEntityID, tracked.ID).Msg("sleeping container woken")
+	return &awake, nil
+}
+
+// publishContainerEvent publishes a container lifecycle event for domainName.
+func (s *Service) publishContainerEvent(ctx context.Context, eventType domain.EventType, domainName string, c *domain.Container, action string) {
+	payload := &domain.ContainerEventPayload{
+		ContainerID: c.ID,
+		Domain:      domainName,
+		Image:       c.Image,
+		Action:      action,
+	}
+
+	if err := s.eventBus.Publish(eventType, payload); err != nil {
+		log := zerowrap.FromCtx(ctx)
+		log.Warn().Err(err).Str("event", string(eventType)).Msg("failed to publish container event")
+	}
+}
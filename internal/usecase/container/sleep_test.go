@@ -0,0 +1,109 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestService_Sleep_StopsAndKeepsTracking(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	eventBus := mocks.NewMockEventPublisher(t)
+	invalidator := mocks.NewMockProxyCacheInvalidator(t)
+
+	svc := NewService(runtime, mocks.NewMockEnvLoader(t), eventBus, nil, Config{}, nil)
+	svc.SetProxyCacheInvalidator(invalidator)
+	ctx := testContext()
+
+	svc.containers["app.example.com"] = &domain.Container{ID: "container-1", Image: "app:v1", Status: "running"}
+
+	runtime.EXPECT().StopContainer(mock.Anything, "container-1").Return(nil).Once()
+	invalidator.EXPECT().InvalidateTarget(mock.Anything, "app.example.com").Once()
+	eventBus.EXPECT().Publish(domain.EventContainerStop, mock.MatchedBy(func(p *domain.ContainerEventPayload) bool {
+		return p.Domain == "app.example.com" && p.Action == "sleep"
+	})).Return(nil).Once()
+
+	require.NoError(t, svc.Sleep(ctx, "app.example.com"))
+
+	tracked, ok := svc.Get(ctx, "app.example.com")
+	require.True(t, ok)
+	assert.Equal(t, "container-1", tracked.ID)
+	assert.Equal(t, string(domain.ContainerStatusSleeping), tracked.Status)
+
+	// Sleeping again is a no-op.
+	require.NoError(t, svc.Sleep(ctx, "app.example.com"))
+}
+
+func TestService_Sleep_NotFound(t *testing.T) {
+	svc := NewService(mocks.NewMockContainerRuntime(t), mocks.NewMockEnvLoader(t), mocks.NewMockEventPublisher(t), nil, Config{}, nil)
+
+	err := svc.Sleep(testContext(), "missing.example.com")
+	assert.ErrorIs(t, err, domain.ErrContainerNotFound)
+}
+
+func TestService_Wake_StartsAndWaitsForReadiness(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	eventBus := mocks.NewMockEventPublisher(t)
+
+	svc := NewService(runtime, mocks.NewMockEnvLoader(t), eventBus, nil, Config{ReadinessDelay: time.Millisecond}, nil)
+	ctx := testContext()
+
+	svc.containers["app.example.com"] = &domain.Container{
+		ID:     "container-1",
+		Image:  "app:v1",
+		Status: string(domain.ContainerStatusSleeping),
+	}
+
+	runtime.EXPECT().StartContainer(mock.Anything, "container-1").Return(nil).Once()
+	runtime.EXPECT().InspectContainer(mock.Anything, "container-1").Return(&domain.Container{ID: "container-1", Ports: []int{8080}}, nil)
+	runtime.EXPECT().IsContainerRunning(mock.Anything, "container-1").Return(true, nil)
+	eventBus.EXPECT().Publish(domain.EventContainerStart, mock.MatchedBy(func(p *domain.ContainerEventPayload) bool {
+		return p.Domain == "app.example.com" && p.Action == "wake"
+	})).Return(nil).Once()
+
+	woken, err := svc.Wake(ctx, "app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "running", woken.Status)
+
+	tracked, _ := svc.Get(ctx, "app.example.com")
+	assert.Equal(t, "running", tracked.Status)
+
+	// Waking an awake route returns it without touching the runtime.
+	again, err := svc.Wake(ctx, "app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "container-1", again.ID)
+}
+
+func TestService_SyncContainers_PreservesSleepingContainers(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, mocks.NewMockEnvLoader(t), mocks.NewMockEventPublisher(t), nil, Config{}, nil)
+	ctx := testContext()
+
+	svc.containers["app.example.com"] = &domain.Container{ID: "container-1", Status: string(domain.ContainerStatusSleeping)}
+	svc.containers["gone.example.com"] = &domain.Container{ID: "container-2", Status: string(domain.ContainerStatusSleeping)}
+
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{
+		{
+			ID:     "container-1",
+			Status: "exited",
+			Labels: map[string]string{
+				domain.LabelDomain:  "app.example.com",
+				domain.LabelManaged: "true",
+			},
+		},
+	}, nil)
+
+	require.NoError(t, svc.SyncContainers(ctx))
+
+	tracked, ok := svc.Get(ctx, "app.example.com")
+	require.True(t, ok)
+	assert.Equal(t, string(domain.ContainerStatusSleeping), tracked.Status)
+	_, ok = svc.Get(ctx, "gone.example.com")
+	assert.False(t, ok)
+}
@@ -0,0 +1,359 @@
+package container
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+const (
+	statsDefaultInterval   = 10 * time.Second
+	statsDefaultHistory    = 60
+	maxConcurrentStatsRead = 10
+)
+
+// statsSettings is the effective resource sampling configuration.
+type statsSettings struct {
+	enabled  bool
+	interval time.Duration
+	history  int
+}
+
+// statsSeries holds the recent samples of one container.
+type statsSeries struct {
+	kind          domain.ContainerStatsKind
+	name          string
+	owner         string
+	containerName string
+	last          *domain.ContainerResourceUsage
+	samples       []domain.ResourceSample
+}
+
+// statsTarget is a running container the sampler reads.
+type statsTarget struct {
+	container *domain.Container
+	kind      domain.ContainerStatsKind
+	name      string
+	owner     string
+}
+
+// statsSampler periodically reads the resource usage of route, attachment
+// and service containers and keeps a short history for each of them.
+type statsSampler struct {
+	service *Service
+	stopCh  chan struct{}
+	stopped chan struct{}
+	mu      sync.RWMutex
+	series  map[string]*statsSeries // keyed by container ID
+}
+
+func newStatsSampler(service *Service) *statsSampler {
+	return &statsSampler{
+		service: service,
+		stopCh:  make(chan struct{}),
+		stopped: make(chan struct{}),
+		series:  make(map[string]*statsSeries),
+	}
+}
+
+// StartStatsSampler begins sampling container resource usage in the
+// background. Safe to call multiple times; subsequent calls are no-ops.
+func (s *Service) StartStatsSampler(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stats != nil {
+		return
+	}
+	s.stats = newStatsSampler(s)
+	go s.stats.run(ctx)
+}
+
+// StopStatsSampler stops the resource sampler.
+// Safe to call multiple times; subsequent calls are no-ops.
+func (s *Service) StopStatsSampler() {
+	s.mu.Lock()
+	ss := s.stats
+	s.stats = nil
+	s.mu.Unlock()
+	if ss != nil {
+		close(ss.stopCh)
+		<-ss.stopped
+	}
+}
+
+// ListStats returns the recent resource usage of every running route,
+// attachment and service container.
+func (s *Service) ListStats(_ context.Context) ([]domain.ContainerStats, error) {
+	s.mu.RLock()
+	ss := s.stats
+	enabled := s.config.StatsEnabled
+	s.mu.RUnlock()
+	if ss == nil || !enabled {
+		return nil, domain.ErrStatsDisabled
+	}
+	return ss.snapshot(), nil
+}
+
+// GetStats returns the recent resource usage of a route's container and its
+// attachments, or of the standalone service called name.
+func (s *Service) GetStats(ctx context.Context, name string) ([]domain.ContainerStats, error) {
+	all, err := s.ListStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]domain.ContainerStats, 0, len(all))
+	for _, st := range all {
+		if strings.EqualFold(st.Owner, name) || (st.Kind == domain.ContainerStatsKindService && st.Name == name) {
+			stats = append(stats, st)
+		}
+	}
+	return stats, nil
+}
+
+func (ss *statsSampler) settings() statsSettings {
+	ss.service.mu.RLock()
+	cfg := ss.service.config
+	ss.service.mu.RUnlock()
+
+	settings := statsSettings{
+		enabled:  cfg.StatsEnabled,
+		interval: cfg.StatsInterval,
+		history:  cfg.StatsHistory,
+	}
+	if settings.interval <= 0 {
+		settings.interval = statsDefaultInterval
+	}
+	if settings.history <= 0 {
+		settings.history = statsDefaultHistory
+	}
+	return settings
+}
+
+// run samples until the sampler stops. Like liveness probing, the settings
+// are re-read on every round so a config reload can turn sampling on or off.
+func (ss *statsSampler) run(ctx context.Context) {
+	defer close(ss.stopped)
+
+	for {
+		settings := ss.settings()
+		if settings.enabled {
+			ss.sample(ctx, settings)
+		} else {
+			ss.mu.Lock()
+			clear(ss.series)
+			ss.mu.Unlock()
+		}
+
+		timer := time.NewTimer(settings.interval)
+		select {
+		case <-ss.stopCh:
+			timer.Stop()
+			return
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+func (ss *statsSampler) sample(ctx context.Context, settings statsSettings) {
+	log := zerowrap.FromCtx(ctx)
+
+	containers, err := ss.service.runtime.ListContainers(ctx, false)
+	if err != nil {
+		log.Debug().Err(err).Msg("stats: failed to list containers")
+		return
+	}
+
+	var targets []statsTarget
+	for _, c := range containers {
+		if target, ok := classifyStatsTarget(c); ok {
+			targets = append(targets, target)
+		}
+	}
+
+	readings := make([]*domain.ContainerResourceUsage, len(targets))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentStatsRead)
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target statsTarget) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			usage, err := ss.service.runtime.ContainerStats(ctx, target.container.ID)
+			if err != nil {
+				log.Debug().Err(err).Str(zerowrap.FieldEntityID, target.container.ID).Msg("stats: failed to read container stats")
+				return
+			}
+			readings[i] = usage
+		}(i, target)
+	}
+	wg.Wait()
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	seen := make(map[string]bool, len(targets))
+	for i, target := range targets {
+		id := target.container.ID
+		seen[id] = true
+		if readings[i] == nil {
+			continue
+		}
+		series := ss.series[id]
+		if series == nil {
+			series = &statsSeries{}
+			ss.series[id] = series
+		}
+		series.kind, series.name, series.owner = target.kind, target.name, target.owner
+		series.containerName = target.container.Name
+		series.add(readings[i], settings.history)
+	}
+	for id := range ss.series {
+		if !seen[id] {
+			delete(ss.series, id)
+		}
+	}
+}
+
+// add records a reading. Rates need two readings, so the first reading of a
+// container, or the first after its counters were reset, only primes the
+// series.
+func (series *statsSeries) add(usage *domain.ContainerResourceUsage, history int) {
+	prev := series.last
+	series.last = usage
+	if prev == nil {
+		return
+	}
+	sample, ok := resourceSample(prev, usage)
+	if !ok {
+		return
+	}
+	series.samples = append(series.samples, sample)
+	if len(series.samples) > history {
+		series.samples = slices.Delete(series.samples, 0, len(series.samples)-history)
+	}
+}
+
+// resourceSample derives a sample from two consecutive readings. It reports
+// false when the counters went backwards, as they do after a restart.
+func resourceSample(prev, cur *domain.ContainerResourceUsage) (domain.ResourceSample, bool) {
+	elapsed := cur.Time.Sub(prev.Time).Seconds()
+	if elapsed <= 0 || cur.CPUUsage < prev.CPUUsage || cur.NetworkRx < prev.NetworkRx ||
+		cur.NetworkTx < prev.NetworkTx || cur.BlockRead < prev.BlockRead || cur.BlockWrite < prev.BlockWrite {
+		return domain.ResourceSample{}, false
+	}
+
+	sample := domain.ResourceSample{
+		Time:           cur.Time,
+		MemoryUsage:    cur.MemoryUsage,
+		MemoryLimit:    cur.MemoryLimit,
+		NetworkRxRate:  float64(cur.NetworkRx-prev.NetworkRx) / elapsed,
+		NetworkTxRate:  float64(cur.NetworkTx-prev.NetworkTx) / elapsed,
+		BlockReadRate:  float64(cur.BlockRead-prev.BlockRead) / elapsed,
+		BlockWriteRate: float64(cur.BlockWrite-prev.BlockWrite) / elapsed,
+		NetworkRx:      cur.NetworkRx,
+		NetworkTx:      cur.NetworkTx,
+		BlockRead:      cur.BlockRead,
+		BlockWrite:     cur.BlockWrite,
+		PIDs:           cur.PIDs,
+	}
+	// Same formula as docker stats: the container's share of host CPU time,
+	// scaled so that one fully used core is 100%.
+	if cur.SystemCPU > prev.SystemCPU && cur.OnlineCPUs > 0 {
+		cpuDelta := float64(cur.CPUUsage - prev.CPUUsage)
+		systemDelta := float64(cur.SystemCPU - prev.SystemCPU)
+		sample.CPUPercent = cpuDelta / systemDelta * float64(cur.OnlineCPUs) * 100
+	}
+	return sample, true
+}
+
+// snapshot returns a copy of the sampled series that have at least one
+// sample, with a route followed by its attachments and services last.
+func (ss *statsSampler) snapshot() []domain.ContainerStats {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+
+	stats := make([]domain.ContainerStats, 0, len(ss.series))
+	for id, series := range ss.series {
+		if len(series.samples) == 0 {
+			continue
+		}
+		stats = append(stats, domain.ContainerStats{
+			Kind:          series.kind,
+			Name:          series.name,
+			Owner:         series.owner,
+			ContainerID:   id,
+			ContainerName: series.containerName,
+			Latest:        series.samples[len(series.samples)-1],
+			History:       slices.Clone(series.samples),
+		})
+	}
+	slices.SortFunc(stats, func(a, b domain.ContainerStats) int {
+		return cmp.Or(
+			cmp.Compare(statsGroup(a), statsGroup(b)),
+			strings.Compare(a.Owner, b.Owner),
+			cmp.Compare(statsKindOrder(a.Kind), statsKindOrder(b.Kind)),
+			strings.Compare(a.Name, b.Name),
+			strings.Compare(a.ContainerID, b.ContainerID),
+		)
+	})
+	return stats
+}
+
+// classifyStatsTarget tells whether a running container is sampled, and as
+// what. One-off task, hook and job containers are not.
+func classifyStatsTarget(c *domain.Container) (statsTarget, bool) {
+	if c == nil || c.Labels == nil {
+		return statsTarget{}, false
+	}
+	switch {
+	case c.Labels[domain.LabelService] == "true":
+		name := c.Labels[domain.LabelServiceName]
+		if name == "" {
+			name = c.Name
+		}
+		return statsTarget{container: c, kind: domain.ContainerStatsKindService, name: name}, true
+	case c.Labels[domain.LabelManaged] != "true":
+		return statsTarget{}, false
+	case c.Labels[domain.LabelAttachment] == "true":
+		return statsTarget{container: c, kind: domain.ContainerStatsKindAttachment, name: c.Name, owner: c.Labels[domain.LabelAttachedTo]}, true
+	case c.Labels[domain.LabelProcess] != "":
+		return statsTarget{container: c, kind: domain.ContainerStatsKindProcess, name: c.Name, owner: c.Labels[domain.LabelRoute]}, true
+	case c.Labels[domain.LabelRoute] != "":
+		route := c.Labels[domain.LabelRoute]
+		return statsTarget{container: c, kind: domain.ContainerStatsKindRoute, name: route, owner: route}, true
+	}
+	return statsTarget{}, false
+}
+
+// statsGroup sorts services after routes.
+func statsGroup(st domain.ContainerStats) int {
+	if st.Kind == domain.ContainerStatsKindService {
+		return 1
+	}
+	return 0
+}
+
+func statsKindOrder(kind domain.ContainerStatsKind) int {
+	switch kind {
+	case domain.ContainerStatsKindRoute:
+		return 0
+	case domain.ContainerStatsKindProcess:
+		return 1
+	case domain.ContainerStatsKindAttachment:
+		return 2
+	default:
+		return 3
+	}
+}
@@ -0,0 +1,109 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestResourceSample_ComputesRates(t *testing.T) {
+	start := time.Date(2026, 2, 7, 12, 0, 0, 0, time.UTC)
+	prev := &domain.ContainerResourceUsage{
+		Time: start, CPUUsage: 1_000_000_000, SystemCPU: 100_000_000_000, OnlineCPUs: 4,
+		NetworkRx: 1000, NetworkTx: 500, BlockRead: 0, BlockWrite: 4096,
+	}
+	cur := &domain.ContainerResourceUsage{
+		Time: start.Add(10 * time.Second), CPUUsage: 6_000_000_000, SystemCPU: 140_000_000_000, OnlineCPUs: 4,
+		MemoryUsage: 64 << 20, MemoryLimit: 512 << 20, PIDs: 7,
+		NetworkRx: 11000, NetworkTx: 2500, BlockRead: 20480, BlockWrite: 4096,
+	}
+
+	sample, ok := resourceSample(prev, cur)
+
+	require.True(t, ok)
+	// 5s of CPU over 40s of host time on 4 cores: half a core.
+	assert.InDelta(t, 50.0, sample.CPUPercent, 0.001)
+	assert.InDelta(t, 1000.0, sample.NetworkRxRate, 0.001)
+	assert.InDelta(t, 200.0, sample.NetworkTxRate, 0.001)
+	assert.InDelta(t, 2048.0, sample.BlockReadRate, 0.001)
+	assert.Zero(t, sample.BlockWriteRate)
+	assert.Equal(t, uint64(64<<20), sample.MemoryUsage)
+	assert.Equal(t, uint64(7), sample.PIDs)
+}
+
+func TestResourceSample_SkipsResetCounters(t *testing.T) {
+	start := time.Now()
+	prev := &domain.ContainerResourceUsage{Time: start, CPUUsage: 5_000_000_000}
+	cur := &domain.ContainerResourceUsage{Time: start.Add(time.Second), CPUUsage: 1_000}
+
+	_, ok := resourceSample(prev, cur)
+	assert.False(t, ok)
+}
+
+func TestStatsSampler_SamplesManagedContainers(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := newTestService(runtime)
+	svc.config = Config{StatsEnabled: true}
+	svc.stats = newStatsSampler(svc)
+
+	runtime.EXPECT().ListContainers(mock.Anything, false).Return([]*domain.Container{
+		{ID: "ctr-route", Name: "gordon-app", Labels: map[string]string{domain.LabelManaged: "true", domain.LabelRoute: "app.example.com"}},
+		{ID: "ctr-worker", Name: "gordon-app-worker", Labels: map[string]string{domain.LabelManaged: "true", domain.LabelRoute: "app.example.com", domain.LabelProcess: "worker"}},
+		{ID: "ctr-db", Name: "gordon-app-postgres", Labels: map[string]string{domain.LabelManaged: "true", domain.LabelAttachment: "true", domain.LabelAttachedTo: "app.example.com"}},
+		{ID: "ctr-svc", Name: "gordon-service-redis", Labels: map[string]string{domain.LabelService: "true", domain.LabelServiceName: "redis"}},
+		{ID: "ctr-task", Labels: map[string]string{domain.LabelManaged: "true", domain.LabelTask: "app.example.com"}},
+		{ID: "ctr-other", Name: "unrelated"},
+	}, nil)
+
+	start := time.Now()
+	reading := func(offset time.Duration, cpu uint64) *domain.ContainerResourceUsage {
+		return &domain.ContainerResourceUsage{Time: start.Add(offset), CPUUsage: cpu, SystemCPU: uint64(offset + time.Hour), OnlineCPUs: 1}
+	}
+	for _, id := range []string{"ctr-route", "ctr-worker", "ctr-db", "ctr-svc"} {
+		runtime.EXPECT().ContainerStats(mock.Anything, id).Return(reading(0, 0), nil).Once()
+		runtime.EXPECT().ContainerStats(mock.Anything, id).Return(reading(10*time.Second, uint64(time.Second)), nil).Once()
+		runtime.EXPECT().ContainerStats(mock.Anything, id).Return(reading(20*time.Second, uint64(2*time.Second)), nil).Once()
+	}
+
+	settings := statsSettings{enabled: true, interval: time.Second, history: 1}
+	svc.stats.sample(monitorTestContext(), settings)
+	stats, err := svc.ListStats(monitorTestContext())
+	require.NoError(t, err)
+	assert.Empty(t, stats, "the first reading only primes the series")
+
+	svc.stats.sample(monitorTestContext(), settings)
+	svc.stats.sample(monitorTestContext(), settings)
+
+	stats, err = svc.ListStats(monitorTestContext())
+	require.NoError(t, err)
+	require.Len(t, stats, 4)
+	assert.Equal(t, domain.ContainerStatsKindRoute, stats[0].Kind)
+	assert.Equal(t, domain.ContainerStatsKindProcess, stats[1].Kind)
+	assert.Equal(t, "app.example.com", stats[1].Owner)
+	assert.Equal(t, domain.ContainerStatsKindAttachment, stats[2].Kind)
+	assert.Equal(t, "app.example.com", stats[2].Owner)
+	assert.Equal(t, domain.ContainerStatsKindService, stats[3].Kind)
+	assert.Equal(t, "redis", stats[3].Name)
+	assert.Len(t, stats[0].History, 1, "history is capped")
+	assert.InDelta(t, 10.0, stats[0].Latest.CPUPercent, 0.001)
+
+	routeStats, err := svc.GetStats(monitorTestContext(), "app.example.com")
+	require.NoError(t, err)
+	assert.Len(t, routeStats, 3)
+	serviceStats, err := svc.GetStats(monitorTestContext(), "redis")
+	require.NoError(t, err)
+	assert.Len(t, serviceStats, 1)
+}
+
+func TestStatsSampler_DisabledReturnsError(t *testing.T) {
+	svc := newTestService(mocks.NewMockContainerRuntime(t))
+
+	_, err := svc.ListStats(monitorTestContext())
+	assert.ErrorIs(t, err, domain.ErrStatsDisabled)
+}
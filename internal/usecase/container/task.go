@@ -0,0 +1,75 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/bnema/zerowrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// RunTask runs a one-off command with the image the route's container runs,
+// pinned by image ID so a push during the task does not change it. The task
+// container gets the env, secrets, volumes and network a deploy would give
+// the route, streams its output to stdout and stderr, and is removed once it
+// exits or ctx is canceled.
+func (s *Service) RunTask(ctx context.Context, route domain.Route, task domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+	if len(task.Command) == 0 || task.Command[0] == "" {
+		return 0, domain.ErrTaskCommandRequired
+	}
+
+	ctx, span := tracer.Start(ctx, "container.run_task",
+		trace.WithAttributes(attribute.String("domain", route.Domain)))
+	defer span.End()
+
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "RunTask",
+		"domain":              route.Domain,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	existing, ok := s.resolveExistingContainer(ctx, route.Domain)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s has no deployed container to run a task with", domain.ErrContainerNotFound, route.Domain)
+	}
+	imageID := s.containerForRedundantCheck(ctx, existing).ImageID
+	if imageID == "" {
+		return 0, fmt.Errorf("failed to resolve the running image of %s", route.Domain)
+	}
+
+	resources, err := s.routeImageResources(ctx, route, s.getNetworkForApp(route.Domain), imageID)
+	if err != nil {
+		return 0, err
+	}
+	config := s.oneOffContainerConfig(route, existing, resources, "run", task.Command, map[string]string{
+		domain.LabelTask: route.Domain,
+	})
+	config.Tty = task.TTY
+
+	log.Info().Strs("command", task.Command).Str("image_id", imageID).Msg("running task")
+
+	taskContainer, err := s.runtime.CreateContainer(ctx, config)
+	if err != nil {
+		return 0, log.WrapErr(err, "failed to create task container")
+	}
+	defer s.cleanupFailedContainer(ctx, taskContainer.ID)
+
+	if err := s.runtime.StartContainer(ctx, taskContainer.ID); err != nil {
+		return 0, log.WrapErr(err, "failed to start task container")
+	}
+	if err := s.runtime.StreamContainerOutput(ctx, taskContainer.ID, stdout, stderr); err != nil {
+		return 0, log.WrapErr(err, "failed to stream task output")
+	}
+
+	exitCode, err := s.waitForContainerExit(ctx, taskContainer.ID)
+	if err != nil {
+		return 0, log.WrapErr(err, "failed to wait for task container")
+	}
+	log.Info().Int("exit_code", exitCode).Msg("task finished")
+	return exitCode, nil
+}
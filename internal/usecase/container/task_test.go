@@ -0,0 +1,80 @@
+package container
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	mocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestService_RunTask_StreamsOutputAndReturnsExitCode(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	svc.containers["app.example.com"] = &domain.Container{ID: "app-1", Name: "gordon-app.example.com", ImageID: "sha256:abc"}
+	route := domain.Route{Domain: "app.example.com", Image: "app:latest", Env: []string{"DATABASE_URL=postgres://db/app"}}
+
+	runtime.EXPECT().GetImageExposedPorts(mock.Anything, "sha256:abc").Return([]int{8000}, nil)
+	runtime.EXPECT().GetImageLabels(mock.Anything, "sha256:abc").Return(nil, nil)
+	runtime.EXPECT().InspectImageEnv(mock.Anything, "sha256:abc").Return(nil, nil)
+	runtime.EXPECT().CreateContainer(mock.Anything, mock.MatchedBy(func(cfg *domain.ContainerConfig) bool {
+		return assert.Equal(t, []string{"./manage", "shell"}, cfg.Cmd) &&
+			assert.Equal(t, "sha256:abc", cfg.Image) &&
+			assert.Equal(t, []string{"DATABASE_URL=postgres://db/app"}, cfg.Env) &&
+			assert.True(t, cfg.Tty) &&
+			assert.Empty(t, cfg.Ports) &&
+			assert.Empty(t, cfg.RestartPolicy) &&
+			assert.Equal(t, "app.example.com", cfg.Labels[domain.LabelTask]) &&
+			assert.NotContains(t, cfg.Labels, domain.LabelRoute)
+	})).Return(&domain.Container{ID: "task-1"}, nil)
+	runtime.EXPECT().StartContainer(mock.Anything, "task-1").Return(nil)
+	runtime.EXPECT().StreamContainerOutput(mock.Anything, "task-1", mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ string, stdout, stderr io.Writer) error {
+			_, _ = io.WriteString(stdout, "ok\n")
+			_, _ = io.WriteString(stderr, "warning\n")
+			return nil
+		})
+	runtime.EXPECT().InspectContainer(mock.Anything, "task-1").Return(&domain.Container{ID: "task-1", Status: string(domain.ContainerStatusExited), ExitCode: 3}, nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "task-1").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "task-1", true).Return(nil)
+
+	var stdout, stderr bytes.Buffer
+	exitCode, err := svc.RunTask(testContext(), route, domain.TaskRequest{Command: []string{"./manage", "shell"}, TTY: true}, &stdout, &stderr)
+	require.NoError(t, err)
+	assert.Equal(t, 3, exitCode)
+	assert.Equal(t, "ok\n", stdout.String())
+	assert.Equal(t, "warning\n", stderr.String())
+}
+
+func TestService_RunTask_RequiresCommandAndDeployedContainer(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+	route := domain.Route{Domain: "app.example.com", Image: "app:latest"}
+
+	_, err := svc.RunTask(testContext(), route, domain.TaskRequest{}, io.Discard, io.Discard)
+	require.ErrorIs(t, err, domain.ErrTaskCommandRequired)
+
+	runtime.EXPECT().ListContainers(mock.Anything, false).Return(nil, nil)
+	_, err = svc.RunTask(testContext(), route, domain.TaskRequest{Command: []string{"true"}}, io.Discard, io.Discard)
+	assert.ErrorIs(t, err, domain.ErrContainerNotFound)
+}
+
+func TestService_CleanupOrphanedContainers_KeepsRunningTasks(t *testing.T) {
+	runtime := mocks.NewMockContainerRuntime(t)
+	svc := NewService(runtime, nil, nil, nil, Config{}, nil)
+
+	runtime.EXPECT().ListContainers(mock.Anything, true).Return([]*domain.Container{
+		{ID: "task-1", Name: "gordon-app.example.com-run-1", Status: "running", Labels: map[string]string{domain.LabelTask: "app.example.com"}},
+		{ID: "task-2", Name: "gordon-app.example.com-run-2", Status: "exited", Labels: map[string]string{domain.LabelTask: "app.example.com"}},
+	}, nil)
+	runtime.EXPECT().StopContainer(mock.Anything, "task-2").Return(nil)
+	runtime.EXPECT().RemoveContainer(mock.Anything, "task-2", true).Return(nil)
+
+	require.NoError(t, svc.cleanupOrphanedContainers(testContext(), "app.example.com", ""))
+}
@@ -12,7 +12,8 @@ import (
 	"github.com/bnema/zerowrap"
 )
 
-// Scheduler runs recurring jobs based on backup schedules.
+// Scheduler runs recurring jobs on preset, interval or cron expression
+// schedules.
 type Scheduler struct {
 	entries map[string]*entry
 	mu      sync.RWMutex
@@ -275,50 +276,63 @@ func (s *Scheduler) dueEntries(now time.Time) []*entry {
 	return entries
 }
 
-// calculateNextRun computes the next execution time using UTC-only preset rules.
+// calculateNextRun computes the next execution time of schedule after now.
 //
-// Current MVP behavior is intentionally fixed to UTC because domain.CronSchedule
-// has no timezone field yet:
-//   - daily:   02:00 UTC
-//   - weekly:  Sunday 03:00 UTC
-//   - monthly: first day of month 04:00 UTC
-//
-// This means users in non-UTC timezones should account for UTC offsets.
-//
-// TODO: add timezone support on domain.CronSchedule once timezone-aware
-// scheduling is introduced. Default timezone should be the host system timezone
-// when no explicit timezone is configured.
+// Presets and expressions are evaluated in schedule.Location, which defaults
+// to UTC so the internal presets keep their fixed times:
+//   - daily:   02:00
+//   - weekly:  Sunday 03:00
+//   - monthly: first day of month 04:00
 func calculateNextRun(now time.Time, schedule domain.CronSchedule) (time.Time, error) {
-	now = now.UTC()
+	loc := schedule.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	now = now.In(loc)
+
 	if schedule.Interval > 0 && schedule.Preset != "" {
 		return time.Time{}, fmt.Errorf("cron schedule cannot set both interval and preset")
 	}
+	if schedule.Expression != "" && (schedule.Interval > 0 || schedule.Preset != "") {
+		return time.Time{}, fmt.Errorf("cron schedule cannot combine an expression with an interval or preset")
+	}
 	if schedule.Interval > 0 {
 		return now.Add(schedule.Interval), nil
 	}
+	if schedule.Expression != "" {
+		expr, err := domain.ParseCronExpression(schedule.Expression)
+		if err != nil {
+			return time.Time{}, err
+		}
+		next := expr.Next(now)
+		if next.IsZero() {
+			return time.Time{}, fmt.Errorf("cron expression %q never matches", schedule.Expression)
+		}
+		return next, nil
+	}
 
 	switch schedule.Preset {
 	case domain.ScheduleHourly:
-		next := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, time.UTC)
+		next := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, loc)
 		if !next.After(now) {
 			next = next.Add(time.Hour)
 		}
 		return next, nil
 	case domain.ScheduleDaily:
-		next := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, time.UTC)
+		next := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, loc)
 		if !next.After(now) {
 			next = next.AddDate(0, 0, 1)
 		}
 		return next, nil
 	case domain.ScheduleWeekly:
 		daysUntilSunday := (7 - int(now.Weekday())) % 7
-		next := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, time.UTC).AddDate(0, 0, daysUntilSunday)
+		next := time.Date(now.Year(), now.Month(), now.Day(), 3, 0, 0, 0, loc).AddDate(0, 0, daysUntilSunday)
 		if !next.After(now) {
 			next = next.AddDate(0, 0, 7)
 		}
 		return next, nil
 	case domain.ScheduleMonthly:
-		next := time.Date(now.Year(), now.Month(), 1, 4, 0, 0, 0, time.UTC)
+		next := time.Date(now.Year(), now.Month(), 1, 4, 0, 0, 0, loc)
 		if !next.After(now) {
 			next = next.AddDate(0, 1, 0)
 		}
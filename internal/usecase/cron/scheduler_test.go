@@ -67,6 +67,35 @@ func TestCalculateNextRunRejectsIntervalAndPreset(t *testing.T) {
 	assert.Contains(t, err.Error(), "both interval and preset")
 }
 
+func TestCalculateNextRunExpressionInLocation(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	now := time.Date(2026, 2, 7, 12, 34, 20, 0, time.UTC) // 13:34 in Paris
+
+	next, err := calculateNextRun(now, domain.CronSchedule{Expression: "0 9 * * mon-fri", Location: paris})
+
+	require.NoError(t, err)
+	assert.True(t, next.Equal(time.Date(2026, 2, 9, 9, 0, 0, 0, paris)), "got %s", next)
+}
+
+func TestCalculateNextRunPresetInLocation(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	now := time.Date(2026, 2, 7, 12, 0, 0, 0, time.UTC) // 21:00 in Tokyo
+
+	next, err := calculateNextRun(now, domain.CronSchedule{Preset: domain.ScheduleDaily, Location: tokyo})
+
+	require.NoError(t, err)
+	assert.True(t, next.Equal(time.Date(2026, 2, 8, 2, 0, 0, 0, tokyo)), "got %s", next)
+}
+
+func TestCalculateNextRunRejectsNeverMatchingExpression(t *testing.T) {
+	_, err := calculateNextRun(time.Now(), domain.CronSchedule{Expression: "0 0 30 2 *"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "never matches")
+}
+
 func TestSchedulerAddListAndRunNow(t *testing.T) {
 	s := NewScheduler(zerowrap.Default())
 	now := time.Date(2026, 2, 7, 12, 0, 0, 0, time.UTC)
@@ -0,0 +1,101 @@
+package jobs
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/pkg/validation"
+)
+
+// Config is one [[jobs]] entry.
+type Config struct {
+	Route    string   `mapstructure:"route"`
+	Name     string   `mapstructure:"name"`
+	Schedule string   `mapstructure:"schedule"`
+	Timezone string   `mapstructure:"timezone"`
+	Command  []string `mapstructure:"command"`
+	Timeout  string   `mapstructure:"timeout"`
+	Overlap  string   `mapstructure:"overlap"`
+}
+
+// ToDomain converts [[jobs]] entries, rejecting duplicate names per route.
+func ToDomain(configs []Config) ([]domain.ScheduledJob, error) {
+	jobs := make([]domain.ScheduledJob, 0, len(configs))
+	seen := make(map[string]struct{}, len(configs))
+	for i, cfg := range configs {
+		job, err := cfg.ToDomain()
+		if err != nil {
+			return nil, fmt.Errorf("job config %d: %w", i, err)
+		}
+		if _, ok := seen[job.ID()]; ok {
+			return nil, fmt.Errorf("job config %d: duplicate job %q for route %s; set a unique name", i, job.Name, job.Route)
+		}
+		seen[job.ID()] = struct{}{}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// ToDomain validates the entry and converts it. An empty timezone means the
+// host timezone.
+func (c Config) ToDomain() (domain.ScheduledJob, error) {
+	route := strings.TrimSpace(c.Route)
+	if route == "" {
+		return domain.ScheduledJob{}, fmt.Errorf("route is required")
+	}
+	if err := validation.ValidateDomainParam(route); err != nil {
+		return domain.ScheduledJob{}, fmt.Errorf("route %q is invalid: %w", route, err)
+	}
+	if len(c.Command) == 0 || strings.TrimSpace(c.Command[0]) == "" {
+		return domain.ScheduledJob{}, fmt.Errorf("command is required")
+	}
+
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		name = path.Base(c.Command[0])
+	}
+	if strings.ContainsAny(name, "/ ") {
+		return domain.ScheduledJob{}, fmt.Errorf("name %q must not contain slashes or spaces", name)
+	}
+
+	loc := time.Local
+	if c.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(c.Timezone); err != nil {
+			return domain.ScheduledJob{}, fmt.Errorf("timezone %q is invalid: %w", c.Timezone, err)
+		}
+	}
+	if _, err := domain.ParseCronExpression(c.Schedule); err != nil {
+		return domain.ScheduledJob{}, fmt.Errorf("schedule: %w", err)
+	}
+
+	var timeout time.Duration
+	if c.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(c.Timeout); err != nil {
+			return domain.ScheduledJob{}, fmt.Errorf("timeout %q is invalid: %w", c.Timeout, err)
+		}
+		if timeout <= 0 {
+			return domain.ScheduledJob{}, fmt.Errorf("timeout must be positive when set")
+		}
+	}
+
+	overlap := domain.JobOverlapPolicy(strings.ToLower(strings.TrimSpace(c.Overlap)))
+	switch overlap {
+	case "", domain.JobOverlapForbid, domain.JobOverlapAllow, domain.JobOverlapReplace:
+	default:
+		return domain.ScheduledJob{}, fmt.Errorf("overlap %q must be forbid, allow or replace", c.Overlap)
+	}
+
+	return domain.ScheduledJob{
+		Route:    route,
+		Name:     name,
+		Schedule: domain.CronSchedule{Expression: c.Schedule, Location: loc},
+		Command:  append([]string(nil), c.Command...),
+		Timeout:  timeout,
+		Overlap:  overlap,
+	}, nil
+}
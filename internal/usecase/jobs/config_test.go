@@ -0,0 +1,75 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestConfigToDomainDefaults(t *testing.T) {
+	cfg := Config{Route: "app.example.com", Schedule: "*/15 * * * *", Command: []string{"bin/cleanup", "--stale"}}
+
+	job, err := cfg.ToDomain()
+
+	require.NoError(t, err)
+	assert.Equal(t, "cleanup", job.Name)
+	assert.Equal(t, time.Local, job.Schedule.Location)
+	assert.Equal(t, domain.DefaultJobTimeout, job.EffectiveTimeout())
+	assert.Equal(t, domain.JobOverlapForbid, job.EffectiveOverlap())
+}
+
+func TestConfigToDomainParsesOptions(t *testing.T) {
+	cfg := Config{
+		Route:    "app.example.com",
+		Name:     "report",
+		Schedule: "0 6 * * mon",
+		Timezone: "Europe/Paris",
+		Command:  []string{"./report"},
+		Timeout:  "10m",
+		Overlap:  "Replace",
+	}
+
+	job, err := cfg.ToDomain()
+
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", job.Schedule.Location.String())
+	assert.Equal(t, 10*time.Minute, job.Timeout)
+	assert.Equal(t, domain.JobOverlapReplace, job.Overlap)
+}
+
+func TestConfigToDomainRejectsInvalidEntries(t *testing.T) {
+	valid := Config{Route: "app.example.com", Schedule: "@daily", Command: []string{"./cleanup"}}
+	tests := map[string]func(*Config){
+		"missing route":    func(c *Config) { c.Route = "" },
+		"missing command":  func(c *Config) { c.Command = nil },
+		"bad schedule":     func(c *Config) { c.Schedule = "every day" },
+		"bad timezone":     func(c *Config) { c.Timezone = "Mars/Olympus" },
+		"bad timeout":      func(c *Config) { c.Timeout = "0s" },
+		"bad overlap":      func(c *Config) { c.Overlap = "queue" },
+		"name with spaces": func(c *Config) { c.Name = "nightly report" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := valid
+			mutate(&cfg)
+			_, err := cfg.ToDomain()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestToDomainRejectsDuplicateJobNamesPerRoute(t *testing.T) {
+	configs := []Config{
+		{Route: "app.example.com", Schedule: "@hourly", Command: []string{"bin/cleanup"}},
+		{Route: "app.example.com", Schedule: "@daily", Command: []string{"/usr/bin/cleanup", "--all"}},
+		{Route: "api.example.com", Schedule: "@daily", Command: []string{"bin/cleanup"}},
+	}
+
+	_, err := ToDomain(configs)
+
+	require.ErrorContains(t, err, "duplicate job")
+}
@@ -0,0 +1,343 @@
+// Package jobs runs the scheduled jobs configured with [[jobs]]: commands
+// started on a cron schedule in ephemeral containers with a route's image.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"github.com/google/uuid"
+
+	"github.com/bnema/gordon/internal/boundaries/in"
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+	cronSvc "github.com/bnema/gordon/internal/usecase/cron"
+)
+
+var (
+	errRunReplaced  = errors.New("replaced by a newer run")
+	errShuttingDown = errors.New("gordon is shutting down")
+)
+
+const overlapSkippedReason = "an earlier run is still active and overlap is forbid"
+
+// routeGetter resolves the route a job runs with.
+type routeGetter interface {
+	GetRoute(ctx context.Context, domain string) (*domain.Route, error)
+}
+
+// Service schedules jobs and records their runs.
+type Service struct {
+	tasks     in.TaskService
+	routes    routeGetter
+	store     out.JobRunStore
+	scheduler *cronSvc.Scheduler
+	nowFn     func() time.Time
+
+	mu     sync.Mutex
+	jobs   map[string]domain.ScheduledJob
+	active map[string]map[string]context.CancelCauseFunc // job ID -> run ID -> cancel
+	wg     sync.WaitGroup
+}
+
+// NewService creates a jobs service. Jobs are registered with Reconcile and
+// run on schedule once Start is called; RunJob works either way.
+func NewService(tasks in.TaskService, routes routeGetter, store out.JobRunStore, log zerowrap.Logger) *Service {
+	return &Service{
+		tasks:     tasks,
+		routes:    routes,
+		store:     store,
+		scheduler: cronSvc.NewScheduler(log),
+		nowFn:     time.Now,
+		jobs:      make(map[string]domain.ScheduledJob),
+		active:    make(map[string]map[string]context.CancelCauseFunc),
+	}
+}
+
+// Reconcile replaces the configured jobs. Runs of removed or changed jobs
+// that are in progress keep going.
+func (s *Service) Reconcile(_ context.Context, jobs []domain.ScheduledJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wanted := make(map[string]domain.ScheduledJob, len(jobs))
+	for _, job := range jobs {
+		wanted[job.ID()] = job
+	}
+
+	for id, current := range s.jobs {
+		if job, ok := wanted[id]; ok && sameJob(current, job) {
+			continue
+		}
+		if err := s.scheduler.Remove(id); err != nil {
+			return fmt.Errorf("unschedule job %s: %w", id, err)
+		}
+		delete(s.jobs, id)
+	}
+
+	for id, job := range wanted {
+		if _, ok := s.jobs[id]; ok {
+			continue
+		}
+		job := job
+		// The scheduler waits for its jobs; hand the run off so a long job
+		// does not hold back other schedules or the overlap policy.
+		err := s.scheduler.Add(id, job.Name, job.Schedule, func(ctx context.Context) error {
+			_, err := s.trigger(ctx, job, domain.JobTriggerSchedule)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("schedule job %s: %w", id, err)
+		}
+		s.jobs[id] = job
+	}
+	return nil
+}
+
+// sameJob reports whether b is the same job as a, comparing timezones by name.
+func sameJob(a, b domain.ScheduledJob) bool {
+	if a.Schedule.Location.String() != b.Schedule.Location.String() {
+		return false
+	}
+	a.Schedule.Location, b.Schedule.Location = nil, nil
+	return a.Route == b.Route && a.Name == b.Name && a.Schedule == b.Schedule &&
+		slices.Equal(a.Command, b.Command) && a.Timeout == b.Timeout && a.Overlap == b.Overlap
+}
+
+// Start runs jobs on their schedules until ctx is canceled or Stop is called.
+func (s *Service) Start(ctx context.Context) {
+	s.scheduler.Start(ctx)
+}
+
+// Stop stops scheduling, cancels the runs in progress and waits for them to
+// be recorded.
+func (s *Service) Stop() {
+	s.scheduler.Stop()
+
+	s.mu.Lock()
+	for _, runs := range s.active {
+		for _, cancel := range runs {
+			cancel(errShuttingDown)
+		}
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+}
+
+// ListJobs returns every configured job with its next and last run.
+func (s *Service) ListJobs(ctx context.Context) ([]domain.JobStatus, error) {
+	nextRuns := make(map[string]time.Time)
+	for _, entry := range s.scheduler.List() {
+		nextRuns[entry.ID] = entry.NextRun
+	}
+
+	s.mu.Lock()
+	statuses := make([]domain.JobStatus, 0, len(s.jobs))
+	for id, job := range s.jobs {
+		statuses = append(statuses, domain.JobStatus{
+			Job:     job,
+			NextRun: nextRuns[id],
+			Active:  len(s.active[id]),
+		})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Job.ID() < statuses[j].Job.ID()
+	})
+	for i := range statuses {
+		if loc := statuses[i].Job.Schedule.Location; loc != nil && !statuses[i].NextRun.IsZero() {
+			statuses[i].NextRun = statuses[i].NextRun.In(loc)
+		}
+		runs, err := s.store.ListRuns(ctx, statuses[i].Job.Route, statuses[i].Job.Name)
+		if err != nil {
+			return nil, fmt.Errorf("load runs of job %s: %w", statuses[i].Job.ID(), err)
+		}
+		if len(runs) > 0 {
+			last := runs[0]
+			statuses[i].LastRun = &last
+		}
+	}
+	return statuses, nil
+}
+
+// RunJob starts a run of the job now. The run is detached from ctx's
+// cancellation and continues after RunJob returns.
+func (s *Service) RunJob(ctx context.Context, route, name string) (domain.JobRun, error) {
+	job, ok := s.job(route, name)
+	if !ok {
+		return domain.JobRun{}, fmt.Errorf("%w: %s/%s", domain.ErrJobNotFound, route, name)
+	}
+	return s.trigger(context.WithoutCancel(ctx), job, domain.JobTriggerManual)
+}
+
+// ListJobRuns returns the recorded runs of a job, newest first.
+func (s *Service) ListJobRuns(ctx context.Context, route, name string) ([]domain.JobRun, error) {
+	if _, ok := s.job(route, name); !ok {
+		return nil, fmt.Errorf("%w: %s/%s", domain.ErrJobNotFound, route, name)
+	}
+	return s.store.ListRuns(ctx, route, name)
+}
+
+func (s *Service) job(route, name string) (domain.ScheduledJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[domain.ScheduledJob{Route: route, Name: name}.ID()]
+	return job, ok
+}
+
+// trigger applies the job's overlap policy and starts a run in the
+// background, returning the run as first recorded.
+func (s *Service) trigger(ctx context.Context, job domain.ScheduledJob, trigger domain.JobTrigger) (domain.JobRun, error) {
+	ctx = zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "RunJob",
+		"domain":              job.Route,
+		"job":                 job.Name,
+	})
+	log := zerowrap.FromCtx(ctx)
+
+	run := domain.JobRun{
+		ID:        uuid.New().String(),
+		Route:     job.Route,
+		Job:       job.Name,
+		Trigger:   trigger,
+		Status:    domain.JobRunRunning,
+		StartedAt: s.nowFn(),
+	}
+
+	s.mu.Lock()
+	active := s.active[job.ID()]
+	if len(active) > 0 {
+		switch job.EffectiveOverlap() {
+		case domain.JobOverlapAllow:
+		case domain.JobOverlapReplace:
+			for _, cancel := range active {
+				cancel(errRunReplaced)
+			}
+		default:
+			s.mu.Unlock()
+			run.Status = domain.JobRunSkipped
+			run.FinishedAt = run.StartedAt
+			run.Error = overlapSkippedReason
+			log.Warn().Str("trigger", string(trigger)).Msg("job run skipped: previous run still active")
+			return run, s.store.SaveRun(ctx, run)
+		}
+	}
+	runCtx, cancel := context.WithCancelCause(ctx)
+	if active == nil {
+		active = make(map[string]context.CancelCauseFunc)
+		s.active[job.ID()] = active
+	}
+	active[run.ID] = cancel
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	if err := s.store.SaveRun(ctx, run); err != nil {
+		log.Warn().Err(err).Msg("failed to record job run start")
+	}
+	log.Info().Str("run_id", run.ID).Str("trigger", string(trigger)).Strs("command", job.Command).Msg("job run started")
+
+	go s.execute(runCtx, cancel, job, run)
+	return run, nil
+}
+
+func (s *Service) execute(ctx context.Context, cancel context.CancelCauseFunc, job domain.ScheduledJob, run domain.JobRun) {
+	defer s.wg.Done()
+	defer func() {
+		cancel(nil)
+		s.mu.Lock()
+		delete(s.active[job.ID()], run.ID)
+		if len(s.active[job.ID()]) == 0 {
+			delete(s.active, job.ID())
+		}
+		s.mu.Unlock()
+	}()
+	log := zerowrap.FromCtx(ctx)
+
+	output := &tailBuffer{max: domain.MaxJobRunOutput}
+	exitCode, err := s.runTask(ctx, job, output)
+
+	run.FinishedAt = s.nowFn()
+	run.Output = output.String()
+	run.ExitCode = exitCode
+	switch {
+	case err == nil && exitCode == 0:
+		run.Status = domain.JobRunSucceeded
+	case err == nil:
+		run.Status = domain.JobRunFailed
+		run.Error = fmt.Sprintf("exit code %d", exitCode)
+	case ctx.Err() != nil:
+		run.Status = domain.JobRunCanceled
+		run.Error = context.Cause(ctx).Error()
+	case errors.Is(err, context.DeadlineExceeded):
+		run.Status = domain.JobRunTimedOut
+		run.Error = fmt.Sprintf("did not finish within %s", job.EffectiveTimeout())
+	default:
+		run.Status = domain.JobRunFailed
+		run.Error = err.Error()
+	}
+
+	if err := s.store.SaveRun(context.WithoutCancel(ctx), run); err != nil {
+		log.Warn().Err(err).Str("run_id", run.ID).Msg("failed to record job run")
+	}
+	event := log.Info()
+	if run.Status != domain.JobRunSucceeded {
+		event = log.Warn()
+	}
+	event.Str("run_id", run.ID).
+		Str("status", string(run.Status)).
+		Int("exit_code", run.ExitCode).
+		Dur("duration", run.FinishedAt.Sub(run.StartedAt)).
+		Str("error", run.Error).
+		Msg("job run finished")
+}
+
+func (s *Service) runTask(ctx context.Context, job domain.ScheduledJob, output *tailBuffer) (int, error) {
+	route, err := s.routes.GetRoute(ctx, job.Route)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, job.EffectiveTimeout())
+	defer cancel()
+
+	exitCode, err := s.tasks.RunTask(ctx, *route, domain.TaskRequest{Command: job.Command}, output, output)
+	if err != nil && ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	return exitCode, err
+}
+
+// tailBuffer keeps the last max bytes written to it.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+	max int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > 2*b.max {
+		b.buf = append([]byte(nil), b.buf[len(b.buf)-b.max:]...)
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.buf) > b.max {
+		return string(b.buf[len(b.buf)-b.max:])
+	}
+	return string(b.buf)
+}
@@ -0,0 +1,192 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// recordingStore returns a job run store mock that keeps the saved runs.
+func recordingStore(t *testing.T) (*outmocks.MockJobRunStore, func() []domain.JobRun) {
+	store := outmocks.NewMockJobRunStore(t)
+	var mu sync.Mutex
+	var saved []domain.JobRun
+	store.EXPECT().SaveRun(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, run domain.JobRun) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, run)
+		return nil
+	}).Maybe()
+	return store, func() []domain.JobRun {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]domain.JobRun(nil), saved...)
+	}
+}
+
+func newTestService(t *testing.T, job domain.ScheduledJob) (*Service, *inmocks.MockTaskService, func() []domain.JobRun) {
+	tasks := inmocks.NewMockTaskService(t)
+	routes := inmocks.NewMockConfigService(t)
+	routes.EXPECT().GetRoute(mock.Anything, job.Route).Return(&domain.Route{Domain: job.Route, Image: "app:latest"}, nil).Maybe()
+	store, saved := recordingStore(t)
+
+	svc := NewService(tasks, routes, store, zerowrap.Default())
+	require.NoError(t, svc.Reconcile(context.Background(), []domain.ScheduledJob{job}))
+	return svc, tasks, saved
+}
+
+func testJob(overlap domain.JobOverlapPolicy) domain.ScheduledJob {
+	return domain.ScheduledJob{
+		Route:    "app.example.com",
+		Name:     "cleanup",
+		Schedule: domain.CronSchedule{Expression: "*/15 * * * *", Location: time.UTC},
+		Command:  []string{"bin/cleanup"},
+		Overlap:  overlap,
+	}
+}
+
+func TestService_RunJob_RecordsOutputAndExitCode(t *testing.T) {
+	svc, tasks, saved := newTestService(t, testJob(""))
+	tasks.EXPECT().RunTask(mock.Anything, mock.Anything, domain.TaskRequest{Command: []string{"bin/cleanup"}}, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ domain.Route, _ domain.TaskRequest, stdout, stderr io.Writer) (int, error) {
+			_, _ = io.WriteString(stdout, "removed 3 sessions\n")
+			_, _ = io.WriteString(stderr, "warning: slow\n")
+			return 2, nil
+		})
+
+	run, err := svc.RunJob(context.Background(), "app.example.com", "cleanup")
+	require.NoError(t, err)
+	assert.Equal(t, domain.JobRunRunning, run.Status)
+	assert.Equal(t, domain.JobTriggerManual, run.Trigger)
+	svc.wg.Wait()
+
+	runs := saved()
+	require.Len(t, runs, 2)
+	finished := runs[1]
+	assert.Equal(t, run.ID, finished.ID)
+	assert.Equal(t, domain.JobRunFailed, finished.Status)
+	assert.Equal(t, 2, finished.ExitCode)
+	assert.Equal(t, "removed 3 sessions\nwarning: slow\n", finished.Output)
+}
+
+func TestService_RunJob_UnknownJob(t *testing.T) {
+	svc, _, _ := newTestService(t, testJob(""))
+
+	_, err := svc.RunJob(context.Background(), "app.example.com", "missing")
+
+	require.ErrorIs(t, err, domain.ErrJobNotFound)
+}
+
+func TestService_RunJob_OverlapPolicies(t *testing.T) {
+	tests := []struct {
+		overlap    domain.JobOverlapPolicy
+		second     domain.JobRunStatus
+		firstFinal domain.JobRunStatus
+	}{
+		{domain.JobOverlapForbid, domain.JobRunSkipped, domain.JobRunSucceeded},
+		{domain.JobOverlapAllow, domain.JobRunRunning, domain.JobRunSucceeded},
+		{domain.JobOverlapReplace, domain.JobRunRunning, domain.JobRunCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.overlap), func(t *testing.T) {
+			svc, tasks, saved := newTestService(t, testJob(tt.overlap))
+			release := make(chan struct{})
+			started := make(chan struct{}, 2)
+			tasks.EXPECT().RunTask(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				RunAndReturn(func(ctx context.Context, _ domain.Route, _ domain.TaskRequest, _, _ io.Writer) (int, error) {
+					started <- struct{}{}
+					select {
+					case <-release:
+						return 0, nil
+					case <-ctx.Done():
+						return 0, ctx.Err()
+					}
+				})
+
+			first, err := svc.RunJob(context.Background(), "app.example.com", "cleanup")
+			require.NoError(t, err)
+			<-started
+			second, err := svc.RunJob(context.Background(), "app.example.com", "cleanup")
+			require.NoError(t, err)
+			assert.Equal(t, tt.second, second.Status)
+
+			close(release)
+			svc.wg.Wait()
+
+			var firstFinal domain.JobRun
+			for _, run := range saved() {
+				if run.ID == first.ID && run.Status.Finished() {
+					firstFinal = run
+				}
+			}
+			assert.Equal(t, tt.firstFinal, firstFinal.Status)
+		})
+	}
+}
+
+func TestService_RunJob_TimesOut(t *testing.T) {
+	job := testJob("")
+	job.Timeout = 10 * time.Millisecond
+	svc, tasks, saved := newTestService(t, job)
+	tasks.EXPECT().RunTask(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, _ domain.Route, _ domain.TaskRequest, _, _ io.Writer) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+
+	_, err := svc.RunJob(context.Background(), "app.example.com", "cleanup")
+	require.NoError(t, err)
+	svc.wg.Wait()
+
+	runs := saved()
+	require.Len(t, runs, 2)
+	assert.Equal(t, domain.JobRunTimedOut, runs[1].Status)
+}
+
+func TestService_ListJobs_IncludesNextAndLastRun(t *testing.T) {
+	tasks := inmocks.NewMockTaskService(t)
+	routes := inmocks.NewMockConfigService(t)
+	store := outmocks.NewMockJobRunStore(t)
+	last := domain.JobRun{ID: "run-1", Route: "app.example.com", Job: "cleanup", Status: domain.JobRunSucceeded}
+	store.EXPECT().ListRuns(mock.Anything, "app.example.com", "cleanup").Return([]domain.JobRun{last}, nil)
+
+	svc := NewService(tasks, routes, store, zerowrap.Default())
+	require.NoError(t, svc.Reconcile(context.Background(), []domain.ScheduledJob{testJob("")}))
+
+	statuses, err := svc.ListJobs(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "cleanup", statuses[0].Job.Name)
+	assert.False(t, statuses[0].NextRun.IsZero())
+	assert.Equal(t, &last, statuses[0].LastRun)
+}
+
+func TestService_Reconcile_UpdatesSchedules(t *testing.T) {
+	svc, _, _ := newTestService(t, testJob(""))
+	changed := testJob("")
+	changed.Schedule.Expression = "@daily"
+	added := testJob("")
+	added.Name = "report"
+
+	require.NoError(t, svc.Reconcile(context.Background(), []domain.ScheduledJob{changed, added}))
+
+	entries := svc.scheduler.List()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "@daily", svc.jobs["app.example.com/cleanup"].Schedule.Expression)
+
+	require.NoError(t, svc.Reconcile(context.Background(), nil))
+	assert.Empty(t, svc.scheduler.List())
+}
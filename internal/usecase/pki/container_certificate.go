@@ -0,0 +1,71 @@
+package pki
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"path"
+	"time"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// Files written into the TLS directory of a container that terminates TLS
+// with a certificate from the internal CA.
+const (
+	ContainerTLSCertFile = "tls.crt"
+	ContainerTLSKeyFile  = "tls.key"
+	ContainerTLSCAFile   = "ca.crt"
+
+	maxContainerCertificateSize = 64 << 10 // 64 KiB
+)
+
+// ContainerCertificateNeedsRenewal reports whether the certificate in the
+// container's TLS directory is missing, unreadable, not issued by the
+// current root CA, or past two thirds of its lifetime.
+func ContainerCertificateNeedsRenewal(ctx context.Context, runtime out.ContainerRuntime, ca out.CertificateAuthority, containerID string, tlsCfg domain.StandaloneServiceTLS) bool {
+	if ca == nil {
+		return true
+	}
+	reader, err := runtime.CopyFromContainer(ctx, containerID, path.Join(tlsCfg.Path, ContainerTLSCertFile))
+	if err != nil {
+		return true
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(io.LimitReader(reader, maxContainerCertificateSize))
+	if err != nil {
+		return true
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return true
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return true
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca.RootCertificate()) {
+		return true
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}}); err != nil {
+		return true
+	}
+	return time.Until(cert.NotAfter) < tlsCfg.TTL/3
+}
+
+// WriteContainerCertificate writes cert, its key and the root CA into the
+// container's TLS directory, owned by the configured uid and gid. The key is
+// cleared from memory afterwards.
+func WriteContainerCertificate(ctx context.Context, runtime out.ContainerRuntime, containerID string, cert *domain.ServiceCertificate, tlsCfg domain.StandaloneServiceTLS) error {
+	files := []domain.ContainerFile{
+		{Name: ContainerTLSCAFile, Data: cert.CAPEM, Mode: 0o644, UID: tlsCfg.UID, GID: tlsCfg.GID},
+		{Name: ContainerTLSCertFile, Data: cert.CertPEM, Mode: 0o644, UID: tlsCfg.UID, GID: tlsCfg.GID},
+		{Name: ContainerTLSKeyFile, Data: cert.KeyPEM, Mode: 0o600, UID: tlsCfg.UID, GID: tlsCfg.GID},
+	}
+	err := runtime.CopyToContainer(ctx, containerID, tlsCfg.Path, files)
+	clear(cert.KeyPEM)
+	return err
+}
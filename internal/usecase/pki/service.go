@@ -112,6 +112,60 @@ func (s *Service) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate,
 	return result.(*tls.Certificate), nil
 }
 
+// ClientAuthTLSConfig is the tls.Config.GetConfigForClient callback for
+// routes with client_auth. It returns (nil, nil) when the route behind the
+// SNI does not ask for client certificates, so base is used unchanged.
+// Otherwise it returns a copy of base that requests a certificate chaining
+// to the route's trust bundle and rejects revoked certificates.
+func (s *Service) ClientAuthTLSConfig(hello *tls.ClientHelloInfo, base *tls.Config) (*tls.Config, error) {
+	policy := s.clientAuthPolicy(hello.Context(), canonicalServerName(hello.ServerName))
+	if policy == nil {
+		return nil, nil
+	}
+
+	pool, err := s.ca.ClientCAPool(policy.CAFile)
+	if err != nil {
+		s.log.Error().Err(err).Str("domain", hello.ServerName).Msg("failed to load client CA bundle")
+		return nil, fmt.Errorf("client auth for %q: %w", hello.ServerName, err)
+	}
+
+	cfg := base.Clone()
+	cfg.GetConfigForClient = nil
+	cfg.ClientCAs = pool
+	cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	if policy.Mode == domain.ClientAuthRequire {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	// Tickets are not bound to the trust bundle, so a session verified for
+	// one route must not resume on another.
+	cfg.SessionTicketsDisabled = true
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if len(cs.VerifiedChains) > 0 && s.ca.IsClientCertificateRevoked(cs.VerifiedChains[0][0]) {
+			return domain.ErrClientCertificateRevoked
+		}
+		return nil
+	}
+	return cfg, nil
+}
+
+func (s *Service) clientAuthPolicy(ctx context.Context, domainName string) *domain.ClientAuthPolicy {
+	if domainName == "" {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for _, r := range s.routes.GetRoutes(ctx) {
+		if r.Domain == domainName {
+			if r.ClientAuth == nil || r.ClientAuth.Mode == "" {
+				return nil
+			}
+			return r.ClientAuth
+		}
+	}
+	return nil
+}
+
 func canonicalServerName(serverName string) string {
 	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(serverName), "."))
 }
@@ -3,7 +3,9 @@ package pki_test
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -232,3 +234,80 @@ func TestService_GetCertificate_Caching(t *testing.T) {
 	// Same pointer = served from cache
 	assert.Same(t, cert1, cert2, "second call should return cached cert")
 }
+
+// clientAuthHandshake runs a TLS handshake for serverName against svc and
+// returns the server-side result.
+func clientAuthHandshake(t *testing.T, svc *pkiusecase.Service, ca *pkiadapter.CA, serverName string, clientCert *tls.Certificate) (tls.ConnectionState, error) {
+	t.Helper()
+	base := &tls.Config{MinVersion: tls.VersionTLS12, GetCertificate: svc.GetCertificate}
+	base.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		return svc.ClientAuthTLSConfig(hello, base)
+	}
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(ca.RootCertificate()))
+	clientCfg := &tls.Config{ServerName: serverName, RootCAs: roots}
+	if clientCert != nil {
+		clientCfg.Certificates = []tls.Certificate{*clientCert}
+	}
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	go func() {
+		defer clientConn.Close()
+		client := tls.Client(clientConn, clientCfg)
+		if client.Handshake() == nil {
+			_, _ = client.Read(make([]byte, 1))
+		}
+	}()
+
+	server := tls.Server(serverConn, base)
+	require.NoError(t, server.SetDeadline(time.Now().Add(5*time.Second)))
+	err := server.Handshake()
+	return server.ConnectionState(), err
+}
+
+func TestService_ClientAuthTLSConfig(t *testing.T) {
+	ca, err := pkiadapter.NewCA(t.TempDir(), testLogger())
+	require.NoError(t, err)
+
+	routes := mocks.NewMockRouteChecker(t)
+	routes.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{
+		{Domain: "admin.example.com", ClientAuth: &domain.ClientAuthPolicy{Mode: domain.ClientAuthRequire}},
+		{Domain: "wiki.example.com", ClientAuth: &domain.ClientAuthPolicy{Mode: domain.ClientAuthOptional}},
+		{Domain: "app.example.com"},
+	}).Maybe()
+	routes.EXPECT().GetExternalRoutes().Return(nil).Maybe()
+
+	svc := pkiusecase.NewService(t.Context(), ca, routes, nil, testLogger())
+	defer svc.Stop()
+
+	issued, err := ca.IssueClientCertificate("laptop", time.Hour)
+	require.NoError(t, err)
+	clientCert, err := tls.X509KeyPair(issued.CertPEM, issued.KeyPEM)
+	require.NoError(t, err)
+
+	state, err := clientAuthHandshake(t, svc, ca, "admin.example.com", &clientCert)
+	require.NoError(t, err)
+	require.NotEmpty(t, state.VerifiedChains)
+	assert.Equal(t, "laptop", state.VerifiedChains[0][0].Subject.CommonName)
+
+	_, err = clientAuthHandshake(t, svc, ca, "admin.example.com", nil)
+	assert.Error(t, err, "require mode rejects clients without a certificate")
+
+	state, err = clientAuthHandshake(t, svc, ca, "wiki.example.com", nil)
+	require.NoError(t, err, "optional mode accepts clients without a certificate")
+	assert.Empty(t, state.VerifiedChains)
+
+	state, err = clientAuthHandshake(t, svc, ca, "app.example.com", &clientCert)
+	require.NoError(t, err)
+	assert.Empty(t, state.PeerCertificates, "routes without client_auth do not ask for certificates")
+
+	require.NoError(t, ca.RevokeClientCertificate(issued.Serial))
+	_, err = clientAuthHandshake(t, svc, ca, "admin.example.com", &clientCert)
+	assert.ErrorIs(t, err, domain.ErrClientCertificateRevoked)
+
+	cfg, err := svc.ClientAuthTLSConfig(&tls.ClientHelloInfo{ServerName: "app.example.com"}, &tls.Config{})
+	require.NoError(t, err)
+	assert.Nil(t, cfg)
+}
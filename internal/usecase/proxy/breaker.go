@@ -0,0 +1,169 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// circuitBreaker tracks consecutive upstream errors of one route.
+//
+// It opens after BreakerThreshold consecutive errors and fails fast until
+// BreakerCooldown has elapsed. A single trial request then decides whether
+// the circuit closes again or stays open for another cooldown.
+type circuitBreaker struct {
+	failures    int
+	openedAt    time.Time // zero while closed
+	probing     bool      // a half-open trial request is in flight
+	lastError   string
+	lastErrorAt time.Time
+}
+
+func (b *circuitBreaker) state(policy domain.UpstreamPolicy, now time.Time) string {
+	switch {
+	case b.openedAt.IsZero():
+		return domain.CircuitClosed
+	case now.Before(b.openedAt.Add(policy.BreakerCooldown)):
+		return domain.CircuitOpen
+	default:
+		return domain.CircuitHalfOpen
+	}
+}
+
+// allow reports whether a request may be forwarded, and whether it is the
+// half-open trial request.
+func (b *circuitBreaker) allow(policy domain.UpstreamPolicy, now time.Time) (allowed, trial bool) {
+	switch b.state(policy, now) {
+	case domain.CircuitOpen:
+		return false, false
+	case domain.CircuitHalfOpen:
+		if b.probing {
+			return false, false
+		}
+		b.probing = true
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+func (b *circuitBreaker) record(policy domain.UpstreamPolicy, now time.Time, err error, trial bool) {
+	if trial {
+		b.probing = false
+	}
+	if errors.Is(err, context.Canceled) {
+		// The client went away before the upstream answered. That says
+		// nothing about the upstream: a trial leaves the circuit half-open
+		// for the next request to try.
+		return
+	}
+	if err == nil {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	b.lastError = err.Error()
+	b.lastErrorAt = now
+	if trial || (b.openedAt.IsZero() && b.failures >= policy.BreakerThreshold) {
+		b.openedAt = now
+	}
+}
+
+// AcquireUpstream checks the circuit breaker of a route before a request is
+// forwarded to its upstream. It fails with domain.ErrUpstreamCircuitOpen
+// while the circuit is open; otherwise the returned function must be called
+// with the upstream error, nil on success, or context.Canceled when the client
+// went away first, once the request completes.
+func (s *Service) AcquireUpstream(domainName string, policy domain.UpstreamPolicy) (func(error), error) {
+	canonicalDomain, ok := domain.CanonicalRouteDomain(domainName)
+	if !ok || policy.BreakerThreshold <= 0 {
+		return func(error) {}, nil
+	}
+
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+
+	if s.breakers == nil {
+		s.breakers = make(map[string]*circuitBreaker)
+	}
+	b, exists := s.breakers[canonicalDomain]
+	if !exists {
+		b = &circuitBreaker{}
+		s.breakers[canonicalDomain] = b
+	}
+
+	allowed, trial := b.allow(policy, s.clock())
+	if !allowed {
+		return nil, domain.ErrUpstreamCircuitOpen
+	}
+
+	return func(err error) {
+		s.breakerMu.Lock()
+		defer s.breakerMu.Unlock()
+		b.record(policy, s.clock(), err, trial)
+	}, nil
+}
+
+// UpstreamStatus returns the circuit breaker state of a route's upstream.
+func (s *Service) UpstreamStatus(ctx context.Context, domainName string) (domain.UpstreamStatus, error) {
+	canonicalDomain, ok := domain.CanonicalRouteDomain(domainName)
+	if !ok {
+		return domain.UpstreamStatus{}, domain.ErrRouteDomainInvalid
+	}
+
+	route, err := s.configSvc.GetRoute(ctx, canonicalDomain)
+	if err != nil {
+		if !errors.Is(err, domain.ErrRouteNotFound) {
+			return domain.UpstreamStatus{}, err
+		}
+		if _, external := s.configSvc.GetExternalRoutes()[canonicalDomain]; !external {
+			return domain.UpstreamStatus{}, domain.ErrRouteNotFound
+		}
+		route = nil
+	}
+	policy := s.upstreamPolicy(route)
+
+	status := domain.UpstreamStatus{
+		Domain:    canonicalDomain,
+		State:     domain.CircuitClosed,
+		Threshold: policy.BreakerThreshold,
+		Cooldown:  policy.BreakerCooldown,
+	}
+	if policy.BreakerThreshold <= 0 {
+		status.State = domain.CircuitDisabled
+	}
+
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+	b, exists := s.breakers[canonicalDomain]
+	if !exists {
+		return status, nil
+	}
+	if status.State != domain.CircuitDisabled {
+		status.State = b.state(policy, s.clock())
+	}
+	status.ConsecutiveFailures = b.failures
+	status.OpenedAt = b.openedAt
+	status.LastError = b.lastError
+	status.LastErrorAt = b.lastErrorAt
+	return status, nil
+}
+
+// resetBreaker forgets the error history of a route, e.g. once a new
+// container has replaced the failing one.
+func (s *Service) resetBreaker(canonicalDomain string) {
+	s.breakerMu.Lock()
+	defer s.breakerMu.Unlock()
+	delete(s.breakers, canonicalDomain)
+}
+
+func (s *Service) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
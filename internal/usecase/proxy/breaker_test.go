@@ -0,0 +1,183 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestService_AcquireUpstream_OpensAfterThreshold(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	policy := domain.UpstreamPolicy{BreakerThreshold: 2, BreakerCooldown: 30 * time.Second}
+	upstreamErr := errors.New("connection refused")
+
+	for range 2 {
+		release, err := svc.AcquireUpstream("app.example.com", policy)
+		require.NoError(t, err)
+		release(upstreamErr)
+	}
+
+	_, err := svc.AcquireUpstream("App.Example.com", policy)
+	assert.ErrorIs(t, err, domain.ErrUpstreamCircuitOpen)
+
+	// After the cooldown a single trial request is let through.
+	now = now.Add(31 * time.Second)
+	release, err := svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	_, err = svc.AcquireUpstream("app.example.com", policy)
+	assert.ErrorIs(t, err, domain.ErrUpstreamCircuitOpen)
+
+	// A failed trial reopens the circuit for another cooldown.
+	release(upstreamErr)
+	_, err = svc.AcquireUpstream("app.example.com", policy)
+	assert.ErrorIs(t, err, domain.ErrUpstreamCircuitOpen)
+
+	// A successful trial closes it.
+	now = now.Add(31 * time.Second)
+	release, err = svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	release(nil)
+	release, err = svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	release(nil)
+}
+
+func TestService_AcquireUpstream_SuccessResetsFailures(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+	policy := domain.UpstreamPolicy{BreakerThreshold: 2, BreakerCooldown: time.Minute}
+	upstreamErr := errors.New("connection reset")
+
+	for _, err := range []error{upstreamErr, nil, upstreamErr} {
+		release, acquireErr := svc.AcquireUpstream("app.example.com", policy)
+		require.NoError(t, acquireErr)
+		release(err)
+	}
+
+	release, err := svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	release(nil)
+}
+
+func TestService_AcquireUpstream_ClientCancelIsNoVerdict(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	policy := domain.UpstreamPolicy{BreakerThreshold: 2, BreakerCooldown: 30 * time.Second}
+	upstreamErr := errors.New("connection refused")
+
+	// A canceled request neither resets nor adds to the failure count.
+	for _, err := range []error{upstreamErr, context.Canceled, upstreamErr} {
+		release, acquireErr := svc.AcquireUpstream("app.example.com", policy)
+		require.NoError(t, acquireErr)
+		release(err)
+	}
+	_, err := svc.AcquireUpstream("app.example.com", policy)
+	assert.ErrorIs(t, err, domain.ErrUpstreamCircuitOpen)
+
+	// A trial the client abandons leaves the circuit half-open and frees
+	// the trial slot for the next request.
+	now = now.Add(31 * time.Second)
+	release, err := svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	release(fmt.Errorf("proxy: %w", context.Canceled))
+
+	status := svc.breakers["app.example.com"]
+	assert.Equal(t, domain.CircuitHalfOpen, status.state(policy, now))
+	assert.Equal(t, 2, status.failures)
+
+	release, err = svc.AcquireUpstream("app.example.com", policy)
+	require.NoError(t, err)
+	_, err = svc.AcquireUpstream("app.example.com", policy)
+	assert.ErrorIs(t, err, domain.ErrUpstreamCircuitOpen)
+	release(nil)
+	assert.Equal(t, domain.CircuitClosed, status.state(policy, now))
+}
+
+func TestService_AcquireUpstream_DisabledWithoutThreshold(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+	policy := domain.DefaultUpstreamPolicy()
+
+	for range 5 {
+		release, err := svc.AcquireUpstream("app.example.com", policy)
+		require.NoError(t, err)
+		release(errors.New("connection refused"))
+	}
+	assert.Empty(t, svc.breakers)
+}
+
+func TestService_UpstreamStatus(t *testing.T) {
+	ctx := testContext()
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(nil, nil, configSvc, Config{})
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	threshold := 1
+	route := &domain.Route{
+		Domain:   "app.example.com",
+		Upstream: &domain.UpstreamOverrides{BreakerThreshold: &threshold},
+	}
+	configSvc.EXPECT().GetRoute(ctx, "app.example.com").Return(route, nil)
+
+	release, err := svc.AcquireUpstream("app.example.com", domain.DefaultUpstreamPolicy().WithOverrides(route.Upstream))
+	require.NoError(t, err)
+	release(errors.New("dial tcp: connection refused"))
+
+	status, err := svc.UpstreamStatus(ctx, "App.Example.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CircuitOpen, status.State)
+	assert.Equal(t, 1, status.ConsecutiveFailures)
+	assert.Equal(t, 1, status.Threshold)
+	assert.Equal(t, domain.DefaultUpstreamBreakerCooldown, status.Cooldown)
+	assert.Equal(t, now, status.OpenedAt)
+	assert.Equal(t, "dial tcp: connection refused", status.LastError)
+
+	svc.InvalidateTarget(ctx, "app.example.com")
+	status, err = svc.UpstreamStatus(ctx, "app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CircuitClosed, status.State)
+	assert.Zero(t, status.ConsecutiveFailures)
+}
+
+func TestService_UpstreamStatus_UnknownRoute(t *testing.T) {
+	ctx := testContext()
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(nil, nil, configSvc, Config{})
+
+	configSvc.EXPECT().GetRoute(ctx, "missing.example.com").Return(nil, domain.ErrRouteNotFound)
+	configSvc.EXPECT().GetExternalRoutes().Return(map[string]string{})
+
+	_, err := svc.UpstreamStatus(ctx, "missing.example.com")
+	assert.ErrorIs(t, err, domain.ErrRouteNotFound)
+}
+
+func TestService_ApplyRoutePolicies_Upstream(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+
+	target := &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com"})
+	assert.Nil(t, target.Upstream)
+
+	retries := 2
+	headerTimeout := 5 * time.Minute
+	target = &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{
+		Domain:   "app.example.com",
+		Upstream: &domain.UpstreamOverrides{Retries: &retries, HeaderTimeout: &headerTimeout},
+	})
+	if assert.NotNil(t, target.Upstream) {
+		assert.Equal(t, 2, target.Upstream.Retries)
+		assert.Equal(t, 5*time.Minute, target.Upstream.HeaderTimeout)
+		assert.Equal(t, domain.DefaultUpstreamDialTimeout, target.Upstream.DialTimeout)
+	}
+}
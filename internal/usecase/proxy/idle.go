@@ -0,0 +1,236 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// defaultIdleCheckInterval is how often route containers are checked against
+// their idle_timeout when StartIdleLoop is given no interval.
+const defaultIdleCheckInterval = 30 * time.Second
+
+// wakeCall coalesces concurrent wake-ups of the same sleeping route.
+type wakeCall struct {
+	done      chan struct{}
+	container *domain.Container
+	err       error
+}
+
+// touchActivity records that containerID just served traffic.
+// Callers must hold inFlightMu.
+func (s *Service) touchActivity(containerID string) {
+	if s.lastActivity == nil {
+		s.lastActivity = make(map[string]time.Time)
+	}
+	s.lastActivity[containerID] = time.Now()
+}
+
+// StartIdleLoop periodically puts route containers to sleep once they have
+// served no requests for longer than their route idle_timeout. The returned
+// channel is closed when the loop exits after ctx is cancelled.
+func (s *Service) StartIdleLoop(ctx context.Context, interval time.Duration) <-chan struct{} {
+	if interval <= 0 {
+		interval = defaultIdleCheckInterval
+	}
+
+	done := make(chan struct{})
+	loopCtx := zerowrap.CtxWithFields(ctx, map[string]any{
+		zerowrap.FieldLayer:   "usecase",
+		zerowrap.FieldUseCase: "SleepIdleRoutes",
+	})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-ticker.C:
+				s.sleepIdleRoutes(loopCtx, time.Now())
+			}
+		}
+	}()
+
+	return done
+}
+
+// sleepIdleRoutes sleeps every running route container whose idle timeout
+// has elapsed without requests.
+func (s *Service) sleepIdleRoutes(ctx context.Context, now time.Time) {
+	log := zerowrap.FromCtx(ctx)
+
+	for _, route := range s.configSvc.GetRoutes(ctx) {
+		if route.IdleTimeout <= 0 {
+			continue
+		}
+		container, exists := s.containerSvc.Get(ctx, route.Domain)
+		if !exists || container == nil || container.Status == string(domain.ContainerStatusSleeping) {
+			continue
+		}
+		s.sleepIdleRoute(ctx, log, route, container.ID, now)
+	}
+}
+
+// sleepIdleRoute puts one idle route to sleep. The route is claimed for
+// sleeping under the same locks handOutTarget uses, so a request either
+// touched the container before the idle check, which keeps it awake, or
+// sees the route sleeping and waits to wake it. No request can be proxied
+// to the container while it is being stopped.
+func (s *Service) sleepIdleRoute(ctx context.Context, log zerowrap.Logger, route domain.Route, containerID string, now time.Time) {
+	domainName := route.Domain
+	done := make(chan struct{})
+	s.wakeMu.Lock()
+	if !s.idleSince(containerID, now, route.IdleTimeout) {
+		s.wakeMu.Unlock()
+		return
+	}
+	s.sleeping[domainName] = done
+	s.wakeMu.Unlock()
+	defer func() {
+		s.wakeMu.Lock()
+		delete(s.sleeping, domainName)
+		s.wakeMu.Unlock()
+		close(done)
+	}()
+
+	s.InvalidateTarget(ctx, domainName)
+	if err := s.containerSvc.Sleep(ctx, domainName); err != nil {
+		log.Warn().Err(err).Str("domain", domainName).Msg("failed to put idle route to sleep")
+		return
+	}
+	// A request that resolved the route before it was claimed may have
+	// cached the target again; it points at the stopped container now.
+	s.InvalidateTarget(ctx, domainName)
+
+	s.inFlightMu.Lock()
+	delete(s.lastActivity, containerID)
+	s.inFlightMu.Unlock()
+}
+
+// handOutTarget records activity on the target's container so an idle sweep
+// cannot stop it under the request, unless the route was already claimed
+// for sleeping, in which case it reports false and the caller resolves the
+// route again.
+func (s *Service) handOutTarget(domainName string, target *domain.ProxyTarget) bool {
+	if target.ContainerID == "" {
+		return true
+	}
+
+	s.wakeMu.Lock()
+	defer s.wakeMu.Unlock()
+	if _, sleeping := s.sleeping[domainName]; sleeping {
+		return false
+	}
+	s.inFlightMu.Lock()
+	s.touchActivity(target.ContainerID)
+	s.inFlightMu.Unlock()
+	return true
+}
+
+// waitForSleep blocks while domainName is being put to sleep.
+func (s *Service) waitForSleep(ctx context.Context, domainName string) error {
+	s.wakeMu.Lock()
+	done := s.sleeping[domainName]
+	s.wakeMu.Unlock()
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// idleSince reports whether containerID has had no requests in flight and no
+// activity for at least timeout. A container never seen by the proxy starts
+// its idle clock on the first check.
+func (s *Service) idleSince(containerID string, now time.Time, timeout time.Duration) bool {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+
+	if s.inFlight[containerID] > 0 {
+		return false
+	}
+	last, seen := s.lastActivity[containerID]
+	if !seen {
+		s.lastActivity[containerID] = now
+		return false
+	}
+	return now.Sub(last) >= timeout
+}
+
+// wakeRoute wakes the sleeping container for domainName. Concurrent requests
+// share one wake-up that outlives any single request. When the caller accepts
+// a "waking up" page and the route enables it, ErrRouteWaking is returned
+// immediately instead of holding the request until the container is ready.
+func (s *Service) wakeRoute(ctx context.Context, domainName string) (*domain.Container, error) {
+	call := s.startWake(ctx, domainName)
+
+	if domain.IsWakePage(ctx) && s.routeWakePage(ctx, domainName) {
+		select {
+		case <-call.done:
+			return call.container, call.err
+		default:
+			return nil, domain.ErrRouteWaking
+		}
+	}
+
+	select {
+	case <-call.done:
+		return call.container, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *Service) startWake(ctx context.Context, domainName string) *wakeCall {
+	s.wakeMu.Lock()
+	defer s.wakeMu.Unlock()
+
+	if call, ok := s.waking[domainName]; ok {
+		return call
+	}
+
+	call := &wakeCall{done: make(chan struct{})}
+	s.waking[domainName] = call
+
+	wakeCtx := context.WithoutCancel(ctx)
+	go func() {
+		log := zerowrap.FromCtx(wakeCtx)
+		log.Info().Msg("waking sleeping route on request")
+
+		call.container, call.err = s.containerSvc.Wake(wakeCtx, domainName)
+		if call.err == nil && call.container == nil {
+			call.err = errors.New("wake returned no container")
+		}
+		if call.err == nil {
+			s.inFlightMu.Lock()
+			s.touchActivity(call.container.ID)
+			s.inFlightMu.Unlock()
+		}
+
+		s.wakeMu.Lock()
+		delete(s.waking, domainName)
+		s.wakeMu.Unlock()
+		close(call.done)
+	}()
+
+	return call
+}
+
+func (s *Service) routeWakePage(ctx context.Context, domainName string) bool {
+	route, err := s.configSvc.GetRoute(ctx, domainName)
+	return err == nil && route != nil && route.WakePage
+}
@@ -0,0 +1,252 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	inmocks "github.com/bnema/gordon/internal/boundaries/in/mocks"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestService_SleepIdleRoutes_SleepsAfterTimeout(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := testContext()
+
+	routes := []domain.Route{
+		{Domain: "idle.example.com", Image: "idle:v1", IdleTimeout: time.Minute},
+		{Domain: "always.example.com", Image: "always:v1"},
+	}
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return(routes)
+	containerSvc.EXPECT().Get(mock.Anything, "idle.example.com").
+		Return(&domain.Container{ID: "idle-1", Status: "running"}, true)
+	svc.targets["idle.example.com"] = &domain.ProxyTarget{ContainerID: "idle-1"}
+
+	now := time.Now()
+	svc.lastActivity["idle-1"] = now.Add(-2 * time.Minute)
+	containerSvc.EXPECT().Sleep(mock.Anything, "idle.example.com").Return(nil).Once()
+
+	svc.sleepIdleRoutes(ctx, now)
+
+	assert.NotContains(t, svc.targets, "idle.example.com")
+	assert.NotContains(t, svc.lastActivity, "idle-1")
+}
+
+func TestService_SleepIdleRoutes_KeepsActiveContainers(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := testContext()
+
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{
+		{Domain: "busy.example.com", IdleTimeout: time.Minute},
+		{Domain: "recent.example.com", IdleTimeout: time.Minute},
+		{Domain: "new.example.com", IdleTimeout: time.Minute},
+	})
+	containerSvc.EXPECT().Get(mock.Anything, "busy.example.com").Return(&domain.Container{ID: "busy-1"}, true)
+	containerSvc.EXPECT().Get(mock.Anything, "recent.example.com").Return(&domain.Container{ID: "recent-1"}, true)
+	containerSvc.EXPECT().Get(mock.Anything, "new.example.com").Return(&domain.Container{ID: "new-1"}, true)
+
+	now := time.Now()
+	release := svc.TrackInFlight("busy-1")
+	defer release()
+	svc.lastActivity["busy-1"] = now.Add(-time.Hour)
+	svc.lastActivity["recent-1"] = now.Add(-10 * time.Second)
+
+	svc.sleepIdleRoutes(ctx, now)
+
+	// A container never seen by the proxy starts its idle clock now.
+	assert.Equal(t, now, svc.lastActivity["new-1"])
+}
+
+func TestService_SleepIdleRoutes_HoldsNewRequestsUntilAsleep(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := testContext()
+
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{{Domain: "idle.example.com", IdleTimeout: time.Minute}})
+	containerSvc.EXPECT().Get(mock.Anything, "idle.example.com").Return(&domain.Container{ID: "idle-1", Status: "running"}, true)
+
+	now := time.Now()
+	svc.lastActivity["idle-1"] = now.Add(-2 * time.Minute)
+	stopping := make(chan struct{})
+	release := make(chan struct{})
+	containerSvc.EXPECT().Sleep(mock.Anything, "idle.example.com").
+		RunAndReturn(func(context.Context, string) error {
+			close(stopping)
+			<-release
+			return nil
+		}).Once()
+
+	slept := make(chan struct{})
+	go func() {
+		defer close(slept)
+		svc.sleepIdleRoutes(ctx, now)
+	}()
+	<-stopping
+
+	// A request arriving while the container stops waits for the sleep to
+	// finish instead of resolving the container being stopped.
+	waited := make(chan error, 1)
+	go func() { waited <- svc.waitForSleep(ctx, "idle.example.com") }()
+	select {
+	case <-waited:
+		t.Fatal("request was not held while the route was put to sleep")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	require.NoError(t, <-waited)
+	<-slept
+	assert.NoError(t, svc.waitForSleep(ctx, "idle.example.com"))
+}
+
+func TestService_SleepIdleRoutes_KeepsRouteWithHandedOutTarget(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := testContext()
+
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{{Domain: "idle.example.com", IdleTimeout: time.Minute}})
+	containerSvc.EXPECT().Get(mock.Anything, "idle.example.com").Return(&domain.Container{ID: "idle-1", Status: "running"}, true)
+	cached := &domain.ProxyTarget{ContainerID: "idle-1"}
+	svc.targets["idle.example.com"] = cached
+
+	// The sweep decided on a clock read before the request got its target
+	// but has not tracked it in flight yet; the container must stay up.
+	now := time.Now()
+	svc.lastActivity["idle-1"] = now.Add(-2 * time.Minute)
+	target, err := svc.GetTarget(ctx, "idle.example.com")
+	require.NoError(t, err)
+	assert.Same(t, cached, target)
+
+	svc.sleepIdleRoutes(ctx, now)
+
+	assert.Same(t, cached, svc.targets["idle.example.com"])
+}
+
+func TestService_GetTarget_InterleavedWithSleep(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := testContext()
+
+	configSvc.EXPECT().GetRoutes(mock.Anything).Return([]domain.Route{{Domain: "idle.example.com", IdleTimeout: time.Minute}})
+	configSvc.EXPECT().GetExternalRoutes().Return(map[string]string{})
+	containerSvc.EXPECT().Get(mock.Anything, "idle.example.com").Return(&domain.Container{ID: "idle-1", Status: "running"}, true).Once()
+	containerSvc.EXPECT().Get(mock.Anything, "idle.example.com").Return(&domain.Container{ID: "idle-1", Status: "sleeping"}, true).Once()
+	svc.targets["idle.example.com"] = &domain.ProxyTarget{ContainerID: "idle-1"}
+
+	now := time.Now()
+	svc.lastActivity["idle-1"] = now.Add(-2 * time.Minute)
+	sleepCall := containerSvc.EXPECT().Sleep(mock.Anything, "idle.example.com").Return(nil).Once()
+	wakeErr := errors.New("wake failed")
+	containerSvc.EXPECT().Wake(mock.Anything, "idle.example.com").Return(nil, wakeErr).Once().NotBefore(sleepCall)
+
+	// Hold the target cache so the request resolves the stale target only
+	// after the sweep has claimed the route for sleeping.
+	svc.mu.Lock()
+	type result struct {
+		target *domain.ProxyTarget
+		err    error
+	}
+	got := make(chan result, 1)
+	go func() {
+		target, err := svc.GetTarget(ctx, "idle.example.com")
+		got <- result{target, err}
+	}()
+
+	slept := make(chan struct{})
+	go func() {
+		defer close(slept)
+		svc.sleepIdleRoutes(ctx, now)
+	}()
+	require.Eventually(t, func() bool {
+		svc.wakeMu.Lock()
+		defer svc.wakeMu.Unlock()
+		return svc.sleeping["idle.example.com"] != nil
+	}, time.Second, time.Millisecond)
+	svc.mu.Unlock()
+
+	// The request must not get the container being stopped; it waits for
+	// the sleep and then takes the wake path.
+	res := <-got
+	<-slept
+	assert.Nil(t, res.target)
+	assert.ErrorIs(t, res.err, wakeErr)
+	assert.NotContains(t, svc.targets, "idle.example.com")
+}
+
+func TestService_WakeRoute_CoalescesConcurrentWakes(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, inmocks.NewMockConfigService(t), Config{})
+	ctx := testContext()
+
+	release := make(chan struct{})
+	containerSvc.EXPECT().Wake(mock.Anything, "app.example.com").
+		RunAndReturn(func(context.Context, string) (*domain.Container, error) {
+			<-release
+			return &domain.Container{ID: "app-1", Status: "running"}, nil
+		}).Once()
+
+	results := make(chan *domain.Container, 2)
+	for range 2 {
+		go func() {
+			c, err := svc.wakeRoute(ctx, "app.example.com")
+			assert.NoError(t, err)
+			results <- c
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		svc.wakeMu.Lock()
+		defer svc.wakeMu.Unlock()
+		return svc.waking["app.example.com"] != nil
+	}, time.Second, 5*time.Millisecond)
+	close(release)
+
+	for range 2 {
+		c := <-results
+		require.NotNil(t, c)
+		assert.Equal(t, "app-1", c.ID)
+	}
+}
+
+func TestService_WakeRoute_ReturnsWakingForWakePage(t *testing.T) {
+	containerSvc := inmocks.NewMockContainerService(t)
+	configSvc := inmocks.NewMockConfigService(t)
+	svc := NewService(outmocks.NewMockContainerRuntime(t), containerSvc, configSvc, Config{})
+	ctx := domain.WithWakePage(testContext())
+
+	release := make(chan struct{})
+	woken := make(chan struct{})
+	configSvc.EXPECT().GetRoute(mock.Anything, "app.example.com").
+		Return(&domain.Route{Domain: "app.example.com", WakePage: true}, nil)
+	containerSvc.EXPECT().Wake(mock.Anything, "app.example.com").
+		RunAndReturn(func(context.Context, string) (*domain.Container, error) {
+			defer close(woken)
+			<-release
+			return &domain.Container{ID: "app-1"}, nil
+		}).Once()
+
+	c, err := svc.wakeRoute(ctx, "app.example.com")
+	assert.ErrorIs(t, err, domain.ErrRouteWaking)
+	assert.Nil(t, c)
+
+	// The wake keeps running in the background after the page is served.
+	close(release)
+	select {
+	case <-woken:
+	case <-time.After(time.Second):
+		t.Fatal("background wake did not complete")
+	}
+}
@@ -32,6 +32,9 @@ type Config struct {
 	MaxBodySize        int64 // Maximum request body size in bytes (0 = no limit)
 	MaxResponseSize    int64 // Maximum response body size in bytes (0 = no limit)
 	MaxConcurrentConns int   // Maximum concurrent proxy connections (0 = no limit)
+	Compression        domain.CompressionPolicy
+	ResponseCache      domain.ResponseCachePolicy
+	Upstream           domain.UpstreamPolicy // zero value means domain.DefaultUpstreamPolicy
 }
 
 // Service implements the ProxyService interface.
@@ -43,8 +46,16 @@ type Service struct {
 	targets          map[string]*domain.ProxyTarget
 	mu               sync.RWMutex
 	inFlight         map[string]int
+	lastActivity     map[string]time.Time // containerID → last request start/end, for idle_timeout
 	inFlightMu       sync.Mutex
+	waking           map[string]*wakeCall     // domain → in-progress wake of a sleeping route
+	sleeping         map[string]chan struct{} // domain → idle sleep in progress, closed when done
+	wakeMu           sync.Mutex
 	registryInFlight atomic.Int64 // active registry proxy requests, for graceful drain
+	responseCache    out.ResponseCacheStore
+	breakers         map[string]*circuitBreaker // canonical domain → upstream circuit breaker
+	breakerMu        sync.Mutex
+	now              func() time.Time // clock for circuit breakers; nil uses time.Now
 }
 
 // NewService creates a new proxy service.
@@ -61,6 +72,10 @@ func NewService(
 		config:       config,
 		targets:      make(map[string]*domain.ProxyTarget),
 		inFlight:     make(map[string]int),
+		lastActivity: make(map[string]time.Time),
+		waking:       make(map[string]*wakeCall),
+		sleeping:     make(map[string]chan struct{}),
+		breakers:     make(map[string]*circuitBreaker),
 	}
 }
 
@@ -89,6 +104,30 @@ func (s *Service) GetTarget(ctx context.Context, domainName string) (target *dom
 	})
 	log := zerowrap.FromCtx(ctx)
 
+	for {
+		// A route being put to sleep must not be resolved to the container
+		// that is being stopped; wait for the sleep and take the wake path.
+		if err := s.waitForSleep(ctx, domainName); err != nil {
+			return nil, err
+		}
+		target, retErr = s.resolveTarget(ctx, log, domainName)
+		if retErr != nil {
+			return nil, retErr
+		}
+		if s.handOutTarget(domainName, target) {
+			return target, nil
+		}
+		// The route was claimed for sleeping after it was resolved; resolve
+		// it again so the request wakes it instead of reaching the container
+		// being stopped.
+	}
+}
+
+// resolveTarget returns the cached target for domainName or builds it from
+// the route's external address or container.
+func (s *Service) resolveTarget(ctx context.Context, log zerowrap.Logger, domainName string) (*domain.ProxyTarget, error) {
+	var target *domain.ProxyTarget
+
 	// Check cache first
 	s.mu.RLock()
 	if target, exists := s.targets[domainName]; exists {
@@ -116,6 +155,23 @@ func (s *Service) GetTarget(ctx context.Context, domainName string) (target *dom
 	}
 	log.Debug().Str("container_id", container.ID).Str("image", container.Image).Msg("found container for domain")
 
+	if container.Status == string(domain.ContainerStatusSleeping) {
+		woken, err := s.wakeRoute(ctx, domainName)
+		if err != nil {
+			return nil, err
+		}
+		container = woken
+	}
+
+	routes := s.configSvc.GetRoutes(ctx)
+	var route *domain.Route
+	for _, r := range routes {
+		if r.Domain == domainName {
+			route = &r
+			break
+		}
+	}
+
 	// Build target based on runtime mode
 
 	if s.isRunningInContainer() {
@@ -138,15 +194,6 @@ func (s *Service) GetTarget(ctx context.Context, domainName string) (target *dom
 		}
 	} else {
 		// Gordon is on the host - use host port mapping
-		routes := s.configSvc.GetRoutes(ctx)
-		var route *domain.Route
-		for _, r := range routes {
-			if r.Domain == domainName {
-				route = &r
-				break
-			}
-		}
-
 		if route == nil {
 			return nil, domain.ErrRouteNotFound
 		}
@@ -170,6 +217,7 @@ func (s *Service) GetTarget(ctx context.Context, domainName string) (target *dom
 			RouteHost:   domainName,
 		}
 	}
+	s.applyRoutePolicies(target, route)
 
 	// Cache the target
 	s.mu.Lock()
@@ -179,6 +227,84 @@ func (s *Service) GetTarget(ctx context.Context, domainName string) (target *dom
 	return target, nil
 }
 
+// applyRoutePolicies attaches the effective per-route proxy policies to a
+// target. route may be nil for targets without a configured route.
+func (s *Service) applyRoutePolicies(target *domain.ProxyTarget, route *domain.Route) {
+	s.mu.RLock()
+	compression := s.config.Compression
+	cache := s.config.ResponseCache
+	hasStore := s.responseCache != nil
+	s.mu.RUnlock()
+
+	if route != nil && route.ClientAuth != nil {
+		target.ClientAuth = route.ClientAuth.Mode
+	}
+
+	if upstream := s.upstreamPolicy(route); upstream != domain.DefaultUpstreamPolicy() {
+		target.Upstream = &upstream
+	}
+
+	if route != nil && route.Compression != nil {
+		compression.Enabled = *route.Compression
+	}
+	if compression.Enabled {
+		target.Compression = &compression
+	}
+
+	if route != nil && route.Cache != nil {
+		cache.Enabled = *route.Cache
+	}
+	if cache.Enabled && hasStore {
+		target.Cache = &cache
+	}
+}
+
+// upstreamPolicy returns the effective upstream policy of a route. route may
+// be nil for targets without a configured route.
+func (s *Service) upstreamPolicy(route *domain.Route) domain.UpstreamPolicy {
+	s.mu.RLock()
+	policy := s.config.Upstream
+	s.mu.RUnlock()
+
+	if policy == (domain.UpstreamPolicy{}) {
+		policy = domain.DefaultUpstreamPolicy()
+	}
+	if route != nil {
+		policy = policy.WithOverrides(route.Upstream)
+	}
+	return policy
+}
+
+// SetResponseCache sets the store backing the proxy response cache.
+// Without a store, routes are never cached and purges fail.
+func (s *Service) SetResponseCache(store out.ResponseCacheStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responseCache = store
+}
+
+// PurgeResponseCache removes the cached responses of a domain, or only those
+// for path when it is not empty. It returns the number of entries removed.
+func (s *Service) PurgeResponseCache(ctx context.Context, domainName, path string) (int, error) {
+	canonicalDomain, ok := domain.CanonicalRouteDomain(domainName)
+	if !ok {
+		return 0, domain.ErrRouteDomainInvalid
+	}
+
+	s.mu.RLock()
+	store := s.responseCache
+	s.mu.RUnlock()
+	if store == nil {
+		return 0, domain.ErrResponseCacheUnavailable
+	}
+
+	purged, err := store.Purge(ctx, canonicalDomain, path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge response cache for %s: %w", canonicalDomain, err)
+	}
+	return purged, nil
+}
+
 // resolveExternalRoute resolves an external route target address into a ProxyTarget,
 // performing DNS validation and SSRF protection.
 func (s *Service) resolveExternalRoute(_ context.Context, domainName, targetAddr string, log zerowrap.Logger) (*domain.ProxyTarget, error) {
@@ -222,6 +348,7 @@ func (s *Service) resolveExternalRoute(_ context.Context, domainName, targetAddr
 		Scheme:       "http",
 		OriginalHost: originalHost,
 	}
+	s.applyRoutePolicies(t, nil)
 
 	// Cache external route target
 	s.mu.Lock()
@@ -265,6 +392,9 @@ func (s *Service) UnregisterTarget(_ context.Context, domainName string) error {
 
 // InvalidateTarget removes a cached proxy target, forcing re-lookup on next request.
 // This is used during zero-downtime deployments to switch traffic to a new container.
+// The route's circuit breaker is reset as well. Cached responses are kept,
+// since sleep and restarts invalidate targets too; deploys purge them with
+// PurgeResponseCache.
 func (s *Service) InvalidateTarget(_ context.Context, domainName string) {
 	canonicalDomain, ok := domain.CanonicalRouteDomain(domainName)
 	if !ok {
@@ -272,9 +402,9 @@ func (s *Service) InvalidateTarget(_ context.Context, domainName string) {
 	}
 
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	delete(s.targets, canonicalDomain)
+	s.mu.Unlock()
+	s.resetBreaker(canonicalDomain)
 }
 
 // WaitForNoInFlight waits until no requests are currently proxied to the
@@ -366,10 +496,12 @@ func (s *Service) TrackInFlight(containerID string) func() {
 
 	s.inFlightMu.Lock()
 	s.inFlight[containerID]++
+	s.touchActivity(containerID)
 	s.inFlightMu.Unlock()
 
 	return func() {
 		s.inFlightMu.Lock()
+		s.touchActivity(containerID)
 		if s.inFlight[containerID] > 1 {
 			s.inFlight[containerID]--
 		} else {
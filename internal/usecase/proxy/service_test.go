@@ -812,3 +812,84 @@ func TestService_GetTarget_HostMode_DefaultProtocol(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "", result.Protocol)
 }
+
+func TestService_ApplyRoutePolicies_Compression(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{
+		Compression: domain.CompressionPolicy{Enabled: true, Algorithms: []string{domain.CompressionGzip}},
+	})
+
+	target := &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com"})
+	if assert.NotNil(t, target.Compression) {
+		assert.Equal(t, []string{domain.CompressionGzip}, target.Compression.Algorithms)
+	}
+
+	disabled := false
+	target = &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com", Compression: &disabled})
+	assert.Nil(t, target.Compression)
+
+	svc.UpdateConfig(Config{})
+	enabled := true
+	target = &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com", Compression: &enabled})
+	assert.NotNil(t, target.Compression)
+}
+
+func TestService_ApplyRoutePolicies_ResponseCache(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{
+		ResponseCache: domain.ResponseCachePolicy{Enabled: true, MaxObjectSize: 1024},
+	})
+
+	target := &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, nil)
+	assert.Nil(t, target.Cache, "caching requires a store")
+
+	svc.SetResponseCache(outmocks.NewMockResponseCacheStore(t))
+	svc.applyRoutePolicies(target, nil)
+	if assert.NotNil(t, target.Cache) {
+		assert.Equal(t, int64(1024), target.Cache.MaxObjectSize)
+	}
+
+	disabled := false
+	target = &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com", Cache: &disabled})
+	assert.Nil(t, target.Cache)
+}
+
+func TestService_ApplyRoutePolicies_ClientAuth(t *testing.T) {
+	svc := NewService(nil, nil, nil, Config{})
+
+	target := &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "admin.example.com", ClientAuth: &domain.ClientAuthPolicy{Mode: domain.ClientAuthRequire}})
+	assert.Equal(t, domain.ClientAuthRequire, target.ClientAuth)
+
+	target = &domain.ProxyTarget{}
+	svc.applyRoutePolicies(target, &domain.Route{Domain: "app.example.com"})
+	assert.Empty(t, target.ClientAuth)
+}
+
+func TestService_PurgeResponseCache(t *testing.T) {
+	ctx := testContext()
+	svc := NewService(nil, nil, nil, Config{})
+
+	_, err := svc.PurgeResponseCache(ctx, "app.example.com", "")
+	assert.ErrorIs(t, err, domain.ErrResponseCacheUnavailable)
+
+	store := outmocks.NewMockResponseCacheStore(t)
+	store.EXPECT().Purge(mock.Anything, "app.example.com", "/a").Return(2, nil)
+	svc.SetResponseCache(store)
+
+	purged, err := svc.PurgeResponseCache(ctx, "App.Example.com", "/a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, purged)
+}
+
+func TestService_InvalidateTarget_KeepsResponseCache(t *testing.T) {
+	ctx := testContext()
+	svc := NewService(nil, nil, nil, Config{})
+	// Sleep, wake and restarts invalidate targets; the mock fails on any Purge.
+	svc.SetResponseCache(outmocks.NewMockResponseCacheStore(t))
+
+	svc.InvalidateTarget(ctx, "App.Example.com")
+}
@@ -0,0 +1,108 @@
+package publictls
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// expiryAlertWindow returns how long before expiry a certificate raises an
+// alert.
+func (s *Service) expiryAlertWindow() time.Duration {
+	days := s.cfg.ExpiryAlertDays
+	if days <= 0 {
+		days = domain.DefaultTLSExpiryAlertDays
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// renewalFailureThreshold returns the number of consecutive failed renewals
+// that raises an alert.
+func (s *Service) renewalFailureThreshold() int {
+	if s.cfg.RenewalFailureThreshold <= 0 {
+		return domain.DefaultTLSRenewalFailureThreshold
+	}
+	return s.cfg.RenewalFailureThreshold
+}
+
+// certificateAlertLocked returns the alert raised by cert, or the empty alert.
+// Must be called with s.mu held.
+func (s *Service) certificateAlertLocked(cert *out.StoredCertificate, now time.Time) domain.TLSCertificateAlert {
+	switch {
+	case cert.OCSP != nil && cert.OCSP.Status == domain.OCSPStatusRevoked:
+		return domain.TLSCertificateAlertRevoked
+	case s.renewFailures[cert.ID] >= s.renewalFailureThreshold():
+		return domain.TLSCertificateAlertRenewalFailing
+	case !cert.NotAfter.IsZero() && !now.Add(s.expiryAlertWindow()).Before(cert.NotAfter):
+		return domain.TLSCertificateAlertExpiring
+	default:
+		return ""
+	}
+}
+
+// checkCertificateAlerts publishes a tls.certificate_alert event and records
+// a metric for each certificate whose alert changed since the last check.
+// Alerts are raised once per reason, not on every check.
+func (s *Service) checkCertificateAlerts(ctx context.Context, now time.Time) {
+	var raised []domain.TLSCertificateAlertPayload
+
+	s.mu.Lock()
+	for id := range s.alerts {
+		if _, ok := s.certs[id]; !ok {
+			delete(s.alerts, id)
+		}
+	}
+	for id, cert := range s.certs {
+		alert := s.certificateAlertLocked(cert, now)
+		if alert == s.alerts[id] {
+			continue
+		}
+		if alert == "" {
+			delete(s.alerts, id)
+			continue
+		}
+		s.alerts[id] = alert
+		raised = append(raised, domain.TLSCertificateAlertPayload{
+			CertificateID:   id,
+			Names:           append([]string(nil), cert.Names...),
+			Reason:          alert,
+			NotAfter:        cert.NotAfter,
+			RenewalFailures: s.renewFailures[id],
+			LastError:       sanitizeError(s.lastErr[id]),
+		})
+	}
+	metrics := s.metrics
+	s.mu.Unlock()
+
+	sort.Slice(raised, func(i, j int) bool {
+		return raised[i].CertificateID < raised[j].CertificateID
+	})
+
+	log := zerowrap.FromCtx(ctx)
+	for _, payload := range raised {
+		log.Warn().
+			Str("certificate", payload.CertificateID).
+			Str("reason", string(payload.Reason)).
+			Time("not_after", payload.NotAfter).
+			Int("renewal_failures", payload.RenewalFailures).
+			Msg("public TLS certificate needs attention")
+		if metrics != nil {
+			metrics.TLSCertificateAlerts.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("certificate", payload.CertificateID),
+				attribute.String("reason", string(payload.Reason)),
+			))
+		}
+		if s.deps.Events != nil {
+			if err := s.deps.Events.Publish(domain.EventTLSCertificateAlert, payload); err != nil {
+				log.Warn().Err(err).Str("certificate", payload.CertificateID).Msg("failed to publish certificate alert")
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package publictls
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func newAlertTestService(t *testing.T, cfg Config, notAfter time.Time, issuer out.PublicCertificateIssuer, events out.EventPublisher) *Service {
+	t.Helper()
+	certPEM, keyPEM, err := generateTestCertPEM([]string{"app.example.com"})
+	require.NoError(t, err)
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	store, _ := newMockCertificateStore(t, out.StoredCertificate{
+		ID:            "http01-app.example.com",
+		Names:         []string{"app.example.com"},
+		Challenge:     domain.ACMEChallengeHTTP01,
+		Certificate:   tlsCert,
+		FullchainPEM:  certPEM,
+		PrivateKeyPEM: keyPEM,
+		NotAfter:      notAfter,
+	})
+	cfg.Enabled = true
+	svc := NewService(cfg, ServiceDeps{
+		Config: cfg,
+		Routes: &fakeRoutes{},
+		Issuer: issuer,
+		Store:  store,
+		Events: events,
+	})
+	require.NoError(t, svc.Load(context.Background()))
+	return svc
+}
+
+func TestCheckCertificateAlertsExpiring(t *testing.T) {
+	now := time.Date(2026, 4, 29, 12, 0, 0, 0, time.UTC)
+	notAfter := now.Add(10 * 24 * time.Hour)
+	events := outmocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(domain.EventTLSCertificateAlert, mock.MatchedBy(func(p domain.TLSCertificateAlertPayload) bool {
+		return p.CertificateID == "http01-app.example.com" &&
+			p.Reason == domain.TLSCertificateAlertExpiring &&
+			p.NotAfter.Equal(notAfter)
+	})).Return(nil).Once()
+	svc := newAlertTestService(t, Config{}, notAfter, nil, events)
+
+	svc.checkCertificateAlerts(context.Background(), now)
+	// The alert is raised once, not on every check.
+	svc.checkCertificateAlerts(context.Background(), now)
+}
+
+func TestCheckCertificateAlertsRespectsWindow(t *testing.T) {
+	now := time.Date(2026, 4, 29, 12, 0, 0, 0, time.UTC)
+	events := outmocks.NewMockEventPublisher(t)
+	svc := newAlertTestService(t, Config{ExpiryAlertDays: 7}, now.Add(10*24*time.Hour), nil, events)
+
+	svc.checkCertificateAlerts(context.Background(), now)
+
+	events.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRenewalFailuresRaiseAlert(t *testing.T) {
+	now := time.Now()
+	issuer, _ := newMockPublicCertificateIssuer(t, nil, func(context.Context, out.StoredCertificate) (*out.StoredCertificate, error) {
+		return nil, errors.New("rate limited")
+	})
+	events := outmocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(domain.EventTLSCertificateAlert, mock.MatchedBy(func(p domain.TLSCertificateAlertPayload) bool {
+		return p.Reason == domain.TLSCertificateAlertRenewalFailing && p.RenewalFailures == 2 && p.LastError == "renew: rate limited"
+	})).Return(nil).Once()
+	// Twenty days left: due for renewal but outside the expiry alert window.
+	svc := newAlertTestService(t, Config{RenewalFailureThreshold: 2}, now.Add(20*24*time.Hour), issuer, events)
+
+	for range 2 {
+		require.NoError(t, svc.renewDueCertificates(context.Background(), now))
+		svc.checkCertificateAlerts(context.Background(), now)
+	}
+
+	status := svc.Status(context.Background())
+	require.Len(t, status.Certificates, 1)
+	assert.Equal(t, 2, status.Certificates[0].RenewalFailures)
+	assert.Equal(t, domain.TLSCertificateAlertRenewalFailing, status.Certificates[0].Alert)
+}
+
+func TestRenewalFailuresSurviveRestart(t *testing.T) {
+	now := time.Now()
+	certPEM, keyPEM, err := generateTestCertPEM([]string{"app.example.com"})
+	require.NoError(t, err)
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	store, _ := newMockCertificateStore(t, out.StoredCertificate{
+		ID:            "http01-app.example.com",
+		Names:         []string{"app.example.com"},
+		Challenge:     domain.ACMEChallengeHTTP01,
+		Certificate:   tlsCert,
+		FullchainPEM:  certPEM,
+		PrivateKeyPEM: keyPEM,
+		NotAfter:      now.Add(20 * 24 * time.Hour),
+	})
+	issuer, _ := newMockPublicCertificateIssuer(t, nil, func(context.Context, out.StoredCertificate) (*out.StoredCertificate, error) {
+		return nil, errors.New("rate limited")
+	})
+	cfg := Config{Enabled: true, RenewalFailureThreshold: 2}
+
+	before := NewService(cfg, ServiceDeps{Config: cfg, Routes: &fakeRoutes{}, Issuer: issuer, Store: store})
+	require.NoError(t, before.Load(context.Background()))
+	for range 2 {
+		require.NoError(t, before.renewDueCertificates(context.Background(), now))
+	}
+
+	// A restarted server still knows the renewals have been failing.
+	events := outmocks.NewMockEventPublisher(t)
+	events.EXPECT().Publish(domain.EventTLSCertificateAlert, mock.MatchedBy(func(p domain.TLSCertificateAlertPayload) bool {
+		return p.Reason == domain.TLSCertificateAlertRenewalFailing && p.RenewalFailures == 2 && p.LastError == "renew: rate limited"
+	})).Return(nil).Once()
+	after := NewService(cfg, ServiceDeps{Config: cfg, Routes: &fakeRoutes{}, Issuer: issuer, Store: store, Events: events})
+	require.NoError(t, after.Load(context.Background()))
+
+	after.checkCertificateAlerts(context.Background(), now)
+}
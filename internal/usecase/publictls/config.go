@@ -15,12 +15,24 @@ type Config struct {
 	Challenge       string
 	HTTPPort        int
 	TLSPort         int
+	TLSALPNPort     int
 	DataDir         string
 	ObtainBatchSize int
 	DNS             DNSConfig
+	RFC2136         RFC2136Config
+	Exec            ExecDNSConfig
+
+	// ExpiryAlertDays raises an alert when a certificate expires within this
+	// many days. Zero selects domain.DefaultTLSExpiryAlertDays.
+	ExpiryAlertDays int
+	// RenewalFailureThreshold raises an alert after this many consecutive
+	// failed renewals. Zero selects domain.DefaultTLSRenewalFailureThreshold.
+	RenewalFailureThreshold int
 }
 
 // EffectiveChallenge represents the resolved ACME challenge configuration.
+// Token holds the DNS provider credential: the Cloudflare API token or the
+// RFC 2136 TSIG secret.
 type EffectiveChallenge struct {
 	ConfiguredMode domain.ACMEChallengeMode
 	Mode           domain.ACMEChallengeMode
@@ -34,6 +46,23 @@ func validPort(p int) bool {
 	return p >= 1 && p <= 65535
 }
 
+// ValidateDNSProvider validates and normalizes the provider settings of the
+// configured DNS-01 challenge mode. Settings of other modes are ignored.
+func (c *Config) ValidateDNSProvider() error {
+	mode, err := domain.ParseACMEChallengeMode(c.Challenge)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case domain.ACMEChallengeRFC2136DNS01:
+		return c.RFC2136.Validate()
+	case domain.ACMEChallengeExecDNS01:
+		return c.Exec.Validate()
+	default:
+		return nil
+	}
+}
+
 // ResolveEffectiveChallenge resolves the effective ACME challenge configuration
 // from the given Config and optional token resolver.
 func ResolveEffectiveChallenge(ctx context.Context, cfg Config, resolver out.SecretResolver) (EffectiveChallenge, error) {
@@ -75,6 +104,17 @@ func ResolveEffectiveChallenge(ctx context.Context, cfg Config, resolver out.Sec
 			Reason:         "http-01 challenge selected",
 		}, nil
 
+	case domain.ACMEChallengeTLSALPN01:
+		if !validPort(cfg.TLSALPNPort) {
+			return EffectiveChallenge{}, fmt.Errorf("%w: tls-alpn-01 challenge requires a smart_tcp or tls_mux entrypoint on port 443", domain.ErrACMEChallengeInvalid)
+		}
+		return EffectiveChallenge{
+			ConfiguredMode: domain.ACMEChallengeTLSALPN01,
+			Mode:           domain.ACMEChallengeTLSALPN01,
+			TokenSource:    domain.ACMETokenSourceNone,
+			Reason:         "tls-alpn-01 challenge selected",
+		}, nil
+
 	case domain.ACMEChallengeCloudflareDNS01:
 		token, source, err := resolveToken(ctx, resolver, true)
 		if err != nil {
@@ -91,6 +131,43 @@ func ResolveEffectiveChallenge(ctx context.Context, cfg Config, resolver out.Sec
 			Reason:         "cloudflare dns-01 challenge selected",
 		}, nil
 
+	case domain.ACMEChallengeRFC2136DNS01:
+		rfc2136 := cfg.RFC2136
+		if err := rfc2136.Validate(); err != nil {
+			return EffectiveChallenge{}, err
+		}
+		effective := EffectiveChallenge{
+			ConfiguredMode: domain.ACMEChallengeRFC2136DNS01,
+			Mode:           domain.ACMEChallengeRFC2136DNS01,
+			TokenSource:    domain.ACMETokenSourceNone,
+			Reason:         "rfc2136 dns-01 challenge selected",
+		}
+		if rfc2136.TSIGKey == "" {
+			return effective, nil
+		}
+		secret, source, err := resolveTSIGSecret(ctx, resolver)
+		if err != nil {
+			return EffectiveChallenge{}, fmt.Errorf("resolve TSIG secret: %w", err)
+		}
+		if secret == "" {
+			return EffectiveChallenge{}, domain.ErrTSIGSecretMissing
+		}
+		effective.Token = secret
+		effective.TokenSource = source
+		return effective, nil
+
+	case domain.ACMEChallengeExecDNS01:
+		exec := cfg.Exec
+		if err := exec.Validate(); err != nil {
+			return EffectiveChallenge{}, err
+		}
+		return EffectiveChallenge{
+			ConfiguredMode: domain.ACMEChallengeExecDNS01,
+			Mode:           domain.ACMEChallengeExecDNS01,
+			TokenSource:    domain.ACMETokenSourceNone,
+			Reason:         "exec dns-01 challenge selected",
+		}, nil
+
 	case domain.ACMEChallengeAuto:
 		token, source, _ := resolveToken(ctx, resolver, false)
 		if token != "" {
@@ -102,15 +179,23 @@ func ResolveEffectiveChallenge(ctx context.Context, cfg Config, resolver out.Sec
 				Reason:         "auto selected cloudflare dns-01 (token available)",
 			}, nil
 		}
-		if !validPort(cfg.HTTPPort) {
-			return EffectiveChallenge{}, fmt.Errorf("%w: http_port must be in range 1..65535 for http-01 fallback in auto mode", domain.ErrACMEChallengeInvalid)
+		if validPort(cfg.HTTPPort) {
+			return EffectiveChallenge{
+				ConfiguredMode: domain.ACMEChallengeAuto,
+				Mode:           domain.ACMEChallengeHTTP01,
+				TokenSource:    domain.ACMETokenSourceNone,
+				Reason:         "auto selected http-01 (no cloudflare token)",
+			}, nil
 		}
-		return EffectiveChallenge{
-			ConfiguredMode: domain.ACMEChallengeAuto,
-			Mode:           domain.ACMEChallengeHTTP01,
-			TokenSource:    domain.ACMETokenSourceNone,
-			Reason:         "auto selected http-01 (no cloudflare token)",
-		}, nil
+		if validPort(cfg.TLSALPNPort) {
+			return EffectiveChallenge{
+				ConfiguredMode: domain.ACMEChallengeAuto,
+				Mode:           domain.ACMEChallengeTLSALPN01,
+				TokenSource:    domain.ACMETokenSourceNone,
+				Reason:         "auto selected tls-alpn-01 (no cloudflare token, no http port)",
+			}, nil
+		}
+		return EffectiveChallenge{}, fmt.Errorf("%w: http_port must be in range 1..65535 for http-01 fallback in auto mode, or a smart_tcp/tls_mux entrypoint must listen on port 443 for tls-alpn-01", domain.ErrACMEChallengeInvalid)
 
 	default:
 		return EffectiveChallenge{}, fmt.Errorf("%w: %q", domain.ErrACMEChallengeInvalid, parsedMode)
@@ -135,3 +220,18 @@ func resolveToken(ctx context.Context, resolver out.SecretResolver, strict bool)
 	}
 	return sv.Value, sv.Source, nil
 }
+
+// resolveTSIGSecret resolves the RFC 2136 TSIG secret via the resolver.
+func resolveTSIGSecret(ctx context.Context, resolver out.SecretResolver) (string, domain.ACMETokenSource, error) {
+	if resolver == nil {
+		return "", domain.ACMETokenSourceNone, nil
+	}
+	sv, err := resolver.ResolveTSIGSecret(ctx)
+	if err != nil {
+		return "", domain.ACMETokenSourceNone, err
+	}
+	if sv.Value == "" {
+		return "", domain.ACMETokenSourceNone, nil
+	}
+	return sv.Value, sv.Source, nil
+}
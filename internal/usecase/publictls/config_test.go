@@ -125,6 +125,32 @@ func TestResolveEffectiveChallenge(t *testing.T) {
 		assert.Contains(t, err2.Error(), "http_port")
 	})
 
+	t.Run("tls-alpn-01 requires a tls-alpn port", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "tls-alpn-01", TLSPort: 8443}
+		_, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, domain.ErrACMEChallengeInvalid)
+
+		cfg.TLSALPNPort = 443
+		ec, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeTLSALPN01, ec.Mode)
+		assert.Equal(t, domain.ACMEChallengeTLSALPN01, ec.ConfiguredMode)
+	})
+
+	t.Run("auto without token or http port falls back to tls-alpn-01", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "auto", TLSPort: 443, TLSALPNPort: 443}
+		ec, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeTLSALPN01, ec.Mode)
+		assert.Equal(t, domain.ACMEChallengeAuto, ec.ConfiguredMode)
+
+		cfg.HTTPPort = 80
+		ec, err = ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeHTTP01, ec.Mode, "http-01 is preferred when port 80 is available")
+	})
+
 	t.Run("cloudflare-dns-01 preserves resolver errors", func(t *testing.T) {
 		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "cloudflare-dns-01", TLSPort: 8443}
 		resolver := newSecretResolverMock(t, out.SecretValue{}, errors.New("pass failed"))
@@ -148,4 +174,56 @@ func TestResolveEffectiveChallenge(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorIs(t, err, domain.ErrCloudflareTokenMissing)
 	})
+
+	t.Run("rfc2136-dns-01 resolves TSIG secret when a key is set", func(t *testing.T) {
+		cfg := Config{
+			Enabled:   true,
+			Email:     "admin@example.com",
+			Challenge: "rfc2136-dns-01",
+			TLSPort:   8443,
+			RFC2136:   RFC2136Config{Nameserver: "ns1.example.com", TSIGKey: "gordon"},
+		}
+		resolver := outmocks.NewMockSecretResolver(t)
+		resolver.EXPECT().ResolveTSIGSecret(mock.Anything).Return(out.SecretValue{Value: "c2VjcmV0", Source: domain.ACMETokenSourceFile}, nil)
+		ec, err := ResolveEffectiveChallenge(context.Background(), cfg, resolver)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeRFC2136DNS01, ec.Mode)
+		assert.Equal(t, "c2VjcmV0", ec.Token)
+		assert.Equal(t, domain.ACMETokenSourceFile, ec.TokenSource)
+	})
+
+	t.Run("rfc2136-dns-01 without TSIG key needs no secret", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "rfc2136-dns-01", TLSPort: 8443, RFC2136: RFC2136Config{Nameserver: "192.0.2.53:53"}}
+		ec, err := ResolveEffectiveChallenge(context.Background(), cfg, outmocks.NewMockSecretResolver(t))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeRFC2136DNS01, ec.Mode)
+		assert.Empty(t, ec.Token)
+		assert.Equal(t, domain.ACMETokenSourceNone, ec.TokenSource)
+	})
+
+	t.Run("rfc2136-dns-01 with TSIG key and no secret fails", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "rfc2136-dns-01", TLSPort: 8443, RFC2136: RFC2136Config{Nameserver: "192.0.2.53", TSIGKey: "gordon."}}
+		resolver := outmocks.NewMockSecretResolver(t)
+		resolver.EXPECT().ResolveTSIGSecret(mock.Anything).Return(out.SecretValue{Source: domain.ACMETokenSourceNone}, nil)
+		_, err := ResolveEffectiveChallenge(context.Background(), cfg, resolver)
+		assert.ErrorIs(t, err, domain.ErrTSIGSecretMissing)
+	})
+
+	t.Run("rfc2136-dns-01 requires a nameserver", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "rfc2136-dns-01", TLSPort: 8443}
+		_, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		assert.ErrorIs(t, err, domain.ErrDNSProviderConfigInvalid)
+	})
+
+	t.Run("exec-dns-01 requires a command", func(t *testing.T) {
+		cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "exec-dns-01", TLSPort: 8443}
+		_, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		assert.ErrorIs(t, err, domain.ErrDNSProviderConfigInvalid)
+
+		cfg.Exec.Command = "/usr/local/bin/dns-hook"
+		ec, err := ResolveEffectiveChallenge(context.Background(), cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ACMEChallengeExecDNS01, ec.Mode)
+		assert.Equal(t, "exec dns-01 challenge selected", ec.Reason)
+	})
 }
@@ -69,3 +69,79 @@ func (c *DNSConfig) Validate() error {
 	}
 	return nil
 }
+
+// DefaultTSIGAlgorithm is the TSIG algorithm used when none is configured. It
+// matches the default of BIND's tsig-keygen.
+const DefaultTSIGAlgorithm = "hmac-sha256."
+
+var validTSIGAlgorithms = map[string]struct{}{
+	"hmac-sha1.":   {},
+	"hmac-sha224.": {},
+	"hmac-sha256.": {},
+	"hmac-sha384.": {},
+	"hmac-sha512.": {},
+}
+
+// RFC2136Config configures the rfc2136-dns-01 provider, which publishes
+// challenge records through RFC 2136 dynamic updates to an authoritative
+// nameserver such as BIND, Knot or PowerDNS.
+type RFC2136Config struct {
+	Nameserver    string
+	TSIGKey       string
+	TSIGAlgorithm string
+}
+
+// Validate checks RFC2136Config and normalizes the nameserver port and TSIG
+// algorithm name.
+func (c *RFC2136Config) Validate() error {
+	nameserver := strings.TrimSpace(c.Nameserver)
+	if nameserver == "" {
+		return fmt.Errorf("%w: tls.acme.rfc2136.nameserver is required", domain.ErrDNSProviderConfigInvalid)
+	}
+	host, port, err := net.SplitHostPort(nameserver)
+	if err != nil {
+		host, port = strings.Trim(nameserver, "[]"), "53"
+	}
+	portNumber, err := strconv.Atoi(port)
+	if host == "" || err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("%w: invalid tls.acme.rfc2136.nameserver %q: expected host or host:port", domain.ErrDNSProviderConfigInvalid, c.Nameserver)
+	}
+	c.Nameserver = net.JoinHostPort(host, port)
+
+	c.TSIGKey = strings.TrimSpace(c.TSIGKey)
+	if c.TSIGKey == "" {
+		c.TSIGAlgorithm = ""
+		return nil
+	}
+	if !strings.HasSuffix(c.TSIGKey, ".") {
+		c.TSIGKey += "."
+	}
+	algorithm := strings.ToLower(strings.TrimSpace(c.TSIGAlgorithm))
+	if algorithm == "" {
+		algorithm = DefaultTSIGAlgorithm
+	}
+	if !strings.HasSuffix(algorithm, ".") {
+		algorithm += "."
+	}
+	if _, ok := validTSIGAlgorithms[algorithm]; !ok {
+		return fmt.Errorf("%w: unsupported tls.acme.rfc2136.tsig_algorithm %q", domain.ErrDNSProviderConfigInvalid, c.TSIGAlgorithm)
+	}
+	c.TSIGAlgorithm = algorithm
+	return nil
+}
+
+// ExecDNSConfig configures the exec-dns-01 provider, which runs a hook
+// program as "<command> present <fqdn> <value>" and
+// "<command> cleanup <fqdn> <value>".
+type ExecDNSConfig struct {
+	Command string
+}
+
+// Validate checks ExecDNSConfig.
+func (c *ExecDNSConfig) Validate() error {
+	c.Command = strings.TrimSpace(c.Command)
+	if c.Command == "" {
+		return fmt.Errorf("%w: tls.acme.exec.command is required", domain.ErrDNSProviderConfigInvalid)
+	}
+	return nil
+}
@@ -121,3 +121,29 @@ func TestDNSConfigValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestRFC2136ConfigValidateNormalizes(t *testing.T) {
+	cfg := RFC2136Config{Nameserver: " ns1.example.com ", TSIGKey: "gordon", TSIGAlgorithm: "HMAC-SHA512"}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "ns1.example.com:53", cfg.Nameserver)
+	assert.Equal(t, "gordon.", cfg.TSIGKey)
+	assert.Equal(t, "hmac-sha512.", cfg.TSIGAlgorithm)
+
+	cfg = RFC2136Config{Nameserver: "2001:db8::53", TSIGKey: "gordon."}
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "[2001:db8::53]:53", cfg.Nameserver)
+	assert.Equal(t, DefaultTSIGAlgorithm, cfg.TSIGAlgorithm)
+}
+
+func TestRFC2136ConfigValidate(t *testing.T) {
+	tests := map[string]RFC2136Config{
+		"missing nameserver": {},
+		"bad port":           {Nameserver: "ns1.example.com:abc"},
+		"bad algorithm":      {Nameserver: "ns1.example.com:53", TSIGKey: "gordon.", TSIGAlgorithm: "hmac-md5"},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, cfg.Validate(), domain.ErrDNSProviderConfigInvalid)
+		})
+	}
+}
@@ -30,8 +30,8 @@ func TestPublicTLSIntegration_DNS01WildcardStatusAndCertificateLookup(t *testing
 	}
 
 	// Mock zone resolver returns "example.com" for routes and the management host.
-	zoneResolver := outmocks.NewMockCloudflareZoneResolver(t)
-	zoneResolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.CloudflareZone{Name: "example.com"}, nil).Times(4)
+	zoneResolver := outmocks.NewMockDNSZoneResolver(t)
+	zoneResolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.DNSZone{Name: "example.com"}, nil).Times(4)
 
 	// Mock issuer: returns valid stored cert for any order.
 	issuer, recorder := newMockPublicCertificateIssuer(t, func(_ context.Context, order out.CertificateOrder) (*out.StoredCertificate, error) {
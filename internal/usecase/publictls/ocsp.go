@@ -0,0 +1,110 @@
+package publictls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+// OCSPRefreshDue reports whether the cached OCSP response of a certificate
+// should be fetched again: when there is none, or once half of its validity
+// period has passed. A response without NextUpdate is always refreshed.
+func OCSPRefreshDue(cert out.StoredCertificate, now time.Time) bool {
+	staple := cert.OCSP
+	if staple == nil || staple.NextUpdate.IsZero() {
+		return true
+	}
+	midpoint := staple.ThisUpdate.Add(staple.NextUpdate.Sub(staple.ThisUpdate) / 2)
+	return !now.Before(midpoint)
+}
+
+// stapleUsable reports whether staple can be sent in a TLS handshake.
+func stapleUsable(staple *out.OCSPStaple, now time.Time) bool {
+	if staple == nil || staple.Status != domain.OCSPStatusGood || len(staple.Response) == 0 {
+		return false
+	}
+	return staple.NextUpdate.IsZero() || now.Before(staple.NextUpdate)
+}
+
+// refreshOCSPResponses fetches OCSP responses for unexpired certificates whose
+// cached response is due for refresh and persists them with the certificate.
+// Certificates without an OCSP responder are skipped. Failures keep the
+// previous response, which stops being stapled once it expires.
+func (s *Service) refreshOCSPResponses(ctx context.Context, now time.Time) {
+	if s.deps.OCSP == nil || s.deps.Store == nil {
+		return
+	}
+	log := zerowrap.FromCtx(ctx)
+
+	s.mu.RLock()
+	due := make([]out.StoredCertificate, 0, len(s.certs))
+	for _, cert := range s.certs {
+		if now.Before(cert.NotAfter) && OCSPRefreshDue(*cert, now) {
+			due = append(due, *cert)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, cert := range due {
+		if ctx.Err() != nil {
+			return
+		}
+		staple, err := s.deps.OCSP.FetchOCSP(ctx, cert)
+		if errors.Is(err, domain.ErrOCSPNotSupported) {
+			continue
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("certificate", cert.ID).Msg("failed to fetch OCSP response")
+			continue
+		}
+		if err := s.saveOCSPResponse(ctx, cert, staple); err != nil {
+			log.Warn().Err(err).Str("certificate", cert.ID).Msg("failed to save OCSP response")
+			continue
+		}
+		if staple.Status == domain.OCSPStatusRevoked {
+			log.Warn().Str("certificate", cert.ID).Msg("OCSP responder reports certificate revoked, renewing")
+		}
+	}
+}
+
+// saveOCSPResponse persists staple with cert and updates the cache, unless
+// the certificate was replaced while the response was being fetched.
+func (s *Service) saveOCSPResponse(ctx context.Context, cert out.StoredCertificate, staple *out.OCSPStaple) error {
+	unlock, err := s.deps.Store.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil {
+			log := zerowrap.FromCtx(ctx)
+			log.Warn().Err(unlockErr).Msg("failed to release store lock")
+		}
+	}()
+
+	s.mu.RLock()
+	current, ok := s.certs[cert.ID]
+	s.mu.RUnlock()
+	if !ok || !bytes.Equal(current.FullchainPEM, cert.FullchainPEM) {
+		return nil
+	}
+
+	updated := *current
+	updated.OCSP = staple
+	if err := populateStoredCertificate(&updated); err != nil {
+		return err
+	}
+	if err := s.deps.Store.Save(ctx, updated); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.certs[updated.ID] = &updated
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,144 @@
+package publictls
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func TestOCSPRefreshDue(t *testing.T) {
+	now := time.Date(2026, 4, 29, 12, 0, 0, 0, time.UTC)
+	staple := func(thisUpdate, nextUpdate time.Time) out.StoredCertificate {
+		return out.StoredCertificate{OCSP: &out.OCSPStaple{ThisUpdate: thisUpdate, NextUpdate: nextUpdate}}
+	}
+
+	tests := []struct {
+		name string
+		cert out.StoredCertificate
+		want bool
+	}{
+		{name: "no cached response", cert: out.StoredCertificate{}, want: true},
+		{name: "fresh response", cert: staple(now.Add(-time.Hour), now.Add(7*24*time.Hour)), want: false},
+		{name: "past midpoint", cert: staple(now.Add(-4*24*time.Hour), now.Add(3*24*time.Hour)), want: true},
+		{name: "expired response", cert: staple(now.Add(-8*24*time.Hour), now.Add(-time.Hour)), want: true},
+		{name: "no next update", cert: staple(now.Add(-time.Hour), time.Time{}), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, OCSPRefreshDue(tt.cert, now))
+		})
+	}
+}
+
+// newOCSPTestService returns a loaded service managing one certificate for
+// app.example.com.
+func newOCSPTestService(t *testing.T, responder out.OCSPResponder, staple *out.OCSPStaple) (*Service, *mockCertificateStoreState) {
+	t.Helper()
+	certPEM, keyPEM, err := generateTestCertPEM([]string{"app.example.com"})
+	require.NoError(t, err)
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+
+	store, state := newMockCertificateStore(t, out.StoredCertificate{
+		ID:            "http01-app.example.com",
+		Names:         []string{"app.example.com"},
+		Challenge:     domain.ACMEChallengeHTTP01,
+		Certificate:   tlsCert,
+		FullchainPEM:  certPEM,
+		PrivateKeyPEM: keyPEM,
+		NotAfter:      time.Now().Add(60 * 24 * time.Hour),
+		OCSP:          staple,
+	})
+	cfg := Config{Enabled: true, Email: "admin@example.com", Challenge: "http-01"}
+	svc := NewService(cfg, ServiceDeps{
+		Config: cfg,
+		Routes: &fakeRoutes{routes: []domain.Route{{Domain: "app.example.com"}}},
+		Store:  store,
+		OCSP:   responder,
+	})
+	require.NoError(t, svc.Load(context.Background()))
+	return svc, state
+}
+
+func TestRefreshOCSPResponsesStaplesGoodResponse(t *testing.T) {
+	now := time.Now()
+	staple := &out.OCSPStaple{
+		Response:   []byte("ocsp-response"),
+		Status:     domain.OCSPStatusGood,
+		ThisUpdate: now.Add(-time.Hour),
+		NextUpdate: now.Add(7 * 24 * time.Hour),
+	}
+	responder := outmocks.NewMockOCSPResponder(t)
+	responder.EXPECT().FetchOCSP(mock.Anything, mock.Anything).Return(staple, nil).Once()
+	svc, state := newOCSPTestService(t, responder, nil)
+
+	svc.refreshOCSPResponses(context.Background(), now)
+	// The response is still fresh, so a second pass does not fetch again.
+	svc.refreshOCSPResponses(context.Background(), now)
+
+	stored := state.All()
+	require.Len(t, stored, 1)
+	assert.Equal(t, staple, stored[0].OCSP)
+	cert, err := svc.GetCertificateForHost("app.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, staple.Response, cert.OCSPStaple)
+
+	status := svc.Status(context.Background())
+	require.Len(t, status.Certificates, 1)
+	assert.Equal(t, domain.OCSPStatusGood, status.Certificates[0].OCSPStatus)
+	assert.True(t, status.Certificates[0].OCSPNextUpdate.Equal(staple.NextUpdate))
+}
+
+func TestRefreshOCSPResponsesSkipsCertificatesWithoutResponder(t *testing.T) {
+	responder := outmocks.NewMockOCSPResponder(t)
+	responder.EXPECT().FetchOCSP(mock.Anything, mock.Anything).Return(nil, domain.ErrOCSPNotSupported).Once()
+	svc, state := newOCSPTestService(t, responder, nil)
+
+	svc.refreshOCSPResponses(context.Background(), time.Now())
+
+	assert.Nil(t, state.All()[0].OCSP)
+	cert, err := svc.GetCertificateForHost("app.example.com")
+	require.NoError(t, err)
+	assert.Empty(t, cert.OCSPStaple)
+}
+
+func TestGetCertificateForHostOmitsUnusableStaple(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		staple *out.OCSPStaple
+	}{
+		{
+			name: "expired",
+			staple: &out.OCSPStaple{
+				Response: []byte("old"), Status: domain.OCSPStatusGood,
+				ThisUpdate: now.Add(-8 * 24 * time.Hour), NextUpdate: now.Add(-time.Hour),
+			},
+		},
+		{
+			name: "revoked",
+			staple: &out.OCSPStaple{
+				Response: []byte("revoked"), Status: domain.OCSPStatusRevoked,
+				ThisUpdate: now.Add(-time.Hour), NextUpdate: now.Add(24 * time.Hour),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _ := newOCSPTestService(t, nil, tt.staple)
+
+			cert, err := svc.GetCertificateForHost("app.example.com")
+			require.NoError(t, err)
+			assert.Empty(t, cert.OCSPStaple)
+		})
+	}
+}
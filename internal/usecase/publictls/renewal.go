@@ -1,6 +1,7 @@
 package publictls
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"time"
@@ -12,11 +13,15 @@ import (
 )
 
 // ShouldRenew reports whether a certificate should be renewed at the given time.
-// A certificate with a zero NotAfter is always considered due for renewal.
+// A certificate with a zero NotAfter or revoked according to its cached OCSP
+// response is always considered due for renewal.
 func ShouldRenew(cert out.StoredCertificate, now time.Time) bool {
 	if cert.NotAfter.IsZero() {
 		return true
 	}
+	if cert.OCSP != nil && cert.OCSP.Status == domain.OCSPStatusRevoked {
+		return true
+	}
 	return !now.Before(cert.NotAfter.Add(-domain.TLSRenewalWindow))
 }
 
@@ -71,9 +76,7 @@ func (s *Service) StartRenewalLoop(ctx context.Context, interval time.Duration)
 		// Startup and reload paths perform an immediate reconcile; the loop still
 		// renews due certificates right away so already-managed certs do not wait
 		// an extra interval before renewal.
-		if err := s.renewDueCertificates(loopCtx, time.Now()); err != nil {
-			log.Warn().Err(err).Msg("failed to renew due public TLS certificates")
-		}
+		s.runMaintenance(loopCtx)
 
 		for {
 			select {
@@ -83,9 +86,7 @@ func (s *Service) StartRenewalLoop(ctx context.Context, interval time.Duration)
 				if err := s.Reconcile(loopCtx); err != nil {
 					log.Warn().Err(err).Msg("failed to reconcile public TLS certificates")
 				}
-				if err := s.renewDueCertificates(loopCtx, time.Now()); err != nil {
-					log.Warn().Err(err).Msg("failed to renew due public TLS certificates")
-				}
+				s.runMaintenance(loopCtx)
 			}
 		}
 	}()
@@ -93,6 +94,17 @@ func (s *Service) StartRenewalLoop(ctx context.Context, interval time.Duration)
 	return done
 }
 
+// runMaintenance renews due certificates, refreshes their OCSP responses and
+// raises alerts for certificates that need attention.
+func (s *Service) runMaintenance(ctx context.Context) {
+	log := zerowrap.FromCtx(ctx)
+	if err := s.renewDueCertificates(ctx, time.Now()); err != nil {
+		log.Warn().Err(err).Msg("failed to renew due public TLS certificates")
+	}
+	s.refreshOCSPResponses(ctx, time.Now())
+	s.checkCertificateAlerts(ctx, time.Now())
+}
+
 // renewDueCertificates renews all certificates in the cache that are due
 // for renewal according to ShouldRenew.
 //
@@ -126,33 +138,26 @@ func (s *Service) renewDueCertificates(ctx context.Context, now time.Time) error
 		}
 		renewed, err := s.deps.Issuer.Renew(ctx, cert)
 		if err != nil {
-			s.mu.Lock()
-			s.lastErr[cert.ID] = fmt.Sprintf("renew: %v", err)
-			s.mu.Unlock()
+			s.recordRenewalFailure(ctx, cert, fmt.Sprintf("renew: %v", err))
 			continue
 		}
 
 		if renewed == nil {
-			s.mu.Lock()
-			s.lastErr[cert.ID] = "renew returned nil certificate"
-			s.mu.Unlock()
+			s.recordRenewalFailure(ctx, cert, "renew returned nil certificate")
 			continue
 		}
 		if err := populateStoredCertificate(renewed); err != nil {
-			s.mu.Lock()
-			s.lastErr[cert.ID] = err.Error()
-			s.mu.Unlock()
+			s.recordRenewalFailure(ctx, cert, err.Error())
 			continue
 		}
 		renewed.LastError = ""
+		renewed.RenewalFailures = 0
 
 		// Acquire store lock only around Save to avoid holding it across
 		// the potentially long-running Issuer.Renew call.
 		unlock, err := s.deps.Store.Lock(ctx)
 		if err != nil {
-			s.mu.Lock()
-			s.lastErr[cert.ID] = fmt.Sprintf("acquire store lock: %v", err)
-			s.mu.Unlock()
+			s.recordRenewalFailure(ctx, cert, fmt.Sprintf("acquire store lock: %v", err))
 			continue
 		}
 
@@ -162,17 +167,66 @@ func (s *Service) renewDueCertificates(ctx context.Context, now time.Time) error
 		}
 
 		if saveErr != nil {
-			s.mu.Lock()
-			s.lastErr[cert.ID] = fmt.Sprintf("save renewed certificate: %v", saveErr)
-			s.mu.Unlock()
+			s.recordRenewalFailure(ctx, cert, fmt.Sprintf("save renewed certificate: %v", saveErr))
 			continue
 		}
 
 		s.mu.Lock()
 		s.certs[renewed.ID] = renewed
 		delete(s.lastErr, renewed.ID)
+		delete(s.renewFailures, renewed.ID)
 		s.mu.Unlock()
 	}
 
 	return nil
 }
+
+// recordRenewalFailure stores the error of a failed renewal and counts it
+// towards the renewal failure alert. The count is saved with the certificate
+// so a restart does not reset the alert.
+func (s *Service) recordRenewalFailure(ctx context.Context, cert out.StoredCertificate, message string) {
+	s.mu.Lock()
+	s.lastErr[cert.ID] = message
+	s.renewFailures[cert.ID]++
+	failures := s.renewFailures[cert.ID]
+	s.mu.Unlock()
+
+	if err := s.saveRenewalFailures(ctx, cert, failures, message); err != nil {
+		log := zerowrap.FromCtx(ctx)
+		log.Warn().Err(err).Str("certificate", cert.ID).Msg("failed to persist renewal failure count")
+	}
+}
+
+// saveRenewalFailures persists the renewal failure count and error with cert
+// and updates the cache, unless the certificate was replaced meanwhile.
+func (s *Service) saveRenewalFailures(ctx context.Context, cert out.StoredCertificate, failures int, message string) error {
+	unlock, err := s.deps.Store.Lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := unlock(); unlockErr != nil {
+			log := zerowrap.FromCtx(ctx)
+			log.Warn().Err(unlockErr).Msg("failed to release store lock")
+		}
+	}()
+
+	s.mu.RLock()
+	current, ok := s.certs[cert.ID]
+	s.mu.RUnlock()
+	if !ok || !bytes.Equal(current.FullchainPEM, cert.FullchainPEM) {
+		return nil
+	}
+
+	updated := *current
+	updated.RenewalFailures = failures
+	updated.LastError = message
+	if err := s.deps.Store.Save(ctx, updated); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.certs[updated.ID] = &updated
+	s.mu.Unlock()
+	return nil
+}
@@ -46,6 +46,14 @@ func TestShouldRenew(t *testing.T) {
 			cert: out.StoredCertificate{},
 			want: true,
 		},
+		{
+			name: "revoked cert should renew",
+			cert: out.StoredCertificate{
+				NotAfter: now.Add(60 * 24 * time.Hour),
+				OCSP:     &out.OCSPStaple{Status: domain.OCSPStatusRevoked},
+			},
+			want: true,
+		},
 	}
 
 	for _, tt := range tests {
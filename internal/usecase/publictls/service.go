@@ -11,6 +11,7 @@ import (
 
 	"github.com/bnema/zerowrap"
 
+	"github.com/bnema/gordon/internal/adapters/out/telemetry"
 	"github.com/bnema/gordon/internal/boundaries/out"
 	"github.com/bnema/gordon/internal/domain"
 )
@@ -27,10 +28,13 @@ type ServiceDeps struct {
 	Routes          RouteSource
 	Issuer          out.PublicCertificateIssuer
 	Store           out.CertificateStore
-	ZoneResolver    out.CloudflareZoneResolver
+	ZoneResolver    out.DNSZoneResolver
 	Challenges      *HTTP01Challenges
+	TLSALPN         *TLSALPN01Challenges
 	Effective       EffectiveChallenge
 	AdditionalHosts []string
+	OCSP            out.OCSPResponder  // optional; enables OCSP stapling
+	Events          out.EventPublisher // optional; receives certificate alerts
 	Log             zerowrap.Logger
 }
 
@@ -43,11 +47,13 @@ type Service struct {
 	cfg             Config
 	deps            ServiceDeps
 	log             zerowrap.Logger
-	certs           map[string]*out.StoredCertificate // indexed by cert ID
-	lastErr         map[string]string                 // indexed by cert ID
-	routeErr        map[string]string                 // indexed by host
-	obtainCursor    int                               // next missing target index for batched obtains
-	additionalHosts []string                          // non-route hosts requiring certificate coverage
+	certs           map[string]*out.StoredCertificate     // indexed by cert ID
+	lastErr         map[string]string                     // indexed by cert ID
+	renewFailures   map[string]int                        // consecutive failed renewals, indexed by cert ID
+	alerts          map[string]domain.TLSCertificateAlert // last raised alert, indexed by cert ID
+	routeErr        map[string]string                     // indexed by host
+	obtainCursor    int                                   // next missing target index for batched obtains
+	additionalHosts []string                              // non-route hosts requiring certificate coverage
 
 	// requiredHosts is the set of hosts that must be covered by ACME certs.
 	requiredHosts map[string]struct{}
@@ -56,6 +62,8 @@ type Service struct {
 	cancel context.CancelFunc
 	// done is closed when the renewal loop exits.
 	done chan struct{}
+
+	metrics *telemetry.Metrics
 }
 
 // NewService creates a new public TLS Service.
@@ -64,6 +72,9 @@ func NewService(cfg Config, deps ServiceDeps) *Service {
 	if deps.Challenges == nil {
 		deps.Challenges = NewHTTP01Challenges()
 	}
+	if deps.TLSALPN == nil {
+		deps.TLSALPN = NewTLSALPN01Challenges()
+	}
 	if reflect.ValueOf(deps.Log).IsZero() {
 		deps.Log = zerowrap.Default()
 	}
@@ -73,12 +84,21 @@ func NewService(cfg Config, deps ServiceDeps) *Service {
 		log:             deps.Log,
 		certs:           make(map[string]*out.StoredCertificate),
 		lastErr:         make(map[string]string),
+		renewFailures:   make(map[string]int),
+		alerts:          make(map[string]domain.TLSCertificateAlert),
 		routeErr:        make(map[string]string),
 		additionalHosts: deps.AdditionalHosts,
 		requiredHosts:   make(map[string]struct{}),
 	}
 }
 
+// SetMetrics sets the telemetry metrics for certificate alerts.
+func (s *Service) SetMetrics(m *telemetry.Metrics) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = m
+}
+
 // Load loads all stored certificates from the store into the internal cache.
 // If the store is nil, this is a no-op.
 func (s *Service) Load(ctx context.Context) error {
@@ -114,6 +134,7 @@ func (s *Service) Load(ctx context.Context) error {
 
 	s.certs = make(map[string]*out.StoredCertificate, len(stored))
 	s.lastErr = make(map[string]string)
+	s.renewFailures = make(map[string]int)
 	s.requiredHosts = make(map[string]struct{})
 	s.obtainCursor = state.ObtainCursor
 
@@ -127,6 +148,9 @@ func (s *Service) Load(ctx context.Context) error {
 		if cert.LastError != "" {
 			s.lastErr[cert.ID] = cert.LastError
 		}
+		if cert.RenewalFailures > 0 {
+			s.renewFailures[cert.ID] = cert.RenewalFailures
+		}
 	}
 
 	s.requiredHosts = required
@@ -333,6 +357,10 @@ func populateStoredCertificate(cert *out.StoredCertificate) error {
 	if len(cert.Certificate.Certificate) == 0 {
 		return fmt.Errorf("stored certificate is empty")
 	}
+	cert.Certificate.OCSPStaple = nil
+	if cert.OCSP != nil && cert.OCSP.Status == domain.OCSPStatusGood {
+		cert.Certificate.OCSPStaple = cert.OCSP.Response
+	}
 	return nil
 }
 
@@ -483,7 +511,8 @@ func hostMatchesCert(names []string, host string) bool {
 // If the SNI host does not require ACME coverage, returns nil, nil.
 // If ACME is required but no cert covers the host, returns
 // ErrTLSRouteNotCovered.
-// If a valid cert is found, returns a pointer to it.
+// If a valid cert is found, returns a pointer to it, stapled with its OCSP
+// response while that response is good and current.
 func (s *Service) GetCertificateForHost(host string) (*tls.Certificate, error) {
 	host = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
 	if host == "" {
@@ -505,6 +534,12 @@ func (s *Service) GetCertificateForHost(host string) (*tls.Certificate, error) {
 			continue
 		}
 		if hostMatchesCert(cert.Names, host) {
+			if len(cert.Certificate.OCSPStaple) > 0 && !stapleUsable(cert.OCSP, now) {
+				// Serving an expired OCSP response is worse than none.
+				unstapled := cert.Certificate
+				unstapled.OCSPStaple = nil
+				return &unstapled, nil
+			}
 			return &cert.Certificate, nil
 		}
 	}
@@ -525,6 +560,11 @@ func (s *Service) GetHTTP01Challenge(ctx context.Context, token string) (string,
 	return s.deps.Challenges.Get(ctx, token)
 }
 
+// GetTLSALPN01Certificate delegates to the TLS-ALPN-01 challenge store.
+func (s *Service) GetTLSALPN01Certificate(serverName string) (*tls.Certificate, bool) {
+	return s.deps.TLSALPN.Get(serverName)
+}
+
 // getStoredCertificate returns a copy of the stored certificate for the given
 // ID, or nil if not found.
 func (s *Service) getStoredCertificate(id string) *out.StoredCertificate {
@@ -175,7 +175,7 @@ func newMockCertificateStore(t *testing.T, initial ...out.StoredCertificate) (*o
 	state := &mockCertificateStoreState{certs: append([]out.StoredCertificate(nil), initial...)}
 	store := outmocks.NewMockCertificateStore(t)
 
-	loadAccountCall := store.EXPECT().LoadAccount(mock.Anything).RunAndReturn(func(context.Context) (*out.ACMEAccount, error) {
+	loadAccountCall := store.EXPECT().LoadAccount(mock.Anything, mock.Anything).RunAndReturn(func(context.Context, string) (*out.ACMEAccount, error) {
 		state.mu.Lock()
 		defer state.mu.Unlock()
 		if state.account == nil {
@@ -896,9 +896,9 @@ func TestServiceReconcileDNS01BrokenResolverReturnsErrTLSRouteNotCovered(t *test
 	store, _ := newMockCertificateStore(t)
 
 	// Use generated mock that always returns an error.
-	zoneResolver := outmocks.NewMockCloudflareZoneResolver(t)
+	zoneResolver := outmocks.NewMockDNSZoneResolver(t)
 	zoneResolver.EXPECT().FindZone(mock.Anything, "app.example.com").Return(
-		out.CloudflareZone{}, fmt.Errorf("zone resolver unavailable for app.example.com"),
+		out.DNSZone{}, fmt.Errorf("zone resolver unavailable for app.example.com"),
 	)
 
 	cfg := Config{
@@ -46,11 +46,17 @@ func (s *Service) buildManagedCertificatesLocked() []domain.ManagedCertificate {
 			lastErr = e
 		}
 		mc := domain.ManagedCertificate{
-			ID:        cert.ID,
-			Names:     cert.Names,
-			Challenge: cert.Challenge,
-			NotAfter:  cert.NotAfter,
-			LastError: sanitizeError(lastErr),
+			ID:              cert.ID,
+			Names:           cert.Names,
+			Challenge:       cert.Challenge,
+			NotAfter:        cert.NotAfter,
+			LastError:       sanitizeError(lastErr),
+			RenewalFailures: s.renewFailures[cert.ID],
+			Alert:           s.certificateAlertLocked(cert, now),
+		}
+		if cert.OCSP != nil {
+			mc.OCSPStatus = cert.OCSP.Status
+			mc.OCSPNextUpdate = cert.OCSP.NextUpdate
 		}
 		mc.Status = mc.Health(now)
 		certs = append(certs, mc)
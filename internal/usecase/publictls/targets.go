@@ -25,15 +25,17 @@ func DeriveCertificateTargets(
 	routes []domain.Route,
 	external map[string]string,
 	additionalHosts []string,
-	resolver out.CloudflareZoneResolver,
+	resolver out.DNSZoneResolver,
 ) ([]CertificateTarget, error) {
 	hosts := routeHosts(routes, external, additionalHosts)
 
 	switch mode {
 	case domain.ACMEChallengeHTTP01:
-		return deriveHTTP01Targets(hosts), nil
-	case domain.ACMEChallengeCloudflareDNS01:
-		return deriveDNS01Targets(ctx, hosts, resolver)
+		return deriveHostTargets("http01-", mode, hosts), nil
+	case domain.ACMEChallengeTLSALPN01:
+		return deriveHostTargets("tlsalpn01-", mode, hosts), nil
+	case domain.ACMEChallengeCloudflareDNS01, domain.ACMEChallengeRFC2136DNS01, domain.ACMEChallengeExecDNS01:
+		return deriveDNS01Targets(ctx, mode, hosts, resolver)
 	default:
 		return nil, fmt.Errorf("unsupported challenge mode: %s", mode)
 	}
@@ -71,21 +73,24 @@ func routeHosts(routes []domain.Route, external map[string]string, additionalHos
 	return hosts
 }
 
-// deriveHTTP01Targets creates one target per host for HTTP-01 challenge.
-func deriveHTTP01Targets(hosts []string) []CertificateTarget {
+// deriveHostTargets creates one target per host for challenges validated on
+// the host itself (HTTP-01 and TLS-ALPN-01), which cannot cover wildcards.
+func deriveHostTargets(idPrefix string, mode domain.ACMEChallengeMode, hosts []string) []CertificateTarget {
 	targets := make([]CertificateTarget, len(hosts))
 	for i, host := range hosts {
 		targets[i] = CertificateTarget{
-			ID:        "http01-" + host,
+			ID:        idPrefix + host,
 			Names:     []string{host},
-			Challenge: domain.ACMEChallengeHTTP01,
+			Challenge: mode,
 		}
 	}
 	return targets
 }
 
-// deriveDNS01Targets creates targets grouped by wildcard base for DNS-01 challenge.
-func deriveDNS01Targets(ctx context.Context, hosts []string, resolver out.CloudflareZoneResolver) ([]CertificateTarget, error) {
+// deriveDNS01Targets creates targets grouped by wildcard base for DNS-01
+// challenge. Every DNS-01 provider shares this grouping; only the zone
+// resolver differs.
+func deriveDNS01Targets(ctx context.Context, mode domain.ACMEChallengeMode, hosts []string, resolver out.DNSZoneResolver) ([]CertificateTarget, error) {
 	if resolver == nil {
 		return nil, fmt.Errorf("dns-01 resolver is nil: cannot resolve DNS zones")
 	}
@@ -114,7 +119,7 @@ func deriveDNS01Targets(ctx context.Context, hosts []string, resolver out.Cloudf
 		targets[i] = CertificateTarget{
 			ID:        "dns01-" + base,
 			Names:     []string{base, "*." + base},
-			Challenge: domain.ACMEChallengeCloudflareDNS01,
+			Challenge: mode,
 		}
 	}
 	return targets, nil
@@ -27,10 +27,21 @@ func TestDeriveTargets_HTTP01PerRoute(t *testing.T) {
 	assert.Equal(t, domain.ACMEChallengeHTTP01, targets[1].Challenge)
 }
 
+func TestDeriveTargets_TLSALPN01PerRoute(t *testing.T) {
+	routes := []domain.Route{{Domain: "app.example.com"}, {Domain: "*.example.com"}}
+	targets, err := DeriveCertificateTargets(context.Background(), domain.ACMEChallengeTLSALPN01, routes, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, targets, 1)
+
+	assert.Equal(t, "tlsalpn01-app.example.com", targets[0].ID)
+	assert.Equal(t, []string{"app.example.com"}, targets[0].Names)
+	assert.Equal(t, domain.ACMEChallengeTLSALPN01, targets[0].Challenge)
+}
+
 func TestDeriveTargets_DNS01WildcardBases(t *testing.T) {
 	routes := []domain.Route{{Domain: "app.example.com"}, {Domain: "api.prod.example.com"}, {Domain: "example.com"}}
-	resolver := outmocks.NewMockCloudflareZoneResolver(t)
-	resolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.CloudflareZone{Name: "example.com"}, nil).Times(3)
+	resolver := outmocks.NewMockDNSZoneResolver(t)
+	resolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.DNSZone{Name: "example.com"}, nil).Times(3)
 	targets, err := DeriveCertificateTargets(context.Background(), domain.ACMEChallengeCloudflareDNS01, routes, nil, nil, resolver)
 	require.NoError(t, err)
 	require.Len(t, targets, 2)
@@ -43,6 +54,22 @@ func TestDeriveTargets_DNS01WildcardBases(t *testing.T) {
 	assert.Equal(t, domain.ACMEChallengeCloudflareDNS01, targets[1].Challenge)
 }
 
+func TestDeriveTargets_DNS01ProvidersShareWildcardGrouping(t *testing.T) {
+	routes := []domain.Route{{Domain: "app.example.com"}, {Domain: "api.example.com"}}
+	for _, mode := range []domain.ACMEChallengeMode{domain.ACMEChallengeRFC2136DNS01, domain.ACMEChallengeExecDNS01} {
+		t.Run(string(mode), func(t *testing.T) {
+			resolver := outmocks.NewMockDNSZoneResolver(t)
+			resolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.DNSZone{Name: "example.com"}, nil).Times(2)
+			targets, err := DeriveCertificateTargets(context.Background(), mode, routes, nil, nil, resolver)
+			require.NoError(t, err)
+			require.Len(t, targets, 1)
+			assert.Equal(t, "dns01-example.com", targets[0].ID)
+			assert.Equal(t, []string{"example.com", "*.example.com"}, targets[0].Names)
+			assert.Equal(t, mode, targets[0].Challenge)
+		})
+	}
+}
+
 func TestDeriveTargets_IncludesAdditionalHosts(t *testing.T) {
 	targets, err := DeriveCertificateTargets(
 		context.Background(),
@@ -79,8 +106,8 @@ func TestDeriveTargets_DNS01NilResolver_ReturnsError(t *testing.T) {
 
 func TestDeriveTargets_DNS01MismatchedZone_ReturnsError(t *testing.T) {
 	routes := []domain.Route{{Domain: "app.example.com"}}
-	resolver := outmocks.NewMockCloudflareZoneResolver(t)
-	resolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.CloudflareZone{Name: "other.test"}, nil).Once()
+	resolver := outmocks.NewMockDNSZoneResolver(t)
+	resolver.EXPECT().FindZone(mock.Anything, mock.Anything).Return(out.DNSZone{Name: "other.test"}, nil).Once()
 	_, err := DeriveCertificateTargets(context.Background(), domain.ACMEChallengeCloudflareDNS01, routes, nil, nil, resolver)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "does not match host")
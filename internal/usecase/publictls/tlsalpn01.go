@@ -0,0 +1,63 @@
+package publictls
+
+import (
+	"crypto/tls"
+	"strings"
+	"sync"
+)
+
+// TLSALPN01Challenges stores ACME TLS-ALPN-01 challenge certificates keyed by
+// the domain being validated. It is safe for concurrent use.
+type TLSALPN01Challenges struct {
+	mu   sync.RWMutex
+	data map[string]tls.Certificate
+}
+
+// NewTLSALPN01Challenges returns an initialized TLSALPN01Challenges.
+func NewTLSALPN01Challenges() *TLSALPN01Challenges {
+	return &TLSALPN01Challenges{
+		data: make(map[string]tls.Certificate),
+	}
+}
+
+// Present stores the challenge certificate for the given domain. It silently
+// ignores empty domains and certificates.
+func (c *TLSALPN01Challenges) Present(domainName string, cert tls.Certificate) error {
+	domainName = normalizeChallengeDomain(domainName)
+	if domainName == "" || len(cert.Certificate) == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data[domainName] = cert
+	return nil
+}
+
+// CleanUp removes the challenge certificate for the given domain.
+func (c *TLSALPN01Challenges) CleanUp(domainName string) error {
+	domainName = normalizeChallengeDomain(domainName)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.data, domainName)
+	return nil
+}
+
+// Get returns the challenge certificate for the given TLS server name, and a
+// boolean indicating whether one is pending.
+func (c *TLSALPN01Challenges) Get(serverName string) (*tls.Certificate, bool) {
+	serverName = normalizeChallengeDomain(serverName)
+	if serverName == "" {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cert, ok := c.data[serverName]
+	if !ok {
+		return nil, false
+	}
+	return &cert, true
+}
+
+func normalizeChallengeDomain(domainName string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domainName)), ".")
+}
@@ -0,0 +1,33 @@
+package publictls
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTLSALPN01Challenges(t *testing.T) {
+	ch := NewTLSALPN01Challenges()
+	cert := tls.Certificate{Certificate: [][]byte{[]byte("der")}}
+
+	require.NoError(t, ch.Present("App.Example.com.", cert))
+	got, ok := ch.Get("app.example.com")
+	require.True(t, ok)
+	assert.Equal(t, cert.Certificate, got.Certificate)
+
+	_, ok = ch.Get("other.example.com")
+	assert.False(t, ok)
+	_, ok = ch.Get("")
+	assert.False(t, ok)
+
+	require.NoError(t, ch.CleanUp("app.example.com"))
+	_, ok = ch.Get("app.example.com")
+	assert.False(t, ok)
+
+	require.NoError(t, ch.Present("", cert))
+	require.NoError(t, ch.Present("empty.example.com", tls.Certificate{}))
+	_, ok = ch.Get("empty.example.com")
+	assert.False(t, ok, "empty certificates are ignored")
+}
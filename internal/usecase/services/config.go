@@ -19,6 +19,12 @@ type Config struct {
 	Cleanup   CleanupConfig     `mapstructure:"cleanup"`
 	Ports     []PortConfig      `mapstructure:"ports"`
 	Volumes   []VolumeConfig    `mapstructure:"volumes"`
+	TLS       bool              `mapstructure:"tls"`
+	TLSSANs   []string          `mapstructure:"tls_sans"`
+	TLSPath   string            `mapstructure:"tls_path"`
+	TLSTTL    string            `mapstructure:"tls_ttl"`
+	TLSOwner  string            `mapstructure:"tls_owner"`
+	TLSReload string            `mapstructure:"tls_reload"`
 }
 
 type PortConfig struct {
@@ -93,6 +99,10 @@ func (c Config) ToDomain() (domain.StandaloneService, error) {
 	if err != nil {
 		return domain.StandaloneService{}, err
 	}
+	tlsCfg, err := c.tlsToDomain()
+	if err != nil {
+		return domain.StandaloneService{}, err
+	}
 	svc := domain.StandaloneService{
 		Name:      c.Name,
 		Image:     c.Image,
@@ -104,6 +114,7 @@ func (c Config) ToDomain() (domain.StandaloneService, error) {
 		Cleanup:   c.Cleanup.toDomain(),
 		Ports:     portsToDomain(c.Ports),
 		Volumes:   volumesToDomain(c.Volumes),
+		TLS:       tlsCfg,
 	}
 	if err := svc.Validate(); err != nil {
 		return domain.StandaloneService{}, err
@@ -131,6 +142,35 @@ func (c Config) readinessToDomain() (domain.StandaloneServiceReadiness, error) {
 	return readiness, nil
 }
 
+func (c Config) tlsToDomain() (*domain.StandaloneServiceTLS, error) {
+	if !c.TLS {
+		if len(c.TLSSANs) > 0 || c.TLSPath != "" || c.TLSTTL != "" || c.TLSOwner != "" || c.TLSReload != "" {
+			return nil, fmt.Errorf("tls_* options require tls = true")
+		}
+		return nil, nil
+	}
+	tlsCfg := &domain.StandaloneServiceTLS{
+		SANs:   append([]string(nil), c.TLSSANs...),
+		Path:   c.TLSPath,
+		Reload: c.TLSReload,
+	}
+	if c.TLSTTL != "" {
+		ttl, err := time.ParseDuration(c.TLSTTL)
+		if err != nil {
+			return nil, fmt.Errorf("tls_ttl %q is invalid: %w", c.TLSTTL, err)
+		}
+		tlsCfg.TTL = ttl
+	}
+	if c.TLSOwner != "" {
+		uid, gid, ok := domain.ParseTLSOwner(c.TLSOwner)
+		if !ok {
+			return nil, fmt.Errorf("tls_owner %q must be a numeric uid or uid:gid", c.TLSOwner)
+		}
+		tlsCfg.UID, tlsCfg.GID = uid, gid
+	}
+	return tlsCfg, nil
+}
+
 func (c CleanupConfig) toDomain() domain.StandaloneServiceCleanup {
 	cleanup := domain.StandaloneServiceCleanup{PreserveVolumes: true, RemoveContainer: true}
 	if c.PreserveVolumes != nil {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -122,3 +123,40 @@ func TestManagedServiceVolumeNameIsDeterministicAndGordonCompatible(t *testing.T
 	assert.Equal(t, "gordon-rust-example-com-var-lib-data", ManagedServiceVolumeName("gordon", "rust.example.com", "/var/lib/data"))
 	assert.Equal(t, "gordon-rust-example-com-data", ManagedServiceVolumeName("gordon", "rust.example.com", "/data"))
 }
+
+func TestConfigToDomainTLS(t *testing.T) {
+	cfg := Config{
+		Name:      "db",
+		Image:     "postgres:16",
+		Enabled:   true,
+		TLS:       true,
+		TLSSANs:   []string{"db.internal"},
+		TLSTTL:    "72h",
+		TLSOwner:  "999:998",
+		TLSReload: "SIGHUP",
+	}
+
+	svc, err := cfg.ToDomain()
+
+	require.NoError(t, err)
+	require.NotNil(t, svc.TLS)
+	assert.Equal(t, domain.StandaloneServiceTLS{SANs: []string{"db.internal"}, TTL: 72 * time.Hour, UID: 999, GID: 998, Reload: "SIGHUP"}, *svc.TLS)
+
+	cfg.TLSOwner = "70"
+	svc, err = cfg.ToDomain()
+	require.NoError(t, err)
+	assert.Equal(t, 70, svc.TLS.UID)
+	assert.Equal(t, 70, svc.TLS.GID)
+
+	cfg.TLSOwner = "postgres"
+	_, err = cfg.ToDomain()
+	require.ErrorContains(t, err, "tls_owner")
+
+	cfg.TLSOwner = ""
+	cfg.TLSTTL = "soon"
+	_, err = cfg.ToDomain()
+	require.ErrorContains(t, err, "tls_ttl")
+
+	_, err = Config{Name: "db", Image: "postgres:16", Enabled: true, TLSSANs: []string{"db.internal"}}.ToDomain()
+	require.ErrorContains(t, err, "require tls = true")
+}
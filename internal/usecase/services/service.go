@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bnema/gordon/internal/boundaries/out"
@@ -28,6 +29,12 @@ type Service struct {
 	runtime        out.ContainerRuntime
 	secretProvider out.SecretProvider
 	volumePrefix   string
+	ca             out.CertificateAuthority
+
+	// mu serializes reconciles with certificate renewals; configured is the
+	// service list of the last reconcile.
+	mu         sync.Mutex
+	configured []domain.StandaloneService
 }
 
 func NewService(runtime out.ContainerRuntime) *Service {
@@ -46,6 +53,10 @@ func NewServiceWithVolumePrefix(runtime out.ContainerRuntime, volumePrefix strin
 }
 
 func (s *Service) Reconcile(ctx context.Context, configured []domain.StandaloneService) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.configured = append([]domain.StandaloneService(nil), configured...)
+
 	containers, err := s.runtime.ListContainers(ctx, true)
 	if err != nil {
 		return fmt.Errorf("list standalone service containers: %w", err)
@@ -127,7 +138,13 @@ func (s *Service) reconcileOne(ctx context.Context, svc domain.StandaloneService
 			return err
 		}
 	}
-	if containerStatus(current) != domain.ContainerStatusRunning {
+	running := containerStatus(current) == domain.ContainerStatusRunning
+	if svc.TLS != nil {
+		if err := s.ensureCertificate(ctx, svc, current.ID, running); err != nil {
+			return err
+		}
+	}
+	if !running {
 		if err := s.runtime.StartContainer(ctx, current.ID); err != nil {
 			return fmt.Errorf("start standalone service %q container: %w", svc.Name, err)
 		}
@@ -246,6 +263,11 @@ func (s *Service) createAndStart(ctx context.Context, svc domain.StandaloneServi
 	if err != nil {
 		return fmt.Errorf("create standalone service %q container: %w", svc.Name, err)
 	}
+	if svc.TLS != nil {
+		if err := s.installCertificate(ctx, svc, container.ID); err != nil {
+			return err
+		}
+	}
 	if err := s.runtime.StartContainer(ctx, container.ID); err != nil {
 		return fmt.Errorf("start standalone service %q container: %w", svc.Name, err)
 	}
@@ -265,6 +287,12 @@ func (s *Service) containerConfig(ctx context.Context, svc domain.StandaloneServ
 		}
 	}
 	mounts := ResolveVolumeMounts(s.volumePrefix, svc.Name, svc.Volumes, imageVolumes)
+	if svc.TLS != nil {
+		// The certificate directory is written with CopyToContainer, so it
+		// is a writable managed volume rather than a read-only mount.
+		tlsPath := svc.TLS.WithDefaults().Path
+		mounts = append(mounts, ResolvedVolumeMount{Source: ManagedServiceVolumeName(s.volumePrefix, svc.Name, tlsPath), Target: tlsPath, Managed: true})
+	}
 	volumes := make(map[string]string)
 	readOnlyVolumes := make(map[string]string)
 	managedVolumes := make([]string, 0)
@@ -364,7 +392,8 @@ func serviceConfigHashWithEnv(svc domain.StandaloneService, resolvedEnv []string
 		Ports       []domain.StandaloneServicePort
 		Volumes     []domain.StandaloneServiceVolume
 		Cleanup     domain.StandaloneServiceCleanup
-	}{svc.Image, append([]string(nil), resolvedEnv...), svc.Readiness, append([]domain.StandaloneServicePort(nil), svc.Ports...), append([]domain.StandaloneServiceVolume(nil), svc.Volumes...), normalizeCleanup(svc.Cleanup)}
+		TLS         *domain.StandaloneServiceTLS `json:",omitempty"`
+	}{svc.Image, append([]string(nil), resolvedEnv...), svc.Readiness, append([]domain.StandaloneServicePort(nil), svc.Ports...), append([]domain.StandaloneServiceVolume(nil), svc.Volumes...), normalizeCleanup(svc.Cleanup), serviceTLSForHash(svc.TLS)}
 	bytes, err := json.Marshal(payload)
 	if err != nil {
 		return "", err
@@ -373,6 +402,16 @@ func serviceConfigHashWithEnv(svc domain.StandaloneService, resolvedEnv []string
 	return hex.EncodeToString(sum[:]), nil
 }
 
+// serviceTLSForHash returns the TLS settings with defaults applied, or nil,
+// so services without tls keep the hash they had before it existed.
+func serviceTLSForHash(tlsCfg *domain.StandaloneServiceTLS) *domain.StandaloneServiceTLS {
+	if tlsCfg == nil {
+		return nil
+	}
+	withDefaults := tlsCfg.WithDefaults()
+	return &withDefaults
+}
+
 func (s *Service) serviceEnv(ctx context.Context, svc domain.StandaloneService) ([]string, error) {
 	envMap := make(map[string]string)
 	if svc.EnvFile != "" {
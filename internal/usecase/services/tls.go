@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bnema/zerowrap"
+
+	"github.com/bnema/gordon/internal/boundaries/out"
+	"github.com/bnema/gordon/internal/domain"
+	"github.com/bnema/gordon/internal/usecase/pki"
+)
+
+// SetCertificateAuthority enables services with tls = true. Their
+// certificates are issued by ca.
+func (s *Service) SetCertificateAuthority(ca out.CertificateAuthority) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ca = ca
+}
+
+// StartCertificateRenewal calls RenewCertificates on every interval until
+// ctx is cancelled.
+func (s *Service) StartCertificateRenewal(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RenewCertificates(ctx); err != nil {
+					log := zerowrap.FromCtx(ctx)
+					log.Warn().Err(err).Msg("standalone service certificate renewal failed")
+				}
+			}
+		}
+	}()
+}
+
+// RenewCertificates rotates the certificate of every running tls = true
+// service from the last reconcile once a third of its lifetime is left, then
+// restarts or signals the container so it picks up the new files.
+func (s *Service) RenewCertificates(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var withTLS []domain.StandaloneService
+	for _, svc := range s.configured {
+		if svc.Enabled && svc.TLS != nil {
+			withTLS = append(withTLS, svc)
+		}
+	}
+	if len(withTLS) == 0 {
+		return nil
+	}
+	containers, err := s.runtime.ListContainers(ctx, true)
+	if err != nil {
+		return fmt.Errorf("list standalone service containers: %w", err)
+	}
+	existing := managedServiceContainers(containers)
+	var errs []error
+	for _, svc := range withTLS {
+		for _, container := range existing[svc.Name] {
+			if containerStatus(container) != domain.ContainerStatusRunning {
+				continue
+			}
+			if err := s.ensureCertificate(ctx, svc, container.ID, true); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ensureCertificate installs a fresh certificate when the container has none
+// or its current one needs renewal. With reload set, the container is
+// restarted or signalled afterwards.
+func (s *Service) ensureCertificate(ctx context.Context, svc domain.StandaloneService, containerID string, reload bool) error {
+	tlsCfg := svc.TLS.WithDefaults()
+	if !s.certificateNeedsRenewal(ctx, containerID, tlsCfg) {
+		return nil
+	}
+	if err := s.installCertificate(ctx, svc, containerID); err != nil {
+		return err
+	}
+	log := zerowrap.FromCtx(ctx)
+	log.Info().Str("service", svc.Name).Msg("rotated standalone service certificate")
+	if !reload {
+		return nil
+	}
+	if tlsCfg.Reload == domain.StandaloneServiceTLSReloadRestart {
+		if err := s.runtime.RestartContainer(ctx, containerID); err != nil {
+			return fmt.Errorf("restart standalone service %q after certificate rotation: %w", svc.Name, err)
+		}
+		return nil
+	}
+	if err := s.runtime.SignalContainer(ctx, containerID, tlsCfg.Reload); err != nil {
+		return fmt.Errorf("signal standalone service %q after certificate rotation: %w", svc.Name, err)
+	}
+	return nil
+}
+
+// certificateNeedsRenewal reports whether the certificate in the container
+// is missing, unreadable, not issued by the current CA, or past two thirds
+// of its lifetime.
+func (s *Service) certificateNeedsRenewal(ctx context.Context, containerID string, tlsCfg domain.StandaloneServiceTLS) bool {
+	return pki.ContainerCertificateNeedsRenewal(ctx, s.runtime, s.ca, containerID, tlsCfg)
+}
+
+// installCertificate issues a certificate for svc and writes it, its key and
+// the root CA into the container's TLS directory.
+func (s *Service) installCertificate(ctx context.Context, svc domain.StandaloneService, containerID string) error {
+	if s.ca == nil {
+		return fmt.Errorf("standalone service %q tls requires the internal CA; configure a smart_tcp or tls_mux entrypoint", svc.Name)
+	}
+	tlsCfg := svc.TLS.WithDefaults()
+	cert, err := s.ca.IssueServiceCertificate(serviceRuntimeIdentifier(svc.Name), serviceCertificateSANs(svc), tlsCfg.TTL)
+	if err != nil {
+		return fmt.Errorf("issue standalone service %q certificate: %w", svc.Name, err)
+	}
+	if err := pki.WriteContainerCertificate(ctx, s.runtime, containerID, cert, tlsCfg); err != nil {
+		return fmt.Errorf("write standalone service %q certificate: %w", svc.Name, err)
+	}
+	return nil
+}
+
+// serviceCertificateSANs returns the configured SANs plus the names the
+// service is reachable under by default: its container name, its runtime
+// identifier and the loopback address of published ports.
+func serviceCertificateSANs(svc domain.StandaloneService) []string {
+	sans := []string{
+		strings.ToLower(serviceContainerName(svc.Name)),
+		strings.ToLower(serviceRuntimeIdentifier(svc.Name)),
+		"localhost",
+		"127.0.0.1",
+	}
+	return append(sans, svc.TLS.SANs...)
+}
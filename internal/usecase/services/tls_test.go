@@ -0,0 +1,149 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bnema/zerowrap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bnema/gordon/internal/adapters/out/pki"
+	outmocks "github.com/bnema/gordon/internal/boundaries/out/mocks"
+	"github.com/bnema/gordon/internal/domain"
+)
+
+func tlsService() domain.StandaloneService {
+	svc := sampleService()
+	svc.Name = "db"
+	svc.TLS = &domain.StandaloneServiceTLS{SANs: []string{"db.internal"}, UID: 999, GID: 999}
+	return svc
+}
+
+func newTestCA(t *testing.T) *pki.CA {
+	t.Helper()
+	ca, err := pki.NewCA(t.TempDir(), zerowrap.Default())
+	require.NoError(t, err)
+	return ca
+}
+
+func TestService_ReconcileInstallsCertificateBeforeStart(t *testing.T) {
+	rt := outmocks.NewMockContainerRuntime(t)
+	svc := tlsService()
+	var created *domain.ContainerConfig
+	var files []domain.ContainerFile
+	rt.On("ListContainers", mock.Anything, true).Return([]*domain.Container{}, nil).Once()
+	rt.On("InspectImageVolumes", mock.Anything, svc.Image).Return([]string{}, nil).Once()
+	rt.On("CreateContainer", mock.Anything, mock.AnythingOfType("*domain.ContainerConfig")).Run(func(args mock.Arguments) {
+		created = args.Get(1).(*domain.ContainerConfig)
+	}).Return(&domain.Container{ID: "created-1"}, nil).Once()
+	copyCall := rt.On("CopyToContainer", mock.Anything, "created-1", domain.DefaultStandaloneServiceTLSPath, mock.Anything).Run(func(args mock.Arguments) {
+		files = args.Get(3).([]domain.ContainerFile)
+	}).Return(nil).Once()
+	rt.On("StartContainer", mock.Anything, "created-1").Return(nil).Once().NotBefore(copyCall)
+
+	service := NewService(rt)
+	service.SetCertificateAuthority(newTestCA(t))
+	require.NoError(t, service.Reconcile(context.Background(), []domain.StandaloneService{svc}))
+
+	require.NotNil(t, created)
+	assert.Equal(t, map[string]string{domain.DefaultStandaloneServiceTLSPath: "gordon-service-db-run-gordon-tls"}, created.Volumes)
+	assert.Equal(t, "gordon-service-db-run-gordon-tls", created.Labels[domain.LabelServiceManagedVolumes])
+	require.Len(t, files, 3)
+	assert.Equal(t, []string{"ca.crt", "tls.crt", "tls.key"}, []string{files[0].Name, files[1].Name, files[2].Name})
+	assert.Equal(t, int64(0o600), files[2].Mode)
+	assert.Equal(t, 999, files[2].UID)
+
+	block, _ := pem.Decode(files[1].Data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gordon-service-db", "db", "localhost", "db.internal"}, cert.DNSNames)
+	assert.WithinDuration(t, time.Now().Add(domain.DefaultStandaloneServiceTLSTTL), cert.NotAfter, time.Minute)
+}
+
+func TestService_ReconcileTLSRequiresCertificateAuthority(t *testing.T) {
+	rt := outmocks.NewMockContainerRuntime(t)
+	svc := tlsService()
+	rt.On("ListContainers", mock.Anything, true).Return([]*domain.Container{}, nil).Once()
+	rt.On("InspectImageVolumes", mock.Anything, svc.Image).Return([]string{}, nil).Once()
+	rt.On("CreateContainer", mock.Anything, mock.Anything).Return(&domain.Container{ID: "created-1"}, nil).Once()
+
+	err := NewService(rt).Reconcile(context.Background(), []domain.StandaloneService{svc})
+
+	require.ErrorContains(t, err, "requires the internal CA")
+}
+
+func TestService_TLSConfigChangesHash(t *testing.T) {
+	plain, err := serviceConfigHash(sampleService())
+	require.NoError(t, err)
+	withTLS := sampleService()
+	withTLS.TLS = &domain.StandaloneServiceTLS{}
+	hashed, err := serviceConfigHash(withTLS)
+	require.NoError(t, err)
+	withTLS.TLS = &domain.StandaloneServiceTLS{Path: domain.DefaultStandaloneServiceTLSPath}
+	explicit, err := serviceConfigHash(withTLS)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, plain, hashed)
+	assert.Equal(t, hashed, explicit)
+}
+
+func TestService_RenewCertificates(t *testing.T) {
+	ca := newTestCA(t)
+	fresh, err := ca.IssueServiceCertificate("db", nil, domain.DefaultStandaloneServiceTLSTTL)
+	require.NoError(t, err)
+	stale, err := ca.IssueServiceCertificate("db", nil, 24*time.Hour)
+	require.NoError(t, err)
+	foreign, err := newTestCA(t).IssueServiceCertificate("db", nil, domain.DefaultStandaloneServiceTLSTTL)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		current []byte
+		reload  string
+		renewed bool
+	}{
+		{name: "fresh certificate is kept", current: fresh.CertPEM},
+		{name: "stale certificate restarts", current: stale.CertPEM, renewed: true},
+		{name: "stale certificate signals", current: stale.CertPEM, reload: "SIGHUP", renewed: true},
+		{name: "certificate from another CA", current: foreign.CertPEM, renewed: true},
+		{name: "garbage", current: []byte("not a certificate"), renewed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rt := outmocks.NewMockContainerRuntime(t)
+			svc := tlsService()
+			svc.TLS.Reload = tt.reload
+			hash, err := serviceConfigHash(svc)
+			require.NoError(t, err)
+			running := []*domain.Container{managedContainer("db-1", svc.Name, hash, "running")}
+			rt.On("ListContainers", mock.Anything, true).Return(running, nil).Twice()
+			rt.On("CopyFromContainer", mock.Anything, "db-1", "/run/gordon/tls/tls.crt").Return(func(context.Context, string, string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(tt.current)), nil
+			}).Twice()
+			if tt.renewed {
+				// Both the reconcile and the renewal find the certificate
+				// in need of rotation, as the mock always serves tt.current.
+				rt.On("CopyToContainer", mock.Anything, "db-1", "/run/gordon/tls", mock.Anything).Return(nil).Twice()
+				switch tt.reload {
+				case "":
+					rt.On("RestartContainer", mock.Anything, "db-1").Return(nil).Twice()
+				default:
+					rt.On("SignalContainer", mock.Anything, "db-1", tt.reload).Return(nil).Twice()
+				}
+			}
+
+			service := NewService(rt)
+			service.SetCertificateAuthority(ca)
+			require.NoError(t, service.Reconcile(context.Background(), []domain.StandaloneService{svc}))
+			require.NoError(t, service.RenewCertificates(context.Background()))
+		})
+	}
+}
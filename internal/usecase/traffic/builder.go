@@ -25,19 +25,22 @@ type Input struct {
 }
 
 type EntryPointConfig struct {
-	Address                 string                    `mapstructure:"address"`
-	Protocol                domain.EntryPointProtocol `mapstructure:"protocol"`
-	TrustedCIDRs            []string                  `mapstructure:"trusted_cidrs"`
-	RawFallback             string                    `mapstructure:"raw_fallback"`
-	RawFallbackTrustedCIDRs []string                  `mapstructure:"raw_fallback_trusted_cidrs"`
-	AllowPublicRawFallback  bool                      `mapstructure:"allow_public_raw_fallback"`
+	Address                   string                    `mapstructure:"address"`
+	Protocol                  domain.EntryPointProtocol `mapstructure:"protocol"`
+	TrustedCIDRs              []string                  `mapstructure:"trusted_cidrs"`
+	RawFallback               string                    `mapstructure:"raw_fallback"`
+	RawFallbackTrustedCIDRs   []string                  `mapstructure:"raw_fallback_trusted_cidrs"`
+	AllowPublicRawFallback    bool                      `mapstructure:"allow_public_raw_fallback"`
+	ProxyProtocol             bool                      `mapstructure:"proxy_protocol"`
+	ProxyProtocolTrustedCIDRs []string                  `mapstructure:"proxy_protocol_trusted_cidrs"`
 }
 
 // Config holds traffic router and option configuration.
 type Config struct {
-	TCP TCPConfig `mapstructure:"tcp"`
-	UDP UDPConfig `mapstructure:"udp"`
-	TLS TLSConfig `mapstructure:"tls"`
+	TCP   TCPConfig    `mapstructure:"tcp"`
+	UDP   UDPConfig    `mapstructure:"udp"`
+	TLS   TLSConfig    `mapstructure:"tls"`
+	Pools []PoolConfig `mapstructure:"pools"`
 }
 
 type TCPConfig struct {
@@ -60,11 +63,43 @@ type TLSConfig struct {
 }
 
 type RouterConfig struct {
-	Name       string `mapstructure:"name"`
-	EntryPoint string `mapstructure:"entrypoint"`
-	Host       string `mapstructure:"host"`
-	SNI        string `mapstructure:"sni"`
-	Service    string `mapstructure:"service"`
+	Name          string       `mapstructure:"name"`
+	EntryPoint    string       `mapstructure:"entrypoint"`
+	Host          string       `mapstructure:"host"`
+	SNI           string       `mapstructure:"sni"`
+	SNIRegex      string       `mapstructure:"sni_regex"`
+	Service       string       `mapstructure:"service"`
+	ProxyProtocol int          `mapstructure:"proxy_protocol"`
+	Limits        LimitsConfig `mapstructure:"limits"`
+}
+
+// LimitsConfig caps connections, connection rate and bandwidth on one L4 router.
+type LimitsConfig struct {
+	MaxConnections          int   `mapstructure:"max_connections"`
+	MaxConnectionsPerIP     int   `mapstructure:"max_connections_per_ip"`
+	ConnectionRate          int   `mapstructure:"connection_rate"`
+	ConnectionBurst         int   `mapstructure:"connection_burst"`
+	BandwidthBytesPerSecond int64 `mapstructure:"bandwidth_bytes_per_second"`
+}
+
+// PoolConfig groups network service and standalone service ports behind one
+// load-balanced L4 service referenced as pool:<name>.
+type PoolConfig struct {
+	Name          string            `mapstructure:"name"`
+	Strategy      string            `mapstructure:"strategy"`
+	Members       []string          `mapstructure:"members"`
+	MaxFailures   int               `mapstructure:"max_failures"`
+	EjectDuration string            `mapstructure:"eject_duration"`
+	HealthCheck   HealthCheckConfig `mapstructure:"health_check"`
+}
+
+// HealthCheckConfig configures active TCP connect checks for a pool.
+type HealthCheckConfig struct {
+	Interval           string `mapstructure:"interval"`
+	Timeout            string `mapstructure:"timeout"`
+	Port               int    `mapstructure:"port"`
+	HealthyThreshold   int    `mapstructure:"healthy_threshold"`
+	UnhealthyThreshold int    `mapstructure:"unhealthy_threshold"`
 }
 
 type NetworkServiceConfig struct {
@@ -94,6 +129,9 @@ func Build(input Input) (domain.TrafficGraph, error) {
 	if err := validateStandaloneServices(input.Services); err != nil {
 		return domain.TrafficGraph{}, fmt.Errorf("validate standalone services: %w", err)
 	}
+	if err := validatePools(input.Traffic.Pools); err != nil {
+		return domain.TrafficGraph{}, fmt.Errorf("validate traffic pools: %w", err)
+	}
 	graph.Options = options
 	graph.EntryPoints = b.buildEntryPoints()
 
@@ -128,13 +166,15 @@ func (b *builder) buildEntryPoints() []domain.EntryPoint {
 	for _, name := range sortedKeys(b.input.EntryPoints) {
 		cfg := b.input.EntryPoints[name]
 		entries = append(entries, domain.EntryPoint{
-			Name:                    name,
-			Address:                 cfg.Address,
-			Protocol:                cfg.Protocol,
-			TrustedCIDRs:            append([]string(nil), cfg.TrustedCIDRs...),
-			RawFallback:             cfg.RawFallback,
-			RawFallbackTrustedCIDRs: append([]string(nil), cfg.RawFallbackTrustedCIDRs...),
-			AllowPublicRawFallback:  cfg.AllowPublicRawFallback,
+			Name:                      name,
+			Address:                   cfg.Address,
+			Protocol:                  cfg.Protocol,
+			TrustedCIDRs:              append([]string(nil), cfg.TrustedCIDRs...),
+			RawFallback:               cfg.RawFallback,
+			RawFallbackTrustedCIDRs:   append([]string(nil), cfg.RawFallbackTrustedCIDRs...),
+			AllowPublicRawFallback:    cfg.AllowPublicRawFallback,
+			ProxyProtocol:             cfg.ProxyProtocol,
+			ProxyProtocolTrustedCIDRs: append([]string(nil), cfg.ProxyProtocolTrustedCIDRs...),
 		})
 	}
 	return entries
@@ -229,11 +269,21 @@ func (b *builder) addL4Routers(graph *domain.TrafficGraph, routers []RouterConfi
 			return fmt.Errorf("router %q: %w", cfg.Name, err)
 		}
 		b.addService(service)
-		graph.Routers = append(graph.Routers, domain.TrafficRouter{Name: cfg.Name, EntryPoint: cfg.EntryPoint, Protocol: protocol, Rule: domain.TrafficRule{Host: cfg.Host, SNI: cfg.SNI}, Service: cfg.Service})
+		graph.Routers = append(graph.Routers, domain.TrafficRouter{Name: cfg.Name, EntryPoint: cfg.EntryPoint, Protocol: protocol, Rule: domain.TrafficRule{Host: cfg.Host, SNI: cfg.SNI, SNIRegex: cfg.SNIRegex}, Service: cfg.Service, ProxyProtocol: cfg.ProxyProtocol, Limits: cfg.Limits.toDomain()})
 	}
 	return nil
 }
 
+func (c LimitsConfig) toDomain() domain.TrafficLimits {
+	return domain.TrafficLimits{
+		MaxConnections:          c.MaxConnections,
+		MaxConnectionsPerIP:     c.MaxConnectionsPerIP,
+		ConnectionRate:          c.ConnectionRate,
+		ConnectionBurst:         c.ConnectionBurst,
+		BandwidthBytesPerSecond: c.BandwidthBytesPerSecond,
+	}
+}
+
 func (b *builder) resolveService(router RouterConfig, protocol domain.NetworkProtocol) (domain.TrafficService, error) {
 	ref, err := domain.ParseTrafficServiceRef(router.Service)
 	if err != nil {
@@ -244,11 +294,88 @@ func (b *builder) resolveService(router RouterConfig, protocol domain.NetworkPro
 		return b.resolveNetworkService(router.Service, ref, protocol)
 	case domain.TrafficServiceRefService:
 		return b.resolveStandaloneService(router, ref, protocol)
+	case domain.TrafficServiceRefPool:
+		return b.resolvePool(router, ref, protocol)
 	case domain.TrafficServiceRefStatic:
 		return domain.TrafficService{}, fmt.Errorf("static service ref %q is unsupported", router.Service)
 	default:
-		return domain.TrafficService{}, fmt.Errorf("service ref %q must be network_service:<name>:<port-name> or service:<name>:<port-name>", router.Service)
+		return domain.TrafficService{}, fmt.Errorf("service ref %q must be network_service:<name>:<port-name>, service:<name>:<port-name> or pool:<name>", router.Service)
+	}
+}
+
+func (b *builder) resolvePool(router RouterConfig, ref domain.TrafficServiceRef, protocol domain.NetworkProtocol) (domain.TrafficService, error) {
+	pool, ok := b.pool(ref.Name)
+	if !ok {
+		return domain.TrafficService{}, fmt.Errorf("unknown pool %q", ref.Name)
+	}
+	if len(pool.Members) == 0 {
+		return domain.TrafficService{}, fmt.Errorf("pool %q has no members", pool.Name)
+	}
+	lb, err := buildLoadBalancer(pool)
+	if err != nil {
+		return domain.TrafficService{}, err
 	}
+	service := domain.TrafficService{Name: router.Service, LoadBalancer: lb}
+	for _, member := range pool.Members {
+		memberRef, err := domain.ParseTrafficServiceRef(member)
+		if err != nil {
+			return domain.TrafficService{}, fmt.Errorf("pool %q member: %w", pool.Name, err)
+		}
+		var resolved domain.TrafficService
+		switch memberRef.Kind {
+		case domain.TrafficServiceRefNetworkService:
+			resolved, err = b.resolveNetworkService(member, memberRef, protocol)
+		case domain.TrafficServiceRefService:
+			resolved, err = b.resolveStandaloneService(RouterConfig{Name: router.Name, EntryPoint: router.EntryPoint, Service: member}, memberRef, protocol)
+		default:
+			return domain.TrafficService{}, fmt.Errorf("pool %q member %q must be network_service:<name>:<port-name> or service:<name>:<port-name>", pool.Name, member)
+		}
+		if err != nil {
+			return domain.TrafficService{}, fmt.Errorf("pool %q member %q: %w", pool.Name, member, err)
+		}
+		service.Backends = append(service.Backends, resolved.Backends...)
+	}
+	return service, nil
+}
+
+func buildLoadBalancer(pool PoolConfig) (domain.TrafficLoadBalancer, error) {
+	field := "traffic.pools." + pool.Name
+	lb := domain.TrafficLoadBalancer{Strategy: domain.LoadBalancerStrategy(pool.Strategy), MaxFailures: pool.MaxFailures}
+	if lb.MaxFailures == 0 {
+		lb.MaxFailures = 3
+	}
+	eject, err := parsePositiveDurationDefault(pool.EjectDuration, 30*time.Second, field+".eject_duration")
+	if err != nil {
+		return domain.TrafficLoadBalancer{}, err
+	}
+	lb.EjectDuration = eject
+	check := pool.HealthCheck
+	if strings.TrimSpace(check.Interval) == "" {
+		return lb, nil
+	}
+	interval, err := parsePositiveDurationDefault(check.Interval, 0, field+".health_check.interval")
+	if err != nil {
+		return domain.TrafficLoadBalancer{}, err
+	}
+	timeout, err := parsePositiveDurationDefault(check.Timeout, 2*time.Second, field+".health_check.timeout")
+	if err != nil {
+		return domain.TrafficLoadBalancer{}, err
+	}
+	lb.HealthCheck = domain.TrafficHealthCheck{
+		Interval:           interval,
+		Timeout:            min(timeout, interval),
+		Port:               check.Port,
+		HealthyThreshold:   positiveOrDefault(check.HealthyThreshold, 2),
+		UnhealthyThreshold: positiveOrDefault(check.UnhealthyThreshold, 3),
+	}
+	return lb, nil
+}
+
+func positiveOrDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
 }
 
 func (b *builder) resolveNetworkService(refValue string, ref domain.TrafficServiceRef, protocol domain.NetworkProtocol) (domain.TrafficService, error) {
@@ -310,6 +437,20 @@ func validateStandaloneServices(services []domain.StandaloneService) error {
 	return nil
 }
 
+func validatePools(pools []PoolConfig) error {
+	seen := map[string]struct{}{}
+	for _, pool := range pools {
+		if pool.Name == "" || strings.Contains(pool.Name, ":") {
+			return fmt.Errorf("invalid pool name %q", pool.Name)
+		}
+		if _, exists := seen[pool.Name]; exists {
+			return fmt.Errorf("duplicate pool %q", pool.Name)
+		}
+		seen[pool.Name] = struct{}{}
+	}
+	return nil
+}
+
 func validateNetworkServices(services []NetworkServiceConfig) error {
 	seen := map[string]struct{}{}
 	for _, service := range services {
@@ -365,6 +506,15 @@ func (b *builder) networkService(name string) (NetworkServiceConfig, bool) {
 	return NetworkServiceConfig{}, false
 }
 
+func (b *builder) pool(name string) (PoolConfig, bool) {
+	for _, pool := range b.input.Traffic.Pools {
+		if pool.Name == name {
+			return pool, true
+		}
+	}
+	return PoolConfig{}, false
+}
+
 func (b *builder) standaloneService(name string) (domain.StandaloneService, bool) {
 	for _, service := range b.input.Services {
 		if service.Name == name {
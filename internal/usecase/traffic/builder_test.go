@@ -82,6 +82,52 @@ func TestBuildRawFallbackConfigMapsToEntryPoint(t *testing.T) {
 	}}, graph.EntryPoints)
 }
 
+func TestBuildProxyProtocolConfigMapsToGraph(t *testing.T) {
+	graph, err := Build(Input{
+		EntryPoints: map[string]EntryPointConfig{"edge": {
+			Address:                   ":443",
+			Protocol:                  domain.EntryPointProtocolSmartTCP,
+			ProxyProtocol:             true,
+			ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"},
+		}},
+		Traffic:         Config{TLS: TLSConfig{Routers: []RouterConfig{{Name: "raw-tls", EntryPoint: "edge", SNI: "raw.example.com", Service: "network_service:raw:tls", ProxyProtocol: domain.ProxyProtocolV2}}}},
+		NetworkServices: []NetworkServiceConfig{{Name: "raw", Ports: []PortConfig{{Name: "tls", Container: 8443, Protocol: domain.NetworkProtocolTCP}}}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []domain.EntryPoint{{
+		Name:                      "edge",
+		Address:                   ":443",
+		Protocol:                  domain.EntryPointProtocolSmartTCP,
+		ProxyProtocol:             true,
+		ProxyProtocolTrustedCIDRs: []string{"10.0.0.0/8"},
+	}}, graph.EntryPoints)
+	require.Contains(t, graph.Routers, domain.TrafficRouter{Name: "raw-tls", EntryPoint: "edge", Protocol: domain.RouterProtocolTLSPassthrough, Rule: domain.TrafficRule{SNI: "raw.example.com"}, Service: "network_service:raw:tls", ProxyProtocol: domain.ProxyProtocolV2})
+}
+
+func TestBuildSNIRegexRouterMapsToGraph(t *testing.T) {
+	graph, err := Build(Input{
+		EntryPoints:     map[string]EntryPointConfig{"edge": {Address: ":443", Protocol: domain.EntryPointProtocolSmartTCP}},
+		Traffic:         Config{TLS: TLSConfig{Routers: []RouterConfig{{Name: "tenants", EntryPoint: "edge", SNIRegex: `tenant-[0-9]+\.example\.com`, Service: "network_service:raw:tls"}}}},
+		NetworkServices: []NetworkServiceConfig{{Name: "raw", Ports: []PortConfig{{Name: "tls", Container: 8443, Protocol: domain.NetworkProtocolTCP}}}},
+	})
+	require.NoError(t, err)
+	require.Contains(t, graph.Routers, domain.TrafficRouter{Name: "tenants", EntryPoint: "edge", Protocol: domain.RouterProtocolTLSPassthrough, Rule: domain.TrafficRule{SNIRegex: `tenant-[0-9]+\.example\.com`}, Service: "network_service:raw:tls"})
+}
+
+func TestBuildRouterLimitsMapToGraph(t *testing.T) {
+	graph, err := Build(Input{
+		EntryPoints: map[string]EntryPointConfig{"game": {Address: ":28015", Protocol: domain.EntryPointProtocolUDP}},
+		Traffic: Config{UDP: UDPConfig{Routers: []RouterConfig{{
+			Name: "rust", EntryPoint: "game", Service: "network_service:rust:game",
+			Limits: LimitsConfig{MaxConnections: 200, MaxConnectionsPerIP: 4, ConnectionRate: 2, ConnectionBurst: 8, BandwidthBytesPerSecond: 65536},
+		}}}},
+		NetworkServices: []NetworkServiceConfig{{Name: "rust", Ports: []PortConfig{{Name: "game", Container: 28015, Protocol: domain.NetworkProtocolUDP}}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, graph.Routers, 1)
+	require.Equal(t, domain.TrafficLimits{MaxConnections: 200, MaxConnectionsPerIP: 4, ConnectionRate: 2, ConnectionBurst: 8, BandwidthBytesPerSecond: 65536}, graph.Routers[0].Limits)
+}
+
 func TestBuildSmartTCPRoutesRequireCompatibleEdge(t *testing.T) {
 	_, err := Build(Input{Routes: []domain.Route{{Domain: "app.example.com"}}})
 	require.ErrorContains(t, err, "entrypoint")
@@ -415,3 +461,67 @@ func TestBuildRejectsInvalidServiceRefs(t *testing.T) {
 		require.ErrorContains(t, err, "does not match")
 	})
 }
+
+func TestBuildResolvesPoolMembers(t *testing.T) {
+	graph, err := Build(Input{
+		EntryPoints: map[string]EntryPointConfig{"udp": {Address: ":28015", Protocol: domain.EntryPointProtocolUDP}},
+		Traffic: Config{
+			UDP: UDPConfig{Routers: []RouterConfig{{Name: "rust-game", EntryPoint: "udp", Service: "pool:rust"}}},
+			Pools: []PoolConfig{{
+				Name:        "rust",
+				Strategy:    "source_ip_hash",
+				Members:     []string{"service:rust:game", "network_service:rust-b:game"},
+				HealthCheck: HealthCheckConfig{Interval: "5s", Port: 28016},
+			}},
+		},
+		NetworkServices: []NetworkServiceConfig{{Name: "rust-b", Ports: []PortConfig{{Name: "game", Container: 28015, Protocol: domain.NetworkProtocolUDP}}}},
+		Services: []domain.StandaloneService{{
+			Name:    "rust",
+			Image:   "localhost/rust:latest",
+			Enabled: true,
+			Ports:   []domain.StandaloneServicePort{{Name: "game", Container: 28015, Protocol: domain.NetworkProtocolUDP, Publish: "127.0.0.1:38015"}},
+		}},
+	})
+	require.NoError(t, err)
+	require.Contains(t, graph.Services, domain.TrafficService{
+		Name: "pool:rust",
+		Backends: []domain.TrafficBackend{
+			{Name: "rust:game", Host: "127.0.0.1", Port: 38015, Protocol: domain.NetworkProtocolUDP},
+			{Name: "rust-b:game", Host: "rust-b", Port: 28015, Protocol: domain.NetworkProtocolUDP},
+		},
+		LoadBalancer: domain.TrafficLoadBalancer{
+			Strategy:      domain.LoadBalancerSourceIPHash,
+			MaxFailures:   3,
+			EjectDuration: 30 * time.Second,
+			HealthCheck:   domain.TrafficHealthCheck{Interval: 5 * time.Second, Timeout: 2 * time.Second, Port: 28016, HealthyThreshold: 2, UnhealthyThreshold: 3},
+		},
+	})
+}
+
+func TestBuildRejectsInvalidPools(t *testing.T) {
+	base := Input{
+		EntryPoints:     map[string]EntryPointConfig{"tcp": {Address: ":1234", Protocol: domain.EntryPointProtocolTCP}},
+		NetworkServices: []NetworkServiceConfig{{Name: "svc", Ports: []PortConfig{{Name: "db", Container: 5432, Protocol: domain.NetworkProtocolTCP}, {Name: "udp", Container: 53, Protocol: domain.NetworkProtocolUDP}}}},
+	}
+	tests := []struct {
+		name    string
+		pools   []PoolConfig
+		wantErr string
+	}{
+		{name: "unknown pool", wantErr: "unknown pool \"db\""},
+		{name: "empty pool", pools: []PoolConfig{{Name: "db"}}, wantErr: "pool \"db\" has no members"},
+		{name: "nested pool", pools: []PoolConfig{{Name: "db", Members: []string{"pool:other"}}}, wantErr: "member \"pool:other\" must be"},
+		{name: "member protocol mismatch", pools: []PoolConfig{{Name: "db", Members: []string{"network_service:svc:udp"}}}, wantErr: "protocol udp does not match router protocol tcp"},
+		{name: "duplicate pool", pools: []PoolConfig{{Name: "db", Members: []string{"network_service:svc:db"}}, {Name: "db"}}, wantErr: "duplicate pool \"db\""},
+		{name: "invalid strategy", pools: []PoolConfig{{Name: "db", Strategy: "random", Members: []string{"network_service:svc:db"}}}, wantErr: "invalid load balancer strategy"},
+		{name: "invalid interval", pools: []PoolConfig{{Name: "db", Members: []string{"network_service:svc:db"}, HealthCheck: HealthCheckConfig{Interval: "soon"}}}, wantErr: "traffic.pools.db.health_check.interval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := base
+			input.Traffic = Config{TCP: TCPConfig{Routers: []RouterConfig{{Name: "db", EntryPoint: "tcp", Service: "pool:db"}}}, Pools: tt.pools}
+			_, err := Build(input)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/bnema/gordon/internal/adapters/in/cli"
@@ -20,6 +21,10 @@ func main() {
 	cli.SetVersionInfo(version, commit, date)
 
 	if err := cli.NewRootCmd().Execute(); err != nil {
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }